@@ -0,0 +1,1222 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: api/feed/admin/v1/admin.proto
+
+package adminv1
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ListRecommendationLogsRequest 描述推荐日志查询条件，所有过滤条件为 AND 关系。
+type ListRecommendationLogsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 每页条数，默认 50，最大 500。
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// 上一页返回的 next_page_token，空值表示第一页；翻页时其余过滤条件需保持不变。
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// 按明文用户 ID 过滤，服务端对所有密钥版本计算哈希后匹配。
+	UserId string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// 按 user_id_hash 精确过滤，与 user_id 互斥。
+	UserIdHash string `protobuf:"bytes,4,opt,name=user_id_hash,json=userIdHash,proto3" json:"user_id_hash,omitempty"`
+	// 按推荐来源过滤，例如 mock、guest。
+	Source string `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+	// 按错误类别过滤，例如 recommendation_unavailable。
+	ErrorKind string `protobuf:"bytes,6,opt,name=error_kind,json=errorKind,proto3" json:"error_kind,omitempty"`
+	// 仅返回存在补水缺失的日志。
+	MissingOnly bool `protobuf:"varint,7,opt,name=missing_only,json=missingOnly,proto3" json:"missing_only,omitempty"`
+	// 时间窗口 [since, until)。
+	Since         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=since,proto3" json:"since,omitempty"`
+	Until         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=until,proto3" json:"until,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecommendationLogsRequest) Reset() {
+	*x = ListRecommendationLogsRequest{}
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecommendationLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecommendationLogsRequest) ProtoMessage() {}
+
+func (x *ListRecommendationLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecommendationLogsRequest.ProtoReflect.Descriptor instead.
+func (*ListRecommendationLogsRequest) Descriptor() ([]byte, []int) {
+	return file_api_feed_admin_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListRecommendationLogsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRecommendationLogsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListRecommendationLogsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListRecommendationLogsRequest) GetUserIdHash() string {
+	if x != nil {
+		return x.UserIdHash
+	}
+	return ""
+}
+
+func (x *ListRecommendationLogsRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ListRecommendationLogsRequest) GetErrorKind() string {
+	if x != nil {
+		return x.ErrorKind
+	}
+	return ""
+}
+
+func (x *ListRecommendationLogsRequest) GetMissingOnly() bool {
+	if x != nil {
+		return x.MissingOnly
+	}
+	return false
+}
+
+func (x *ListRecommendationLogsRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *ListRecommendationLogsRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+// ListRecommendationLogsResponse 返回一页推荐日志。
+type ListRecommendationLogsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Logs  []*RecommendationLog   `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	// 下一页游标，空值表示没有更多数据。
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecommendationLogsResponse) Reset() {
+	*x = ListRecommendationLogsResponse{}
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecommendationLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecommendationLogsResponse) ProtoMessage() {}
+
+func (x *ListRecommendationLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecommendationLogsResponse.ProtoReflect.Descriptor instead.
+func (*ListRecommendationLogsResponse) Descriptor() ([]byte, []int) {
+	return file_api_feed_admin_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListRecommendationLogsResponse) GetLogs() []*RecommendationLog {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+func (x *ListRecommendationLogsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// GetRecommendationLogRequest 按 log_id 查询单条日志。
+type GetRecommendationLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LogId         string                 `protobuf:"bytes,1,opt,name=log_id,json=logId,proto3" json:"log_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecommendationLogRequest) Reset() {
+	*x = GetRecommendationLogRequest{}
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecommendationLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecommendationLogRequest) ProtoMessage() {}
+
+func (x *GetRecommendationLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecommendationLogRequest.ProtoReflect.Descriptor instead.
+func (*GetRecommendationLogRequest) Descriptor() ([]byte, []int) {
+	return file_api_feed_admin_v1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetRecommendationLogRequest) GetLogId() string {
+	if x != nil {
+		return x.LogId
+	}
+	return ""
+}
+
+// RecommendationLog 对应 feed.recommendation_logs 的一行，不包含明文用户标识。
+type RecommendationLog struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	LogId                   string                 `protobuf:"bytes,1,opt,name=log_id,json=logId,proto3" json:"log_id,omitempty"`
+	UserIdHash              string                 `protobuf:"bytes,2,opt,name=user_id_hash,json=userIdHash,proto3" json:"user_id_hash,omitempty"`
+	UserIdKeyVersion        string                 `protobuf:"bytes,3,opt,name=user_id_key_version,json=userIdKeyVersion,proto3" json:"user_id_key_version,omitempty"`
+	RequestLimit            int32                  `protobuf:"varint,4,opt,name=request_limit,json=requestLimit,proto3" json:"request_limit,omitempty"`
+	RecommendationSource    string                 `protobuf:"bytes,5,opt,name=recommendation_source,json=recommendationSource,proto3" json:"recommendation_source,omitempty"`
+	RecommendationLatencyMs int32                  `protobuf:"varint,6,opt,name=recommendation_latency_ms,json=recommendationLatencyMs,proto3" json:"recommendation_latency_ms,omitempty"`
+	RecommendedItems        []*RecommendedItem     `protobuf:"bytes,7,rep,name=recommended_items,json=recommendedItems,proto3" json:"recommended_items,omitempty"`
+	MissingVideoIds         []string               `protobuf:"bytes,8,rep,name=missing_video_ids,json=missingVideoIds,proto3" json:"missing_video_ids,omitempty"`
+	ErrorKind               string                 `protobuf:"bytes,9,opt,name=error_kind,json=errorKind,proto3" json:"error_kind,omitempty"`
+	GeneratedAt             *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	IdempotencyKey          string                 `protobuf:"bytes,11,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	Replayed                bool                   `protobuf:"varint,12,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	Guest                   bool                   `protobuf:"varint,13,opt,name=guest,proto3" json:"guest,omitempty"`
+	// 补水、过滤、重排后实际返回给用户的条目。
+	ServedItems []*ServedItem `protobuf:"bytes,14,rep,name=served_items,json=servedItems,proto3" json:"served_items,omitempty"`
+	RequestId   string        `protobuf:"bytes,15,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	TraceId     string        `protobuf:"bytes,16,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	Scene       string        `protobuf:"bytes,17,opt,name=scene,proto3" json:"scene,omitempty"`
+	// 游标分页页码，从 1 开始；0 表示历史数据未记录。
+	Page int32 `protobuf:"varint,18,opt,name=page,proto3" json:"page,omitempty"`
+	// 命中的 A/B 实验分组。
+	Experiments   []*ExperimentAssignment `protobuf:"bytes,19,rep,name=experiments,proto3" json:"experiments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecommendationLog) Reset() {
+	*x = RecommendationLog{}
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendationLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendationLog) ProtoMessage() {}
+
+func (x *RecommendationLog) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendationLog.ProtoReflect.Descriptor instead.
+func (*RecommendationLog) Descriptor() ([]byte, []int) {
+	return file_api_feed_admin_v1_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RecommendationLog) GetLogId() string {
+	if x != nil {
+		return x.LogId
+	}
+	return ""
+}
+
+func (x *RecommendationLog) GetUserIdHash() string {
+	if x != nil {
+		return x.UserIdHash
+	}
+	return ""
+}
+
+func (x *RecommendationLog) GetUserIdKeyVersion() string {
+	if x != nil {
+		return x.UserIdKeyVersion
+	}
+	return ""
+}
+
+func (x *RecommendationLog) GetRequestLimit() int32 {
+	if x != nil {
+		return x.RequestLimit
+	}
+	return 0
+}
+
+func (x *RecommendationLog) GetRecommendationSource() string {
+	if x != nil {
+		return x.RecommendationSource
+	}
+	return ""
+}
+
+func (x *RecommendationLog) GetRecommendationLatencyMs() int32 {
+	if x != nil {
+		return x.RecommendationLatencyMs
+	}
+	return 0
+}
+
+func (x *RecommendationLog) GetRecommendedItems() []*RecommendedItem {
+	if x != nil {
+		return x.RecommendedItems
+	}
+	return nil
+}
+
+func (x *RecommendationLog) GetMissingVideoIds() []string {
+	if x != nil {
+		return x.MissingVideoIds
+	}
+	return nil
+}
+
+func (x *RecommendationLog) GetErrorKind() string {
+	if x != nil {
+		return x.ErrorKind
+	}
+	return ""
+}
+
+func (x *RecommendationLog) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+func (x *RecommendationLog) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *RecommendationLog) GetReplayed() bool {
+	if x != nil {
+		return x.Replayed
+	}
+	return false
+}
+
+func (x *RecommendationLog) GetGuest() bool {
+	if x != nil {
+		return x.Guest
+	}
+	return false
+}
+
+func (x *RecommendationLog) GetServedItems() []*ServedItem {
+	if x != nil {
+		return x.ServedItems
+	}
+	return nil
+}
+
+func (x *RecommendationLog) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *RecommendationLog) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *RecommendationLog) GetScene() string {
+	if x != nil {
+		return x.Scene
+	}
+	return ""
+}
+
+func (x *RecommendationLog) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *RecommendationLog) GetExperiments() []*ExperimentAssignment {
+	if x != nil {
+		return x.Experiments
+	}
+	return nil
+}
+
+// ExperimentAssignment 为请求在单个实验中命中的分组。
+type ExperimentAssignment struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Experiment string                 `protobuf:"bytes,1,opt,name=experiment,proto3" json:"experiment,omitempty"`
+	Variant    string                 `protobuf:"bytes,2,opt,name=variant,proto3" json:"variant,omitempty"`
+	// 经 x-md-experiment-variants 强制命中，分析时应剔除。
+	Forced        bool `protobuf:"varint,3,opt,name=forced,proto3" json:"forced,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExperimentAssignment) Reset() {
+	*x = ExperimentAssignment{}
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExperimentAssignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExperimentAssignment) ProtoMessage() {}
+
+func (x *ExperimentAssignment) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExperimentAssignment.ProtoReflect.Descriptor instead.
+func (*ExperimentAssignment) Descriptor() ([]byte, []int) {
+	return file_api_feed_admin_v1_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExperimentAssignment) GetExperiment() string {
+	if x != nil {
+		return x.Experiment
+	}
+	return ""
+}
+
+func (x *ExperimentAssignment) GetVariant() string {
+	if x != nil {
+		return x.Variant
+	}
+	return ""
+}
+
+func (x *ExperimentAssignment) GetForced() bool {
+	if x != nil {
+		return x.Forced
+	}
+	return false
+}
+
+// RecommendedItem 为推荐系统返回的原始条目。
+type RecommendedItem struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	VideoId string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Reason  string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Score   float64                `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	Meta    map[string]string      `protobuf:"bytes,4,rep,name=meta,proto3" json:"meta,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 未下发原因：projection_missing / invalid_video_id / watched，已下发时为空。
+	MissingReason string `protobuf:"bytes,5,opt,name=missing_reason,json=missingReason,proto3" json:"missing_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecommendedItem) Reset() {
+	*x = RecommendedItem{}
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecommendedItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecommendedItem) ProtoMessage() {}
+
+func (x *RecommendedItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecommendedItem.ProtoReflect.Descriptor instead.
+func (*RecommendedItem) Descriptor() ([]byte, []int) {
+	return file_api_feed_admin_v1_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RecommendedItem) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *RecommendedItem) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RecommendedItem) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *RecommendedItem) GetMeta() map[string]string {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *RecommendedItem) GetMissingReason() string {
+	if x != nil {
+		return x.MissingReason
+	}
+	return ""
+}
+
+// ServedItem 为实际下发的条目。
+type ServedItem struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	VideoId string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	// 响应中的位次，从 1 开始。
+	Position int32 `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"`
+	// 补水所用投影的版本号。
+	ProjectionVersion int64  `protobuf:"varint,3,opt,name=projection_version,json=projectionVersion,proto3" json:"projection_version,omitempty"`
+	VisibilityStatus  string `protobuf:"bytes,4,opt,name=visibility_status,json=visibilityStatus,proto3" json:"visibility_status,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ServedItem) Reset() {
+	*x = ServedItem{}
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServedItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServedItem) ProtoMessage() {}
+
+func (x *ServedItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServedItem.ProtoReflect.Descriptor instead.
+func (*ServedItem) Descriptor() ([]byte, []int) {
+	return file_api_feed_admin_v1_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ServedItem) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *ServedItem) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *ServedItem) GetProjectionVersion() int64 {
+	if x != nil {
+		return x.ProjectionVersion
+	}
+	return 0
+}
+
+func (x *ServedItem) GetVisibilityStatus() string {
+	if x != nil {
+		return x.VisibilityStatus
+	}
+	return ""
+}
+
+// CurationRule 对应 feed.curation_rules 的一行；scene / locale / user_segment 为空表示不限。
+type CurationRule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 服务端生成，创建时忽略。
+	RuleId string `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	// pin：置顶到 position；boost：已被推荐时最多提到 position；block：从结果中剔除。
+	Action  string `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	VideoId string `protobuf:"bytes,3,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	// 适用场景，home 与空场景等价。
+	Scene string `protobuf:"bytes,4,opt,name=scene,proto3" json:"scene,omitempty"`
+	// 适用语言区域，按 BCP 47 前缀匹配 x-md-locale，例如 ja 匹配 ja-JP。
+	Locale string `protobuf:"bytes,5,opt,name=locale,proto3" json:"locale,omitempty"`
+	// 适用用户分群：guest、user 或网关透传的 x-md-user-segment。
+	UserSegment string `protobuf:"bytes,6,opt,name=user_segment,json=userSegment,proto3" json:"user_segment,omitempty"`
+	// 目标位次，从 1 开始，0 表示第 1 位；block 规则不设置。
+	Position int32 `protobuf:"varint,7,opt,name=position,proto3" json:"position,omitempty"`
+	// 冲突时优先级高的规则先执行。
+	Priority int32 `protobuf:"varint,8,opt,name=priority,proto3" json:"priority,omitempty"`
+	// 生效时间窗口 [starts_at, ends_at)，缺省表示不限。
+	StartsAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt        *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	Note          string                 `protobuf:"bytes,11,opt,name=note,proto3" json:"note,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CurationRule) Reset() {
+	*x = CurationRule{}
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CurationRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurationRule) ProtoMessage() {}
+
+func (x *CurationRule) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurationRule.ProtoReflect.Descriptor instead.
+func (*CurationRule) Descriptor() ([]byte, []int) {
+	return file_api_feed_admin_v1_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CurationRule) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *CurationRule) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *CurationRule) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *CurationRule) GetScene() string {
+	if x != nil {
+		return x.Scene
+	}
+	return ""
+}
+
+func (x *CurationRule) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *CurationRule) GetUserSegment() string {
+	if x != nil {
+		return x.UserSegment
+	}
+	return ""
+}
+
+func (x *CurationRule) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *CurationRule) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *CurationRule) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *CurationRule) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *CurationRule) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *CurationRule) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CurationRule) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// ListCurationRulesRequest 描述规则列表查询条件。
+type ListCurationRulesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 是否包含已过期的规则。
+	IncludeExpired bool `protobuf:"varint,1,opt,name=include_expired,json=includeExpired,proto3" json:"include_expired,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListCurationRulesRequest) Reset() {
+	*x = ListCurationRulesRequest{}
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCurationRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCurationRulesRequest) ProtoMessage() {}
+
+func (x *ListCurationRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCurationRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListCurationRulesRequest) Descriptor() ([]byte, []int) {
+	return file_api_feed_admin_v1_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListCurationRulesRequest) GetIncludeExpired() bool {
+	if x != nil {
+		return x.IncludeExpired
+	}
+	return false
+}
+
+// ListCurationRulesResponse 返回全部匹配的规则，规则数量有限，不分页。
+type ListCurationRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*CurationRule        `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCurationRulesResponse) Reset() {
+	*x = ListCurationRulesResponse{}
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCurationRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCurationRulesResponse) ProtoMessage() {}
+
+func (x *ListCurationRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCurationRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListCurationRulesResponse) Descriptor() ([]byte, []int) {
+	return file_api_feed_admin_v1_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListCurationRulesResponse) GetRules() []*CurationRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+// CreateCurationRuleRequest 新增规则。
+type CreateCurationRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *CurationRule          `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCurationRuleRequest) Reset() {
+	*x = CreateCurationRuleRequest{}
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCurationRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCurationRuleRequest) ProtoMessage() {}
+
+func (x *CreateCurationRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCurationRuleRequest.ProtoReflect.Descriptor instead.
+func (*CreateCurationRuleRequest) Descriptor() ([]byte, []int) {
+	return file_api_feed_admin_v1_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateCurationRuleRequest) GetRule() *CurationRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+// UpdateCurationRuleRequest 整体覆盖规则。
+type UpdateCurationRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleId        string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	Rule          *CurationRule          `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCurationRuleRequest) Reset() {
+	*x = UpdateCurationRuleRequest{}
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCurationRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCurationRuleRequest) ProtoMessage() {}
+
+func (x *UpdateCurationRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCurationRuleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCurationRuleRequest) Descriptor() ([]byte, []int) {
+	return file_api_feed_admin_v1_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateCurationRuleRequest) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *UpdateCurationRuleRequest) GetRule() *CurationRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+// DeleteCurationRuleRequest 删除规则。
+type DeleteCurationRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleId        string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCurationRuleRequest) Reset() {
+	*x = DeleteCurationRuleRequest{}
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCurationRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCurationRuleRequest) ProtoMessage() {}
+
+func (x *DeleteCurationRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_admin_v1_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCurationRuleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCurationRuleRequest) Descriptor() ([]byte, []int) {
+	return file_api_feed_admin_v1_admin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteCurationRuleRequest) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+var File_api_feed_admin_v1_admin_proto protoreflect.FileDescriptor
+
+const file_api_feed_admin_v1_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x1dapi/feed/admin/v1/admin.proto\x12\rfeed.admin.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bbuf/validate/validate.proto\"\xe0\x02\n" +
+	"\x1dListRecommendationLogsRequest\x12'\n" +
+	"\tpage_size\x18\x01 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xf4\x03(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12 \n" +
+	"\fuser_id_hash\x18\x04 \x01(\tR\n" +
+	"userIdHash\x12\x16\n" +
+	"\x06source\x18\x05 \x01(\tR\x06source\x12\x1d\n" +
+	"\n" +
+	"error_kind\x18\x06 \x01(\tR\terrorKind\x12!\n" +
+	"\fmissing_only\x18\a \x01(\bR\vmissingOnly\x120\n" +
+	"\x05since\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x05until\"~\n" +
+	"\x1eListRecommendationLogsResponse\x124\n" +
+	"\x04logs\x18\x01 \x03(\v2 .feed.admin.v1.RecommendationLogR\x04logs\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\">\n" +
+	"\x1bGetRecommendationLogRequest\x12\x1f\n" +
+	"\x06log_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x05logId\"\xac\x06\n" +
+	"\x11RecommendationLog\x12\x15\n" +
+	"\x06log_id\x18\x01 \x01(\tR\x05logId\x12 \n" +
+	"\fuser_id_hash\x18\x02 \x01(\tR\n" +
+	"userIdHash\x12-\n" +
+	"\x13user_id_key_version\x18\x03 \x01(\tR\x10userIdKeyVersion\x12#\n" +
+	"\rrequest_limit\x18\x04 \x01(\x05R\frequestLimit\x123\n" +
+	"\x15recommendation_source\x18\x05 \x01(\tR\x14recommendationSource\x12:\n" +
+	"\x19recommendation_latency_ms\x18\x06 \x01(\x05R\x17recommendationLatencyMs\x12K\n" +
+	"\x11recommended_items\x18\a \x03(\v2\x1e.feed.admin.v1.RecommendedItemR\x10recommendedItems\x12*\n" +
+	"\x11missing_video_ids\x18\b \x03(\tR\x0fmissingVideoIds\x12\x1d\n" +
+	"\n" +
+	"error_kind\x18\t \x01(\tR\terrorKind\x12=\n" +
+	"\fgenerated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\x12'\n" +
+	"\x0fidempotency_key\x18\v \x01(\tR\x0eidempotencyKey\x12\x1a\n" +
+	"\breplayed\x18\f \x01(\bR\breplayed\x12\x14\n" +
+	"\x05guest\x18\r \x01(\bR\x05guest\x12<\n" +
+	"\fserved_items\x18\x0e \x03(\v2\x19.feed.admin.v1.ServedItemR\vservedItems\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x0f \x01(\tR\trequestId\x12\x19\n" +
+	"\btrace_id\x18\x10 \x01(\tR\atraceId\x12\x14\n" +
+	"\x05scene\x18\x11 \x01(\tR\x05scene\x12\x12\n" +
+	"\x04page\x18\x12 \x01(\x05R\x04page\x12E\n" +
+	"\vexperiments\x18\x13 \x03(\v2#.feed.admin.v1.ExperimentAssignmentR\vexperiments\"h\n" +
+	"\x14ExperimentAssignment\x12\x1e\n" +
+	"\n" +
+	"experiment\x18\x01 \x01(\tR\n" +
+	"experiment\x12\x18\n" +
+	"\avariant\x18\x02 \x01(\tR\avariant\x12\x16\n" +
+	"\x06forced\x18\x03 \x01(\bR\x06forced\"\xf8\x01\n" +
+	"\x0fRecommendedItem\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\x12<\n" +
+	"\x04meta\x18\x04 \x03(\v2(.feed.admin.v1.RecommendedItem.MetaEntryR\x04meta\x12%\n" +
+	"\x0emissing_reason\x18\x05 \x01(\tR\rmissingReason\x1a7\n" +
+	"\tMetaEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x9f\x01\n" +
+	"\n" +
+	"ServedItem\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1a\n" +
+	"\bposition\x18\x02 \x01(\x05R\bposition\x12-\n" +
+	"\x12projection_version\x18\x03 \x01(\x03R\x11projectionVersion\x12+\n" +
+	"\x11visibility_status\x18\x04 \x01(\tR\x10visibilityStatus\"\xa0\x04\n" +
+	"\fCurationRule\x12\x17\n" +
+	"\arule_id\x18\x01 \x01(\tR\x06ruleId\x12!\n" +
+	"\x06action\x18\x02 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x18\x10R\x06action\x12#\n" +
+	"\bvideo_id\x18\x03 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\avideoId\x12\x1d\n" +
+	"\x05scene\x18\x04 \x01(\tB\a\xbaH\x04r\x02\x18@R\x05scene\x12\x1f\n" +
+	"\x06locale\x18\x05 \x01(\tB\a\xbaH\x04r\x02\x18#R\x06locale\x12*\n" +
+	"\fuser_segment\x18\x06 \x01(\tB\a\xbaH\x04r\x02\x18@R\vuserSegment\x12%\n" +
+	"\bposition\x18\a \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x00R\bposition\x12\x1a\n" +
+	"\bpriority\x18\b \x01(\x05R\bpriority\x127\n" +
+	"\tstarts_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\x12\x1c\n" +
+	"\x04note\x18\v \x01(\tB\b\xbaH\x05r\x03\x18\x80\x04R\x04note\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"C\n" +
+	"\x18ListCurationRulesRequest\x12'\n" +
+	"\x0finclude_expired\x18\x01 \x01(\bR\x0eincludeExpired\"N\n" +
+	"\x19ListCurationRulesResponse\x121\n" +
+	"\x05rules\x18\x01 \x03(\v2\x1b.feed.admin.v1.CurationRuleR\x05rules\"T\n" +
+	"\x19CreateCurationRuleRequest\x127\n" +
+	"\x04rule\x18\x01 \x01(\v2\x1b.feed.admin.v1.CurationRuleB\x06\xbaH\x03\xc8\x01\x01R\x04rule\"w\n" +
+	"\x19UpdateCurationRuleRequest\x12!\n" +
+	"\arule_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06ruleId\x127\n" +
+	"\x04rule\x18\x02 \x01(\v2\x1b.feed.admin.v1.CurationRuleB\x06\xbaH\x03\xc8\x01\x01R\x04rule\">\n" +
+	"\x19DeleteCurationRuleRequest\x12!\n" +
+	"\arule_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x06ruleId2\xe9\x04\n" +
+	"\x10FeedAdminService\x12u\n" +
+	"\x16ListRecommendationLogs\x12,.feed.admin.v1.ListRecommendationLogsRequest\x1a-.feed.admin.v1.ListRecommendationLogsResponse\x12d\n" +
+	"\x14GetRecommendationLog\x12*.feed.admin.v1.GetRecommendationLogRequest\x1a .feed.admin.v1.RecommendationLog\x12f\n" +
+	"\x11ListCurationRules\x12'.feed.admin.v1.ListCurationRulesRequest\x1a(.feed.admin.v1.ListCurationRulesResponse\x12[\n" +
+	"\x12CreateCurationRule\x12(.feed.admin.v1.CreateCurationRuleRequest\x1a\x1b.feed.admin.v1.CurationRule\x12[\n" +
+	"\x12UpdateCurationRule\x12(.feed.admin.v1.UpdateCurationRuleRequest\x1a\x1b.feed.admin.v1.CurationRule\x12V\n" +
+	"\x12DeleteCurationRule\x12(.feed.admin.v1.DeleteCurationRuleRequest\x1a\x16.google.protobuf.EmptyBFZDgithub.com/bionicotaku/lingo-services-feed/api/feed/admin/v1;adminv1b\x06proto3"
+
+var (
+	file_api_feed_admin_v1_admin_proto_rawDescOnce sync.Once
+	file_api_feed_admin_v1_admin_proto_rawDescData []byte
+)
+
+func file_api_feed_admin_v1_admin_proto_rawDescGZIP() []byte {
+	file_api_feed_admin_v1_admin_proto_rawDescOnce.Do(func() {
+		file_api_feed_admin_v1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_feed_admin_v1_admin_proto_rawDesc), len(file_api_feed_admin_v1_admin_proto_rawDesc)))
+	})
+	return file_api_feed_admin_v1_admin_proto_rawDescData
+}
+
+var file_api_feed_admin_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_api_feed_admin_v1_admin_proto_goTypes = []any{
+	(*ListRecommendationLogsRequest)(nil),  // 0: feed.admin.v1.ListRecommendationLogsRequest
+	(*ListRecommendationLogsResponse)(nil), // 1: feed.admin.v1.ListRecommendationLogsResponse
+	(*GetRecommendationLogRequest)(nil),    // 2: feed.admin.v1.GetRecommendationLogRequest
+	(*RecommendationLog)(nil),              // 3: feed.admin.v1.RecommendationLog
+	(*ExperimentAssignment)(nil),           // 4: feed.admin.v1.ExperimentAssignment
+	(*RecommendedItem)(nil),                // 5: feed.admin.v1.RecommendedItem
+	(*ServedItem)(nil),                     // 6: feed.admin.v1.ServedItem
+	(*CurationRule)(nil),                   // 7: feed.admin.v1.CurationRule
+	(*ListCurationRulesRequest)(nil),       // 8: feed.admin.v1.ListCurationRulesRequest
+	(*ListCurationRulesResponse)(nil),      // 9: feed.admin.v1.ListCurationRulesResponse
+	(*CreateCurationRuleRequest)(nil),      // 10: feed.admin.v1.CreateCurationRuleRequest
+	(*UpdateCurationRuleRequest)(nil),      // 11: feed.admin.v1.UpdateCurationRuleRequest
+	(*DeleteCurationRuleRequest)(nil),      // 12: feed.admin.v1.DeleteCurationRuleRequest
+	nil,                                    // 13: feed.admin.v1.RecommendedItem.MetaEntry
+	(*timestamppb.Timestamp)(nil),          // 14: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                  // 15: google.protobuf.Empty
+}
+var file_api_feed_admin_v1_admin_proto_depIdxs = []int32{
+	14, // 0: feed.admin.v1.ListRecommendationLogsRequest.since:type_name -> google.protobuf.Timestamp
+	14, // 1: feed.admin.v1.ListRecommendationLogsRequest.until:type_name -> google.protobuf.Timestamp
+	3,  // 2: feed.admin.v1.ListRecommendationLogsResponse.logs:type_name -> feed.admin.v1.RecommendationLog
+	5,  // 3: feed.admin.v1.RecommendationLog.recommended_items:type_name -> feed.admin.v1.RecommendedItem
+	14, // 4: feed.admin.v1.RecommendationLog.generated_at:type_name -> google.protobuf.Timestamp
+	6,  // 5: feed.admin.v1.RecommendationLog.served_items:type_name -> feed.admin.v1.ServedItem
+	4,  // 6: feed.admin.v1.RecommendationLog.experiments:type_name -> feed.admin.v1.ExperimentAssignment
+	13, // 7: feed.admin.v1.RecommendedItem.meta:type_name -> feed.admin.v1.RecommendedItem.MetaEntry
+	14, // 8: feed.admin.v1.CurationRule.starts_at:type_name -> google.protobuf.Timestamp
+	14, // 9: feed.admin.v1.CurationRule.ends_at:type_name -> google.protobuf.Timestamp
+	14, // 10: feed.admin.v1.CurationRule.created_at:type_name -> google.protobuf.Timestamp
+	14, // 11: feed.admin.v1.CurationRule.updated_at:type_name -> google.protobuf.Timestamp
+	7,  // 12: feed.admin.v1.ListCurationRulesResponse.rules:type_name -> feed.admin.v1.CurationRule
+	7,  // 13: feed.admin.v1.CreateCurationRuleRequest.rule:type_name -> feed.admin.v1.CurationRule
+	7,  // 14: feed.admin.v1.UpdateCurationRuleRequest.rule:type_name -> feed.admin.v1.CurationRule
+	0,  // 15: feed.admin.v1.FeedAdminService.ListRecommendationLogs:input_type -> feed.admin.v1.ListRecommendationLogsRequest
+	2,  // 16: feed.admin.v1.FeedAdminService.GetRecommendationLog:input_type -> feed.admin.v1.GetRecommendationLogRequest
+	8,  // 17: feed.admin.v1.FeedAdminService.ListCurationRules:input_type -> feed.admin.v1.ListCurationRulesRequest
+	10, // 18: feed.admin.v1.FeedAdminService.CreateCurationRule:input_type -> feed.admin.v1.CreateCurationRuleRequest
+	11, // 19: feed.admin.v1.FeedAdminService.UpdateCurationRule:input_type -> feed.admin.v1.UpdateCurationRuleRequest
+	12, // 20: feed.admin.v1.FeedAdminService.DeleteCurationRule:input_type -> feed.admin.v1.DeleteCurationRuleRequest
+	1,  // 21: feed.admin.v1.FeedAdminService.ListRecommendationLogs:output_type -> feed.admin.v1.ListRecommendationLogsResponse
+	3,  // 22: feed.admin.v1.FeedAdminService.GetRecommendationLog:output_type -> feed.admin.v1.RecommendationLog
+	9,  // 23: feed.admin.v1.FeedAdminService.ListCurationRules:output_type -> feed.admin.v1.ListCurationRulesResponse
+	7,  // 24: feed.admin.v1.FeedAdminService.CreateCurationRule:output_type -> feed.admin.v1.CurationRule
+	7,  // 25: feed.admin.v1.FeedAdminService.UpdateCurationRule:output_type -> feed.admin.v1.CurationRule
+	15, // 26: feed.admin.v1.FeedAdminService.DeleteCurationRule:output_type -> google.protobuf.Empty
+	21, // [21:27] is the sub-list for method output_type
+	15, // [15:21] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_api_feed_admin_v1_admin_proto_init() }
+func file_api_feed_admin_v1_admin_proto_init() {
+	if File_api_feed_admin_v1_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_feed_admin_v1_admin_proto_rawDesc), len(file_api_feed_admin_v1_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_feed_admin_v1_admin_proto_goTypes,
+		DependencyIndexes: file_api_feed_admin_v1_admin_proto_depIdxs,
+		MessageInfos:      file_api_feed_admin_v1_admin_proto_msgTypes,
+	}.Build()
+	File_api_feed_admin_v1_admin_proto = out.File
+	file_api_feed_admin_v1_admin_proto_goTypes = nil
+	file_api_feed_admin_v1_admin_proto_depIdxs = nil
+}
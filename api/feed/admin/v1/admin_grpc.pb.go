@@ -0,0 +1,330 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: api/feed/admin/v1/admin.proto
+
+package adminv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FeedAdminService_ListRecommendationLogs_FullMethodName = "/feed.admin.v1.FeedAdminService/ListRecommendationLogs"
+	FeedAdminService_GetRecommendationLog_FullMethodName   = "/feed.admin.v1.FeedAdminService/GetRecommendationLog"
+	FeedAdminService_ListCurationRules_FullMethodName      = "/feed.admin.v1.FeedAdminService/ListCurationRules"
+	FeedAdminService_CreateCurationRule_FullMethodName     = "/feed.admin.v1.FeedAdminService/CreateCurationRule"
+	FeedAdminService_UpdateCurationRule_FullMethodName     = "/feed.admin.v1.FeedAdminService/UpdateCurationRule"
+	FeedAdminService_DeleteCurationRule_FullMethodName     = "/feed.admin.v1.FeedAdminService/DeleteCurationRule"
+)
+
+// FeedAdminServiceClient is the client API for FeedAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FeedAdminService 供运维/客服工具查询推荐日志、维护运营干预规则，仅通过 gRPC 暴露，不映射 HTTP 路由。
+// 调用方需持有管理员 audience 或角色，见 server.admin 配置。
+type FeedAdminServiceClient interface {
+	// ListRecommendationLogs 按 (generated_at, log_id) 倒序分页列出推荐日志。
+	ListRecommendationLogs(ctx context.Context, in *ListRecommendationLogsRequest, opts ...grpc.CallOption) (*ListRecommendationLogsResponse, error)
+	// GetRecommendationLog 返回单条推荐日志。
+	GetRecommendationLog(ctx context.Context, in *GetRecommendationLogRequest, opts ...grpc.CallOption) (*RecommendationLog, error)
+	// ListCurationRules 按优先级降序列出运营干预规则。
+	ListCurationRules(ctx context.Context, in *ListCurationRulesRequest, opts ...grpc.CallOption) (*ListCurationRulesResponse, error)
+	// CreateCurationRule 新增一条规则，各实例经 LISTEN/NOTIFY 刷新缓存后生效。
+	CreateCurationRule(ctx context.Context, in *CreateCurationRuleRequest, opts ...grpc.CallOption) (*CurationRule, error)
+	// UpdateCurationRule 整体覆盖一条规则。
+	UpdateCurationRule(ctx context.Context, in *UpdateCurationRuleRequest, opts ...grpc.CallOption) (*CurationRule, error)
+	// DeleteCurationRule 删除一条规则。
+	DeleteCurationRule(ctx context.Context, in *DeleteCurationRuleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type feedAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFeedAdminServiceClient(cc grpc.ClientConnInterface) FeedAdminServiceClient {
+	return &feedAdminServiceClient{cc}
+}
+
+func (c *feedAdminServiceClient) ListRecommendationLogs(ctx context.Context, in *ListRecommendationLogsRequest, opts ...grpc.CallOption) (*ListRecommendationLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRecommendationLogsResponse)
+	err := c.cc.Invoke(ctx, FeedAdminService_ListRecommendationLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedAdminServiceClient) GetRecommendationLog(ctx context.Context, in *GetRecommendationLogRequest, opts ...grpc.CallOption) (*RecommendationLog, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecommendationLog)
+	err := c.cc.Invoke(ctx, FeedAdminService_GetRecommendationLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedAdminServiceClient) ListCurationRules(ctx context.Context, in *ListCurationRulesRequest, opts ...grpc.CallOption) (*ListCurationRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCurationRulesResponse)
+	err := c.cc.Invoke(ctx, FeedAdminService_ListCurationRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedAdminServiceClient) CreateCurationRule(ctx context.Context, in *CreateCurationRuleRequest, opts ...grpc.CallOption) (*CurationRule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CurationRule)
+	err := c.cc.Invoke(ctx, FeedAdminService_CreateCurationRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedAdminServiceClient) UpdateCurationRule(ctx context.Context, in *UpdateCurationRuleRequest, opts ...grpc.CallOption) (*CurationRule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CurationRule)
+	err := c.cc.Invoke(ctx, FeedAdminService_UpdateCurationRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedAdminServiceClient) DeleteCurationRule(ctx context.Context, in *DeleteCurationRuleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, FeedAdminService_DeleteCurationRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FeedAdminServiceServer is the server API for FeedAdminService service.
+// All implementations must embed UnimplementedFeedAdminServiceServer
+// for forward compatibility.
+//
+// FeedAdminService 供运维/客服工具查询推荐日志、维护运营干预规则，仅通过 gRPC 暴露，不映射 HTTP 路由。
+// 调用方需持有管理员 audience 或角色，见 server.admin 配置。
+type FeedAdminServiceServer interface {
+	// ListRecommendationLogs 按 (generated_at, log_id) 倒序分页列出推荐日志。
+	ListRecommendationLogs(context.Context, *ListRecommendationLogsRequest) (*ListRecommendationLogsResponse, error)
+	// GetRecommendationLog 返回单条推荐日志。
+	GetRecommendationLog(context.Context, *GetRecommendationLogRequest) (*RecommendationLog, error)
+	// ListCurationRules 按优先级降序列出运营干预规则。
+	ListCurationRules(context.Context, *ListCurationRulesRequest) (*ListCurationRulesResponse, error)
+	// CreateCurationRule 新增一条规则，各实例经 LISTEN/NOTIFY 刷新缓存后生效。
+	CreateCurationRule(context.Context, *CreateCurationRuleRequest) (*CurationRule, error)
+	// UpdateCurationRule 整体覆盖一条规则。
+	UpdateCurationRule(context.Context, *UpdateCurationRuleRequest) (*CurationRule, error)
+	// DeleteCurationRule 删除一条规则。
+	DeleteCurationRule(context.Context, *DeleteCurationRuleRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedFeedAdminServiceServer()
+}
+
+// UnimplementedFeedAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFeedAdminServiceServer struct{}
+
+func (UnimplementedFeedAdminServiceServer) ListRecommendationLogs(context.Context, *ListRecommendationLogsRequest) (*ListRecommendationLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecommendationLogs not implemented")
+}
+func (UnimplementedFeedAdminServiceServer) GetRecommendationLog(context.Context, *GetRecommendationLogRequest) (*RecommendationLog, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecommendationLog not implemented")
+}
+func (UnimplementedFeedAdminServiceServer) ListCurationRules(context.Context, *ListCurationRulesRequest) (*ListCurationRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCurationRules not implemented")
+}
+func (UnimplementedFeedAdminServiceServer) CreateCurationRule(context.Context, *CreateCurationRuleRequest) (*CurationRule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCurationRule not implemented")
+}
+func (UnimplementedFeedAdminServiceServer) UpdateCurationRule(context.Context, *UpdateCurationRuleRequest) (*CurationRule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCurationRule not implemented")
+}
+func (UnimplementedFeedAdminServiceServer) DeleteCurationRule(context.Context, *DeleteCurationRuleRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCurationRule not implemented")
+}
+func (UnimplementedFeedAdminServiceServer) mustEmbedUnimplementedFeedAdminServiceServer() {}
+func (UnimplementedFeedAdminServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeFeedAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FeedAdminServiceServer will
+// result in compilation errors.
+type UnsafeFeedAdminServiceServer interface {
+	mustEmbedUnimplementedFeedAdminServiceServer()
+}
+
+func RegisterFeedAdminServiceServer(s grpc.ServiceRegistrar, srv FeedAdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFeedAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FeedAdminService_ServiceDesc, srv)
+}
+
+func _FeedAdminService_ListRecommendationLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecommendationLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedAdminServiceServer).ListRecommendationLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedAdminService_ListRecommendationLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedAdminServiceServer).ListRecommendationLogs(ctx, req.(*ListRecommendationLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeedAdminService_GetRecommendationLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecommendationLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedAdminServiceServer).GetRecommendationLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedAdminService_GetRecommendationLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedAdminServiceServer).GetRecommendationLog(ctx, req.(*GetRecommendationLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeedAdminService_ListCurationRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCurationRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedAdminServiceServer).ListCurationRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedAdminService_ListCurationRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedAdminServiceServer).ListCurationRules(ctx, req.(*ListCurationRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeedAdminService_CreateCurationRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCurationRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedAdminServiceServer).CreateCurationRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedAdminService_CreateCurationRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedAdminServiceServer).CreateCurationRule(ctx, req.(*CreateCurationRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeedAdminService_UpdateCurationRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCurationRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedAdminServiceServer).UpdateCurationRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedAdminService_UpdateCurationRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedAdminServiceServer).UpdateCurationRule(ctx, req.(*UpdateCurationRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeedAdminService_DeleteCurationRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCurationRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedAdminServiceServer).DeleteCurationRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedAdminService_DeleteCurationRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedAdminServiceServer).DeleteCurationRule(ctx, req.(*DeleteCurationRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FeedAdminService_ServiceDesc is the grpc.ServiceDesc for FeedAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FeedAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "feed.admin.v1.FeedAdminService",
+	HandlerType: (*FeedAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListRecommendationLogs",
+			Handler:    _FeedAdminService_ListRecommendationLogs_Handler,
+		},
+		{
+			MethodName: "GetRecommendationLog",
+			Handler:    _FeedAdminService_GetRecommendationLog_Handler,
+		},
+		{
+			MethodName: "ListCurationRules",
+			Handler:    _FeedAdminService_ListCurationRules_Handler,
+		},
+		{
+			MethodName: "CreateCurationRule",
+			Handler:    _FeedAdminService_CreateCurationRule_Handler,
+		},
+		{
+			MethodName: "UpdateCurationRule",
+			Handler:    _FeedAdminService_UpdateCurationRule_Handler,
+		},
+		{
+			MethodName: "DeleteCurationRule",
+			Handler:    _FeedAdminService_DeleteCurationRule_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/feed/admin/v1/admin.proto",
+}
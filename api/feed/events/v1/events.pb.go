@@ -0,0 +1,560 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: api/feed/events/v1/events.proto
+
+package eventsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// InteractionType 为交互事件类型。
+type InteractionType int32
+
+const (
+	InteractionType_INTERACTION_TYPE_UNSPECIFIED InteractionType = 0
+	InteractionType_INTERACTION_TYPE_IMPRESSION  InteractionType = 1
+	InteractionType_INTERACTION_TYPE_CLICK       InteractionType = 2
+	InteractionType_INTERACTION_TYPE_REFRESH     InteractionType = 3
+)
+
+// Enum value maps for InteractionType.
+var (
+	InteractionType_name = map[int32]string{
+		0: "INTERACTION_TYPE_UNSPECIFIED",
+		1: "INTERACTION_TYPE_IMPRESSION",
+		2: "INTERACTION_TYPE_CLICK",
+		3: "INTERACTION_TYPE_REFRESH",
+	}
+	InteractionType_value = map[string]int32{
+		"INTERACTION_TYPE_UNSPECIFIED": 0,
+		"INTERACTION_TYPE_IMPRESSION":  1,
+		"INTERACTION_TYPE_CLICK":       2,
+		"INTERACTION_TYPE_REFRESH":     3,
+	}
+)
+
+func (x InteractionType) Enum() *InteractionType {
+	p := new(InteractionType)
+	*p = x
+	return p
+}
+
+func (x InteractionType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InteractionType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_feed_events_v1_events_proto_enumTypes[0].Descriptor()
+}
+
+func (InteractionType) Type() protoreflect.EnumType {
+	return &file_api_feed_events_v1_events_proto_enumTypes[0]
+}
+
+func (x InteractionType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InteractionType.Descriptor instead.
+func (InteractionType) EnumDescriptor() ([]byte, []int) {
+	return file_api_feed_events_v1_events_proto_rawDescGZIP(), []int{0}
+}
+
+// InteractionEvent 为经推荐日志校验后的用户交互事件。
+type InteractionEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 客户端生成的事件 ID，与 outbox event_id 一致。
+	EventId string          `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Type    InteractionType `protobuf:"varint,2,opt,name=type,proto3,enum=feed.events.v1.InteractionType" json:"type,omitempty"`
+	// 事件关联的推荐日志 ID。
+	LogId string `protobuf:"bytes,3,opt,name=log_id,json=logId,proto3" json:"log_id,omitempty"`
+	// 刷新事件为空。
+	VideoId string `protobuf:"bytes,4,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	// 视频在该页实际下发时的位次（从 1 开始），刷新事件为 0。
+	Position int32 `protobuf:"varint,5,opt,name=position,proto3" json:"position,omitempty"`
+	// 与推荐日志一致的用户标识 HMAC 哈希，访客为空。
+	UserIdHash           string `protobuf:"bytes,6,opt,name=user_id_hash,json=userIdHash,proto3" json:"user_id_hash,omitempty"`
+	UserIdKeyVersion     string `protobuf:"bytes,7,opt,name=user_id_key_version,json=userIdKeyVersion,proto3" json:"user_id_key_version,omitempty"`
+	Guest                bool   `protobuf:"varint,8,opt,name=guest,proto3" json:"guest,omitempty"`
+	Scene                string `protobuf:"bytes,9,opt,name=scene,proto3" json:"scene,omitempty"`
+	RecommendationSource string `protobuf:"bytes,10,opt,name=recommendation_source,json=recommendationSource,proto3" json:"recommendation_source,omitempty"`
+	// 补水所用投影版本，刷新事件为 0。
+	ProjectionVersion int64                  `protobuf:"varint,11,opt,name=projection_version,json=projectionVersion,proto3" json:"projection_version,omitempty"`
+	OccurredAt        *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	ReceivedAt        *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *InteractionEvent) Reset() {
+	*x = InteractionEvent{}
+	mi := &file_api_feed_events_v1_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InteractionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InteractionEvent) ProtoMessage() {}
+
+func (x *InteractionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_events_v1_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InteractionEvent.ProtoReflect.Descriptor instead.
+func (*InteractionEvent) Descriptor() ([]byte, []int) {
+	return file_api_feed_events_v1_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *InteractionEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *InteractionEvent) GetType() InteractionType {
+	if x != nil {
+		return x.Type
+	}
+	return InteractionType_INTERACTION_TYPE_UNSPECIFIED
+}
+
+func (x *InteractionEvent) GetLogId() string {
+	if x != nil {
+		return x.LogId
+	}
+	return ""
+}
+
+func (x *InteractionEvent) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *InteractionEvent) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *InteractionEvent) GetUserIdHash() string {
+	if x != nil {
+		return x.UserIdHash
+	}
+	return ""
+}
+
+func (x *InteractionEvent) GetUserIdKeyVersion() string {
+	if x != nil {
+		return x.UserIdKeyVersion
+	}
+	return ""
+}
+
+func (x *InteractionEvent) GetGuest() bool {
+	if x != nil {
+		return x.Guest
+	}
+	return false
+}
+
+func (x *InteractionEvent) GetScene() string {
+	if x != nil {
+		return x.Scene
+	}
+	return ""
+}
+
+func (x *InteractionEvent) GetRecommendationSource() string {
+	if x != nil {
+		return x.RecommendationSource
+	}
+	return ""
+}
+
+func (x *InteractionEvent) GetProjectionVersion() int64 {
+	if x != nil {
+		return x.ProjectionVersion
+	}
+	return 0
+}
+
+func (x *InteractionEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *InteractionEvent) GetReceivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return nil
+}
+
+// FeedServedEvent 记录一次推荐响应实际下发的内容，与推荐日志在同一事务内写入 outbox。
+type FeedServedEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 与 log_id 相同，使同一条日志至多产生一个 served 事件。
+	EventId string `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	LogId   string `protobuf:"bytes,2,opt,name=log_id,json=logId,proto3" json:"log_id,omitempty"`
+	// 与推荐日志一致的用户标识 HMAC 哈希，访客为空。
+	UserIdHash       string `protobuf:"bytes,3,opt,name=user_id_hash,json=userIdHash,proto3" json:"user_id_hash,omitempty"`
+	UserIdKeyVersion string `protobuf:"bytes,4,opt,name=user_id_key_version,json=userIdKeyVersion,proto3" json:"user_id_key_version,omitempty"`
+	Guest            bool   `protobuf:"varint,5,opt,name=guest,proto3" json:"guest,omitempty"`
+	Scene            string `protobuf:"bytes,6,opt,name=scene,proto3" json:"scene,omitempty"`
+	// 游标分页页码，从 1 开始。
+	Page                 int32         `protobuf:"varint,7,opt,name=page,proto3" json:"page,omitempty"`
+	RecommendationSource string        `protobuf:"bytes,8,opt,name=recommendation_source,json=recommendationSource,proto3" json:"recommendation_source,omitempty"`
+	Items                []*ServedItem `protobuf:"bytes,9,rep,name=items,proto3" json:"items,omitempty"`
+	// 幂等重放的响应为 true，消费方可据此去重计数。
+	Replayed      bool                   `protobuf:"varint,10,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	RequestId     string                 `protobuf:"bytes,11,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	TraceId       string                 `protobuf:"bytes,12,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	GeneratedAt   *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedServedEvent) Reset() {
+	*x = FeedServedEvent{}
+	mi := &file_api_feed_events_v1_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedServedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedServedEvent) ProtoMessage() {}
+
+func (x *FeedServedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_events_v1_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedServedEvent.ProtoReflect.Descriptor instead.
+func (*FeedServedEvent) Descriptor() ([]byte, []int) {
+	return file_api_feed_events_v1_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FeedServedEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *FeedServedEvent) GetLogId() string {
+	if x != nil {
+		return x.LogId
+	}
+	return ""
+}
+
+func (x *FeedServedEvent) GetUserIdHash() string {
+	if x != nil {
+		return x.UserIdHash
+	}
+	return ""
+}
+
+func (x *FeedServedEvent) GetUserIdKeyVersion() string {
+	if x != nil {
+		return x.UserIdKeyVersion
+	}
+	return ""
+}
+
+func (x *FeedServedEvent) GetGuest() bool {
+	if x != nil {
+		return x.Guest
+	}
+	return false
+}
+
+func (x *FeedServedEvent) GetScene() string {
+	if x != nil {
+		return x.Scene
+	}
+	return ""
+}
+
+func (x *FeedServedEvent) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *FeedServedEvent) GetRecommendationSource() string {
+	if x != nil {
+		return x.RecommendationSource
+	}
+	return ""
+}
+
+func (x *FeedServedEvent) GetItems() []*ServedItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *FeedServedEvent) GetReplayed() bool {
+	if x != nil {
+		return x.Replayed
+	}
+	return false
+}
+
+func (x *FeedServedEvent) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *FeedServedEvent) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *FeedServedEvent) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+// ServedItem 为实际下发的单个视频。
+type ServedItem struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	VideoId string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	// 响应中的位次，从 1 开始。
+	Position int32 `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"`
+	// 补水所用投影版本。
+	ProjectionVersion int64 `protobuf:"varint,3,opt,name=projection_version,json=projectionVersion,proto3" json:"projection_version,omitempty"`
+	// 推荐模块给出的理由与分数，未命中原始推荐时为空。
+	Reason        string  `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Score         float64 `protobuf:"fixed64,5,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServedItem) Reset() {
+	*x = ServedItem{}
+	mi := &file_api_feed_events_v1_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServedItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServedItem) ProtoMessage() {}
+
+func (x *ServedItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_events_v1_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServedItem.ProtoReflect.Descriptor instead.
+func (*ServedItem) Descriptor() ([]byte, []int) {
+	return file_api_feed_events_v1_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ServedItem) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *ServedItem) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *ServedItem) GetProjectionVersion() int64 {
+	if x != nil {
+		return x.ProjectionVersion
+	}
+	return 0
+}
+
+func (x *ServedItem) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ServedItem) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+var File_api_feed_events_v1_events_proto protoreflect.FileDescriptor
+
+const file_api_feed_events_v1_events_proto_rawDesc = "" +
+	"\n" +
+	"\x1fapi/feed/events/v1/events.proto\x12\x0efeed.events.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8b\x04\n" +
+	"\x10InteractionEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x123\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1f.feed.events.v1.InteractionTypeR\x04type\x12\x15\n" +
+	"\x06log_id\x18\x03 \x01(\tR\x05logId\x12\x19\n" +
+	"\bvideo_id\x18\x04 \x01(\tR\avideoId\x12\x1a\n" +
+	"\bposition\x18\x05 \x01(\x05R\bposition\x12 \n" +
+	"\fuser_id_hash\x18\x06 \x01(\tR\n" +
+	"userIdHash\x12-\n" +
+	"\x13user_id_key_version\x18\a \x01(\tR\x10userIdKeyVersion\x12\x14\n" +
+	"\x05guest\x18\b \x01(\bR\x05guest\x12\x14\n" +
+	"\x05scene\x18\t \x01(\tR\x05scene\x123\n" +
+	"\x15recommendation_source\x18\n" +
+	" \x01(\tR\x14recommendationSource\x12-\n" +
+	"\x12projection_version\x18\v \x01(\x03R\x11projectionVersion\x12;\n" +
+	"\voccurred_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12;\n" +
+	"\vreceived_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"receivedAt\"\xd0\x03\n" +
+	"\x0fFeedServedEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x15\n" +
+	"\x06log_id\x18\x02 \x01(\tR\x05logId\x12 \n" +
+	"\fuser_id_hash\x18\x03 \x01(\tR\n" +
+	"userIdHash\x12-\n" +
+	"\x13user_id_key_version\x18\x04 \x01(\tR\x10userIdKeyVersion\x12\x14\n" +
+	"\x05guest\x18\x05 \x01(\bR\x05guest\x12\x14\n" +
+	"\x05scene\x18\x06 \x01(\tR\x05scene\x12\x12\n" +
+	"\x04page\x18\a \x01(\x05R\x04page\x123\n" +
+	"\x15recommendation_source\x18\b \x01(\tR\x14recommendationSource\x120\n" +
+	"\x05items\x18\t \x03(\v2\x1a.feed.events.v1.ServedItemR\x05items\x12\x1a\n" +
+	"\breplayed\x18\n" +
+	" \x01(\bR\breplayed\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\v \x01(\tR\trequestId\x12\x19\n" +
+	"\btrace_id\x18\f \x01(\tR\atraceId\x12=\n" +
+	"\fgenerated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\"\xa0\x01\n" +
+	"\n" +
+	"ServedItem\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1a\n" +
+	"\bposition\x18\x02 \x01(\x05R\bposition\x12-\n" +
+	"\x12projection_version\x18\x03 \x01(\x03R\x11projectionVersion\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05score\x18\x05 \x01(\x01R\x05score*\x8e\x01\n" +
+	"\x0fInteractionType\x12 \n" +
+	"\x1cINTERACTION_TYPE_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bINTERACTION_TYPE_IMPRESSION\x10\x01\x12\x1a\n" +
+	"\x16INTERACTION_TYPE_CLICK\x10\x02\x12\x1c\n" +
+	"\x18INTERACTION_TYPE_REFRESH\x10\x03BHZFgithub.com/bionicotaku/lingo-services-feed/api/feed/events/v1;eventsv1b\x06proto3"
+
+var (
+	file_api_feed_events_v1_events_proto_rawDescOnce sync.Once
+	file_api_feed_events_v1_events_proto_rawDescData []byte
+)
+
+func file_api_feed_events_v1_events_proto_rawDescGZIP() []byte {
+	file_api_feed_events_v1_events_proto_rawDescOnce.Do(func() {
+		file_api_feed_events_v1_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_feed_events_v1_events_proto_rawDesc), len(file_api_feed_events_v1_events_proto_rawDesc)))
+	})
+	return file_api_feed_events_v1_events_proto_rawDescData
+}
+
+var file_api_feed_events_v1_events_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_feed_events_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_api_feed_events_v1_events_proto_goTypes = []any{
+	(InteractionType)(0),          // 0: feed.events.v1.InteractionType
+	(*InteractionEvent)(nil),      // 1: feed.events.v1.InteractionEvent
+	(*FeedServedEvent)(nil),       // 2: feed.events.v1.FeedServedEvent
+	(*ServedItem)(nil),            // 3: feed.events.v1.ServedItem
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+}
+var file_api_feed_events_v1_events_proto_depIdxs = []int32{
+	0, // 0: feed.events.v1.InteractionEvent.type:type_name -> feed.events.v1.InteractionType
+	4, // 1: feed.events.v1.InteractionEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	4, // 2: feed.events.v1.InteractionEvent.received_at:type_name -> google.protobuf.Timestamp
+	3, // 3: feed.events.v1.FeedServedEvent.items:type_name -> feed.events.v1.ServedItem
+	4, // 4: feed.events.v1.FeedServedEvent.generated_at:type_name -> google.protobuf.Timestamp
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_api_feed_events_v1_events_proto_init() }
+func file_api_feed_events_v1_events_proto_init() {
+	if File_api_feed_events_v1_events_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_feed_events_v1_events_proto_rawDesc), len(file_api_feed_events_v1_events_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_feed_events_v1_events_proto_goTypes,
+		DependencyIndexes: file_api_feed_events_v1_events_proto_depIdxs,
+		EnumInfos:         file_api_feed_events_v1_events_proto_enumTypes,
+		MessageInfos:      file_api_feed_events_v1_events_proto_msgTypes,
+	}.Build()
+	File_api_feed_events_v1_events_proto = out.File
+	file_api_feed_events_v1_events_proto_goTypes = nil
+	file_api_feed_events_v1_events_proto_depIdxs = nil
+}
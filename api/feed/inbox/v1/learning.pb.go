@@ -0,0 +1,454 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: api/feed/inbox/v1/learning.proto
+
+package inboxv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// LearningEventType 为学习进度事件类型。
+type LearningEventType int32
+
+const (
+	LearningEventType_LEARNING_EVENT_TYPE_UNSPECIFIED LearningEventType = 0
+	// FSRS 调度出下一次复习时间（首次学习或完成一次复习后）。
+	LearningEventType_LEARNING_EVENT_TYPE_REVIEW_SCHEDULED LearningEventType = 1
+	// 视频移出复习队列（用户移除或卡片归档）。
+	LearningEventType_LEARNING_EVENT_TYPE_REVIEW_REMOVED LearningEventType = 2
+)
+
+// Enum value maps for LearningEventType.
+var (
+	LearningEventType_name = map[int32]string{
+		0: "LEARNING_EVENT_TYPE_UNSPECIFIED",
+		1: "LEARNING_EVENT_TYPE_REVIEW_SCHEDULED",
+		2: "LEARNING_EVENT_TYPE_REVIEW_REMOVED",
+	}
+	LearningEventType_value = map[string]int32{
+		"LEARNING_EVENT_TYPE_UNSPECIFIED":      0,
+		"LEARNING_EVENT_TYPE_REVIEW_SCHEDULED": 1,
+		"LEARNING_EVENT_TYPE_REVIEW_REMOVED":   2,
+	}
+)
+
+func (x LearningEventType) Enum() *LearningEventType {
+	p := new(LearningEventType)
+	*p = x
+	return p
+}
+
+func (x LearningEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LearningEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_feed_inbox_v1_learning_proto_enumTypes[0].Descriptor()
+}
+
+func (LearningEventType) Type() protoreflect.EnumType {
+	return &file_api_feed_inbox_v1_learning_proto_enumTypes[0]
+}
+
+func (x LearningEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LearningEventType.Descriptor instead.
+func (LearningEventType) EnumDescriptor() ([]byte, []int) {
+	return file_api_feed_inbox_v1_learning_proto_rawDescGZIP(), []int{0}
+}
+
+// LearningEvent 为 Learning Outbox 事件的统一信封。
+type LearningEvent struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	EventId   string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType LearningEventType      `protobuf:"varint,2,opt,name=event_type,json=eventType,proto3,enum=feed.inbox.v1.LearningEventType" json:"event_type,omitempty"`
+	// 聚合根为 (user_id, video_id)，取值形如 "<user_id>:<video_id>"。
+	AggregateId   string `protobuf:"bytes,3,opt,name=aggregate_id,json=aggregateId,proto3" json:"aggregate_id,omitempty"`
+	AggregateType string `protobuf:"bytes,4,opt,name=aggregate_type,json=aggregateType,proto3" json:"aggregate_type,omitempty"`
+	// 同一聚合根内单调递增的版本号。
+	Version int64 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	// RFC3339 时间戳。
+	OccurredAt string `protobuf:"bytes,6,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*LearningEvent_ReviewScheduled_
+	//	*LearningEvent_ReviewRemoved_
+	Payload       isLearningEvent_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LearningEvent) Reset() {
+	*x = LearningEvent{}
+	mi := &file_api_feed_inbox_v1_learning_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LearningEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LearningEvent) ProtoMessage() {}
+
+func (x *LearningEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_inbox_v1_learning_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LearningEvent.ProtoReflect.Descriptor instead.
+func (*LearningEvent) Descriptor() ([]byte, []int) {
+	return file_api_feed_inbox_v1_learning_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LearningEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *LearningEvent) GetEventType() LearningEventType {
+	if x != nil {
+		return x.EventType
+	}
+	return LearningEventType_LEARNING_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *LearningEvent) GetAggregateId() string {
+	if x != nil {
+		return x.AggregateId
+	}
+	return ""
+}
+
+func (x *LearningEvent) GetAggregateType() string {
+	if x != nil {
+		return x.AggregateType
+	}
+	return ""
+}
+
+func (x *LearningEvent) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *LearningEvent) GetOccurredAt() string {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return ""
+}
+
+func (x *LearningEvent) GetPayload() isLearningEvent_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *LearningEvent) GetReviewScheduled() *LearningEvent_ReviewScheduled {
+	if x != nil {
+		if x, ok := x.Payload.(*LearningEvent_ReviewScheduled_); ok {
+			return x.ReviewScheduled
+		}
+	}
+	return nil
+}
+
+func (x *LearningEvent) GetReviewRemoved() *LearningEvent_ReviewRemoved {
+	if x != nil {
+		if x, ok := x.Payload.(*LearningEvent_ReviewRemoved_); ok {
+			return x.ReviewRemoved
+		}
+	}
+	return nil
+}
+
+type isLearningEvent_Payload interface {
+	isLearningEvent_Payload()
+}
+
+type LearningEvent_ReviewScheduled_ struct {
+	ReviewScheduled *LearningEvent_ReviewScheduled `protobuf:"bytes,10,opt,name=review_scheduled,json=reviewScheduled,proto3,oneof"`
+}
+
+type LearningEvent_ReviewRemoved_ struct {
+	ReviewRemoved *LearningEvent_ReviewRemoved `protobuf:"bytes,11,opt,name=review_removed,json=reviewRemoved,proto3,oneof"`
+}
+
+func (*LearningEvent_ReviewScheduled_) isLearningEvent_Payload() {}
+
+func (*LearningEvent_ReviewRemoved_) isLearningEvent_Payload() {}
+
+// ReviewScheduled 对应 learning.review.scheduled。
+type LearningEvent_ReviewScheduled struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	UserId  string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	VideoId string                 `protobuf:"bytes,2,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	// 下一次复习到期时间，RFC3339。
+	DueAt string `protobuf:"bytes,3,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	// FSRS 记忆稳定度（天）与难度，仅用于排序与观测。
+	Stability  float64 `protobuf:"fixed64,4,opt,name=stability,proto3" json:"stability,omitempty"`
+	Difficulty float64 `protobuf:"fixed64,5,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	// 已完成的复习次数。
+	Reps int32 `protobuf:"varint,6,opt,name=reps,proto3" json:"reps,omitempty"`
+	// 最近一次复习时间，RFC3339，首次学习时为空。
+	LastReviewedAt string `protobuf:"bytes,7,opt,name=last_reviewed_at,json=lastReviewedAt,proto3" json:"last_reviewed_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LearningEvent_ReviewScheduled) Reset() {
+	*x = LearningEvent_ReviewScheduled{}
+	mi := &file_api_feed_inbox_v1_learning_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LearningEvent_ReviewScheduled) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LearningEvent_ReviewScheduled) ProtoMessage() {}
+
+func (x *LearningEvent_ReviewScheduled) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_inbox_v1_learning_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LearningEvent_ReviewScheduled.ProtoReflect.Descriptor instead.
+func (*LearningEvent_ReviewScheduled) Descriptor() ([]byte, []int) {
+	return file_api_feed_inbox_v1_learning_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *LearningEvent_ReviewScheduled) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LearningEvent_ReviewScheduled) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *LearningEvent_ReviewScheduled) GetDueAt() string {
+	if x != nil {
+		return x.DueAt
+	}
+	return ""
+}
+
+func (x *LearningEvent_ReviewScheduled) GetStability() float64 {
+	if x != nil {
+		return x.Stability
+	}
+	return 0
+}
+
+func (x *LearningEvent_ReviewScheduled) GetDifficulty() float64 {
+	if x != nil {
+		return x.Difficulty
+	}
+	return 0
+}
+
+func (x *LearningEvent_ReviewScheduled) GetReps() int32 {
+	if x != nil {
+		return x.Reps
+	}
+	return 0
+}
+
+func (x *LearningEvent_ReviewScheduled) GetLastReviewedAt() string {
+	if x != nil {
+		return x.LastReviewedAt
+	}
+	return ""
+}
+
+// ReviewRemoved 对应 learning.review.removed。
+type LearningEvent_ReviewRemoved struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	VideoId       string                 `protobuf:"bytes,2,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LearningEvent_ReviewRemoved) Reset() {
+	*x = LearningEvent_ReviewRemoved{}
+	mi := &file_api_feed_inbox_v1_learning_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LearningEvent_ReviewRemoved) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LearningEvent_ReviewRemoved) ProtoMessage() {}
+
+func (x *LearningEvent_ReviewRemoved) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_inbox_v1_learning_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LearningEvent_ReviewRemoved.ProtoReflect.Descriptor instead.
+func (*LearningEvent_ReviewRemoved) Descriptor() ([]byte, []int) {
+	return file_api_feed_inbox_v1_learning_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *LearningEvent_ReviewRemoved) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LearningEvent_ReviewRemoved) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+var File_api_feed_inbox_v1_learning_proto protoreflect.FileDescriptor
+
+const file_api_feed_inbox_v1_learning_proto_rawDesc = "" +
+	"\n" +
+	" api/feed/inbox/v1/learning.proto\x12\rfeed.inbox.v1\"\xcb\x05\n" +
+	"\rLearningEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12?\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\x0e2 .feed.inbox.v1.LearningEventTypeR\teventType\x12!\n" +
+	"\faggregate_id\x18\x03 \x01(\tR\vaggregateId\x12%\n" +
+	"\x0eaggregate_type\x18\x04 \x01(\tR\raggregateType\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\x12\x1f\n" +
+	"\voccurred_at\x18\x06 \x01(\tR\n" +
+	"occurredAt\x12Y\n" +
+	"\x10review_scheduled\x18\n" +
+	" \x01(\v2,.feed.inbox.v1.LearningEvent.ReviewScheduledH\x00R\x0freviewScheduled\x12S\n" +
+	"\x0ereview_removed\x18\v \x01(\v2*.feed.inbox.v1.LearningEvent.ReviewRemovedH\x00R\rreviewRemoved\x1a\xd8\x01\n" +
+	"\x0fReviewScheduled\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\bvideo_id\x18\x02 \x01(\tR\avideoId\x12\x15\n" +
+	"\x06due_at\x18\x03 \x01(\tR\x05dueAt\x12\x1c\n" +
+	"\tstability\x18\x04 \x01(\x01R\tstability\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x05 \x01(\x01R\n" +
+	"difficulty\x12\x12\n" +
+	"\x04reps\x18\x06 \x01(\x05R\x04reps\x12(\n" +
+	"\x10last_reviewed_at\x18\a \x01(\tR\x0elastReviewedAt\x1aC\n" +
+	"\rReviewRemoved\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\bvideo_id\x18\x02 \x01(\tR\avideoIdB\t\n" +
+	"\apayload*\x8a\x01\n" +
+	"\x11LearningEventType\x12#\n" +
+	"\x1fLEARNING_EVENT_TYPE_UNSPECIFIED\x10\x00\x12(\n" +
+	"$LEARNING_EVENT_TYPE_REVIEW_SCHEDULED\x10\x01\x12&\n" +
+	"\"LEARNING_EVENT_TYPE_REVIEW_REMOVED\x10\x02BFZDgithub.com/bionicotaku/lingo-services-feed/api/feed/inbox/v1;inboxv1b\x06proto3"
+
+var (
+	file_api_feed_inbox_v1_learning_proto_rawDescOnce sync.Once
+	file_api_feed_inbox_v1_learning_proto_rawDescData []byte
+)
+
+func file_api_feed_inbox_v1_learning_proto_rawDescGZIP() []byte {
+	file_api_feed_inbox_v1_learning_proto_rawDescOnce.Do(func() {
+		file_api_feed_inbox_v1_learning_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_feed_inbox_v1_learning_proto_rawDesc), len(file_api_feed_inbox_v1_learning_proto_rawDesc)))
+	})
+	return file_api_feed_inbox_v1_learning_proto_rawDescData
+}
+
+var file_api_feed_inbox_v1_learning_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_feed_inbox_v1_learning_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_api_feed_inbox_v1_learning_proto_goTypes = []any{
+	(LearningEventType)(0),                // 0: feed.inbox.v1.LearningEventType
+	(*LearningEvent)(nil),                 // 1: feed.inbox.v1.LearningEvent
+	(*LearningEvent_ReviewScheduled)(nil), // 2: feed.inbox.v1.LearningEvent.ReviewScheduled
+	(*LearningEvent_ReviewRemoved)(nil),   // 3: feed.inbox.v1.LearningEvent.ReviewRemoved
+}
+var file_api_feed_inbox_v1_learning_proto_depIdxs = []int32{
+	0, // 0: feed.inbox.v1.LearningEvent.event_type:type_name -> feed.inbox.v1.LearningEventType
+	2, // 1: feed.inbox.v1.LearningEvent.review_scheduled:type_name -> feed.inbox.v1.LearningEvent.ReviewScheduled
+	3, // 2: feed.inbox.v1.LearningEvent.review_removed:type_name -> feed.inbox.v1.LearningEvent.ReviewRemoved
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_api_feed_inbox_v1_learning_proto_init() }
+func file_api_feed_inbox_v1_learning_proto_init() {
+	if File_api_feed_inbox_v1_learning_proto != nil {
+		return
+	}
+	file_api_feed_inbox_v1_learning_proto_msgTypes[0].OneofWrappers = []any{
+		(*LearningEvent_ReviewScheduled_)(nil),
+		(*LearningEvent_ReviewRemoved_)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_feed_inbox_v1_learning_proto_rawDesc), len(file_api_feed_inbox_v1_learning_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_feed_inbox_v1_learning_proto_goTypes,
+		DependencyIndexes: file_api_feed_inbox_v1_learning_proto_depIdxs,
+		EnumInfos:         file_api_feed_inbox_v1_learning_proto_enumTypes,
+		MessageInfos:      file_api_feed_inbox_v1_learning_proto_msgTypes,
+	}.Build()
+	File_api_feed_inbox_v1_learning_proto = out.File
+	file_api_feed_inbox_v1_learning_proto_goTypes = nil
+	file_api_feed_inbox_v1_learning_proto_depIdxs = nil
+}
@@ -0,0 +1,511 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: api/feed/inbox/v1/profile.proto
+
+package inboxv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ProfileEventType 为 Profile 事件类型。
+type ProfileEventType int32
+
+const (
+	ProfileEventType_PROFILE_EVENT_TYPE_UNSPECIFIED        ProfileEventType = 0
+	ProfileEventType_PROFILE_EVENT_TYPE_ENGAGEMENT_ADDED   ProfileEventType = 1
+	ProfileEventType_PROFILE_EVENT_TYPE_ENGAGEMENT_REMOVED ProfileEventType = 2
+	ProfileEventType_PROFILE_EVENT_TYPE_WATCH_PROGRESSED   ProfileEventType = 3
+)
+
+// Enum value maps for ProfileEventType.
+var (
+	ProfileEventType_name = map[int32]string{
+		0: "PROFILE_EVENT_TYPE_UNSPECIFIED",
+		1: "PROFILE_EVENT_TYPE_ENGAGEMENT_ADDED",
+		2: "PROFILE_EVENT_TYPE_ENGAGEMENT_REMOVED",
+		3: "PROFILE_EVENT_TYPE_WATCH_PROGRESSED",
+	}
+	ProfileEventType_value = map[string]int32{
+		"PROFILE_EVENT_TYPE_UNSPECIFIED":        0,
+		"PROFILE_EVENT_TYPE_ENGAGEMENT_ADDED":   1,
+		"PROFILE_EVENT_TYPE_ENGAGEMENT_REMOVED": 2,
+		"PROFILE_EVENT_TYPE_WATCH_PROGRESSED":   3,
+	}
+)
+
+func (x ProfileEventType) Enum() *ProfileEventType {
+	p := new(ProfileEventType)
+	*p = x
+	return p
+}
+
+func (x ProfileEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProfileEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_feed_inbox_v1_profile_proto_enumTypes[0].Descriptor()
+}
+
+func (ProfileEventType) Type() protoreflect.EnumType {
+	return &file_api_feed_inbox_v1_profile_proto_enumTypes[0]
+}
+
+func (x ProfileEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProfileEventType.Descriptor instead.
+func (ProfileEventType) EnumDescriptor() ([]byte, []int) {
+	return file_api_feed_inbox_v1_profile_proto_rawDescGZIP(), []int{0}
+}
+
+// EngagementType 为互动类型。
+type EngagementType int32
+
+const (
+	EngagementType_ENGAGEMENT_TYPE_UNSPECIFIED EngagementType = 0
+	EngagementType_ENGAGEMENT_TYPE_LIKE        EngagementType = 1
+	EngagementType_ENGAGEMENT_TYPE_BOOKMARK    EngagementType = 2
+)
+
+// Enum value maps for EngagementType.
+var (
+	EngagementType_name = map[int32]string{
+		0: "ENGAGEMENT_TYPE_UNSPECIFIED",
+		1: "ENGAGEMENT_TYPE_LIKE",
+		2: "ENGAGEMENT_TYPE_BOOKMARK",
+	}
+	EngagementType_value = map[string]int32{
+		"ENGAGEMENT_TYPE_UNSPECIFIED": 0,
+		"ENGAGEMENT_TYPE_LIKE":        1,
+		"ENGAGEMENT_TYPE_BOOKMARK":    2,
+	}
+)
+
+func (x EngagementType) Enum() *EngagementType {
+	p := new(EngagementType)
+	*p = x
+	return p
+}
+
+func (x EngagementType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EngagementType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_feed_inbox_v1_profile_proto_enumTypes[1].Descriptor()
+}
+
+func (EngagementType) Type() protoreflect.EnumType {
+	return &file_api_feed_inbox_v1_profile_proto_enumTypes[1]
+}
+
+func (x EngagementType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EngagementType.Descriptor instead.
+func (EngagementType) EnumDescriptor() ([]byte, []int) {
+	return file_api_feed_inbox_v1_profile_proto_rawDescGZIP(), []int{1}
+}
+
+// ProfileEvent 为 Profile Outbox 事件的统一信封。
+type ProfileEvent struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	EventId   string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType ProfileEventType       `protobuf:"varint,2,opt,name=event_type,json=eventType,proto3,enum=feed.inbox.v1.ProfileEventType" json:"event_type,omitempty"`
+	// 聚合根为 (user_id, video_id)，取值形如 "<user_id>:<video_id>"。
+	AggregateId   string `protobuf:"bytes,3,opt,name=aggregate_id,json=aggregateId,proto3" json:"aggregate_id,omitempty"`
+	AggregateType string `protobuf:"bytes,4,opt,name=aggregate_type,json=aggregateType,proto3" json:"aggregate_type,omitempty"`
+	// 同一聚合根、同一状态维度内单调递增的版本号。
+	Version int64 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	// RFC3339 时间戳。
+	OccurredAt string `protobuf:"bytes,6,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ProfileEvent_Engagement
+	//	*ProfileEvent_WatchProgressed_
+	Payload       isProfileEvent_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfileEvent) Reset() {
+	*x = ProfileEvent{}
+	mi := &file_api_feed_inbox_v1_profile_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileEvent) ProtoMessage() {}
+
+func (x *ProfileEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_inbox_v1_profile_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileEvent.ProtoReflect.Descriptor instead.
+func (*ProfileEvent) Descriptor() ([]byte, []int) {
+	return file_api_feed_inbox_v1_profile_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProfileEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *ProfileEvent) GetEventType() ProfileEventType {
+	if x != nil {
+		return x.EventType
+	}
+	return ProfileEventType_PROFILE_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *ProfileEvent) GetAggregateId() string {
+	if x != nil {
+		return x.AggregateId
+	}
+	return ""
+}
+
+func (x *ProfileEvent) GetAggregateType() string {
+	if x != nil {
+		return x.AggregateType
+	}
+	return ""
+}
+
+func (x *ProfileEvent) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ProfileEvent) GetOccurredAt() string {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return ""
+}
+
+func (x *ProfileEvent) GetPayload() isProfileEvent_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ProfileEvent) GetEngagement() *ProfileEvent_EngagementChanged {
+	if x != nil {
+		if x, ok := x.Payload.(*ProfileEvent_Engagement); ok {
+			return x.Engagement
+		}
+	}
+	return nil
+}
+
+func (x *ProfileEvent) GetWatchProgressed() *ProfileEvent_WatchProgressed {
+	if x != nil {
+		if x, ok := x.Payload.(*ProfileEvent_WatchProgressed_); ok {
+			return x.WatchProgressed
+		}
+	}
+	return nil
+}
+
+type isProfileEvent_Payload interface {
+	isProfileEvent_Payload()
+}
+
+type ProfileEvent_Engagement struct {
+	Engagement *ProfileEvent_EngagementChanged `protobuf:"bytes,10,opt,name=engagement,proto3,oneof"`
+}
+
+type ProfileEvent_WatchProgressed_ struct {
+	WatchProgressed *ProfileEvent_WatchProgressed `protobuf:"bytes,11,opt,name=watch_progressed,json=watchProgressed,proto3,oneof"`
+}
+
+func (*ProfileEvent_Engagement) isProfileEvent_Payload() {}
+
+func (*ProfileEvent_WatchProgressed_) isProfileEvent_Payload() {}
+
+// EngagementChanged 对应 profile.engagement.added / profile.engagement.removed。
+type ProfileEvent_EngagementChanged struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	VideoId        string                 `protobuf:"bytes,2,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	EngagementType EngagementType         `protobuf:"varint,3,opt,name=engagement_type,json=engagementType,proto3,enum=feed.inbox.v1.EngagementType" json:"engagement_type,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ProfileEvent_EngagementChanged) Reset() {
+	*x = ProfileEvent_EngagementChanged{}
+	mi := &file_api_feed_inbox_v1_profile_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileEvent_EngagementChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileEvent_EngagementChanged) ProtoMessage() {}
+
+func (x *ProfileEvent_EngagementChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_inbox_v1_profile_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileEvent_EngagementChanged.ProtoReflect.Descriptor instead.
+func (*ProfileEvent_EngagementChanged) Descriptor() ([]byte, []int) {
+	return file_api_feed_inbox_v1_profile_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *ProfileEvent_EngagementChanged) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ProfileEvent_EngagementChanged) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *ProfileEvent_EngagementChanged) GetEngagementType() EngagementType {
+	if x != nil {
+		return x.EngagementType
+	}
+	return EngagementType_ENGAGEMENT_TYPE_UNSPECIFIED
+}
+
+// WatchProgressed 对应 profile.watch.progressed。
+type ProfileEvent_WatchProgressed struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	UserId  string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	VideoId string                 `protobuf:"bytes,2,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	// 当前播放位置与视频总时长（微秒）。
+	PositionMicros int64 `protobuf:"varint,3,opt,name=position_micros,json=positionMicros,proto3" json:"position_micros,omitempty"`
+	DurationMicros int64 `protobuf:"varint,4,opt,name=duration_micros,json=durationMicros,proto3" json:"duration_micros,omitempty"`
+	// 发布端计算的观看进度 [0, 1]；为 0 时由 position/duration 推算。
+	ProgressRatio float64 `protobuf:"fixed64,5,opt,name=progress_ratio,json=progressRatio,proto3" json:"progress_ratio,omitempty"`
+	// RFC3339 时间戳，为空时取 occurred_at。
+	LastWatchedAt string `protobuf:"bytes,6,opt,name=last_watched_at,json=lastWatchedAt,proto3" json:"last_watched_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfileEvent_WatchProgressed) Reset() {
+	*x = ProfileEvent_WatchProgressed{}
+	mi := &file_api_feed_inbox_v1_profile_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileEvent_WatchProgressed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileEvent_WatchProgressed) ProtoMessage() {}
+
+func (x *ProfileEvent_WatchProgressed) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_inbox_v1_profile_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileEvent_WatchProgressed.ProtoReflect.Descriptor instead.
+func (*ProfileEvent_WatchProgressed) Descriptor() ([]byte, []int) {
+	return file_api_feed_inbox_v1_profile_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *ProfileEvent_WatchProgressed) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ProfileEvent_WatchProgressed) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *ProfileEvent_WatchProgressed) GetPositionMicros() int64 {
+	if x != nil {
+		return x.PositionMicros
+	}
+	return 0
+}
+
+func (x *ProfileEvent_WatchProgressed) GetDurationMicros() int64 {
+	if x != nil {
+		return x.DurationMicros
+	}
+	return 0
+}
+
+func (x *ProfileEvent_WatchProgressed) GetProgressRatio() float64 {
+	if x != nil {
+		return x.ProgressRatio
+	}
+	return 0
+}
+
+func (x *ProfileEvent_WatchProgressed) GetLastWatchedAt() string {
+	if x != nil {
+		return x.LastWatchedAt
+	}
+	return ""
+}
+
+var File_api_feed_inbox_v1_profile_proto protoreflect.FileDescriptor
+
+const file_api_feed_inbox_v1_profile_proto_rawDesc = "" +
+	"\n" +
+	"\x1fapi/feed/inbox/v1/profile.proto\x12\rfeed.inbox.v1\"\x9f\x06\n" +
+	"\fProfileEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12>\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\x0e2\x1f.feed.inbox.v1.ProfileEventTypeR\teventType\x12!\n" +
+	"\faggregate_id\x18\x03 \x01(\tR\vaggregateId\x12%\n" +
+	"\x0eaggregate_type\x18\x04 \x01(\tR\raggregateType\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\x12\x1f\n" +
+	"\voccurred_at\x18\x06 \x01(\tR\n" +
+	"occurredAt\x12O\n" +
+	"\n" +
+	"engagement\x18\n" +
+	" \x01(\v2-.feed.inbox.v1.ProfileEvent.EngagementChangedH\x00R\n" +
+	"engagement\x12X\n" +
+	"\x10watch_progressed\x18\v \x01(\v2+.feed.inbox.v1.ProfileEvent.WatchProgressedH\x00R\x0fwatchProgressed\x1a\x8f\x01\n" +
+	"\x11EngagementChanged\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\bvideo_id\x18\x02 \x01(\tR\avideoId\x12F\n" +
+	"\x0fengagement_type\x18\x03 \x01(\x0e2\x1d.feed.inbox.v1.EngagementTypeR\x0eengagementType\x1a\xe6\x01\n" +
+	"\x0fWatchProgressed\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\bvideo_id\x18\x02 \x01(\tR\avideoId\x12'\n" +
+	"\x0fposition_micros\x18\x03 \x01(\x03R\x0epositionMicros\x12'\n" +
+	"\x0fduration_micros\x18\x04 \x01(\x03R\x0edurationMicros\x12%\n" +
+	"\x0eprogress_ratio\x18\x05 \x01(\x01R\rprogressRatio\x12&\n" +
+	"\x0flast_watched_at\x18\x06 \x01(\tR\rlastWatchedAtB\t\n" +
+	"\apayload*\xb3\x01\n" +
+	"\x10ProfileEventType\x12\"\n" +
+	"\x1ePROFILE_EVENT_TYPE_UNSPECIFIED\x10\x00\x12'\n" +
+	"#PROFILE_EVENT_TYPE_ENGAGEMENT_ADDED\x10\x01\x12)\n" +
+	"%PROFILE_EVENT_TYPE_ENGAGEMENT_REMOVED\x10\x02\x12'\n" +
+	"#PROFILE_EVENT_TYPE_WATCH_PROGRESSED\x10\x03*i\n" +
+	"\x0eEngagementType\x12\x1f\n" +
+	"\x1bENGAGEMENT_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14ENGAGEMENT_TYPE_LIKE\x10\x01\x12\x1c\n" +
+	"\x18ENGAGEMENT_TYPE_BOOKMARK\x10\x02BFZDgithub.com/bionicotaku/lingo-services-feed/api/feed/inbox/v1;inboxv1b\x06proto3"
+
+var (
+	file_api_feed_inbox_v1_profile_proto_rawDescOnce sync.Once
+	file_api_feed_inbox_v1_profile_proto_rawDescData []byte
+)
+
+func file_api_feed_inbox_v1_profile_proto_rawDescGZIP() []byte {
+	file_api_feed_inbox_v1_profile_proto_rawDescOnce.Do(func() {
+		file_api_feed_inbox_v1_profile_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_feed_inbox_v1_profile_proto_rawDesc), len(file_api_feed_inbox_v1_profile_proto_rawDesc)))
+	})
+	return file_api_feed_inbox_v1_profile_proto_rawDescData
+}
+
+var file_api_feed_inbox_v1_profile_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_api_feed_inbox_v1_profile_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_api_feed_inbox_v1_profile_proto_goTypes = []any{
+	(ProfileEventType)(0),                  // 0: feed.inbox.v1.ProfileEventType
+	(EngagementType)(0),                    // 1: feed.inbox.v1.EngagementType
+	(*ProfileEvent)(nil),                   // 2: feed.inbox.v1.ProfileEvent
+	(*ProfileEvent_EngagementChanged)(nil), // 3: feed.inbox.v1.ProfileEvent.EngagementChanged
+	(*ProfileEvent_WatchProgressed)(nil),   // 4: feed.inbox.v1.ProfileEvent.WatchProgressed
+}
+var file_api_feed_inbox_v1_profile_proto_depIdxs = []int32{
+	0, // 0: feed.inbox.v1.ProfileEvent.event_type:type_name -> feed.inbox.v1.ProfileEventType
+	3, // 1: feed.inbox.v1.ProfileEvent.engagement:type_name -> feed.inbox.v1.ProfileEvent.EngagementChanged
+	4, // 2: feed.inbox.v1.ProfileEvent.watch_progressed:type_name -> feed.inbox.v1.ProfileEvent.WatchProgressed
+	1, // 3: feed.inbox.v1.ProfileEvent.EngagementChanged.engagement_type:type_name -> feed.inbox.v1.EngagementType
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_api_feed_inbox_v1_profile_proto_init() }
+func file_api_feed_inbox_v1_profile_proto_init() {
+	if File_api_feed_inbox_v1_profile_proto != nil {
+		return
+	}
+	file_api_feed_inbox_v1_profile_proto_msgTypes[0].OneofWrappers = []any{
+		(*ProfileEvent_Engagement)(nil),
+		(*ProfileEvent_WatchProgressed_)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_feed_inbox_v1_profile_proto_rawDesc), len(file_api_feed_inbox_v1_profile_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_feed_inbox_v1_profile_proto_goTypes,
+		DependencyIndexes: file_api_feed_inbox_v1_profile_proto_depIdxs,
+		EnumInfos:         file_api_feed_inbox_v1_profile_proto_enumTypes,
+		MessageInfos:      file_api_feed_inbox_v1_profile_proto_msgTypes,
+	}.Build()
+	File_api_feed_inbox_v1_profile_proto = out.File
+	file_api_feed_inbox_v1_profile_proto_goTypes = nil
+	file_api_feed_inbox_v1_profile_proto_depIdxs = nil
+}
@@ -8,6 +8,7 @@ package feedv1
 
 import (
 	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
@@ -23,11 +24,127 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// InteractionType 为交互事件类型。
+type InteractionType int32
+
+const (
+	InteractionType_INTERACTION_TYPE_UNSPECIFIED InteractionType = 0
+	// 卡片曝光。
+	InteractionType_INTERACTION_TYPE_IMPRESSION InteractionType = 1
+	// 卡片点击。
+	InteractionType_INTERACTION_TYPE_CLICK InteractionType = 2
+	// 整页刷新（下拉刷新/换一批），不关联具体视频。
+	InteractionType_INTERACTION_TYPE_REFRESH InteractionType = 3
+)
+
+// Enum value maps for InteractionType.
+var (
+	InteractionType_name = map[int32]string{
+		0: "INTERACTION_TYPE_UNSPECIFIED",
+		1: "INTERACTION_TYPE_IMPRESSION",
+		2: "INTERACTION_TYPE_CLICK",
+		3: "INTERACTION_TYPE_REFRESH",
+	}
+	InteractionType_value = map[string]int32{
+		"INTERACTION_TYPE_UNSPECIFIED": 0,
+		"INTERACTION_TYPE_IMPRESSION":  1,
+		"INTERACTION_TYPE_CLICK":       2,
+		"INTERACTION_TYPE_REFRESH":     3,
+	}
+)
+
+func (x InteractionType) Enum() *InteractionType {
+	p := new(InteractionType)
+	*p = x
+	return p
+}
+
+func (x InteractionType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InteractionType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_feed_v1_feed_proto_enumTypes[0].Descriptor()
+}
+
+func (InteractionType) Type() protoreflect.EnumType {
+	return &file_api_feed_v1_feed_proto_enumTypes[0]
+}
+
+func (x InteractionType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InteractionType.Descriptor instead.
+func (InteractionType) EnumDescriptor() ([]byte, []int) {
+	return file_api_feed_v1_feed_proto_rawDescGZIP(), []int{0}
+}
+
+// InteractionStatus 为单个事件的处理结果。
+type InteractionStatus int32
+
+const (
+	InteractionStatus_INTERACTION_STATUS_UNSPECIFIED InteractionStatus = 0
+	// 已写入 Outbox，等待发布。
+	InteractionStatus_INTERACTION_STATUS_ACCEPTED InteractionStatus = 1
+	// 该 event_id 已写入过，本次忽略。
+	InteractionStatus_INTERACTION_STATUS_DUPLICATE InteractionStatus = 2
+	// 校验未通过，reason 给出原因。
+	InteractionStatus_INTERACTION_STATUS_REJECTED InteractionStatus = 3
+)
+
+// Enum value maps for InteractionStatus.
+var (
+	InteractionStatus_name = map[int32]string{
+		0: "INTERACTION_STATUS_UNSPECIFIED",
+		1: "INTERACTION_STATUS_ACCEPTED",
+		2: "INTERACTION_STATUS_DUPLICATE",
+		3: "INTERACTION_STATUS_REJECTED",
+	}
+	InteractionStatus_value = map[string]int32{
+		"INTERACTION_STATUS_UNSPECIFIED": 0,
+		"INTERACTION_STATUS_ACCEPTED":    1,
+		"INTERACTION_STATUS_DUPLICATE":   2,
+		"INTERACTION_STATUS_REJECTED":    3,
+	}
+)
+
+func (x InteractionStatus) Enum() *InteractionStatus {
+	p := new(InteractionStatus)
+	*p = x
+	return p
+}
+
+func (x InteractionStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InteractionStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_feed_v1_feed_proto_enumTypes[1].Descriptor()
+}
+
+func (InteractionStatus) Type() protoreflect.EnumType {
+	return &file_api_feed_v1_feed_proto_enumTypes[1]
+}
+
+func (x InteractionStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InteractionStatus.Descriptor instead.
+func (InteractionStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_feed_v1_feed_proto_rawDescGZIP(), []int{1}
+}
+
 // GetFeedRequest 描述 Feed 获取请求的参数。
 type GetFeedRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// 请求条目数量，默认 20，最大 100。
-	Limit         int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// 推荐场景，留空为默认首页；continue_learning 返回看到一半的视频。
+	Scene string `protobuf:"bytes,2,opt,name=scene,proto3" json:"scene,omitempty"`
+	// 上一页返回的 next_cursor，留空表示第一页。
+	Cursor        string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -69,6 +186,20 @@ func (x *GetFeedRequest) GetLimit() int32 {
 	return 0
 }
 
+func (x *GetFeedRequest) GetScene() string {
+	if x != nil {
+		return x.Scene
+	}
+	return ""
+}
+
+func (x *GetFeedRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 // GetFeedResponse 返回补水后的推荐卡片以及分页信息。
 type GetFeedResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -81,8 +212,10 @@ type GetFeedResponse struct {
 	GeneratedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
 	// 补水缺失的视频 ID 列表，便于观测定位。
 	MissingProjections []*MissingProjection `protobuf:"bytes,5,rep,name=missing_projections,json=missingProjections,proto3" json:"missing_projections,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	// 本页对应的推荐日志 ID，上报交互事件时回传；为空表示本页未记录日志（被采样跳过）。
+	LogId         string `protobuf:"bytes,6,opt,name=log_id,json=logId,proto3" json:"log_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetFeedResponse) Reset() {
@@ -150,6 +283,13 @@ func (x *GetFeedResponse) GetMissingProjections() []*MissingProjection {
 	return nil
 }
 
+func (x *GetFeedResponse) GetLogId() string {
+	if x != nil {
+		return x.LogId
+	}
+	return ""
+}
+
 // FeedItem 表示返回给终端的单个推荐卡片。
 type FeedItem struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
@@ -165,8 +305,10 @@ type FeedItem struct {
 	VisibilityStatus  string                 `protobuf:"bytes,10,opt,name=visibility_status,json=visibilityStatus,proto3" json:"visibility_status,omitempty"`
 	PublishedAt       *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"`
 	Attributes        map[string]string      `protobuf:"bytes,12,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	// 当前用户在该视频上的互动状态，访客或服务未启用用户状态时不返回。
+	UserState     *UserVideoState `protobuf:"bytes,13,opt,name=user_state,json=userState,proto3" json:"user_state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FeedItem) Reset() {
@@ -283,6 +425,92 @@ func (x *FeedItem) GetAttributes() map[string]string {
 	return nil
 }
 
+func (x *FeedItem) GetUserState() *UserVideoState {
+	if x != nil {
+		return x.UserState
+	}
+	return nil
+}
+
+// UserVideoState 描述用户对视频的点赞、收藏与观看进度。
+type UserVideoState struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Liked      bool                   `protobuf:"varint,1,opt,name=liked,proto3" json:"liked,omitempty"`
+	Bookmarked bool                   `protobuf:"varint,2,opt,name=bookmarked,proto3" json:"bookmarked,omitempty"`
+	// 观看进度 [0, 1]，0 表示未观看。
+	WatchedRatio  float64                `protobuf:"fixed64,3,opt,name=watched_ratio,json=watchedRatio,proto3" json:"watched_ratio,omitempty"`
+	LastWatchedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_watched_at,json=lastWatchedAt,proto3" json:"last_watched_at,omitempty"`
+	// 续播位置（微秒），未上报播放位置时为 0。
+	ResumePositionMicros int64 `protobuf:"varint,5,opt,name=resume_position_micros,json=resumePositionMicros,proto3" json:"resume_position_micros,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *UserVideoState) Reset() {
+	*x = UserVideoState{}
+	mi := &file_api_feed_v1_feed_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserVideoState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserVideoState) ProtoMessage() {}
+
+func (x *UserVideoState) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_v1_feed_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserVideoState.ProtoReflect.Descriptor instead.
+func (*UserVideoState) Descriptor() ([]byte, []int) {
+	return file_api_feed_v1_feed_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UserVideoState) GetLiked() bool {
+	if x != nil {
+		return x.Liked
+	}
+	return false
+}
+
+func (x *UserVideoState) GetBookmarked() bool {
+	if x != nil {
+		return x.Bookmarked
+	}
+	return false
+}
+
+func (x *UserVideoState) GetWatchedRatio() float64 {
+	if x != nil {
+		return x.WatchedRatio
+	}
+	return 0
+}
+
+func (x *UserVideoState) GetLastWatchedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastWatchedAt
+	}
+	return nil
+}
+
+func (x *UserVideoState) GetResumePositionMicros() int64 {
+	if x != nil {
+		return x.ResumePositionMicros
+	}
+	return 0
+}
+
 // MissingProjection 描述未能补水的条目。
 type MissingProjection struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -294,7 +522,7 @@ type MissingProjection struct {
 
 func (x *MissingProjection) Reset() {
 	*x = MissingProjection{}
-	mi := &file_api_feed_v1_feed_proto_msgTypes[3]
+	mi := &file_api_feed_v1_feed_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -306,7 +534,7 @@ func (x *MissingProjection) String() string {
 func (*MissingProjection) ProtoMessage() {}
 
 func (x *MissingProjection) ProtoReflect() protoreflect.Message {
-	mi := &file_api_feed_v1_feed_proto_msgTypes[3]
+	mi := &file_api_feed_v1_feed_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -319,7 +547,7 @@ func (x *MissingProjection) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MissingProjection.ProtoReflect.Descriptor instead.
 func (*MissingProjection) Descriptor() ([]byte, []int) {
-	return file_api_feed_v1_feed_proto_rawDescGZIP(), []int{3}
+	return file_api_feed_v1_feed_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *MissingProjection) GetVideoId() string {
@@ -336,20 +564,274 @@ func (x *MissingProjection) GetReason() string {
 	return ""
 }
 
+// Interaction 为客户端上报的单个交互事件。
+type Interaction struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 客户端生成的事件 ID，重试时保持不变以便去重。
+	EventId string          `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Type    InteractionType `protobuf:"varint,2,opt,name=type,proto3,enum=feed.v1.InteractionType" json:"type,omitempty"`
+	// GetFeedResponse.log_id。
+	LogId string `protobuf:"bytes,3,opt,name=log_id,json=logId,proto3" json:"log_id,omitempty"`
+	// 曝光/点击必填，须出现在该页实际下发的条目中。
+	VideoId string `protobuf:"bytes,4,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	// 卡片位次（从 1 开始），0 表示不校验。
+	Position int32 `protobuf:"varint,5,opt,name=position,proto3" json:"position,omitempty"`
+	// 事件在客户端发生的时间。
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Interaction) Reset() {
+	*x = Interaction{}
+	mi := &file_api_feed_v1_feed_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Interaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Interaction) ProtoMessage() {}
+
+func (x *Interaction) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_v1_feed_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Interaction.ProtoReflect.Descriptor instead.
+func (*Interaction) Descriptor() ([]byte, []int) {
+	return file_api_feed_v1_feed_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Interaction) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *Interaction) GetType() InteractionType {
+	if x != nil {
+		return x.Type
+	}
+	return InteractionType_INTERACTION_TYPE_UNSPECIFIED
+}
+
+func (x *Interaction) GetLogId() string {
+	if x != nil {
+		return x.LogId
+	}
+	return ""
+}
+
+func (x *Interaction) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *Interaction) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *Interaction) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+// ReportInteractionsRequest 为批量上报请求。
+type ReportInteractionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*Interaction         `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportInteractionsRequest) Reset() {
+	*x = ReportInteractionsRequest{}
+	mi := &file_api_feed_v1_feed_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportInteractionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportInteractionsRequest) ProtoMessage() {}
+
+func (x *ReportInteractionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_v1_feed_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportInteractionsRequest.ProtoReflect.Descriptor instead.
+func (*ReportInteractionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_feed_v1_feed_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReportInteractionsRequest) GetEvents() []*Interaction {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// InteractionResult 与请求中的事件一一对应。
+type InteractionResult struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	EventId string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Status  InteractionStatus      `protobuf:"varint,2,opt,name=status,proto3,enum=feed.v1.InteractionStatus" json:"status,omitempty"`
+	// 拒绝原因：log_not_found / log_owner_mismatch / video_not_served / position_mismatch /
+	// occurred_at_out_of_range / duplicate_in_batch / video_id_required。
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InteractionResult) Reset() {
+	*x = InteractionResult{}
+	mi := &file_api_feed_v1_feed_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InteractionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InteractionResult) ProtoMessage() {}
+
+func (x *InteractionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_v1_feed_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InteractionResult.ProtoReflect.Descriptor instead.
+func (*InteractionResult) Descriptor() ([]byte, []int) {
+	return file_api_feed_v1_feed_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *InteractionResult) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *InteractionResult) GetStatus() InteractionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return InteractionStatus_INTERACTION_STATUS_UNSPECIFIED
+}
+
+func (x *InteractionResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// ReportInteractionsResponse 返回逐条处理结果。
+type ReportInteractionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*InteractionResult   `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Accepted      int32                  `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportInteractionsResponse) Reset() {
+	*x = ReportInteractionsResponse{}
+	mi := &file_api_feed_v1_feed_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportInteractionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportInteractionsResponse) ProtoMessage() {}
+
+func (x *ReportInteractionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_feed_v1_feed_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportInteractionsResponse.ProtoReflect.Descriptor instead.
+func (*ReportInteractionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_feed_v1_feed_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReportInteractionsResponse) GetResults() []*InteractionResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ReportInteractionsResponse) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
 var File_api_feed_v1_feed_proto protoreflect.FileDescriptor
 
 const file_api_feed_v1_feed_proto_rawDesc = "" +
 	"\n" +
-	"\x16api/feed/v1/feed.proto\x12\afeed.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bbuf/validate/validate.proto\"1\n" +
+	"\x16api/feed/v1/feed.proto\x12\afeed.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bbuf/validate/validate.proto\"r\n" +
 	"\x0eGetFeedRequest\x12\x1f\n" +
-	"\x05limit\x18\x01 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x01R\x05limit\"\x81\x02\n" +
+	"\x05limit\x18\x01 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x01R\x05limit\x12\x1d\n" +
+	"\x05scene\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x18@R\x05scene\x12 \n" +
+	"\x06cursor\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x02R\x06cursor\"\x98\x02\n" +
 	"\x0fGetFeedResponse\x12'\n" +
 	"\x05items\x18\x01 \x03(\v2\x11.feed.v1.FeedItemR\x05items\x12\x1f\n" +
 	"\vnext_cursor\x18\x02 \x01(\tR\n" +
 	"nextCursor\x12\x18\n" +
 	"\apartial\x18\x03 \x01(\bR\apartial\x12=\n" +
 	"\fgenerated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\x12K\n" +
-	"\x13missing_projections\x18\x05 \x03(\v2\x1a.feed.v1.MissingProjectionR\x12missingProjections\"\xac\x04\n" +
+	"\x13missing_projections\x18\x05 \x03(\v2\x1a.feed.v1.MissingProjectionR\x12missingProjections\x12\x15\n" +
+	"\x06log_id\x18\x06 \x01(\tR\x05logId\"\xe4\x04\n" +
 	"\bFeedItem\x12\"\n" +
 	"\bvideo_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\avideoId\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -366,15 +848,54 @@ const file_api_feed_v1_feed_proto_rawDesc = "" +
 	"\fpublished_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vpublishedAt\x12A\n" +
 	"\n" +
 	"attributes\x18\f \x03(\v2!.feed.v1.FeedItem.AttributesEntryR\n" +
-	"attributes\x1a=\n" +
+	"attributes\x126\n" +
+	"\n" +
+	"user_state\x18\r \x01(\v2\x17.feed.v1.UserVideoStateR\tuserState\x1a=\n" +
 	"\x0fAttributesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"F\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe5\x01\n" +
+	"\x0eUserVideoState\x12\x14\n" +
+	"\x05liked\x18\x01 \x01(\bR\x05liked\x12\x1e\n" +
+	"\n" +
+	"bookmarked\x18\x02 \x01(\bR\n" +
+	"bookmarked\x12#\n" +
+	"\rwatched_ratio\x18\x03 \x01(\x01R\fwatchedRatio\x12B\n" +
+	"\x0flast_watched_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rlastWatchedAt\x124\n" +
+	"\x16resume_position_micros\x18\x05 \x01(\x03R\x14resumePositionMicros\"F\n" +
 	"\x11MissingProjection\x12\x19\n" +
 	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x16\n" +
-	"\x06reason\x18\x02 \x01(\tR\x06reason2K\n" +
-	"\vFeedService\x12<\n" +
-	"\aGetFeed\x12\x17.feed.v1.GetFeedRequest\x1a\x18.feed.v1.GetFeedResponseB?Z=github.com/bionicotaku/lingo-services-feed/api/feed/v1;feedv1b\x06proto3"
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x92\x02\n" +
+	"\vInteraction\x12#\n" +
+	"\bevent_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\aeventId\x128\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x18.feed.v1.InteractionTypeB\n" +
+	"\xbaH\a\x82\x01\x04\x10\x01 \x00R\x04type\x12\x1f\n" +
+	"\x06log_id\x18\x03 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\x05logId\x12\x19\n" +
+	"\bvideo_id\x18\x04 \x01(\tR\avideoId\x12#\n" +
+	"\bposition\x18\x05 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bposition\x12C\n" +
+	"\voccurred_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampB\x06\xbaH\x03\xc8\x01\x01R\n" +
+	"occurredAt\"V\n" +
+	"\x19ReportInteractionsRequest\x129\n" +
+	"\x06events\x18\x01 \x03(\v2\x14.feed.v1.InteractionB\v\xbaH\b\x92\x01\x05\b\x01\x10\xf4\x03R\x06events\"z\n" +
+	"\x11InteractionResult\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x122\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1a.feed.v1.InteractionStatusR\x06status\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"n\n" +
+	"\x1aReportInteractionsResponse\x124\n" +
+	"\aresults\x18\x01 \x03(\v2\x1a.feed.v1.InteractionResultR\aresults\x12\x1a\n" +
+	"\baccepted\x18\x02 \x01(\x05R\baccepted*\x8e\x01\n" +
+	"\x0fInteractionType\x12 \n" +
+	"\x1cINTERACTION_TYPE_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bINTERACTION_TYPE_IMPRESSION\x10\x01\x12\x1a\n" +
+	"\x16INTERACTION_TYPE_CLICK\x10\x02\x12\x1c\n" +
+	"\x18INTERACTION_TYPE_REFRESH\x10\x03*\x9b\x01\n" +
+	"\x11InteractionStatus\x12\"\n" +
+	"\x1eINTERACTION_STATUS_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bINTERACTION_STATUS_ACCEPTED\x10\x01\x12 \n" +
+	"\x1cINTERACTION_STATUS_DUPLICATE\x10\x02\x12\x1f\n" +
+	"\x1bINTERACTION_STATUS_REJECTED\x10\x032\xe7\x01\n" +
+	"\vFeedService\x12R\n" +
+	"\aGetFeed\x12\x17.feed.v1.GetFeedRequest\x1a\x18.feed.v1.GetFeedResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/api/v1/feed\x12\x83\x01\n" +
+	"\x12ReportInteractions\x12\".feed.v1.ReportInteractionsRequest\x1a#.feed.v1.ReportInteractionsResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/api/v1/feed/interactionsB?Z=github.com/bionicotaku/lingo-services-feed/api/feed/v1;feedv1b\x06proto3"
 
 var (
 	file_api_feed_v1_feed_proto_rawDescOnce sync.Once
@@ -388,28 +909,45 @@ func file_api_feed_v1_feed_proto_rawDescGZIP() []byte {
 	return file_api_feed_v1_feed_proto_rawDescData
 }
 
-var file_api_feed_v1_feed_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_api_feed_v1_feed_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_api_feed_v1_feed_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_api_feed_v1_feed_proto_goTypes = []any{
-	(*GetFeedRequest)(nil),        // 0: feed.v1.GetFeedRequest
-	(*GetFeedResponse)(nil),       // 1: feed.v1.GetFeedResponse
-	(*FeedItem)(nil),              // 2: feed.v1.FeedItem
-	(*MissingProjection)(nil),     // 3: feed.v1.MissingProjection
-	nil,                           // 4: feed.v1.FeedItem.AttributesEntry
-	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(InteractionType)(0),               // 0: feed.v1.InteractionType
+	(InteractionStatus)(0),             // 1: feed.v1.InteractionStatus
+	(*GetFeedRequest)(nil),             // 2: feed.v1.GetFeedRequest
+	(*GetFeedResponse)(nil),            // 3: feed.v1.GetFeedResponse
+	(*FeedItem)(nil),                   // 4: feed.v1.FeedItem
+	(*UserVideoState)(nil),             // 5: feed.v1.UserVideoState
+	(*MissingProjection)(nil),          // 6: feed.v1.MissingProjection
+	(*Interaction)(nil),                // 7: feed.v1.Interaction
+	(*ReportInteractionsRequest)(nil),  // 8: feed.v1.ReportInteractionsRequest
+	(*InteractionResult)(nil),          // 9: feed.v1.InteractionResult
+	(*ReportInteractionsResponse)(nil), // 10: feed.v1.ReportInteractionsResponse
+	nil,                                // 11: feed.v1.FeedItem.AttributesEntry
+	(*timestamppb.Timestamp)(nil),      // 12: google.protobuf.Timestamp
 }
 var file_api_feed_v1_feed_proto_depIdxs = []int32{
-	2, // 0: feed.v1.GetFeedResponse.items:type_name -> feed.v1.FeedItem
-	5, // 1: feed.v1.GetFeedResponse.generated_at:type_name -> google.protobuf.Timestamp
-	3, // 2: feed.v1.GetFeedResponse.missing_projections:type_name -> feed.v1.MissingProjection
-	5, // 3: feed.v1.FeedItem.published_at:type_name -> google.protobuf.Timestamp
-	4, // 4: feed.v1.FeedItem.attributes:type_name -> feed.v1.FeedItem.AttributesEntry
-	0, // 5: feed.v1.FeedService.GetFeed:input_type -> feed.v1.GetFeedRequest
-	1, // 6: feed.v1.FeedService.GetFeed:output_type -> feed.v1.GetFeedResponse
-	6, // [6:7] is the sub-list for method output_type
-	5, // [5:6] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	4,  // 0: feed.v1.GetFeedResponse.items:type_name -> feed.v1.FeedItem
+	12, // 1: feed.v1.GetFeedResponse.generated_at:type_name -> google.protobuf.Timestamp
+	6,  // 2: feed.v1.GetFeedResponse.missing_projections:type_name -> feed.v1.MissingProjection
+	12, // 3: feed.v1.FeedItem.published_at:type_name -> google.protobuf.Timestamp
+	11, // 4: feed.v1.FeedItem.attributes:type_name -> feed.v1.FeedItem.AttributesEntry
+	5,  // 5: feed.v1.FeedItem.user_state:type_name -> feed.v1.UserVideoState
+	12, // 6: feed.v1.UserVideoState.last_watched_at:type_name -> google.protobuf.Timestamp
+	0,  // 7: feed.v1.Interaction.type:type_name -> feed.v1.InteractionType
+	12, // 8: feed.v1.Interaction.occurred_at:type_name -> google.protobuf.Timestamp
+	7,  // 9: feed.v1.ReportInteractionsRequest.events:type_name -> feed.v1.Interaction
+	1,  // 10: feed.v1.InteractionResult.status:type_name -> feed.v1.InteractionStatus
+	9,  // 11: feed.v1.ReportInteractionsResponse.results:type_name -> feed.v1.InteractionResult
+	2,  // 12: feed.v1.FeedService.GetFeed:input_type -> feed.v1.GetFeedRequest
+	8,  // 13: feed.v1.FeedService.ReportInteractions:input_type -> feed.v1.ReportInteractionsRequest
+	3,  // 14: feed.v1.FeedService.GetFeed:output_type -> feed.v1.GetFeedResponse
+	10, // 15: feed.v1.FeedService.ReportInteractions:output_type -> feed.v1.ReportInteractionsResponse
+	14, // [14:16] is the sub-list for method output_type
+	12, // [12:14] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_api_feed_v1_feed_proto_init() }
@@ -422,13 +960,14 @@ func file_api_feed_v1_feed_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_feed_v1_feed_proto_rawDesc), len(file_api_feed_v1_feed_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      2,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_feed_v1_feed_proto_goTypes,
 		DependencyIndexes: file_api_feed_v1_feed_proto_depIdxs,
+		EnumInfos:         file_api_feed_v1_feed_proto_enumTypes,
 		MessageInfos:      file_api_feed_v1_feed_proto_msgTypes,
 	}.Build()
 	File_api_feed_v1_feed_proto = out.File
@@ -19,17 +19,20 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	FeedService_GetFeed_FullMethodName = "/feed.v1.FeedService/GetFeed"
+	FeedService_GetFeed_FullMethodName            = "/feed.v1.FeedService/GetFeed"
+	FeedService_ReportInteractions_FullMethodName = "/feed.v1.FeedService/ReportInteractions"
 )
 
 // FeedServiceClient is the client API for FeedService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 //
-// FeedService 提供终端 Feed 推荐结果，所有接口仅通过 gRPC 暴露。
+// FeedService 提供终端 Feed 推荐结果，通过 gRPC 暴露，并按 google.api.http 注解映射为 HTTP/JSON。
 type FeedServiceClient interface {
 	// GetFeed 返回针对指定用户/场景的推荐条目。
 	GetFeed(ctx context.Context, in *GetFeedRequest, opts ...grpc.CallOption) (*GetFeedResponse, error)
+	// ReportInteractions 批量上报曝光/点击/刷新事件；事件须对应已下发并记录日志的推荐页。
+	ReportInteractions(ctx context.Context, in *ReportInteractionsRequest, opts ...grpc.CallOption) (*ReportInteractionsResponse, error)
 }
 
 type feedServiceClient struct {
@@ -50,14 +53,26 @@ func (c *feedServiceClient) GetFeed(ctx context.Context, in *GetFeedRequest, opt
 	return out, nil
 }
 
+func (c *feedServiceClient) ReportInteractions(ctx context.Context, in *ReportInteractionsRequest, opts ...grpc.CallOption) (*ReportInteractionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportInteractionsResponse)
+	err := c.cc.Invoke(ctx, FeedService_ReportInteractions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FeedServiceServer is the server API for FeedService service.
 // All implementations must embed UnimplementedFeedServiceServer
 // for forward compatibility.
 //
-// FeedService 提供终端 Feed 推荐结果，所有接口仅通过 gRPC 暴露。
+// FeedService 提供终端 Feed 推荐结果，通过 gRPC 暴露，并按 google.api.http 注解映射为 HTTP/JSON。
 type FeedServiceServer interface {
 	// GetFeed 返回针对指定用户/场景的推荐条目。
 	GetFeed(context.Context, *GetFeedRequest) (*GetFeedResponse, error)
+	// ReportInteractions 批量上报曝光/点击/刷新事件；事件须对应已下发并记录日志的推荐页。
+	ReportInteractions(context.Context, *ReportInteractionsRequest) (*ReportInteractionsResponse, error)
 	mustEmbedUnimplementedFeedServiceServer()
 }
 
@@ -71,6 +86,9 @@ type UnimplementedFeedServiceServer struct{}
 func (UnimplementedFeedServiceServer) GetFeed(context.Context, *GetFeedRequest) (*GetFeedResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFeed not implemented")
 }
+func (UnimplementedFeedServiceServer) ReportInteractions(context.Context, *ReportInteractionsRequest) (*ReportInteractionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportInteractions not implemented")
+}
 func (UnimplementedFeedServiceServer) mustEmbedUnimplementedFeedServiceServer() {}
 func (UnimplementedFeedServiceServer) testEmbeddedByValue()                     {}
 
@@ -110,6 +128,24 @@ func _FeedService_GetFeed_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FeedService_ReportInteractions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportInteractionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedServiceServer).ReportInteractions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedService_ReportInteractions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedServiceServer).ReportInteractions(ctx, req.(*ReportInteractionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FeedService_ServiceDesc is the grpc.ServiceDesc for FeedService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -121,6 +157,10 @@ var FeedService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetFeed",
 			Handler:    _FeedService_GetFeed_Handler,
 		},
+		{
+			MethodName: "ReportInteractions",
+			Handler:    _FeedService_ReportInteractions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/feed/v1/feed.proto",
@@ -0,0 +1,305 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: api/recommendation/v1/recommendation.proto
+
+package recommendationv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetFeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                                                       // 登录用户 ID，访客请求不调用推荐服务
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                                                                                      // 期望返回条数
+	Scene         string                 `protobuf:"bytes,3,opt,name=scene,proto3" json:"scene,omitempty"`                                                                                       // 推荐场景，空值表示首页
+	Cursor        string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`                                                                                     // 上一页返回的游标
+	Experiments   map[string]string      `protobuf:"bytes,5,rep,name=experiments,proto3" json:"experiments,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 命中的实验分组：experiment → variant
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFeedRequest) Reset() {
+	*x = GetFeedRequest{}
+	mi := &file_api_recommendation_v1_recommendation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFeedRequest) ProtoMessage() {}
+
+func (x *GetFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_recommendation_v1_recommendation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeedRequest.ProtoReflect.Descriptor instead.
+func (*GetFeedRequest) Descriptor() ([]byte, []int) {
+	return file_api_recommendation_v1_recommendation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetFeedRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetFeedRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetFeedRequest) GetScene() string {
+	if x != nil {
+		return x.Scene
+	}
+	return ""
+}
+
+func (x *GetFeedRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetFeedRequest) GetExperiments() map[string]string {
+	if x != nil {
+		return x.Experiments
+	}
+	return nil
+}
+
+type GetFeedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Item                `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // 空值表示没有更多数据
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFeedResponse) Reset() {
+	*x = GetFeedResponse{}
+	mi := &file_api_recommendation_v1_recommendation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFeedResponse) ProtoMessage() {}
+
+func (x *GetFeedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_recommendation_v1_recommendation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeedResponse.ProtoReflect.Descriptor instead.
+func (*GetFeedResponse) Descriptor() ([]byte, []int) {
+	return file_api_recommendation_v1_recommendation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetFeedResponse) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *GetFeedResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type Item struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	ReasonCode    string                 `protobuf:"bytes,2,opt,name=reason_code,json=reasonCode,proto3" json:"reason_code,omitempty"` // 推荐理由，例如 cf.similar_users
+	Score         float64                `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 透传给卡片 attributes 的附加信息
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Item) Reset() {
+	*x = Item{}
+	mi := &file_api_recommendation_v1_recommendation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Item) ProtoMessage() {}
+
+func (x *Item) ProtoReflect() protoreflect.Message {
+	mi := &file_api_recommendation_v1_recommendation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Item.ProtoReflect.Descriptor instead.
+func (*Item) Descriptor() ([]byte, []int) {
+	return file_api_recommendation_v1_recommendation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Item) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *Item) GetReasonCode() string {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return ""
+}
+
+func (x *Item) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Item) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+var File_api_recommendation_v1_recommendation_proto protoreflect.FileDescriptor
+
+const file_api_recommendation_v1_recommendation_proto_rawDesc = "" +
+	"\n" +
+	"*api/recommendation/v1/recommendation.proto\x12\x11recommendation.v1\"\x83\x02\n" +
+	"\x0eGetFeedRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x14\n" +
+	"\x05scene\x18\x03 \x01(\tR\x05scene\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\x12T\n" +
+	"\vexperiments\x18\x05 \x03(\v22.recommendation.v1.GetFeedRequest.ExperimentsEntryR\vexperiments\x1a>\n" +
+	"\x10ExperimentsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"a\n" +
+	"\x0fGetFeedResponse\x12-\n" +
+	"\x05items\x18\x01 \x03(\v2\x17.recommendation.v1.ItemR\x05items\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\xd8\x01\n" +
+	"\x04Item\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1f\n" +
+	"\vreason_code\x18\x02 \x01(\tR\n" +
+	"reasonCode\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\x12A\n" +
+	"\bmetadata\x18\x04 \x03(\v2%.recommendation.v1.Item.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012i\n" +
+	"\x15RecommendationService\x12P\n" +
+	"\aGetFeed\x12!.recommendation.v1.GetFeedRequest\x1a\".recommendation.v1.GetFeedResponseBSZQgithub.com/bionicotaku/lingo-services-feed/api/recommendation/v1;recommendationv1b\x06proto3"
+
+var (
+	file_api_recommendation_v1_recommendation_proto_rawDescOnce sync.Once
+	file_api_recommendation_v1_recommendation_proto_rawDescData []byte
+)
+
+func file_api_recommendation_v1_recommendation_proto_rawDescGZIP() []byte {
+	file_api_recommendation_v1_recommendation_proto_rawDescOnce.Do(func() {
+		file_api_recommendation_v1_recommendation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_recommendation_v1_recommendation_proto_rawDesc), len(file_api_recommendation_v1_recommendation_proto_rawDesc)))
+	})
+	return file_api_recommendation_v1_recommendation_proto_rawDescData
+}
+
+var file_api_recommendation_v1_recommendation_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_api_recommendation_v1_recommendation_proto_goTypes = []any{
+	(*GetFeedRequest)(nil),  // 0: recommendation.v1.GetFeedRequest
+	(*GetFeedResponse)(nil), // 1: recommendation.v1.GetFeedResponse
+	(*Item)(nil),            // 2: recommendation.v1.Item
+	nil,                     // 3: recommendation.v1.GetFeedRequest.ExperimentsEntry
+	nil,                     // 4: recommendation.v1.Item.MetadataEntry
+}
+var file_api_recommendation_v1_recommendation_proto_depIdxs = []int32{
+	3, // 0: recommendation.v1.GetFeedRequest.experiments:type_name -> recommendation.v1.GetFeedRequest.ExperimentsEntry
+	2, // 1: recommendation.v1.GetFeedResponse.items:type_name -> recommendation.v1.Item
+	4, // 2: recommendation.v1.Item.metadata:type_name -> recommendation.v1.Item.MetadataEntry
+	0, // 3: recommendation.v1.RecommendationService.GetFeed:input_type -> recommendation.v1.GetFeedRequest
+	1, // 4: recommendation.v1.RecommendationService.GetFeed:output_type -> recommendation.v1.GetFeedResponse
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_api_recommendation_v1_recommendation_proto_init() }
+func file_api_recommendation_v1_recommendation_proto_init() {
+	if File_api_recommendation_v1_recommendation_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_recommendation_v1_recommendation_proto_rawDesc), len(file_api_recommendation_v1_recommendation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_recommendation_v1_recommendation_proto_goTypes,
+		DependencyIndexes: file_api_recommendation_v1_recommendation_proto_depIdxs,
+		MessageInfos:      file_api_recommendation_v1_recommendation_proto_msgTypes,
+	}.Build()
+	File_api_recommendation_v1_recommendation_proto = out.File
+	file_api_recommendation_v1_recommendation_proto_goTypes = nil
+	file_api_recommendation_v1_recommendation_proto_depIdxs = nil
+}
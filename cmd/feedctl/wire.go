@@ -0,0 +1,36 @@
+//go:build wireinject
+// +build wireinject
+
+// Package main 为 feedctl 运维命令行提供 Wire 依赖注入定义。
+package main
+
+import (
+	"context"
+
+	configloader "github.com/bionicotaku/lingo-services-feed/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	logexport "github.com/bionicotaku/lingo-services-feed/internal/tasks/log_export"
+
+	"github.com/bionicotaku/lingo-utils/gclog"
+	"github.com/bionicotaku/lingo-utils/pgxpoolx"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/wire"
+)
+
+//go:generate go run github.com/google/wire/cmd/wire
+
+func wireLogExporter(context.Context, configloader.Params) (*logExportApp, func(), error) {
+	panic(wire.Build(
+		configloader.ProviderSet,
+		gclog.ProviderSet,
+		pgxpoolx.ProviderSet,
+		repositories.NewFeedRecommendationLogRepository,
+		wire.Bind(new(logexport.LogSource), new(*repositories.FeedRecommendationLogRepository)),
+		logexport.NewExporter,
+		newLogExportApp,
+	))
+}
+
+func newLogExportApp(logger log.Logger, exporter *logexport.Exporter) *logExportApp {
+	return &logExportApp{Exporter: exporter, Logger: logger}
+}
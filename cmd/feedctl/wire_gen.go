@@ -0,0 +1,54 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package main
+
+import (
+	"context"
+	"github.com/bionicotaku/lingo-services-feed/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/bionicotaku/lingo-services-feed/internal/tasks/log_export"
+	"github.com/bionicotaku/lingo-utils/gclog"
+	"github.com/bionicotaku/lingo-utils/pgxpoolx"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// Injectors from wire.go:
+
+func wireLogExporter(contextContext context.Context, params configloader.Params) (*logExportApp, func(), error) {
+	runtimeConfig, err := configloader.LoadRuntimeConfig(params)
+	if err != nil {
+		return nil, nil, err
+	}
+	serviceInfo := configloader.ProvideServiceInfo(runtimeConfig)
+	config := configloader.ProvideLoggerConfig(serviceInfo)
+	component, cleanup, err := gclog.NewComponent(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	logger := gclog.ProvideLogger(component)
+	databaseConfig := configloader.ProvideDatabaseConfig(runtimeConfig)
+	pgxpoolxConfig := configloader.ProvidePgxConfig(databaseConfig)
+	pgxpoolxComponent, cleanup2, err := pgxpoolx.ProvideComponent(contextContext, pgxpoolxConfig, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	pool := pgxpoolx.ProvidePool(pgxpoolxComponent)
+	feedRecommendationLogRepository := repositories.NewFeedRecommendationLogRepository(pool, logger)
+	exporter := logexport.NewExporter(feedRecommendationLogRepository, logger)
+	mainLogExportApp := newLogExportApp(logger, exporter)
+	return mainLogExportApp, func() {
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+// wire.go:
+
+func newLogExportApp(logger log.Logger, exporter *logexport.Exporter) *logExportApp {
+	return &logExportApp{Exporter: exporter, Logger: logger}
+}
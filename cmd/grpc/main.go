@@ -1,5 +1,5 @@
 // Package main 提供 Kratos gRPC 服务的启动入口。
-// 负责加载配置、初始化依赖（通过 Wire）、启动 gRPC Server 并优雅关闭。
+// 负责加载配置、初始化依赖（通过 Wire）、启动 gRPC 与 HTTP/JSON Server 并优雅关闭。
 package main
 
 import (
@@ -7,10 +7,13 @@ import (
 	"flag"
 
 	configloader "github.com/bionicotaku/lingo-services-feed/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-feed/internal/services"
 	obswire "github.com/bionicotaku/lingo-utils/observability"
 	"github.com/go-kratos/kratos/v2"
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
+	"github.com/go-kratos/kratos/v2/transport/http"
 
 	_ "go.uber.org/automaxprocs" // 自动设置 GOMAXPROCS 为容器 CPU 配额
 )
@@ -21,6 +24,7 @@ import (
 //   - obsCmp: 可观测性组件（Tracer/Meter Provider），Wire 自动管理生命周期
 //   - logger: 结构化日志器（gclog），包含 trace_id/span_id 关联
 //   - gs: 配置完整的 gRPC Server（已注册 Handler 和中间件）
+//   - hs: HTTP/JSON Server（google.api.http 路由），未配置地址时为 nil 并跳过注册
 //   - meta: 服务元信息（Name/Version/Environment/InstanceID）
 //
 // 返回 kratos.App 实例，调用 app.Run() 启动服务并阻塞直到收到停止信号。
@@ -28,19 +32,53 @@ func newApp(
 	_ *obswire.Component,
 	logger log.Logger,
 	gs *grpc.Server,
+	hs *http.Server,
 	meta configloader.ServiceInfo,
 ) *kratos.App {
+	servers := []transport.Server{gs}
+	if hs != nil {
+		servers = append(servers, hs)
+	}
 	options := []kratos.Option{
 		kratos.ID(meta.InstanceID),
 		kratos.Name(meta.Name),
 		kratos.Version(meta.Version),
 		kratos.Metadata(map[string]string{"environment": meta.Environment}),
 		kratos.Logger(logger),
-		kratos.Server(gs),
+		kratos.Server(servers...),
 	}
 	return kratos.New(options...)
 }
 
+// provideRecommendationChain 构造推荐链组装器：启用多路混排时以混排 Provider 为本地链路，否则为 Mock 推荐。
+// 默认推荐链与实验分组的推荐链都经它组装，影子流量与首页复习混排未启用时原样透传。
+func provideRecommendationChain(
+	mock *services.MockRecommendationProvider,
+	blending *services.BlendingRecommendationProvider,
+	shadow *services.ShadowTraffic,
+	review *services.ReviewDueProvider,
+	cfg services.ReviewQueueConfig,
+	logger log.Logger,
+) *services.RecommendationChain {
+	var local services.RecommendationProvider = mock
+	if blending != nil {
+		local = blending
+	}
+	return services.NewRecommendationChain(local, shadow, review, cfg, logger)
+}
+
+// provideRecommendationProvider 组装默认主推荐链：外部推荐服务配置为主推荐时以其为主，失败或熔断时回退到本地链路。
+func provideRecommendationProvider(
+	chain *services.RecommendationChain,
+	remote services.RemoteRecommendationSource,
+	remoteCfg services.RemoteRecommendationConfig,
+) services.RecommendationProvider {
+	if remote != nil && remoteCfg.Primary {
+		return chain.Build(remote)
+	}
+	return chain.Build(nil)
+}
+
 func main() {
 	ctx := context.Background()
 
@@ -15,7 +15,12 @@ func TestWireProviderSets(t *testing.T) {
 	moduleRoot := filepath.Clean(filepath.Join(filepath.Dir(file), "..", "..", ".."))
 	packages := []string{
 		"./cmd/grpc",
+		"./cmd/feedctl",
 		"./cmd/tasks/catalog_inbox",
+		"./cmd/tasks/learning_inbox",
+		"./cmd/tasks/log_retention",
+		"./cmd/tasks/outbox_publisher",
+		"./cmd/tasks/profile_inbox",
 	}
 
 	for _, pkg := range packages {
@@ -10,9 +10,12 @@ package main
 import (
 	"context"
 
+	"github.com/bionicotaku/lingo-services-feed/internal/clients"
 	"github.com/bionicotaku/lingo-services-feed/internal/controllers"
 	configloader "github.com/bionicotaku/lingo-services-feed/internal/infrastructure/configloader"
 	grpcserver "github.com/bionicotaku/lingo-services-feed/internal/infrastructure/grpc_server"
+	httpserver "github.com/bionicotaku/lingo-services-feed/internal/infrastructure/http_server"
+	"github.com/bionicotaku/lingo-services-feed/internal/infrastructure/ratelimiter"
 	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
 	"github.com/bionicotaku/lingo-services-feed/internal/services"
 
@@ -20,6 +23,7 @@ import (
 	"github.com/bionicotaku/lingo-utils/gclog"
 	obswire "github.com/bionicotaku/lingo-utils/observability"
 	"github.com/bionicotaku/lingo-utils/pgxpoolx"
+	"github.com/bionicotaku/lingo-utils/txmanager"
 	"github.com/go-kratos/kratos/v2"
 	"github.com/google/wire"
 )
@@ -36,6 +40,29 @@ var feedConfigSet = wire.NewSet(
 	configloader.ProvidePgxConfig,
 	configloader.ProvideTxConfig,
 	configloader.ProvideJWTConfig,
+	configloader.ProvideFeedServiceConfig,
+	configloader.ProvideGuestPolicy,
+	configloader.ProvideRateLimitConfig,
+	configloader.ProvideAdminAuthPolicy,
+	configloader.ProvideRecommendationLogWriterConfig,
+	configloader.ProvideUserHasher,
+	configloader.ProvideRecommendationLogSamplingConfig,
+	configloader.ProvideMessagingConfig,
+	configloader.ProvideOutboxConfig,
+	configloader.ProvideInteractionConfig,
+	configloader.ProvideServedEventConfig,
+	configloader.ProvideUserStateConfig,
+	configloader.ProvideWatchedFilterConfig,
+	configloader.ProvideContinueLearningConfig,
+	configloader.ProvideReviewQueueConfig,
+	configloader.ProvideBlendingConfig,
+	configloader.ProvideRerankConfig,
+	configloader.ProvideCurationConfig,
+	configloader.ProvideExperimentConfig,
+	configloader.ProvideShadowConfig,
+	configloader.ProvideRemoteRecommendationConfig,
+	configloader.ProvideMockRecommendationConfig,
+	configloader.ProvideClientConfig,
 )
 
 // wireApp 构建整个 Kratos 应用，分阶段装配依赖。
@@ -46,7 +73,7 @@ var feedConfigSet = wire.NewSet(
 //  1. 配置加载: configloader.ProviderSet 解析配置并派生组件配置
 //  2. 基础设施: gclog → observability → gcjwt → pgxpoolx → txmanager
 //  3. 业务层: repositories → services → controllers
-//  4. 服务器: grpc_server.ProviderSet 组装 gRPC Server
+//  4. 服务器: grpc_server.ProviderSet 组装 gRPC Server，http_server.ProviderSet 组装 HTTP/JSON Server
 //  5. 应用: newApp 创建 Kratos App
 func wireApp(context.Context, configloader.Params) (*kratos.App, func(), error) {
 	panic(wire.Build(
@@ -55,15 +82,39 @@ func wireApp(context.Context, configloader.Params) (*kratos.App, func(), error)
 		gcjwt.ProviderSet,      // JWT 认证中间件
 		obswire.ProviderSet,    // OpenTelemetry 追踪和指标
 		pgxpoolx.ProviderSet,   // PostgreSQL 连接池
+		txmanager.ProviderSet,  // 事务管理（交互事件与 Outbox 同事务写入）
 		grpcserver.ProviderSet, // gRPC Server
+		httpserver.ProviderSet, // HTTP/JSON Server（google.api.http 路由）
 		// grpcclient.ProviderSet, // 暂时不使用, 未来需要调用外部 gRPC 服务时再启用
-		// clients.ProviderSet,    // 暂时不使用, 未来需要调用外部服务时再启用
+		clients.ProviderSet, // 外部推荐服务客户端（feed.remote_recommendation 未启用时为 nil）
 		repositories.ProviderSet,
+		ratelimiter.ProviderSet, // 用户级令牌桶限流（memory / postgres）
+		wire.Bind(new(services.GuestRateLimiter), new(*ratelimiter.Limiter)), // 按访客 ID 的令牌桶
 		services.NewMockRecommendationProvider,
+		services.NewFreshRecommendationProvider,
+		services.NewGuestRecommendationProvider,
+		services.NewRecommendationLogWriter,
+		services.NewRecommendationLogSampler,
 		services.NewFeedService,
-		wire.Bind(new(services.RecommendationProvider), new(*services.MockRecommendationProvider)),
-		controllers.ProviderSet, // 控制器层（gRPC handlers）
-		newApp,                  // 组装 Kratos 应用
+		services.NewRecommendationLogLookup,
+		services.NewInteractionRecorder,
+		services.NewRecommendationLogStore, // 推荐日志与 feed.served 事件同事务写入
+		services.NewUserStateHydrator,      // 登录用户卡片的点赞/收藏/观看进度
+		services.NewWatchedFilter,          // 按观看历史剔除/降权已看过的视频
+		services.NewRerankPipeline,         // 补水后的多样性重排
+		services.NewCurationEngine,         // 运营干预规则（LISTEN/NOTIFY 刷新缓存）
+		services.NewCurationRuleAdmin,      // 管理端维护运营干预规则
+		services.NewExperimentAssigner,     // A/B 实验分桶与分组覆盖
+		services.NewShadowTraffic,          // 候选推荐源影子流量比对
+		services.NewContinueLearningProvider,
+		services.NewReviewDueProvider,
+		services.NewSceneProviders,                 // 按场景路由推荐 Provider（continue_learning / review）
+		services.NewBlendingSources,                // 可参与首页混排的推荐源（mock / fresh / review）
+		services.NewBlendingRecommendationProvider, // 首页多路混排
+		provideRecommendationChain,                 // 推荐链组装器：默认链路与实验分组共用
+		provideRecommendationProvider,              // 主推荐链：外部推荐（兜底：多路混排或 Mock 推荐）+ 影子流量 + 首页复习混排
+		controllers.ProviderSet,                    // 控制器层（gRPC handlers）
+		newApp,                                     // 组装 Kratos 应用
 	))
 }
 
@@ -174,9 +225,14 @@ func wireApp(context.Context, configloader.Params) (*kratos.App, func(), error)
 // └─────────────────────────────────────────────────────────────────────────┘
 //
 //   - grpcserver.NewGRPCServer(*configpb.Server, *observability.MetricsConfig,
-//                               gcjwt.ServerMiddleware, *controllers.VideoHandler,
-//                               log.Logger) *grpc.Server
-//       构建 gRPC Server，注入指标、日志、JWT 等中间件。
+//                               gcjwt.ServerMiddleware, controllers.AdminAuthMiddleware,
+//                               controllers.RateLimitMiddleware, *controllers.FeedHandler,
+//                               *controllers.FeedAdminHandler, log.Logger) *grpc.Server
+//       构建 gRPC Server，注入指标、日志、JWT、管理端鉴权、用户级限流等中间件。
+//
+//   - grpcserver.NewAdminTokenVerifier(controllers.AdminAuthPolicy, log.Logger)
+//                               (controllers.AdminTokenVerifier, func(), error)
+//       管理端独立的 gcjwt 签名校验（Token 必填，aud 为 server.admin.audience）。
 //
 // ┌─────────────────────────────────────────────────────────────────────────┐
 // │ 8. 业务层 (repositories/services/controllers)                           │
@@ -8,15 +8,19 @@ package main
 
 import (
 	"context"
+	"github.com/bionicotaku/lingo-services-feed/internal/clients/recommendation"
 	"github.com/bionicotaku/lingo-services-feed/internal/controllers"
 	"github.com/bionicotaku/lingo-services-feed/internal/infrastructure/configloader"
 	"github.com/bionicotaku/lingo-services-feed/internal/infrastructure/grpc_server"
+	"github.com/bionicotaku/lingo-services-feed/internal/infrastructure/http_server"
+	"github.com/bionicotaku/lingo-services-feed/internal/infrastructure/ratelimiter"
 	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
 	"github.com/bionicotaku/lingo-services-feed/internal/services"
 	"github.com/bionicotaku/lingo-utils/gcjwt"
 	"github.com/bionicotaku/lingo-utils/gclog"
 	"github.com/bionicotaku/lingo-utils/observability"
 	"github.com/bionicotaku/lingo-utils/pgxpoolx"
+	"github.com/bionicotaku/lingo-utils/txmanager"
 	"github.com/go-kratos/kratos/v2"
 	"github.com/google/wire"
 )
@@ -35,7 +39,7 @@ import (
 //  1. 配置加载: configloader.ProviderSet 解析配置并派生组件配置
 //  2. 基础设施: gclog → observability → gcjwt → pgxpoolx → txmanager
 //  3. 业务层: repositories → services → controllers
-//  4. 服务器: grpc_server.ProviderSet 组装 gRPC Server
+//  4. 服务器: grpc_server.ProviderSet 组装 gRPC Server，http_server.ProviderSet 组装 HTTP/JSON Server
 //  5. 应用: newApp 创建 Kratos App
 func wireApp(contextContext context.Context, params configloader.Params) (*kratos.App, func(), error) {
 	runtimeConfig, err := configloader.LoadRuntimeConfig(params)
@@ -72,27 +76,166 @@ func wireApp(contextContext context.Context, params configloader.Params) (*krato
 		cleanup()
 		return nil, nil, err
 	}
+	adminAuthPolicy := configloader.ProvideAdminAuthPolicy(runtimeConfig)
+	adminTokenVerifier, cleanup4, err := grpcserver.NewAdminTokenVerifier(adminAuthPolicy, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	adminAuthMiddleware := controllers.NewAdminAuthMiddleware(adminAuthPolicy, adminTokenVerifier)
+	ratelimiterConfig := configloader.ProvideRateLimitConfig(runtimeConfig)
 	databaseConfig := configloader.ProvideDatabaseConfig(runtimeConfig)
 	pgxpoolxConfig := configloader.ProvidePgxConfig(databaseConfig)
-	pgxpoolxComponent, cleanup4, err := pgxpoolx.ProvideComponent(contextContext, pgxpoolxConfig, logger)
+	pgxpoolxComponent, cleanup5, err := pgxpoolx.ProvideComponent(contextContext, pgxpoolxConfig, logger)
 	if err != nil {
+		cleanup4()
 		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	pool := pgxpoolx.ProvidePool(pgxpoolxComponent)
+	rateLimitRepository := repositories.NewRateLimitRepository(pool, logger)
+	limiter := ratelimiter.NewLimiter(ratelimiterConfig, rateLimitRepository, logger)
+	rateLimitMiddleware := controllers.NewRateLimitMiddleware(limiter)
 	feedVideoProjectionRepository := repositories.NewFeedVideoProjectionRepository(pool, logger)
-	mockRecommendationProvider := services.NewMockRecommendationProvider(feedVideoProjectionRepository, logger)
+	mockRecommendationConfig := configloader.ProvideMockRecommendationConfig(runtimeConfig)
+	mockRecommendationProvider := services.NewMockRecommendationProvider(feedVideoProjectionRepository, mockRecommendationConfig, logger)
+	freshRecommendationProvider := services.NewFreshRecommendationProvider(feedVideoProjectionRepository, logger)
+	reviewScheduleRepository := repositories.NewReviewScheduleRepository(pool, logger)
+	reviewQueueConfig := configloader.ProvideReviewQueueConfig(runtimeConfig)
+	reviewDueProvider := services.NewReviewDueProvider(reviewScheduleRepository, reviewQueueConfig, logger)
+	remoteRecommendationConfig := configloader.ProvideRemoteRecommendationConfig(runtimeConfig)
+	grpcClientConfig := configloader.ProvideClientConfig(runtimeConfig)
+	client, cleanup6, err := recommendation.NewGRPCClient(remoteRecommendationConfig, grpcClientConfig, metricsConfig, gcjwtComponent, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	remoteRecommendationSource := recommendation.ProvideSource(client)
+	blendingSources := services.NewBlendingSources(mockRecommendationProvider, freshRecommendationProvider, reviewDueProvider, remoteRecommendationSource)
+	blendingConfig := configloader.ProvideBlendingConfig(runtimeConfig)
+	blendingRecommendationProvider, err := services.NewBlendingRecommendationProvider(blendingSources, blendingConfig, logger)
+	if err != nil {
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	shadowConfig := configloader.ProvideShadowConfig(runtimeConfig)
+	shadowTraffic, cleanup7, err := services.NewShadowTraffic(shadowConfig, blendingSources, feedVideoProjectionRepository, logger)
+	if err != nil {
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	recommendationChain := provideRecommendationChain(mockRecommendationProvider, blendingRecommendationProvider, shadowTraffic, reviewDueProvider, reviewQueueConfig, logger)
+	recommendationProvider := provideRecommendationProvider(recommendationChain, remoteRecommendationSource, remoteRecommendationConfig)
+	guestRecommendationProvider := services.NewGuestRecommendationProvider(freshRecommendationProvider, feedVideoProjectionRepository, logger)
 	feedRecommendationLogRepository := repositories.NewFeedRecommendationLogRepository(pool, logger)
-	feedService := services.NewFeedService(mockRecommendationProvider, feedVideoProjectionRepository, feedRecommendationLogRepository, logger)
+	messagingConfig := configloader.ProvideMessagingConfig(runtimeConfig)
+	configConfig := configloader.ProvideOutboxConfig(messagingConfig)
+	outboxRepository := repositories.NewOutboxRepository(pool, logger, configConfig)
+	txmanagerConfig := configloader.ProvideTxConfig(runtimeConfig)
+	txmanagerComponent, cleanup8, err := txmanager.NewComponent(txmanagerConfig, pool, logger)
+	if err != nil {
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	manager := txmanager.ProvideManager(txmanagerComponent)
+	servedEventConfig := configloader.ProvideServedEventConfig(runtimeConfig)
+	recommendationLogStore := services.NewRecommendationLogStore(feedRecommendationLogRepository, outboxRepository, manager, servedEventConfig, logger)
+	servedPageRepository := repositories.NewServedPageRepository(pool, logger)
+	recommendationLogWriterConfig := configloader.ProvideRecommendationLogWriterConfig(runtimeConfig)
+	recommendationLogWriter, cleanup9 := services.NewRecommendationLogWriter(recommendationLogStore, servedPageRepository, recommendationLogWriterConfig, logger)
+	feedIdempotencyRepository := repositories.NewFeedIdempotencyRepository(pool, logger)
+	hasher, err := configloader.ProvideUserHasher(runtimeConfig)
+	if err != nil {
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	recommendationLogSamplingConfig := configloader.ProvideRecommendationLogSamplingConfig(runtimeConfig)
+	recommendationLogSampler := services.NewRecommendationLogSampler(recommendationLogSamplingConfig)
+	interactionConfig := configloader.ProvideInteractionConfig(runtimeConfig)
+	interactionRecorder := services.NewInteractionRecorder(servedPageRepository, feedRecommendationLogRepository, outboxRepository, manager, hasher, interactionConfig, logger)
+	userVideoStateRepository := repositories.NewUserVideoStateRepository(pool, logger)
+	userStateConfig := configloader.ProvideUserStateConfig(runtimeConfig)
+	userStateHydrator := services.NewUserStateHydrator(userVideoStateRepository, userStateConfig, logger)
+	userWatchHistoryRepository := repositories.NewUserWatchHistoryRepository(pool, logger)
+	watchedFilterConfig := configloader.ProvideWatchedFilterConfig(runtimeConfig)
+	watchedFilter := services.NewWatchedFilter(userWatchHistoryRepository, watchedFilterConfig, logger)
+	rerankConfig := configloader.ProvideRerankConfig(runtimeConfig)
+	rerankPipeline := services.NewRerankPipeline(rerankConfig, logger)
+	curationRuleRepository := repositories.NewCurationRuleRepository(pool, logger)
+	curationConfig := configloader.ProvideCurationConfig(runtimeConfig)
+	curationEngine, cleanup10 := services.NewCurationEngine(curationRuleRepository, curationConfig, logger)
+	experimentConfig := configloader.ProvideExperimentConfig(runtimeConfig)
+	experimentAssigner, err := services.NewExperimentAssigner(experimentConfig, blendingSources, blendingConfig, recommendationChain, logger)
+	if err != nil {
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	continueLearningConfig := configloader.ProvideContinueLearningConfig(runtimeConfig)
+	continueLearningProvider := services.NewContinueLearningProvider(userVideoStateRepository, continueLearningConfig, logger)
+	sceneProviders := services.NewSceneProviders(continueLearningProvider, reviewDueProvider)
+	feedServiceConfig := configloader.ProvideFeedServiceConfig(runtimeConfig)
+	feedService := services.NewFeedService(recommendationProvider, guestRecommendationProvider, feedVideoProjectionRepository, recommendationLogWriter, feedIdempotencyRepository, hasher, recommendationLogSampler, interactionRecorder, userStateHydrator, watchedFilter, rerankPipeline, curationEngine, experimentAssigner, sceneProviders, limiter, feedServiceConfig, logger)
 	feedServiceAPI := controllers.ProvideFeedServiceAPI(feedService)
 	handlerTimeouts := configloader.ProvideHandlerTimeouts(runtimeConfig)
 	baseHandler := controllers.NewBaseHandler(handlerTimeouts)
-	feedHandler := controllers.NewFeedHandler(feedServiceAPI, baseHandler, logger)
-	server := grpcserver.NewGRPCServer(serverConfig, metricsConfig, serverMiddleware, feedHandler, logger)
-	app := newApp(observabilityComponent, logger, server, serviceInfo)
+	guestPolicy := configloader.ProvideGuestPolicy(runtimeConfig)
+	feedHandler := controllers.NewFeedHandler(feedServiceAPI, baseHandler, guestPolicy, logger)
+	recommendationLogLookup := services.NewRecommendationLogLookup(feedRecommendationLogRepository, hasher)
+	recommendationLogAdminAPI := controllers.ProvideRecommendationLogAdminAPI(recommendationLogLookup)
+	curationRuleAdmin := services.NewCurationRuleAdmin(curationRuleRepository, curationEngine)
+	curationRuleAdminAPI := controllers.ProvideCurationRuleAdminAPI(curationRuleAdmin)
+	feedAdminHandler := controllers.NewFeedAdminHandler(recommendationLogAdminAPI, curationRuleAdminAPI, baseHandler, logger)
+	server := grpcserver.NewGRPCServer(serverConfig, metricsConfig, serverMiddleware, adminAuthMiddleware, rateLimitMiddleware, feedHandler, feedAdminHandler, logger)
+	httpServer := httpserver.NewHTTPServer(serverConfig, serverMiddleware, rateLimitMiddleware, feedHandler, logger)
+	app := newApp(observabilityComponent, logger, server, httpServer, serviceInfo)
 	return app, func() {
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
 		cleanup4()
 		cleanup3()
 		cleanup2()
@@ -102,4 +245,4 @@ func wireApp(contextContext context.Context, params configloader.Params) (*krato
 
 // wire.go:
 
-var feedConfigSet = wire.NewSet(configloader.LoadRuntimeConfig, configloader.ProvideServiceInfo, configloader.ProvideLoggerConfig, configloader.ProvideObservabilityConfig, configloader.ProvideObservabilityInfo, configloader.ProvideServerConfig, configloader.ProvideHandlerTimeouts, configloader.ProvideDatabaseConfig, configloader.ProvidePgxConfig, configloader.ProvideTxConfig, configloader.ProvideJWTConfig)
+var feedConfigSet = wire.NewSet(configloader.LoadRuntimeConfig, configloader.ProvideServiceInfo, configloader.ProvideLoggerConfig, configloader.ProvideObservabilityConfig, configloader.ProvideObservabilityInfo, configloader.ProvideServerConfig, configloader.ProvideHandlerTimeouts, configloader.ProvideDatabaseConfig, configloader.ProvidePgxConfig, configloader.ProvideTxConfig, configloader.ProvideJWTConfig, configloader.ProvideFeedServiceConfig, configloader.ProvideGuestPolicy, configloader.ProvideRateLimitConfig, configloader.ProvideAdminAuthPolicy, configloader.ProvideRecommendationLogWriterConfig, configloader.ProvideUserHasher, configloader.ProvideRecommendationLogSamplingConfig, configloader.ProvideMessagingConfig, configloader.ProvideOutboxConfig, configloader.ProvideInteractionConfig, configloader.ProvideServedEventConfig, configloader.ProvideUserStateConfig, configloader.ProvideWatchedFilterConfig, configloader.ProvideContinueLearningConfig, configloader.ProvideReviewQueueConfig, configloader.ProvideBlendingConfig, configloader.ProvideRerankConfig, configloader.ProvideCurationConfig, configloader.ProvideExperimentConfig, configloader.ProvideShadowConfig, configloader.ProvideRemoteRecommendationConfig, configloader.ProvideMockRecommendationConfig, configloader.ProvideClientConfig)
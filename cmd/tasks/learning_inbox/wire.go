@@ -0,0 +1,55 @@
+//go:build wireinject
+// +build wireinject
+
+// Package main 为 learning inbox 任务提供 Wire 依赖注入定义。
+package main
+
+import (
+	"context"
+	"fmt"
+
+	configloader "github.com/bionicotaku/lingo-services-feed/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	learninginbox "github.com/bionicotaku/lingo-services-feed/internal/tasks/learning_inbox"
+
+	"github.com/bionicotaku/lingo-utils/gclog"
+	obswire "github.com/bionicotaku/lingo-utils/observability"
+	"github.com/bionicotaku/lingo-utils/pgxpoolx"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/wire"
+)
+
+//go:generate go run github.com/google/wire/cmd/wire
+
+var learningInboxRepoSet = wire.NewSet(
+	repositories.NewInboxRepository,
+	repositories.NewReviewScheduleRepository,
+)
+
+func wireLearningInboxTask(context.Context, configloader.Params) (*learningInboxApp, func(), error) {
+	panic(wire.Build(
+		configloader.ProviderSet,
+		gclog.ProviderSet,
+		obswire.ProviderSet,
+		pgxpoolx.ProviderSet,
+		txmanager.ProviderSet,
+		learningInboxRepoSet,
+		learninginbox.ProvideSubscriber,
+		learninginbox.ProvideTask,
+		newLearningInboxApp,
+	))
+}
+
+func newLearningInboxApp(_ *obswire.Component, logger log.Logger, task *learninginbox.Task) (*learningInboxApp, error) {
+	if task == nil {
+		return &learningInboxApp{Logger: logger}, nil
+	}
+	if logger == nil {
+		return nil, fmt.Errorf("logger not initialized")
+	}
+	return &learningInboxApp{
+		Task:   task,
+		Logger: logger,
+	}, nil
+}
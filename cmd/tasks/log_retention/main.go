@@ -0,0 +1,61 @@
+// Package main 提供推荐日志分区维护任务的独立入口，负责预建 feed.recommendation_logs
+// 的未来日分区并按保留期删除过期分区。
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	configloader "github.com/bionicotaku/lingo-services-feed/internal/infrastructure/configloader"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type logRetentionApp struct {
+	Task   runner
+	Logger log.Logger
+}
+
+type runner interface {
+	Run(ctx context.Context) error
+}
+
+func main() {
+	ctx := context.Background()
+
+	confFlag := flag.String("conf", "", "config path or directory, eg: -conf configs/config.yaml")
+	flag.Parse()
+
+	params := configloader.Params{ConfPath: *confFlag}
+	app, cleanup, err := wireLogRetentionTask(ctx, params)
+	if err != nil {
+		panic(err)
+	}
+	defer cleanup()
+
+	logger := app.Logger
+	if logger == nil {
+		logger = log.NewStdLogger(os.Stdout)
+	}
+	helper := log.NewHelper(logger)
+
+	if app.Task == nil {
+		helper.Warn("log retention task disabled (feed.log_retention.enabled=false)")
+		return
+	}
+
+	helper.Info("starting log retention task")
+
+	runCtx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := app.Task.Run(runCtx); err != nil && !errors.Is(err, context.Canceled) {
+		helper.Errorf("log retention task stopped unexpectedly: %v", err)
+		os.Exit(1)
+	}
+
+	helper.Info("log retention task stopped")
+}
@@ -0,0 +1,51 @@
+//go:build wireinject
+// +build wireinject
+
+// Package main 为推荐日志分区维护任务提供 Wire 依赖注入定义。
+package main
+
+import (
+	"context"
+	"fmt"
+
+	configloader "github.com/bionicotaku/lingo-services-feed/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	logretention "github.com/bionicotaku/lingo-services-feed/internal/tasks/log_retention"
+
+	"github.com/bionicotaku/lingo-utils/gclog"
+	obswire "github.com/bionicotaku/lingo-utils/observability"
+	"github.com/bionicotaku/lingo-utils/pgxpoolx"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/wire"
+)
+
+//go:generate go run github.com/google/wire/cmd/wire
+
+func wireLogRetentionTask(context.Context, configloader.Params) (*logRetentionApp, func(), error) {
+	panic(wire.Build(
+		configloader.ProviderSet,
+		gclog.ProviderSet,
+		obswire.ProviderSet,
+		pgxpoolx.ProviderSet,
+		repositories.NewFeedRecommendationLogRepository,
+		repositories.NewServedPageRepository,
+		repositories.NewFeedIdempotencyRepository,
+		repositories.NewRateLimitRepository,
+		logretention.ProvideSweepers,
+		logretention.ProvideTask,
+		newLogRetentionApp,
+	))
+}
+
+func newLogRetentionApp(_ *obswire.Component, logger log.Logger, task *logretention.Task) (*logRetentionApp, error) {
+	if task == nil {
+		return &logRetentionApp{Logger: logger}, nil
+	}
+	if logger == nil {
+		return nil, fmt.Errorf("logger not initialized")
+	}
+	return &logRetentionApp{
+		Task:   task,
+		Logger: logger,
+	}, nil
+}
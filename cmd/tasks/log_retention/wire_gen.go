@@ -0,0 +1,86 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/bionicotaku/lingo-services-feed/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/bionicotaku/lingo-services-feed/internal/tasks/log_retention"
+	"github.com/bionicotaku/lingo-utils/gclog"
+	"github.com/bionicotaku/lingo-utils/observability"
+	"github.com/bionicotaku/lingo-utils/pgxpoolx"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// Injectors from wire.go:
+
+func wireLogRetentionTask(contextContext context.Context, params configloader.Params) (*logRetentionApp, func(), error) {
+	runtimeConfig, err := configloader.LoadRuntimeConfig(params)
+	if err != nil {
+		return nil, nil, err
+	}
+	observabilityConfig := configloader.ProvideObservabilityConfig(runtimeConfig)
+	serviceInfo := configloader.ProvideServiceInfo(runtimeConfig)
+	observabilityServiceInfo := configloader.ProvideObservabilityInfo(serviceInfo)
+	config := configloader.ProvideLoggerConfig(serviceInfo)
+	component, cleanup, err := gclog.NewComponent(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	logger := gclog.ProvideLogger(component)
+	observabilityComponent, cleanup2, err := observability.NewComponent(contextContext, observabilityConfig, observabilityServiceInfo, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	databaseConfig := configloader.ProvideDatabaseConfig(runtimeConfig)
+	pgxpoolxConfig := configloader.ProvidePgxConfig(databaseConfig)
+	pgxpoolxComponent, cleanup3, err := pgxpoolx.ProvideComponent(contextContext, pgxpoolxConfig, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	pool := pgxpoolx.ProvidePool(pgxpoolxComponent)
+	feedRecommendationLogRepository := repositories.NewFeedRecommendationLogRepository(pool, logger)
+	logretentionConfig := configloader.ProvideLogRetentionConfig(runtimeConfig)
+	servedPageRepository := repositories.NewServedPageRepository(pool, logger)
+	feedIdempotencyRepository := repositories.NewFeedIdempotencyRepository(pool, logger)
+	rateLimitRepository := repositories.NewRateLimitRepository(pool, logger)
+	ratelimiterConfig := configloader.ProvideRateLimitConfig(runtimeConfig)
+	sweepers := logretention.ProvideSweepers(servedPageRepository, feedIdempotencyRepository, rateLimitRepository, ratelimiterConfig, logretentionConfig)
+	task := logretention.ProvideTask(feedRecommendationLogRepository, logretentionConfig, sweepers, logger)
+	mainLogRetentionApp, err := newLogRetentionApp(observabilityComponent, logger, task)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	return mainLogRetentionApp, func() {
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+// wire.go:
+
+func newLogRetentionApp(_ *observability.Component, logger log.Logger, task *logretention.Task) (*logRetentionApp, error) {
+	if task == nil {
+		return &logRetentionApp{Logger: logger}, nil
+	}
+	if logger == nil {
+		return nil, fmt.Errorf("logger not initialized")
+	}
+	return &logRetentionApp{
+		Task:   task,
+		Logger: logger,
+	}, nil
+}
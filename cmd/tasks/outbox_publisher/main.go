@@ -0,0 +1,61 @@
+// Package main 提供 Outbox Publisher 的独立入口，负责将 feed.outbox_events 中的
+// feed.* 事件发布到 Pub/Sub。
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	configloader "github.com/bionicotaku/lingo-services-feed/internal/infrastructure/configloader"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type outboxPublisherApp struct {
+	Task   runner
+	Logger log.Logger
+}
+
+type runner interface {
+	Run(ctx context.Context) error
+}
+
+func main() {
+	ctx := context.Background()
+
+	confFlag := flag.String("conf", "", "config path or directory, eg: -conf configs/config.yaml")
+	flag.Parse()
+
+	params := configloader.Params{ConfPath: *confFlag}
+	app, cleanup, err := wireOutboxPublisherTask(ctx, params)
+	if err != nil {
+		panic(err)
+	}
+	defer cleanup()
+
+	logger := app.Logger
+	if logger == nil {
+		logger = log.NewStdLogger(os.Stdout)
+	}
+	helper := log.NewHelper(logger)
+
+	if app.Task == nil {
+		helper.Warn("outbox publisher disabled (missing messaging topic for feed events)")
+		return
+	}
+
+	helper.Info("starting outbox publisher task")
+
+	runCtx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := app.Task.Run(runCtx); err != nil && !errors.Is(err, context.Canceled) {
+		helper.Errorf("outbox publisher stopped unexpectedly: %v", err)
+		os.Exit(1)
+	}
+
+	helper.Info("outbox publisher task stopped")
+}
@@ -0,0 +1,48 @@
+//go:build wireinject
+// +build wireinject
+
+// Package main 为 outbox publisher 任务提供 Wire 依赖注入定义。
+package main
+
+import (
+	"context"
+	"fmt"
+
+	configloader "github.com/bionicotaku/lingo-services-feed/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	outboxpublisher "github.com/bionicotaku/lingo-services-feed/internal/tasks/outbox_publisher"
+
+	"github.com/bionicotaku/lingo-utils/gclog"
+	obswire "github.com/bionicotaku/lingo-utils/observability"
+	"github.com/bionicotaku/lingo-utils/pgxpoolx"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/wire"
+)
+
+//go:generate go run github.com/google/wire/cmd/wire
+
+func wireOutboxPublisherTask(context.Context, configloader.Params) (*outboxPublisherApp, func(), error) {
+	panic(wire.Build(
+		configloader.ProviderSet,
+		gclog.ProviderSet,
+		obswire.ProviderSet,
+		pgxpoolx.ProviderSet,
+		repositories.NewOutboxRepository,
+		outboxpublisher.ProvidePublisher,
+		outboxpublisher.ProvideTask,
+		newOutboxPublisherApp,
+	))
+}
+
+func newOutboxPublisherApp(_ *obswire.Component, logger log.Logger, task *outboxpublisher.Task) (*outboxPublisherApp, error) {
+	if task == nil {
+		return &outboxPublisherApp{Logger: logger}, nil
+	}
+	if logger == nil {
+		return nil, fmt.Errorf("logger not initialized")
+	}
+	return &outboxPublisherApp{
+		Task:   task,
+		Logger: logger,
+	}, nil
+}
@@ -0,0 +1,93 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/bionicotaku/lingo-services-feed/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/bionicotaku/lingo-services-feed/internal/tasks/outbox_publisher"
+	"github.com/bionicotaku/lingo-utils/gclog"
+	"github.com/bionicotaku/lingo-utils/observability"
+	"github.com/bionicotaku/lingo-utils/pgxpoolx"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// Injectors from wire.go:
+
+func wireOutboxPublisherTask(contextContext context.Context, params configloader.Params) (*outboxPublisherApp, func(), error) {
+	runtimeConfig, err := configloader.LoadRuntimeConfig(params)
+	if err != nil {
+		return nil, nil, err
+	}
+	observabilityConfig := configloader.ProvideObservabilityConfig(runtimeConfig)
+	serviceInfo := configloader.ProvideServiceInfo(runtimeConfig)
+	observabilityServiceInfo := configloader.ProvideObservabilityInfo(serviceInfo)
+	config := configloader.ProvideLoggerConfig(serviceInfo)
+	component, cleanup, err := gclog.NewComponent(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	logger := gclog.ProvideLogger(component)
+	observabilityComponent, cleanup2, err := observability.NewComponent(contextContext, observabilityConfig, observabilityServiceInfo, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	topicConfig := configloader.ProvideEventTopicConfig(runtimeConfig)
+	dependencies := configloader.ProvidePubSubDependencies(logger)
+	publisher, cleanup3, err := outboxpublisher.ProvidePublisher(contextContext, topicConfig, dependencies)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	databaseConfig := configloader.ProvideDatabaseConfig(runtimeConfig)
+	pgxpoolxConfig := configloader.ProvidePgxConfig(databaseConfig)
+	pgxpoolxComponent, cleanup4, err := pgxpoolx.ProvideComponent(contextContext, pgxpoolxConfig, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	pool := pgxpoolx.ProvidePool(pgxpoolxComponent)
+	messagingConfig := configloader.ProvideMessagingConfig(runtimeConfig)
+	configConfig := configloader.ProvideOutboxConfig(messagingConfig)
+	outboxRepository := repositories.NewOutboxRepository(pool, logger, configConfig)
+	task := outboxpublisher.ProvideTask(publisher, outboxRepository, topicConfig, configConfig, logger)
+	mainOutboxPublisherApp, err := newOutboxPublisherApp(observabilityComponent, logger, task)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	return mainOutboxPublisherApp, func() {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+// wire.go:
+
+func newOutboxPublisherApp(_ *observability.Component, logger log.Logger, task *outboxpublisher.Task) (*outboxPublisherApp, error) {
+	if task == nil {
+		return &outboxPublisherApp{Logger: logger}, nil
+	}
+	if logger == nil {
+		return nil, fmt.Errorf("logger not initialized")
+	}
+	return &outboxPublisherApp{
+		Task:   task,
+		Logger: logger,
+	}, nil
+}
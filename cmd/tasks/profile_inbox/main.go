@@ -0,0 +1,61 @@
+// Package main 提供 Profile Inbox Runner 的独立入口，负责消费 profile.engagement.* 与 profile.watch.progressed 事件
+// 并维护 feed.user_video_state 投影表。
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	configloader "github.com/bionicotaku/lingo-services-feed/internal/infrastructure/configloader"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type profileInboxApp struct {
+	Task   runner
+	Logger log.Logger
+}
+
+type runner interface {
+	Run(ctx context.Context) error
+}
+
+func main() {
+	ctx := context.Background()
+
+	confFlag := flag.String("conf", "", "config path or directory, eg: -conf configs/config.yaml")
+	flag.Parse()
+
+	params := configloader.Params{ConfPath: *confFlag}
+	app, cleanup, err := wireProfileInboxTask(ctx, params)
+	if err != nil {
+		panic(err)
+	}
+	defer cleanup()
+
+	logger := app.Logger
+	if logger == nil {
+		logger = log.NewStdLogger(os.Stdout)
+	}
+	helper := log.NewHelper(logger)
+
+	if app.Task == nil {
+		helper.Warn("profile inbox runner disabled (missing messaging.topics[feed.user_state.topic] subscription)")
+		return
+	}
+
+	helper.Info("starting profile inbox task")
+
+	runCtx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := app.Task.Run(runCtx); err != nil && !errors.Is(err, context.Canceled) {
+		helper.Errorf("profile inbox runner stopped unexpectedly: %v", err)
+		os.Exit(1)
+	}
+
+	helper.Info("profile inbox task stopped")
+}
@@ -0,0 +1,56 @@
+//go:build wireinject
+// +build wireinject
+
+// Package main 为 profile inbox 任务提供 Wire 依赖注入定义。
+package main
+
+import (
+	"context"
+	"fmt"
+
+	configloader "github.com/bionicotaku/lingo-services-feed/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	profileinbox "github.com/bionicotaku/lingo-services-feed/internal/tasks/profile_inbox"
+
+	"github.com/bionicotaku/lingo-utils/gclog"
+	obswire "github.com/bionicotaku/lingo-utils/observability"
+	"github.com/bionicotaku/lingo-utils/pgxpoolx"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/wire"
+)
+
+//go:generate go run github.com/google/wire/cmd/wire
+
+var profileInboxRepoSet = wire.NewSet(
+	repositories.NewInboxRepository,
+	repositories.NewUserVideoStateRepository,
+	repositories.NewUserWatchHistoryRepository,
+)
+
+func wireProfileInboxTask(context.Context, configloader.Params) (*profileInboxApp, func(), error) {
+	panic(wire.Build(
+		configloader.ProviderSet,
+		gclog.ProviderSet,
+		obswire.ProviderSet,
+		pgxpoolx.ProviderSet,
+		txmanager.ProviderSet,
+		profileInboxRepoSet,
+		profileinbox.ProvideSubscriber,
+		profileinbox.ProvideTask,
+		newProfileInboxApp,
+	))
+}
+
+func newProfileInboxApp(_ *obswire.Component, logger log.Logger, task *profileinbox.Task) (*profileInboxApp, error) {
+	if task == nil {
+		return &profileInboxApp{Logger: logger}, nil
+	}
+	if logger == nil {
+		return nil, fmt.Errorf("logger not initialized")
+	}
+	return &profileInboxApp{
+		Task:   task,
+		Logger: logger,
+	}, nil
+}
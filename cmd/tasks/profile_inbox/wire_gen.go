@@ -0,0 +1,111 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/bionicotaku/lingo-services-feed/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/bionicotaku/lingo-services-feed/internal/tasks/profile_inbox"
+	"github.com/bionicotaku/lingo-utils/gclog"
+	"github.com/bionicotaku/lingo-utils/observability"
+	"github.com/bionicotaku/lingo-utils/pgxpoolx"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/wire"
+)
+
+// Injectors from wire.go:
+
+func wireProfileInboxTask(contextContext context.Context, params configloader.Params) (*profileInboxApp, func(), error) {
+	runtimeConfig, err := configloader.LoadRuntimeConfig(params)
+	if err != nil {
+		return nil, nil, err
+	}
+	observabilityConfig := configloader.ProvideObservabilityConfig(runtimeConfig)
+	serviceInfo := configloader.ProvideServiceInfo(runtimeConfig)
+	observabilityServiceInfo := configloader.ProvideObservabilityInfo(serviceInfo)
+	config := configloader.ProvideLoggerConfig(serviceInfo)
+	component, cleanup, err := gclog.NewComponent(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	logger := gclog.ProvideLogger(component)
+	observabilityComponent, cleanup2, err := observability.NewComponent(contextContext, observabilityConfig, observabilityServiceInfo, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	subscriptionConfig := configloader.ProvideProfileSubscriptionConfig(runtimeConfig)
+	dependencies := configloader.ProvidePubSubDependencies(logger)
+	subscriber, cleanup3, err := profileinbox.ProvideSubscriber(contextContext, subscriptionConfig, dependencies)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	databaseConfig := configloader.ProvideDatabaseConfig(runtimeConfig)
+	pgxpoolxConfig := configloader.ProvidePgxConfig(databaseConfig)
+	pgxpoolxComponent, cleanup4, err := pgxpoolx.ProvideComponent(contextContext, pgxpoolxConfig, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	pool := pgxpoolx.ProvidePool(pgxpoolxComponent)
+	messagingConfig := configloader.ProvideMessagingConfig(runtimeConfig)
+	configConfig := configloader.ProvideOutboxConfig(messagingConfig)
+	inboxRepository := repositories.NewInboxRepository(pool, logger, configConfig)
+	userVideoStateRepository := repositories.NewUserVideoStateRepository(pool, logger)
+	userWatchHistoryRepository := repositories.NewUserWatchHistoryRepository(pool, logger)
+	txmanagerConfig := configloader.ProvideTxConfig(runtimeConfig)
+	txmanagerComponent, cleanup5, err := txmanager.NewComponent(txmanagerConfig, pool, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	manager := txmanager.ProvideManager(txmanagerComponent)
+	task := profileinbox.ProvideTask(subscriber, inboxRepository, userVideoStateRepository, userWatchHistoryRepository, manager, subscriptionConfig, logger)
+	mainProfileInboxApp, err := newProfileInboxApp(observabilityComponent, logger, task)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	return mainProfileInboxApp, func() {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+// wire.go:
+
+var profileInboxRepoSet = wire.NewSet(repositories.NewInboxRepository, repositories.NewUserVideoStateRepository, repositories.NewUserWatchHistoryRepository)
+
+func newProfileInboxApp(_ *observability.Component, logger log.Logger, task *profileinbox.Task) (*profileInboxApp, error) {
+	if task == nil {
+		return &profileInboxApp{Logger: logger}, nil
+	}
+	if logger == nil {
+		return nil, fmt.Errorf("logger not initialized")
+	}
+	return &profileInboxApp{
+		Task:   task,
+		Logger: logger,
+	}, nil
+}
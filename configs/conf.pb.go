@@ -29,6 +29,7 @@ type Bootstrap struct {
 	Data          *Data                  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 	Observability *Observability         `protobuf:"bytes,3,opt,name=observability,proto3" json:"observability,omitempty"`
 	Messaging     *Messaging             `protobuf:"bytes,4,opt,name=messaging,proto3" json:"messaging,omitempty"`
+	Feed          *Feed                  `protobuf:"bytes,5,opt,name=feed,proto3" json:"feed,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -91,12 +92,22 @@ func (x *Bootstrap) GetMessaging() *Messaging {
 	return nil
 }
 
+func (x *Bootstrap) GetFeed() *Feed {
+	if x != nil {
+		return x.Feed
+	}
+	return nil
+}
+
 type Server struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Grpc          *Server_GRPC           `protobuf:"bytes,1,opt,name=grpc,proto3" json:"grpc,omitempty"`
 	Jwt           *Server_JWT            `protobuf:"bytes,2,opt,name=jwt,proto3" json:"jwt,omitempty"`
 	Handlers      *Server_Handlers       `protobuf:"bytes,3,opt,name=handlers,proto3" json:"handlers,omitempty"`
 	MetadataKeys  []string               `protobuf:"bytes,4,rep,name=metadata_keys,json=metadataKeys,proto3" json:"metadata_keys,omitempty"` // 透传 header 列表，如 X-Apigateway-Api-Userinfo（actor 字段 Post-MVP 可追加）
+	Http          *Server_HTTP           `protobuf:"bytes,5,opt,name=http,proto3" json:"http,omitempty"`
+	RateLimit     *Server_RateLimit      `protobuf:"bytes,6,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
+	Admin         *Server_Admin          `protobuf:"bytes,7,opt,name=admin,proto3" json:"admin,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -159,6 +170,27 @@ func (x *Server) GetMetadataKeys() []string {
 	return nil
 }
 
+func (x *Server) GetHttp() *Server_HTTP {
+	if x != nil {
+		return x.Http
+	}
+	return nil
+}
+
+func (x *Server) GetRateLimit() *Server_RateLimit {
+	if x != nil {
+		return x.RateLimit
+	}
+	return nil
+}
+
+func (x *Server) GetAdmin() *Server_Admin {
+	if x != nil {
+		return x.Admin
+	}
+	return nil
+}
+
 type Data struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Postgres      *Data_PostgreSQL       `protobuf:"bytes,1,opt,name=postgres,proto3" json:"postgres,omitempty"`
@@ -725,6 +757,194 @@ func (x *InboxConsumer) GetMetricsEnabled() bool {
 	return false
 }
 
+type Feed struct {
+	state                protoimpl.MessageState     `protogen:"open.v1"`
+	Idempotency          *Feed_Idempotency          `protobuf:"bytes,1,opt,name=idempotency,proto3" json:"idempotency,omitempty"`
+	Guest                *Feed_Guest                `protobuf:"bytes,2,opt,name=guest,proto3" json:"guest,omitempty"`
+	LogWriter            *Feed_LogWriter            `protobuf:"bytes,3,opt,name=log_writer,json=logWriter,proto3" json:"log_writer,omitempty"`
+	LogRetention         *Feed_LogRetention         `protobuf:"bytes,4,opt,name=log_retention,json=logRetention,proto3" json:"log_retention,omitempty"`
+	Pseudonymization     *Feed_Pseudonymization     `protobuf:"bytes,5,opt,name=pseudonymization,proto3" json:"pseudonymization,omitempty"`
+	LogSampling          *Feed_LogSampling          `protobuf:"bytes,6,opt,name=log_sampling,json=logSampling,proto3" json:"log_sampling,omitempty"`
+	Interactions         *Feed_Interactions         `protobuf:"bytes,7,opt,name=interactions,proto3" json:"interactions,omitempty"`
+	ServedEvents         *Feed_ServedEvents         `protobuf:"bytes,8,opt,name=served_events,json=servedEvents,proto3" json:"served_events,omitempty"`
+	UserState            *Feed_UserState            `protobuf:"bytes,9,opt,name=user_state,json=userState,proto3" json:"user_state,omitempty"`
+	WatchedFilter        *Feed_WatchedFilter        `protobuf:"bytes,10,opt,name=watched_filter,json=watchedFilter,proto3" json:"watched_filter,omitempty"`
+	ContinueLearning     *Feed_ContinueLearning     `protobuf:"bytes,11,opt,name=continue_learning,json=continueLearning,proto3" json:"continue_learning,omitempty"`
+	ReviewQueue          *Feed_ReviewQueue          `protobuf:"bytes,12,opt,name=review_queue,json=reviewQueue,proto3" json:"review_queue,omitempty"`
+	Blending             *Feed_Blending             `protobuf:"bytes,13,opt,name=blending,proto3" json:"blending,omitempty"`
+	Rerank               *Feed_Rerank               `protobuf:"bytes,14,opt,name=rerank,proto3" json:"rerank,omitempty"`
+	Curation             *Feed_Curation             `protobuf:"bytes,15,opt,name=curation,proto3" json:"curation,omitempty"`
+	Experiments          *Feed_Experiments          `protobuf:"bytes,16,opt,name=experiments,proto3" json:"experiments,omitempty"`
+	Shadow               *Feed_Shadow               `protobuf:"bytes,17,opt,name=shadow,proto3" json:"shadow,omitempty"`
+	RemoteRecommendation *Feed_RemoteRecommendation `protobuf:"bytes,18,opt,name=remote_recommendation,json=remoteRecommendation,proto3" json:"remote_recommendation,omitempty"`
+	Mock                 *Feed_Mock                 `protobuf:"bytes,19,opt,name=mock,proto3" json:"mock,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Feed) Reset() {
+	*x = Feed{}
+	mi := &file_configs_conf_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed) ProtoMessage() {}
+
+func (x *Feed) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed.ProtoReflect.Descriptor instead.
+func (*Feed) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Feed) GetIdempotency() *Feed_Idempotency {
+	if x != nil {
+		return x.Idempotency
+	}
+	return nil
+}
+
+func (x *Feed) GetGuest() *Feed_Guest {
+	if x != nil {
+		return x.Guest
+	}
+	return nil
+}
+
+func (x *Feed) GetLogWriter() *Feed_LogWriter {
+	if x != nil {
+		return x.LogWriter
+	}
+	return nil
+}
+
+func (x *Feed) GetLogRetention() *Feed_LogRetention {
+	if x != nil {
+		return x.LogRetention
+	}
+	return nil
+}
+
+func (x *Feed) GetPseudonymization() *Feed_Pseudonymization {
+	if x != nil {
+		return x.Pseudonymization
+	}
+	return nil
+}
+
+func (x *Feed) GetLogSampling() *Feed_LogSampling {
+	if x != nil {
+		return x.LogSampling
+	}
+	return nil
+}
+
+func (x *Feed) GetInteractions() *Feed_Interactions {
+	if x != nil {
+		return x.Interactions
+	}
+	return nil
+}
+
+func (x *Feed) GetServedEvents() *Feed_ServedEvents {
+	if x != nil {
+		return x.ServedEvents
+	}
+	return nil
+}
+
+func (x *Feed) GetUserState() *Feed_UserState {
+	if x != nil {
+		return x.UserState
+	}
+	return nil
+}
+
+func (x *Feed) GetWatchedFilter() *Feed_WatchedFilter {
+	if x != nil {
+		return x.WatchedFilter
+	}
+	return nil
+}
+
+func (x *Feed) GetContinueLearning() *Feed_ContinueLearning {
+	if x != nil {
+		return x.ContinueLearning
+	}
+	return nil
+}
+
+func (x *Feed) GetReviewQueue() *Feed_ReviewQueue {
+	if x != nil {
+		return x.ReviewQueue
+	}
+	return nil
+}
+
+func (x *Feed) GetBlending() *Feed_Blending {
+	if x != nil {
+		return x.Blending
+	}
+	return nil
+}
+
+func (x *Feed) GetRerank() *Feed_Rerank {
+	if x != nil {
+		return x.Rerank
+	}
+	return nil
+}
+
+func (x *Feed) GetCuration() *Feed_Curation {
+	if x != nil {
+		return x.Curation
+	}
+	return nil
+}
+
+func (x *Feed) GetExperiments() *Feed_Experiments {
+	if x != nil {
+		return x.Experiments
+	}
+	return nil
+}
+
+func (x *Feed) GetShadow() *Feed_Shadow {
+	if x != nil {
+		return x.Shadow
+	}
+	return nil
+}
+
+func (x *Feed) GetRemoteRecommendation() *Feed_RemoteRecommendation {
+	if x != nil {
+		return x.RemoteRecommendation
+	}
+	return nil
+}
+
+func (x *Feed) GetMock() *Feed_Mock {
+	if x != nil {
+		return x.Mock
+	}
+	return nil
+}
+
 type Server_GRPC struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
@@ -736,7 +956,7 @@ type Server_GRPC struct {
 
 func (x *Server_GRPC) Reset() {
 	*x = Server_GRPC{}
-	mi := &file_configs_conf_proto_msgTypes[9]
+	mi := &file_configs_conf_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -748,7 +968,7 @@ func (x *Server_GRPC) String() string {
 func (*Server_GRPC) ProtoMessage() {}
 
 func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[9]
+	mi := &file_configs_conf_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -797,7 +1017,7 @@ type Server_JWT struct {
 
 func (x *Server_JWT) Reset() {
 	*x = Server_JWT{}
-	mi := &file_configs_conf_proto_msgTypes[10]
+	mi := &file_configs_conf_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -809,7 +1029,7 @@ func (x *Server_JWT) String() string {
 func (*Server_JWT) ProtoMessage() {}
 
 func (x *Server_JWT) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[10]
+	mi := &file_configs_conf_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -864,7 +1084,7 @@ type Server_Handlers struct {
 
 func (x *Server_Handlers) Reset() {
 	*x = Server_Handlers{}
-	mi := &file_configs_conf_proto_msgTypes[11]
+	mi := &file_configs_conf_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -876,7 +1096,7 @@ func (x *Server_Handlers) String() string {
 func (*Server_Handlers) ProtoMessage() {}
 
 func (x *Server_Handlers) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[11]
+	mi := &file_configs_conf_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -913,41 +1133,30 @@ func (x *Server_Handlers) GetQueryTimeout() *durationpb.Duration {
 	return nil
 }
 
-// PostgreSQL 数据库配置（Supabase 专用）
-type Data_PostgreSQL struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// DSN 连接串（必填）
-	Dsn string `protobuf:"bytes,1,opt,name=dsn,proto3" json:"dsn,omitempty"`
-	// 连接池配置
-	MaxOpenConns      int32                `protobuf:"varint,2,opt,name=max_open_conns,json=maxOpenConns,proto3" json:"max_open_conns,omitempty"`
-	MinOpenConns      int32                `protobuf:"varint,3,opt,name=min_open_conns,json=minOpenConns,proto3" json:"min_open_conns,omitempty"`
-	MaxConnLifetime   *durationpb.Duration `protobuf:"bytes,4,opt,name=max_conn_lifetime,json=maxConnLifetime,proto3" json:"max_conn_lifetime,omitempty"`
-	MaxConnIdleTime   *durationpb.Duration `protobuf:"bytes,5,opt,name=max_conn_idle_time,json=maxConnIdleTime,proto3" json:"max_conn_idle_time,omitempty"`
-	HealthCheckPeriod *durationpb.Duration `protobuf:"bytes,6,opt,name=health_check_period,json=healthCheckPeriod,proto3" json:"health_check_period,omitempty"`
-	// Supabase 特定配置
-	Schema                    string                       `protobuf:"bytes,7,opt,name=schema,proto3" json:"schema,omitempty"`                                                                           // 默认 schema（Feed 服务使用 "feed"）
-	PreparedStatementsEnabled bool                         `protobuf:"varint,8,opt,name=prepared_statements_enabled,json=preparedStatementsEnabled,proto3" json:"prepared_statements_enabled,omitempty"` // Pooler 模式建议设为 false
-	Transaction               *Data_PostgreSQL_Transaction `protobuf:"bytes,9,opt,name=transaction,proto3" json:"transaction,omitempty"`
-	PoolMetricsEnabled        *bool                        `protobuf:"varint,10,opt,name=pool_metrics_enabled,json=poolMetricsEnabled,proto3,oneof" json:"pool_metrics_enabled,omitempty"`
-	unknownFields             protoimpl.UnknownFields
-	sizeCache                 protoimpl.SizeCache
+type Server_HTTP struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"` // 留空表示不启动 HTTP/JSON 入口
+	Timeout       *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Data_PostgreSQL) Reset() {
-	*x = Data_PostgreSQL{}
-	mi := &file_configs_conf_proto_msgTypes[12]
+func (x *Server_HTTP) Reset() {
+	*x = Server_HTTP{}
+	mi := &file_configs_conf_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Data_PostgreSQL) String() string {
+func (x *Server_HTTP) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Data_PostgreSQL) ProtoMessage() {}
+func (*Server_HTTP) ProtoMessage() {}
 
-func (x *Data_PostgreSQL) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[12]
+func (x *Server_HTTP) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -958,106 +1167,375 @@ func (x *Data_PostgreSQL) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Data_PostgreSQL.ProtoReflect.Descriptor instead.
-func (*Data_PostgreSQL) Descriptor() ([]byte, []int) {
-	return file_configs_conf_proto_rawDescGZIP(), []int{2, 0}
+// Deprecated: Use Server_HTTP.ProtoReflect.Descriptor instead.
+func (*Server_HTTP) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{1, 3}
 }
 
-func (x *Data_PostgreSQL) GetDsn() string {
+func (x *Server_HTTP) GetNetwork() string {
 	if x != nil {
-		return x.Dsn
+		return x.Network
 	}
 	return ""
 }
 
-func (x *Data_PostgreSQL) GetMaxOpenConns() int32 {
+func (x *Server_HTTP) GetAddr() string {
 	if x != nil {
-		return x.MaxOpenConns
+		return x.Addr
 	}
-	return 0
+	return ""
 }
 
-func (x *Data_PostgreSQL) GetMinOpenConns() int32 {
+func (x *Server_HTTP) GetTimeout() *durationpb.Duration {
 	if x != nil {
-		return x.MinOpenConns
+		return x.Timeout
 	}
-	return 0
+	return nil
 }
 
-func (x *Data_PostgreSQL) GetMaxConnLifetime() *durationpb.Duration {
-	if x != nil {
-		return x.MaxConnLifetime
-	}
-	return nil
+// 用户级令牌桶限流
+type Server_RateLimit struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Enabled       bool                     `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Backend       string                   `protobuf:"bytes,2,opt,name=backend,proto3" json:"backend,omitempty"` // memory（默认，单实例）/ postgres（多实例共享）
+	DefaultRps    float64                  `protobuf:"fixed64,3,opt,name=default_rps,json=defaultRps,proto3" json:"default_rps,omitempty"`
+	DefaultBurst  int32                    `protobuf:"varint,4,opt,name=default_burst,json=defaultBurst,proto3" json:"default_burst,omitempty"`
+	Rules         []*Server_RateLimit_Rule `protobuf:"bytes,5,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Data_PostgreSQL) GetMaxConnIdleTime() *durationpb.Duration {
+func (x *Server_RateLimit) Reset() {
+	*x = Server_RateLimit{}
+	mi := &file_configs_conf_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_RateLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_RateLimit) ProtoMessage() {}
+
+func (x *Server_RateLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[14]
 	if x != nil {
-		return x.MaxConnIdleTime
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Data_PostgreSQL) GetHealthCheckPeriod() *durationpb.Duration {
+// Deprecated: Use Server_RateLimit.ProtoReflect.Descriptor instead.
+func (*Server_RateLimit) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{1, 4}
+}
+
+func (x *Server_RateLimit) GetEnabled() bool {
 	if x != nil {
-		return x.HealthCheckPeriod
+		return x.Enabled
 	}
-	return nil
+	return false
 }
 
-func (x *Data_PostgreSQL) GetSchema() string {
+func (x *Server_RateLimit) GetBackend() string {
 	if x != nil {
-		return x.Schema
+		return x.Backend
 	}
 	return ""
 }
 
-func (x *Data_PostgreSQL) GetPreparedStatementsEnabled() bool {
+func (x *Server_RateLimit) GetDefaultRps() float64 {
 	if x != nil {
-		return x.PreparedStatementsEnabled
+		return x.DefaultRps
 	}
-	return false
+	return 0
 }
 
-func (x *Data_PostgreSQL) GetTransaction() *Data_PostgreSQL_Transaction {
+func (x *Server_RateLimit) GetDefaultBurst() int32 {
 	if x != nil {
-		return x.Transaction
+		return x.DefaultBurst
 	}
-	return nil
+	return 0
 }
 
-func (x *Data_PostgreSQL) GetPoolMetricsEnabled() bool {
-	if x != nil && x.PoolMetricsEnabled != nil {
-		return *x.PoolMetricsEnabled
+func (x *Server_RateLimit) GetRules() []*Server_RateLimit_Rule {
+	if x != nil {
+		return x.Rules
 	}
-	return false
+	return nil
 }
 
-// gRPC Client 配置（可选，用于服务间调用）
-type Data_Client struct {
+// 管理端 feed.admin.v1（仅 gRPC），要求签名校验通过且 aud 命中 audience 的 ID Token
+type Server_Admin struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Target        string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"` // 目标地址，留空则不创建 client
-	Jwt           *Data_Client_JWT       `protobuf:"bytes,2,opt,name=jwt,proto3" json:"jwt,omitempty"`
-	MetadataKeys  []string               `protobuf:"bytes,3,rep,name=metadata_keys,json=metadataKeys,proto3" json:"metadata_keys,omitempty"` // 转发到下游的 header 列表（默认同 server.metadata_keys）
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`  // false 时不注册管理端服务
+	Audience      string                 `protobuf:"bytes,2,opt,name=audience,proto3" json:"audience,omitempty"` // 管理端专用 audience，与公共 API 区分；启用时必填
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Data_Client) Reset() {
-	*x = Data_Client{}
-	mi := &file_configs_conf_proto_msgTypes[13]
+func (x *Server_Admin) Reset() {
+	*x = Server_Admin{}
+	mi := &file_configs_conf_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Data_Client) String() string {
+func (x *Server_Admin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Admin) ProtoMessage() {}
+
+func (x *Server_Admin) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Admin.ProtoReflect.Descriptor instead.
+func (*Server_Admin) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{1, 5}
+}
+
+func (x *Server_Admin) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Server_Admin) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+type Server_RateLimit_Rule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Method        string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"` // RPC 方法名，例如 GetFeed
+	Scene         string                 `protobuf:"bytes,2,opt,name=scene,proto3" json:"scene,omitempty"`   // 推荐场景，留空表示该方法下所有场景
+	Rps           float64                `protobuf:"fixed64,3,opt,name=rps,proto3" json:"rps,omitempty"`
+	Burst         int32                  `protobuf:"varint,4,opt,name=burst,proto3" json:"burst,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_RateLimit_Rule) Reset() {
+	*x = Server_RateLimit_Rule{}
+	mi := &file_configs_conf_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_RateLimit_Rule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_RateLimit_Rule) ProtoMessage() {}
+
+func (x *Server_RateLimit_Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_RateLimit_Rule.ProtoReflect.Descriptor instead.
+func (*Server_RateLimit_Rule) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{1, 4, 0}
+}
+
+func (x *Server_RateLimit_Rule) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *Server_RateLimit_Rule) GetScene() string {
+	if x != nil {
+		return x.Scene
+	}
+	return ""
+}
+
+func (x *Server_RateLimit_Rule) GetRps() float64 {
+	if x != nil {
+		return x.Rps
+	}
+	return 0
+}
+
+func (x *Server_RateLimit_Rule) GetBurst() int32 {
+	if x != nil {
+		return x.Burst
+	}
+	return 0
+}
+
+// PostgreSQL 数据库配置（Supabase 专用）
+type Data_PostgreSQL struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// DSN 连接串（必填）
+	Dsn string `protobuf:"bytes,1,opt,name=dsn,proto3" json:"dsn,omitempty"`
+	// 连接池配置
+	MaxOpenConns      int32                `protobuf:"varint,2,opt,name=max_open_conns,json=maxOpenConns,proto3" json:"max_open_conns,omitempty"`
+	MinOpenConns      int32                `protobuf:"varint,3,opt,name=min_open_conns,json=minOpenConns,proto3" json:"min_open_conns,omitempty"`
+	MaxConnLifetime   *durationpb.Duration `protobuf:"bytes,4,opt,name=max_conn_lifetime,json=maxConnLifetime,proto3" json:"max_conn_lifetime,omitempty"`
+	MaxConnIdleTime   *durationpb.Duration `protobuf:"bytes,5,opt,name=max_conn_idle_time,json=maxConnIdleTime,proto3" json:"max_conn_idle_time,omitempty"`
+	HealthCheckPeriod *durationpb.Duration `protobuf:"bytes,6,opt,name=health_check_period,json=healthCheckPeriod,proto3" json:"health_check_period,omitempty"`
+	// Supabase 特定配置
+	Schema                    string                       `protobuf:"bytes,7,opt,name=schema,proto3" json:"schema,omitempty"`                                                                           // 默认 schema（Feed 服务使用 "feed"）
+	PreparedStatementsEnabled bool                         `protobuf:"varint,8,opt,name=prepared_statements_enabled,json=preparedStatementsEnabled,proto3" json:"prepared_statements_enabled,omitempty"` // Pooler 模式建议设为 false
+	Transaction               *Data_PostgreSQL_Transaction `protobuf:"bytes,9,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	PoolMetricsEnabled        *bool                        `protobuf:"varint,10,opt,name=pool_metrics_enabled,json=poolMetricsEnabled,proto3,oneof" json:"pool_metrics_enabled,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *Data_PostgreSQL) Reset() {
+	*x = Data_PostgreSQL{}
+	mi := &file_configs_conf_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_PostgreSQL) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_PostgreSQL) ProtoMessage() {}
+
+func (x *Data_PostgreSQL) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_PostgreSQL.ProtoReflect.Descriptor instead.
+func (*Data_PostgreSQL) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *Data_PostgreSQL) GetDsn() string {
+	if x != nil {
+		return x.Dsn
+	}
+	return ""
+}
+
+func (x *Data_PostgreSQL) GetMaxOpenConns() int32 {
+	if x != nil {
+		return x.MaxOpenConns
+	}
+	return 0
+}
+
+func (x *Data_PostgreSQL) GetMinOpenConns() int32 {
+	if x != nil {
+		return x.MinOpenConns
+	}
+	return 0
+}
+
+func (x *Data_PostgreSQL) GetMaxConnLifetime() *durationpb.Duration {
+	if x != nil {
+		return x.MaxConnLifetime
+	}
+	return nil
+}
+
+func (x *Data_PostgreSQL) GetMaxConnIdleTime() *durationpb.Duration {
+	if x != nil {
+		return x.MaxConnIdleTime
+	}
+	return nil
+}
+
+func (x *Data_PostgreSQL) GetHealthCheckPeriod() *durationpb.Duration {
+	if x != nil {
+		return x.HealthCheckPeriod
+	}
+	return nil
+}
+
+func (x *Data_PostgreSQL) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *Data_PostgreSQL) GetPreparedStatementsEnabled() bool {
+	if x != nil {
+		return x.PreparedStatementsEnabled
+	}
+	return false
+}
+
+func (x *Data_PostgreSQL) GetTransaction() *Data_PostgreSQL_Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *Data_PostgreSQL) GetPoolMetricsEnabled() bool {
+	if x != nil && x.PoolMetricsEnabled != nil {
+		return *x.PoolMetricsEnabled
+	}
+	return false
+}
+
+// gRPC Client 配置（可选，用于服务间调用）
+type Data_Client struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"` // 目标地址，留空则不创建 client
+	Jwt           *Data_Client_JWT       `protobuf:"bytes,2,opt,name=jwt,proto3" json:"jwt,omitempty"`
+	MetadataKeys  []string               `protobuf:"bytes,3,rep,name=metadata_keys,json=metadataKeys,proto3" json:"metadata_keys,omitempty"` // 转发到下游的 header 列表（默认同 server.metadata_keys）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Client) Reset() {
+	*x = Data_Client{}
+	mi := &file_configs_conf_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Client) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
 func (*Data_Client) ProtoMessage() {}
 
 func (x *Data_Client) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[13]
+	mi := &file_configs_conf_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1107,7 +1585,7 @@ type Data_PostgreSQL_Transaction struct {
 
 func (x *Data_PostgreSQL_Transaction) Reset() {
 	*x = Data_PostgreSQL_Transaction{}
-	mi := &file_configs_conf_proto_msgTypes[14]
+	mi := &file_configs_conf_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1119,7 +1597,7 @@ func (x *Data_PostgreSQL_Transaction) String() string {
 func (*Data_PostgreSQL_Transaction) ProtoMessage() {}
 
 func (x *Data_PostgreSQL_Transaction) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[14]
+	mi := &file_configs_conf_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1181,7 +1659,7 @@ type Data_Client_JWT struct {
 
 func (x *Data_Client_JWT) Reset() {
 	*x = Data_Client_JWT{}
-	mi := &file_configs_conf_proto_msgTypes[15]
+	mi := &file_configs_conf_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1193,7 +1671,7 @@ func (x *Data_Client_JWT) String() string {
 func (*Data_Client_JWT) ProtoMessage() {}
 
 func (x *Data_Client_JWT) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[15]
+	mi := &file_configs_conf_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1253,7 +1731,7 @@ type Observability_Tracing struct {
 
 func (x *Observability_Tracing) Reset() {
 	*x = Observability_Tracing{}
-	mi := &file_configs_conf_proto_msgTypes[16]
+	mi := &file_configs_conf_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1265,7 +1743,7 @@ func (x *Observability_Tracing) String() string {
 func (*Observability_Tracing) ProtoMessage() {}
 
 func (x *Observability_Tracing) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[16]
+	mi := &file_configs_conf_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1405,7 +1883,7 @@ type Observability_Metrics struct {
 
 func (x *Observability_Metrics) Reset() {
 	*x = Observability_Metrics{}
-	mi := &file_configs_conf_proto_msgTypes[17]
+	mi := &file_configs_conf_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1417,7 +1895,7 @@ func (x *Observability_Metrics) String() string {
 func (*Observability_Metrics) ProtoMessage() {}
 
 func (x *Observability_Metrics) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[17]
+	mi := &file_configs_conf_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1510,41 +1988,2062 @@ func (x *Observability_Metrics) GetGrpcIncludeHealth() bool {
 	return false
 }
 
-var File_configs_conf_proto protoreflect.FileDescriptor
+type Feed_Idempotency struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Ttl           *durationpb.Duration   `protobuf:"bytes,2,opt,name=ttl,proto3" json:"ttl,omitempty"` // 幂等快照保留时长，默认 10m
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_configs_conf_proto_rawDesc = "" +
-	"\n" +
-	"\x12configs/conf.proto\x12\n" +
-	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bbuf/validate/validate.proto\"\xd3\x01\n" +
-	"\tBootstrap\x12*\n" +
-	"\x06server\x18\x01 \x01(\v2\x12.kratos.api.ServerR\x06server\x12$\n" +
-	"\x04data\x18\x02 \x01(\v2\x10.kratos.api.DataR\x04data\x12?\n" +
-	"\robservability\x18\x03 \x01(\v2\x19.kratos.api.ObservabilityR\robservability\x123\n" +
-	"\tmessaging\x18\x04 \x01(\v2\x15.kratos.api.MessagingR\tmessaging\"\x92\x05\n" +
-	"\x06Server\x12+\n" +
-	"\x04grpc\x18\x01 \x01(\v2\x17.kratos.api.Server.GRPCR\x04grpc\x12(\n" +
-	"\x03jwt\x18\x02 \x01(\v2\x16.kratos.api.Server.JWTR\x03jwt\x127\n" +
-	"\bhandlers\x18\x03 \x01(\v2\x1b.kratos.api.Server.HandlersR\bhandlers\x12#\n" +
-	"\rmetadata_keys\x18\x04 \x03(\tR\fmetadataKeys\x1ai\n" +
-	"\x04GRPC\x12\x18\n" +
-	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
-	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
-	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1a\x92\x01\n" +
-	"\x03JWT\x12+\n" +
-	"\x11expected_audience\x18\x01 \x01(\tR\x10expectedAudience\x12#\n" +
-	"\rskip_validate\x18\x02 \x01(\bR\fskipValidate\x12\x1a\n" +
-	"\brequired\x18\x03 \x01(\bR\brequired\x12\x1d\n" +
-	"\n" +
-	"header_key\x18\x04 \x01(\tR\theaderKey\x1a\xd2\x01\n" +
-	"\bHandlers\x12B\n" +
-	"\x0fdefault_timeout\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x0edefaultTimeout\x12B\n" +
-	"\x0fcommand_timeout\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x0ecommandTimeout\x12>\n" +
-	"\rquery_timeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\fqueryTimeout\"\xe6\t\n" +
-	"\x04Data\x12?\n" +
-	"\bpostgres\x18\x01 \x01(\v2\x1b.kratos.api.Data.PostgreSQLB\x06\xbaH\x03\xc8\x01\x01R\bpostgres\x128\n" +
-	"\vgrpc_client\x18\x02 \x01(\v2\x17.kratos.api.Data.ClientR\n" +
-	"grpcClient\x1a\x8d\a\n" +
-	"\n" +
+func (x *Feed_Idempotency) Reset() {
+	*x = Feed_Idempotency{}
+	mi := &file_configs_conf_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_Idempotency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_Idempotency) ProtoMessage() {}
+
+func (x *Feed_Idempotency) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_Idempotency.ProtoReflect.Descriptor instead.
+func (*Feed_Idempotency) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 0}
+}
+
+func (x *Feed_Idempotency) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Feed_Idempotency) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+type Feed_Guest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Enabled              bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`                                                           // 允许无 userinfo 的请求凭 x-md-device-id 以访客身份访问
+	CacheTtl             *durationpb.Duration   `protobuf:"bytes,2,opt,name=cache_ttl,json=cacheTtl,proto3" json:"cache_ttl,omitempty"`                                          // 访客 Feed 缓存时长，默认 60s
+	RateLimitRps         float64                `protobuf:"fixed64,3,opt,name=rate_limit_rps,json=rateLimitRps,proto3" json:"rate_limit_rps,omitempty"`                          // 访客流量共享的每秒请求数，默认 50
+	RateLimitBurst       int32                  `protobuf:"varint,4,opt,name=rate_limit_burst,json=rateLimitBurst,proto3" json:"rate_limit_burst,omitempty"`                     // 访客令牌桶容量，默认 100
+	DeviceRateLimitRps   float64                `protobuf:"fixed64,5,opt,name=device_rate_limit_rps,json=deviceRateLimitRps,proto3" json:"device_rate_limit_rps,omitempty"`      // 单个访客（设备派生 ID）的每秒请求数，默认 1；经 server.rate_limit 的限流器与存储后端计数，未启用时不生效
+	DeviceRateLimitBurst int32                  `protobuf:"varint,6,opt,name=device_rate_limit_burst,json=deviceRateLimitBurst,proto3" json:"device_rate_limit_burst,omitempty"` // 单个访客的令牌桶容量，默认 10
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Feed_Guest) Reset() {
+	*x = Feed_Guest{}
+	mi := &file_configs_conf_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_Guest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_Guest) ProtoMessage() {}
+
+func (x *Feed_Guest) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_Guest.ProtoReflect.Descriptor instead.
+func (*Feed_Guest) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 1}
+}
+
+func (x *Feed_Guest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Feed_Guest) GetCacheTtl() *durationpb.Duration {
+	if x != nil {
+		return x.CacheTtl
+	}
+	return nil
+}
+
+func (x *Feed_Guest) GetRateLimitRps() float64 {
+	if x != nil {
+		return x.RateLimitRps
+	}
+	return 0
+}
+
+func (x *Feed_Guest) GetRateLimitBurst() int32 {
+	if x != nil {
+		return x.RateLimitBurst
+	}
+	return 0
+}
+
+func (x *Feed_Guest) GetDeviceRateLimitRps() float64 {
+	if x != nil {
+		return x.DeviceRateLimitRps
+	}
+	return 0
+}
+
+func (x *Feed_Guest) GetDeviceRateLimitBurst() int32 {
+	if x != nil {
+		return x.DeviceRateLimitBurst
+	}
+	return 0
+}
+
+type Feed_LogWriter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Async         bool                   `protobuf:"varint,1,opt,name=async,proto3" json:"async,omitempty"`                                     // true 时推荐日志入队后由后台按批 COPY 写入
+	QueueSize     int32                  `protobuf:"varint,2,opt,name=queue_size,json=queueSize,proto3" json:"queue_size,omitempty"`            // 异步队列容量，默认 1024
+	BatchSize     int32                  `protobuf:"varint,3,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`            // 单批最大条数，默认 100
+	FlushInterval *durationpb.Duration   `protobuf:"bytes,4,opt,name=flush_interval,json=flushInterval,proto3" json:"flush_interval,omitempty"` // 定时刷盘周期，默认 1s
+	FlushTimeout  *durationpb.Duration   `protobuf:"bytes,5,opt,name=flush_timeout,json=flushTimeout,proto3" json:"flush_timeout,omitempty"`    // 单批写入与关闭排空超时，默认 5s
+	Overflow      string                 `protobuf:"bytes,6,opt,name=overflow,proto3" json:"overflow,omitempty"`                                // 队列满时策略：drop（默认）/ block
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feed_LogWriter) Reset() {
+	*x = Feed_LogWriter{}
+	mi := &file_configs_conf_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_LogWriter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_LogWriter) ProtoMessage() {}
+
+func (x *Feed_LogWriter) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_LogWriter.ProtoReflect.Descriptor instead.
+func (*Feed_LogWriter) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 2}
+}
+
+func (x *Feed_LogWriter) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+func (x *Feed_LogWriter) GetQueueSize() int32 {
+	if x != nil {
+		return x.QueueSize
+	}
+	return 0
+}
+
+func (x *Feed_LogWriter) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *Feed_LogWriter) GetFlushInterval() *durationpb.Duration {
+	if x != nil {
+		return x.FlushInterval
+	}
+	return nil
+}
+
+func (x *Feed_LogWriter) GetFlushTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.FlushTimeout
+	}
+	return nil
+}
+
+func (x *Feed_LogWriter) GetOverflow() string {
+	if x != nil {
+		return x.Overflow
+	}
+	return ""
+}
+
+type Feed_LogRetention struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Enabled             bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`                                                     // 是否运行 cmd/tasks/log_retention 分区维护
+	Retention           *durationpb.Duration   `protobuf:"bytes,2,opt,name=retention,proto3" json:"retention,omitempty"`                                                  // 推荐日志保留时长，默认 720h（30 天），0 表示不删除
+	PremakeDays         int32                  `protobuf:"varint,3,opt,name=premake_days,json=premakeDays,proto3" json:"premake_days,omitempty"`                          // 预建未来分区天数，默认 7
+	Interval            *durationpb.Duration   `protobuf:"bytes,4,opt,name=interval,proto3" json:"interval,omitempty"`                                                    // 维护周期，默认 1h
+	ServedPageRetention *durationpb.Duration   `protobuf:"bytes,5,opt,name=served_page_retention,json=servedPageRetention,proto3" json:"served_page_retention,omitempty"` // 下发页记录保留时长，默认 48h，不短于交互事件的 max_event_age + clock_skew
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Feed_LogRetention) Reset() {
+	*x = Feed_LogRetention{}
+	mi := &file_configs_conf_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_LogRetention) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_LogRetention) ProtoMessage() {}
+
+func (x *Feed_LogRetention) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_LogRetention.ProtoReflect.Descriptor instead.
+func (*Feed_LogRetention) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 3}
+}
+
+func (x *Feed_LogRetention) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Feed_LogRetention) GetRetention() *durationpb.Duration {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+func (x *Feed_LogRetention) GetPremakeDays() int32 {
+	if x != nil {
+		return x.PremakeDays
+	}
+	return 0
+}
+
+func (x *Feed_LogRetention) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *Feed_LogRetention) GetServedPageRetention() *durationpb.Duration {
+	if x != nil {
+		return x.ServedPageRetention
+	}
+	return nil
+}
+
+type Feed_Pseudonymization struct {
+	state            protoimpl.MessageState       `protogen:"open.v1"`
+	ActiveKeyVersion string                       `protobuf:"bytes,1,opt,name=active_key_version,json=activeKeyVersion,proto3" json:"active_key_version,omitempty"` // 新日志使用的密钥版本，缺省取最后一个
+	Keys             []*Feed_Pseudonymization_Key `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`                                                   // 历史密钥需保留以便按用户回查旧日志
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Feed_Pseudonymization) Reset() {
+	*x = Feed_Pseudonymization{}
+	mi := &file_configs_conf_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_Pseudonymization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_Pseudonymization) ProtoMessage() {}
+
+func (x *Feed_Pseudonymization) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_Pseudonymization.ProtoReflect.Descriptor instead.
+func (*Feed_Pseudonymization) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 4}
+}
+
+func (x *Feed_Pseudonymization) GetActiveKeyVersion() string {
+	if x != nil {
+		return x.ActiveKeyVersion
+	}
+	return ""
+}
+
+func (x *Feed_Pseudonymization) GetKeys() []*Feed_Pseudonymization_Key {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type Feed_LogSampling struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Enabled       bool                     `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`                                // false 时全量记录推荐日志
+	DefaultRate   float64                  `protobuf:"fixed64,2,opt,name=default_rate,json=defaultRate,proto3" json:"default_rate,omitempty"`    // 未命中规则时的采样率
+	Rules         []*Feed_LogSampling_Rule `protobuf:"bytes,3,rep,name=rules,proto3" json:"rules,omitempty"`                                     // 优先级：source+scene > source > scene
+	DebugUserIds  []string                 `protobuf:"bytes,4,rep,name=debug_user_ids,json=debugUserIds,proto3" json:"debug_user_ids,omitempty"` // 始终全量记录的用户
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feed_LogSampling) Reset() {
+	*x = Feed_LogSampling{}
+	mi := &file_configs_conf_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_LogSampling) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_LogSampling) ProtoMessage() {}
+
+func (x *Feed_LogSampling) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_LogSampling.ProtoReflect.Descriptor instead.
+func (*Feed_LogSampling) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 5}
+}
+
+func (x *Feed_LogSampling) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Feed_LogSampling) GetDefaultRate() float64 {
+	if x != nil {
+		return x.DefaultRate
+	}
+	return 0
+}
+
+func (x *Feed_LogSampling) GetRules() []*Feed_LogSampling_Rule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *Feed_LogSampling) GetDebugUserIds() []string {
+	if x != nil {
+		return x.DebugUserIds
+	}
+	return nil
+}
+
+type Feed_Interactions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`                                 // 是否接受 ReportInteractions 上报
+	Topic         string                 `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`                                      // Feed 事件（交互与 served）发布目标，对应 messaging.topics 的键，默认 feed_events
+	MaxBatchSize  int32                  `protobuf:"varint,3,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"` // 单次上报最大事件数，默认 500
+	MaxEventAge   *durationpb.Duration   `protobuf:"bytes,4,opt,name=max_event_age,json=maxEventAge,proto3" json:"max_event_age,omitempty"`     // occurred_at 距今的最大时长，默认 24h
+	ClockSkew     *durationpb.Duration   `protobuf:"bytes,5,opt,name=clock_skew,json=clockSkew,proto3" json:"clock_skew,omitempty"`             // 允许的客户端时钟偏差，默认 5m
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feed_Interactions) Reset() {
+	*x = Feed_Interactions{}
+	mi := &file_configs_conf_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_Interactions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_Interactions) ProtoMessage() {}
+
+func (x *Feed_Interactions) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_Interactions.ProtoReflect.Descriptor instead.
+func (*Feed_Interactions) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 6}
+}
+
+func (x *Feed_Interactions) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Feed_Interactions) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *Feed_Interactions) GetMaxBatchSize() int32 {
+	if x != nil {
+		return x.MaxBatchSize
+	}
+	return 0
+}
+
+func (x *Feed_Interactions) GetMaxEventAge() *durationpb.Duration {
+	if x != nil {
+		return x.MaxEventAge
+	}
+	return nil
+}
+
+func (x *Feed_Interactions) GetClockSkew() *durationpb.Duration {
+	if x != nil {
+		return x.ClockSkew
+	}
+	return nil
+}
+
+type Feed_ServedEvents struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"` // 写推荐日志时在同一事务内写入 FeedServedEvent 到 feed.outbox_events
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feed_ServedEvents) Reset() {
+	*x = Feed_ServedEvents{}
+	mi := &file_configs_conf_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_ServedEvents) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_ServedEvents) ProtoMessage() {}
+
+func (x *Feed_ServedEvents) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_ServedEvents.ProtoReflect.Descriptor instead.
+func (*Feed_ServedEvents) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 7}
+}
+
+func (x *Feed_ServedEvents) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type Feed_UserState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"` // 登录用户的卡片补充点赞/收藏/观看进度
+	Topic         string                 `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`      // Profile 事件订阅，对应 messaging.topics 的键，默认 profile_events
+	Inbox         string                 `protobuf:"bytes,3,opt,name=inbox,proto3" json:"inbox,omitempty"`      // Inbox 配置，对应 messaging.inboxes 的键，默认 profile
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feed_UserState) Reset() {
+	*x = Feed_UserState{}
+	mi := &file_configs_conf_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_UserState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_UserState) ProtoMessage() {}
+
+func (x *Feed_UserState) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_UserState.ProtoReflect.Descriptor instead.
+func (*Feed_UserState) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 8}
+}
+
+func (x *Feed_UserState) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Feed_UserState) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *Feed_UserState) GetInbox() string {
+	if x != nil {
+		return x.Inbox
+	}
+	return ""
+}
+
+type Feed_WatchedFilter struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Enabled       bool                        `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`                             // 依据 feed.user_watch_history 过滤登录用户已看过的视频
+	DropRatio     float64                     `protobuf:"fixed64,2,opt,name=drop_ratio,json=dropRatio,proto3" json:"drop_ratio,omitempty"`       // 历史最高进度达到该值视为看完并剔除，默认 0.9
+	DemoteRatio   float64                     `protobuf:"fixed64,3,opt,name=demote_ratio,json=demoteRatio,proto3" json:"demote_ratio,omitempty"` // 历史最高进度达到该值（且未看完）时移到本页末尾，0 表示不降权
+	Scenes        []*Feed_WatchedFilter_Scene `protobuf:"bytes,4,rep,name=scenes,proto3" json:"scenes,omitempty"`                                // 按场景覆盖阈值
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feed_WatchedFilter) Reset() {
+	*x = Feed_WatchedFilter{}
+	mi := &file_configs_conf_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_WatchedFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_WatchedFilter) ProtoMessage() {}
+
+func (x *Feed_WatchedFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_WatchedFilter.ProtoReflect.Descriptor instead.
+func (*Feed_WatchedFilter) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 9}
+}
+
+func (x *Feed_WatchedFilter) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Feed_WatchedFilter) GetDropRatio() float64 {
+	if x != nil {
+		return x.DropRatio
+	}
+	return 0
+}
+
+func (x *Feed_WatchedFilter) GetDemoteRatio() float64 {
+	if x != nil {
+		return x.DemoteRatio
+	}
+	return 0
+}
+
+func (x *Feed_WatchedFilter) GetScenes() []*Feed_WatchedFilter_Scene {
+	if x != nil {
+		return x.Scenes
+	}
+	return nil
+}
+
+type Feed_ContinueLearning struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`                    // 开启 continue_learning 场景：基于 feed.user_video_state 列出看到一半的视频
+	MinRatio      float64                `protobuf:"fixed64,2,opt,name=min_ratio,json=minRatio,proto3" json:"min_ratio,omitempty"` // 入选的最低观看进度，默认 0.05
+	MaxRatio      float64                `protobuf:"fixed64,3,opt,name=max_ratio,json=maxRatio,proto3" json:"max_ratio,omitempty"` // 入选的最高观看进度，默认 0.9
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feed_ContinueLearning) Reset() {
+	*x = Feed_ContinueLearning{}
+	mi := &file_configs_conf_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_ContinueLearning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_ContinueLearning) ProtoMessage() {}
+
+func (x *Feed_ContinueLearning) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_ContinueLearning.ProtoReflect.Descriptor instead.
+func (*Feed_ContinueLearning) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 10}
+}
+
+func (x *Feed_ContinueLearning) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Feed_ContinueLearning) GetMinRatio() float64 {
+	if x != nil {
+		return x.MinRatio
+	}
+	return 0
+}
+
+func (x *Feed_ContinueLearning) GetMaxRatio() float64 {
+	if x != nil {
+		return x.MaxRatio
+	}
+	return 0
+}
+
+type Feed_ReviewQueue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`                                   // 开启 review 场景：基于 feed.review_schedule 返回到期的复习视频
+	Topic         string                 `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`                                        // Learning 事件订阅，对应 messaging.topics 的键，默认 learning_events
+	Inbox         string                 `protobuf:"bytes,3,opt,name=inbox,proto3" json:"inbox,omitempty"`                                        // Inbox 配置，对应 messaging.inboxes 的键，默认 learning
+	BlendFraction float64                `protobuf:"fixed64,4,opt,name=blend_fraction,json=blendFraction,proto3" json:"blend_fraction,omitempty"` // 首页第一页中复习条目的占比，0 表示不混排
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feed_ReviewQueue) Reset() {
+	*x = Feed_ReviewQueue{}
+	mi := &file_configs_conf_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_ReviewQueue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_ReviewQueue) ProtoMessage() {}
+
+func (x *Feed_ReviewQueue) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_ReviewQueue.ProtoReflect.Descriptor instead.
+func (*Feed_ReviewQueue) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 11}
+}
+
+func (x *Feed_ReviewQueue) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Feed_ReviewQueue) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *Feed_ReviewQueue) GetInbox() string {
+	if x != nil {
+		return x.Inbox
+	}
+	return ""
+}
+
+func (x *Feed_ReviewQueue) GetBlendFraction() float64 {
+	if x != nil {
+		return x.BlendFraction
+	}
+	return 0
+}
+
+type Feed_Blending struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Enabled       bool                    `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`                                 // 首页并发调用多个推荐源并交织结果
+	Strategy      string                  `protobuf:"bytes,2,opt,name=strategy,proto3" json:"strategy,omitempty"`                                // slots：按权重固定槽位；weighted_round_robin：平滑加权轮询，默认 slots
+	Sources       []*Feed_Blending_Source `protobuf:"bytes,3,rep,name=sources,proto3" json:"sources,omitempty"`                                  // 按优先级排列，非首页场景走第一个来源
+	DefaultBudget *durationpb.Duration    `protobuf:"bytes,4,opt,name=default_budget,json=defaultBudget,proto3" json:"default_budget,omitempty"` // 默认来源超时，默认 150ms
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feed_Blending) Reset() {
+	*x = Feed_Blending{}
+	mi := &file_configs_conf_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_Blending) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_Blending) ProtoMessage() {}
+
+func (x *Feed_Blending) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_Blending.ProtoReflect.Descriptor instead.
+func (*Feed_Blending) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 12}
+}
+
+func (x *Feed_Blending) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Feed_Blending) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *Feed_Blending) GetSources() []*Feed_Blending_Source {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *Feed_Blending) GetDefaultBudget() *durationpb.Duration {
+	if x != nil {
+		return x.DefaultBudget
+	}
+	return nil
+}
+
+type Feed_Rerank struct {
+	state          protoimpl.MessageState      `protogen:"open.v1"`
+	Mmr            *Feed_Rerank_MMR            `protobuf:"bytes,1,opt,name=mmr,proto3" json:"mmr,omitempty"`
+	CreatorCap     *Feed_Rerank_CreatorCap     `protobuf:"bytes,2,opt,name=creator_cap,json=creatorCap,proto3" json:"creator_cap,omitempty"`
+	LanguageRun    *Feed_Rerank_LanguageRun    `protobuf:"bytes,3,opt,name=language_run,json=languageRun,proto3" json:"language_run,omitempty"`
+	DurationSpread *Feed_Rerank_DurationSpread `protobuf:"bytes,4,opt,name=duration_spread,json=durationSpread,proto3" json:"duration_spread,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Feed_Rerank) Reset() {
+	*x = Feed_Rerank{}
+	mi := &file_configs_conf_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_Rerank) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_Rerank) ProtoMessage() {}
+
+func (x *Feed_Rerank) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_Rerank.ProtoReflect.Descriptor instead.
+func (*Feed_Rerank) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 13}
+}
+
+func (x *Feed_Rerank) GetMmr() *Feed_Rerank_MMR {
+	if x != nil {
+		return x.Mmr
+	}
+	return nil
+}
+
+func (x *Feed_Rerank) GetCreatorCap() *Feed_Rerank_CreatorCap {
+	if x != nil {
+		return x.CreatorCap
+	}
+	return nil
+}
+
+func (x *Feed_Rerank) GetLanguageRun() *Feed_Rerank_LanguageRun {
+	if x != nil {
+		return x.LanguageRun
+	}
+	return nil
+}
+
+func (x *Feed_Rerank) GetDurationSpread() *Feed_Rerank_DurationSpread {
+	if x != nil {
+		return x.DurationSpread
+	}
+	return nil
+}
+
+type Feed_Curation struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Enabled          bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`                                          // 开启运营干预规则（feed.curation_rules），规则缓存在内存中，经 LISTEN/NOTIFY 刷新
+	RefreshInterval  *durationpb.Duration   `protobuf:"bytes,2,opt,name=refresh_interval,json=refreshInterval,proto3" json:"refresh_interval,omitempty"`    // 兜底全量刷新间隔，默认 5m
+	ReconnectBackoff *durationpb.Duration   `protobuf:"bytes,3,opt,name=reconnect_backoff,json=reconnectBackoff,proto3" json:"reconnect_backoff,omitempty"` // LISTEN 连接断开后的重连间隔，默认 5s
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Feed_Curation) Reset() {
+	*x = Feed_Curation{}
+	mi := &file_configs_conf_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_Curation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_Curation) ProtoMessage() {}
+
+func (x *Feed_Curation) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_Curation.ProtoReflect.Descriptor instead.
+func (*Feed_Curation) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 14}
+}
+
+func (x *Feed_Curation) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Feed_Curation) GetRefreshInterval() *durationpb.Duration {
+	if x != nil {
+		return x.RefreshInterval
+	}
+	return nil
+}
+
+func (x *Feed_Curation) GetReconnectBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.ReconnectBackoff
+	}
+	return nil
+}
+
+type Feed_Experiments struct {
+	state               protoimpl.MessageState         `protogen:"open.v1"`
+	Enabled             bool                           `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`                                                      // 开启 A/B 实验：按 user_id 哈希确定性分桶，访客不参与
+	AllowForcedVariants bool                           `protobuf:"varint,2,opt,name=allow_forced_variants,json=allowForcedVariants,proto3" json:"allow_forced_variants,omitempty"` // 接受 x-md-experiment-variants 请求头强制命中分组，仅供 QA 环境开启
+	Experiments         []*Feed_Experiments_Experiment `protobuf:"bytes,3,rep,name=experiments,proto3" json:"experiments,omitempty"`                                               // 多个实验独立分桶；覆盖项冲突时以靠后的实验为准
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Feed_Experiments) Reset() {
+	*x = Feed_Experiments{}
+	mi := &file_configs_conf_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_Experiments) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_Experiments) ProtoMessage() {}
+
+func (x *Feed_Experiments) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_Experiments.ProtoReflect.Descriptor instead.
+func (*Feed_Experiments) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 15}
+}
+
+func (x *Feed_Experiments) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Feed_Experiments) GetAllowForcedVariants() bool {
+	if x != nil {
+		return x.AllowForcedVariants
+	}
+	return false
+}
+
+func (x *Feed_Experiments) GetExperiments() []*Feed_Experiments_Experiment {
+	if x != nil {
+		return x.Experiments
+	}
+	return nil
+}
+
+type Feed_Shadow struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Enabled        bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`                                     // 影子流量：主推荐照常返回，同一请求异步调用候选推荐源并比对结果，不影响用户
+	Candidate      string                 `protobuf:"bytes,2,opt,name=candidate,proto3" json:"candidate,omitempty"`                                  // 候选推荐源：mock / fresh / review / remote
+	Budget         *durationpb.Duration   `protobuf:"bytes,3,opt,name=budget,proto3" json:"budget,omitempty"`                                        // 候选调用与投影命中检查的超时，默认 300ms
+	MaxConcurrency int32                  `protobuf:"varint,4,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"` // 同时进行的影子调用上限，超出即丢弃，默认 8
+	SampleRate     float64                `protobuf:"fixed64,5,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"`            // 参与影子比对的请求比例，0 取默认 1
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Feed_Shadow) Reset() {
+	*x = Feed_Shadow{}
+	mi := &file_configs_conf_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_Shadow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_Shadow) ProtoMessage() {}
+
+func (x *Feed_Shadow) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_Shadow.ProtoReflect.Descriptor instead.
+func (*Feed_Shadow) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 16}
+}
+
+func (x *Feed_Shadow) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Feed_Shadow) GetCandidate() string {
+	if x != nil {
+		return x.Candidate
+	}
+	return ""
+}
+
+func (x *Feed_Shadow) GetBudget() *durationpb.Duration {
+	if x != nil {
+		return x.Budget
+	}
+	return nil
+}
+
+func (x *Feed_Shadow) GetMaxConcurrency() int32 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+func (x *Feed_Shadow) GetSampleRate() float64 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+type Feed_RemoteRecommendation struct {
+	state         protoimpl.MessageState             `protogen:"open.v1"`
+	Enabled       bool                               `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"` // 创建外部推荐服务客户端，登记为推荐源 remote；出站 JWT 与 metadata 透传沿用 data.grpc_client
+	Primary       bool                               `protobuf:"varint,2,opt,name=primary,proto3" json:"primary,omitempty"` // 以外部推荐作为主推荐链，失败或熔断时回退到混排 / Mock 推荐
+	Targets       []string                           `protobuf:"bytes,3,rep,name=targets,proto3" json:"targets,omitempty"`  // 推荐服务后端地址
+	Timeout       *durationpb.Duration               `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`  // 单次调用（含对冲）的总超时，默认 200ms
+	Hedge         *Feed_RemoteRecommendation_Hedge   `protobuf:"bytes,5,opt,name=hedge,proto3" json:"hedge,omitempty"`
+	Breaker       *Feed_RemoteRecommendation_Breaker `protobuf:"bytes,6,opt,name=breaker,proto3" json:"breaker,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feed_RemoteRecommendation) Reset() {
+	*x = Feed_RemoteRecommendation{}
+	mi := &file_configs_conf_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_RemoteRecommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_RemoteRecommendation) ProtoMessage() {}
+
+func (x *Feed_RemoteRecommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_RemoteRecommendation.ProtoReflect.Descriptor instead.
+func (*Feed_RemoteRecommendation) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 17}
+}
+
+func (x *Feed_RemoteRecommendation) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Feed_RemoteRecommendation) GetPrimary() bool {
+	if x != nil {
+		return x.Primary
+	}
+	return false
+}
+
+func (x *Feed_RemoteRecommendation) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *Feed_RemoteRecommendation) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *Feed_RemoteRecommendation) GetHedge() *Feed_RemoteRecommendation_Hedge {
+	if x != nil {
+		return x.Hedge
+	}
+	return nil
+}
+
+func (x *Feed_RemoteRecommendation) GetBreaker() *Feed_RemoteRecommendation_Breaker {
+	if x != nil {
+		return x.Breaker
+	}
+	return nil
+}
+
+type Feed_Mock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seed          int64                  `protobuf:"varint,1,opt,name=seed,proto3" json:"seed,omitempty"`   // Mock 推荐的全局种子，与 user_id、日期共同决定每次请求的排列顺序；修改后所有用户的顺序随之改变
+	Fixed         bool                   `protobuf:"varint,2,opt,name=fixed,proto3" json:"fixed,omitempty"` // 请求种子不含日期，同一用户的顺序跨天不变，供 QA 与集成测试使用
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feed_Mock) Reset() {
+	*x = Feed_Mock{}
+	mi := &file_configs_conf_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_Mock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_Mock) ProtoMessage() {}
+
+func (x *Feed_Mock) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_Mock.ProtoReflect.Descriptor instead.
+func (*Feed_Mock) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 18}
+}
+
+func (x *Feed_Mock) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *Feed_Mock) GetFixed() bool {
+	if x != nil {
+		return x.Fixed
+	}
+	return false
+}
+
+type Feed_Pseudonymization_Key struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`                      // 密钥版本号，随哈希一并写入日志
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`                        // HMAC 密钥明文，仅用于测试；配置了 secret_env 时忽略
+	SecretEnv     string                 `protobuf:"bytes,3,opt,name=secret_env,json=secretEnv,proto3" json:"secret_env,omitempty"` // 从该环境变量读取密钥，变量为空时启动失败
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feed_Pseudonymization_Key) Reset() {
+	*x = Feed_Pseudonymization_Key{}
+	mi := &file_configs_conf_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_Pseudonymization_Key) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_Pseudonymization_Key) ProtoMessage() {}
+
+func (x *Feed_Pseudonymization_Key) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_Pseudonymization_Key.ProtoReflect.Descriptor instead.
+func (*Feed_Pseudonymization_Key) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 4, 0}
+}
+
+func (x *Feed_Pseudonymization_Key) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Feed_Pseudonymization_Key) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *Feed_Pseudonymization_Key) GetSecretEnv() string {
+	if x != nil {
+		return x.SecretEnv
+	}
+	return ""
+}
+
+type Feed_LogSampling_Rule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"` // 推荐来源，空值匹配任意来源
+	Scene         string                 `protobuf:"bytes,2,opt,name=scene,proto3" json:"scene,omitempty"`   // 推荐场景，空值匹配任意场景
+	Rate          float64                `protobuf:"fixed64,3,opt,name=rate,proto3" json:"rate,omitempty"`   // 采样率 [0,1]
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feed_LogSampling_Rule) Reset() {
+	*x = Feed_LogSampling_Rule{}
+	mi := &file_configs_conf_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_LogSampling_Rule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_LogSampling_Rule) ProtoMessage() {}
+
+func (x *Feed_LogSampling_Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_LogSampling_Rule.ProtoReflect.Descriptor instead.
+func (*Feed_LogSampling_Rule) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 5, 0}
+}
+
+func (x *Feed_LogSampling_Rule) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Feed_LogSampling_Rule) GetScene() string {
+	if x != nil {
+		return x.Scene
+	}
+	return ""
+}
+
+func (x *Feed_LogSampling_Rule) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+type Feed_WatchedFilter_Scene struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scene         string                 `protobuf:"bytes,1,opt,name=scene,proto3" json:"scene,omitempty"`                                        // 推荐场景
+	DropRatio     *float64               `protobuf:"fixed64,2,opt,name=drop_ratio,json=dropRatio,proto3,oneof" json:"drop_ratio,omitempty"`       // 覆盖默认阈值，0 表示该场景不剔除
+	DemoteRatio   *float64               `protobuf:"fixed64,3,opt,name=demote_ratio,json=demoteRatio,proto3,oneof" json:"demote_ratio,omitempty"` // 覆盖默认阈值，0 表示该场景不降权
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feed_WatchedFilter_Scene) Reset() {
+	*x = Feed_WatchedFilter_Scene{}
+	mi := &file_configs_conf_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_WatchedFilter_Scene) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_WatchedFilter_Scene) ProtoMessage() {}
+
+func (x *Feed_WatchedFilter_Scene) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_WatchedFilter_Scene.ProtoReflect.Descriptor instead.
+func (*Feed_WatchedFilter_Scene) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 9, 0}
+}
+
+func (x *Feed_WatchedFilter_Scene) GetScene() string {
+	if x != nil {
+		return x.Scene
+	}
+	return ""
+}
+
+func (x *Feed_WatchedFilter_Scene) GetDropRatio() float64 {
+	if x != nil && x.DropRatio != nil {
+		return *x.DropRatio
+	}
+	return 0
+}
+
+func (x *Feed_WatchedFilter_Scene) GetDemoteRatio() float64 {
+	if x != nil && x.DemoteRatio != nil {
+		return *x.DemoteRatio
+	}
+	return 0
+}
+
+type Feed_Blending_Source struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`       // 推荐源：mock / fresh / review / remote
+	Weight        float64                `protobuf:"fixed64,2,opt,name=weight,proto3" json:"weight,omitempty"` // 混排权重，按比例分配槽位
+	Budget        *durationpb.Duration   `protobuf:"bytes,3,opt,name=budget,proto3" json:"budget,omitempty"`   // 该来源的调用超时，缺省取 default_budget
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feed_Blending_Source) Reset() {
+	*x = Feed_Blending_Source{}
+	mi := &file_configs_conf_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_Blending_Source) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_Blending_Source) ProtoMessage() {}
+
+func (x *Feed_Blending_Source) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_Blending_Source.ProtoReflect.Descriptor instead.
+func (*Feed_Blending_Source) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 12, 0}
+}
+
+func (x *Feed_Blending_Source) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Feed_Blending_Source) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Feed_Blending_Source) GetBudget() *durationpb.Duration {
+	if x != nil {
+		return x.Budget
+	}
+	return nil
+}
+
+type Feed_Rerank_MMR struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"` // 按标签相似度做最大边际相关性重排
+	Lambda        float64                `protobuf:"fixed64,2,opt,name=lambda,proto3" json:"lambda,omitempty"`  // 相关性权重，1 保持原顺序，越小越强调多样性，0 取默认 0.7
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feed_Rerank_MMR) Reset() {
+	*x = Feed_Rerank_MMR{}
+	mi := &file_configs_conf_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_Rerank_MMR) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_Rerank_MMR) ProtoMessage() {}
+
+func (x *Feed_Rerank_MMR) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_Rerank_MMR.ProtoReflect.Descriptor instead.
+func (*Feed_Rerank_MMR) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 13, 0}
+}
+
+func (x *Feed_Rerank_MMR) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Feed_Rerank_MMR) GetLambda() float64 {
+	if x != nil {
+		return x.Lambda
+	}
+	return 0
+}
+
+type Feed_Rerank_CreatorCap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`                                    // 同一创作者超出上限的条目移到本页末尾
+	MaxPerCreator int32                  `protobuf:"varint,2,opt,name=max_per_creator,json=maxPerCreator,proto3" json:"max_per_creator,omitempty"` // 默认 2
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feed_Rerank_CreatorCap) Reset() {
+	*x = Feed_Rerank_CreatorCap{}
+	mi := &file_configs_conf_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_Rerank_CreatorCap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_Rerank_CreatorCap) ProtoMessage() {}
+
+func (x *Feed_Rerank_CreatorCap) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_Rerank_CreatorCap.ProtoReflect.Descriptor instead.
+func (*Feed_Rerank_CreatorCap) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 13, 1}
+}
+
+func (x *Feed_Rerank_CreatorCap) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Feed_Rerank_CreatorCap) GetMaxPerCreator() int32 {
+	if x != nil {
+		return x.MaxPerCreator
+	}
+	return 0
+}
+
+type Feed_Rerank_LanguageRun struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Enabled        bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`                                     // 限制同一语言的视频连续出现
+	MaxConsecutive int32                  `protobuf:"varint,2,opt,name=max_consecutive,json=maxConsecutive,proto3" json:"max_consecutive,omitempty"` // 默认 3
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Feed_Rerank_LanguageRun) Reset() {
+	*x = Feed_Rerank_LanguageRun{}
+	mi := &file_configs_conf_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_Rerank_LanguageRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_Rerank_LanguageRun) ProtoMessage() {}
+
+func (x *Feed_Rerank_LanguageRun) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_Rerank_LanguageRun.ProtoReflect.Descriptor instead.
+func (*Feed_Rerank_LanguageRun) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 13, 2}
+}
+
+func (x *Feed_Rerank_LanguageRun) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Feed_Rerank_LanguageRun) GetMaxConsecutive() int32 {
+	if x != nil {
+		return x.MaxConsecutive
+	}
+	return 0
+}
+
+type Feed_Rerank_DurationSpread struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Enabled        bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`                                     // 按时长分档，限制同一档的视频连续出现
+	Boundaries     []*durationpb.Duration `protobuf:"bytes,2,rep,name=boundaries,proto3" json:"boundaries,omitempty"`                                // 升序的分档边界，默认 [1m, 5m]
+	MaxConsecutive int32                  `protobuf:"varint,3,opt,name=max_consecutive,json=maxConsecutive,proto3" json:"max_consecutive,omitempty"` // 默认 2
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Feed_Rerank_DurationSpread) Reset() {
+	*x = Feed_Rerank_DurationSpread{}
+	mi := &file_configs_conf_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_Rerank_DurationSpread) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_Rerank_DurationSpread) ProtoMessage() {}
+
+func (x *Feed_Rerank_DurationSpread) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_Rerank_DurationSpread.ProtoReflect.Descriptor instead.
+func (*Feed_Rerank_DurationSpread) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 13, 3}
+}
+
+func (x *Feed_Rerank_DurationSpread) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Feed_Rerank_DurationSpread) GetBoundaries() []*durationpb.Duration {
+	if x != nil {
+		return x.Boundaries
+	}
+	return nil
+}
+
+func (x *Feed_Rerank_DurationSpread) GetMaxConsecutive() int32 {
+	if x != nil {
+		return x.MaxConsecutive
+	}
+	return 0
+}
+
+type Feed_Experiments_Variant struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Name          string                  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                     // 分组名，写入推荐日志与卡片 attributes
+	Weight        uint32                  `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`                                // 流量权重，按比例分桶；0 表示不分流，只能经 x-md-experiment-variants 强制命中
+	Provider      string                  `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`                             // 覆盖主推荐源：mock / fresh / review / remote，空表示沿用默认推荐链
+	BlendSources  []*Feed_Blending_Source `protobuf:"bytes,4,rep,name=blend_sources,json=blendSources,proto3" json:"blend_sources,omitempty"` // 覆盖首页混排来源与权重，非空时以该组来源混排为主推荐，优先于 provider
+	Rerank        *Feed_Rerank            `protobuf:"bytes,5,opt,name=rerank,proto3" json:"rerank,omitempty"`                                 // 覆盖多样性重排配置，缺省沿用 feed.rerank
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feed_Experiments_Variant) Reset() {
+	*x = Feed_Experiments_Variant{}
+	mi := &file_configs_conf_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_Experiments_Variant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_Experiments_Variant) ProtoMessage() {}
+
+func (x *Feed_Experiments_Variant) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_Experiments_Variant.ProtoReflect.Descriptor instead.
+func (*Feed_Experiments_Variant) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 15, 0}
+}
+
+func (x *Feed_Experiments_Variant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Feed_Experiments_Variant) GetWeight() uint32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Feed_Experiments_Variant) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Feed_Experiments_Variant) GetBlendSources() []*Feed_Blending_Source {
+	if x != nil {
+		return x.BlendSources
+	}
+	return nil
+}
+
+func (x *Feed_Experiments_Variant) GetRerank() *Feed_Rerank {
+	if x != nil {
+		return x.Rerank
+	}
+	return nil
+}
+
+type Feed_Experiments_Experiment struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Key           string                      `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`   // 实验标识，同时作为 attributes 键 exp.<key>
+	Salt          string                      `protobuf:"bytes,2,opt,name=salt,proto3" json:"salt,omitempty"` // 分桶哈希盐，默认取 key；修改后用户重新分桶
+	Variants      []*Feed_Experiments_Variant `protobuf:"bytes,3,rep,name=variants,proto3" json:"variants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feed_Experiments_Experiment) Reset() {
+	*x = Feed_Experiments_Experiment{}
+	mi := &file_configs_conf_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_Experiments_Experiment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_Experiments_Experiment) ProtoMessage() {}
+
+func (x *Feed_Experiments_Experiment) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_Experiments_Experiment.ProtoReflect.Descriptor instead.
+func (*Feed_Experiments_Experiment) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 15, 1}
+}
+
+func (x *Feed_Experiments_Experiment) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Feed_Experiments_Experiment) GetSalt() string {
+	if x != nil {
+		return x.Salt
+	}
+	return ""
+}
+
+func (x *Feed_Experiments_Experiment) GetVariants() []*Feed_Experiments_Variant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+type Feed_RemoteRecommendation_Hedge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`                              // 首个请求超过延迟分位数仍未返回（或快速失败）时向另一后端补发一次，需至少两个 targets
+	Percentile    float64                `protobuf:"fixed64,2,opt,name=percentile,proto3" json:"percentile,omitempty"`                       // 触发对冲的延迟分位数，0 取默认 0.95
+	InitialDelay  *durationpb.Duration   `protobuf:"bytes,3,opt,name=initial_delay,json=initialDelay,proto3" json:"initial_delay,omitempty"` // 样本不足时的对冲延迟，默认 50ms
+	MinDelay      *durationpb.Duration   `protobuf:"bytes,4,opt,name=min_delay,json=minDelay,proto3" json:"min_delay,omitempty"`             // 对冲延迟下限，默认 5ms
+	Window        int32                  `protobuf:"varint,5,opt,name=window,proto3" json:"window,omitempty"`                                // 统计分位数的最近调用尝试数（含对冲落败方），默认 200
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feed_RemoteRecommendation_Hedge) Reset() {
+	*x = Feed_RemoteRecommendation_Hedge{}
+	mi := &file_configs_conf_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_RemoteRecommendation_Hedge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_RemoteRecommendation_Hedge) ProtoMessage() {}
+
+func (x *Feed_RemoteRecommendation_Hedge) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_RemoteRecommendation_Hedge.ProtoReflect.Descriptor instead.
+func (*Feed_RemoteRecommendation_Hedge) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 17, 0}
+}
+
+func (x *Feed_RemoteRecommendation_Hedge) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Feed_RemoteRecommendation_Hedge) GetPercentile() float64 {
+	if x != nil {
+		return x.Percentile
+	}
+	return 0
+}
+
+func (x *Feed_RemoteRecommendation_Hedge) GetInitialDelay() *durationpb.Duration {
+	if x != nil {
+		return x.InitialDelay
+	}
+	return nil
+}
+
+func (x *Feed_RemoteRecommendation_Hedge) GetMinDelay() *durationpb.Duration {
+	if x != nil {
+		return x.MinDelay
+	}
+	return nil
+}
+
+func (x *Feed_RemoteRecommendation_Hedge) GetWindow() int32 {
+	if x != nil {
+		return x.Window
+	}
+	return 0
+}
+
+type Feed_RemoteRecommendation_Breaker struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Enabled          bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`                                           // 连续失败达到阈值后打开熔断，期间请求直接走兜底链路
+	FailureThreshold int32                  `protobuf:"varint,2,opt,name=failure_threshold,json=failureThreshold,proto3" json:"failure_threshold,omitempty"` // 默认 5
+	OpenDuration     *durationpb.Duration   `protobuf:"bytes,3,opt,name=open_duration,json=openDuration,proto3" json:"open_duration,omitempty"`              // 打开后多久进入半开放行探测，默认 10s
+	HalfOpenProbes   int32                  `protobuf:"varint,4,opt,name=half_open_probes,json=halfOpenProbes,proto3" json:"half_open_probes,omitempty"`     // 半开时同时放行的探测请求数，默认 1
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Feed_RemoteRecommendation_Breaker) Reset() {
+	*x = Feed_RemoteRecommendation_Breaker{}
+	mi := &file_configs_conf_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feed_RemoteRecommendation_Breaker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feed_RemoteRecommendation_Breaker) ProtoMessage() {}
+
+func (x *Feed_RemoteRecommendation_Breaker) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feed_RemoteRecommendation_Breaker.ProtoReflect.Descriptor instead.
+func (*Feed_RemoteRecommendation_Breaker) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{9, 17, 1}
+}
+
+func (x *Feed_RemoteRecommendation_Breaker) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Feed_RemoteRecommendation_Breaker) GetFailureThreshold() int32 {
+	if x != nil {
+		return x.FailureThreshold
+	}
+	return 0
+}
+
+func (x *Feed_RemoteRecommendation_Breaker) GetOpenDuration() *durationpb.Duration {
+	if x != nil {
+		return x.OpenDuration
+	}
+	return nil
+}
+
+func (x *Feed_RemoteRecommendation_Breaker) GetHalfOpenProbes() int32 {
+	if x != nil {
+		return x.HalfOpenProbes
+	}
+	return 0
+}
+
+var File_configs_conf_proto protoreflect.FileDescriptor
+
+const file_configs_conf_proto_rawDesc = "" +
+	"\n" +
+	"\x12configs/conf.proto\x12\n" +
+	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bbuf/validate/validate.proto\"\xf9\x01\n" +
+	"\tBootstrap\x12*\n" +
+	"\x06server\x18\x01 \x01(\v2\x12.kratos.api.ServerR\x06server\x12$\n" +
+	"\x04data\x18\x02 \x01(\v2\x10.kratos.api.DataR\x04data\x12?\n" +
+	"\robservability\x18\x03 \x01(\v2\x19.kratos.api.ObservabilityR\robservability\x123\n" +
+	"\tmessaging\x18\x04 \x01(\v2\x15.kratos.api.MessagingR\tmessaging\x12$\n" +
+	"\x04feed\x18\x05 \x01(\v2\x10.kratos.api.FeedR\x04feed\"\x82\n" +
+	"\n" +
+	"\x06Server\x12+\n" +
+	"\x04grpc\x18\x01 \x01(\v2\x17.kratos.api.Server.GRPCR\x04grpc\x12(\n" +
+	"\x03jwt\x18\x02 \x01(\v2\x16.kratos.api.Server.JWTR\x03jwt\x127\n" +
+	"\bhandlers\x18\x03 \x01(\v2\x1b.kratos.api.Server.HandlersR\bhandlers\x12#\n" +
+	"\rmetadata_keys\x18\x04 \x03(\tR\fmetadataKeys\x12+\n" +
+	"\x04http\x18\x05 \x01(\v2\x17.kratos.api.Server.HTTPR\x04http\x12;\n" +
+	"\n" +
+	"rate_limit\x18\x06 \x01(\v2\x1c.kratos.api.Server.RateLimitR\trateLimit\x12.\n" +
+	"\x05admin\x18\a \x01(\v2\x18.kratos.api.Server.AdminR\x05admin\x1ai\n" +
+	"\x04GRPC\x12\x18\n" +
+	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
+	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1a\x92\x01\n" +
+	"\x03JWT\x12+\n" +
+	"\x11expected_audience\x18\x01 \x01(\tR\x10expectedAudience\x12#\n" +
+	"\rskip_validate\x18\x02 \x01(\bR\fskipValidate\x12\x1a\n" +
+	"\brequired\x18\x03 \x01(\bR\brequired\x12\x1d\n" +
+	"\n" +
+	"header_key\x18\x04 \x01(\tR\theaderKey\x1a\xd2\x01\n" +
+	"\bHandlers\x12B\n" +
+	"\x0fdefault_timeout\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x0edefaultTimeout\x12B\n" +
+	"\x0fcommand_timeout\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x0ecommandTimeout\x12>\n" +
+	"\rquery_timeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\fqueryTimeout\x1ai\n" +
+	"\x04HTTP\x12\x18\n" +
+	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
+	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1a\x9c\x02\n" +
+	"\tRateLimit\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x18\n" +
+	"\abackend\x18\x02 \x01(\tR\abackend\x12\x1f\n" +
+	"\vdefault_rps\x18\x03 \x01(\x01R\n" +
+	"defaultRps\x12#\n" +
+	"\rdefault_burst\x18\x04 \x01(\x05R\fdefaultBurst\x127\n" +
+	"\x05rules\x18\x05 \x03(\v2!.kratos.api.Server.RateLimit.RuleR\x05rules\x1a\\\n" +
+	"\x04Rule\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12\x14\n" +
+	"\x05scene\x18\x02 \x01(\tR\x05scene\x12\x10\n" +
+	"\x03rps\x18\x03 \x01(\x01R\x03rps\x12\x14\n" +
+	"\x05burst\x18\x04 \x01(\x05R\x05burst\x1aJ\n" +
+	"\x05Admin\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1a\n" +
+	"\baudience\x18\x02 \x01(\tR\baudienceJ\x04\b\x03\x10\x04R\x05roles\"\xe6\t\n" +
+	"\x04Data\x12?\n" +
+	"\bpostgres\x18\x01 \x01(\v2\x1b.kratos.api.Data.PostgreSQLB\x06\xbaH\x03\xc8\x01\x01R\bpostgres\x128\n" +
+	"\vgrpc_client\x18\x02 \x01(\v2\x17.kratos.api.Data.ClientR\n" +
+	"grpcClient\x1a\x8d\a\n" +
+	"\n" +
 	"PostgreSQL\x12.\n" +
 	"\x03dsn\x18\x01 \x01(\tB\x1c\xbaH\x19r\x17\x10\x012\x13^postgres(ql)?://.*R\x03dsn\x12/\n" +
 	"\x0emax_open_conns\x18\x02 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x01R\fmaxOpenConns\x12/\n" +
@@ -1681,7 +4180,187 @@ const file_configs_conf_proto_rawDesc = "" +
 	"\x0flogging_enabled\x18\x03 \x01(\bH\x00R\x0eloggingEnabled\x88\x01\x01\x12,\n" +
 	"\x0fmetrics_enabled\x18\x04 \x01(\bH\x01R\x0emetricsEnabled\x88\x01\x01B\x12\n" +
 	"\x10_logging_enabledB\x12\n" +
-	"\x10_metrics_enabledB=Z;github.com/bionicotaku/lingo-services-feed/configs;configpbb\x06proto3"
+	"\x10_metrics_enabled\"\xc60\n" +
+	"\x04Feed\x12>\n" +
+	"\vidempotency\x18\x01 \x01(\v2\x1c.kratos.api.Feed.IdempotencyR\vidempotency\x12,\n" +
+	"\x05guest\x18\x02 \x01(\v2\x16.kratos.api.Feed.GuestR\x05guest\x129\n" +
+	"\n" +
+	"log_writer\x18\x03 \x01(\v2\x1a.kratos.api.Feed.LogWriterR\tlogWriter\x12B\n" +
+	"\rlog_retention\x18\x04 \x01(\v2\x1d.kratos.api.Feed.LogRetentionR\flogRetention\x12M\n" +
+	"\x10pseudonymization\x18\x05 \x01(\v2!.kratos.api.Feed.PseudonymizationR\x10pseudonymization\x12?\n" +
+	"\flog_sampling\x18\x06 \x01(\v2\x1c.kratos.api.Feed.LogSamplingR\vlogSampling\x12A\n" +
+	"\finteractions\x18\a \x01(\v2\x1d.kratos.api.Feed.InteractionsR\finteractions\x12B\n" +
+	"\rserved_events\x18\b \x01(\v2\x1d.kratos.api.Feed.ServedEventsR\fservedEvents\x129\n" +
+	"\n" +
+	"user_state\x18\t \x01(\v2\x1a.kratos.api.Feed.UserStateR\tuserState\x12E\n" +
+	"\x0ewatched_filter\x18\n" +
+	" \x01(\v2\x1e.kratos.api.Feed.WatchedFilterR\rwatchedFilter\x12N\n" +
+	"\x11continue_learning\x18\v \x01(\v2!.kratos.api.Feed.ContinueLearningR\x10continueLearning\x12?\n" +
+	"\freview_queue\x18\f \x01(\v2\x1c.kratos.api.Feed.ReviewQueueR\vreviewQueue\x125\n" +
+	"\bblending\x18\r \x01(\v2\x19.kratos.api.Feed.BlendingR\bblending\x12/\n" +
+	"\x06rerank\x18\x0e \x01(\v2\x17.kratos.api.Feed.RerankR\x06rerank\x125\n" +
+	"\bcuration\x18\x0f \x01(\v2\x19.kratos.api.Feed.CurationR\bcuration\x12>\n" +
+	"\vexperiments\x18\x10 \x01(\v2\x1c.kratos.api.Feed.ExperimentsR\vexperiments\x12/\n" +
+	"\x06shadow\x18\x11 \x01(\v2\x17.kratos.api.Feed.ShadowR\x06shadow\x12Z\n" +
+	"\x15remote_recommendation\x18\x12 \x01(\v2%.kratos.api.Feed.RemoteRecommendationR\x14remoteRecommendation\x12)\n" +
+	"\x04mock\x18\x13 \x01(\v2\x15.kratos.api.Feed.MockR\x04mock\x1aT\n" +
+	"\vIdempotency\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12+\n" +
+	"\x03ttl\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x1a\x93\x02\n" +
+	"\x05Guest\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x126\n" +
+	"\tcache_ttl\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\bcacheTtl\x12$\n" +
+	"\x0erate_limit_rps\x18\x03 \x01(\x01R\frateLimitRps\x12(\n" +
+	"\x10rate_limit_burst\x18\x04 \x01(\x05R\x0erateLimitBurst\x121\n" +
+	"\x15device_rate_limit_rps\x18\x05 \x01(\x01R\x12deviceRateLimitRps\x125\n" +
+	"\x17device_rate_limit_burst\x18\x06 \x01(\x05R\x14deviceRateLimitBurst\x1a\xfd\x01\n" +
+	"\tLogWriter\x12\x14\n" +
+	"\x05async\x18\x01 \x01(\bR\x05async\x12\x1d\n" +
+	"\n" +
+	"queue_size\x18\x02 \x01(\x05R\tqueueSize\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x03 \x01(\x05R\tbatchSize\x12@\n" +
+	"\x0eflush_interval\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\rflushInterval\x12>\n" +
+	"\rflush_timeout\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\fflushTimeout\x12\x1a\n" +
+	"\boverflow\x18\x06 \x01(\tR\boverflow\x1a\x8a\x02\n" +
+	"\fLogRetention\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x127\n" +
+	"\tretention\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\tretention\x12!\n" +
+	"\fpremake_days\x18\x03 \x01(\x05R\vpremakeDays\x125\n" +
+	"\binterval\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12M\n" +
+	"\x15served_page_retention\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x13servedPageRetention\x1a\xd3\x01\n" +
+	"\x10Pseudonymization\x12,\n" +
+	"\x12active_key_version\x18\x01 \x01(\tR\x10activeKeyVersion\x129\n" +
+	"\x04keys\x18\x02 \x03(\v2%.kratos.api.Feed.Pseudonymization.KeyR\x04keys\x1aV\n" +
+	"\x03Key\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\x12\x1d\n" +
+	"\n" +
+	"secret_env\x18\x03 \x01(\tR\tsecretEnv\x1a\xf3\x01\n" +
+	"\vLogSampling\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12!\n" +
+	"\fdefault_rate\x18\x02 \x01(\x01R\vdefaultRate\x127\n" +
+	"\x05rules\x18\x03 \x03(\v2!.kratos.api.Feed.LogSampling.RuleR\x05rules\x12$\n" +
+	"\x0edebug_user_ids\x18\x04 \x03(\tR\fdebugUserIds\x1aH\n" +
+	"\x04Rule\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x14\n" +
+	"\x05scene\x18\x02 \x01(\tR\x05scene\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\x01R\x04rate\x1a\xdd\x01\n" +
+	"\fInteractions\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x14\n" +
+	"\x05topic\x18\x02 \x01(\tR\x05topic\x12$\n" +
+	"\x0emax_batch_size\x18\x03 \x01(\x05R\fmaxBatchSize\x12=\n" +
+	"\rmax_event_age\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\vmaxEventAge\x128\n" +
+	"\n" +
+	"clock_skew\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\tclockSkew\x1a(\n" +
+	"\fServedEvents\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x1aQ\n" +
+	"\tUserState\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x14\n" +
+	"\x05topic\x18\x02 \x01(\tR\x05topic\x12\x14\n" +
+	"\x05inbox\x18\x03 \x01(\tR\x05inbox\x1a\xb5\x02\n" +
+	"\rWatchedFilter\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1d\n" +
+	"\n" +
+	"drop_ratio\x18\x02 \x01(\x01R\tdropRatio\x12!\n" +
+	"\fdemote_ratio\x18\x03 \x01(\x01R\vdemoteRatio\x12<\n" +
+	"\x06scenes\x18\x04 \x03(\v2$.kratos.api.Feed.WatchedFilter.SceneR\x06scenes\x1a\x89\x01\n" +
+	"\x05Scene\x12\x14\n" +
+	"\x05scene\x18\x01 \x01(\tR\x05scene\x12\"\n" +
+	"\n" +
+	"drop_ratio\x18\x02 \x01(\x01H\x00R\tdropRatio\x88\x01\x01\x12&\n" +
+	"\fdemote_ratio\x18\x03 \x01(\x01H\x01R\vdemoteRatio\x88\x01\x01B\r\n" +
+	"\v_drop_ratioB\x0f\n" +
+	"\r_demote_ratio\x1af\n" +
+	"\x10ContinueLearning\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1b\n" +
+	"\tmin_ratio\x18\x02 \x01(\x01R\bminRatio\x12\x1b\n" +
+	"\tmax_ratio\x18\x03 \x01(\x01R\bmaxRatio\x1a\x93\x01\n" +
+	"\vReviewQueue\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x14\n" +
+	"\x05topic\x18\x02 \x01(\tR\x05topic\x12\x14\n" +
+	"\x05inbox\x18\x03 \x01(\tR\x05inbox\x12>\n" +
+	"\x0eblend_fraction\x18\x04 \x01(\x01B\x17\xbaH\x14\x12\x12\x19\x00\x00\x00\x00\x00\x00\xf0?)\x00\x00\x00\x00\x00\x00\x00\x00R\rblendFraction\x1a\xc1\x02\n" +
+	"\bBlending\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1a\n" +
+	"\bstrategy\x18\x02 \x01(\tR\bstrategy\x12:\n" +
+	"\asources\x18\x03 \x03(\v2 .kratos.api.Feed.Blending.SourceR\asources\x12@\n" +
+	"\x0edefault_budget\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\rdefaultBudget\x1a\x80\x01\n" +
+	"\x06Source\x12\x1b\n" +
+	"\x04name\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x04name\x12&\n" +
+	"\x06weight\x18\x02 \x01(\x01B\x0e\xbaH\v\x12\t!\x00\x00\x00\x00\x00\x00\x00\x00R\x06weight\x121\n" +
+	"\x06budget\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x06budget\x1a\xb5\x05\n" +
+	"\x06Rerank\x12-\n" +
+	"\x03mmr\x18\x01 \x01(\v2\x1b.kratos.api.Feed.Rerank.MMRR\x03mmr\x12C\n" +
+	"\vcreator_cap\x18\x02 \x01(\v2\".kratos.api.Feed.Rerank.CreatorCapR\n" +
+	"creatorCap\x12F\n" +
+	"\flanguage_run\x18\x03 \x01(\v2#.kratos.api.Feed.Rerank.LanguageRunR\vlanguageRun\x12O\n" +
+	"\x0fduration_spread\x18\x04 \x01(\v2&.kratos.api.Feed.Rerank.DurationSpreadR\x0edurationSpread\x1aP\n" +
+	"\x03MMR\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12/\n" +
+	"\x06lambda\x18\x02 \x01(\x01B\x17\xbaH\x14\x12\x12\x19\x00\x00\x00\x00\x00\x00\xf0?)\x00\x00\x00\x00\x00\x00\x00\x00R\x06lambda\x1aW\n" +
+	"\n" +
+	"CreatorCap\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12/\n" +
+	"\x0fmax_per_creator\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\rmaxPerCreator\x1aY\n" +
+	"\vLanguageRun\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x120\n" +
+	"\x0fmax_consecutive\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x0emaxConsecutive\x1a\x97\x01\n" +
+	"\x0eDurationSpread\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x129\n" +
+	"\n" +
+	"boundaries\x18\x02 \x03(\v2\x19.google.protobuf.DurationR\n" +
+	"boundaries\x120\n" +
+	"\x0fmax_consecutive\x18\x03 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x0emaxConsecutive\x1a\xb2\x01\n" +
+	"\bCuration\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12D\n" +
+	"\x10refresh_interval\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x0frefreshInterval\x12F\n" +
+	"\x11reconnect_backoff\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x10reconnectBackoff\x1a\x85\x04\n" +
+	"\vExperiments\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x122\n" +
+	"\x15allow_forced_variants\x18\x02 \x01(\bR\x13allowForcedVariants\x12I\n" +
+	"\vexperiments\x18\x03 \x03(\v2'.kratos.api.Feed.Experiments.ExperimentR\vexperiments\x1a\xd2\x01\n" +
+	"\aVariant\x12\x1b\n" +
+	"\x04name\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x04name\x12\x16\n" +
+	"\x06weight\x18\x02 \x01(\rR\x06weight\x12\x1a\n" +
+	"\bprovider\x18\x03 \x01(\tR\bprovider\x12E\n" +
+	"\rblend_sources\x18\x04 \x03(\v2 .kratos.api.Feed.Blending.SourceR\fblendSources\x12/\n" +
+	"\x06rerank\x18\x05 \x01(\v2\x17.kratos.api.Feed.RerankR\x06rerank\x1a\x87\x01\n" +
+	"\n" +
+	"Experiment\x12\x19\n" +
+	"\x03key\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x03key\x12\x12\n" +
+	"\x04salt\x18\x02 \x01(\tR\x04salt\x12J\n" +
+	"\bvariants\x18\x03 \x03(\v2$.kratos.api.Feed.Experiments.VariantB\b\xbaH\x05\x92\x01\x02\b\x01R\bvariants\x1a\xdf\x01\n" +
+	"\x06Shadow\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1c\n" +
+	"\tcandidate\x18\x02 \x01(\tR\tcandidate\x121\n" +
+	"\x06budget\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x06budget\x120\n" +
+	"\x0fmax_concurrency\x18\x04 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x0emaxConcurrency\x128\n" +
+	"\vsample_rate\x18\x05 \x01(\x01B\x17\xbaH\x14\x12\x12\x19\x00\x00\x00\x00\x00\x00\xf0?)\x00\x00\x00\x00\x00\x00\x00\x00R\n" +
+	"sampleRate\x1a\xea\x05\n" +
+	"\x14RemoteRecommendation\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x18\n" +
+	"\aprimary\x18\x02 \x01(\bR\aprimary\x12\x18\n" +
+	"\atargets\x18\x03 \x03(\tR\atargets\x123\n" +
+	"\atimeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12A\n" +
+	"\x05hedge\x18\x05 \x01(\v2+.kratos.api.Feed.RemoteRecommendation.HedgeR\x05hedge\x12G\n" +
+	"\abreaker\x18\x06 \x01(\v2-.kratos.api.Feed.RemoteRecommendation.BreakerR\abreaker\x1a\xf3\x01\n" +
+	"\x05Hedge\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x127\n" +
+	"\n" +
+	"percentile\x18\x02 \x01(\x01B\x17\xbaH\x14\x12\x12\x19\x00\x00\x00\x00\x00\x00\xf0?)\x00\x00\x00\x00\x00\x00\x00\x00R\n" +
+	"percentile\x12>\n" +
+	"\rinitial_delay\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\finitialDelay\x126\n" +
+	"\tmin_delay\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\bminDelay\x12\x1f\n" +
+	"\x06window\x18\x05 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x06window\x1a\xcc\x01\n" +
+	"\aBreaker\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x124\n" +
+	"\x11failure_threshold\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x10failureThreshold\x12>\n" +
+	"\ropen_duration\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\fopenDuration\x121\n" +
+	"\x10half_open_probes\x18\x04 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x0ehalfOpenProbes\x1a0\n" +
+	"\x04Mock\x12\x12\n" +
+	"\x04seed\x18\x01 \x01(\x03R\x04seed\x12\x14\n" +
+	"\x05fixed\x18\x02 \x01(\bR\x05fixedB=Z;github.com/bionicotaku/lingo-services-feed/configs;configpbb\x06proto3"
 
 var (
 	file_configs_conf_proto_rawDescOnce sync.Once
@@ -1695,85 +4374,179 @@ func file_configs_conf_proto_rawDescGZIP() []byte {
 	return file_configs_conf_proto_rawDescData
 }
 
-var file_configs_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_configs_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 61)
 var file_configs_conf_proto_goTypes = []any{
-	(*Bootstrap)(nil),                   // 0: kratos.api.Bootstrap
-	(*Server)(nil),                      // 1: kratos.api.Server
-	(*Data)(nil),                        // 2: kratos.api.Data
-	(*Observability)(nil),               // 3: kratos.api.Observability
-	(*Messaging)(nil),                   // 4: kratos.api.Messaging
-	(*PubSub)(nil),                      // 5: kratos.api.PubSub
-	(*Receive)(nil),                     // 6: kratos.api.Receive
-	(*OutboxPublisher)(nil),             // 7: kratos.api.OutboxPublisher
-	(*InboxConsumer)(nil),               // 8: kratos.api.InboxConsumer
-	(*Server_GRPC)(nil),                 // 9: kratos.api.Server.GRPC
-	(*Server_JWT)(nil),                  // 10: kratos.api.Server.JWT
-	(*Server_Handlers)(nil),             // 11: kratos.api.Server.Handlers
-	(*Data_PostgreSQL)(nil),             // 12: kratos.api.Data.PostgreSQL
-	(*Data_Client)(nil),                 // 13: kratos.api.Data.Client
-	(*Data_PostgreSQL_Transaction)(nil), // 14: kratos.api.Data.PostgreSQL.Transaction
-	(*Data_Client_JWT)(nil),             // 15: kratos.api.Data.Client.JWT
-	(*Observability_Tracing)(nil),       // 16: kratos.api.Observability.Tracing
-	(*Observability_Metrics)(nil),       // 17: kratos.api.Observability.Metrics
-	nil,                                 // 18: kratos.api.Observability.GlobalAttributesEntry
-	nil,                                 // 19: kratos.api.Observability.Tracing.HeadersEntry
-	nil,                                 // 20: kratos.api.Observability.Tracing.AttributesEntry
-	nil,                                 // 21: kratos.api.Observability.Metrics.HeadersEntry
-	nil,                                 // 22: kratos.api.Observability.Metrics.ResourceAttributesEntry
-	nil,                                 // 23: kratos.api.Messaging.TopicsEntry
-	nil,                                 // 24: kratos.api.Messaging.InboxesEntry
-	(*durationpb.Duration)(nil),         // 25: google.protobuf.Duration
+	(*Bootstrap)(nil),                         // 0: kratos.api.Bootstrap
+	(*Server)(nil),                            // 1: kratos.api.Server
+	(*Data)(nil),                              // 2: kratos.api.Data
+	(*Observability)(nil),                     // 3: kratos.api.Observability
+	(*Messaging)(nil),                         // 4: kratos.api.Messaging
+	(*PubSub)(nil),                            // 5: kratos.api.PubSub
+	(*Receive)(nil),                           // 6: kratos.api.Receive
+	(*OutboxPublisher)(nil),                   // 7: kratos.api.OutboxPublisher
+	(*InboxConsumer)(nil),                     // 8: kratos.api.InboxConsumer
+	(*Feed)(nil),                              // 9: kratos.api.Feed
+	(*Server_GRPC)(nil),                       // 10: kratos.api.Server.GRPC
+	(*Server_JWT)(nil),                        // 11: kratos.api.Server.JWT
+	(*Server_Handlers)(nil),                   // 12: kratos.api.Server.Handlers
+	(*Server_HTTP)(nil),                       // 13: kratos.api.Server.HTTP
+	(*Server_RateLimit)(nil),                  // 14: kratos.api.Server.RateLimit
+	(*Server_Admin)(nil),                      // 15: kratos.api.Server.Admin
+	(*Server_RateLimit_Rule)(nil),             // 16: kratos.api.Server.RateLimit.Rule
+	(*Data_PostgreSQL)(nil),                   // 17: kratos.api.Data.PostgreSQL
+	(*Data_Client)(nil),                       // 18: kratos.api.Data.Client
+	(*Data_PostgreSQL_Transaction)(nil),       // 19: kratos.api.Data.PostgreSQL.Transaction
+	(*Data_Client_JWT)(nil),                   // 20: kratos.api.Data.Client.JWT
+	(*Observability_Tracing)(nil),             // 21: kratos.api.Observability.Tracing
+	(*Observability_Metrics)(nil),             // 22: kratos.api.Observability.Metrics
+	nil,                                       // 23: kratos.api.Observability.GlobalAttributesEntry
+	nil,                                       // 24: kratos.api.Observability.Tracing.HeadersEntry
+	nil,                                       // 25: kratos.api.Observability.Tracing.AttributesEntry
+	nil,                                       // 26: kratos.api.Observability.Metrics.HeadersEntry
+	nil,                                       // 27: kratos.api.Observability.Metrics.ResourceAttributesEntry
+	nil,                                       // 28: kratos.api.Messaging.TopicsEntry
+	nil,                                       // 29: kratos.api.Messaging.InboxesEntry
+	(*Feed_Idempotency)(nil),                  // 30: kratos.api.Feed.Idempotency
+	(*Feed_Guest)(nil),                        // 31: kratos.api.Feed.Guest
+	(*Feed_LogWriter)(nil),                    // 32: kratos.api.Feed.LogWriter
+	(*Feed_LogRetention)(nil),                 // 33: kratos.api.Feed.LogRetention
+	(*Feed_Pseudonymization)(nil),             // 34: kratos.api.Feed.Pseudonymization
+	(*Feed_LogSampling)(nil),                  // 35: kratos.api.Feed.LogSampling
+	(*Feed_Interactions)(nil),                 // 36: kratos.api.Feed.Interactions
+	(*Feed_ServedEvents)(nil),                 // 37: kratos.api.Feed.ServedEvents
+	(*Feed_UserState)(nil),                    // 38: kratos.api.Feed.UserState
+	(*Feed_WatchedFilter)(nil),                // 39: kratos.api.Feed.WatchedFilter
+	(*Feed_ContinueLearning)(nil),             // 40: kratos.api.Feed.ContinueLearning
+	(*Feed_ReviewQueue)(nil),                  // 41: kratos.api.Feed.ReviewQueue
+	(*Feed_Blending)(nil),                     // 42: kratos.api.Feed.Blending
+	(*Feed_Rerank)(nil),                       // 43: kratos.api.Feed.Rerank
+	(*Feed_Curation)(nil),                     // 44: kratos.api.Feed.Curation
+	(*Feed_Experiments)(nil),                  // 45: kratos.api.Feed.Experiments
+	(*Feed_Shadow)(nil),                       // 46: kratos.api.Feed.Shadow
+	(*Feed_RemoteRecommendation)(nil),         // 47: kratos.api.Feed.RemoteRecommendation
+	(*Feed_Mock)(nil),                         // 48: kratos.api.Feed.Mock
+	(*Feed_Pseudonymization_Key)(nil),         // 49: kratos.api.Feed.Pseudonymization.Key
+	(*Feed_LogSampling_Rule)(nil),             // 50: kratos.api.Feed.LogSampling.Rule
+	(*Feed_WatchedFilter_Scene)(nil),          // 51: kratos.api.Feed.WatchedFilter.Scene
+	(*Feed_Blending_Source)(nil),              // 52: kratos.api.Feed.Blending.Source
+	(*Feed_Rerank_MMR)(nil),                   // 53: kratos.api.Feed.Rerank.MMR
+	(*Feed_Rerank_CreatorCap)(nil),            // 54: kratos.api.Feed.Rerank.CreatorCap
+	(*Feed_Rerank_LanguageRun)(nil),           // 55: kratos.api.Feed.Rerank.LanguageRun
+	(*Feed_Rerank_DurationSpread)(nil),        // 56: kratos.api.Feed.Rerank.DurationSpread
+	(*Feed_Experiments_Variant)(nil),          // 57: kratos.api.Feed.Experiments.Variant
+	(*Feed_Experiments_Experiment)(nil),       // 58: kratos.api.Feed.Experiments.Experiment
+	(*Feed_RemoteRecommendation_Hedge)(nil),   // 59: kratos.api.Feed.RemoteRecommendation.Hedge
+	(*Feed_RemoteRecommendation_Breaker)(nil), // 60: kratos.api.Feed.RemoteRecommendation.Breaker
+	(*durationpb.Duration)(nil),               // 61: google.protobuf.Duration
 }
 var file_configs_conf_proto_depIdxs = []int32{
-	1,  // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
-	2,  // 1: kratos.api.Bootstrap.data:type_name -> kratos.api.Data
-	3,  // 2: kratos.api.Bootstrap.observability:type_name -> kratos.api.Observability
-	4,  // 3: kratos.api.Bootstrap.messaging:type_name -> kratos.api.Messaging
-	9,  // 4: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
-	10, // 5: kratos.api.Server.jwt:type_name -> kratos.api.Server.JWT
-	11, // 6: kratos.api.Server.handlers:type_name -> kratos.api.Server.Handlers
-	12, // 7: kratos.api.Data.postgres:type_name -> kratos.api.Data.PostgreSQL
-	13, // 8: kratos.api.Data.grpc_client:type_name -> kratos.api.Data.Client
-	18, // 9: kratos.api.Observability.global_attributes:type_name -> kratos.api.Observability.GlobalAttributesEntry
-	16, // 10: kratos.api.Observability.tracing:type_name -> kratos.api.Observability.Tracing
-	17, // 11: kratos.api.Observability.metrics:type_name -> kratos.api.Observability.Metrics
-	23, // 12: kratos.api.Messaging.topics:type_name -> kratos.api.Messaging.TopicsEntry
-	7,  // 13: kratos.api.Messaging.outbox:type_name -> kratos.api.OutboxPublisher
-	24, // 14: kratos.api.Messaging.inboxes:type_name -> kratos.api.Messaging.InboxesEntry
-	25, // 15: kratos.api.PubSub.publish_timeout:type_name -> google.protobuf.Duration
-	6,  // 16: kratos.api.PubSub.receive:type_name -> kratos.api.Receive
-	25, // 17: kratos.api.Receive.max_extension:type_name -> google.protobuf.Duration
-	25, // 18: kratos.api.Receive.max_extension_period:type_name -> google.protobuf.Duration
-	25, // 19: kratos.api.OutboxPublisher.tick_interval:type_name -> google.protobuf.Duration
-	25, // 20: kratos.api.OutboxPublisher.initial_backoff:type_name -> google.protobuf.Duration
-	25, // 21: kratos.api.OutboxPublisher.max_backoff:type_name -> google.protobuf.Duration
-	25, // 22: kratos.api.OutboxPublisher.publish_timeout:type_name -> google.protobuf.Duration
-	25, // 23: kratos.api.OutboxPublisher.lock_ttl:type_name -> google.protobuf.Duration
-	25, // 24: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
-	25, // 25: kratos.api.Server.Handlers.default_timeout:type_name -> google.protobuf.Duration
-	25, // 26: kratos.api.Server.Handlers.command_timeout:type_name -> google.protobuf.Duration
-	25, // 27: kratos.api.Server.Handlers.query_timeout:type_name -> google.protobuf.Duration
-	25, // 28: kratos.api.Data.PostgreSQL.max_conn_lifetime:type_name -> google.protobuf.Duration
-	25, // 29: kratos.api.Data.PostgreSQL.max_conn_idle_time:type_name -> google.protobuf.Duration
-	25, // 30: kratos.api.Data.PostgreSQL.health_check_period:type_name -> google.protobuf.Duration
-	14, // 31: kratos.api.Data.PostgreSQL.transaction:type_name -> kratos.api.Data.PostgreSQL.Transaction
-	15, // 32: kratos.api.Data.Client.jwt:type_name -> kratos.api.Data.Client.JWT
-	25, // 33: kratos.api.Data.PostgreSQL.Transaction.default_timeout:type_name -> google.protobuf.Duration
-	25, // 34: kratos.api.Data.PostgreSQL.Transaction.lock_timeout:type_name -> google.protobuf.Duration
-	19, // 35: kratos.api.Observability.Tracing.headers:type_name -> kratos.api.Observability.Tracing.HeadersEntry
-	25, // 36: kratos.api.Observability.Tracing.batch_timeout:type_name -> google.protobuf.Duration
-	25, // 37: kratos.api.Observability.Tracing.export_timeout:type_name -> google.protobuf.Duration
-	20, // 38: kratos.api.Observability.Tracing.attributes:type_name -> kratos.api.Observability.Tracing.AttributesEntry
-	21, // 39: kratos.api.Observability.Metrics.headers:type_name -> kratos.api.Observability.Metrics.HeadersEntry
-	25, // 40: kratos.api.Observability.Metrics.interval:type_name -> google.protobuf.Duration
-	22, // 41: kratos.api.Observability.Metrics.resource_attributes:type_name -> kratos.api.Observability.Metrics.ResourceAttributesEntry
-	5,  // 42: kratos.api.Messaging.TopicsEntry.value:type_name -> kratos.api.PubSub
-	8,  // 43: kratos.api.Messaging.InboxesEntry.value:type_name -> kratos.api.InboxConsumer
-	44, // [44:44] is the sub-list for method output_type
-	44, // [44:44] is the sub-list for method input_type
-	44, // [44:44] is the sub-list for extension type_name
-	44, // [44:44] is the sub-list for extension extendee
-	0,  // [0:44] is the sub-list for field type_name
+	1,   // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
+	2,   // 1: kratos.api.Bootstrap.data:type_name -> kratos.api.Data
+	3,   // 2: kratos.api.Bootstrap.observability:type_name -> kratos.api.Observability
+	4,   // 3: kratos.api.Bootstrap.messaging:type_name -> kratos.api.Messaging
+	9,   // 4: kratos.api.Bootstrap.feed:type_name -> kratos.api.Feed
+	10,  // 5: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
+	11,  // 6: kratos.api.Server.jwt:type_name -> kratos.api.Server.JWT
+	12,  // 7: kratos.api.Server.handlers:type_name -> kratos.api.Server.Handlers
+	13,  // 8: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
+	14,  // 9: kratos.api.Server.rate_limit:type_name -> kratos.api.Server.RateLimit
+	15,  // 10: kratos.api.Server.admin:type_name -> kratos.api.Server.Admin
+	17,  // 11: kratos.api.Data.postgres:type_name -> kratos.api.Data.PostgreSQL
+	18,  // 12: kratos.api.Data.grpc_client:type_name -> kratos.api.Data.Client
+	23,  // 13: kratos.api.Observability.global_attributes:type_name -> kratos.api.Observability.GlobalAttributesEntry
+	21,  // 14: kratos.api.Observability.tracing:type_name -> kratos.api.Observability.Tracing
+	22,  // 15: kratos.api.Observability.metrics:type_name -> kratos.api.Observability.Metrics
+	28,  // 16: kratos.api.Messaging.topics:type_name -> kratos.api.Messaging.TopicsEntry
+	7,   // 17: kratos.api.Messaging.outbox:type_name -> kratos.api.OutboxPublisher
+	29,  // 18: kratos.api.Messaging.inboxes:type_name -> kratos.api.Messaging.InboxesEntry
+	61,  // 19: kratos.api.PubSub.publish_timeout:type_name -> google.protobuf.Duration
+	6,   // 20: kratos.api.PubSub.receive:type_name -> kratos.api.Receive
+	61,  // 21: kratos.api.Receive.max_extension:type_name -> google.protobuf.Duration
+	61,  // 22: kratos.api.Receive.max_extension_period:type_name -> google.protobuf.Duration
+	61,  // 23: kratos.api.OutboxPublisher.tick_interval:type_name -> google.protobuf.Duration
+	61,  // 24: kratos.api.OutboxPublisher.initial_backoff:type_name -> google.protobuf.Duration
+	61,  // 25: kratos.api.OutboxPublisher.max_backoff:type_name -> google.protobuf.Duration
+	61,  // 26: kratos.api.OutboxPublisher.publish_timeout:type_name -> google.protobuf.Duration
+	61,  // 27: kratos.api.OutboxPublisher.lock_ttl:type_name -> google.protobuf.Duration
+	30,  // 28: kratos.api.Feed.idempotency:type_name -> kratos.api.Feed.Idempotency
+	31,  // 29: kratos.api.Feed.guest:type_name -> kratos.api.Feed.Guest
+	32,  // 30: kratos.api.Feed.log_writer:type_name -> kratos.api.Feed.LogWriter
+	33,  // 31: kratos.api.Feed.log_retention:type_name -> kratos.api.Feed.LogRetention
+	34,  // 32: kratos.api.Feed.pseudonymization:type_name -> kratos.api.Feed.Pseudonymization
+	35,  // 33: kratos.api.Feed.log_sampling:type_name -> kratos.api.Feed.LogSampling
+	36,  // 34: kratos.api.Feed.interactions:type_name -> kratos.api.Feed.Interactions
+	37,  // 35: kratos.api.Feed.served_events:type_name -> kratos.api.Feed.ServedEvents
+	38,  // 36: kratos.api.Feed.user_state:type_name -> kratos.api.Feed.UserState
+	39,  // 37: kratos.api.Feed.watched_filter:type_name -> kratos.api.Feed.WatchedFilter
+	40,  // 38: kratos.api.Feed.continue_learning:type_name -> kratos.api.Feed.ContinueLearning
+	41,  // 39: kratos.api.Feed.review_queue:type_name -> kratos.api.Feed.ReviewQueue
+	42,  // 40: kratos.api.Feed.blending:type_name -> kratos.api.Feed.Blending
+	43,  // 41: kratos.api.Feed.rerank:type_name -> kratos.api.Feed.Rerank
+	44,  // 42: kratos.api.Feed.curation:type_name -> kratos.api.Feed.Curation
+	45,  // 43: kratos.api.Feed.experiments:type_name -> kratos.api.Feed.Experiments
+	46,  // 44: kratos.api.Feed.shadow:type_name -> kratos.api.Feed.Shadow
+	47,  // 45: kratos.api.Feed.remote_recommendation:type_name -> kratos.api.Feed.RemoteRecommendation
+	48,  // 46: kratos.api.Feed.mock:type_name -> kratos.api.Feed.Mock
+	61,  // 47: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
+	61,  // 48: kratos.api.Server.Handlers.default_timeout:type_name -> google.protobuf.Duration
+	61,  // 49: kratos.api.Server.Handlers.command_timeout:type_name -> google.protobuf.Duration
+	61,  // 50: kratos.api.Server.Handlers.query_timeout:type_name -> google.protobuf.Duration
+	61,  // 51: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
+	16,  // 52: kratos.api.Server.RateLimit.rules:type_name -> kratos.api.Server.RateLimit.Rule
+	61,  // 53: kratos.api.Data.PostgreSQL.max_conn_lifetime:type_name -> google.protobuf.Duration
+	61,  // 54: kratos.api.Data.PostgreSQL.max_conn_idle_time:type_name -> google.protobuf.Duration
+	61,  // 55: kratos.api.Data.PostgreSQL.health_check_period:type_name -> google.protobuf.Duration
+	19,  // 56: kratos.api.Data.PostgreSQL.transaction:type_name -> kratos.api.Data.PostgreSQL.Transaction
+	20,  // 57: kratos.api.Data.Client.jwt:type_name -> kratos.api.Data.Client.JWT
+	61,  // 58: kratos.api.Data.PostgreSQL.Transaction.default_timeout:type_name -> google.protobuf.Duration
+	61,  // 59: kratos.api.Data.PostgreSQL.Transaction.lock_timeout:type_name -> google.protobuf.Duration
+	24,  // 60: kratos.api.Observability.Tracing.headers:type_name -> kratos.api.Observability.Tracing.HeadersEntry
+	61,  // 61: kratos.api.Observability.Tracing.batch_timeout:type_name -> google.protobuf.Duration
+	61,  // 62: kratos.api.Observability.Tracing.export_timeout:type_name -> google.protobuf.Duration
+	25,  // 63: kratos.api.Observability.Tracing.attributes:type_name -> kratos.api.Observability.Tracing.AttributesEntry
+	26,  // 64: kratos.api.Observability.Metrics.headers:type_name -> kratos.api.Observability.Metrics.HeadersEntry
+	61,  // 65: kratos.api.Observability.Metrics.interval:type_name -> google.protobuf.Duration
+	27,  // 66: kratos.api.Observability.Metrics.resource_attributes:type_name -> kratos.api.Observability.Metrics.ResourceAttributesEntry
+	5,   // 67: kratos.api.Messaging.TopicsEntry.value:type_name -> kratos.api.PubSub
+	8,   // 68: kratos.api.Messaging.InboxesEntry.value:type_name -> kratos.api.InboxConsumer
+	61,  // 69: kratos.api.Feed.Idempotency.ttl:type_name -> google.protobuf.Duration
+	61,  // 70: kratos.api.Feed.Guest.cache_ttl:type_name -> google.protobuf.Duration
+	61,  // 71: kratos.api.Feed.LogWriter.flush_interval:type_name -> google.protobuf.Duration
+	61,  // 72: kratos.api.Feed.LogWriter.flush_timeout:type_name -> google.protobuf.Duration
+	61,  // 73: kratos.api.Feed.LogRetention.retention:type_name -> google.protobuf.Duration
+	61,  // 74: kratos.api.Feed.LogRetention.interval:type_name -> google.protobuf.Duration
+	61,  // 75: kratos.api.Feed.LogRetention.served_page_retention:type_name -> google.protobuf.Duration
+	49,  // 76: kratos.api.Feed.Pseudonymization.keys:type_name -> kratos.api.Feed.Pseudonymization.Key
+	50,  // 77: kratos.api.Feed.LogSampling.rules:type_name -> kratos.api.Feed.LogSampling.Rule
+	61,  // 78: kratos.api.Feed.Interactions.max_event_age:type_name -> google.protobuf.Duration
+	61,  // 79: kratos.api.Feed.Interactions.clock_skew:type_name -> google.protobuf.Duration
+	51,  // 80: kratos.api.Feed.WatchedFilter.scenes:type_name -> kratos.api.Feed.WatchedFilter.Scene
+	52,  // 81: kratos.api.Feed.Blending.sources:type_name -> kratos.api.Feed.Blending.Source
+	61,  // 82: kratos.api.Feed.Blending.default_budget:type_name -> google.protobuf.Duration
+	53,  // 83: kratos.api.Feed.Rerank.mmr:type_name -> kratos.api.Feed.Rerank.MMR
+	54,  // 84: kratos.api.Feed.Rerank.creator_cap:type_name -> kratos.api.Feed.Rerank.CreatorCap
+	55,  // 85: kratos.api.Feed.Rerank.language_run:type_name -> kratos.api.Feed.Rerank.LanguageRun
+	56,  // 86: kratos.api.Feed.Rerank.duration_spread:type_name -> kratos.api.Feed.Rerank.DurationSpread
+	61,  // 87: kratos.api.Feed.Curation.refresh_interval:type_name -> google.protobuf.Duration
+	61,  // 88: kratos.api.Feed.Curation.reconnect_backoff:type_name -> google.protobuf.Duration
+	58,  // 89: kratos.api.Feed.Experiments.experiments:type_name -> kratos.api.Feed.Experiments.Experiment
+	61,  // 90: kratos.api.Feed.Shadow.budget:type_name -> google.protobuf.Duration
+	61,  // 91: kratos.api.Feed.RemoteRecommendation.timeout:type_name -> google.protobuf.Duration
+	59,  // 92: kratos.api.Feed.RemoteRecommendation.hedge:type_name -> kratos.api.Feed.RemoteRecommendation.Hedge
+	60,  // 93: kratos.api.Feed.RemoteRecommendation.breaker:type_name -> kratos.api.Feed.RemoteRecommendation.Breaker
+	61,  // 94: kratos.api.Feed.Blending.Source.budget:type_name -> google.protobuf.Duration
+	61,  // 95: kratos.api.Feed.Rerank.DurationSpread.boundaries:type_name -> google.protobuf.Duration
+	52,  // 96: kratos.api.Feed.Experiments.Variant.blend_sources:type_name -> kratos.api.Feed.Blending.Source
+	43,  // 97: kratos.api.Feed.Experiments.Variant.rerank:type_name -> kratos.api.Feed.Rerank
+	57,  // 98: kratos.api.Feed.Experiments.Experiment.variants:type_name -> kratos.api.Feed.Experiments.Variant
+	61,  // 99: kratos.api.Feed.RemoteRecommendation.Hedge.initial_delay:type_name -> google.protobuf.Duration
+	61,  // 100: kratos.api.Feed.RemoteRecommendation.Hedge.min_delay:type_name -> google.protobuf.Duration
+	61,  // 101: kratos.api.Feed.RemoteRecommendation.Breaker.open_duration:type_name -> google.protobuf.Duration
+	102, // [102:102] is the sub-list for method output_type
+	102, // [102:102] is the sub-list for method input_type
+	102, // [102:102] is the sub-list for extension type_name
+	102, // [102:102] is the sub-list for extension extendee
+	0,   // [0:102] is the sub-list for field type_name
 }
 
 func init() { file_configs_conf_proto_init() }
@@ -1783,16 +4556,17 @@ func file_configs_conf_proto_init() {
 	}
 	file_configs_conf_proto_msgTypes[7].OneofWrappers = []any{}
 	file_configs_conf_proto_msgTypes[8].OneofWrappers = []any{}
-	file_configs_conf_proto_msgTypes[12].OneofWrappers = []any{}
-	file_configs_conf_proto_msgTypes[14].OneofWrappers = []any{}
 	file_configs_conf_proto_msgTypes[17].OneofWrappers = []any{}
+	file_configs_conf_proto_msgTypes[19].OneofWrappers = []any{}
+	file_configs_conf_proto_msgTypes[22].OneofWrappers = []any{}
+	file_configs_conf_proto_msgTypes[51].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_configs_conf_proto_rawDesc), len(file_configs_conf_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   25,
+			NumMessages:   61,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -2,8 +2,14 @@
 // 该层负责将外部 gRPC/REST 调用封装为业务层接口。
 package clients
 
-import "github.com/google/wire"
+import (
+	"github.com/bionicotaku/lingo-services-feed/internal/clients/recommendation"
+	"github.com/google/wire"
+)
 
 // ProviderSet 暴露 Clients 层的构造函数供 Wire 依赖注入使用。
 // 当需要添加外部服务客户端时，在此注册构造器。
-var ProviderSet = wire.NewSet()
+var ProviderSet = wire.NewSet(
+	recommendation.NewGRPCClient, // 外部推荐服务（对冲请求 + 熔断）
+	recommendation.ProvideSource, // 登记为推荐源 remote，未启用时为 nil
+)
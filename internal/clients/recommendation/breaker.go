@@ -0,0 +1,171 @@
+package recommendation
+
+import (
+	"sync"
+	"time"
+)
+
+// BreakerState 为熔断器状态，数值同时作为 feed_recommendation_breaker_state 指标的取值。
+type BreakerState int
+
+const (
+	// BreakerClosed 正常放行请求，统计连续失败次数。
+	BreakerClosed BreakerState = iota
+	// BreakerHalfOpen 打开时长已过，放行有限的探测请求，探测成功即关闭，失败重新打开。
+	BreakerHalfOpen
+	// BreakerOpen 拒绝所有请求，调用方直接走兜底链路。
+	BreakerOpen
+)
+
+// String 返回状态名，用于日志与指标属性。
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerHalfOpen:
+		return "half_open"
+	case BreakerOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+type callOutcome int
+
+const (
+	outcomeSuccess callOutcome = iota
+	outcomeFailure
+	// outcomeIgnored 表示调用方取消或参数错误等与下游健康无关的结果，只归还探测名额。
+	outcomeIgnored
+)
+
+// breaker 是按连续失败计数的三态熔断器。disabled 时始终放行且保持关闭。
+type breaker struct {
+	disabled  bool
+	threshold int
+	openFor   time.Duration
+	probes    int
+	now       func() time.Time
+	onChange  func(from, to BreakerState)
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	inFlight int
+	// generation 在每次状态切换时递增，早于当前代放行的请求其结果不再计入。
+	generation uint64
+}
+
+// breakerTicket 记录请求被放行时熔断器所处的代，done 据此丢弃跨越状态切换的迟到结果。
+type breakerTicket struct {
+	generation uint64
+}
+
+func newBreaker(enabled bool, threshold int, openFor time.Duration, probes int, onChange func(from, to BreakerState)) *breaker {
+	if threshold <= 0 {
+		threshold = defaultBreakerFailureThreshold
+	}
+	if openFor <= 0 {
+		openFor = defaultBreakerOpenDuration
+	}
+	if probes <= 0 {
+		probes = defaultBreakerHalfOpenProbes
+	}
+	return &breaker{
+		disabled:  !enabled,
+		threshold: threshold,
+		openFor:   openFor,
+		probes:    probes,
+		now:       time.Now,
+		onChange:  onChange,
+	}
+}
+
+// State 返回当前状态；打开时长已过但尚无请求到达时仍报告 open。
+func (b *breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// allow 判断是否放行本次请求。放行后调用方必须以 done 报告结果并带回返回的 ticket，半开状态据此归还探测名额。
+func (b *breaker) allow() (breakerTicket, bool) {
+	if b.disabled {
+		return breakerTicket{}, true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.openFor {
+			return breakerTicket{}, false
+		}
+		b.transition(BreakerHalfOpen)
+		fallthrough
+	case BreakerHalfOpen:
+		if b.inFlight >= b.probes {
+			return breakerTicket{}, false
+		}
+		b.inFlight++
+	}
+	return breakerTicket{generation: b.generation}, true
+}
+
+// done 报告放行请求的结果。放行后熔断器已切换过状态时结果作废：关闭期间放行的慢请求
+// 不会在半开或重新关闭后扣减探测名额、重新打开熔断器。
+func (b *breaker) done(ticket breakerTicket, outcome callOutcome) {
+	if b.disabled {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if ticket.generation != b.generation {
+		return
+	}
+	if b.state == BreakerHalfOpen {
+		if b.inFlight > 0 {
+			b.inFlight--
+		}
+		switch outcome {
+		case outcomeSuccess:
+			b.failures = 0
+			b.transition(BreakerClosed)
+		case outcomeFailure:
+			b.open()
+		}
+		return
+	}
+	if b.state != BreakerClosed {
+		return
+	}
+	switch outcome {
+	case outcomeSuccess:
+		b.failures = 0
+	case outcomeFailure:
+		b.failures++
+		if b.failures >= b.threshold {
+			b.open()
+		}
+	}
+}
+
+func (b *breaker) open() {
+	b.openedAt = b.now()
+	b.failures = 0
+	b.inFlight = 0
+	b.transition(BreakerOpen)
+}
+
+func (b *breaker) transition(to BreakerState) {
+	from := b.state
+	if from == to {
+		return
+	}
+	b.state = to
+	b.generation++
+	if b.onChange != nil {
+		b.onChange(from, to)
+	}
+}
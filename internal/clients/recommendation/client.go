@@ -0,0 +1,271 @@
+// Package recommendation 封装外部推荐服务的 gRPC 客户端，实现 services.RecommendationProvider。
+// 客户端在多个后端之间轮询，支持按延迟分位数向另一后端发送对冲请求，并维护显式的三态熔断器：
+// 熔断打开时请求不出站，直接返回 services.ErrRecommendationCircuitOpen，由兜底链路接管。
+package recommendation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sync/atomic"
+	"time"
+
+	recommendationv1 "github.com/bionicotaku/lingo-services-feed/api/recommendation/v1"
+	configloader "github.com/bionicotaku/lingo-services-feed/internal/infrastructure/configloader"
+	grpcclient "github.com/bionicotaku/lingo-services-feed/internal/infrastructure/grpc_client"
+	"github.com/bionicotaku/lingo-services-feed/internal/services"
+
+	"github.com/bionicotaku/lingo-utils/gcjwt"
+	"github.com/bionicotaku/lingo-utils/observability"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	remoteSource = "remote"
+
+	defaultTimeout                 = 200 * time.Millisecond
+	defaultHedgePercentile         = 0.95
+	defaultHedgeInitialDelay       = 50 * time.Millisecond
+	defaultHedgeWindow             = 200
+	defaultBreakerFailureThreshold = 5
+	defaultBreakerOpenDuration     = 10 * time.Second
+	defaultBreakerHalfOpenProbes   = 1
+
+	hedgeTriggerDelay = "delay"
+	hedgeTriggerError = "error"
+)
+
+// Client 调用外部推荐服务。并发安全，可同时登记为推荐源与主推荐链。
+type Client struct {
+	backends []recommendationv1.RecommendationServiceClient
+	timeout  time.Duration
+	hedge    bool
+	latency  *latencyTracker
+	breaker  *breaker
+	next     atomic.Uint64
+	metrics  *clientMetrics
+	log      *log.Helper
+}
+
+// NewClient 以已建立的后端连接构造客户端；后端不足两个时关闭对冲并告警。
+func NewClient(backends []recommendationv1.RecommendationServiceClient, cfg services.RemoteRecommendationConfig, logger log.Logger) (*Client, error) {
+	if len(backends) == 0 {
+		return nil, errors.New("recommendation client: no backends")
+	}
+	helper := log.NewHelper(logger)
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	hedge := cfg.Hedge.Enabled
+	if hedge && len(backends) < 2 {
+		helper.Warn("recommendation client: hedging requires at least two targets; disabled")
+		hedge = false
+	}
+	c := &Client{
+		backends: backends,
+		timeout:  timeout,
+		hedge:    hedge,
+		latency:  newLatencyTracker(cfg.Hedge.Percentile, cfg.Hedge.Window, cfg.Hedge.InitialDelay, cfg.Hedge.MinDelay, timeout),
+		log:      helper,
+	}
+	c.metrics = newClientMetrics()
+	c.breaker = newBreaker(cfg.Breaker.Enabled, cfg.Breaker.FailureThreshold, cfg.Breaker.OpenDuration, cfg.Breaker.HalfOpenProbes, func(from, to BreakerState) {
+		c.metrics.recordTransition(context.Background(), from, to)
+		helper.Infow("msg", "recommendation client: breaker state changed", "from", from.String(), "to", to.String())
+	})
+	c.metrics.observeBreaker(c.breaker)
+	return c, nil
+}
+
+// NewGRPCClient 按配置拨号到各推荐后端并构造客户端；未启用时返回 nil。
+// 出站连接沿用 data.grpc_client 的 metadata 透传与 JWT 设置，不挂载 kratos 自适应熔断。返回的 cleanup 关闭所有连接。
+func NewGRPCClient(cfg services.RemoteRecommendationConfig, grpcCfg configloader.GRPCClientConfig, metricsCfg *observability.MetricsConfig, jwt *gcjwt.Component, logger log.Logger) (*Client, func(), error) {
+	if !cfg.Enabled {
+		return nil, func() {}, nil
+	}
+	if len(cfg.Targets) == 0 {
+		return nil, nil, errors.New("recommendation client: no targets configured")
+	}
+	mw, err := gcjwt.ProvideClientMiddleware(jwt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("recommendation client: jwt middleware: %w", err)
+	}
+	helper := log.NewHelper(logger)
+	conns := make([]*grpc.ClientConn, 0, len(cfg.Targets))
+	closeAll := func() {
+		for _, conn := range conns {
+			if err := conn.Close(); err != nil {
+				helper.Errorf("close recommendation client: %v", err)
+			}
+		}
+	}
+	backends := make([]recommendationv1.RecommendationServiceClient, 0, len(cfg.Targets))
+	for _, target := range cfg.Targets {
+		conn, err := grpcclient.Dial(target, grpcCfg, metricsCfg, mw, false)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("recommendation client: dial %s: %w", target, err)
+		}
+		conns = append(conns, conn)
+		backends = append(backends, recommendationv1.NewRecommendationServiceClient(conn))
+	}
+	client, err := NewClient(backends, cfg, logger)
+	if err != nil {
+		closeAll()
+		return nil, nil, err
+	}
+	return client, func() {
+		client.metrics.close()
+		closeAll()
+	}, nil
+}
+
+// ProvideSource 将客户端登记为推荐源；未启用时返回 nil 接口。
+func ProvideSource(client *Client) services.RemoteRecommendationSource {
+	if client == nil {
+		return nil
+	}
+	return client
+}
+
+// Source 返回推荐来源标识。
+func (c *Client) Source() string {
+	return remoteSource
+}
+
+// State 返回熔断器当前状态。
+func (c *Client) State() BreakerState {
+	return c.breaker.State()
+}
+
+type attemptResult struct {
+	resp  *recommendationv1.GetFeedResponse
+	err   error
+	hedge bool
+}
+
+// GetFeed 调用推荐服务。熔断打开时立即返回 services.ErrRecommendationCircuitOpen；
+// 首个请求与对冲请求都失败时返回最后一个错误，并计入熔断器的连续失败。
+func (c *Client) GetFeed(ctx context.Context, input services.RecommendationInput) (*services.RecommendationResult, error) {
+	ticket, allowed := c.breaker.allow()
+	if !allowed {
+		c.metrics.recordShortCircuit(ctx)
+		return nil, services.ErrRecommendationCircuitOpen
+	}
+	resp, err := c.call(ctx, &recommendationv1.GetFeedRequest{
+		UserId:      input.UserID,
+		Limit:       int32(input.Limit),
+		Scene:       input.Scene,
+		Cursor:      input.Cursor,
+		Experiments: input.Experiments,
+	})
+	c.breaker.done(ticket, classify(ctx, err))
+	if err != nil {
+		return nil, fmt.Errorf("recommendation client: get feed: %w", err)
+	}
+	result := &services.RecommendationResult{
+		Items:      make([]services.RecommendationItem, 0, len(resp.GetItems())),
+		Source:     remoteSource,
+		NextCursor: resp.GetNextCursor(),
+	}
+	for _, item := range resp.GetItems() {
+		result.Items = append(result.Items, services.RecommendationItem{
+			VideoID:  item.GetVideoId(),
+			Reason:   item.GetReasonCode(),
+			Score:    item.GetScore(),
+			Metadata: maps.Clone(item.GetMetadata()),
+		})
+	}
+	return result, nil
+}
+
+// call 向轮询选中的后端发起请求；启用对冲时，在延迟分位数到达或首个请求失败后向下一个后端补发一次，
+// 以先成功者为准，另一请求随 callCtx 取消。
+//
+// 每个尝试结束时各自记录耗时，落败方同样计入：在胜出前完成的按实际耗时记录；被取消或超时的记录截至取消时的耗时，
+// 作为其真实耗时的下界。只统计胜出方会系统性地漏掉慢请求，使分位数偏低、对冲越发频繁。
+// 快速失败的尝试不反映正常应答耗时，调用方自身取消时也不记录。
+func (c *Client) call(ctx context.Context, req *recommendationv1.GetFeedRequest) (*recommendationv1.GetFeedResponse, error) {
+	callCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	first := int(c.next.Add(1)-1) % len(c.backends)
+	results := make(chan attemptResult, 2)
+	launch := func(backend int, hedge bool) {
+		go func() {
+			started := time.Now()
+			resp, err := c.backends[backend].GetFeed(callCtx, req)
+			latency := time.Since(started)
+			if err == nil || (callCtx.Err() != nil && ctx.Err() == nil) {
+				c.latency.observe(latency)
+			}
+			results <- attemptResult{resp: resp, err: err, hedge: hedge}
+		}()
+	}
+	launch(first, false)
+	pending := 1
+
+	var timer <-chan time.Time
+	if c.hedge {
+		t := time.NewTimer(c.latency.hedgeDelay())
+		defer t.Stop()
+		timer = t.C
+	}
+	hedged := false
+	sendHedge := func(trigger string) {
+		hedged = true
+		timer = nil
+		pending++
+		c.metrics.recordHedge(ctx, trigger)
+		launch((first+1)%len(c.backends), true)
+	}
+
+	var lastErr error
+	for pending > 0 {
+		select {
+		case <-timer:
+			sendHedge(hedgeTriggerDelay)
+		case r := <-results:
+			pending--
+			if r.err == nil {
+				if r.hedge {
+					c.metrics.recordHedgeWin(ctx)
+				}
+				return r.resp, nil
+			}
+			lastErr = r.err
+			if c.hedge && !hedged && callCtx.Err() == nil && retryable(r.err) {
+				sendHedge(hedgeTriggerError)
+			}
+		}
+	}
+	return nil, lastErr
+}
+
+// classify 将调用结果归入熔断统计：调用方取消或请求参数类错误不反映下游健康，不计入连续失败。
+func classify(ctx context.Context, err error) callOutcome {
+	switch {
+	case err == nil:
+		return outcomeSuccess
+	case ctx.Err() != nil:
+		return outcomeIgnored
+	case retryable(err):
+		return outcomeFailure
+	default:
+		return outcomeIgnored
+	}
+}
+
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted, codes.Internal, codes.Unknown:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,83 @@
+package recommendation
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// minLatencySamples 为按分位数计算对冲延迟所需的最少样本数，不足时使用 initialDelay。
+const minLatencySamples = 20
+
+// latencyTracker 以环形缓冲保存最近 window 次调用尝试的耗时（含对冲落败方），估算对冲延迟。
+type latencyTracker struct {
+	percentile   float64
+	initialDelay time.Duration
+	minDelay     time.Duration
+	maxDelay     time.Duration
+
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+	full    bool
+}
+
+func newLatencyTracker(percentile float64, window int, initialDelay, minDelay, maxDelay time.Duration) *latencyTracker {
+	if percentile <= 0 || percentile > 1 {
+		percentile = defaultHedgePercentile
+	}
+	if window <= 0 {
+		window = defaultHedgeWindow
+	}
+	if initialDelay <= 0 {
+		initialDelay = defaultHedgeInitialDelay
+	}
+	return &latencyTracker{
+		percentile:   percentile,
+		initialDelay: initialDelay,
+		minDelay:     minDelay,
+		maxDelay:     maxDelay,
+		samples:      make([]time.Duration, window),
+	}
+}
+
+func (t *latencyTracker) observe(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.samples[t.next] = d
+	t.next++
+	if t.next == len(t.samples) {
+		t.next = 0
+		t.full = true
+	}
+}
+
+// hedgeDelay 返回窗口内耗时的 percentile 分位数（最近秩法），不低于 minDelay；
+// 分位数达到总超时时取总超时的一半，保证对冲请求仍有时间返回。
+func (t *latencyTracker) hedgeDelay() time.Duration {
+	t.mu.Lock()
+	n := t.next
+	if t.full {
+		n = len(t.samples)
+	}
+	var sorted []time.Duration
+	if n >= min(minLatencySamples, len(t.samples)) {
+		sorted = slices.Clone(t.samples[:n])
+	}
+	t.mu.Unlock()
+
+	delay := t.initialDelay
+	if sorted != nil {
+		slices.Sort(sorted)
+		rank := int(math.Ceil(t.percentile*float64(n))) - 1
+		delay = sorted[max(rank, 0)]
+	}
+	if delay < t.minDelay {
+		delay = t.minDelay
+	}
+	if t.maxDelay > 0 && delay >= t.maxDelay {
+		delay = t.maxDelay / 2
+	}
+	return delay
+}
@@ -0,0 +1,109 @@
+package recommendation
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	noopmetric "go.opentelemetry.io/otel/metric/noop"
+)
+
+type clientMetrics struct {
+	hedges        metric.Int64Counter
+	hedgeWins     metric.Int64Counter
+	shortCircuits metric.Int64Counter
+	transitions   metric.Int64Counter
+	state         metric.Int64ObservableGauge
+	meter         metric.Meter
+	registration  metric.Registration
+	enabled       bool
+}
+
+func newClientMetrics() *clientMetrics {
+	meterProvider := otel.GetMeterProvider()
+	if meterProvider == nil {
+		meterProvider = noopmetric.NewMeterProvider()
+	}
+	meter := meterProvider.Meter("lingo-services-feed.recommendation_client")
+
+	hedges, err := meter.Int64Counter("feed_recommendation_hedges_total", metric.WithDescription("Number of hedged recommendation requests sent to a second backend"))
+	if err != nil {
+		return &clientMetrics{}
+	}
+	hedgeWins, err := meter.Int64Counter("feed_recommendation_hedge_wins_total", metric.WithDescription("Number of recommendation calls answered by the hedged request"))
+	if err != nil {
+		return &clientMetrics{}
+	}
+	shortCircuits, err := meter.Int64Counter("feed_recommendation_short_circuits_total", metric.WithDescription("Number of recommendation calls rejected by the open circuit breaker"))
+	if err != nil {
+		return &clientMetrics{}
+	}
+	transitions, err := meter.Int64Counter("feed_recommendation_breaker_transitions_total", metric.WithDescription("Number of recommendation circuit breaker state changes"))
+	if err != nil {
+		return &clientMetrics{}
+	}
+	state, err := meter.Int64ObservableGauge("feed_recommendation_breaker_state", metric.WithDescription("Recommendation circuit breaker state: 0 closed, 1 half_open, 2 open"))
+	if err != nil {
+		return &clientMetrics{}
+	}
+	return &clientMetrics{
+		hedges:        hedges,
+		hedgeWins:     hedgeWins,
+		shortCircuits: shortCircuits,
+		transitions:   transitions,
+		state:         state,
+		meter:         meter,
+		enabled:       true,
+	}
+}
+
+// observeBreaker 注册熔断器状态的异步采集回调。
+func (m *clientMetrics) observeBreaker(b *breaker) {
+	if m == nil || !m.enabled {
+		return
+	}
+	registration, err := m.meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveInt64(m.state, int64(b.State()))
+		return nil
+	}, m.state)
+	if err != nil {
+		return
+	}
+	m.registration = registration
+}
+
+func (m *clientMetrics) close() {
+	if m == nil || m.registration == nil {
+		return
+	}
+	_ = m.registration.Unregister()
+}
+
+func (m *clientMetrics) recordHedge(ctx context.Context, trigger string) {
+	if m == nil || !m.enabled {
+		return
+	}
+	m.hedges.Add(ctx, 1, metric.WithAttributes(attribute.String("trigger", trigger)))
+}
+
+func (m *clientMetrics) recordHedgeWin(ctx context.Context) {
+	if m == nil || !m.enabled {
+		return
+	}
+	m.hedgeWins.Add(ctx, 1)
+}
+
+func (m *clientMetrics) recordShortCircuit(ctx context.Context) {
+	if m == nil || !m.enabled {
+		return
+	}
+	m.shortCircuits.Add(ctx, 1)
+}
+
+func (m *clientMetrics) recordTransition(ctx context.Context, from, to BreakerState) {
+	if m == nil || !m.enabled {
+		return
+	}
+	m.transitions.Add(ctx, 1, metric.WithAttributes(attribute.String("from", from.String()), attribute.String("to", to.String())))
+}
@@ -0,0 +1,340 @@
+package recommendation_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	recommendationv1 "github.com/bionicotaku/lingo-services-feed/api/recommendation/v1"
+	"github.com/bionicotaku/lingo-services-feed/internal/clients/recommendation"
+	"github.com/bionicotaku/lingo-services-feed/internal/services"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// fault 描述一次调用注入的延迟与错误。
+type fault struct {
+	delay time.Duration
+	err   error
+}
+
+// fakeServer 是可注入延迟与错误的推荐服务，按后端名返回条目，便于判断由哪个后端应答。
+type fakeServer struct {
+	recommendationv1.UnimplementedRecommendationServiceServer
+	name string
+
+	mu    sync.Mutex
+	fault fault
+	last  *recommendationv1.GetFeedRequest
+	calls atomic.Int32
+}
+
+func (f *fakeServer) setFault(ft fault) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fault = ft
+}
+
+func (f *fakeServer) GetFeed(ctx context.Context, req *recommendationv1.GetFeedRequest) (*recommendationv1.GetFeedResponse, error) {
+	f.calls.Add(1)
+	f.mu.Lock()
+	ft := f.fault
+	f.last = req
+	f.mu.Unlock()
+	if ft.delay > 0 {
+		select {
+		case <-time.After(ft.delay):
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+	if ft.err != nil {
+		return nil, ft.err
+	}
+	return &recommendationv1.GetFeedResponse{
+		Items: []*recommendationv1.Item{
+			{VideoId: f.name + "-1", ReasonCode: "cf.similar_users", Score: 0.9, Metadata: map[string]string{"backend": f.name}},
+			{VideoId: f.name + "-2", ReasonCode: "cf.similar_users", Score: 0.8},
+		},
+		NextCursor: "next",
+	}, nil
+}
+
+func startBackend(t *testing.T, name string) (*fakeServer, recommendationv1.RecommendationServiceClient) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	fake := &fakeServer{name: name}
+	recommendationv1.RegisterRecommendationServiceServer(srv, fake)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return fake, recommendationv1.NewRecommendationServiceClient(conn)
+}
+
+func newClient(t *testing.T, cfg services.RemoteRecommendationConfig, backends ...recommendationv1.RecommendationServiceClient) *recommendation.Client {
+	t.Helper()
+	client, err := recommendation.NewClient(backends, cfg, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+	return client
+}
+
+func TestClient_GetFeed_MapsRequestAndResponse(t *testing.T) {
+	server, backend := startBackend(t, "a")
+	client := newClient(t, services.RemoteRecommendationConfig{Timeout: time.Second}, backend)
+
+	result, err := client.GetFeed(context.Background(), services.RecommendationInput{
+		UserID:      "user-1",
+		Limit:       2,
+		Scene:       services.SceneHome,
+		Cursor:      "c1",
+		Experiments: map[string]string{"ranker": "treatment"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "remote", result.Source)
+	require.Equal(t, "next", result.NextCursor)
+	require.Equal(t, []services.RecommendationItem{
+		{VideoID: "a-1", Reason: "cf.similar_users", Score: 0.9, Metadata: map[string]string{"backend": "a"}},
+		{VideoID: "a-2", Reason: "cf.similar_users", Score: 0.8},
+	}, result.Items)
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	require.Equal(t, "user-1", server.last.GetUserId())
+	require.EqualValues(t, 2, server.last.GetLimit())
+	require.Equal(t, "c1", server.last.GetCursor())
+	require.Equal(t, map[string]string{"ranker": "treatment"}, server.last.GetExperiments())
+}
+
+func TestClient_GetFeed_HedgesSlowBackend(t *testing.T) {
+	slow, slowBackend := startBackend(t, "slow")
+	fast, fastBackend := startBackend(t, "fast")
+	slow.setFault(fault{delay: 2 * time.Second})
+
+	client := newClient(t, services.RemoteRecommendationConfig{
+		Timeout: 3 * time.Second,
+		Hedge:   services.RemoteHedgeConfig{Enabled: true, InitialDelay: 20 * time.Millisecond},
+	}, slowBackend, fastBackend)
+
+	// 首个请求轮询到慢后端，超过对冲延迟后改由快后端应答，无需等待慢后端。
+	started := time.Now()
+	result, err := client.GetFeed(context.Background(), services.RecommendationInput{UserID: "user-1", Limit: 2})
+	require.NoError(t, err)
+	require.Less(t, time.Since(started), time.Second)
+	require.Equal(t, "fast-1", result.Items[0].VideoID)
+	require.EqualValues(t, 1, slow.calls.Load())
+	require.EqualValues(t, 1, fast.calls.Load())
+}
+
+func TestClient_GetFeed_HedgeDelayFollowsObservedPercentile(t *testing.T) {
+	a, backendA := startBackend(t, "a")
+	b, backendB := startBackend(t, "b")
+	client := newClient(t, services.RemoteRecommendationConfig{
+		Timeout: 3 * time.Second,
+		Hedge:   services.RemoteHedgeConfig{Enabled: true, Percentile: 0.95, InitialDelay: 2 * time.Second, Window: 50},
+	}, backendA, backendB)
+	ctx := context.Background()
+	input := services.RecommendationInput{UserID: "user-1", Limit: 2}
+
+	// 预热后对冲延迟取本地调用的 p95（毫秒级），不再使用 2s 的初始值。
+	for i := 0; i < 40; i++ {
+		_, err := client.GetFeed(ctx, input)
+		require.NoError(t, err)
+	}
+	a.setFault(fault{delay: 2 * time.Second})
+	// 预热阶段偶有超过 p95 的调用触发对冲，只统计之后的调用次数。
+	warmed := b.calls.Load()
+	for i := 0; i < 2; i++ {
+		started := time.Now()
+		result, err := client.GetFeed(ctx, input)
+		require.NoError(t, err)
+		require.Less(t, time.Since(started), time.Second)
+		require.Equal(t, "b-1", result.Items[0].VideoID)
+	}
+	require.Equal(t, warmed+2, b.calls.Load())
+}
+
+func TestClient_GetFeed_HedgeDelayCountsLosingAttempts(t *testing.T) {
+	a, backendA := startBackend(t, "a")
+	b, backendB := startBackend(t, "b")
+	a.setFault(fault{delay: 500 * time.Millisecond})
+	client := newClient(t, services.RemoteRecommendationConfig{
+		Timeout: 3 * time.Second,
+		Hedge:   services.RemoteHedgeConfig{Enabled: true, Percentile: 1, InitialDelay: 100 * time.Millisecond, Window: 20},
+	}, backendA, backendB)
+	ctx := context.Background()
+	input := services.RecommendationInput{UserID: "user-1", Limit: 2}
+
+	// 从 a 发起的请求在 100ms 后对冲到 b 并由 b 胜出；落败的 a 按取消时的耗时（约 100ms）计入窗口。
+	for i := 0; i < 20; i++ {
+		_, err := client.GetFeed(ctx, input)
+		require.NoError(t, err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	// 对冲延迟约为 100ms：从 b 发起、耗时 40ms 的请求不再对冲到 a。
+	a.setFault(fault{})
+	b.setFault(fault{delay: 40 * time.Millisecond})
+	callsA := a.calls.Load()
+	for i := 0; i < 2; i++ {
+		_, err := client.GetFeed(ctx, input)
+		require.NoError(t, err)
+	}
+	require.Equal(t, callsA+1, a.calls.Load())
+}
+
+func TestClient_GetFeed_HedgesFailedAttemptAndWithoutHedgeFails(t *testing.T) {
+	broken, brokenBackend := startBackend(t, "broken")
+	_, healthyBackend := startBackend(t, "healthy")
+	broken.setFault(fault{err: status.Error(codes.Unavailable, "injected")})
+
+	client := newClient(t, services.RemoteRecommendationConfig{
+		Timeout: time.Second,
+		Hedge:   services.RemoteHedgeConfig{Enabled: true, InitialDelay: time.Second},
+	}, brokenBackend, healthyBackend)
+	result, err := client.GetFeed(context.Background(), services.RecommendationInput{UserID: "user-1", Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, "healthy-1", result.Items[0].VideoID)
+
+	// 参数类错误不触发对冲。
+	broken.setFault(fault{err: status.Error(codes.InvalidArgument, "bad limit")})
+	client = newClient(t, services.RemoteRecommendationConfig{
+		Timeout: time.Second,
+		Hedge:   services.RemoteHedgeConfig{Enabled: true, InitialDelay: time.Second},
+	}, brokenBackend, healthyBackend)
+	_, err = client.GetFeed(context.Background(), services.RecommendationInput{UserID: "user-1", Limit: 2})
+	require.Equal(t, codes.InvalidArgument, status.Code(errors.Unwrap(err)))
+
+	// 单后端时对冲关闭，超时直接返回错误。
+	broken.setFault(fault{delay: time.Second})
+	client = newClient(t, services.RemoteRecommendationConfig{
+		Timeout: 50 * time.Millisecond,
+		Hedge:   services.RemoteHedgeConfig{Enabled: true, InitialDelay: 10 * time.Millisecond},
+	}, brokenBackend)
+	_, err = client.GetFeed(context.Background(), services.RecommendationInput{UserID: "user-1", Limit: 2})
+	require.Error(t, err)
+}
+
+func TestClient_Breaker_ShortCircuitsToFallback(t *testing.T) {
+	server, backend := startBackend(t, "a")
+	server.setFault(fault{err: status.Error(codes.Unavailable, "injected")})
+	client := newClient(t, services.RemoteRecommendationConfig{
+		Timeout: time.Second,
+		Breaker: services.RemoteBreakerConfig{Enabled: true, FailureThreshold: 2, OpenDuration: 100 * time.Millisecond, HalfOpenProbes: 1},
+	}, backend)
+	ctx := context.Background()
+	input := services.RecommendationInput{UserID: "user-1", Limit: 2}
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GetFeed(ctx, input)
+		require.Error(t, err)
+		require.NotErrorIs(t, err, services.ErrRecommendationCircuitOpen)
+	}
+	require.Equal(t, recommendation.BreakerOpen, client.State())
+
+	// 熔断打开时请求不出站，兜底链路直接接管。
+	_, err := client.GetFeed(ctx, input)
+	require.ErrorIs(t, err, services.ErrRecommendationCircuitOpen)
+	require.EqualValues(t, 2, server.calls.Load())
+
+	fallback := &staticProvider{name: "mock", ids: []string{"m-1"}}
+	provider := services.NewFallbackRecommendationProvider(client, fallback, log.NewStdLogger(io.Discard))
+	require.Equal(t, "remote", provider.Source())
+	result, err := provider.GetFeed(ctx, input)
+	require.NoError(t, err)
+	require.Equal(t, "mock", result.Source)
+	require.EqualValues(t, 2, server.calls.Load())
+
+	// 打开时长过后放行一次探测：失败重新打开，成功则关闭。
+	time.Sleep(150 * time.Millisecond)
+	_, err = client.GetFeed(ctx, input)
+	require.NotErrorIs(t, err, services.ErrRecommendationCircuitOpen)
+	require.Equal(t, recommendation.BreakerOpen, client.State())
+	require.EqualValues(t, 3, server.calls.Load())
+
+	server.setFault(fault{})
+	time.Sleep(150 * time.Millisecond)
+	result, err = provider.GetFeed(ctx, input)
+	require.NoError(t, err)
+	require.Equal(t, "remote", result.Source)
+	require.Equal(t, recommendation.BreakerClosed, client.State())
+}
+
+func TestClient_Breaker_IgnoresCallerCancellation(t *testing.T) {
+	server, backend := startBackend(t, "a")
+	server.setFault(fault{delay: time.Second})
+	client := newClient(t, services.RemoteRecommendationConfig{
+		Timeout: 2 * time.Second,
+		Breaker: services.RemoteBreakerConfig{Enabled: true, FailureThreshold: 1, OpenDuration: time.Minute},
+	}, backend)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.GetFeed(ctx, services.RecommendationInput{UserID: "user-1", Limit: 2})
+	require.Error(t, err)
+	require.Equal(t, recommendation.BreakerClosed, client.State())
+}
+
+// staticProvider 返回固定条目，作为兜底链路。
+type staticProvider struct {
+	name string
+	ids  []string
+}
+
+func (s *staticProvider) GetFeed(context.Context, services.RecommendationInput) (*services.RecommendationResult, error) {
+	items := make([]services.RecommendationItem, 0, len(s.ids))
+	for _, id := range s.ids {
+		items = append(items, services.RecommendationItem{VideoID: id})
+	}
+	return &services.RecommendationResult{Items: items, Source: s.name}, nil
+}
+
+func (s *staticProvider) Source() string { return s.name }
+
+func TestClient_Breaker_IgnoresOutcomeFromEarlierState(t *testing.T) {
+	server, backend := startBackend(t, "a")
+	client := newClient(t, services.RemoteRecommendationConfig{
+		Timeout: 2 * time.Second,
+		Breaker: services.RemoteBreakerConfig{Enabled: true, FailureThreshold: 1, OpenDuration: 100 * time.Millisecond, HalfOpenProbes: 1},
+	}, backend)
+	ctx := context.Background()
+	input := services.RecommendationInput{UserID: "user-1", Limit: 2}
+
+	// 关闭期间放行一个慢请求，最终失败。
+	server.setFault(fault{delay: 400 * time.Millisecond, err: status.Error(codes.Unavailable, "slow")})
+	slow := make(chan error, 1)
+	go func() {
+		_, err := client.GetFeed(ctx, input)
+		slow <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	// 期间的快速失败打开熔断，打开时长过后探测成功重新关闭。
+	server.setFault(fault{err: status.Error(codes.Unavailable, "fast")})
+	_, err := client.GetFeed(ctx, input)
+	require.Error(t, err)
+	require.Equal(t, recommendation.BreakerOpen, client.State())
+	time.Sleep(150 * time.Millisecond)
+	server.setFault(fault{})
+	_, err = client.GetFeed(ctx, input)
+	require.NoError(t, err)
+	require.Equal(t, recommendation.BreakerClosed, client.State())
+
+	// 迟到的失败属于上一轮关闭期，不再打开熔断器。
+	require.Error(t, <-slow)
+	require.Equal(t, recommendation.BreakerClosed, client.State())
+}
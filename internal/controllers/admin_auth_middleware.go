@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"google.golang.org/grpc/codes"
+)
+
+// adminOperationPrefix 为 feed.admin.v1 下所有 RPC 的 Operation 前缀。
+const adminOperationPrefix = "/feed.admin.v1."
+
+// AdminAuthPolicy 描述访问管理端 RPC 所需的身份：HeaderKey 携带签名校验通过且 aud 为 Audience 的 ID Token。
+type AdminAuthPolicy struct {
+	Enabled   bool
+	Audience  string
+	HeaderKey string
+}
+
+// AdminTokenVerifier 为按 AdminAuthPolicy 校验签名 Token 的中间件，校验失败时不调用下游 handler。
+type AdminTokenVerifier middleware.Middleware
+
+// AdminAuthMiddleware 仅对 feed.admin.v1 的 RPC 生效的鉴权中间件，未启用管理端时为 nil。
+type AdminAuthMiddleware middleware.Middleware
+
+// NewAdminAuthMiddleware 构造管理端鉴权中间件。
+//
+// 公共 FeedService 的请求直接放行；管理端请求交由 verifier 校验签名 Token，
+// 不信任 Gateway 透传的 userinfo（公共监听端口上可被调用方伪造）。verifier 缺失时管理端请求一律拒绝。
+func NewAdminAuthMiddleware(policy AdminAuthPolicy, verifier AdminTokenVerifier) AdminAuthMiddleware {
+	if !policy.Enabled {
+		return nil
+	}
+	return func(handler middleware.Handler) middleware.Handler {
+		verified := handler
+		if verifier != nil {
+			verified = verifier(handler)
+		}
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok || !strings.HasPrefix(tr.Operation(), adminOperationPrefix) {
+				return handler(ctx, req)
+			}
+			if verifier == nil {
+				return nil, problemError(codes.Unauthenticated, reasonUnauthenticated, "admin token verifier unavailable", nil)
+			}
+			return verified(ctx, req)
+		}
+	}
+}
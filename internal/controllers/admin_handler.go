@@ -0,0 +1,302 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	adminv1 "github.com/bionicotaku/lingo-services-feed/api/feed/admin/v1"
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/services"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RecommendationLogAdminAPI 定义 FeedAdminHandler 依赖的 Service 能力。
+type RecommendationLogAdminAPI interface {
+	List(ctx context.Context, query services.RecommendationLogQuery) (*services.RecommendationLogPage, error)
+	Get(ctx context.Context, logID string) (*po.FeedRecommendationLog, error)
+}
+
+// CurationRuleAdminAPI 定义运营干预规则管理所需的 Service 能力。
+type CurationRuleAdminAPI interface {
+	List(ctx context.Context, includeExpired bool) ([]*po.CurationRule, error)
+	Create(ctx context.Context, input services.CurationRuleInput) (*po.CurationRule, error)
+	Update(ctx context.Context, ruleID string, input services.CurationRuleInput) (*po.CurationRule, error)
+	Delete(ctx context.Context, ruleID string) error
+}
+
+// FeedAdminHandler 实现 feed.admin.v1.FeedAdminService，仅注册在 gRPC Server 上。
+type FeedAdminHandler struct {
+	adminv1.UnimplementedFeedAdminServiceServer
+
+	*BaseHandler
+	logs     RecommendationLogAdminAPI
+	curation CurationRuleAdminAPI
+	log      *log.Helper
+}
+
+// NewFeedAdminHandler 构造 FeedAdminHandler。
+func NewFeedAdminHandler(logs RecommendationLogAdminAPI, curation CurationRuleAdminAPI, base *BaseHandler, logger log.Logger) *FeedAdminHandler {
+	if base == nil {
+		base = NewBaseHandler(HandlerTimeouts{})
+	}
+	return &FeedAdminHandler{
+		BaseHandler: base,
+		logs:        logs,
+		curation:    curation,
+		log:         log.NewHelper(logger),
+	}
+}
+
+// ListRecommendationLogs 按 (generated_at, log_id) 倒序分页返回推荐日志。
+func (h *FeedAdminHandler) ListRecommendationLogs(ctx context.Context, req *adminv1.ListRecommendationLogsRequest) (*adminv1.ListRecommendationLogsResponse, error) {
+	if req == nil {
+		return nil, problemError(codes.InvalidArgument, reasonInvalidArgument, "request is nil", nil)
+	}
+	query := services.RecommendationLogQuery{
+		UserID:      req.GetUserId(),
+		UserIDHash:  req.GetUserIdHash(),
+		Source:      req.GetSource(),
+		ErrorKind:   req.GetErrorKind(),
+		MissingOnly: req.GetMissingOnly(),
+		Since:       optionalTime(req.GetSince()),
+		Until:       optionalTime(req.GetUntil()),
+		PageSize:    int(req.GetPageSize()),
+		PageToken:   req.GetPageToken(),
+	}
+
+	timeoutCtx, cancel := h.WithTimeout(ctx, HandlerTypeQuery)
+	defer cancel()
+
+	page, err := h.logs.List(timeoutCtx, query)
+	if err != nil {
+		return nil, h.statusError(ctx, "list recommendation logs failed", err)
+	}
+	resp := &adminv1.ListRecommendationLogsResponse{
+		Logs:          make([]*adminv1.RecommendationLog, 0, len(page.Logs)),
+		NextPageToken: page.NextPageToken,
+	}
+	for _, entry := range page.Logs {
+		resp.Logs = append(resp.Logs, toProtoRecommendationLog(entry))
+	}
+	return resp, nil
+}
+
+// GetRecommendationLog 返回单条推荐日志。
+func (h *FeedAdminHandler) GetRecommendationLog(ctx context.Context, req *adminv1.GetRecommendationLogRequest) (*adminv1.RecommendationLog, error) {
+	if req == nil {
+		return nil, problemError(codes.InvalidArgument, reasonInvalidArgument, "request is nil", nil)
+	}
+
+	timeoutCtx, cancel := h.WithTimeout(ctx, HandlerTypeQuery)
+	defer cancel()
+
+	entry, err := h.logs.Get(timeoutCtx, req.GetLogId())
+	if err != nil {
+		return nil, h.statusError(ctx, "get recommendation log failed", err)
+	}
+	return toProtoRecommendationLog(entry), nil
+}
+
+// ListCurationRules 按优先级降序返回运营干预规则。
+func (h *FeedAdminHandler) ListCurationRules(ctx context.Context, req *adminv1.ListCurationRulesRequest) (*adminv1.ListCurationRulesResponse, error) {
+	if req == nil {
+		return nil, problemError(codes.InvalidArgument, reasonInvalidArgument, "request is nil", nil)
+	}
+
+	timeoutCtx, cancel := h.WithTimeout(ctx, HandlerTypeQuery)
+	defer cancel()
+
+	rules, err := h.curation.List(timeoutCtx, req.GetIncludeExpired())
+	if err != nil {
+		return nil, h.statusError(ctx, "list curation rules failed", err)
+	}
+	resp := &adminv1.ListCurationRulesResponse{Rules: make([]*adminv1.CurationRule, 0, len(rules))}
+	for _, rule := range rules {
+		resp.Rules = append(resp.Rules, toProtoCurationRule(rule))
+	}
+	return resp, nil
+}
+
+// CreateCurationRule 新增一条运营干预规则。
+func (h *FeedAdminHandler) CreateCurationRule(ctx context.Context, req *adminv1.CreateCurationRuleRequest) (*adminv1.CurationRule, error) {
+	if req == nil || req.GetRule() == nil {
+		return nil, problemError(codes.InvalidArgument, reasonInvalidArgument, "rule is required", nil)
+	}
+
+	timeoutCtx, cancel := h.WithTimeout(ctx, HandlerTypeCommand)
+	defer cancel()
+
+	rule, err := h.curation.Create(timeoutCtx, curationRuleInputFromProto(req.GetRule()))
+	if err != nil {
+		return nil, h.statusError(ctx, "create curation rule failed", err)
+	}
+	return toProtoCurationRule(rule), nil
+}
+
+// UpdateCurationRule 整体覆盖一条运营干预规则。
+func (h *FeedAdminHandler) UpdateCurationRule(ctx context.Context, req *adminv1.UpdateCurationRuleRequest) (*adminv1.CurationRule, error) {
+	if req == nil || req.GetRule() == nil {
+		return nil, problemError(codes.InvalidArgument, reasonInvalidArgument, "rule is required", nil)
+	}
+
+	timeoutCtx, cancel := h.WithTimeout(ctx, HandlerTypeCommand)
+	defer cancel()
+
+	rule, err := h.curation.Update(timeoutCtx, req.GetRuleId(), curationRuleInputFromProto(req.GetRule()))
+	if err != nil {
+		return nil, h.statusError(ctx, "update curation rule failed", err)
+	}
+	return toProtoCurationRule(rule), nil
+}
+
+// DeleteCurationRule 删除一条运营干预规则。
+func (h *FeedAdminHandler) DeleteCurationRule(ctx context.Context, req *adminv1.DeleteCurationRuleRequest) (*emptypb.Empty, error) {
+	if req == nil {
+		return nil, problemError(codes.InvalidArgument, reasonInvalidArgument, "request is nil", nil)
+	}
+
+	timeoutCtx, cancel := h.WithTimeout(ctx, HandlerTypeCommand)
+	defer cancel()
+
+	if err := h.curation.Delete(timeoutCtx, req.GetRuleId()); err != nil {
+		return nil, h.statusError(ctx, "delete curation rule failed", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (h *FeedAdminHandler) statusError(ctx context.Context, msg string, err error) error {
+	stErr := toStatusError(err)
+	if status.Code(stErr) == codes.Internal {
+		h.log.WithContext(ctx).Errorw("msg", msg, "error", err)
+	} else {
+		h.log.WithContext(ctx).Warnw("msg", msg, "error", err)
+	}
+	return stErr
+}
+
+func toProtoRecommendationLog(entry *po.FeedRecommendationLog) *adminv1.RecommendationLog {
+	if entry == nil {
+		return &adminv1.RecommendationLog{}
+	}
+	out := &adminv1.RecommendationLog{
+		LogId:                entry.LogID,
+		UserIdHash:           derefString(entry.UserIDHash),
+		UserIdKeyVersion:     derefString(entry.UserIDKeyVersion),
+		RequestLimit:         entry.RequestLimit,
+		RecommendationSource: entry.RecommendationSource,
+		MissingVideoIds:      entry.MissingVideoIDs,
+		ErrorKind:            derefString(entry.ErrorKind),
+		IdempotencyKey:       derefString(entry.IdempotencyKey),
+		Replayed:             entry.Replayed,
+		Guest:                entry.Guest,
+		RecommendedItems:     make([]*adminv1.RecommendedItem, 0, len(entry.RecommendedItems)),
+		ServedItems:          make([]*adminv1.ServedItem, 0, len(entry.ServedItems)),
+		RequestId:            derefString(entry.RequestID),
+		TraceId:              derefString(entry.TraceID),
+		Scene:                derefString(entry.Scene),
+	}
+	if entry.Page != nil {
+		out.Page = *entry.Page
+	}
+	if entry.RecommendationLatencyMS != nil {
+		out.RecommendationLatencyMs = *entry.RecommendationLatencyMS
+	}
+	if !entry.GeneratedAt.IsZero() {
+		out.GeneratedAt = timestamppb.New(entry.GeneratedAt.UTC())
+	}
+	for _, item := range entry.RecommendedItems {
+		out.RecommendedItems = append(out.RecommendedItems, &adminv1.RecommendedItem{
+			VideoId:       item.VideoID,
+			Reason:        item.Reason,
+			Score:         item.Score,
+			Meta:          item.Meta,
+			MissingReason: item.MissingReason,
+		})
+	}
+	for _, item := range entry.ServedItems {
+		out.ServedItems = append(out.ServedItems, &adminv1.ServedItem{
+			VideoId:           item.VideoID,
+			Position:          item.Position,
+			ProjectionVersion: item.ProjectionVersion,
+			VisibilityStatus:  item.VisibilityStatus,
+		})
+	}
+	for _, exp := range entry.Experiments {
+		out.Experiments = append(out.Experiments, &adminv1.ExperimentAssignment{
+			Experiment: exp.Experiment,
+			Variant:    exp.Variant,
+			Forced:     exp.Forced,
+		})
+	}
+	return out
+}
+
+func curationRuleInputFromProto(rule *adminv1.CurationRule) services.CurationRuleInput {
+	return services.CurationRuleInput{
+		Action:      rule.GetAction(),
+		VideoID:     rule.GetVideoId(),
+		Scene:       rule.GetScene(),
+		Locale:      rule.GetLocale(),
+		UserSegment: rule.GetUserSegment(),
+		Position:    rule.GetPosition(),
+		Priority:    rule.GetPriority(),
+		StartsAt:    optionalTime(rule.GetStartsAt()),
+		EndsAt:      optionalTime(rule.GetEndsAt()),
+		Note:        rule.GetNote(),
+	}
+}
+
+func toProtoCurationRule(rule *po.CurationRule) *adminv1.CurationRule {
+	if rule == nil {
+		return &adminv1.CurationRule{}
+	}
+	out := &adminv1.CurationRule{
+		RuleId:      rule.RuleID,
+		Action:      rule.Action,
+		VideoId:     rule.VideoID,
+		Scene:       derefString(rule.Scene),
+		Locale:      derefString(rule.Locale),
+		UserSegment: derefString(rule.UserSegment),
+		Priority:    rule.Priority,
+		Note:        derefString(rule.Note),
+		StartsAt:    optionalTimestamp(rule.StartsAt),
+		EndsAt:      optionalTimestamp(rule.EndsAt),
+	}
+	if rule.Position != nil {
+		out.Position = *rule.Position
+	}
+	if !rule.CreatedAt.IsZero() {
+		out.CreatedAt = timestamppb.New(rule.CreatedAt.UTC())
+	}
+	if !rule.UpdatedAt.IsZero() {
+		out.UpdatedAt = timestamppb.New(rule.UpdatedAt.UTC())
+	}
+	return out
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(t.UTC())
+}
+
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime().UTC()
+	return &t
+}
+
+func derefString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
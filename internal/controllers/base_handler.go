@@ -6,6 +6,7 @@ import (
 	"time"
 
 	metadata "github.com/bionicotaku/lingo-services-feed/internal/metadata"
+	"github.com/go-kratos/kratos/v2/transport"
 	grpcmetadata "google.golang.org/grpc/metadata"
 )
 
@@ -35,6 +36,11 @@ const (
 	headerIdempotencyKey   = "x-md-idempotency-key"
 	headerIfMatch          = "x-md-if-match"
 	headerIfNoneMatch      = "x-md-if-none-match"
+	headerDeviceID         = "x-md-device-id"
+	headerRequestID        = "x-md-request-id"
+	headerLocale           = "x-md-locale"
+	headerUserSegment      = "x-md-user-segment"
+	headerExperiments      = "x-md-experiment-variants"
 )
 
 // BaseHandler 提供公共的超时、Metadata 解析能力，供具体 Handler 内嵌复用。
@@ -87,17 +93,23 @@ func (h *BaseHandler) WithTimeout(ctx context.Context, kind HandlerType) (contex
 }
 
 // ExtractMetadata 解析请求中常见的幂等与条件请求 Header。
+// gRPC 请求读取 incoming metadata，HTTP 请求回退到 Kratos transport 的请求头。
 func (h *BaseHandler) ExtractMetadata(ctx context.Context) metadata.HandlerMetadata {
-	md, ok := grpcmetadata.FromIncomingContext(ctx)
+	lookup, ok := headerLookup(ctx)
 	if !ok {
 		return metadata.HandlerMetadata{}
 	}
 	meta := metadata.HandlerMetadata{
-		IdempotencyKey: firstMetadata(md, headerIdempotencyKey),
-		IfMatch:        firstMetadata(md, headerIfMatch),
-		IfNoneMatch:    firstMetadata(md, headerIfNoneMatch),
+		IdempotencyKey:     lookup(headerIdempotencyKey),
+		IfMatch:            lookup(headerIfMatch),
+		IfNoneMatch:        lookup(headerIfNoneMatch),
+		DeviceID:           lookup(headerDeviceID),
+		RequestID:          lookup(headerRequestID),
+		Locale:             lookup(headerLocale),
+		UserSegment:        lookup(headerUserSegment),
+		ExperimentVariants: lookup(headerExperiments),
 	}
-	rawUserInfo := firstMetadata(md, headerUserInfo)
+	rawUserInfo := lookup(headerUserInfo)
 	meta.RawUserInfo = rawUserInfo
 	if rawUserInfo != "" {
 		if userID, err := metadata.ExtractUserIDFromUserInfo(rawUserInfo); err == nil {
@@ -125,6 +137,18 @@ func HandlerMetadataFromContext(ctx context.Context) (metadata.HandlerMetadata,
 	return metadata.FromContext(ctx)
 }
 
+// headerLookup 返回按 key 读取首个 Header 值的函数，不存在可用来源时返回 false。
+func headerLookup(ctx context.Context) (func(string) string, bool) {
+	if md, ok := grpcmetadata.FromIncomingContext(ctx); ok {
+		return func(key string) string { return firstMetadata(md, key) }, true
+	}
+	if tr, ok := transport.FromServerContext(ctx); ok {
+		header := tr.RequestHeader()
+		return func(key string) string { return strings.TrimSpace(header.Get(key)) }, true
+	}
+	return nil, false
+}
+
 func firstMetadata(md grpcmetadata.MD, key string) string {
 	if len(md) == 0 {
 		return ""
@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/services"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// errorDomain 写入 ErrorInfo.Domain，供 Gateway 区分错误来源服务。
+const errorDomain = "feed.lingo"
+
+// 控制器层自身产生的 Problem type，与 services.FeedError.Reason 共用 feed.errors. 前缀。
+const (
+	reasonInvalidArgument  = "feed.errors.invalid_argument"
+	reasonUnauthenticated  = "feed.errors.unauthenticated"
+	reasonPermissionDenied = "feed.errors.permission_denied"
+	reasonDeadlineExceeded = "feed.errors.deadline_exceeded"
+	reasonCanceled         = "feed.errors.canceled"
+	reasonInternal         = "feed.errors.internal"
+)
+
+// problemError 构造携带 ErrorInfo 的 gRPC 错误，Gateway 据 reason 渲染 RFC 7807 JSON。
+func problemError(code codes.Code, reason, message string, retry *errdetails.RetryInfo) error {
+	st := status.New(code, message)
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}}
+	if retry != nil {
+		details = append(details, retry)
+	}
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// toStatusError 将用例层错误映射为 gRPC status，未知错误统一收敛为 Internal 且不暴露原因。
+func toStatusError(err error) error {
+	var feedErr *services.FeedError
+	switch {
+	case errors.As(err, &feedErr):
+		var retry *errdetails.RetryInfo
+		if feedErr.Retryable() {
+			retry = &errdetails.RetryInfo{RetryDelay: durationpb.New(feedErr.RetryAfter)}
+		}
+		return problemError(codeForKind(feedErr.Kind), feedErr.Reason(), feedErr.Message, retry)
+	case errors.Is(err, context.DeadlineExceeded):
+		return problemError(codes.DeadlineExceeded, reasonDeadlineExceeded, "deadline exceeded", nil)
+	case errors.Is(err, context.Canceled):
+		return problemError(codes.Canceled, reasonCanceled, "request canceled", nil)
+	default:
+		return problemError(codes.Internal, reasonInternal, "internal error", nil)
+	}
+}
+
+func codeForKind(kind services.ErrorKind) codes.Code {
+	switch kind {
+	case services.ErrorKindRecommendationUnavailable, services.ErrorKindProjectionUnavailable, services.ErrorKindEventStoreUnavailable:
+		return codes.Unavailable
+	case services.ErrorKindIdempotencyConflict:
+		return codes.FailedPrecondition
+	case services.ErrorKindRateLimited:
+		return codes.ResourceExhausted
+	case services.ErrorKindInvalidArgument:
+		return codes.InvalidArgument
+	case services.ErrorKindNotFound:
+		return codes.NotFound
+	case services.ErrorKindPseudonymizationDisabled, services.ErrorKindFeatureDisabled:
+		return codes.FailedPrecondition
+	default:
+		return codes.Internal
+	}
+}
@@ -2,9 +2,11 @@ package controllers
 
 import (
 	"context"
-	"errors"
+	"strconv"
+	"strings"
 
 	feedv1 "github.com/bionicotaku/lingo-services-feed/api/feed/v1"
+	"github.com/bionicotaku/lingo-services-feed/internal/metadata"
 	"github.com/bionicotaku/lingo-services-feed/internal/models/vo"
 	"github.com/bionicotaku/lingo-services-feed/internal/services"
 
@@ -14,9 +16,23 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// maxIdempotencyKeyLength 限制 x-md-idempotency-key 的长度，避免异常大键写入快照表。
+const maxIdempotencyKeyLength = 128
+
+// pageCursorPrefix 标记游标外层附加的下一页页码：n<页码>.<推荐链游标>。推荐链签发的游标均不以该形式开头
+// （base64url 不含 "."，兜底链路前缀为 p./f.）。
+const pageCursorPrefix = "n"
+
 // FeedServiceAPI 定义 FeedHandler 依赖的 Service 能力。
 type FeedServiceAPI interface {
 	GetFeed(ctx context.Context, input services.GetFeedInput) (*vo.FeedResponse, error)
+	ReportInteractions(ctx context.Context, input services.ReportInteractionsInput) (*services.ReportInteractionsResult, error)
+}
+
+// GuestPolicy 控制匿名访客请求的准入策略。
+type GuestPolicy struct {
+	// Enabled 为 true 时，未携带 userinfo 的请求凭 x-md-device-id 以访客身份访问。
+	Enabled bool
 }
 
 // FeedHandler 实现 FeedService gRPC 接口。
@@ -25,51 +41,220 @@ type FeedHandler struct {
 
 	*BaseHandler
 	service FeedServiceAPI
+	guest   GuestPolicy
 	log     *log.Helper
 }
 
 // NewFeedHandler 构造 FeedHandler。
-func NewFeedHandler(feed FeedServiceAPI, base *BaseHandler, logger log.Logger) *FeedHandler {
+func NewFeedHandler(feed FeedServiceAPI, base *BaseHandler, guest GuestPolicy, logger log.Logger) *FeedHandler {
 	if base == nil {
 		base = NewBaseHandler(HandlerTimeouts{})
 	}
 	return &FeedHandler{
 		BaseHandler: base,
 		service:     feed,
+		guest:       guest,
 		log:         log.NewHelper(logger),
 	}
 }
 
-// GetFeed 返回推荐结果。当前尚未实现完整逻辑，后续步骤将替换占位实现。
+// GetFeed 返回补水后的推荐卡片。
+//
+// 幂等键超长直接返回 InvalidArgument。调用方身份取自 userinfo；访客模式下完全缺少 userinfo 的请求凭设备号
+// 以访客身份访问（访客不使用幂等键），其余无法识别身份的请求返回 Unauthenticated。
+// 游标外层的页码在此解出并为下一页重新附加。Service 返回的 FeedError 按类别映射为 gRPC 状态：
+// 推荐源、投影不可用为 Unavailable，限流为 ResourceExhausted（附 RetryInfo），幂等键冲突为 FailedPrecondition，
+// 非法游标为 InvalidArgument；超时与取消分别为 DeadlineExceeded 与 Canceled，其余错误为 Internal 且不暴露原因。
 func (h *FeedHandler) GetFeed(ctx context.Context, req *feedv1.GetFeedRequest) (*feedv1.GetFeedResponse, error) {
 	if req == nil {
-		return nil, status.Error(codes.InvalidArgument, "request is nil")
+		return nil, problemError(codes.InvalidArgument, reasonInvalidArgument, "request is nil", nil)
 	}
 
 	meta := h.ExtractMetadata(ctx)
-	if meta.InvalidUserInfo || meta.UserID == "" {
-		return nil, status.Error(codes.Unauthenticated, "invalid user info")
+	if len(meta.IdempotencyKey) > maxIdempotencyKeyLength {
+		return nil, problemError(codes.InvalidArgument, reasonInvalidArgument, "idempotency key too long", nil)
 	}
 
-	userID := meta.UserID
-
+	page, cursor := decodePageCursor(strings.TrimSpace(req.GetCursor()))
 	input := services.GetFeedInput{
-		UserID: userID,
-		Limit:  int(req.GetLimit()),
+		Limit:     int(req.GetLimit()),
+		Cursor:    cursor,
+		Page:      page,
+		Scene:     req.GetScene(),
+		RequestID: meta.RequestID,
+		Locale:    meta.Locale,
+		Segment:   meta.UserSegment,
+	}
+	if forced := parseExperimentVariants(meta.ExperimentVariants); len(forced) > 0 {
+		input.ForcedVariants = forced
+	}
+	userID, guestID, err := h.resolveCaller(meta)
+	if err != nil {
+		return nil, err
+	}
+	if guestID != "" {
+		input.Guest = true
+		input.GuestID = guestID
+	} else {
+		input.UserID = userID
+		input.IdempotencyKey = meta.IdempotencyKey
 	}
 
 	timeoutCtx, cancel := h.WithTimeout(ctx, HandlerTypeQuery)
 	defer cancel()
 
 	res, err := h.service.GetFeed(timeoutCtx, input)
+	if err != nil {
+		stErr := toStatusError(err)
+		if status.Code(stErr) == codes.Internal {
+			h.log.WithContext(ctx).Errorw("msg", "get feed failed", "error", err)
+		} else {
+			h.log.WithContext(ctx).Warnw("msg", "get feed failed", "error", err)
+		}
+		return nil, stErr
+	}
+	resp := toProtoFeedResponse(res)
+	resp.NextCursor = encodePageCursor(page, resp.GetNextCursor())
+	return resp, nil
+}
+
+// ReportInteractions 接收客户端批量上报的曝光/点击/刷新事件，逐条返回处理结果。
+func (h *FeedHandler) ReportInteractions(ctx context.Context, req *feedv1.ReportInteractionsRequest) (*feedv1.ReportInteractionsResponse, error) {
+	if req == nil {
+		return nil, problemError(codes.InvalidArgument, reasonInvalidArgument, "request is nil", nil)
+	}
+	userID, guestID, err := h.resolveCaller(h.ExtractMetadata(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	input := services.ReportInteractionsInput{
+		UserID: userID,
+		Guest:  guestID != "",
+		Events: make([]services.InteractionInput, 0, len(req.GetEvents())),
+	}
+	for _, evt := range req.GetEvents() {
+		item := services.InteractionInput{
+			EventID:  evt.GetEventId(),
+			Type:     interactionTypeFromProto(evt.GetType()),
+			LogID:    evt.GetLogId(),
+			VideoID:  evt.GetVideoId(),
+			Position: int(evt.GetPosition()),
+		}
+		if evt.GetOccurredAt() != nil {
+			item.OccurredAt = evt.GetOccurredAt().AsTime()
+		}
+		input.Events = append(input.Events, item)
+	}
+
+	timeoutCtx, cancel := h.WithTimeout(ctx, HandlerTypeCommand)
+	defer cancel()
+
+	res, err := h.service.ReportInteractions(timeoutCtx, input)
+	if err != nil {
+		stErr := toStatusError(err)
+		if status.Code(stErr) == codes.Internal {
+			h.log.WithContext(ctx).Errorw("msg", "report interactions failed", "error", err)
+		} else {
+			h.log.WithContext(ctx).Warnw("msg", "report interactions failed", "error", err)
+		}
+		return nil, stErr
+	}
+	resp := &feedv1.ReportInteractionsResponse{
+		Results:  make([]*feedv1.InteractionResult, 0, len(res.Results)),
+		Accepted: int32(res.Accepted),
+	}
+	for _, result := range res.Results {
+		resp.Results = append(resp.Results, &feedv1.InteractionResult{
+			EventId: result.EventID,
+			Status:  interactionStatusToProto(result.Status),
+			Reason:  result.Reason,
+		})
+	}
+	return resp, nil
+}
+
+// resolveCaller 解析调用方身份：有效 userinfo 返回 userID；访客模式下返回设备派生的 guestID。
+func (h *FeedHandler) resolveCaller(meta metadata.HandlerMetadata) (string, string, error) {
 	switch {
-	case err == nil:
-		return toProtoFeedResponse(res), nil
-	case errors.Is(err, services.ErrRecommendationUnavailable):
-		return nil, status.Error(codes.Unavailable, err.Error())
+	case !meta.InvalidUserInfo && meta.UserID != "":
+		return meta.UserID, "", nil
+	case h.guest.Enabled && meta.RawUserInfo == "":
+		// 仅在完全缺少 userinfo 时降级为访客；携带但无法解析的 userinfo 仍视为鉴权失败。
+		guestID := metadata.GuestIDFromDevice(meta.DeviceID)
+		if guestID == "" {
+			return "", "", problemError(codes.Unauthenticated, reasonUnauthenticated, "device id required for guest access", nil)
+		}
+		return "", guestID, nil
+	default:
+		return "", "", problemError(codes.Unauthenticated, reasonUnauthenticated, "invalid user info", nil)
+	}
+}
+
+// decodePageCursor 拆出游标携带的页码与推荐链游标：空游标为第一页；
+// 未带页码的游标（页码上线前签发）原样交给推荐链，页码记为 0 表示未知。
+func decodePageCursor(cursor string) (int, string) {
+	if cursor == "" {
+		return 1, ""
+	}
+	if rest, ok := strings.CutPrefix(cursor, pageCursorPrefix); ok {
+		if digits, inner, ok := strings.Cut(rest, "."); ok && inner != "" {
+			if page, err := strconv.Atoi(digits); err == nil && page > 1 {
+				return page, inner
+			}
+		}
+	}
+	return 0, cursor
+}
+
+// encodePageCursor 在推荐链的下一页游标外层附加下一页页码；当前页码未知或没有下一页时原样返回。
+func encodePageCursor(page int, next string) string {
+	if page <= 0 || next == "" {
+		return next
+	}
+	return pageCursorPrefix + strconv.Itoa(page+1) + "." + next
+}
+
+// parseExperimentVariants 解析 experiment=variant 形式、逗号分隔的强制分组，忽略格式不合法的片段。
+func parseExperimentVariants(raw string) map[string]string {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	out := make(map[string]string)
+	for _, part := range strings.Split(raw, ",") {
+		key, value, ok := strings.Cut(part, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" || value == "" {
+			continue
+		}
+		out[key] = value
+	}
+	return out
+}
+
+func interactionTypeFromProto(t feedv1.InteractionType) services.InteractionType {
+	switch t {
+	case feedv1.InteractionType_INTERACTION_TYPE_IMPRESSION:
+		return services.InteractionImpression
+	case feedv1.InteractionType_INTERACTION_TYPE_CLICK:
+		return services.InteractionClick
+	case feedv1.InteractionType_INTERACTION_TYPE_REFRESH:
+		return services.InteractionRefresh
 	default:
-		h.log.WithContext(ctx).Errorw("msg", "get feed failed", "error", err)
-		return nil, status.Errorf(codes.Internal, "get feed: %v", err)
+		return ""
+	}
+}
+
+func interactionStatusToProto(s services.InteractionStatus) feedv1.InteractionStatus {
+	switch s {
+	case services.InteractionAccepted:
+		return feedv1.InteractionStatus_INTERACTION_STATUS_ACCEPTED
+	case services.InteractionDuplicate:
+		return feedv1.InteractionStatus_INTERACTION_STATUS_DUPLICATE
+	case services.InteractionRejected:
+		return feedv1.InteractionStatus_INTERACTION_STATUS_REJECTED
+	default:
+		return feedv1.InteractionStatus_INTERACTION_STATUS_UNSPECIFIED
 	}
 }
 
@@ -80,6 +265,7 @@ func toProtoFeedResponse(res *vo.FeedResponse) *feedv1.GetFeedResponse {
 	resp := &feedv1.GetFeedResponse{
 		NextCursor: res.NextCursor,
 		Partial:    res.Partial,
+		LogId:      res.LogID,
 	}
 	if !res.GeneratedAt.IsZero() {
 		resp.GeneratedAt = timestamppb.New(res.GeneratedAt.UTC())
@@ -120,5 +306,16 @@ func toProtoFeedItem(item vo.FeedItem) *feedv1.FeedItem {
 	if item.PublishedAt != nil && !item.PublishedAt.IsZero() {
 		feedItem.PublishedAt = timestamppb.New(item.PublishedAt.UTC())
 	}
+	if state := item.UserState; state != nil {
+		feedItem.UserState = &feedv1.UserVideoState{
+			Liked:                state.Liked,
+			Bookmarked:           state.Bookmarked,
+			WatchedRatio:         state.WatchedRatio,
+			ResumePositionMicros: state.ResumePositionMicros,
+		}
+		if state.LastWatchedAt != nil && !state.LastWatchedAt.IsZero() {
+			feedItem.UserState.LastWatchedAt = timestamppb.New(state.LastWatchedAt.UTC())
+		}
+	}
 	return feedItem
 }
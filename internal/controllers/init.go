@@ -12,9 +12,22 @@ import (
 // ProvideFeedServiceAPI adapts FeedService into FeedServiceAPI for dependency injection.
 func ProvideFeedServiceAPI(s *services.FeedService) FeedServiceAPI { return s }
 
+// ProvideRecommendationLogAdminAPI adapts RecommendationLogLookup into RecommendationLogAdminAPI.
+func ProvideRecommendationLogAdminAPI(l *services.RecommendationLogLookup) RecommendationLogAdminAPI {
+	return l
+}
+
+// ProvideCurationRuleAdminAPI adapts CurationRuleAdmin into CurationRuleAdminAPI.
+func ProvideCurationRuleAdminAPI(a *services.CurationRuleAdmin) CurationRuleAdminAPI { return a }
+
 // ProviderSet collects controller constructors for Wire DI.
 var ProviderSet = wire.NewSet(
 	NewBaseHandler,
 	ProvideFeedServiceAPI,
 	NewFeedHandler,
+	NewRateLimitMiddleware,
+	ProvideRecommendationLogAdminAPI,
+	ProvideCurationRuleAdminAPI,
+	NewFeedAdminHandler,
+	NewAdminAuthMiddleware,
 )
@@ -0,0 +1,228 @@
+package controllers_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	adminv1 "github.com/bionicotaku/lingo-services-feed/api/feed/admin/v1"
+	controllers "github.com/bionicotaku/lingo-services-feed/internal/controllers"
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/services"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type fakeTransport struct {
+	operation string
+}
+
+func (t fakeTransport) Kind() transport.Kind            { return transport.KindGRPC }
+func (t fakeTransport) Endpoint() string                { return "" }
+func (t fakeTransport) Operation() string               { return t.operation }
+func (t fakeTransport) RequestHeader() transport.Header { return nil }
+func (t fakeTransport) ReplyHeader() transport.Header   { return nil }
+
+type stubLogAdmin struct {
+	page  *services.RecommendationLogPage
+	entry *po.FeedRecommendationLog
+	err   error
+	query services.RecommendationLogQuery
+}
+
+func (s *stubLogAdmin) List(_ context.Context, query services.RecommendationLogQuery) (*services.RecommendationLogPage, error) {
+	s.query = query
+	return s.page, s.err
+}
+
+func (s *stubLogAdmin) Get(_ context.Context, _ string) (*po.FeedRecommendationLog, error) {
+	return s.entry, s.err
+}
+
+func adminContext(t *testing.T, operation string, pairs ...string) context.Context {
+	t.Helper()
+	ctx := transport.NewServerContext(context.Background(), fakeTransport{operation: operation})
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(pairs...))
+}
+
+// fakeAdminVerifier 模拟签名校验：仅接受固定的 Bearer Token。
+func fakeAdminVerifier(handler middleware.Handler) middleware.Handler {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get("authorization"); len(values) == 0 || values[0] != "Bearer admin-token" {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		return handler(ctx, req)
+	}
+}
+
+func TestAdminAuthMiddleware(t *testing.T) {
+	policy := controllers.AdminAuthPolicy{Enabled: true, Audience: "feed-admin", HeaderKey: "authorization"}
+	mw := controllers.NewAdminAuthMiddleware(policy, fakeAdminVerifier)
+	require.NotNil(t, mw)
+	next := func(context.Context, interface{}) (interface{}, error) { return "ok", nil }
+	handler := mw(next)
+	adminOp := "/feed.admin.v1.FeedAdminService/ListRecommendationLogs"
+	forgedUserInfo := encodeUserInfo(t, map[string]any{"sub": "ops", "aud": "feed-admin", "roles": []any{"feed.admin"}})
+
+	cases := []struct {
+		name string
+		ctx  context.Context
+		code codes.Code
+	}{
+		{"public operation bypasses", adminContext(t, "/feed.v1.FeedService/GetFeed"), codes.OK},
+		{"signed token allowed", adminContext(t, adminOp, "authorization", "Bearer admin-token"), codes.OK},
+		{"missing token", adminContext(t, adminOp), codes.Unauthenticated},
+		{"unsigned userinfo denied", adminContext(t, adminOp, "x-apigateway-api-userinfo", forgedUserInfo), codes.Unauthenticated},
+		{"invalid token", adminContext(t, adminOp, "authorization", "Bearer forged"), codes.Unauthenticated},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := handler(tc.ctx, nil)
+			require.Equal(t, tc.code, status.Code(err))
+		})
+	}
+
+	// 缺少校验器时管理端请求一律拒绝，公共请求不受影响。
+	failClosed := controllers.NewAdminAuthMiddleware(policy, nil)(next)
+	_, err := failClosed(adminContext(t, adminOp, "authorization", "Bearer admin-token"), nil)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = failClosed(adminContext(t, "/feed.v1.FeedService/GetFeed"), nil)
+	require.NoError(t, err)
+
+	require.Nil(t, controllers.NewAdminAuthMiddleware(controllers.AdminAuthPolicy{}, fakeAdminVerifier))
+}
+
+func TestFeedAdminHandler_ListRecommendationLogs(t *testing.T) {
+	hash := "hash-1"
+	latency := int32(12)
+	generated := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	stub := &stubLogAdmin{page: &services.RecommendationLogPage{
+		Logs: []*po.FeedRecommendationLog{{
+			LogID:                   "4b1f8f5e-52c5-4f4e-9f37-0f5a1d4b8c11",
+			UserIDHash:              &hash,
+			RequestLimit:            5,
+			RecommendationSource:    "mock",
+			RecommendationLatencyMS: &latency,
+			RecommendedItems:        []po.RecommendedItemLog{{VideoID: "v1", Reason: "mock.random", Score: 0.5, MissingReason: po.MissingReasonProjection}},
+			MissingVideoIDs:         []string{"v1"},
+			GeneratedAt:             generated,
+		}},
+		NextPageToken: "next",
+	}}
+	handler := controllers.NewFeedAdminHandler(stub, nil, controllers.NewBaseHandler(controllers.HandlerTimeouts{}), log.NewStdLogger(io.Discard))
+
+	resp, err := handler.ListRecommendationLogs(context.Background(), &adminv1.ListRecommendationLogsRequest{
+		PageSize:    10,
+		PageToken:   "prev",
+		UserId:      "user-1",
+		Source:      "mock",
+		MissingOnly: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "next", resp.GetNextPageToken())
+	require.Len(t, resp.GetLogs(), 1)
+	got := resp.GetLogs()[0]
+	require.Equal(t, hash, got.GetUserIdHash())
+	require.Equal(t, int32(12), got.GetRecommendationLatencyMs())
+	require.Equal(t, generated, got.GetGeneratedAt().AsTime())
+	require.Equal(t, "v1", got.GetRecommendedItems()[0].GetVideoId())
+	require.Equal(t, po.MissingReasonProjection, got.GetRecommendedItems()[0].GetMissingReason())
+
+	require.Equal(t, "user-1", stub.query.UserID)
+	require.Equal(t, "prev", stub.query.PageToken)
+	require.Equal(t, 10, stub.query.PageSize)
+	require.True(t, stub.query.MissingOnly)
+	require.Nil(t, stub.query.Since)
+}
+
+func TestFeedAdminHandler_ErrorMapping(t *testing.T) {
+	stub := &stubLogAdmin{err: services.ErrRecommendationLogNotFound}
+	handler := controllers.NewFeedAdminHandler(stub, nil, nil, log.NewStdLogger(io.Discard))
+
+	_, err := handler.GetRecommendationLog(context.Background(), &adminv1.GetRecommendationLogRequest{LogId: "4b1f8f5e-52c5-4f4e-9f37-0f5a1d4b8c11"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	stub.err = services.ErrInvalidPageToken
+	_, err = handler.ListRecommendationLogs(context.Background(), &adminv1.ListRecommendationLogsRequest{PageToken: "garbage"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+type stubCurationAdmin struct {
+	rule   *po.CurationRule
+	err    error
+	input  services.CurationRuleInput
+	ruleID string
+}
+
+func (s *stubCurationAdmin) List(context.Context, bool) ([]*po.CurationRule, error) {
+	return []*po.CurationRule{s.rule}, s.err
+}
+
+func (s *stubCurationAdmin) Create(_ context.Context, input services.CurationRuleInput) (*po.CurationRule, error) {
+	s.input = input
+	return s.rule, s.err
+}
+
+func (s *stubCurationAdmin) Update(_ context.Context, ruleID string, input services.CurationRuleInput) (*po.CurationRule, error) {
+	s.ruleID, s.input = ruleID, input
+	return s.rule, s.err
+}
+
+func (s *stubCurationAdmin) Delete(_ context.Context, ruleID string) error {
+	s.ruleID = ruleID
+	return s.err
+}
+
+func TestFeedAdminHandler_CurationRules(t *testing.T) {
+	position := int32(1)
+	endsAt := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	scene := "home"
+	stub := &stubCurationAdmin{rule: &po.CurationRule{
+		RuleID:   "4b1f8f5e-52c5-4f4e-9f37-0f5a1d4b8c11",
+		Action:   "pin",
+		VideoID:  "9d7f3c1e-0f4b-4c55-8d1d-2f6b7f0e9a01",
+		Scene:    &scene,
+		Position: &position,
+		EndsAt:   &endsAt,
+	}}
+	handler := controllers.NewFeedAdminHandler(nil, stub, nil, log.NewStdLogger(io.Discard))
+
+	got, err := handler.CreateCurationRule(context.Background(), &adminv1.CreateCurationRuleRequest{Rule: &adminv1.CurationRule{
+		Action:   "pin",
+		VideoId:  "9d7f3c1e-0f4b-4c55-8d1d-2f6b7f0e9a01",
+		Scene:    "home",
+		Locale:   "ja",
+		Position: 1,
+		EndsAt:   timestamppb.New(endsAt),
+	}})
+	require.NoError(t, err)
+	require.Equal(t, "pin", stub.input.Action)
+	require.Equal(t, "ja", stub.input.Locale)
+	require.Nil(t, stub.input.StartsAt)
+	require.Equal(t, endsAt, *stub.input.EndsAt)
+	require.Equal(t, "home", got.GetScene())
+	require.Empty(t, got.GetLocale())
+	require.Equal(t, int32(1), got.GetPosition())
+	require.Nil(t, got.GetStartsAt())
+
+	list, err := handler.ListCurationRules(context.Background(), &adminv1.ListCurationRulesRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.GetRules(), 1)
+
+	stub.err = services.ErrCurationRuleNotFound
+	_, err = handler.DeleteCurationRule(context.Background(), &adminv1.DeleteCurationRuleRequest{RuleId: "4b1f8f5e-52c5-4f4e-9f37-0f5a1d4b8c11"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	stub.err = services.ErrInvalidCurationRule
+	_, err = handler.UpdateCurationRule(context.Background(), &adminv1.UpdateCurationRuleRequest{RuleId: "4b1f8f5e-52c5-4f4e-9f37-0f5a1d4b8c11", Rule: &adminv1.CurationRule{}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
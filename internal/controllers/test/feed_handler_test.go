@@ -4,7 +4,10 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,15 +18,20 @@ import (
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type stubFeedService struct {
 	response *vo.FeedResponse
 	err      error
 	input    services.GetFeedInput
+
+	interactions      *services.ReportInteractionsResult
+	interactionsInput services.ReportInteractionsInput
 }
 
 func (s *stubFeedService) GetFeed(_ context.Context, input services.GetFeedInput) (*vo.FeedResponse, error) {
@@ -31,6 +39,11 @@ func (s *stubFeedService) GetFeed(_ context.Context, input services.GetFeedInput
 	return s.response, s.err
 }
 
+func (s *stubFeedService) ReportInteractions(_ context.Context, input services.ReportInteractionsInput) (*services.ReportInteractionsResult, error) {
+	s.interactionsInput = input
+	return s.interactions, s.err
+}
+
 func TestFeedHandler_GetFeed_Success(t *testing.T) {
 	service := &stubFeedService{
 		response: &vo.FeedResponse{
@@ -40,20 +53,83 @@ func TestFeedHandler_GetFeed_Success(t *testing.T) {
 			GeneratedAt: time.Now(),
 		},
 	}
-	handler := controllers.NewFeedHandler(service, controllers.NewBaseHandler(controllers.HandlerTimeouts{}), log.NewStdLogger(io.Discard))
+	handler := controllers.NewFeedHandler(service, controllers.NewBaseHandler(controllers.HandlerTimeouts{}), controllers.GuestPolicy{}, log.NewStdLogger(io.Discard))
 
-	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-apigateway-api-userinfo", encodeUserInfo(t, map[string]any{"sub": "user-1"})))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-apigateway-api-userinfo", encodeUserInfo(t, map[string]any{"sub": "user-1"}),
+		"x-md-request-id", "req-1",
+	))
 	resp, err := handler.GetFeed(ctx, &feedv1.GetFeedRequest{Limit: 5})
 	require.NoError(t, err)
 	require.Len(t, resp.GetItems(), 1)
 	require.Equal(t, "v1", resp.GetItems()[0].GetVideoId())
 	require.Equal(t, "user-1", service.input.UserID)
 	require.Equal(t, 5, service.input.Limit)
+	require.Equal(t, "req-1", service.input.RequestID)
+}
+
+func TestFeedHandler_GetFeed_UserState(t *testing.T) {
+	watchedAt := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	service := &stubFeedService{
+		response: &vo.FeedResponse{
+			Items: []vo.FeedItem{
+				{VideoID: "v1", UserState: &vo.UserVideoState{Liked: true, WatchedRatio: 0.5, LastWatchedAt: &watchedAt, ResumePositionMicros: 42_000_000}},
+				{VideoID: "v2"},
+			},
+			GeneratedAt: time.Now(),
+		},
+	}
+	handler := controllers.NewFeedHandler(service, controllers.NewBaseHandler(controllers.HandlerTimeouts{}), controllers.GuestPolicy{}, log.NewStdLogger(io.Discard))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-apigateway-api-userinfo", encodeUserInfo(t, map[string]any{"sub": "user-1"}),
+	))
+	resp, err := handler.GetFeed(ctx, &feedv1.GetFeedRequest{Limit: 2, Scene: "continue_learning", Cursor: "next-page"})
+	require.NoError(t, err)
+	require.Equal(t, "continue_learning", service.input.Scene)
+	require.Equal(t, "next-page", service.input.Cursor)
+	state := resp.GetItems()[0].GetUserState()
+	require.NotNil(t, state)
+	require.True(t, state.GetLiked())
+	require.False(t, state.GetBookmarked())
+	require.Equal(t, 0.5, state.GetWatchedRatio())
+	require.Equal(t, watchedAt, state.GetLastWatchedAt().AsTime())
+	require.Equal(t, int64(42_000_000), state.GetResumePositionMicros())
+	require.Nil(t, resp.GetItems()[1].GetUserState())
+}
+
+func TestFeedHandler_GetFeed_PageCarriedInCursor(t *testing.T) {
+	service := &stubFeedService{response: &vo.FeedResponse{NextCursor: "provider-1", GeneratedAt: time.Now()}}
+	handler := controllers.NewFeedHandler(service, controllers.NewBaseHandler(controllers.HandlerTimeouts{}), controllers.GuestPolicy{}, log.NewStdLogger(io.Discard))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-apigateway-api-userinfo", encodeUserInfo(t, map[string]any{"sub": "user-1"}),
+	))
+
+	resp, err := handler.GetFeed(ctx, &feedv1.GetFeedRequest{Limit: 5})
+	require.NoError(t, err)
+	require.Equal(t, 1, service.input.Page)
+	require.Equal(t, "n2.provider-1", resp.GetNextCursor())
+
+	// 第二页：推荐链只看到自己签发的游标，页码 2 写入推荐日志与 feed.served 事件。
+	service.response = &vo.FeedResponse{NextCursor: "provider-2", GeneratedAt: time.Now()}
+	resp, err = handler.GetFeed(ctx, &feedv1.GetFeedRequest{Limit: 5, Cursor: resp.GetNextCursor()})
+	require.NoError(t, err)
+	require.Equal(t, 2, service.input.Page)
+	require.Equal(t, "provider-1", service.input.Cursor)
+	require.Equal(t, "n3.provider-2", resp.GetNextCursor())
+
+	// 页码上线前签发的游标原样透传，页码未知。
+	service.response = &vo.FeedResponse{NextCursor: "provider-3", GeneratedAt: time.Now()}
+	resp, err = handler.GetFeed(ctx, &feedv1.GetFeedRequest{Limit: 5, Cursor: "p.legacy"})
+	require.NoError(t, err)
+	require.Zero(t, service.input.Page)
+	require.Equal(t, "p.legacy", service.input.Cursor)
+	require.Equal(t, "provider-3", resp.GetNextCursor())
 }
 
 func TestFeedHandler_GetFeed_InvalidMetadata(t *testing.T) {
 	service := &stubFeedService{}
-	handler := controllers.NewFeedHandler(service, controllers.NewBaseHandler(controllers.HandlerTimeouts{}), log.NewStdLogger(io.Discard))
+	handler := controllers.NewFeedHandler(service, controllers.NewBaseHandler(controllers.HandlerTimeouts{}), controllers.GuestPolicy{}, log.NewStdLogger(io.Discard))
 
 	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-apigateway-api-userinfo", "invalid-base64"))
 	_, err := handler.GetFeed(ctx, &feedv1.GetFeedRequest{Limit: 1})
@@ -70,7 +146,7 @@ func TestFeedHandler_GetFeed_InvalidMetadata(t *testing.T) {
 
 func TestFeedHandler_GetFeed_RecommendationUnavailable(t *testing.T) {
 	service := &stubFeedService{err: services.ErrRecommendationUnavailable}
-	handler := controllers.NewFeedHandler(service, controllers.NewBaseHandler(controllers.HandlerTimeouts{}), log.NewStdLogger(io.Discard))
+	handler := controllers.NewFeedHandler(service, controllers.NewBaseHandler(controllers.HandlerTimeouts{}), controllers.GuestPolicy{}, log.NewStdLogger(io.Discard))
 
 	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-apigateway-api-userinfo", encodeUserInfo(t, map[string]any{"sub": "user-2"})))
 	_, err := handler.GetFeed(ctx, &feedv1.GetFeedRequest{Limit: 3})
@@ -78,6 +154,230 @@ func TestFeedHandler_GetFeed_RecommendationUnavailable(t *testing.T) {
 	st, _ := status.FromError(err)
 	require.Equal(t, codes.Unavailable, st.Code())
 	require.Equal(t, "user-2", service.input.UserID)
+
+	info, retry := problemDetails(t, st)
+	require.Equal(t, "feed.errors.recommendation_unavailable", info.GetReason())
+	require.NotNil(t, retry)
+	require.Equal(t, time.Second, retry.GetRetryDelay().AsDuration())
+}
+
+func TestFeedHandler_GetFeed_InternalErrorHidesCause(t *testing.T) {
+	service := &stubFeedService{err: errors.New("pq: connection refused to 10.0.0.1")}
+	handler := controllers.NewFeedHandler(service, controllers.NewBaseHandler(controllers.HandlerTimeouts{}), controllers.GuestPolicy{}, log.NewStdLogger(io.Discard))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-apigateway-api-userinfo", encodeUserInfo(t, map[string]any{"sub": "user-9"})))
+	_, err := handler.GetFeed(ctx, &feedv1.GetFeedRequest{Limit: 3})
+	require.Error(t, err)
+	st, _ := status.FromError(err)
+	require.Equal(t, codes.Internal, st.Code())
+	require.NotContains(t, st.Message(), "connection refused")
+
+	info, retry := problemDetails(t, st)
+	require.Equal(t, "feed.errors.internal", info.GetReason())
+	require.Nil(t, retry)
+}
+
+func TestFeedHandler_GetFeed_ProjectionUnavailable(t *testing.T) {
+	service := &stubFeedService{err: fmt.Errorf("hydrate: %w", services.ErrProjectionUnavailable)}
+	handler := controllers.NewFeedHandler(service, controllers.NewBaseHandler(controllers.HandlerTimeouts{}), controllers.GuestPolicy{}, log.NewStdLogger(io.Discard))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-apigateway-api-userinfo", encodeUserInfo(t, map[string]any{"sub": "user-10"})))
+	_, err := handler.GetFeed(ctx, &feedv1.GetFeedRequest{Limit: 3})
+	require.Error(t, err)
+	st, _ := status.FromError(err)
+	require.Equal(t, codes.Unavailable, st.Code())
+
+	info, retry := problemDetails(t, st)
+	require.Equal(t, "feed.errors.projection_unavailable", info.GetReason())
+	require.NotNil(t, retry)
+}
+
+func TestFeedHandler_GetFeed_GuestMode(t *testing.T) {
+	service := &stubFeedService{response: &vo.FeedResponse{}}
+	handler := controllers.NewFeedHandler(service, controllers.NewBaseHandler(controllers.HandlerTimeouts{}), controllers.GuestPolicy{Enabled: true}, log.NewStdLogger(io.Discard))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-md-device-id", "device-1",
+		"x-md-idempotency-key", "retry-1",
+	))
+	_, err := handler.GetFeed(ctx, &feedv1.GetFeedRequest{Limit: 4})
+	require.NoError(t, err)
+	require.True(t, service.input.Guest)
+	require.Empty(t, service.input.UserID)
+	require.True(t, strings.HasPrefix(service.input.GuestID, "guest:"))
+	require.Empty(t, service.input.IdempotencyKey)
+
+	// 缺少设备号时无法派生访客 ID。
+	_, err = handler.GetFeed(metadata.NewIncomingContext(context.Background(), metadata.Pairs()), &feedv1.GetFeedRequest{Limit: 4})
+	st, _ := status.FromError(err)
+	require.Equal(t, codes.Unauthenticated, st.Code())
+
+	// 携带但无法解析的 userinfo 不降级为访客。
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-apigateway-api-userinfo", "invalid-base64",
+		"x-md-device-id", "device-1",
+	))
+	_, err = handler.GetFeed(ctx, &feedv1.GetFeedRequest{Limit: 4})
+	st, _ = status.FromError(err)
+	require.Equal(t, codes.Unauthenticated, st.Code())
+}
+
+func TestFeedHandler_GetFeed_GuestDisabled(t *testing.T) {
+	service := &stubFeedService{response: &vo.FeedResponse{}}
+	handler := controllers.NewFeedHandler(service, controllers.NewBaseHandler(controllers.HandlerTimeouts{}), controllers.GuestPolicy{}, log.NewStdLogger(io.Discard))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-md-device-id", "device-1"))
+	_, err := handler.GetFeed(ctx, &feedv1.GetFeedRequest{Limit: 4})
+	st, _ := status.FromError(err)
+	require.Equal(t, codes.Unauthenticated, st.Code())
+	require.False(t, service.input.Guest)
+}
+
+func TestFeedHandler_GetFeed_RateLimited(t *testing.T) {
+	service := &stubFeedService{err: services.ErrGuestRateLimited}
+	handler := controllers.NewFeedHandler(service, controllers.NewBaseHandler(controllers.HandlerTimeouts{}), controllers.GuestPolicy{Enabled: true}, log.NewStdLogger(io.Discard))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-md-device-id", "device-1"))
+	_, err := handler.GetFeed(ctx, &feedv1.GetFeedRequest{Limit: 4})
+	st, _ := status.FromError(err)
+	require.Equal(t, codes.ResourceExhausted, st.Code())
+	info, retry := problemDetails(t, st)
+	require.Equal(t, "feed.errors.rate_limited", info.GetReason())
+	require.NotNil(t, retry)
+}
+
+func problemDetails(t *testing.T, st *status.Status) (*errdetails.ErrorInfo, *errdetails.RetryInfo) {
+	t.Helper()
+	var (
+		info  *errdetails.ErrorInfo
+		retry *errdetails.RetryInfo
+	)
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.RetryInfo:
+			retry = d
+		}
+	}
+	require.NotNil(t, info, "expected ErrorInfo detail")
+	return info, retry
+}
+
+func TestFeedHandler_GetFeed_IdempotencyKey(t *testing.T) {
+	service := &stubFeedService{response: &vo.FeedResponse{}}
+	handler := controllers.NewFeedHandler(service, controllers.NewBaseHandler(controllers.HandlerTimeouts{}), controllers.GuestPolicy{}, log.NewStdLogger(io.Discard))
+
+	userInfo := encodeUserInfo(t, map[string]any{"sub": "user-3"})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-apigateway-api-userinfo", userInfo,
+		"x-md-idempotency-key", "retry-123",
+	))
+	_, err := handler.GetFeed(ctx, &feedv1.GetFeedRequest{Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, "retry-123", service.input.IdempotencyKey)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-apigateway-api-userinfo", userInfo,
+		"x-md-idempotency-key", strings.Repeat("k", 129),
+	))
+	_, err = handler.GetFeed(ctx, &feedv1.GetFeedRequest{Limit: 2})
+	require.Error(t, err)
+	st, _ := status.FromError(err)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+
+	service.err = services.ErrIdempotencyKeyMismatch
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-apigateway-api-userinfo", userInfo,
+		"x-md-idempotency-key", "retry-123",
+	))
+	_, err = handler.GetFeed(ctx, &feedv1.GetFeedRequest{Limit: 3})
+	require.Error(t, err)
+	st, _ = status.FromError(err)
+	require.Equal(t, codes.FailedPrecondition, st.Code())
+	info, _ := problemDetails(t, st)
+	require.Equal(t, "feed.errors.idempotency_conflict", info.GetReason())
+}
+
+func TestFeedHandler_GetFeed_ForcedExperimentVariants(t *testing.T) {
+	service := &stubFeedService{response: &vo.FeedResponse{}}
+	handler := controllers.NewFeedHandler(service, controllers.NewBaseHandler(controllers.HandlerTimeouts{}), controllers.GuestPolicy{}, log.NewStdLogger(io.Discard))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-apigateway-api-userinfo", encodeUserInfo(t, map[string]any{"sub": "user-4"}),
+		"x-md-experiment-variants", " home_diversity = no_mmr ,broken,=x,ranker=v2",
+	))
+	_, err := handler.GetFeed(ctx, &feedv1.GetFeedRequest{Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"home_diversity": "no_mmr", "ranker": "v2"}, service.input.ForcedVariants)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-apigateway-api-userinfo", encodeUserInfo(t, map[string]any{"sub": "user-4"}),
+	))
+	_, err = handler.GetFeed(ctx, &feedv1.GetFeedRequest{Limit: 2})
+	require.NoError(t, err)
+	require.Nil(t, service.input.ForcedVariants)
+}
+
+func TestFeedHandler_ReportInteractions(t *testing.T) {
+	service := &stubFeedService{interactions: &services.ReportInteractionsResult{
+		Results: []services.InteractionResult{
+			{EventID: "e1", Status: services.InteractionAccepted},
+			{EventID: "e2", Status: services.InteractionRejected, Reason: services.RejectReasonVideoNotServed},
+		},
+		Accepted: 1,
+	}}
+	handler := controllers.NewFeedHandler(service, controllers.NewBaseHandler(controllers.HandlerTimeouts{}), controllers.GuestPolicy{}, log.NewStdLogger(io.Discard))
+
+	occurredAt := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-apigateway-api-userinfo", encodeUserInfo(t, map[string]any{"sub": "user-7"}),
+	))
+	resp, err := handler.ReportInteractions(ctx, &feedv1.ReportInteractionsRequest{Events: []*feedv1.Interaction{
+		{EventId: "e1", Type: feedv1.InteractionType_INTERACTION_TYPE_CLICK, LogId: "l1", VideoId: "v1", Position: 2, OccurredAt: timestamppb.New(occurredAt)},
+		{EventId: "e2", Type: feedv1.InteractionType_INTERACTION_TYPE_REFRESH, LogId: "l1"},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), resp.GetAccepted())
+	require.Len(t, resp.GetResults(), 2)
+	require.Equal(t, feedv1.InteractionStatus_INTERACTION_STATUS_ACCEPTED, resp.GetResults()[0].GetStatus())
+	require.Equal(t, feedv1.InteractionStatus_INTERACTION_STATUS_REJECTED, resp.GetResults()[1].GetStatus())
+	require.Equal(t, "video_not_served", resp.GetResults()[1].GetReason())
+
+	input := service.interactionsInput
+	require.Equal(t, "user-7", input.UserID)
+	require.False(t, input.Guest)
+	require.Len(t, input.Events, 2)
+	require.Equal(t, services.InteractionClick, input.Events[0].Type)
+	require.Equal(t, 2, input.Events[0].Position)
+	require.True(t, input.Events[0].OccurredAt.Equal(occurredAt))
+	require.Equal(t, services.InteractionRefresh, input.Events[1].Type)
+	require.True(t, input.Events[1].OccurredAt.IsZero())
+}
+
+func TestFeedHandler_ReportInteractions_Errors(t *testing.T) {
+	service := &stubFeedService{err: services.ErrInteractionsDisabled}
+	handler := controllers.NewFeedHandler(service, controllers.NewBaseHandler(controllers.HandlerTimeouts{}), controllers.GuestPolicy{Enabled: true}, log.NewStdLogger(io.Discard))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-md-device-id", "device-1"))
+	_, err := handler.ReportInteractions(ctx, &feedv1.ReportInteractionsRequest{})
+	st, _ := status.FromError(err)
+	require.Equal(t, codes.FailedPrecondition, st.Code())
+	info, _ := problemDetails(t, st)
+	require.Equal(t, "feed.errors.feature_disabled", info.GetReason())
+	require.True(t, service.interactionsInput.Guest)
+	require.Empty(t, service.interactionsInput.UserID)
+
+	service.err = services.ErrInteractionStoreUnavailable
+	_, err = handler.ReportInteractions(ctx, &feedv1.ReportInteractionsRequest{})
+	st, _ = status.FromError(err)
+	require.Equal(t, codes.Unavailable, st.Code())
+	_, retry := problemDetails(t, st)
+	require.NotNil(t, retry)
+
+	_, err = handler.ReportInteractions(metadata.NewIncomingContext(context.Background(), metadata.Pairs()), &feedv1.ReportInteractionsRequest{})
+	st, _ = status.FromError(err)
+	require.Equal(t, codes.Unauthenticated, st.Code())
 }
 
 func encodeUserInfo(t *testing.T, claims map[string]any) string {
@@ -1,6 +1,8 @@
 package configloader
 
 import (
+	"os"
+	"strings"
 	"time"
 
 	configpb "github.com/bionicotaku/lingo-services-feed/configs"
@@ -9,8 +11,57 @@ import (
 )
 
 const (
-	defaultHandlerTimeout = 5 * time.Second
-	defaultQueryTimeout   = 3 * time.Second
+	defaultHandlerTimeout   = 5 * time.Second
+	defaultQueryTimeout     = 3 * time.Second
+	defaultIdempotencyTTL   = 10 * time.Minute
+	defaultGuestCacheTTL    = 60 * time.Second
+	defaultGuestRPS         = 50
+	defaultGuestBurst       = 100
+	defaultGuestDeviceRPS   = 1
+	defaultGuestDeviceBurst = 10
+
+	defaultRateLimitBackend = "memory"
+	defaultLogOverflow      = "drop"
+
+	defaultLogPremakeDays       = 7
+	defaultServedPageRetention  = 48 * time.Hour
+	defaultLogRetentionInterval = time.Hour
+
+	defaultInteractionsTopic       = "feed_events"
+	defaultInteractionMaxBatchSize = 500
+	defaultInteractionMaxEventAge  = 24 * time.Hour
+	defaultInteractionClockSkew    = 5 * time.Minute
+
+	defaultUserStateTopic   = "profile_events"
+	defaultUserStateInbox   = "profile"
+	defaultWatchedDropRatio = 0.9
+	defaultContinueMinRatio = 0.05
+	defaultContinueMaxRatio = 0.9
+	defaultReviewTopic      = "learning_events"
+	defaultReviewInbox      = "learning"
+	defaultBlendStrategy    = "slots"
+	defaultBlendBudget      = 150 * time.Millisecond
+
+	defaultRerankMMRLambda      = 0.7
+	defaultRerankMaxPerCreator  = 2
+	defaultRerankMaxLanguageRun = 3
+	defaultRerankMaxDurationRun = 2
+
+	defaultCurationRefreshInterval  = 5 * time.Minute
+	defaultCurationReconnectBackoff = 5 * time.Second
+
+	defaultShadowBudget         = 300 * time.Millisecond
+	defaultShadowMaxConcurrency = 8
+	defaultShadowSampleRate     = 1.0
+
+	defaultRemoteTimeout           = 200 * time.Millisecond
+	defaultHedgePercentile         = 0.95
+	defaultHedgeInitialDelay       = 50 * time.Millisecond
+	defaultHedgeMinDelay           = 5 * time.Millisecond
+	defaultHedgeWindow             = 200
+	defaultBreakerFailureThreshold = 5
+	defaultBreakerOpenDuration     = 10 * time.Second
+	defaultBreakerHalfOpenProbes   = 1
 )
 
 func fromProto(b *configpb.Bootstrap) RuntimeConfig {
@@ -23,6 +74,7 @@ func fromProto(b *configpb.Bootstrap) RuntimeConfig {
 		GRPCClient:    grpcClientFromProto(b.GetData().GetGrpcClient()),
 		Observability: observabilityFromProto(b.GetObservability()),
 		Messaging:     messagingFromProto(b.GetMessaging(), b.GetData()),
+		Feed:          feedFromProto(b.GetFeed()),
 	}
 	return rc
 }
@@ -45,11 +97,279 @@ func serverFromProto(s *configpb.Server) ServerConfig {
 			HeaderKey:        firstNonEmpty(jwt.GetHeaderKey(), "authorization"),
 		}
 	}
+	if http := s.GetHttp(); http != nil {
+		server.HTTP = HTTPServerConfig{
+			Network: http.GetNetwork(),
+			Address: http.GetAddr(),
+			Timeout: durationOrZero(http.GetTimeout()),
+		}
+	}
+	server.RateLimit = rateLimitFromProto(s.GetRateLimit())
+	if admin := s.GetAdmin(); admin != nil {
+		server.Admin = AdminConfig{
+			Enabled:  admin.GetEnabled(),
+			Audience: strings.TrimSpace(admin.GetAudience()),
+		}
+	}
 	server.Handlers = handlerTimeoutFromProto(s.GetHandlers())
 	server.MetadataKeys = append([]string(nil), s.GetMetadataKeys()...)
 	return server
 }
 
+func rateLimitFromProto(rl *configpb.Server_RateLimit) RateLimitConfig {
+	if rl == nil {
+		return RateLimitConfig{}
+	}
+	cfg := RateLimitConfig{
+		Enabled:      rl.GetEnabled(),
+		Backend:      strings.ToLower(strings.TrimSpace(rl.GetBackend())),
+		DefaultRPS:   rl.GetDefaultRps(),
+		DefaultBurst: int(rl.GetDefaultBurst()),
+	}
+	for _, rule := range rl.GetRules() {
+		cfg.Rules = append(cfg.Rules, RateLimitRule{
+			Method: rule.GetMethod(),
+			Scene:  rule.GetScene(),
+			RPS:    rule.GetRps(),
+			Burst:  int(rule.GetBurst()),
+		})
+	}
+	return cfg
+}
+
+func feedFromProto(f *configpb.Feed) FeedConfig {
+	cfg := FeedConfig{}
+	if idem := f.GetIdempotency(); idem != nil {
+		cfg.Idempotency = IdempotencyConfig{
+			Enabled: idem.GetEnabled(),
+			TTL:     durationOrZero(idem.GetTtl()),
+		}
+	}
+	if guest := f.GetGuest(); guest != nil {
+		cfg.Guest = GuestConfig{
+			Enabled:        guest.GetEnabled(),
+			CacheTTL:       durationOrZero(guest.GetCacheTtl()),
+			RateLimitRPS:   guest.GetRateLimitRps(),
+			RateLimitBurst: int(guest.GetRateLimitBurst()),
+			DeviceRPS:      guest.GetDeviceRateLimitRps(),
+			DeviceBurst:    int(guest.GetDeviceRateLimitBurst()),
+		}
+	}
+	if writer := f.GetLogWriter(); writer != nil {
+		cfg.LogWriter = LogWriterConfig{
+			Async:         writer.GetAsync(),
+			QueueSize:     int(writer.GetQueueSize()),
+			BatchSize:     int(writer.GetBatchSize()),
+			FlushInterval: durationOrZero(writer.GetFlushInterval()),
+			FlushTimeout:  durationOrZero(writer.GetFlushTimeout()),
+			Overflow:      strings.ToLower(strings.TrimSpace(writer.GetOverflow())),
+		}
+	}
+	if retention := f.GetLogRetention(); retention != nil {
+		cfg.Retention = LogRetentionConfig{
+			Enabled:     retention.GetEnabled(),
+			Retention:   durationOrZero(retention.GetRetention()),
+			PremakeDays: int(retention.GetPremakeDays()),
+			Interval:    durationOrZero(retention.GetInterval()),
+
+			ServedPageRetention: durationOrZero(retention.GetServedPageRetention()),
+		}
+	}
+	if pseudo := f.GetPseudonymization(); pseudo != nil {
+		cfg.Pseudonym = PseudonymConfig{
+			ActiveKeyVersion: strings.TrimSpace(pseudo.GetActiveKeyVersion()),
+		}
+		for _, key := range pseudo.GetKeys() {
+			secret := key.GetSecret()
+			env := strings.TrimSpace(key.GetSecretEnv())
+			if env != "" {
+				secret = os.Getenv(env)
+			}
+			cfg.Pseudonym.Keys = append(cfg.Pseudonym.Keys, PseudonymKey{
+				Version:   strings.TrimSpace(key.GetVersion()),
+				Secret:    secret,
+				SecretEnv: env,
+			})
+		}
+	}
+	if sampling := f.GetLogSampling(); sampling != nil {
+		cfg.Sampling = LogSamplingConfig{
+			Enabled:      sampling.GetEnabled(),
+			DefaultRate:  sampling.GetDefaultRate(),
+			DebugUserIDs: append([]string(nil), sampling.GetDebugUserIds()...),
+		}
+		for _, rule := range sampling.GetRules() {
+			cfg.Sampling.Rules = append(cfg.Sampling.Rules, LogSamplingRule{
+				Source: strings.TrimSpace(rule.GetSource()),
+				Scene:  strings.TrimSpace(rule.GetScene()),
+				Rate:   rule.GetRate(),
+			})
+		}
+	}
+	if interactions := f.GetInteractions(); interactions != nil {
+		cfg.Interactions = InteractionsConfig{
+			Enabled:      interactions.GetEnabled(),
+			Topic:        strings.TrimSpace(interactions.GetTopic()),
+			MaxBatchSize: int(interactions.GetMaxBatchSize()),
+			MaxEventAge:  durationOrZero(interactions.GetMaxEventAge()),
+			ClockSkew:    durationOrZero(interactions.GetClockSkew()),
+		}
+	}
+	if served := f.GetServedEvents(); served != nil {
+		cfg.ServedEvents = ServedEventsConfig{Enabled: served.GetEnabled()}
+	}
+	if state := f.GetUserState(); state != nil {
+		cfg.UserState = UserStateConfig{
+			Enabled: state.GetEnabled(),
+			Topic:   strings.TrimSpace(state.GetTopic()),
+			Inbox:   strings.TrimSpace(state.GetInbox()),
+		}
+	}
+	if watched := f.GetWatchedFilter(); watched != nil {
+		cfg.Watched = WatchedFilterConfig{
+			Enabled:     watched.GetEnabled(),
+			DropRatio:   watched.GetDropRatio(),
+			DemoteRatio: watched.GetDemoteRatio(),
+		}
+		for _, scene := range watched.GetScenes() {
+			cfg.Watched.Scenes = append(cfg.Watched.Scenes, WatchedFilterScene{
+				Scene:       strings.TrimSpace(scene.GetScene()),
+				DropRatio:   scene.DropRatio,
+				DemoteRatio: scene.DemoteRatio,
+			})
+		}
+	}
+	if cont := f.GetContinueLearning(); cont != nil {
+		cfg.Continue = ContinueLearningConfig{
+			Enabled:  cont.GetEnabled(),
+			MinRatio: cont.GetMinRatio(),
+			MaxRatio: cont.GetMaxRatio(),
+		}
+	}
+	if review := f.GetReviewQueue(); review != nil {
+		cfg.Review = ReviewQueueConfig{
+			Enabled:       review.GetEnabled(),
+			Topic:         strings.TrimSpace(review.GetTopic()),
+			Inbox:         strings.TrimSpace(review.GetInbox()),
+			BlendFraction: review.GetBlendFraction(),
+		}
+	}
+	if blending := f.GetBlending(); blending != nil {
+		cfg.Blending = BlendingConfig{
+			Enabled:       blending.GetEnabled(),
+			Strategy:      strings.ToLower(strings.TrimSpace(blending.GetStrategy())),
+			DefaultBudget: durationOrZero(blending.GetDefaultBudget()),
+		}
+		cfg.Blending.Sources = blendingSourcesFromProto(blending.GetSources())
+	}
+	if rerank := f.GetRerank(); rerank != nil {
+		cfg.Rerank = rerankFromProto(rerank)
+	}
+	if curation := f.GetCuration(); curation != nil {
+		cfg.Curation = CurationConfig{
+			Enabled:          curation.GetEnabled(),
+			RefreshInterval:  durationOrZero(curation.GetRefreshInterval()),
+			ReconnectBackoff: durationOrZero(curation.GetReconnectBackoff()),
+		}
+	}
+	if experiments := f.GetExperiments(); experiments != nil {
+		cfg.Experiments = ExperimentsConfig{
+			Enabled:             experiments.GetEnabled(),
+			AllowForcedVariants: experiments.GetAllowForcedVariants(),
+		}
+		for _, exp := range experiments.GetExperiments() {
+			def := ExperimentConfig{
+				Key:  strings.TrimSpace(exp.GetKey()),
+				Salt: strings.TrimSpace(exp.GetSalt()),
+			}
+			for _, variant := range exp.GetVariants() {
+				vc := ExperimentVariantConfig{
+					Name:         strings.TrimSpace(variant.GetName()),
+					Weight:       variant.GetWeight(),
+					Provider:     strings.TrimSpace(variant.GetProvider()),
+					BlendSources: blendingSourcesFromProto(variant.GetBlendSources()),
+				}
+				if variant.GetRerank() != nil {
+					rerank := rerankFromProto(variant.GetRerank())
+					vc.Rerank = &rerank
+				}
+				def.Variants = append(def.Variants, vc)
+			}
+			cfg.Experiments.Experiments = append(cfg.Experiments.Experiments, def)
+		}
+	}
+	if shadow := f.GetShadow(); shadow != nil {
+		cfg.Shadow = ShadowConfig{
+			Enabled:        shadow.GetEnabled(),
+			Candidate:      strings.TrimSpace(shadow.GetCandidate()),
+			Budget:         durationOrZero(shadow.GetBudget()),
+			MaxConcurrency: int(shadow.GetMaxConcurrency()),
+			SampleRate:     shadow.GetSampleRate(),
+		}
+	}
+	if mock := f.GetMock(); mock != nil {
+		cfg.Mock = MockRecommendationConfig{
+			Seed:  mock.GetSeed(),
+			Fixed: mock.GetFixed(),
+		}
+	}
+	if remote := f.GetRemoteRecommendation(); remote != nil {
+		cfg.Remote = RemoteRecommendationConfig{
+			Enabled: remote.GetEnabled(),
+			Primary: remote.GetPrimary(),
+			Timeout: durationOrZero(remote.GetTimeout()),
+
+			HedgeEnabled:      remote.GetHedge().GetEnabled(),
+			HedgePercentile:   remote.GetHedge().GetPercentile(),
+			HedgeInitialDelay: durationOrZero(remote.GetHedge().GetInitialDelay()),
+			HedgeMinDelay:     durationOrZero(remote.GetHedge().GetMinDelay()),
+			HedgeWindow:       int(remote.GetHedge().GetWindow()),
+
+			BreakerEnabled:          remote.GetBreaker().GetEnabled(),
+			BreakerFailureThreshold: int(remote.GetBreaker().GetFailureThreshold()),
+			BreakerOpenDuration:     durationOrZero(remote.GetBreaker().GetOpenDuration()),
+			BreakerHalfOpenProbes:   int(remote.GetBreaker().GetHalfOpenProbes()),
+		}
+		for _, target := range remote.GetTargets() {
+			if target = strings.TrimSpace(target); target != "" {
+				cfg.Remote.Targets = append(cfg.Remote.Targets, target)
+			}
+		}
+	}
+	return cfg
+}
+
+func blendingSourcesFromProto(sources []*configpb.Feed_Blending_Source) []BlendingSourceConfig {
+	var out []BlendingSourceConfig
+	for _, src := range sources {
+		out = append(out, BlendingSourceConfig{
+			Name:   strings.TrimSpace(src.GetName()),
+			Weight: src.GetWeight(),
+			Budget: durationOrZero(src.GetBudget()),
+		})
+	}
+	return out
+}
+
+func rerankFromProto(rerank *configpb.Feed_Rerank) RerankConfig {
+	cfg := RerankConfig{
+		MMREnabled:            rerank.GetMmr().GetEnabled(),
+		MMRLambda:             rerank.GetMmr().GetLambda(),
+		CreatorCapEnabled:     rerank.GetCreatorCap().GetEnabled(),
+		MaxPerCreator:         int(rerank.GetCreatorCap().GetMaxPerCreator()),
+		LanguageRunEnabled:    rerank.GetLanguageRun().GetEnabled(),
+		MaxLanguageRun:        int(rerank.GetLanguageRun().GetMaxConsecutive()),
+		DurationSpreadEnabled: rerank.GetDurationSpread().GetEnabled(),
+		MaxDurationRun:        int(rerank.GetDurationSpread().GetMaxConsecutive()),
+	}
+	for _, b := range rerank.GetDurationSpread().GetBoundaries() {
+		if d := durationOrZero(b); d > 0 {
+			cfg.DurationBoundaries = append(cfg.DurationBoundaries, d)
+		}
+	}
+	return cfg
+}
+
 func handlerTimeoutFromProto(h *configpb.Server_Handlers) HandlerTimeoutConfig {
 	cfg := HandlerTimeoutConfig{
 		Default: defaultHandlerTimeout,
@@ -321,4 +641,159 @@ func fillDefaults(cfg *RuntimeConfig) {
 	if len(cfg.GRPCClient.MetadataKeys) == 0 {
 		cfg.GRPCClient.MetadataKeys = append([]string(nil), cfg.Server.MetadataKeys...)
 	}
+	if cfg.Feed.Idempotency.TTL <= 0 {
+		cfg.Feed.Idempotency.TTL = defaultIdempotencyTTL
+	}
+	if cfg.Server.RateLimit.Backend == "" {
+		cfg.Server.RateLimit.Backend = defaultRateLimitBackend
+	}
+	if cfg.Feed.Guest.CacheTTL <= 0 {
+		cfg.Feed.Guest.CacheTTL = defaultGuestCacheTTL
+	}
+	if cfg.Feed.Guest.RateLimitRPS <= 0 {
+		cfg.Feed.Guest.RateLimitRPS = defaultGuestRPS
+	}
+	if cfg.Feed.Guest.RateLimitBurst <= 0 {
+		cfg.Feed.Guest.RateLimitBurst = defaultGuestBurst
+	}
+	if cfg.Feed.Guest.DeviceRPS <= 0 {
+		cfg.Feed.Guest.DeviceRPS = defaultGuestDeviceRPS
+	}
+	if cfg.Feed.Guest.DeviceBurst <= 0 {
+		cfg.Feed.Guest.DeviceBurst = defaultGuestDeviceBurst
+	}
+	if cfg.Feed.LogWriter.Overflow == "" {
+		cfg.Feed.LogWriter.Overflow = defaultLogOverflow
+	}
+	if cfg.Feed.Retention.PremakeDays <= 0 {
+		cfg.Feed.Retention.PremakeDays = defaultLogPremakeDays
+	}
+	if cfg.Feed.Retention.Interval <= 0 {
+		cfg.Feed.Retention.Interval = defaultLogRetentionInterval
+	}
+	if cfg.Feed.Interactions.Topic == "" {
+		cfg.Feed.Interactions.Topic = defaultInteractionsTopic
+	}
+	if cfg.Feed.Interactions.MaxBatchSize <= 0 || cfg.Feed.Interactions.MaxBatchSize > defaultInteractionMaxBatchSize {
+		cfg.Feed.Interactions.MaxBatchSize = defaultInteractionMaxBatchSize
+	}
+	if cfg.Feed.Interactions.MaxEventAge <= 0 {
+		cfg.Feed.Interactions.MaxEventAge = defaultInteractionMaxEventAge
+	}
+	if cfg.Feed.Interactions.ClockSkew <= 0 {
+		cfg.Feed.Interactions.ClockSkew = defaultInteractionClockSkew
+	}
+	if cfg.Feed.Retention.ServedPageRetention <= 0 {
+		cfg.Feed.Retention.ServedPageRetention = defaultServedPageRetention
+	}
+	if window := cfg.Feed.Interactions.MaxEventAge + cfg.Feed.Interactions.ClockSkew; cfg.Feed.Retention.ServedPageRetention < window {
+		cfg.Feed.Retention.ServedPageRetention = window
+	}
+	if cfg.Feed.UserState.Topic == "" {
+		cfg.Feed.UserState.Topic = defaultUserStateTopic
+	}
+	if cfg.Feed.UserState.Inbox == "" {
+		cfg.Feed.UserState.Inbox = defaultUserStateInbox
+	}
+	if cfg.Feed.Watched.DropRatio <= 0 {
+		cfg.Feed.Watched.DropRatio = defaultWatchedDropRatio
+	}
+	if cfg.Feed.Continue.MinRatio <= 0 {
+		cfg.Feed.Continue.MinRatio = defaultContinueMinRatio
+	}
+	if cfg.Feed.Continue.MaxRatio <= 0 || cfg.Feed.Continue.MaxRatio > 1 {
+		cfg.Feed.Continue.MaxRatio = defaultContinueMaxRatio
+	}
+	if cfg.Feed.Review.Topic == "" {
+		cfg.Feed.Review.Topic = defaultReviewTopic
+	}
+	if cfg.Feed.Review.Inbox == "" {
+		cfg.Feed.Review.Inbox = defaultReviewInbox
+	}
+	if cfg.Feed.Blending.Strategy == "" {
+		cfg.Feed.Blending.Strategy = defaultBlendStrategy
+	}
+	if cfg.Feed.Blending.DefaultBudget <= 0 {
+		cfg.Feed.Blending.DefaultBudget = defaultBlendBudget
+	}
+	fillBlendingSourceDefaults(cfg.Feed.Blending.Sources, cfg.Feed.Blending.DefaultBudget)
+	fillRerankDefaults(&cfg.Feed.Rerank)
+	if cfg.Feed.Curation.RefreshInterval <= 0 {
+		cfg.Feed.Curation.RefreshInterval = defaultCurationRefreshInterval
+	}
+	if cfg.Feed.Curation.ReconnectBackoff <= 0 {
+		cfg.Feed.Curation.ReconnectBackoff = defaultCurationReconnectBackoff
+	}
+	for i := range cfg.Feed.Experiments.Experiments {
+		exp := &cfg.Feed.Experiments.Experiments[i]
+		if exp.Salt == "" {
+			exp.Salt = exp.Key
+		}
+		for j := range exp.Variants {
+			fillBlendingSourceDefaults(exp.Variants[j].BlendSources, cfg.Feed.Blending.DefaultBudget)
+			if exp.Variants[j].Rerank != nil {
+				fillRerankDefaults(exp.Variants[j].Rerank)
+			}
+		}
+	}
+	if cfg.Feed.Shadow.Budget <= 0 {
+		cfg.Feed.Shadow.Budget = defaultShadowBudget
+	}
+	if cfg.Feed.Shadow.MaxConcurrency <= 0 {
+		cfg.Feed.Shadow.MaxConcurrency = defaultShadowMaxConcurrency
+	}
+	if cfg.Feed.Shadow.SampleRate <= 0 {
+		cfg.Feed.Shadow.SampleRate = defaultShadowSampleRate
+	}
+	remote := &cfg.Feed.Remote
+	if remote.Timeout <= 0 {
+		remote.Timeout = defaultRemoteTimeout
+	}
+	if remote.HedgePercentile <= 0 {
+		remote.HedgePercentile = defaultHedgePercentile
+	}
+	if remote.HedgeInitialDelay <= 0 {
+		remote.HedgeInitialDelay = defaultHedgeInitialDelay
+	}
+	if remote.HedgeMinDelay <= 0 {
+		remote.HedgeMinDelay = defaultHedgeMinDelay
+	}
+	if remote.HedgeWindow <= 0 {
+		remote.HedgeWindow = defaultHedgeWindow
+	}
+	if remote.BreakerFailureThreshold <= 0 {
+		remote.BreakerFailureThreshold = defaultBreakerFailureThreshold
+	}
+	if remote.BreakerOpenDuration <= 0 {
+		remote.BreakerOpenDuration = defaultBreakerOpenDuration
+	}
+	if remote.BreakerHalfOpenProbes <= 0 {
+		remote.BreakerHalfOpenProbes = defaultBreakerHalfOpenProbes
+	}
+}
+
+func fillBlendingSourceDefaults(sources []BlendingSourceConfig, budget time.Duration) {
+	for i := range sources {
+		if sources[i].Budget <= 0 {
+			sources[i].Budget = budget
+		}
+	}
+}
+
+func fillRerankDefaults(rerank *RerankConfig) {
+	if rerank.MMRLambda <= 0 {
+		rerank.MMRLambda = defaultRerankMMRLambda
+	}
+	if rerank.MaxPerCreator <= 0 {
+		rerank.MaxPerCreator = defaultRerankMaxPerCreator
+	}
+	if rerank.MaxLanguageRun <= 0 {
+		rerank.MaxLanguageRun = defaultRerankMaxLanguageRun
+	}
+	if len(rerank.DurationBoundaries) == 0 {
+		rerank.DurationBoundaries = []time.Duration{time.Minute, 5 * time.Minute}
+	}
+	if rerank.MaxDurationRun <= 0 {
+		rerank.MaxDurationRun = defaultRerankMaxDurationRun
+	}
 }
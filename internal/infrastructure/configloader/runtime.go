@@ -11,6 +11,7 @@ type RuntimeConfig struct {
 	GRPCClient    GRPCClientConfig
 	Observability ObservabilityConfig
 	Messaging     MessagingConfig
+	Feed          FeedConfig
 }
 
 // ServiceInfo 描述服务标识与运行环境。
@@ -29,6 +30,39 @@ type ServerConfig struct {
 	JWT          ServerJWTConfig
 	Handlers     HandlerTimeoutConfig
 	MetadataKeys []string
+	HTTP         HTTPServerConfig
+	RateLimit    RateLimitConfig
+	Admin        AdminConfig
+}
+
+// AdminConfig 控制管理端 RPC 的启用与访问身份。
+type AdminConfig struct {
+	Enabled  bool
+	Audience string
+}
+
+// RateLimitConfig 描述用户级令牌桶限流的后端与速率规则。
+type RateLimitConfig struct {
+	Enabled      bool
+	Backend      string
+	DefaultRPS   float64
+	DefaultBurst int
+	Rules        []RateLimitRule
+}
+
+// RateLimitRule 为指定方法（可选场景）覆盖默认速率。
+type RateLimitRule struct {
+	Method string
+	Scene  string
+	RPS    float64
+	Burst  int
+}
+
+// HTTPServerConfig 描述 HTTP/JSON 入口的监听配置，Address 为空时不启动。
+type HTTPServerConfig struct {
+	Network string
+	Address string
+	Timeout time.Duration
 }
 
 // ServerJWTConfig 管理入站请求的 JWT 校验策略。
@@ -174,3 +208,234 @@ type InboxConfig struct {
 	LoggingEnabled *bool
 	MetricsEnabled *bool
 }
+
+// FeedConfig 汇总 Feed 用例层的行为开关。
+type FeedConfig struct {
+	Idempotency  IdempotencyConfig
+	Guest        GuestConfig
+	LogWriter    LogWriterConfig
+	Retention    LogRetentionConfig
+	Pseudonym    PseudonymConfig
+	Sampling     LogSamplingConfig
+	Interactions InteractionsConfig
+	ServedEvents ServedEventsConfig
+	UserState    UserStateConfig
+	Watched      WatchedFilterConfig
+	Continue     ContinueLearningConfig
+	Review       ReviewQueueConfig
+	Blending     BlendingConfig
+	Rerank       RerankConfig
+	Curation     CurationConfig
+	Experiments  ExperimentsConfig
+	Shadow       ShadowConfig
+	Remote       RemoteRecommendationConfig
+	Mock         MockRecommendationConfig
+}
+
+// IdempotencyConfig 控制 GetFeed 幂等重放。
+type IdempotencyConfig struct {
+	Enabled bool
+	TTL     time.Duration
+}
+
+// GuestConfig 控制匿名访客 Feed 的准入、缓存与限流。
+type GuestConfig struct {
+	Enabled        bool
+	CacheTTL       time.Duration
+	RateLimitRPS   float64
+	RateLimitBurst int
+	// DeviceRPS / DeviceBurst 为单个访客 ID 的令牌桶，由 server.rate_limit 的限流器计数。
+	DeviceRPS   float64
+	DeviceBurst int
+}
+
+// LogWriterConfig 控制推荐日志的同步/异步写入方式。
+type LogWriterConfig struct {
+	Async         bool
+	QueueSize     int
+	BatchSize     int
+	FlushInterval time.Duration
+	FlushTimeout  time.Duration
+	Overflow      string
+}
+
+// LogRetentionConfig 控制推荐日志分区的预建与清理。
+type LogRetentionConfig struct {
+	Enabled     bool
+	Retention   time.Duration
+	PremakeDays int
+	Interval    time.Duration
+	// ServedPageRetention 为 feed.served_pages 的保留时长。
+	ServedPageRetention time.Duration
+}
+
+// PseudonymConfig 控制推荐日志中用户标识的 HMAC 假名化。
+type PseudonymConfig struct {
+	ActiveKeyVersion string
+	Keys             []PseudonymKey
+}
+
+// PseudonymKey 为单个版本的 HMAC 密钥。配置了 SecretEnv 时 Secret 取自该环境变量，不再回退到配置文件中的明文。
+type PseudonymKey struct {
+	Version   string
+	Secret    string
+	SecretEnv string
+}
+
+// LogSamplingConfig 控制推荐日志的按来源/场景采样。
+type LogSamplingConfig struct {
+	Enabled      bool
+	DefaultRate  float64
+	Rules        []LogSamplingRule
+	DebugUserIDs []string
+}
+
+// LogSamplingRule 为单条 source/scene 采样率规则。
+type LogSamplingRule struct {
+	Source string
+	Scene  string
+	Rate   float64
+}
+
+// InteractionsConfig 控制曝光/点击/刷新事件上报的校验窗口与发布目标。
+type InteractionsConfig struct {
+	Enabled      bool
+	Topic        string
+	MaxBatchSize int
+	MaxEventAge  time.Duration
+	ClockSkew    time.Duration
+}
+
+// ServedEventsConfig 控制推荐日志写入时是否同事务产出 FeedServedEvent。
+type ServedEventsConfig struct {
+	Enabled bool
+}
+
+// UserStateConfig 控制用户-视频状态补水及其 Profile 事件订阅。
+type UserStateConfig struct {
+	Enabled bool
+	Topic   string
+	Inbox   string
+}
+
+// WatchedFilterConfig 控制按观看历史剔除/降权已看过的视频。
+type WatchedFilterConfig struct {
+	Enabled     bool
+	DropRatio   float64
+	DemoteRatio float64
+	Scenes      []WatchedFilterScene
+}
+
+// WatchedFilterScene 为单个场景的阈值覆盖，nil 表示沿用默认值。
+type WatchedFilterScene struct {
+	Scene       string
+	DropRatio   *float64
+	DemoteRatio *float64
+}
+
+// ContinueLearningConfig 控制 continue_learning 场景的观看进度区间。
+type ContinueLearningConfig struct {
+	Enabled  bool
+	MinRatio float64
+	MaxRatio float64
+}
+
+// ReviewQueueConfig 控制复习队列、首页混排比例及其 Learning 事件订阅。
+type ReviewQueueConfig struct {
+	Enabled       bool
+	Topic         string
+	Inbox         string
+	BlendFraction float64
+}
+
+// BlendingConfig 控制首页多路推荐源混排。
+type BlendingConfig struct {
+	Enabled       bool
+	Strategy      string
+	DefaultBudget time.Duration
+	Sources       []BlendingSourceConfig
+}
+
+// BlendingSourceConfig 为单个推荐源的权重与超时。
+type BlendingSourceConfig struct {
+	Name   string
+	Weight float64
+	Budget time.Duration
+}
+
+// CurationConfig 控制运营干预规则的缓存刷新。
+type CurationConfig struct {
+	Enabled          bool
+	RefreshInterval  time.Duration
+	ReconnectBackoff time.Duration
+}
+
+// ExperimentsConfig 控制 A/B 实验分桶与分组覆盖。
+type ExperimentsConfig struct {
+	Enabled             bool
+	AllowForcedVariants bool
+	Experiments         []ExperimentConfig
+}
+
+// ExperimentConfig 为单个实验的定义，Salt 已按 key 补齐。
+type ExperimentConfig struct {
+	Key      string
+	Salt     string
+	Variants []ExperimentVariantConfig
+}
+
+// ExperimentVariantConfig 为实验分组的流量权重与覆盖项，Rerank 为 nil 表示沿用全局重排配置。
+type ExperimentVariantConfig struct {
+	Name         string
+	Weight       uint32
+	Provider     string
+	BlendSources []BlendingSourceConfig
+	Rerank       *RerankConfig
+}
+
+// ShadowConfig 控制候选推荐源的影子流量比对。
+type ShadowConfig struct {
+	Enabled        bool
+	Candidate      string
+	Budget         time.Duration
+	MaxConcurrency int
+	SampleRate     float64
+}
+
+// MockRecommendationConfig 控制 Mock 推荐的确定性排列。
+type MockRecommendationConfig struct {
+	Seed  int64
+	Fixed bool
+}
+
+// RemoteRecommendationConfig 控制外部推荐服务客户端的对冲请求与熔断。
+type RemoteRecommendationConfig struct {
+	Enabled bool
+	Primary bool
+	Targets []string
+	Timeout time.Duration
+
+	HedgeEnabled      bool
+	HedgePercentile   float64
+	HedgeInitialDelay time.Duration
+	HedgeMinDelay     time.Duration
+	HedgeWindow       int
+
+	BreakerEnabled          bool
+	BreakerFailureThreshold int
+	BreakerOpenDuration     time.Duration
+	BreakerHalfOpenProbes   int
+}
+
+// RerankConfig 控制补水后的多样性重排，各阶段独立开关。
+type RerankConfig struct {
+	MMREnabled            bool
+	MMRLambda             float64
+	CreatorCapEnabled     bool
+	MaxPerCreator         int
+	LanguageRunEnabled    bool
+	MaxLanguageRun        int
+	DurationSpreadEnabled bool
+	DurationBoundaries    []time.Duration
+	MaxDurationRun        int
+}
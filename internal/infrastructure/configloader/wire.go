@@ -1,6 +1,7 @@
 package configloader
 
 import (
+	"fmt"
 	"github.com/bionicotaku/lingo-utils/gcjwt"
 	"github.com/bionicotaku/lingo-utils/gclog"
 	"github.com/bionicotaku/lingo-utils/gcpubsub"
@@ -12,6 +13,13 @@ import (
 	"github.com/google/wire"
 
 	"github.com/bionicotaku/lingo-services-feed/internal/controllers"
+	"github.com/bionicotaku/lingo-services-feed/internal/infrastructure/ratelimiter"
+	"github.com/bionicotaku/lingo-services-feed/internal/pseudonym"
+	"github.com/bionicotaku/lingo-services-feed/internal/services"
+	learninginbox "github.com/bionicotaku/lingo-services-feed/internal/tasks/learning_inbox"
+	logretention "github.com/bionicotaku/lingo-services-feed/internal/tasks/log_retention"
+	outboxpublisher "github.com/bionicotaku/lingo-services-feed/internal/tasks/outbox_publisher"
+	profileinbox "github.com/bionicotaku/lingo-services-feed/internal/tasks/profile_inbox"
 )
 
 // ProviderSet 暴露配置加载相关的依赖注入入口。
@@ -32,6 +40,30 @@ var ProviderSet = wire.NewSet(
 	ProvidePubSubDependencies,
 	ProvideOutboxConfig,
 	ProvideHandlerTimeouts,
+	ProvideFeedServiceConfig,
+	ProvideGuestPolicy,
+	ProvideRateLimitConfig,
+	ProvideAdminAuthPolicy,
+	ProvideRecommendationLogWriterConfig,
+	ProvideLogRetentionConfig,
+	ProvideUserHasher,
+	ProvideRecommendationLogSamplingConfig,
+	ProvideInteractionConfig,
+	ProvideServedEventConfig,
+	ProvideEventTopicConfig,
+	ProvideUserStateConfig,
+	ProvideWatchedFilterConfig,
+	ProvideContinueLearningConfig,
+	ProvideReviewQueueConfig,
+	ProvideBlendingConfig,
+	ProvideRerankConfig,
+	ProvideCurationConfig,
+	ProvideExperimentConfig,
+	ProvideShadowConfig,
+	ProvideRemoteRecommendationConfig,
+	ProvideMockRecommendationConfig,
+	ProvideProfileSubscriptionConfig,
+	ProvideLearningSubscriptionConfig,
 )
 
 // LoadRuntimeConfig 调用 Load 并供 Wire 使用。
@@ -163,6 +195,353 @@ func ProvideHandlerTimeouts(cfg RuntimeConfig) controllers.HandlerTimeouts {
 	}
 }
 
+// ProvideFeedServiceConfig 将 Feed 配置映射为用例层参数，未启用幂等时 TTL 置零。
+func ProvideFeedServiceConfig(cfg RuntimeConfig) services.FeedServiceConfig {
+	result := services.FeedServiceConfig{
+		GuestCacheTTL:  cfg.Feed.Guest.CacheTTL,
+		GuestRateLimit: cfg.Feed.Guest.RateLimitRPS,
+		GuestBurst:     cfg.Feed.Guest.RateLimitBurst,
+	}
+	if cfg.Feed.Idempotency.Enabled {
+		result.IdempotencyTTL = cfg.Feed.Idempotency.TTL
+	}
+	return result
+}
+
+// ProvideRecommendationLogWriterConfig 将推荐日志写入配置映射为用例层参数。
+func ProvideRecommendationLogWriterConfig(cfg RuntimeConfig) services.RecommendationLogWriterConfig {
+	writer := cfg.Feed.LogWriter
+	return services.RecommendationLogWriterConfig{
+		Async:         writer.Async,
+		QueueSize:     writer.QueueSize,
+		BatchSize:     writer.BatchSize,
+		FlushInterval: writer.FlushInterval,
+		FlushTimeout:  writer.FlushTimeout,
+		Overflow:      services.LogOverflowPolicy(writer.Overflow),
+	}
+}
+
+// ProvideLogRetentionConfig 返回推荐日志分区维护任务的配置。
+func ProvideLogRetentionConfig(cfg RuntimeConfig) logretention.Config {
+	retention := cfg.Feed.Retention
+	return logretention.Config{
+		Enabled:     retention.Enabled,
+		Retention:   retention.Retention,
+		PremakeDays: retention.PremakeDays,
+		Interval:    retention.Interval,
+
+		ServedPageRetention: retention.ServedPageRetention,
+	}
+}
+
+// ProvideRecommendationLogSamplingConfig 将推荐日志采样配置映射为用例层参数。
+func ProvideRecommendationLogSamplingConfig(cfg RuntimeConfig) services.RecommendationLogSamplingConfig {
+	sampling := cfg.Feed.Sampling
+	rules := make([]services.RecommendationLogSamplingRule, 0, len(sampling.Rules))
+	for _, rule := range sampling.Rules {
+		rules = append(rules, services.RecommendationLogSamplingRule{
+			Source: rule.Source,
+			Scene:  rule.Scene,
+			Rate:   rule.Rate,
+		})
+	}
+	return services.RecommendationLogSamplingConfig{
+		Enabled:      sampling.Enabled,
+		DefaultRate:  sampling.DefaultRate,
+		Rules:        rules,
+		DebugUserIDs: sampling.DebugUserIDs,
+	}
+}
+
+// ProvideInteractionConfig 将交互事件上报配置映射为用例层参数。
+func ProvideInteractionConfig(cfg RuntimeConfig) services.InteractionConfig {
+	interactions := cfg.Feed.Interactions
+	return services.InteractionConfig{
+		Enabled:      interactions.Enabled,
+		MaxBatchSize: interactions.MaxBatchSize,
+		MaxEventAge:  interactions.MaxEventAge,
+		ClockSkew:    interactions.ClockSkew,
+	}
+}
+
+// ProvideServedEventConfig 将 served 事件开关映射为用例层参数。
+func ProvideServedEventConfig(cfg RuntimeConfig) services.ServedEventConfig {
+	return services.ServedEventConfig{Enabled: cfg.Feed.ServedEvents.Enabled}
+}
+
+// ProvideEventTopicConfig 返回 Feed 事件发布目标 Topic，键由 feed.interactions.topic 指定。
+func ProvideEventTopicConfig(cfg RuntimeConfig) outboxpublisher.TopicConfig {
+	name := cfg.Feed.Interactions.Topic
+	return outboxpublisher.TopicConfig{
+		Name:   name,
+		PubSub: toGCPubSubConfig(cfg.Messaging.Topics[name]),
+	}
+}
+
+// ProvideUserStateConfig 将用户状态补水开关映射为用例层参数。
+func ProvideUserStateConfig(cfg RuntimeConfig) services.UserStateConfig {
+	return services.UserStateConfig{Enabled: cfg.Feed.UserState.Enabled}
+}
+
+// ProvideWatchedFilterConfig 将观看过滤阈值映射为用例层参数，场景覆盖未设置的字段沿用默认值。
+func ProvideWatchedFilterConfig(cfg RuntimeConfig) services.WatchedFilterConfig {
+	watched := cfg.Feed.Watched
+	out := services.WatchedFilterConfig{
+		Enabled: watched.Enabled,
+		Default: services.WatchedThresholds{DropRatio: watched.DropRatio, DemoteRatio: watched.DemoteRatio},
+	}
+	if len(watched.Scenes) > 0 {
+		out.Scenes = make(map[string]services.WatchedThresholds, len(watched.Scenes))
+	}
+	for _, scene := range watched.Scenes {
+		thresholds := out.Default
+		if scene.DropRatio != nil {
+			thresholds.DropRatio = *scene.DropRatio
+		}
+		if scene.DemoteRatio != nil {
+			thresholds.DemoteRatio = *scene.DemoteRatio
+		}
+		out.Scenes[scene.Scene] = thresholds
+	}
+	return out
+}
+
+// ProvideContinueLearningConfig 将 continue_learning 场景配置映射为用例层参数。
+func ProvideContinueLearningConfig(cfg RuntimeConfig) services.ContinueLearningConfig {
+	cont := cfg.Feed.Continue
+	return services.ContinueLearningConfig{
+		Enabled:  cont.Enabled,
+		MinRatio: cont.MinRatio,
+		MaxRatio: cont.MaxRatio,
+	}
+}
+
+// ProvideReviewQueueConfig 将复习队列与首页混排比例映射为用例层参数。
+func ProvideReviewQueueConfig(cfg RuntimeConfig) services.ReviewQueueConfig {
+	review := cfg.Feed.Review
+	return services.ReviewQueueConfig{
+		Enabled:       review.Enabled,
+		BlendFraction: review.BlendFraction,
+	}
+}
+
+// ProvideBlendingConfig 将首页混排配置映射为用例层参数。
+func ProvideBlendingConfig(cfg RuntimeConfig) services.BlendingConfig {
+	blending := cfg.Feed.Blending
+	return services.BlendingConfig{
+		Enabled:  blending.Enabled,
+		Strategy: services.BlendStrategy(blending.Strategy),
+		Sources:  toServiceBlendingSources(blending.Sources),
+	}
+}
+
+func toServiceBlendingSources(sources []BlendingSourceConfig) []services.BlendingSourceConfig {
+	out := make([]services.BlendingSourceConfig, 0, len(sources))
+	for _, src := range sources {
+		out = append(out, services.BlendingSourceConfig{
+			Name:   src.Name,
+			Weight: src.Weight,
+			Budget: src.Budget,
+		})
+	}
+	return out
+}
+
+// ProvideRerankConfig 将多样性重排配置映射为用例层参数。
+func ProvideRerankConfig(cfg RuntimeConfig) services.RerankConfig {
+	return toServiceRerankConfig(cfg.Feed.Rerank)
+}
+
+func toServiceRerankConfig(rerank RerankConfig) services.RerankConfig {
+	return services.RerankConfig{
+		MMR: services.MMRConfig{
+			Enabled: rerank.MMREnabled,
+			Lambda:  rerank.MMRLambda,
+		},
+		CreatorCap: services.CreatorCapConfig{
+			Enabled:       rerank.CreatorCapEnabled,
+			MaxPerCreator: rerank.MaxPerCreator,
+		},
+		LanguageRun: services.LanguageRunConfig{
+			Enabled:        rerank.LanguageRunEnabled,
+			MaxConsecutive: rerank.MaxLanguageRun,
+		},
+		DurationSpread: services.DurationSpreadConfig{
+			Enabled:        rerank.DurationSpreadEnabled,
+			Boundaries:     rerank.DurationBoundaries,
+			MaxConsecutive: rerank.MaxDurationRun,
+		},
+	}
+}
+
+// ProvideExperimentConfig 将 A/B 实验配置映射为用例层参数。
+func ProvideExperimentConfig(cfg RuntimeConfig) services.ExperimentConfig {
+	experiments := cfg.Feed.Experiments
+	out := services.ExperimentConfig{
+		Enabled:             experiments.Enabled,
+		AllowForcedVariants: experiments.AllowForcedVariants,
+		Experiments:         make([]services.ExperimentDefinition, 0, len(experiments.Experiments)),
+	}
+	for _, exp := range experiments.Experiments {
+		def := services.ExperimentDefinition{
+			Key:      exp.Key,
+			Salt:     exp.Salt,
+			Variants: make([]services.ExperimentVariantConfig, 0, len(exp.Variants)),
+		}
+		for _, variant := range exp.Variants {
+			vc := services.ExperimentVariantConfig{
+				Name:     variant.Name,
+				Weight:   variant.Weight,
+				Provider: variant.Provider,
+			}
+			if len(variant.BlendSources) > 0 {
+				vc.BlendSources = toServiceBlendingSources(variant.BlendSources)
+			}
+			if variant.Rerank != nil {
+				rerank := toServiceRerankConfig(*variant.Rerank)
+				vc.Rerank = &rerank
+			}
+			def.Variants = append(def.Variants, vc)
+		}
+		out.Experiments = append(out.Experiments, def)
+	}
+	return out
+}
+
+// ProvideShadowConfig 将影子流量配置映射为用例层参数。
+func ProvideShadowConfig(cfg RuntimeConfig) services.ShadowConfig {
+	return services.ShadowConfig{
+		Enabled:        cfg.Feed.Shadow.Enabled,
+		Candidate:      cfg.Feed.Shadow.Candidate,
+		Budget:         cfg.Feed.Shadow.Budget,
+		MaxConcurrency: cfg.Feed.Shadow.MaxConcurrency,
+		SampleRate:     cfg.Feed.Shadow.SampleRate,
+	}
+}
+
+// ProvideMockRecommendationConfig 将 Mock 推荐的种子配置映射为用例层参数。
+func ProvideMockRecommendationConfig(cfg RuntimeConfig) services.MockRecommendationConfig {
+	return services.MockRecommendationConfig{
+		Seed:  cfg.Feed.Mock.Seed,
+		Fixed: cfg.Feed.Mock.Fixed,
+	}
+}
+
+// ProvideRemoteRecommendationConfig 将外部推荐服务客户端配置映射为用例层参数。
+func ProvideRemoteRecommendationConfig(cfg RuntimeConfig) services.RemoteRecommendationConfig {
+	remote := cfg.Feed.Remote
+	return services.RemoteRecommendationConfig{
+		Enabled: remote.Enabled,
+		Primary: remote.Primary,
+		Targets: remote.Targets,
+		Timeout: remote.Timeout,
+		Hedge: services.RemoteHedgeConfig{
+			Enabled:      remote.HedgeEnabled,
+			Percentile:   remote.HedgePercentile,
+			InitialDelay: remote.HedgeInitialDelay,
+			MinDelay:     remote.HedgeMinDelay,
+			Window:       remote.HedgeWindow,
+		},
+		Breaker: services.RemoteBreakerConfig{
+			Enabled:          remote.BreakerEnabled,
+			FailureThreshold: remote.BreakerFailureThreshold,
+			OpenDuration:     remote.BreakerOpenDuration,
+			HalfOpenProbes:   remote.BreakerHalfOpenProbes,
+		},
+	}
+}
+
+// ProvideCurationConfig 将运营干预规则配置映射为用例层参数。
+func ProvideCurationConfig(cfg RuntimeConfig) services.CurationConfig {
+	return services.CurationConfig{
+		Enabled:          cfg.Feed.Curation.Enabled,
+		RefreshInterval:  cfg.Feed.Curation.RefreshInterval,
+		ReconnectBackoff: cfg.Feed.Curation.ReconnectBackoff,
+	}
+}
+
+// ProvideProfileSubscriptionConfig 返回 Profile 事件订阅及其 Inbox 配置，键由 feed.user_state.topic / inbox 指定。
+func ProvideProfileSubscriptionConfig(cfg RuntimeConfig) profileinbox.SubscriptionConfig {
+	state := cfg.Feed.UserState
+	inbox := cfg.Messaging.Inboxes[state.Inbox]
+	return profileinbox.SubscriptionConfig{
+		Name:   state.Topic,
+		PubSub: toGCPubSubConfig(cfg.Messaging.Topics[state.Topic]),
+		Inbox: outboxcfg.InboxConfig{
+			SourceService:  inbox.SourceService,
+			MaxConcurrency: inbox.MaxConcurrency,
+			LoggingEnabled: inbox.LoggingEnabled,
+			MetricsEnabled: inbox.MetricsEnabled,
+		},
+	}
+}
+
+// ProvideLearningSubscriptionConfig 返回 Learning 事件订阅及其 Inbox 配置，键由 feed.review_queue.topic / inbox 指定。
+func ProvideLearningSubscriptionConfig(cfg RuntimeConfig) learninginbox.SubscriptionConfig {
+	review := cfg.Feed.Review
+	inbox := cfg.Messaging.Inboxes[review.Inbox]
+	return learninginbox.SubscriptionConfig{
+		Name:   review.Topic,
+		PubSub: toGCPubSubConfig(cfg.Messaging.Topics[review.Topic]),
+		Inbox: outboxcfg.InboxConfig{
+			SourceService:  inbox.SourceService,
+			MaxConcurrency: inbox.MaxConcurrency,
+			LoggingEnabled: inbox.LoggingEnabled,
+			MetricsEnabled: inbox.MetricsEnabled,
+		},
+	}
+}
+
+// ProvideUserHasher 构造推荐日志使用的用户标识假名化器；未配置密钥时返回 nil。
+// 配置了 secret_env 但环境变量为空时启动失败，避免误用仓库中可见的密钥写入哈希。
+func ProvideUserHasher(cfg RuntimeConfig) (*pseudonym.Hasher, error) {
+	pc := cfg.Feed.Pseudonym
+	keys := make([]pseudonym.Key, 0, len(pc.Keys))
+	for _, key := range pc.Keys {
+		if key.SecretEnv != "" && key.Secret == "" {
+			return nil, fmt.Errorf("pseudonymization key %q: environment variable %s is empty", key.Version, key.SecretEnv)
+		}
+		keys = append(keys, pseudonym.Key{Version: key.Version, Secret: []byte(key.Secret)})
+	}
+	return pseudonym.New(pseudonym.Config{ActiveVersion: pc.ActiveKeyVersion, Keys: keys})
+}
+
+// ProvideGuestPolicy 返回控制层使用的访客准入策略。
+func ProvideGuestPolicy(cfg RuntimeConfig) controllers.GuestPolicy {
+	return controllers.GuestPolicy{Enabled: cfg.Feed.Guest.Enabled}
+}
+
+// ProvideAdminAuthPolicy 返回管理端 RPC 的鉴权策略。
+func ProvideAdminAuthPolicy(cfg RuntimeConfig) controllers.AdminAuthPolicy {
+	admin := cfg.Server.Admin
+	return controllers.AdminAuthPolicy{
+		Enabled:   admin.Enabled,
+		Audience:  admin.Audience,
+		HeaderKey: firstNonEmpty(cfg.Server.JWT.HeaderKey, "authorization"),
+	}
+}
+
+// ProvideRateLimitConfig 将 Server 层限流配置映射为 ratelimiter 使用的策略。
+func ProvideRateLimitConfig(cfg RuntimeConfig) ratelimiter.Config {
+	rl := cfg.Server.RateLimit
+	out := ratelimiter.Config{
+		Enabled: rl.Enabled,
+		Backend: rl.Backend,
+		Default: ratelimiter.Rule{RPS: rl.DefaultRPS, Burst: rl.DefaultBurst},
+	}
+	if cfg.Feed.Guest.Enabled {
+		out.Guest = ratelimiter.Rule{RPS: cfg.Feed.Guest.DeviceRPS, Burst: cfg.Feed.Guest.DeviceBurst}
+	}
+	for _, rule := range rl.Rules {
+		out.Rules = append(out.Rules, ratelimiter.ScopedRule{
+			Method: rule.Method,
+			Scene:  rule.Scene,
+			Rule:   ratelimiter.Rule{RPS: rule.RPS, Burst: rule.Burst},
+		})
+	}
+	return out
+}
+
 // ProvideJWTConfig 汇总客户端与服务端 JWT 配置。
 func ProvideJWTConfig(cfg RuntimeConfig) gcjwt.Config {
 	var serverCfg *gcjwt.ServerConfig
@@ -175,8 +554,9 @@ func ProvideJWTConfig(cfg RuntimeConfig) gcjwt.Config {
 		}
 	}
 
+	// 外部推荐服务客户端复用 data.grpc_client 的出站 JWT 设置。
 	var clientCfg *gcjwt.ClientConfig
-	if cfg.GRPCClient.Target != "" {
+	if cfg.GRPCClient.Target != "" || cfg.Feed.Remote.Enabled {
 		clientCfg = &gcjwt.ClientConfig{
 			Audience:  cfg.GRPCClient.JWT.Audience,
 			Disabled:  cfg.GRPCClient.JWT.Disabled,
@@ -51,6 +51,24 @@ func NewGRPCClient(cfg configloader.GRPCClientConfig, metricsCfg *observability.
 		return nil, func() {}, nil
 	}
 
+	conn, err := Dial(cfg.Target, cfg, metricsCfg, jwt, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cleanup := func() {
+		if err := conn.Close(); err != nil {
+			helper.Errorf("close grpc client: %v", err)
+		}
+	}
+
+	return conn, cleanup, nil
+}
+
+// Dial 按 cfg 的 metadata 传播与 JWT 设置拨号到 target，中间件链与 NewGRPCClient 相同。
+// withBreaker 为 false 时不挂载 kratos 自适应熔断，供自行维护熔断状态的调用方（如推荐客户端）使用，
+// 避免两层熔断叠加。连接由调用方负责关闭。
+func Dial(target string, cfg configloader.GRPCClientConfig, metricsCfg *observability.MetricsConfig, jwt gcjwt.ClientMiddleware, withBreaker bool) (*grpc.ClientConn, error) {
 	// metricsCfg 为可选参数，默认启用指标采集以保持向后兼容
 	metricsEnabled := true
 	includeHealth := false
@@ -69,31 +87,20 @@ func NewGRPCClient(cfg configloader.GRPCClientConfig, metricsCfg *observability.
 		mws = append(mws, middleware.Middleware(jwt))
 	}
 	// 追踪与熔断保留原顺序，保证链路观测与保护能力。
-	mws = append(mws,
-		obsTrace.Client(),
-		circuitbreaker.Client(),
-	)
+	mws = append(mws, obsTrace.Client())
+	if withBreaker {
+		mws = append(mws, circuitbreaker.Client())
+	}
 
 	opts := []kgrpc.ClientOption{
-		kgrpc.WithEndpoint(cfg.Target),
+		kgrpc.WithEndpoint(target),
 		kgrpc.WithMiddleware(mws...),
 	}
 	if metricsEnabled {
 		opts = append(opts, kgrpc.WithOptions(grpc.WithStatsHandler(newClientHandler(includeHealth))))
 	}
 
-	conn, err := kgrpc.DialInsecure(context.Background(), opts...)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	cleanup := func() {
-		if err := conn.Close(); err != nil {
-			helper.Errorf("close grpc client: %v", err)
-		}
-	}
-
-	return conn, cleanup, nil
+	return kgrpc.DialInsecure(context.Background(), opts...)
 }
 
 // newClientHandler 构造 gRPC Client 的 OpenTelemetry StatsHandler。
@@ -0,0 +1,39 @@
+package grpcserver
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/controllers"
+	"github.com/bionicotaku/lingo-utils/gcjwt"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// NewAdminTokenVerifier 为管理端构造独立的 gcjwt 服务端校验：Token 必须存在、签名有效且 aud 为管理端 audience。
+//
+// 公共 JWT 中间件可配置为跳过校验或可选，管理端不复用其配置；未启用管理端时返回 nil。
+func NewAdminTokenVerifier(policy controllers.AdminAuthPolicy, logger log.Logger) (controllers.AdminTokenVerifier, func(), error) {
+	noop := func() {}
+	if !policy.Enabled {
+		return nil, noop, nil
+	}
+	if policy.Audience == "" {
+		return nil, noop, errors.New("server.admin.audience is required when admin is enabled")
+	}
+	component, cleanup, err := gcjwt.NewComponent(gcjwt.Config{
+		Server: &gcjwt.ServerConfig{
+			ExpectedAudience: policy.Audience,
+			Required:         true,
+			HeaderKey:        policy.HeaderKey,
+		},
+	}, logger)
+	if err != nil {
+		return nil, noop, fmt.Errorf("init admin token verifier: %w", err)
+	}
+	verifier, err := gcjwt.ProvideServerMiddleware(component)
+	if err != nil {
+		cleanup()
+		return nil, noop, fmt.Errorf("init admin token verifier: %w", err)
+	}
+	return controllers.AdminTokenVerifier(verifier), cleanup, nil
+}
@@ -3,6 +3,7 @@
 package grpcserver
 
 import (
+	adminv1 "github.com/bionicotaku/lingo-services-feed/api/feed/admin/v1"
 	feedv1 "github.com/bionicotaku/lingo-services-feed/api/feed/v1"
 	"github.com/bionicotaku/lingo-services-feed/internal/controllers"
 	configloader "github.com/bionicotaku/lingo-services-feed/internal/infrastructure/configloader"
@@ -31,9 +32,14 @@ import (
 // 1. obsTrace.Server() - OpenTelemetry 追踪，自动创建 Span
 // 2. recovery.Recovery() - Panic 恢复，防止服务崩溃
 // 3. metadata.Server() - 元数据传播，转发 x-template- 前缀的 header
-// 4. ratelimit.Server() - 限流保护
-// 5. pvmw.Server() - protovalidate 运行时参数校验（基于反射，无需代码生成）
-// 6. logging.Server() - 结构化日志记录（含 trace_id/span_id）
+// 4. jwt - 可选的 JWT 校验
+// 5. adminAuth - 管理端 RPC 的签名 Token 校验（aud 为管理端 audience），仅作用于 feed.admin.v1
+// 6. rateLimit - 可选的按用户令牌桶限流（超限返回 ResourceExhausted + RetryInfo）
+// 7. ratelimit.Server() - 实例级自适应限流保护
+// 8. pvmw.Server() - protovalidate 运行时参数校验（基于反射，无需代码生成）
+// 9. logging.Server() - 结构化日志记录（含 trace_id/span_id）
+//
+// 管理端 FeedAdminService 仅在 adminAuth 非空（server.admin.enabled）时注册。
 //
 // 可选指标采集：
 // - 根据 metricsCfg.GRPCEnabled 决定是否启用 otelgrpc.StatsHandler
@@ -42,7 +48,10 @@ func NewGRPCServer(
 	cfg configloader.ServerConfig,
 	metricsCfg *observability.MetricsConfig,
 	jwt gcjwt.ServerMiddleware,
+	adminAuth controllers.AdminAuthMiddleware,
+	rateLimit controllers.RateLimitMiddleware,
 	feed *controllers.FeedHandler,
+	admin *controllers.FeedAdminHandler,
 	logger log.Logger,
 ) *grpc.Server {
 	// metricsCfg 为可选参数，默认启用指标采集以保持向后兼容。
@@ -64,6 +73,14 @@ func NewGRPCServer(
 	if jwt != nil {
 		mws = append(mws, middleware.Middleware(jwt))
 	}
+	// 管理端鉴权同样依赖身份信息，未通过时不消耗限流配额。
+	if adminAuth != nil {
+		mws = append(mws, middleware.Middleware(adminAuth))
+	}
+	// 用户级限流依赖身份信息，需位于 JWT 之后。
+	if rateLimit != nil {
+		mws = append(mws, middleware.Middleware(rateLimit))
+	}
 	// 其余中间件保持原有顺序，保护限流、参数校验与结构化日志逻辑。
 	mws = append(mws,
 		ratelimit.Server(),
@@ -91,6 +108,9 @@ func NewGRPCServer(
 	if feed != nil {
 		feedv1.RegisterFeedServiceServer(srv, feed)
 	}
+	if admin != nil && adminAuth != nil {
+		adminv1.RegisterFeedAdminServiceServer(srv, admin)
+	}
 	return srv
 }
 
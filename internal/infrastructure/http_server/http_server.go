@@ -0,0 +1,70 @@
+// Package httpserver 负责装配入站 HTTP/JSON Server。
+// 路由由 feed.proto 中的 google.api.http 注解生成，与 gRPC 共用同一组 Handler 与中间件语义。
+package httpserver
+
+import (
+	feedv1 "github.com/bionicotaku/lingo-services-feed/api/feed/v1"
+	"github.com/bionicotaku/lingo-services-feed/internal/controllers"
+	configloader "github.com/bionicotaku/lingo-services-feed/internal/infrastructure/configloader"
+
+	"github.com/bionicotaku/lingo-utils/gcjwt"
+	obsTrace "github.com/bionicotaku/lingo-utils/observability/tracing"
+	pvmw "github.com/go-kratos-ecosystem/components/v2/middleware/protovalidate"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/middleware/logging"
+	"github.com/go-kratos/kratos/v2/middleware/metadata"
+	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// NewHTTPServer 构造 Kratos HTTP Server，暴露 GET /api/v1/feed 等 REST 路由。
+//
+// 中间件链与 gRPC Server 保持一致（追踪 → 恢复 → 元数据 → JWT → 用户限流 → 校验 → 日志），
+// 错误统一经 EncodeProblem 输出为 application/problem+json。
+//
+// cfg.HTTP.Address 为空时返回 nil，newApp 将跳过注册。
+func NewHTTPServer(
+	cfg configloader.ServerConfig,
+	jwt gcjwt.ServerMiddleware,
+	rateLimit controllers.RateLimitMiddleware,
+	feed *controllers.FeedHandler,
+	logger log.Logger,
+) *http.Server {
+	if cfg.HTTP.Address == "" {
+		return nil
+	}
+
+	mws := []middleware.Middleware{
+		obsTrace.Server(),
+		recovery.Recovery(),
+		metadata.Server(metadata.WithPropagatedPrefix(cfg.MetadataKeys...)),
+	}
+	if jwt != nil {
+		mws = append(mws, middleware.Middleware(jwt))
+	}
+	if rateLimit != nil {
+		mws = append(mws, middleware.Middleware(rateLimit))
+	}
+	mws = append(mws,
+		pvmw.Server(),
+		logging.Server(logger),
+	)
+
+	opts := []http.ServerOption{
+		http.Middleware(mws...),
+		http.ErrorEncoder(EncodeProblem),
+		http.Address(cfg.HTTP.Address),
+	}
+	if cfg.HTTP.Network != "" {
+		opts = append(opts, http.Network(cfg.HTTP.Network))
+	}
+	if cfg.HTTP.Timeout > 0 {
+		opts = append(opts, http.Timeout(cfg.HTTP.Timeout))
+	}
+	srv := http.NewServer(opts...)
+	if feed != nil {
+		feedv1.RegisterFeedServiceHTTPServer(srv, feed)
+	}
+	return srv
+}
@@ -0,0 +1,106 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"math"
+	nethttp "net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+// problemContentType 为 RFC 7807 规定的媒体类型。
+const problemContentType = "application/problem+json"
+
+// problemTypePrefix 与 gRPC ErrorInfo.Reason 的约定前缀一致。
+const problemTypePrefix = "feed.errors."
+
+// Problem 是 RFC 7807 Problem Details 的 JSON 结构。
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// EncodeProblem 将 Handler 或中间件返回的错误编码为 Problem Details JSON。
+//
+// type 取自 gRPC ErrorInfo.Reason；非 Feed 领域错误（如参数校验、JWT）按 HTTP 状态码归入通用类型。
+// 携带 RetryInfo 的错误额外输出 Retry-After 头。
+func EncodeProblem(w nethttp.ResponseWriter, r *nethttp.Request, err error) {
+	se := errors.FromError(err)
+	code := int(se.Code)
+	if code < 400 || code > 599 {
+		code = nethttp.StatusInternalServerError
+	}
+	problem := Problem{
+		Type:   problemType(se.Reason, code),
+		Title:  nethttp.StatusText(code),
+		Status: code,
+		Detail: se.Message,
+	}
+	if code == nethttp.StatusInternalServerError && !strings.HasPrefix(se.Reason, problemTypePrefix) {
+		// 未经领域映射的 500 可能包含底层细节，统一收敛。
+		problem.Detail = "internal error"
+	}
+	if r != nil && r.URL != nil {
+		problem.Instance = r.URL.Path
+	}
+	if seconds := retryAfterSeconds(err); seconds > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	}
+	body, marshalErr := json.Marshal(problem)
+	if marshalErr != nil {
+		w.WriteHeader(nethttp.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+}
+
+func problemType(reason string, code int) string {
+	if strings.HasPrefix(reason, problemTypePrefix) {
+		return reason
+	}
+	switch code {
+	case nethttp.StatusBadRequest:
+		return problemTypePrefix + "invalid_argument"
+	case nethttp.StatusUnauthorized:
+		return problemTypePrefix + "unauthenticated"
+	case nethttp.StatusForbidden:
+		return problemTypePrefix + "permission_denied"
+	case nethttp.StatusNotFound:
+		return problemTypePrefix + "not_found"
+	case nethttp.StatusTooManyRequests:
+		return problemTypePrefix + "resource_exhausted"
+	case nethttp.StatusServiceUnavailable:
+		return problemTypePrefix + "unavailable"
+	case nethttp.StatusGatewayTimeout:
+		return problemTypePrefix + "deadline_exceeded"
+	default:
+		return problemTypePrefix + "internal"
+	}
+}
+
+// retryAfterSeconds 读取 gRPC status 中的 RetryInfo，向上取整为秒。
+func retryAfterSeconds(err error) int {
+	st, ok := status.FromError(err)
+	if !ok {
+		return 0
+	}
+	for _, detail := range st.Details() {
+		if retry, ok := detail.(*errdetails.RetryInfo); ok && retry.GetRetryDelay() != nil {
+			delay := retry.GetRetryDelay().AsDuration()
+			if delay <= 0 {
+				return 0
+			}
+			return int(math.Ceil(delay.Seconds()))
+		}
+	}
+	return 0
+}
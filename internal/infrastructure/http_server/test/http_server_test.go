@@ -0,0 +1,196 @@
+package httpserver_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	controllers "github.com/bionicotaku/lingo-services-feed/internal/controllers"
+	configloader "github.com/bionicotaku/lingo-services-feed/internal/infrastructure/configloader"
+	httpserver "github.com/bionicotaku/lingo-services-feed/internal/infrastructure/http_server"
+	"github.com/bionicotaku/lingo-services-feed/internal/infrastructure/ratelimiter"
+	"github.com/bionicotaku/lingo-services-feed/internal/models/vo"
+	"github.com/bionicotaku/lingo-services-feed/internal/services"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+)
+
+type stubFeedService struct {
+	response *vo.FeedResponse
+	err      error
+	input    services.GetFeedInput
+
+	interactions      *services.ReportInteractionsResult
+	interactionsInput services.ReportInteractionsInput
+}
+
+func (s *stubFeedService) GetFeed(_ context.Context, input services.GetFeedInput) (*vo.FeedResponse, error) {
+	s.input = input
+	return s.response, s.err
+}
+
+func (s *stubFeedService) ReportInteractions(_ context.Context, input services.ReportInteractionsInput) (*services.ReportInteractionsResult, error) {
+	s.interactionsInput = input
+	return s.interactions, s.err
+}
+
+func newServer(t *testing.T, service *stubFeedService) http.Handler {
+	t.Helper()
+	return newServerWithRateLimit(t, service, nil)
+}
+
+func newServerWithRateLimit(t *testing.T, service *stubFeedService, rateLimit controllers.RateLimitMiddleware) http.Handler {
+	t.Helper()
+	logger := log.NewStdLogger(io.Discard)
+	handler := controllers.NewFeedHandler(service, controllers.NewBaseHandler(controllers.HandlerTimeouts{}), controllers.GuestPolicy{}, logger)
+	srv := httpserver.NewHTTPServer(configloader.ServerConfig{
+		HTTP:         configloader.HTTPServerConfig{Address: "127.0.0.1:0"},
+		MetadataKeys: []string{"x-apigateway-api-userinfo", "x-md-"},
+	}, nil, rateLimit, handler, logger)
+	require.NotNil(t, srv)
+	return srv
+}
+
+func TestHTTPServer_GetFeed(t *testing.T) {
+	service := &stubFeedService{response: &vo.FeedResponse{
+		Items:       []vo.FeedItem{{VideoID: "v1", Title: "Video 1"}},
+		GeneratedAt: time.Now(),
+	}}
+	srv := newServer(t, service)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/feed?limit=3", nil)
+	req.Header.Set("X-Apigateway-Api-Userinfo", encodeUserInfo(t, map[string]any{"sub": "user-1"}))
+	req.Header.Set("X-Md-Idempotency-Key", "retry-1")
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "user-1", service.input.UserID)
+	require.Equal(t, 3, service.input.Limit)
+	require.Equal(t, "retry-1", service.input.IdempotencyKey)
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	items, ok := body["items"].([]any)
+	require.True(t, ok)
+	require.Len(t, items, 1)
+}
+
+func TestHTTPServer_GetFeed_ProblemDetails(t *testing.T) {
+	service := &stubFeedService{err: services.ErrRecommendationUnavailable}
+	srv := newServer(t, service)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/feed?limit=3", nil)
+	req.Header.Set("X-Apigateway-Api-Userinfo", encodeUserInfo(t, map[string]any{"sub": "user-2"}))
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	require.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+	require.Equal(t, "1", rec.Header().Get("Retry-After"))
+
+	var problem httpserver.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	require.Equal(t, "feed.errors.recommendation_unavailable", problem.Type)
+	require.Equal(t, http.StatusServiceUnavailable, problem.Status)
+	require.Equal(t, "/api/v1/feed", problem.Instance)
+}
+
+func TestHTTPServer_GetFeed_Unauthenticated(t *testing.T) {
+	srv := newServer(t, &stubFeedService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/feed?limit=3", nil)
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	var problem httpserver.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	require.Equal(t, "feed.errors.unauthenticated", problem.Type)
+}
+
+func TestHTTPServer_GetFeed_BindProblem(t *testing.T) {
+	srv := newServer(t, &stubFeedService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/feed?limit=abc", nil)
+	req.Header.Set("X-Apigateway-Api-Userinfo", encodeUserInfo(t, map[string]any{"sub": "user-3"}))
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	var problem httpserver.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	require.Equal(t, "feed.errors.invalid_argument", problem.Type)
+}
+
+func TestHTTPServer_GetFeed_RateLimited(t *testing.T) {
+	logger := log.NewStdLogger(io.Discard)
+	limiter := ratelimiter.NewLimiterWithStore(ratelimiter.Config{
+		Enabled: true,
+		Default: ratelimiter.Rule{RPS: 0.5, Burst: 1},
+	}, ratelimiter.NewMemoryStore(nil), ratelimiter.BackendMemory, logger)
+	service := &stubFeedService{response: &vo.FeedResponse{GeneratedAt: time.Now()}}
+	srv := newServerWithRateLimit(t, service, controllers.NewRateLimitMiddleware(limiter))
+
+	send := func(sub string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/feed?limit=3", nil)
+		req.Header.Set("X-Apigateway-Api-Userinfo", encodeUserInfo(t, map[string]any{"sub": sub}))
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+
+	require.Equal(t, http.StatusOK, send("user-4").Code)
+
+	rec := send("user-4")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.Equal(t, "2", rec.Header().Get("Retry-After"))
+	var problem httpserver.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	require.Equal(t, "feed.errors.rate_limited", problem.Type)
+
+	// 不同用户各自持有令牌桶。
+	require.Equal(t, http.StatusOK, send("user-5").Code)
+}
+
+func TestHTTPServer_ReportInteractions(t *testing.T) {
+	service := &stubFeedService{interactions: &services.ReportInteractionsResult{
+		Results:  []services.InteractionResult{{EventID: "e1", Status: services.InteractionDuplicate}},
+		Accepted: 0,
+	}}
+	srv := newServer(t, service)
+
+	body := `{"events":[{"event_id":"e1","type":"INTERACTION_TYPE_IMPRESSION","log_id":"l1","video_id":"v1","position":1,"occurred_at":"2026-03-01T08:00:00Z"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/feed/interactions", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Apigateway-Api-Userinfo", encodeUserInfo(t, map[string]any{"sub": "user-6"}))
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "user-6", service.interactionsInput.UserID)
+	require.Len(t, service.interactionsInput.Events, 1)
+	require.Equal(t, services.InteractionImpression, service.interactionsInput.Events[0].Type)
+	require.Equal(t, "v1", service.interactionsInput.Events[0].VideoID)
+
+	var resp map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	results, ok := resp["results"].([]any)
+	require.True(t, ok)
+	require.Len(t, results, 1)
+	require.Equal(t, "INTERACTION_STATUS_DUPLICATE", results[0].(map[string]any)["status"])
+}
+
+func encodeUserInfo(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
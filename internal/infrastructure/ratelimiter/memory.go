@@ -0,0 +1,72 @@
+package ratelimiter
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryIdleTTL 为空闲令牌桶的保留时长，超过后在下次清扫时回收。
+const memoryIdleTTL = 10 * time.Minute
+
+type memoryBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// MemoryStore 是进程内令牌桶实现，多实例部署时各实例独立计数。
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+// NewMemoryStore 构造进程内存储，now 为空时使用 time.Now。
+func NewMemoryStore(now func() time.Time) *MemoryStore {
+	if now == nil {
+		now = time.Now
+	}
+	return &MemoryStore{
+		buckets: make(map[string]*memoryBucket),
+		now:     now,
+	}
+}
+
+// Take 惰性补充令牌并尝试扣减一个。
+func (s *MemoryStore) Take(_ context.Context, key string, rule Rule) (Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweepLocked(now)
+
+	burst := float64(rule.Burst)
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &memoryBucket{tokens: burst, updated: now}
+		s.buckets[key] = bucket
+	} else if elapsed := now.Sub(bucket.updated); elapsed > 0 {
+		bucket.tokens = min(burst, bucket.tokens+elapsed.Seconds()*rule.RPS)
+		bucket.updated = now
+	}
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return Decision{Allowed: true}, nil
+	}
+	return Decision{Allowed: false, RetryAfter: retryAfter(bucket.tokens, rule)}, nil
+}
+
+// sweepLocked 定期回收空闲令牌桶，防止用户键无限增长。
+func (s *MemoryStore) sweepLocked(now time.Time) {
+	if now.Sub(s.lastSweep) < memoryIdleTTL {
+		return
+	}
+	s.lastSweep = now
+	for key, bucket := range s.buckets {
+		if now.Sub(bucket.updated) > memoryIdleTTL {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package ratelimiter
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	noopmetric "go.opentelemetry.io/otel/metric/noop"
+)
+
+const (
+	resultAllowed  = "allowed"
+	resultRejected = "rejected"
+	resultError    = "error"
+)
+
+type limiterMetrics struct {
+	requests metric.Int64Counter
+	enabled  bool
+}
+
+func newLimiterMetrics() *limiterMetrics {
+	meterProvider := otel.GetMeterProvider()
+	if meterProvider == nil {
+		meterProvider = noopmetric.NewMeterProvider()
+	}
+	meter := meterProvider.Meter("lingo-services-feed.ratelimiter")
+
+	requests, err := meter.Int64Counter("feed_ratelimit_requests_total", metric.WithDescription("Number of per-user rate limit decisions by result"))
+	if err != nil {
+		return &limiterMetrics{}
+	}
+	return &limiterMetrics{requests: requests, enabled: true}
+}
+
+func (m *limiterMetrics) record(ctx context.Context, method, scene, backend, result string) {
+	if m == nil || !m.enabled {
+		return
+	}
+	m.requests.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("method", method),
+		attribute.String("scene", scene),
+		attribute.String("backend", backend),
+		attribute.String("result", result),
+	))
+}
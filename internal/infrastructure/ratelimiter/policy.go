@@ -0,0 +1,144 @@
+// Package ratelimiter 提供按用户维度的令牌桶限流，支持进程内与 Postgres 共享两种存储。
+// 速率按 方法 → 场景 逐级覆盖，拒绝时由控制层映射为 ResourceExhausted + RetryInfo。
+package ratelimiter
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// BackendMemory 表示进程内令牌桶，仅对单实例生效。
+	BackendMemory = "memory"
+	// BackendPostgres 表示基于 feed.rate_limit_buckets 的共享令牌桶。
+	BackendPostgres = "postgres"
+)
+
+// Rule 描述单个令牌桶的速率与容量，RPS<=0 表示不限流。
+type Rule struct {
+	RPS   float64
+	Burst int
+}
+
+// Unlimited 表示该规则不做限制。
+func (r Rule) Unlimited() bool {
+	return r.RPS <= 0
+}
+
+// ScopedRule 将规则绑定到指定方法与场景；Scene 为空表示该方法下的所有场景。
+type ScopedRule struct {
+	Method string
+	Scene  string
+	Rule   Rule
+}
+
+// Config 汇总限流开关、存储后端与速率策略。
+type Config struct {
+	Enabled bool
+	Backend string
+	Default Rule
+	Rules   []ScopedRule
+	// Guest 为访客 Feed 按访客 ID 计数的规则，与按方法/场景的规则相互独立；RPS<=0 表示不单独限制。
+	Guest Rule
+}
+
+// Policy 按方法与场景解析生效的规则。
+type Policy struct {
+	defaults Rule
+	methods  map[string]Rule
+	scenes   map[string]Rule
+	guest    Rule
+}
+
+// NewPolicy 根据配置构造策略，Burst 缺省时取 RPS 向上取整。
+func NewPolicy(cfg Config) Policy {
+	policy := Policy{
+		defaults: normalizeRule(cfg.Default),
+		methods:  make(map[string]Rule),
+		scenes:   make(map[string]Rule),
+		guest:    normalizeRule(cfg.Guest),
+	}
+	for _, scoped := range cfg.Rules {
+		method := strings.TrimSpace(scoped.Method)
+		if method == "" {
+			continue
+		}
+		rule := normalizeRule(scoped.Rule)
+		scene := strings.TrimSpace(scoped.Scene)
+		if scene == "" {
+			policy.methods[method] = rule
+			continue
+		}
+		policy.scenes[sceneKey(method, scene)] = rule
+	}
+	return policy
+}
+
+// Resolve 返回 method/scene 对应的规则，优先级：方法+场景 > 方法 > 默认。
+func (p Policy) Resolve(method, scene string) Rule {
+	rule, _ := p.ResolveScope(method, scene)
+	return rule
+}
+
+// ResolveScope 返回生效规则及其作用的场景：仅当命中场景级规则时返回该场景，否则返回空串。
+// 场景取值来自客户端，调用方应以返回的场景而非原始输入构造桶键与指标标签，避免未知场景绕过限流或放大基数。
+func (p Policy) ResolveScope(method, scene string) (Rule, string) {
+	if scene != "" {
+		if rule, ok := p.scenes[sceneKey(method, scene)]; ok {
+			return rule, scene
+		}
+	}
+	if rule, ok := p.methods[method]; ok {
+		return rule, ""
+	}
+	return p.defaults, ""
+}
+
+// GuestRule 返回访客 Feed 按访客 ID 计数的规则。
+func (p Policy) GuestRule() Rule {
+	return p.guest
+}
+
+// RefillWindow 返回所有规则中令牌从零补满所需的最长时间。空闲超过该时长的桶已补满，
+// 与不存在的桶等价，可安全删除。
+func (p Policy) RefillWindow() time.Duration {
+	var window time.Duration
+	for _, rule := range p.rules() {
+		if rule.Unlimited() {
+			continue
+		}
+		if d := time.Duration(float64(rule.Burst) / rule.RPS * float64(time.Second)); d > window {
+			window = d
+		}
+	}
+	return window
+}
+
+func (p Policy) rules() []Rule {
+	rules := make([]Rule, 0, 2+len(p.methods)+len(p.scenes))
+	rules = append(rules, p.defaults, p.guest)
+	for _, rule := range p.methods {
+		rules = append(rules, rule)
+	}
+	for _, rule := range p.scenes {
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func normalizeRule(rule Rule) Rule {
+	if rule.RPS <= 0 {
+		return Rule{}
+	}
+	if rule.Burst <= 0 {
+		rule.Burst = int(rule.RPS)
+		if float64(rule.Burst) < rule.RPS {
+			rule.Burst++
+		}
+	}
+	return rule
+}
+
+func sceneKey(method, scene string) string {
+	return method + "|" + scene
+}
@@ -0,0 +1,33 @@
+package ratelimiter
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+)
+
+// PostgresStore 基于 feed.rate_limit_buckets 的共享令牌桶，多实例间共享配额。
+type PostgresStore struct {
+	repo *repositories.RateLimitRepository
+}
+
+// NewPostgresStore 构造共享存储。
+func NewPostgresStore(repo *repositories.RateLimitRepository) *PostgresStore {
+	return &PostgresStore{repo: repo}
+}
+
+// Take 通过单条 upsert 原子地补充并扣减令牌。
+func (s *PostgresStore) Take(ctx context.Context, key string, rule Rule) (Decision, error) {
+	if s == nil || s.repo == nil {
+		return Decision{}, errors.New("rate limit repository not configured")
+	}
+	take, err := s.repo.Take(ctx, nil, key, rule.RPS, rule.Burst)
+	if err != nil {
+		return Decision{}, err
+	}
+	if take.Allowed {
+		return Decision{Allowed: true}, nil
+	}
+	return Decision{Allowed: false, RetryAfter: retryAfter(take.Tokens, rule)}, nil
+}
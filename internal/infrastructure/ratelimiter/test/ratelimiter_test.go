@@ -0,0 +1,202 @@
+package ratelimiter_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/infrastructure/ratelimiter"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestPolicy_Resolve(t *testing.T) {
+	policy := ratelimiter.NewPolicy(ratelimiter.Config{
+		Default: ratelimiter.Rule{RPS: 5, Burst: 10},
+		Rules: []ratelimiter.ScopedRule{
+			{Method: "GetFeed", Rule: ratelimiter.Rule{RPS: 2}},
+			{Method: "GetFeed", Scene: "home", Rule: ratelimiter.Rule{RPS: 1, Burst: 3}},
+		},
+	})
+
+	require.Equal(t, ratelimiter.Rule{RPS: 1, Burst: 3}, policy.Resolve("GetFeed", "home"))
+	require.Equal(t, ratelimiter.Rule{RPS: 2, Burst: 2}, policy.Resolve("GetFeed", "search"))
+	require.Equal(t, ratelimiter.Rule{RPS: 2, Burst: 2}, policy.Resolve("GetFeed", ""))
+	require.Equal(t, ratelimiter.Rule{RPS: 5, Burst: 10}, policy.Resolve("Other", "home"))
+}
+
+func TestPolicy_RefillWindow(t *testing.T) {
+	policy := ratelimiter.NewPolicy(ratelimiter.Config{
+		Default: ratelimiter.Rule{RPS: 5, Burst: 10},
+		Rules: []ratelimiter.ScopedRule{
+			{Method: "GetFeed", Rule: ratelimiter.Rule{RPS: 2}},
+			{Method: "GetFeed", Scene: "home", Rule: ratelimiter.Rule{RPS: 0.5, Burst: 3}},
+		},
+	})
+	require.Equal(t, 6*time.Second, policy.RefillWindow())
+	// 访客规则同样计入补满时长，共享存储中的访客桶不会被提前清理。
+	require.Equal(t, 20*time.Second, ratelimiter.NewPolicy(ratelimiter.Config{Guest: ratelimiter.Rule{RPS: 0.5, Burst: 10}}).RefillWindow())
+	require.Zero(t, ratelimiter.NewPolicy(ratelimiter.Config{}).RefillWindow())
+}
+
+func TestMemoryStore_RefillsOverTime(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+	store := ratelimiter.NewMemoryStore(clock.Now)
+	rule := ratelimiter.Rule{RPS: 2, Burst: 2}
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		decision, err := store.Take(ctx, "user-1", rule)
+		require.NoError(t, err)
+		require.True(t, decision.Allowed)
+	}
+
+	decision, err := store.Take(ctx, "user-1", rule)
+	require.NoError(t, err)
+	require.False(t, decision.Allowed)
+	require.Equal(t, 500*time.Millisecond, decision.RetryAfter)
+
+	clock.Advance(500 * time.Millisecond)
+	decision, err = store.Take(ctx, "user-1", rule)
+	require.NoError(t, err)
+	require.True(t, decision.Allowed)
+
+	decision, err = store.Take(ctx, "user-2", rule)
+	require.NoError(t, err)
+	require.True(t, decision.Allowed, "buckets are isolated per key")
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+	limiter := ratelimiter.NewLimiterWithStore(ratelimiter.Config{
+		Enabled: true,
+		Rules: []ratelimiter.ScopedRule{
+			{Method: "GetFeed", Rule: ratelimiter.Rule{RPS: 1, Burst: 1}},
+		},
+	}, ratelimiter.NewMemoryStore(clock.Now), ratelimiter.BackendMemory, log.NewStdLogger(io.Discard))
+	ctx := context.Background()
+
+	require.True(t, limiter.Allow(ctx, "GetFeed", "", "user:1").Allowed)
+	rejected := limiter.Allow(ctx, "GetFeed", "", "user:1")
+	require.False(t, rejected.Allowed)
+	require.Equal(t, time.Second, rejected.RetryAfter)
+
+	// 未配置规则的方法不限流，空主体直接放行。
+	for i := 0; i < 5; i++ {
+		require.True(t, limiter.Allow(ctx, "Other", "", "user:1").Allowed)
+	}
+	require.True(t, limiter.Allow(ctx, "GetFeed", "", "").Allowed)
+}
+
+func TestLimiter_Allow_UnknownScenesShareMethodBucket(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+	store := &recordingStore{Store: ratelimiter.NewMemoryStore(clock.Now)}
+	limiter := ratelimiter.NewLimiterWithStore(ratelimiter.Config{
+		Enabled: true,
+		Rules: []ratelimiter.ScopedRule{
+			{Method: "GetFeed", Rule: ratelimiter.Rule{RPS: 1, Burst: 1}},
+			{Method: "GetFeed", Scene: "home", Rule: ratelimiter.Rule{RPS: 1, Burst: 1}},
+		},
+	}, store, ratelimiter.BackendMemory, log.NewStdLogger(io.Discard))
+	ctx := context.Background()
+
+	// 更换未配置的场景不会得到新的满桶。
+	require.True(t, limiter.Allow(ctx, "GetFeed", "", "user:1").Allowed)
+	require.False(t, limiter.Allow(ctx, "GetFeed", "made-up-1", "user:1").Allowed)
+	require.False(t, limiter.Allow(ctx, "GetFeed", "made-up-2", "user:1").Allowed)
+
+	// 命中场景级规则的场景使用独立的桶。
+	require.True(t, limiter.Allow(ctx, "GetFeed", "home", "user:1").Allowed)
+	require.False(t, limiter.Allow(ctx, "GetFeed", "home", "user:1").Allowed)
+
+	require.ElementsMatch(t, []string{"GetFeed|user:1", "GetFeed|home|user:1"}, store.distinctKeys())
+}
+
+func TestLimiter_AllowGuest(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1_700_000_000, 0)}
+	store := &recordingStore{Store: ratelimiter.NewMemoryStore(clock.Now)}
+	limiter := ratelimiter.NewLimiterWithStore(ratelimiter.Config{
+		Enabled: true,
+		Rules: []ratelimiter.ScopedRule{
+			{Method: "GetFeed", Rule: ratelimiter.Rule{RPS: 1, Burst: 1}},
+		},
+		Guest: ratelimiter.Rule{RPS: 1, Burst: 2},
+	}, store, ratelimiter.BackendMemory, log.NewStdLogger(io.Discard))
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		allowed, _ := limiter.AllowGuest(ctx, "guest:a")
+		require.True(t, allowed)
+	}
+	allowed, retryAfter := limiter.AllowGuest(ctx, "guest:a")
+	require.False(t, allowed)
+	require.Equal(t, time.Second, retryAfter)
+
+	// 其他访客与同一访客的方法级桶互不影响。
+	allowed, _ = limiter.AllowGuest(ctx, "guest:b")
+	require.True(t, allowed)
+	require.True(t, limiter.Allow(ctx, "GetFeed", "", "guest:a").Allowed)
+	require.ElementsMatch(t, []string{"guest_feed|guest:a", "guest_feed|guest:b", "GetFeed|guest:a"}, store.distinctKeys())
+
+	// 未配置访客规则或限流器未启用时直接放行。
+	unlimited := ratelimiter.NewLimiterWithStore(ratelimiter.Config{Enabled: true}, store, ratelimiter.BackendMemory, log.NewStdLogger(io.Discard))
+	allowed, _ = unlimited.AllowGuest(ctx, "guest:a")
+	require.True(t, allowed)
+	var disabled *ratelimiter.Limiter
+	allowed, _ = disabled.AllowGuest(ctx, "guest:a")
+	require.True(t, allowed)
+}
+
+// recordingStore 记录 Take 使用过的桶键。
+type recordingStore struct {
+	ratelimiter.Store
+	keys []string
+}
+
+func (s *recordingStore) Take(ctx context.Context, key string, rule ratelimiter.Rule) (ratelimiter.Decision, error) {
+	s.keys = append(s.keys, key)
+	return s.Store.Take(ctx, key, rule)
+}
+
+func (s *recordingStore) distinctKeys() []string {
+	seen := make(map[string]struct{})
+	var out []string
+	for _, key := range s.keys {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		out = append(out, key)
+	}
+	return out
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimiter.Rule) (ratelimiter.Decision, error) {
+	return ratelimiter.Decision{}, errors.New("store down")
+}
+
+func TestLimiter_FailsOpen(t *testing.T) {
+	limiter := ratelimiter.NewLimiterWithStore(ratelimiter.Config{
+		Enabled: true,
+		Default: ratelimiter.Rule{RPS: 1, Burst: 1},
+	}, failingStore{}, ratelimiter.BackendPostgres, log.NewStdLogger(io.Discard))
+
+	require.True(t, limiter.Allow(context.Background(), "GetFeed", "", "user:1").Allowed)
+}
+
+func TestNewLimiter_Disabled(t *testing.T) {
+	require.Nil(t, ratelimiter.NewLimiter(ratelimiter.Config{}, nil, log.NewStdLogger(io.Discard)))
+}
@@ -3,7 +3,9 @@ package metadata
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"strings"
@@ -13,12 +15,17 @@ import (
 
 // HandlerMetadata 描述从请求头或上游链路解析出的上下文信息。
 type HandlerMetadata struct {
-	IdempotencyKey  string
-	IfMatch         string
-	IfNoneMatch     string
-	UserID          string
-	RawUserInfo     string
-	InvalidUserInfo bool
+	IdempotencyKey     string
+	IfMatch            string
+	IfNoneMatch        string
+	DeviceID           string
+	RequestID          string
+	Locale             string
+	UserSegment        string
+	ExperimentVariants string
+	UserID             string
+	RawUserInfo        string
+	InvalidUserInfo    bool
 }
 
 // IsZero 判断 Metadata 是否为空。
@@ -26,6 +33,11 @@ func (m HandlerMetadata) IsZero() bool {
 	return m.IdempotencyKey == "" &&
 		m.IfMatch == "" &&
 		m.IfNoneMatch == "" &&
+		m.DeviceID == "" &&
+		m.RequestID == "" &&
+		m.Locale == "" &&
+		m.UserSegment == "" &&
+		m.ExperimentVariants == "" &&
 		m.UserID == "" &&
 		m.RawUserInfo == "" &&
 		!m.InvalidUserInfo
@@ -43,6 +55,19 @@ func (m HandlerMetadata) UserUUID() (uuid.UUID, bool) {
 	return value, true
 }
 
+// guestIDPrefix 标记由设备号派生的访客伪 ID，避免与真实 user_id 冲突。
+const guestIDPrefix = "guest:"
+
+// GuestIDFromDevice 基于设备号派生稳定的访客伪 ID，设备号原文不会落库。
+func GuestIDFromDevice(deviceID string) string {
+	deviceID = strings.TrimSpace(deviceID)
+	if deviceID == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(deviceID))
+	return guestIDPrefix + hex.EncodeToString(sum[:16])
+}
+
 type ctxKey struct{}
 
 // Inject 将 HandlerMetadata 注入 Context。
@@ -88,6 +113,56 @@ func ExtractUserIDFromUserInfo(raw string) (string, error) {
 	return "", nil
 }
 
+// UserInfoClaims 为 userinfo 中与授权相关的声明。
+type UserInfoClaims struct {
+	Subject   string
+	Audiences []string
+	Roles     []string
+}
+
+// ParseUserInfoClaims 解析 X-Apigateway-Api-Userinfo 中的 sub/aud/roles 声明。
+// aud 与 roles 兼容字符串与字符串数组两种形式，role 单值声明并入 Roles。
+func ParseUserInfoClaims(raw string) (UserInfoClaims, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return UserInfoClaims{}, errors.New("userinfo header is empty")
+	}
+	payload, err := decodeUserInfo(raw)
+	if err != nil {
+		return UserInfoClaims{}, err
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return UserInfoClaims{}, err
+	}
+	out := UserInfoClaims{
+		Audiences: stringsClaim(claims["aud"]),
+		Roles:     append(stringsClaim(claims["roles"]), stringsClaim(claims["role"])...),
+	}
+	out.Subject, _ = claims["sub"].(string)
+	return out, nil
+}
+
+func stringsClaim(value any) []string {
+	switch v := value.(type) {
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return nil
+		}
+		return []string{v}
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok && strings.TrimSpace(s) != "" {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
 func decodeUserInfo(raw string) ([]byte, error) {
 	decoders := []func(string) ([]byte, error){
 		func(s string) ([]byte, error) { return base64.RawURLEncoding.DecodeString(s) },
@@ -0,0 +1,27 @@
+package metadata_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/metadata"
+)
+
+func TestGuestIDFromDevice(t *testing.T) {
+	first := metadata.GuestIDFromDevice("device-123")
+	if !strings.HasPrefix(first, "guest:") {
+		t.Fatalf("expected guest prefix, got %q", first)
+	}
+	if strings.Contains(first, "device-123") {
+		t.Fatalf("guest id must not contain raw device id: %q", first)
+	}
+	if again := metadata.GuestIDFromDevice(" device-123 "); again != first {
+		t.Fatalf("expected stable guest id, got %q and %q", first, again)
+	}
+	if other := metadata.GuestIDFromDevice("device-456"); other == first {
+		t.Fatalf("expected different devices to map to different ids")
+	}
+	if empty := metadata.GuestIDFromDevice("  "); empty != "" {
+		t.Fatalf("expected empty guest id for blank device, got %q", empty)
+	}
+}
@@ -16,6 +16,71 @@ type FeedVideoProjection struct {
 	PublishedAt       *time.Time
 	Version           int64
 	UpdatedAt         time.Time
+	// CreatorID、PrimaryLanguage、Tags 供重排阶段使用，为空表示上游尚未提供。
+	CreatorID       *string
+	PrimaryLanguage *string
+	Tags            []string
+}
+
+// UserVideoState 表示用户对单个视频的点赞、收藏与观看进度投影。
+type UserVideoState struct {
+	UserID            string
+	VideoID           string
+	Liked             bool
+	LikedVersion      int64
+	Bookmarked        bool
+	BookmarkedVersion int64
+	// WatchedRatio 为观看进度，取值 [0, 1]。
+	WatchedRatio float64
+	// LastPositionMicros 为最近一次上报的播放位置，用于续播；未上报时为空。
+	LastPositionMicros *int64
+	LastWatchedAt      *time.Time
+	ProgressVersion    int64
+	UpdatedAt          time.Time
+}
+
+// UserWatchHistory 表示用户对单个视频的历史最高观看进度。
+type UserWatchHistory struct {
+	UserID  string
+	VideoID string
+	// MaxWatchedRatio 为历史最高观看进度，取值 [0, 1]，只增不减。
+	MaxWatchedRatio float64
+	FirstWatchedAt  time.Time
+	LastWatchedAt   time.Time
+	UpdatedAt       time.Time
+}
+
+// ReviewSchedule 表示 feed.review_schedule 中的复习计划。
+type ReviewSchedule struct {
+	UserID  string
+	VideoID string
+	DueAt   time.Time
+	// Stability 与 Difficulty 为 FSRS 参数，仅用于排序与观测。
+	Stability      float64
+	Difficulty     float64
+	Reps           int32
+	LastReviewedAt *time.Time
+	Active         bool
+	Version        int64
+	UpdatedAt      time.Time
+}
+
+// CurationRule 表示 feed.curation_rules 中的一条运营干预规则；范围字段为 nil 表示不限。
+type CurationRule struct {
+	RuleID      string
+	Action      string
+	VideoID     string
+	Scene       *string
+	Locale      *string
+	UserSegment *string
+	// Position 对 pin 为目标位次，对 boost 为最多提到的位次，从 1 开始。
+	Position  *int32
+	Priority  int32
+	StartsAt  *time.Time
+	EndsAt    *time.Time
+	Note      *string
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 // FeedInboxEvent 记录 Inbox 消费状态。
@@ -42,12 +107,88 @@ type FeedRecommendationLog struct {
 	MissingVideoIDs         []string
 	ErrorKind               *string
 	GeneratedAt             time.Time
+	IdempotencyKey          *string
+	Replayed                bool
+	Guest                   bool
+	// UserIDHash 为用户标识的 HMAC 哈希，UserID 仅在脱敏上线前的历史数据中存在。
+	UserIDHash       *string
+	UserIDKeyVersion *string
+	// ServedItems 为补水、过滤、重排后实际返回给用户的条目，是曝光的事实依据。
+	ServedItems []ServedItemLog
+	RequestID   *string
+	TraceID     *string
+	Scene       *string
+	// Page 为游标分页页码，从 1 开始。
+	Page *int32
+	// Experiments 为本次请求命中的 A/B 实验分组。
+	Experiments []ExperimentAssignmentLog
 }
 
+// 推荐条目未下发的原因，写入 RecommendedItemLog.MissingReason。
+const (
+	// MissingReasonInvalidVideoID 表示推荐返回的 video_id 无法解析。
+	MissingReasonInvalidVideoID = "invalid_video_id"
+	// MissingReasonProjection 表示补水时缺少视频投影。
+	MissingReasonProjection = "projection_missing"
+	// MissingReasonWatched 表示用户已看完该视频，被观看过滤剔除。
+	MissingReasonWatched = "watched"
+)
+
 // RecommendedItemLog 记录推荐模块原始返回的条目。
 type RecommendedItemLog struct {
 	VideoID string            `json:"video_id"`
 	Reason  string            `json:"reason"`
 	Score   float64           `json:"score"`
 	Meta    map[string]string `json:"meta,omitempty"`
+	// MissingReason 为该条目未下发的原因，已下发时为空；仅写入推荐日志，不进入幂等快照。
+	MissingReason string `json:"missing_reason,omitempty"`
+}
+
+// ServedItemLog 记录实际下发的条目及其最终位次。
+type ServedItemLog struct {
+	VideoID string `json:"video_id"`
+	// Position 为响应中的位次，从 1 开始。
+	Position int32 `json:"position"`
+	// ProjectionVersion 为补水所用投影的版本号，可据此还原用户看到的元数据。
+	ProjectionVersion int64  `json:"projection_version"`
+	VisibilityStatus  string `json:"visibility_status,omitempty"`
+}
+
+// ServedPage 为一次下发的最小事实记录（feed.served_pages），不采样、在请求路径内同步写入，
+// 作为交互事件校验归属与下发条目的依据。
+type ServedPage struct {
+	LogID                string
+	UserIDHash           *string
+	UserIDKeyVersion     *string
+	Guest                bool
+	Scene                *string
+	RecommendationSource string
+	ServedItems          []ServedItemLog
+	GeneratedAt          time.Time
+	// UserID 仅在由脱敏上线前的历史推荐日志回查时存在，下发页记录本身只保存哈希。
+	UserID *string
+}
+
+// ExperimentAssignmentLog 记录一次请求命中的实验分组。
+type ExperimentAssignmentLog struct {
+	Experiment string `json:"experiment"`
+	Variant    string `json:"variant"`
+	// Forced 为 true 表示经 QA 强制头命中，而非哈希分桶。
+	Forced bool `json:"forced,omitempty"`
+}
+
+// FeedIdempotencySnapshot 保存幂等请求首次返回的推荐页，用于重试时重放。
+type FeedIdempotencySnapshot struct {
+	// UserIDHash 为用户标识在 UserIDKeyVersion 密钥下的 HMAC 哈希，快照不保存明文 user_id。
+	UserIDHash           string
+	UserIDKeyVersion     string
+	IdempotencyKey       string
+	RequestLimit         int32
+	RecommendationSource string
+	Items                []RecommendedItemLog
+	NextCursor           *string
+	CreatedAt            time.Time
+	ExpiresAt            time.Time
+	// RequestHash 为首次请求参数 (scene, cursor, limit) 的指纹，历史快照为空。
+	RequestHash string
 }
@@ -7,7 +7,10 @@ import (
 
 // FeedRecommendationLogParams 描述构造推荐日志所需的参数。
 type FeedRecommendationLogParams struct {
-	UserID                  string
+	// LogID 为预先生成的日志 ID（会返回给客户端用于上报交互），空值时由数据库生成。
+	LogID                   string
+	UserIDHash              string
+	UserIDKeyVersion        string
 	RequestLimit            int
 	RecommendationSource    string
 	RecommendationLatencyMS int32
@@ -15,21 +18,42 @@ type FeedRecommendationLogParams struct {
 	MissingVideoIDs         []string
 	ErrorKind               string
 	GeneratedAt             time.Time
+	IdempotencyKey          string
+	Replayed                bool
+	Guest                   bool
+	ServedItems             []ServedItemLog
+	RequestID               string
+	TraceID                 string
+	Scene                   string
+	Page                    int32
+	Experiments             []ExperimentAssignmentLog
 }
 
 // NewFeedRecommendationLog 基于参数构造 FeedRecommendationLog 实例。
 func NewFeedRecommendationLog(params FeedRecommendationLogParams) FeedRecommendationLog {
 	items := cloneRecommendedItems(params.RecommendedItems)
 	missing := cloneStrings(params.MissingVideoIDs)
+	served := cloneServedItems(params.ServedItems)
 
 	entry := FeedRecommendationLog{
-		UserID:                  optionalString(params.UserID),
+		LogID:                   strings.TrimSpace(params.LogID),
+		UserIDHash:              optionalString(params.UserIDHash),
+		UserIDKeyVersion:        optionalString(params.UserIDKeyVersion),
 		RequestLimit:            int32(params.RequestLimit),
 		RecommendationSource:    strings.TrimSpace(params.RecommendationSource),
 		RecommendationLatencyMS: optionalInt32(params.RecommendationLatencyMS),
 		RecommendedItems:        items,
 		MissingVideoIDs:         missing,
 		GeneratedAt:             params.GeneratedAt,
+		IdempotencyKey:          optionalString(params.IdempotencyKey),
+		Replayed:                params.Replayed,
+		Guest:                   params.Guest,
+		ServedItems:             served,
+		RequestID:               optionalString(params.RequestID),
+		TraceID:                 optionalString(params.TraceID),
+		Scene:                   optionalString(params.Scene),
+		Page:                    optionalInt32(params.Page),
+		Experiments:             cloneExperiments(params.Experiments),
 	}
 	if entry.GeneratedAt.IsZero() {
 		entry.GeneratedAt = time.Now().UTC()
@@ -62,6 +86,24 @@ func cloneRecommendedItems(src []RecommendedItemLog) []RecommendedItemLog {
 	return dst
 }
 
+func cloneServedItems(src []ServedItemLog) []ServedItemLog {
+	if len(src) == 0 {
+		return []ServedItemLog{}
+	}
+	dst := make([]ServedItemLog, len(src))
+	copy(dst, src)
+	return dst
+}
+
+func cloneExperiments(src []ExperimentAssignmentLog) []ExperimentAssignmentLog {
+	if len(src) == 0 {
+		return []ExperimentAssignmentLog{}
+	}
+	dst := make([]ExperimentAssignmentLog, len(src))
+	copy(dst, src)
+	return dst
+}
+
 func cloneStrings(src []string) []string {
 	if len(src) == 0 {
 		return []string{}
@@ -20,9 +20,11 @@ func TestNewFeedRecommendationLog_PopulatesFields(t *testing.T) {
 		},
 	}
 	missing := []string{"v2"}
+	served := []ServedItemLog{{VideoID: "v1", Position: 1, ProjectionVersion: 7, VisibilityStatus: "public"}}
 
 	params := FeedRecommendationLogParams{
-		UserID:                  "user-1",
+		UserIDHash:              "hash-1",
+		UserIDKeyVersion:        "v1",
 		RequestLimit:            5,
 		RecommendationSource:    " mock ",
 		RecommendationLatencyMS: 123,
@@ -30,12 +32,23 @@ func TestNewFeedRecommendationLog_PopulatesFields(t *testing.T) {
 		MissingVideoIDs:         missing,
 		ErrorKind:               "projection_error",
 		GeneratedAt:             now,
+		IdempotencyKey:          " retry-1 ",
+		Replayed:                true,
+		Guest:                   true,
+		ServedItems:             served,
+		RequestID:               " req-1 ",
+		TraceID:                 "4bf92f3577b34da6a3ce929d0e0e4736",
+		Scene:                   "home",
+		Page:                    2,
 	}
 
 	entry := NewFeedRecommendationLog(params)
 
-	require.NotNil(t, entry.UserID)
-	require.Equal(t, "user-1", *entry.UserID)
+	require.Nil(t, entry.UserID)
+	require.NotNil(t, entry.UserIDHash)
+	require.Equal(t, "hash-1", *entry.UserIDHash)
+	require.NotNil(t, entry.UserIDKeyVersion)
+	require.Equal(t, "v1", *entry.UserIDKeyVersion)
 	require.Equal(t, int32(5), entry.RequestLimit)
 	require.Equal(t, "mock", entry.RecommendationSource)
 	require.NotNil(t, entry.RecommendationLatencyMS)
@@ -45,12 +58,26 @@ func TestNewFeedRecommendationLog_PopulatesFields(t *testing.T) {
 	require.NotNil(t, entry.ErrorKind)
 	require.Equal(t, "projection_error", *entry.ErrorKind)
 	require.WithinDuration(t, now, entry.GeneratedAt, time.Millisecond)
+	require.NotNil(t, entry.IdempotencyKey)
+	require.Equal(t, "retry-1", *entry.IdempotencyKey)
+	require.True(t, entry.Replayed)
+	require.True(t, entry.Guest)
+	require.Equal(t, served, entry.ServedItems)
+	require.NotNil(t, entry.RequestID)
+	require.Equal(t, "req-1", *entry.RequestID)
+	require.NotNil(t, entry.TraceID)
+	require.NotNil(t, entry.Scene)
+	require.Equal(t, "home", *entry.Scene)
+	require.NotNil(t, entry.Page)
+	require.Equal(t, int32(2), *entry.Page)
 
 	// Mutate original slices/maps to ensure cloning occurred.
 	recommended[0].Meta["experiment"] = "changed"
 	missing[0] = "other"
+	served[0].Position = 9
 	require.Equal(t, "exp-1", entry.RecommendedItems[0].Meta["experiment"])
 	require.Equal(t, []string{"v2"}, entry.MissingVideoIDs)
+	require.Equal(t, int32(1), entry.ServedItems[0].Position)
 }
 
 func TestNewFeedRecommendationLog_Defaults(t *testing.T) {
@@ -62,6 +89,8 @@ func TestNewFeedRecommendationLog_Defaults(t *testing.T) {
 	entry := NewFeedRecommendationLog(params)
 
 	require.Nil(t, entry.UserID)
+	require.Nil(t, entry.UserIDHash)
+	require.Nil(t, entry.UserIDKeyVersion)
 	require.Equal(t, int32(10), entry.RequestLimit)
 	require.Equal(t, "", entry.RecommendationSource)
 	require.Nil(t, entry.RecommendationLatencyMS)
@@ -69,4 +98,12 @@ func TestNewFeedRecommendationLog_Defaults(t *testing.T) {
 	require.Empty(t, entry.MissingVideoIDs)
 	require.Nil(t, entry.ErrorKind)
 	require.False(t, entry.GeneratedAt.IsZero())
+	require.Nil(t, entry.IdempotencyKey)
+	require.False(t, entry.Replayed)
+	require.NotNil(t, entry.ServedItems)
+	require.Empty(t, entry.ServedItems)
+	require.Nil(t, entry.RequestID)
+	require.Nil(t, entry.TraceID)
+	require.Nil(t, entry.Scene)
+	require.Nil(t, entry.Page)
 }
@@ -17,8 +17,35 @@ type FeedItem struct {
 	VisibilityStatus  string
 	PublishedAt       *time.Time
 	Attributes        map[string]string
+	// ProjectionVersion 为补水所用投影的版本号，仅用于推荐日志，不对外返回。
+	ProjectionVersion int64
+	// CreatorID、Language、Tags 来自投影，仅供重排阶段使用，不对外返回；为空表示未知。
+	CreatorID string
+	Language  string
+	Tags      []string
+	// UserState 为当前用户在该视频上的点赞/收藏/观看进度，访客或未启用补水时为空。
+	UserState *UserVideoState
 }
 
+// UserVideoState 描述用户对视频的互动状态。
+type UserVideoState struct {
+	Liked      bool
+	Bookmarked bool
+	// WatchedRatio 为观看进度，取值 [0, 1]。
+	WatchedRatio  float64
+	LastWatchedAt *time.Time
+	// ResumePositionMicros 为续播位置，未上报播放位置时为 0。
+	ResumePositionMicros int64
+}
+
+// 补水失败原因，写入 MissingProjection.Reason。
+const (
+	// MissingReasonInvalidVideoID 表示推荐返回的 video_id 无法解析。
+	MissingReasonInvalidVideoID = "invalid video id"
+	// MissingReasonProjection 表示缺少视频投影。
+	MissingReasonProjection = "projection missing"
+)
+
 // MissingProjection 描述补水失败的条目。
 type MissingProjection struct {
 	VideoID string
@@ -32,4 +59,6 @@ type FeedResponse struct {
 	Partial            bool
 	GeneratedAt        time.Time
 	MissingProjections []MissingProjection
+	// LogID 为本次推荐日志的 ID，客户端上报曝光/点击时回传；未记录日志（如被采样跳过）时为空。
+	LogID string
 }
@@ -16,6 +16,10 @@ func FeedItemFromProjection(record *po.FeedVideoProjection) FeedItem {
 		HLSMasterPlaylist: derefString(record.HLSMasterPlaylist),
 		VisibilityStatus:  derefString(record.VisibilityStatus),
 		Attributes:        map[string]string{},
+		ProjectionVersion: record.Version,
+		CreatorID:         derefString(record.CreatorID),
+		Language:          derefString(record.PrimaryLanguage),
+		Tags:              record.Tags,
 	}
 	if record.PublishedAt != nil {
 		item.PublishedAt = record.PublishedAt
@@ -47,6 +51,20 @@ func (item *FeedItem) ApplyRecommendation(reason string, metadata map[string]str
 	}
 }
 
+// ApplyUserState 合并用户-视频状态投影。
+func (item *FeedItem) ApplyUserState(state *po.UserVideoState) {
+	if item == nil || state == nil {
+		return
+	}
+	item.UserState = &UserVideoState{
+		Liked:                state.Liked,
+		Bookmarked:           state.Bookmarked,
+		WatchedRatio:         state.WatchedRatio,
+		LastWatchedAt:        state.LastWatchedAt,
+		ResumePositionMicros: derefInt64(state.LastPositionMicros),
+	}
+}
+
 func derefString(ptr *string) string {
 	if ptr == nil {
 		return ""
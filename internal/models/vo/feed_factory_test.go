@@ -68,3 +68,19 @@ func TestFeedItem_ApplyRecommendation(t *testing.T) {
 	item.ApplyRecommendation("algo.mock", nil, 0.9)
 	require.Equal(t, 0.9, item.Score)
 }
+
+func TestFeedItem_ApplyUserState(t *testing.T) {
+	item := FeedItem{}
+	item.ApplyUserState(nil)
+	require.Nil(t, item.UserState)
+
+	watchedAt := time.Now().UTC()
+	position := int64(30_000_000)
+	item.ApplyUserState(&po.UserVideoState{Liked: true, WatchedRatio: 0.4, LastWatchedAt: &watchedAt, LastPositionMicros: &position})
+	require.NotNil(t, item.UserState)
+	require.True(t, item.UserState.Liked)
+	require.False(t, item.UserState.Bookmarked)
+	require.Equal(t, 0.4, item.UserState.WatchedRatio)
+	require.Equal(t, &watchedAt, item.UserState.LastWatchedAt)
+	require.Equal(t, position, item.UserState.ResumePositionMicros)
+}
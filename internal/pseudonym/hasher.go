@@ -0,0 +1,130 @@
+// Package pseudonym 提供用户标识的带密钥哈希（HMAC-SHA256），用于推荐日志与结构化日志脱敏。
+//
+// 密钥按版本轮换：新数据始终使用 active 版本，旧版本仅用于按已知 user_id 反查历史日志。
+package pseudonym
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownKeyVersion 表示请求的密钥版本未配置。
+var ErrUnknownKeyVersion = errors.New("pseudonym: unknown key version")
+
+// Key 描述一个版本的 HMAC 密钥。
+type Key struct {
+	Version string
+	Secret  []byte
+}
+
+// Config 汇总密钥列表与当前生效版本。
+type Config struct {
+	ActiveVersion string
+	Keys          []Key
+}
+
+// Digest 为一次哈希的结果，KeyVersion 记录所用密钥版本以便轮换后仍可反查。
+type Digest struct {
+	Hash       string
+	KeyVersion string
+}
+
+// IsZero 表示未产生哈希（输入为空或未配置密钥）。
+func (d Digest) IsZero() bool {
+	return d.Hash == ""
+}
+
+// Hasher 以 active 密钥对用户标识做 HMAC，零值/nil 时所有方法返回空结果。
+type Hasher struct {
+	active string
+	keys   map[string][]byte
+	order  []string
+}
+
+// New 校验配置并构造 Hasher；未配置任何密钥时返回 nil，调用方据此不落用户标识。
+func New(cfg Config) (*Hasher, error) {
+	if len(cfg.Keys) == 0 {
+		return nil, nil
+	}
+	h := &Hasher{keys: make(map[string][]byte, len(cfg.Keys))}
+	for _, key := range cfg.Keys {
+		version := strings.TrimSpace(key.Version)
+		if version == "" {
+			return nil, errors.New("pseudonym: key version is required")
+		}
+		if len(key.Secret) == 0 {
+			return nil, fmt.Errorf("pseudonym: secret for key version %q is empty", version)
+		}
+		if _, dup := h.keys[version]; dup {
+			return nil, fmt.Errorf("pseudonym: duplicate key version %q", version)
+		}
+		h.keys[version] = append([]byte(nil), key.Secret...)
+		h.order = append(h.order, version)
+	}
+	active := strings.TrimSpace(cfg.ActiveVersion)
+	if active == "" {
+		active = h.order[len(h.order)-1]
+	}
+	if _, ok := h.keys[active]; !ok {
+		return nil, fmt.Errorf("%w: active version %q", ErrUnknownKeyVersion, active)
+	}
+	h.active = active
+	return h, nil
+}
+
+// Enabled 表示是否配置了密钥。
+func (h *Hasher) Enabled() bool {
+	return h != nil && h.active != ""
+}
+
+// ActiveVersion 返回当前生效的密钥版本。
+func (h *Hasher) ActiveVersion() string {
+	if h == nil {
+		return ""
+	}
+	return h.active
+}
+
+// Hash 使用 active 密钥哈希 userID，空输入或未启用时返回零值。
+func (h *Hasher) Hash(userID string) Digest {
+	if !h.Enabled() {
+		return Digest{}
+	}
+	hash, _ := h.HashWithVersion(userID, h.active)
+	if hash == "" {
+		return Digest{}
+	}
+	return Digest{Hash: hash, KeyVersion: h.active}
+}
+
+// HashWithVersion 使用指定版本的密钥哈希 userID。
+func (h *Hasher) HashWithVersion(userID, version string) (string, error) {
+	userID = strings.TrimSpace(userID)
+	if h == nil || userID == "" {
+		return "", nil
+	}
+	secret, ok := h.keys[version]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownKeyVersion, version)
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(userID))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// Candidates 返回 userID 在所有已配置密钥版本下的哈希，供运维按已知用户反查跨轮换周期的日志。
+func (h *Hasher) Candidates(userID string) []Digest {
+	if !h.Enabled() || strings.TrimSpace(userID) == "" {
+		return nil
+	}
+	out := make([]Digest, 0, len(h.order))
+	for _, version := range h.order {
+		hash, _ := h.HashWithVersion(userID, version)
+		out = append(out, Digest{Hash: hash, KeyVersion: version})
+	}
+	return out
+}
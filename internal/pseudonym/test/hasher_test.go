@@ -0,0 +1,89 @@
+package pseudonym_test
+
+import (
+	"testing"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/pseudonym"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newHasher(t *testing.T, active string) *pseudonym.Hasher {
+	t.Helper()
+	hasher, err := pseudonym.New(pseudonym.Config{
+		ActiveVersion: active,
+		Keys: []pseudonym.Key{
+			{Version: "v1", Secret: []byte("secret-one")},
+			{Version: "v2", Secret: []byte("secret-two")},
+		},
+	})
+	require.NoError(t, err)
+	return hasher
+}
+
+func TestHasher_HashIsStableAndKeyed(t *testing.T) {
+	hasher := newHasher(t, "v1")
+
+	first := hasher.Hash("user-1")
+	require.Equal(t, "v1", first.KeyVersion)
+	require.Len(t, first.Hash, 64)
+	require.Equal(t, first, hasher.Hash(" user-1 "))
+	require.NotEqual(t, first.Hash, hasher.Hash("user-2").Hash)
+	require.NotContains(t, first.Hash, "user-1")
+
+	rotated := newHasher(t, "v2").Hash("user-1")
+	require.Equal(t, "v2", rotated.KeyVersion)
+	require.NotEqual(t, first.Hash, rotated.Hash)
+}
+
+func TestHasher_DefaultsToLastKey(t *testing.T) {
+	hasher := newHasher(t, "")
+	require.Equal(t, "v2", hasher.ActiveVersion())
+}
+
+func TestHasher_CandidatesCoverAllVersions(t *testing.T) {
+	hasher := newHasher(t, "v2")
+
+	candidates := hasher.Candidates("user-1")
+	require.Len(t, candidates, 2)
+	require.Equal(t, "v1", candidates[0].KeyVersion)
+	require.Equal(t, newHasher(t, "v1").Hash("user-1").Hash, candidates[0].Hash)
+	require.Equal(t, hasher.Hash("user-1"), candidates[1])
+
+	require.Empty(t, hasher.Candidates(""))
+}
+
+func TestHasher_EmptyInputAndNilHasher(t *testing.T) {
+	hasher := newHasher(t, "v1")
+	require.True(t, hasher.Hash("").IsZero())
+
+	var disabled *pseudonym.Hasher
+	require.False(t, disabled.Enabled())
+	require.True(t, disabled.Hash("user-1").IsZero())
+	require.Empty(t, disabled.Candidates("user-1"))
+
+	none, err := pseudonym.New(pseudonym.Config{})
+	require.NoError(t, err)
+	require.Nil(t, none)
+}
+
+func TestNew_RejectsInvalidConfig(t *testing.T) {
+	_, err := pseudonym.New(pseudonym.Config{Keys: []pseudonym.Key{{Version: "v1"}}})
+	require.Error(t, err)
+
+	_, err = pseudonym.New(pseudonym.Config{Keys: []pseudonym.Key{
+		{Version: "v1", Secret: []byte("a")},
+		{Version: "v1", Secret: []byte("b")},
+	}})
+	require.Error(t, err)
+
+	_, err = pseudonym.New(pseudonym.Config{
+		ActiveVersion: "v9",
+		Keys:          []pseudonym.Key{{Version: "v1", Secret: []byte("a")}},
+	})
+	require.ErrorIs(t, err, pseudonym.ErrUnknownKeyVersion)
+
+	hasher := newHasher(t, "v1")
+	_, err = hasher.HashWithVersion("user-1", "v9")
+	require.ErrorIs(t, err, pseudonym.ErrUnknownKeyVersion)
+}
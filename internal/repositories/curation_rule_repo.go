@@ -0,0 +1,189 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories/feeddb"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories/mappers"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// CurationRuleChannel 为 feed.curation_rules 变更的 LISTEN/NOTIFY 通道，由迁移中的触发器发送。
+const CurationRuleChannel = "feed_curation_rules"
+
+// ErrCurationRuleNotFound 表示指定 rule_id 的运营规则不存在。
+var ErrCurationRuleNotFound = errors.New("curation rule not found")
+
+// CurationRuleRepository 维护 feed.curation_rules。
+type CurationRuleRepository struct {
+	db      *pgxpool.Pool
+	queries *feeddb.Queries
+	log     *log.Helper
+}
+
+// NewCurationRuleRepository 构造仓储实例。
+func NewCurationRuleRepository(db *pgxpool.Pool, logger log.Logger) *CurationRuleRepository {
+	return &CurationRuleRepository{
+		db:      db,
+		queries: feeddb.New(db),
+		log:     log.NewHelper(logger),
+	}
+}
+
+// CurationRuleInput 描述一条运营规则的内容，范围字段为 nil 表示不限。
+type CurationRuleInput struct {
+	Action      string
+	VideoID     uuid.UUID
+	Scene       *string
+	Locale      *string
+	UserSegment *string
+	Position    *int32
+	Priority    int32
+	StartsAt    *time.Time
+	EndsAt      *time.Time
+	Note        *string
+}
+
+// Create 写入一条规则并返回完整记录。
+func (r *CurationRuleRepository) Create(ctx context.Context, sess txmanager.Session, input CurationRuleInput) (*po.CurationRule, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	row, err := queries.InsertCurationRule(ctx, feeddb.InsertCurationRuleParams{
+		Action:      input.Action,
+		VideoID:     input.VideoID,
+		Scene:       mappers.ToPgText(input.Scene),
+		Locale:      mappers.ToPgText(input.Locale),
+		UserSegment: mappers.ToPgText(input.UserSegment),
+		Position:    mappers.ToPgInt4(input.Position),
+		Priority:    input.Priority,
+		StartsAt:    mappers.ToPgTimestamptzPtr(input.StartsAt),
+		EndsAt:      mappers.ToPgTimestamptzPtr(input.EndsAt),
+		Note:        mappers.ToPgText(input.Note),
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorw("msg", "insert curation rule failed", "video_id", input.VideoID, "error", err)
+		return nil, fmt.Errorf("insert curation rule: %w", err)
+	}
+	return mappers.CurationRuleFromRow(row), nil
+}
+
+// Update 整体覆盖规则内容；规则不存在时返回 ErrCurationRuleNotFound。
+func (r *CurationRuleRepository) Update(ctx context.Context, sess txmanager.Session, ruleID uuid.UUID, input CurationRuleInput) (*po.CurationRule, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	row, err := queries.UpdateCurationRule(ctx, feeddb.UpdateCurationRuleParams{
+		RuleID:      ruleID,
+		Action:      input.Action,
+		VideoID:     input.VideoID,
+		Scene:       mappers.ToPgText(input.Scene),
+		Locale:      mappers.ToPgText(input.Locale),
+		UserSegment: mappers.ToPgText(input.UserSegment),
+		Position:    mappers.ToPgInt4(input.Position),
+		Priority:    input.Priority,
+		StartsAt:    mappers.ToPgTimestamptzPtr(input.StartsAt),
+		EndsAt:      mappers.ToPgTimestamptzPtr(input.EndsAt),
+		Note:        mappers.ToPgText(input.Note),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCurationRuleNotFound
+		}
+		r.log.WithContext(ctx).Errorw("msg", "update curation rule failed", "rule_id", ruleID, "error", err)
+		return nil, fmt.Errorf("update curation rule: %w", err)
+	}
+	return mappers.CurationRuleFromRow(row), nil
+}
+
+// Delete 删除规则；规则不存在时返回 ErrCurationRuleNotFound。
+func (r *CurationRuleRepository) Delete(ctx context.Context, sess txmanager.Session, ruleID uuid.UUID) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	affected, err := queries.DeleteCurationRule(ctx, ruleID)
+	if err != nil {
+		r.log.WithContext(ctx).Errorw("msg", "delete curation rule failed", "rule_id", ruleID, "error", err)
+		return fmt.Errorf("delete curation rule: %w", err)
+	}
+	if affected == 0 {
+		return ErrCurationRuleNotFound
+	}
+	return nil
+}
+
+// Get 按 rule_id 查询规则。
+func (r *CurationRuleRepository) Get(ctx context.Context, sess txmanager.Session, ruleID uuid.UUID) (*po.CurationRule, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	row, err := queries.GetCurationRule(ctx, ruleID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCurationRuleNotFound
+		}
+		return nil, fmt.Errorf("get curation rule: %w", err)
+	}
+	return mappers.CurationRuleFromRow(row), nil
+}
+
+// List 按优先级降序返回规则；includeExpired 为 false 时仅返回 ends_at 晚于 now 或不限期的规则。
+func (r *CurationRuleRepository) List(ctx context.Context, sess txmanager.Session, now time.Time, includeExpired bool) ([]*po.CurationRule, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	rows, err := queries.ListCurationRules(ctx, feeddb.ListCurationRulesParams{
+		IncludeExpired: includeExpired,
+		Now:            mappers.ToPgTimestamptzPtr(&now),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list curation rules: %w", err)
+	}
+	result := make([]*po.CurationRule, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, mappers.CurationRuleFromRow(row))
+	}
+	return result, nil
+}
+
+// Listen 占用一条专用连接 LISTEN 规则变更通道，每收到一次通知调用 onChange；
+// LISTEN 成功后先调用一次 onReady，调用方可借此在监听建立后全量刷新，避免错过建立前的变更。
+// 阻塞直到 ctx 结束或连接出错，连接出错时返回错误，由调用方负责重连。
+func (r *CurationRuleRepository) Listen(ctx context.Context, onReady, onChange func()) error {
+	pooled, err := r.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire listen connection: %w", err)
+	}
+	// 连接上残留 LISTEN 状态，脱离连接池并在退出时关闭。
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "listen "+CurationRuleChannel); err != nil {
+		return fmt.Errorf("listen %s: %w", CurationRuleChannel, err)
+	}
+	if onReady != nil {
+		onReady()
+	}
+	for {
+		if _, err := conn.WaitForNotification(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("wait curation rule notification: %w", err)
+		}
+		onChange()
+	}
+}
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories/feeddb"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories/mappers"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// FeedIdempotencyRepository 负责 GetFeed 幂等快照的读写。
+type FeedIdempotencyRepository struct {
+	db      *pgxpool.Pool
+	queries *feeddb.Queries
+	log     *log.Helper
+}
+
+// NewFeedIdempotencyRepository 构造仓储实例。
+func NewFeedIdempotencyRepository(db *pgxpool.Pool, logger log.Logger) *FeedIdempotencyRepository {
+	return &FeedIdempotencyRepository{
+		db:      db,
+		queries: feeddb.New(db),
+		log:     log.NewHelper(logger),
+	}
+}
+
+// Get 按用户哈希与幂等键查询未过期的幂等快照，不存在时返回 nil。
+func (r *FeedIdempotencyRepository) Get(ctx context.Context, sess txmanager.Session, userIDHash, key string) (*po.FeedIdempotencySnapshot, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	row, err := queries.GetIdempotencySnapshot(ctx, feeddb.GetIdempotencySnapshotParams{
+		UserIDHash:     userIDHash,
+		IdempotencyKey: key,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get idempotency snapshot: %w", err)
+	}
+	return mappers.FeedIdempotencySnapshotFromRow(row)
+}
+
+// Save 写入幂等快照；若同一键已有未过期快照则不覆盖并返回 false。
+func (r *FeedIdempotencyRepository) Save(ctx context.Context, sess txmanager.Session, snapshot po.FeedIdempotencySnapshot) (bool, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	items := snapshot.Items
+	if items == nil {
+		items = []po.RecommendedItemLog{}
+	}
+	payload, err := json.Marshal(items)
+	if err != nil {
+		return false, fmt.Errorf("marshal snapshot items: %w", err)
+	}
+	rows, err := queries.InsertIdempotencySnapshot(ctx, feeddb.InsertIdempotencySnapshotParams{
+		UserIDHash:           snapshot.UserIDHash,
+		UserIDKeyVersion:     pgtype.Text{String: snapshot.UserIDKeyVersion, Valid: snapshot.UserIDKeyVersion != ""},
+		IdempotencyKey:       snapshot.IdempotencyKey,
+		RequestLimit:         snapshot.RequestLimit,
+		RecommendationSource: snapshot.RecommendationSource,
+		Items:                payload,
+		NextCursor:           mappers.ToPgText(snapshot.NextCursor),
+		ExpiresAt:            pgtype.Timestamptz{Time: snapshot.ExpiresAt.UTC(), Valid: true},
+		RequestHash:          pgtype.Text{String: snapshot.RequestHash, Valid: snapshot.RequestHash != ""},
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorw("msg", "insert idempotency snapshot failed", "error", err)
+		return false, fmt.Errorf("insert idempotency snapshot: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// PurgeExpired 删除 before 之前过期的快照，返回删除行数。
+func (r *FeedIdempotencyRepository) PurgeExpired(ctx context.Context, sess txmanager.Session, before time.Time) (int64, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	rows, err := queries.DeleteExpiredIdempotencySnapshots(ctx, pgtype.Timestamptz{Time: before.UTC(), Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("delete expired idempotency snapshots: %w", err)
+	}
+	return rows, nil
+}
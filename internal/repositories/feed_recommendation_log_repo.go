@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/bionicotaku/lingo-utils/txmanager"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -38,35 +40,43 @@ func (r *FeedRecommendationLogRepository) Insert(ctx context.Context, sess txman
 	if sess != nil {
 		queries = queries.WithTx(sess.Tx())
 	}
-	recommended := logEntry.RecommendedItems
-	if recommended == nil {
-		recommended = []po.RecommendedItemLog{}
-	}
-	recommendedPayload, err := json.Marshal(recommended)
-	if err != nil {
-		return fmt.Errorf("marshal recommended_items: %w", err)
-	}
-	missing := logEntry.MissingVideoIDs
-	if missing == nil {
-		missing = []string{}
-	}
-	missingPayload, err := json.Marshal(missing)
+	payloads, err := marshalRecommendationLogPayloads(logEntry)
 	if err != nil {
-		return fmt.Errorf("marshal missing_video_ids: %w", err)
+		return err
 	}
 	var generatedAt *time.Time
 	if !logEntry.GeneratedAt.IsZero() {
 		gt := logEntry.GeneratedAt.UTC()
 		generatedAt = &gt
 	}
+	var logID pgtype.UUID
+	if logEntry.LogID != "" {
+		parsed, err := uuid.Parse(logEntry.LogID)
+		if err != nil {
+			return fmt.Errorf("insert feed recommendation log: invalid log id %q: %w", logEntry.LogID, err)
+		}
+		logID = pgtype.UUID{Bytes: parsed, Valid: true}
+	}
 	params := feeddb.InsertRecommendationLogParams{
+		LogID:                   logID,
 		UserID:                  mappers.ToPgText(logEntry.UserID),
 		RequestLimit:            logEntry.RequestLimit,
 		RecommendationSource:    logEntry.RecommendationSource,
 		RecommendationLatencyMs: mappers.ToPgInt4(logEntry.RecommendationLatencyMS),
-		RecommendedItems:        recommendedPayload,
-		MissingVideoIds:         missingPayload,
+		RecommendedItems:        payloads.recommended,
+		MissingVideoIds:         payloads.missing,
 		ErrorKind:               mappers.ToPgText(logEntry.ErrorKind),
+		IdempotencyKey:          mappers.ToPgText(logEntry.IdempotencyKey),
+		Replayed:                logEntry.Replayed,
+		Guest:                   logEntry.Guest,
+		UserIDHash:              mappers.ToPgText(logEntry.UserIDHash),
+		UserIDKeyVersion:        mappers.ToPgText(logEntry.UserIDKeyVersion),
+		ServedItems:             payloads.served,
+		RequestID:               mappers.ToPgText(logEntry.RequestID),
+		TraceID:                 mappers.ToPgText(logEntry.TraceID),
+		Scene:                   mappers.ToPgText(logEntry.Scene),
+		Page:                    mappers.ToPgInt4(logEntry.Page),
+		Experiments:             payloads.experiments,
 		GeneratedAt:             mappers.ToPgTimestamptzPtr(generatedAt),
 	}
 	if err := queries.InsertRecommendationLog(ctx, params); err != nil {
@@ -76,6 +86,92 @@ func (r *FeedRecommendationLogRepository) Insert(ctx context.Context, sess txman
 	return nil
 }
 
+// recommendationLogCopyColumns 为 CopyFrom 批量写入的列顺序；COPY 不会回退到列默认值，
+// 未预先生成 log_id 的条目在写入前补齐。
+var recommendationLogCopyColumns = []string{
+	"log_id",
+	"user_id",
+	"request_limit",
+	"recommendation_source",
+	"recommendation_latency_ms",
+	"recommended_items",
+	"missing_video_ids",
+	"error_kind",
+	"idempotency_key",
+	"replayed",
+	"guest",
+	"user_id_hash",
+	"user_id_key_version",
+	"served_items",
+	"request_id",
+	"trace_id",
+	"scene",
+	"page",
+	"experiments",
+	"generated_at",
+}
+
+// InsertBatch 通过 COPY 协议批量写入推荐日志，返回写入行数。
+// 批内任一条编码失败时整批放弃，避免部分写入难以排查；sess 非空时 COPY 在该事务内执行。
+func (r *FeedRecommendationLogRepository) InsertBatch(ctx context.Context, sess txmanager.Session, entries []po.FeedRecommendationLog) (int64, error) {
+	if len(entries) == 0 {
+		return 0, nil
+	}
+	rows := make([][]any, 0, len(entries))
+	for _, entry := range entries {
+		payloads, err := marshalRecommendationLogPayloads(entry)
+		if err != nil {
+			return 0, err
+		}
+		generatedAt := entry.GeneratedAt.UTC()
+		if entry.GeneratedAt.IsZero() {
+			generatedAt = time.Now().UTC()
+		}
+		logID := uuid.New()
+		if entry.LogID != "" {
+			parsed, err := uuid.Parse(entry.LogID)
+			if err != nil {
+				return 0, fmt.Errorf("copy feed recommendation logs: invalid log id %q: %w", entry.LogID, err)
+			}
+			logID = parsed
+		}
+		rows = append(rows, []any{
+			logID,
+			entry.UserID,
+			entry.RequestLimit,
+			entry.RecommendationSource,
+			entry.RecommendationLatencyMS,
+			payloads.recommended,
+			payloads.missing,
+			entry.ErrorKind,
+			entry.IdempotencyKey,
+			entry.Replayed,
+			entry.Guest,
+			entry.UserIDHash,
+			entry.UserIDKeyVersion,
+			payloads.served,
+			entry.RequestID,
+			entry.TraceID,
+			entry.Scene,
+			entry.Page,
+			payloads.experiments,
+			generatedAt,
+		})
+	}
+	var copier interface {
+		CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error)
+	} = r.db
+	if sess != nil {
+		copier = sess.Tx()
+	}
+	copied, err := copier.CopyFrom(ctx, pgx.Identifier{"feed", "recommendation_logs"}, recommendationLogCopyColumns, pgx.CopyFromRows(rows))
+	if err != nil {
+		r.log.WithContext(ctx).Errorw("msg", "copy feed recommendation logs failed", "count", len(entries), "error", err)
+		return 0, fmt.Errorf("copy feed recommendation logs: %w", err)
+	}
+	return copied, nil
+}
+
 // GetByID 按 log_id 查询推荐日志。
 func (r *FeedRecommendationLogRepository) GetByID(ctx context.Context, sess txmanager.Session, id uuid.UUID) (*po.FeedRecommendationLog, error) {
 	queries := r.queries
@@ -84,21 +180,39 @@ func (r *FeedRecommendationLogRepository) GetByID(ctx context.Context, sess txma
 	}
 	row, err := queries.GetRecommendationLog(ctx, id)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRecommendationLogNotFound
+		}
 		return nil, fmt.Errorf("get recommendation log: %w", err)
 	}
 	return mappers.FeedRecommendationLogFromRow(row)
 }
 
+// ErrRecommendationLogNotFound 表示指定 log_id 的推荐日志不存在（或已随分区过期）。
+var ErrRecommendationLogNotFound = errors.New("recommendation log not found")
+
+// RecommendationLogCursor 为 (generated_at, log_id) 键集分页位置，List 返回严格位于其后的记录。
+type RecommendationLogCursor struct {
+	GeneratedAt time.Time
+	LogID       uuid.UUID
+}
+
 // ListRecommendationLogsParams 描述推荐日志的查询条件。
 type ListRecommendationLogsParams struct {
 	UserID *string
-	Source *string
-	Since  *time.Time
-	Until  *time.Time
-	Limit  int
+	// UserIDHashes 匹配任一哈希，通常为同一用户在各密钥版本下的哈希。
+	UserIDHashes []string
+	Source       *string
+	ErrorKind    *string
+	// MissingOnly 为 true 时仅返回存在补水缺失的日志。
+	MissingOnly bool
+	Since       *time.Time
+	Until       *time.Time
+	After       *RecommendationLogCursor
+	Limit       int
 }
 
-// List 返回满足条件的推荐日志，按时间倒序排序。
+// List 返回满足条件的推荐日志，按 (generated_at, log_id) 倒序排序。
 func (r *FeedRecommendationLogRepository) List(ctx context.Context, sess txmanager.Session, params ListRecommendationLogsParams) ([]*po.FeedRecommendationLog, error) {
 	limit := params.Limit
 	if limit <= 0 {
@@ -109,11 +223,18 @@ func (r *FeedRecommendationLogRepository) List(ctx context.Context, sess txmanag
 		queries = queries.WithTx(sess.Tx())
 	}
 	dbParams := feeddb.ListRecommendationLogsParams{
-		UserID:   textFromPtr(params.UserID),
-		Source:   textFromPtr(params.Source),
-		Since:    timestamptzFromPtr(params.Since),
-		Until:    timestamptzFromPtr(params.Until),
-		RowLimit: int32(limit),
+		UserID:       textFromPtr(params.UserID),
+		UserIDHashes: params.UserIDHashes,
+		Source:       textFromPtr(params.Source),
+		ErrorKind:    textFromPtr(params.ErrorKind),
+		MissingOnly:  params.MissingOnly,
+		Since:        timestamptzFromPtr(params.Since),
+		Until:        timestamptzFromPtr(params.Until),
+		RowLimit:     int32(limit),
+	}
+	if params.After != nil {
+		dbParams.AfterGeneratedAt = pgtype.Timestamptz{Time: params.After.GeneratedAt.UTC(), Valid: true}
+		dbParams.AfterLogID = pgtype.UUID{Bytes: params.After.LogID, Valid: true}
 	}
 	rows, err := queries.ListRecommendationLogs(ctx, dbParams)
 	if err != nil {
@@ -130,6 +251,175 @@ func (r *FeedRecommendationLogRepository) List(ctx context.Context, sess txmanag
 	return result, nil
 }
 
+// ListByIDs 批量按 log_id 查询推荐日志，不存在的 ID 直接忽略，结果顺序不保证。
+func (r *FeedRecommendationLogRepository) ListByIDs(ctx context.Context, sess txmanager.Session, ids []uuid.UUID) ([]*po.FeedRecommendationLog, error) {
+	if len(ids) == 0 {
+		return []*po.FeedRecommendationLog{}, nil
+	}
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	rows, err := queries.ListRecommendationLogsByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("list recommendation logs by ids: %w", err)
+	}
+	result := make([]*po.FeedRecommendationLog, 0, len(rows))
+	for _, row := range rows {
+		entry, mapErr := mappers.FeedRecommendationLogFromRow(row)
+		if mapErr != nil {
+			return nil, mapErr
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// ExportRecommendationLogsParams 描述离线导出的扫描窗口，结果按 (generated_at, log_id) 正序返回。
+type ExportRecommendationLogsParams struct {
+	// After 为上次导出的水位，仅返回严格位于其后的记录。
+	After *RecommendationLogCursor
+	Since *time.Time
+	Until time.Time
+	Limit int
+}
+
+// ListForExport 按 (generated_at, log_id) 正序分批扫描推荐日志，供离线导出使用。
+func (r *FeedRecommendationLogRepository) ListForExport(ctx context.Context, sess txmanager.Session, params ExportRecommendationLogsParams) ([]*po.FeedRecommendationLog, error) {
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 1000
+	}
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	dbParams := feeddb.ListRecommendationLogsForExportParams{
+		Until:    pgtype.Timestamptz{Time: params.Until.UTC(), Valid: true},
+		Since:    timestamptzFromPtr(params.Since),
+		RowLimit: int32(limit),
+	}
+	if params.After != nil {
+		dbParams.AfterGeneratedAt = pgtype.Timestamptz{Time: params.After.GeneratedAt.UTC(), Valid: true}
+		dbParams.AfterLogID = pgtype.UUID{Bytes: params.After.LogID, Valid: true}
+	}
+	rows, err := queries.ListRecommendationLogsForExport(ctx, dbParams)
+	if err != nil {
+		return nil, fmt.Errorf("list recommendation logs for export: %w", err)
+	}
+	result := make([]*po.FeedRecommendationLog, 0, len(rows))
+	for _, row := range rows {
+		entry, mapErr := mappers.FeedRecommendationLogFromRow(row)
+		if mapErr != nil {
+			return nil, mapErr
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// EnsurePartitions 预建 from 所在 UTC 自然日起 days 天的日分区，返回新建数量。
+func (r *FeedRecommendationLogRepository) EnsurePartitions(ctx context.Context, sess txmanager.Session, from time.Time, days int) (int, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	created, err := queries.EnsureRecommendationLogPartitions(ctx, feeddb.EnsureRecommendationLogPartitionsParams{
+		FromDay: utcDate(from),
+		Days:    int32(days),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("ensure recommendation log partitions: %w", err)
+	}
+	return int(created), nil
+}
+
+// DropPartitionsBefore 删除整日早于 before 所在 UTC 自然日的分区，返回被删除的分区名。
+func (r *FeedRecommendationLogRepository) DropPartitionsBefore(ctx context.Context, sess txmanager.Session, before time.Time) ([]string, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	dropped, err := queries.DropRecommendationLogPartitions(ctx, utcDate(before))
+	if err != nil {
+		return nil, fmt.Errorf("drop recommendation log partitions: %w", err)
+	}
+	return dropped, nil
+}
+
+// PurgeDefaultPartitionBefore 删除 default 兜底分区中 generated_at 早于 before 的行，返回删除行数。
+func (r *FeedRecommendationLogRepository) PurgeDefaultPartitionBefore(ctx context.Context, sess txmanager.Session, before time.Time) (int64, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	deleted, err := queries.PurgeRecommendationLogDefault(ctx, pgtype.Timestamptz{Time: before.UTC(), Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("purge recommendation log default partition: %w", err)
+	}
+	return deleted, nil
+}
+
+// CountDefaultPartition 返回 default 兜底分区的当前行数。
+func (r *FeedRecommendationLogRepository) CountDefaultPartition(ctx context.Context, sess txmanager.Session) (int64, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	remaining, err := queries.CountRecommendationLogDefault(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("count recommendation log default partition: %w", err)
+	}
+	return remaining, nil
+}
+
+func utcDate(t time.Time) pgtype.Date {
+	utc := t.UTC()
+	return pgtype.Date{Time: time.Date(utc.Year(), utc.Month(), utc.Day(), 0, 0, 0, 0, time.UTC), Valid: true}
+}
+
+// recommendationLogPayloads 为推荐日志中各 jsonb 列的编码结果。
+type recommendationLogPayloads struct {
+	recommended []byte
+	missing     []byte
+	served      []byte
+	experiments []byte
+}
+
+func marshalRecommendationLogPayloads(logEntry po.FeedRecommendationLog) (recommendationLogPayloads, error) {
+	var payloads recommendationLogPayloads
+	recommended := logEntry.RecommendedItems
+	if recommended == nil {
+		recommended = []po.RecommendedItemLog{}
+	}
+	var err error
+	if payloads.recommended, err = json.Marshal(recommended); err != nil {
+		return payloads, fmt.Errorf("marshal recommended_items: %w", err)
+	}
+	missing := logEntry.MissingVideoIDs
+	if missing == nil {
+		missing = []string{}
+	}
+	if payloads.missing, err = json.Marshal(missing); err != nil {
+		return payloads, fmt.Errorf("marshal missing_video_ids: %w", err)
+	}
+	served := logEntry.ServedItems
+	if served == nil {
+		served = []po.ServedItemLog{}
+	}
+	if payloads.served, err = json.Marshal(served); err != nil {
+		return payloads, fmt.Errorf("marshal served_items: %w", err)
+	}
+	experiments := logEntry.Experiments
+	if experiments == nil {
+		experiments = []po.ExperimentAssignmentLog{}
+	}
+	if payloads.experiments, err = json.Marshal(experiments); err != nil {
+		return payloads, fmt.Errorf("marshal experiments: %w", err)
+	}
+	return payloads, nil
+}
+
 func textFromPtr(ptr *string) pgtype.Text {
 	if ptr == nil || *ptr == "" {
 		return pgtype.Text{}
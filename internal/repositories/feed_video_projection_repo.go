@@ -11,6 +11,7 @@ import (
 	"github.com/bionicotaku/lingo-utils/txmanager"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -43,6 +44,10 @@ type UpsertFeedVideoProjectionInput struct {
 	PublishedAt       *time.Time
 	Version           int64
 	UpdatedAt         *time.Time
+	// CreatorID、PrimaryLanguage、Tags 为空时保留投影中的原值。
+	CreatorID       *string
+	PrimaryLanguage *string
+	Tags            []string
 }
 
 // Upsert 写入或更新投影记录。
@@ -63,6 +68,9 @@ func (r *FeedVideoProjectionRepository) Upsert(ctx context.Context, sess txmanag
 		PublishedAt:       mappers.ToPgTimestamptzPtr(input.PublishedAt),
 		Version:           input.Version,
 		Column11:          mappers.ToPgTimestamptzPtr(input.UpdatedAt),
+		CreatorID:         mappers.ToPgText(input.CreatorID),
+		PrimaryLanguage:   mappers.ToPgText(input.PrimaryLanguage),
+		Tags:              input.Tags,
 	}
 	if err := queries.UpsertVideoProjection(ctx, params); err != nil {
 		r.log.WithContext(ctx).Errorw("msg", "upsert feed video projection failed", "video_id", input.VideoID, "error", err)
@@ -104,6 +112,72 @@ func (r *FeedVideoProjectionRepository) ListByIDs(ctx context.Context, sess txma
 	return result, nil
 }
 
+// SeededVideoCursor 为确定性伪随机顺序中的键集分页位置，列表返回严格位于其后的记录。
+type SeededVideoCursor struct {
+	SortKey []byte
+	VideoID uuid.UUID
+}
+
+// ListSeededVideoIDsParams 描述确定性伪随机列表的查询条件。
+type ListSeededVideoIDsParams struct {
+	// Seed 决定排列顺序，相同 Seed 在投影不变时总是得到相同顺序。
+	Seed  string
+	After *SeededVideoCursor
+	Limit int
+}
+
+// SeededVideoID 为确定性伪随机列表中的一条记录，SortKey 为 sha256(seed || video_id)。
+type SeededVideoID struct {
+	VideoID uuid.UUID
+	SortKey []byte
+}
+
+// ListSeededIDs 按 sha256(seed || video_id) 升序返回已发布视频，替代 random() 以获得可复现、可翻页的 Mock 推荐顺序。
+func (r *FeedVideoProjectionRepository) ListSeededIDs(ctx context.Context, sess txmanager.Session, params ListSeededVideoIDsParams) ([]SeededVideoID, error) {
+	if params.Limit <= 0 {
+		return nil, nil
+	}
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	dbParams := feeddb.ListSeededVideoIDsParams{
+		Seed:     params.Seed,
+		RowLimit: int32(params.Limit),
+	}
+	if params.After != nil {
+		dbParams.AfterSortKey = params.After.SortKey
+		dbParams.AfterVideoID = pgtype.UUID{Bytes: params.After.VideoID, Valid: true}
+	}
+	rows, err := queries.ListSeededVideoIDs(ctx, dbParams)
+	if err != nil {
+		return nil, fmt.Errorf("list seeded feed video ids: %w", err)
+	}
+	result := make([]SeededVideoID, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, SeededVideoID{VideoID: row.VideoID, SortKey: row.SortKey})
+	}
+	return result, nil
+}
+
+// ListRecentIDs 返回按发布时间倒序的 video_id 列表，供访客等非个性化场景使用。
+func (r *FeedVideoProjectionRepository) ListRecentIDs(ctx context.Context, sess txmanager.Session, limit int) ([]uuid.UUID, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	rows, err := queries.ListRecentVideoIDs(ctx, int32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("list recent feed video ids: %w", err)
+	}
+	ids := make([]uuid.UUID, len(rows))
+	copy(ids, rows)
+	return ids, nil
+}
+
 // ListRandomIDs 返回随机挑选的 video_id 列表。
 func (r *FeedVideoProjectionRepository) ListRandomIDs(ctx context.Context, sess txmanager.Session, limit int) ([]uuid.UUID, error) {
 	if limit <= 0 {
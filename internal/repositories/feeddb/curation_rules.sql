@@ -0,0 +1,113 @@
+-- name: InsertCurationRule :one
+insert into feed.curation_rules (
+  action,
+  video_id,
+  scene,
+  locale,
+  user_segment,
+  position,
+  priority,
+  starts_at,
+  ends_at,
+  note
+)
+values (
+  sqlc.arg(action),
+  sqlc.arg(video_id),
+  sqlc.narg(scene),
+  sqlc.narg(locale),
+  sqlc.narg(user_segment),
+  sqlc.narg(position),
+  sqlc.arg(priority),
+  sqlc.narg(starts_at),
+  sqlc.narg(ends_at),
+  sqlc.narg(note)
+)
+returning
+  rule_id,
+  action,
+  video_id,
+  scene,
+  locale,
+  user_segment,
+  position,
+  priority,
+  starts_at,
+  ends_at,
+  note,
+  created_at,
+  updated_at;
+
+-- name: UpdateCurationRule :one
+-- 整体覆盖规则内容，规则不存在时返回 no rows。
+update feed.curation_rules
+set action       = sqlc.arg(action),
+    video_id     = sqlc.arg(video_id),
+    scene        = sqlc.narg(scene),
+    locale       = sqlc.narg(locale),
+    user_segment = sqlc.narg(user_segment),
+    position     = sqlc.narg(position),
+    priority     = sqlc.arg(priority),
+    starts_at    = sqlc.narg(starts_at),
+    ends_at      = sqlc.narg(ends_at),
+    note         = sqlc.narg(note),
+    updated_at   = now()
+where rule_id = sqlc.arg(rule_id)
+returning
+  rule_id,
+  action,
+  video_id,
+  scene,
+  locale,
+  user_segment,
+  position,
+  priority,
+  starts_at,
+  ends_at,
+  note,
+  created_at,
+  updated_at;
+
+-- name: DeleteCurationRule :execrows
+delete from feed.curation_rules
+where rule_id = sqlc.arg(rule_id);
+
+-- name: GetCurationRule :one
+select
+  rule_id,
+  action,
+  video_id,
+  scene,
+  locale,
+  user_segment,
+  position,
+  priority,
+  starts_at,
+  ends_at,
+  note,
+  created_at,
+  updated_at
+from feed.curation_rules
+where rule_id = sqlc.arg(rule_id);
+
+-- name: ListCurationRules :many
+-- 管理端列表：include_expired 为 false 时仅返回未过期的规则。
+select
+  rule_id,
+  action,
+  video_id,
+  scene,
+  locale,
+  user_segment,
+  position,
+  priority,
+  starts_at,
+  ends_at,
+  note,
+  created_at,
+  updated_at
+from feed.curation_rules
+where sqlc.arg(include_expired)::boolean
+   or ends_at is null
+   or ends_at > sqlc.arg(now)
+order by priority desc, created_at asc, rule_id asc;
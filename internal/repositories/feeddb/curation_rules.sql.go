@@ -0,0 +1,292 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: curation_rules.sql
+
+package feeddb
+
+import (
+	"context"
+
+	uuid "github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const deleteCurationRule = `-- name: DeleteCurationRule :execrows
+delete from feed.curation_rules
+where rule_id = $1
+`
+
+func (q *Queries) DeleteCurationRule(ctx context.Context, ruleID uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCurationRule, ruleID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getCurationRule = `-- name: GetCurationRule :one
+select
+  rule_id,
+  action,
+  video_id,
+  scene,
+  locale,
+  user_segment,
+  position,
+  priority,
+  starts_at,
+  ends_at,
+  note,
+  created_at,
+  updated_at
+from feed.curation_rules
+where rule_id = $1
+`
+
+func (q *Queries) GetCurationRule(ctx context.Context, ruleID uuid.UUID) (FeedCurationRule, error) {
+	row := q.db.QueryRow(ctx, getCurationRule, ruleID)
+	var i FeedCurationRule
+	err := row.Scan(
+		&i.RuleID,
+		&i.Action,
+		&i.VideoID,
+		&i.Scene,
+		&i.Locale,
+		&i.UserSegment,
+		&i.Position,
+		&i.Priority,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.Note,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const insertCurationRule = `-- name: InsertCurationRule :one
+insert into feed.curation_rules (
+  action,
+  video_id,
+  scene,
+  locale,
+  user_segment,
+  position,
+  priority,
+  starts_at,
+  ends_at,
+  note
+)
+values (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  $7,
+  $8,
+  $9,
+  $10
+)
+returning
+  rule_id,
+  action,
+  video_id,
+  scene,
+  locale,
+  user_segment,
+  position,
+  priority,
+  starts_at,
+  ends_at,
+  note,
+  created_at,
+  updated_at
+`
+
+type InsertCurationRuleParams struct {
+	Action      string             `json:"action"`
+	VideoID     uuid.UUID          `json:"video_id"`
+	Scene       pgtype.Text        `json:"scene"`
+	Locale      pgtype.Text        `json:"locale"`
+	UserSegment pgtype.Text        `json:"user_segment"`
+	Position    pgtype.Int4        `json:"position"`
+	Priority    int32              `json:"priority"`
+	StartsAt    pgtype.Timestamptz `json:"starts_at"`
+	EndsAt      pgtype.Timestamptz `json:"ends_at"`
+	Note        pgtype.Text        `json:"note"`
+}
+
+func (q *Queries) InsertCurationRule(ctx context.Context, arg InsertCurationRuleParams) (FeedCurationRule, error) {
+	row := q.db.QueryRow(ctx, insertCurationRule,
+		arg.Action,
+		arg.VideoID,
+		arg.Scene,
+		arg.Locale,
+		arg.UserSegment,
+		arg.Position,
+		arg.Priority,
+		arg.StartsAt,
+		arg.EndsAt,
+		arg.Note,
+	)
+	var i FeedCurationRule
+	err := row.Scan(
+		&i.RuleID,
+		&i.Action,
+		&i.VideoID,
+		&i.Scene,
+		&i.Locale,
+		&i.UserSegment,
+		&i.Position,
+		&i.Priority,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.Note,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listCurationRules = `-- name: ListCurationRules :many
+select
+  rule_id,
+  action,
+  video_id,
+  scene,
+  locale,
+  user_segment,
+  position,
+  priority,
+  starts_at,
+  ends_at,
+  note,
+  created_at,
+  updated_at
+from feed.curation_rules
+where $1::boolean
+   or ends_at is null
+   or ends_at > $2
+order by priority desc, created_at asc, rule_id asc
+`
+
+type ListCurationRulesParams struct {
+	IncludeExpired bool               `json:"include_expired"`
+	Now            pgtype.Timestamptz `json:"now"`
+}
+
+// 管理端列表：include_expired 为 false 时仅返回未过期的规则。
+func (q *Queries) ListCurationRules(ctx context.Context, arg ListCurationRulesParams) ([]FeedCurationRule, error) {
+	rows, err := q.db.Query(ctx, listCurationRules, arg.IncludeExpired, arg.Now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FeedCurationRule{}
+	for rows.Next() {
+		var i FeedCurationRule
+		if err := rows.Scan(
+			&i.RuleID,
+			&i.Action,
+			&i.VideoID,
+			&i.Scene,
+			&i.Locale,
+			&i.UserSegment,
+			&i.Position,
+			&i.Priority,
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.Note,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateCurationRule = `-- name: UpdateCurationRule :one
+update feed.curation_rules
+set action       = $1,
+    video_id     = $2,
+    scene        = $3,
+    locale       = $4,
+    user_segment = $5,
+    position     = $6,
+    priority     = $7,
+    starts_at    = $8,
+    ends_at      = $9,
+    note         = $10,
+    updated_at   = now()
+where rule_id = $11
+returning
+  rule_id,
+  action,
+  video_id,
+  scene,
+  locale,
+  user_segment,
+  position,
+  priority,
+  starts_at,
+  ends_at,
+  note,
+  created_at,
+  updated_at
+`
+
+type UpdateCurationRuleParams struct {
+	Action      string             `json:"action"`
+	VideoID     uuid.UUID          `json:"video_id"`
+	Scene       pgtype.Text        `json:"scene"`
+	Locale      pgtype.Text        `json:"locale"`
+	UserSegment pgtype.Text        `json:"user_segment"`
+	Position    pgtype.Int4        `json:"position"`
+	Priority    int32              `json:"priority"`
+	StartsAt    pgtype.Timestamptz `json:"starts_at"`
+	EndsAt      pgtype.Timestamptz `json:"ends_at"`
+	Note        pgtype.Text        `json:"note"`
+	RuleID      uuid.UUID          `json:"rule_id"`
+}
+
+// 整体覆盖规则内容，规则不存在时返回 no rows。
+func (q *Queries) UpdateCurationRule(ctx context.Context, arg UpdateCurationRuleParams) (FeedCurationRule, error) {
+	row := q.db.QueryRow(ctx, updateCurationRule,
+		arg.Action,
+		arg.VideoID,
+		arg.Scene,
+		arg.Locale,
+		arg.UserSegment,
+		arg.Position,
+		arg.Priority,
+		arg.StartsAt,
+		arg.EndsAt,
+		arg.Note,
+		arg.RuleID,
+	)
+	var i FeedCurationRule
+	err := row.Scan(
+		&i.RuleID,
+		&i.Action,
+		&i.VideoID,
+		&i.Scene,
+		&i.Locale,
+		&i.UserSegment,
+		&i.Position,
+		&i.Priority,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.Note,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,56 @@
+-- name: GetIdempotencySnapshot :one
+select
+  user_id_hash,
+  idempotency_key,
+  request_limit,
+  recommendation_source,
+  items,
+  next_cursor,
+  created_at,
+  expires_at,
+  request_hash,
+  user_id_key_version
+from feed.idempotency_snapshots
+where user_id_hash = sqlc.arg(user_id_hash)
+  and idempotency_key = sqlc.arg(idempotency_key)
+  and expires_at > now();
+
+-- name: InsertIdempotencySnapshot :execrows
+insert into feed.idempotency_snapshots (
+  user_id_hash,
+  user_id_key_version,
+  idempotency_key,
+  request_limit,
+  recommendation_source,
+  items,
+  next_cursor,
+  created_at,
+  expires_at,
+  request_hash
+)
+values (
+  sqlc.arg(user_id_hash),
+  sqlc.narg(user_id_key_version),
+  sqlc.arg(idempotency_key),
+  sqlc.arg(request_limit),
+  sqlc.arg(recommendation_source),
+  coalesce(sqlc.arg(items), '[]'::jsonb),
+  sqlc.narg(next_cursor),
+  now(),
+  sqlc.arg(expires_at),
+  sqlc.narg(request_hash)
+)
+on conflict (user_id_hash, idempotency_key) do update
+set user_id_key_version   = excluded.user_id_key_version,
+    request_limit         = excluded.request_limit,
+    recommendation_source = excluded.recommendation_source,
+    items                 = excluded.items,
+    next_cursor           = excluded.next_cursor,
+    created_at            = excluded.created_at,
+    expires_at            = excluded.expires_at,
+    request_hash          = excluded.request_hash
+where feed.idempotency_snapshots.expires_at <= now();
+
+-- name: DeleteExpiredIdempotencySnapshots :execrows
+delete from feed.idempotency_snapshots
+where expires_at <= sqlc.arg(before);
@@ -0,0 +1,133 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: idempotency_snapshots.sql
+
+package feeddb
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const deleteExpiredIdempotencySnapshots = `-- name: DeleteExpiredIdempotencySnapshots :execrows
+delete from feed.idempotency_snapshots
+where expires_at <= $1
+`
+
+func (q *Queries) DeleteExpiredIdempotencySnapshots(ctx context.Context, before pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredIdempotencySnapshots, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getIdempotencySnapshot = `-- name: GetIdempotencySnapshot :one
+select
+  user_id_hash,
+  idempotency_key,
+  request_limit,
+  recommendation_source,
+  items,
+  next_cursor,
+  created_at,
+  expires_at,
+  request_hash,
+  user_id_key_version
+from feed.idempotency_snapshots
+where user_id_hash = $1
+  and idempotency_key = $2
+  and expires_at > now()
+`
+
+type GetIdempotencySnapshotParams struct {
+	UserIDHash     string `json:"user_id_hash"`
+	IdempotencyKey string `json:"idempotency_key"`
+}
+
+func (q *Queries) GetIdempotencySnapshot(ctx context.Context, arg GetIdempotencySnapshotParams) (FeedIdempotencySnapshot, error) {
+	row := q.db.QueryRow(ctx, getIdempotencySnapshot, arg.UserIDHash, arg.IdempotencyKey)
+	var i FeedIdempotencySnapshot
+	err := row.Scan(
+		&i.UserIDHash,
+		&i.IdempotencyKey,
+		&i.RequestLimit,
+		&i.RecommendationSource,
+		&i.Items,
+		&i.NextCursor,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.RequestHash,
+		&i.UserIDKeyVersion,
+	)
+	return i, err
+}
+
+const insertIdempotencySnapshot = `-- name: InsertIdempotencySnapshot :execrows
+insert into feed.idempotency_snapshots (
+  user_id_hash,
+  user_id_key_version,
+  idempotency_key,
+  request_limit,
+  recommendation_source,
+  items,
+  next_cursor,
+  created_at,
+  expires_at,
+  request_hash
+)
+values (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  coalesce($6, '[]'::jsonb),
+  $7,
+  now(),
+  $8,
+  $9
+)
+on conflict (user_id_hash, idempotency_key) do update
+set user_id_key_version   = excluded.user_id_key_version,
+    request_limit         = excluded.request_limit,
+    recommendation_source = excluded.recommendation_source,
+    items                 = excluded.items,
+    next_cursor           = excluded.next_cursor,
+    created_at            = excluded.created_at,
+    expires_at            = excluded.expires_at,
+    request_hash          = excluded.request_hash
+where feed.idempotency_snapshots.expires_at <= now()
+`
+
+type InsertIdempotencySnapshotParams struct {
+	UserIDHash           string             `json:"user_id_hash"`
+	UserIDKeyVersion     pgtype.Text        `json:"user_id_key_version"`
+	IdempotencyKey       string             `json:"idempotency_key"`
+	RequestLimit         int32              `json:"request_limit"`
+	RecommendationSource string             `json:"recommendation_source"`
+	Items                interface{}        `json:"items"`
+	NextCursor           pgtype.Text        `json:"next_cursor"`
+	ExpiresAt            pgtype.Timestamptz `json:"expires_at"`
+	RequestHash          pgtype.Text        `json:"request_hash"`
+}
+
+func (q *Queries) InsertIdempotencySnapshot(ctx context.Context, arg InsertIdempotencySnapshotParams) (int64, error) {
+	result, err := q.db.Exec(ctx, insertIdempotencySnapshot,
+		arg.UserIDHash,
+		arg.UserIDKeyVersion,
+		arg.IdempotencyKey,
+		arg.RequestLimit,
+		arg.RecommendationSource,
+		arg.Items,
+		arg.NextCursor,
+		arg.ExpiresAt,
+		arg.RequestHash,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
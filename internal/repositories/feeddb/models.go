@@ -9,6 +9,35 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type FeedCurationRule struct {
+	RuleID      uuid.UUID          `json:"rule_id"`
+	Action      string             `json:"action"`
+	VideoID     uuid.UUID          `json:"video_id"`
+	Scene       pgtype.Text        `json:"scene"`
+	Locale      pgtype.Text        `json:"locale"`
+	UserSegment pgtype.Text        `json:"user_segment"`
+	Position    pgtype.Int4        `json:"position"`
+	Priority    int32              `json:"priority"`
+	StartsAt    pgtype.Timestamptz `json:"starts_at"`
+	EndsAt      pgtype.Timestamptz `json:"ends_at"`
+	Note        pgtype.Text        `json:"note"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type FeedIdempotencySnapshot struct {
+	UserIDHash           string             `json:"user_id_hash"`
+	IdempotencyKey       string             `json:"idempotency_key"`
+	RequestLimit         int32              `json:"request_limit"`
+	RecommendationSource string             `json:"recommendation_source"`
+	Items                []byte             `json:"items"`
+	NextCursor           pgtype.Text        `json:"next_cursor"`
+	CreatedAt            pgtype.Timestamptz `json:"created_at"`
+	ExpiresAt            pgtype.Timestamptz `json:"expires_at"`
+	RequestHash          pgtype.Text        `json:"request_hash"`
+	UserIDKeyVersion     pgtype.Text        `json:"user_id_key_version"`
+}
+
 type FeedInboxEvent struct {
 	EventID       uuid.UUID          `json:"event_id"`
 	SourceService string             `json:"source_service"`
@@ -21,6 +50,29 @@ type FeedInboxEvent struct {
 	LastError     pgtype.Text        `json:"last_error"`
 }
 
+type FeedOutboxEvent struct {
+	EventID          uuid.UUID          `json:"event_id"`
+	AggregateType    string             `json:"aggregate_type"`
+	AggregateID      uuid.UUID          `json:"aggregate_id"`
+	EventType        string             `json:"event_type"`
+	Payload          []byte             `json:"payload"`
+	Headers          []byte             `json:"headers"`
+	OccurredAt       pgtype.Timestamptz `json:"occurred_at"`
+	AvailableAt      pgtype.Timestamptz `json:"available_at"`
+	PublishedAt      pgtype.Timestamptz `json:"published_at"`
+	DeliveryAttempts int32              `json:"delivery_attempts"`
+	LastError        pgtype.Text        `json:"last_error"`
+	LockToken        pgtype.Text        `json:"lock_token"`
+	LockedAt         pgtype.Timestamptz `json:"locked_at"`
+}
+
+type FeedRateLimitBucket struct {
+	BucketKey   string             `json:"bucket_key"`
+	Tokens      float64            `json:"tokens"`
+	LastAllowed bool               `json:"last_allowed"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
 type FeedRecommendationLog struct {
 	LogID                   uuid.UUID          `json:"log_id"`
 	UserID                  pgtype.Text        `json:"user_id"`
@@ -31,6 +83,64 @@ type FeedRecommendationLog struct {
 	MissingVideoIds         []byte             `json:"missing_video_ids"`
 	ErrorKind               pgtype.Text        `json:"error_kind"`
 	GeneratedAt             pgtype.Timestamptz `json:"generated_at"`
+	IdempotencyKey          pgtype.Text        `json:"idempotency_key"`
+	Replayed                bool               `json:"replayed"`
+	Guest                   bool               `json:"guest"`
+	UserIDHash              pgtype.Text        `json:"user_id_hash"`
+	UserIDKeyVersion        pgtype.Text        `json:"user_id_key_version"`
+	ServedItems             []byte             `json:"served_items"`
+	RequestID               pgtype.Text        `json:"request_id"`
+	TraceID                 pgtype.Text        `json:"trace_id"`
+	Scene                   pgtype.Text        `json:"scene"`
+	Page                    pgtype.Int4        `json:"page"`
+	Experiments             []byte             `json:"experiments"`
+}
+
+type FeedReviewSchedule struct {
+	UserID         string             `json:"user_id"`
+	VideoID        uuid.UUID          `json:"video_id"`
+	DueAt          pgtype.Timestamptz `json:"due_at"`
+	Stability      float64            `json:"stability"`
+	Difficulty     float64            `json:"difficulty"`
+	Reps           int32              `json:"reps"`
+	LastReviewedAt pgtype.Timestamptz `json:"last_reviewed_at"`
+	Active         bool               `json:"active"`
+	Version        int64              `json:"version"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+type FeedServedPage struct {
+	LogID                uuid.UUID          `json:"log_id"`
+	UserIDHash           pgtype.Text        `json:"user_id_hash"`
+	UserIDKeyVersion     pgtype.Text        `json:"user_id_key_version"`
+	Guest                bool               `json:"guest"`
+	Scene                pgtype.Text        `json:"scene"`
+	RecommendationSource string             `json:"recommendation_source"`
+	ServedItems          []byte             `json:"served_items"`
+	GeneratedAt          pgtype.Timestamptz `json:"generated_at"`
+}
+
+type FeedUserVideoState struct {
+	UserID             string             `json:"user_id"`
+	VideoID            uuid.UUID          `json:"video_id"`
+	Liked              bool               `json:"liked"`
+	LikedVersion       int64              `json:"liked_version"`
+	Bookmarked         bool               `json:"bookmarked"`
+	BookmarkedVersion  int64              `json:"bookmarked_version"`
+	WatchedRatio       float64            `json:"watched_ratio"`
+	LastWatchedAt      pgtype.Timestamptz `json:"last_watched_at"`
+	ProgressVersion    int64              `json:"progress_version"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+	LastPositionMicros pgtype.Int8        `json:"last_position_micros"`
+}
+
+type FeedUserWatchHistory struct {
+	UserID          string             `json:"user_id"`
+	VideoID         uuid.UUID          `json:"video_id"`
+	MaxWatchedRatio float64            `json:"max_watched_ratio"`
+	FirstWatchedAt  pgtype.Timestamptz `json:"first_watched_at"`
+	LastWatchedAt   pgtype.Timestamptz `json:"last_watched_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
 }
 
 type FeedVideosProjection struct {
@@ -45,4 +155,7 @@ type FeedVideosProjection struct {
 	PublishedAt       pgtype.Timestamptz `json:"published_at"`
 	Version           int64              `json:"version"`
 	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+	CreatorID         pgtype.Text        `json:"creator_id"`
+	PrimaryLanguage   pgtype.Text        `json:"primary_language"`
+	Tags              []string           `json:"tags"`
 }
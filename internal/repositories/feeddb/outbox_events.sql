@@ -0,0 +1,4 @@
+-- name: ListExistingOutboxEventIDs :many
+select event_id
+from feed.outbox_events
+where event_id = any(sqlc.arg(event_ids)::uuid[]);
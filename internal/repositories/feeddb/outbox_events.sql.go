@@ -0,0 +1,38 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: outbox_events.sql
+
+package feeddb
+
+import (
+	"context"
+
+	uuid "github.com/google/uuid"
+)
+
+const listExistingOutboxEventIDs = `-- name: ListExistingOutboxEventIDs :many
+select event_id
+from feed.outbox_events
+where event_id = any($1::uuid[])
+`
+
+func (q *Queries) ListExistingOutboxEventIDs(ctx context.Context, eventIds []uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listExistingOutboxEventIDs, eventIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var event_id uuid.UUID
+		if err := rows.Scan(&event_id); err != nil {
+			return nil, err
+		}
+		items = append(items, event_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
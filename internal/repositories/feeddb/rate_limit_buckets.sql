@@ -0,0 +1,27 @@
+-- name: TakeRateLimitToken :one
+-- 原子地补充并尝试扣减一个令牌，last_allowed 表示本次是否放行。
+insert into feed.rate_limit_buckets as b (
+  bucket_key,
+  tokens,
+  last_allowed,
+  updated_at
+)
+values (
+  sqlc.arg(bucket_key),
+  sqlc.arg(burst)::float8 - 1,
+  true,
+  now()
+)
+on conflict (bucket_key) do update
+set tokens = case
+      when least(sqlc.arg(burst)::float8, b.tokens + extract(epoch from (now() - b.updated_at))::float8 * sqlc.arg(rate)::float8) >= 1
+        then least(sqlc.arg(burst)::float8, b.tokens + extract(epoch from (now() - b.updated_at))::float8 * sqlc.arg(rate)::float8) - 1
+      else least(sqlc.arg(burst)::float8, b.tokens + extract(epoch from (now() - b.updated_at))::float8 * sqlc.arg(rate)::float8)
+    end,
+    last_allowed = least(sqlc.arg(burst)::float8, b.tokens + extract(epoch from (now() - b.updated_at))::float8 * sqlc.arg(rate)::float8) >= 1,
+    updated_at = now()
+returning tokens, last_allowed;
+
+-- name: DeleteIdleRateLimitBuckets :execrows
+delete from feed.rate_limit_buckets
+where updated_at < sqlc.arg(before);
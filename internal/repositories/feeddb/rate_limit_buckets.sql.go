@@ -0,0 +1,68 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: rate_limit_buckets.sql
+
+package feeddb
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const deleteIdleRateLimitBuckets = `-- name: DeleteIdleRateLimitBuckets :execrows
+delete from feed.rate_limit_buckets
+where updated_at < $1
+`
+
+func (q *Queries) DeleteIdleRateLimitBuckets(ctx context.Context, before pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteIdleRateLimitBuckets, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const takeRateLimitToken = `-- name: TakeRateLimitToken :one
+insert into feed.rate_limit_buckets as b (
+  bucket_key,
+  tokens,
+  last_allowed,
+  updated_at
+)
+values (
+  $1,
+  $2::float8 - 1,
+  true,
+  now()
+)
+on conflict (bucket_key) do update
+set tokens = case
+      when least($2::float8, b.tokens + extract(epoch from (now() - b.updated_at))::float8 * $3::float8) >= 1
+        then least($2::float8, b.tokens + extract(epoch from (now() - b.updated_at))::float8 * $3::float8) - 1
+      else least($2::float8, b.tokens + extract(epoch from (now() - b.updated_at))::float8 * $3::float8)
+    end,
+    last_allowed = least($2::float8, b.tokens + extract(epoch from (now() - b.updated_at))::float8 * $3::float8) >= 1,
+    updated_at = now()
+returning tokens, last_allowed
+`
+
+type TakeRateLimitTokenParams struct {
+	BucketKey string  `json:"bucket_key"`
+	Burst     float64 `json:"burst"`
+	Rate      float64 `json:"rate"`
+}
+
+type TakeRateLimitTokenRow struct {
+	Tokens      float64 `json:"tokens"`
+	LastAllowed bool    `json:"last_allowed"`
+}
+
+// 原子地补充并尝试扣减一个令牌，last_allowed 表示本次是否放行。
+func (q *Queries) TakeRateLimitToken(ctx context.Context, arg TakeRateLimitTokenParams) (TakeRateLimitTokenRow, error) {
+	row := q.db.QueryRow(ctx, takeRateLimitToken, arg.BucketKey, arg.Burst, arg.Rate)
+	var i TakeRateLimitTokenRow
+	err := row.Scan(&i.Tokens, &i.LastAllowed)
+	return i, err
+}
@@ -0,0 +1,12 @@
+-- name: EnsureRecommendationLogPartitions :one
+select feed.ensure_recommendation_log_partitions(sqlc.arg(from_day)::date, sqlc.arg(days)::integer)::integer as created;
+
+-- name: DropRecommendationLogPartitions :many
+select partition_name::text
+from feed.drop_recommendation_log_partitions(sqlc.arg(before_day)::date) as partition_name;
+
+-- name: PurgeRecommendationLogDefault :one
+select feed.purge_recommendation_log_default(sqlc.arg(before)::timestamptz)::bigint as deleted;
+
+-- name: CountRecommendationLogDefault :one
+select feed.count_recommendation_log_default()::bigint as remaining;
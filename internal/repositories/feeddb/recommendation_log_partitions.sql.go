@@ -0,0 +1,75 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: recommendation_log_partitions.sql
+
+package feeddb
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const countRecommendationLogDefault = `-- name: CountRecommendationLogDefault :one
+select feed.count_recommendation_log_default()::bigint as remaining
+`
+
+func (q *Queries) CountRecommendationLogDefault(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countRecommendationLogDefault)
+	var remaining int64
+	err := row.Scan(&remaining)
+	return remaining, err
+}
+
+const dropRecommendationLogPartitions = `-- name: DropRecommendationLogPartitions :many
+select partition_name::text
+from feed.drop_recommendation_log_partitions($1::date) as partition_name
+`
+
+func (q *Queries) DropRecommendationLogPartitions(ctx context.Context, beforeDay pgtype.Date) ([]string, error) {
+	rows, err := q.db.Query(ctx, dropRecommendationLogPartitions, beforeDay)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var partition_name string
+		if err := rows.Scan(&partition_name); err != nil {
+			return nil, err
+		}
+		items = append(items, partition_name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ensureRecommendationLogPartitions = `-- name: EnsureRecommendationLogPartitions :one
+select feed.ensure_recommendation_log_partitions($1::date, $2::integer)::integer as created
+`
+
+type EnsureRecommendationLogPartitionsParams struct {
+	FromDay pgtype.Date `json:"from_day"`
+	Days    int32       `json:"days"`
+}
+
+func (q *Queries) EnsureRecommendationLogPartitions(ctx context.Context, arg EnsureRecommendationLogPartitionsParams) (int32, error) {
+	row := q.db.QueryRow(ctx, ensureRecommendationLogPartitions, arg.FromDay, arg.Days)
+	var created int32
+	err := row.Scan(&created)
+	return created, err
+}
+
+const purgeRecommendationLogDefault = `-- name: PurgeRecommendationLogDefault :one
+select feed.purge_recommendation_log_default($1::timestamptz)::bigint as deleted
+`
+
+func (q *Queries) PurgeRecommendationLogDefault(ctx context.Context, before pgtype.Timestamptz) (int64, error) {
+	row := q.db.QueryRow(ctx, purgeRecommendationLogDefault, before)
+	var deleted int64
+	err := row.Scan(&deleted)
+	return deleted, err
+}
@@ -1,5 +1,6 @@
 -- name: InsertRecommendationLog :exec
 insert into feed.recommendation_logs (
+  log_id,
   user_id,
   request_limit,
   recommendation_source,
@@ -7,9 +8,21 @@ insert into feed.recommendation_logs (
   recommended_items,
   missing_video_ids,
   error_kind,
+  idempotency_key,
+  replayed,
+  guest,
+  user_id_hash,
+  user_id_key_version,
+  served_items,
+  request_id,
+  trace_id,
+  scene,
+  page,
+  experiments,
   generated_at
 )
 values (
+  coalesce(sqlc.narg(log_id)::uuid, gen_random_uuid()),
   sqlc.arg(user_id),
   sqlc.arg(request_limit),
   sqlc.arg(recommendation_source),
@@ -17,6 +30,17 @@ values (
   coalesce(sqlc.arg(recommended_items), '[]'::jsonb),
   coalesce(sqlc.arg(missing_video_ids), '[]'::jsonb),
   sqlc.arg(error_kind),
+  sqlc.narg(idempotency_key),
+  sqlc.arg(replayed),
+  sqlc.arg(guest),
+  sqlc.narg(user_id_hash),
+  sqlc.narg(user_id_key_version),
+  coalesce(sqlc.arg(served_items), '[]'::jsonb),
+  sqlc.narg(request_id),
+  sqlc.narg(trace_id),
+  sqlc.narg(scene),
+  sqlc.narg(page),
+  coalesce(sqlc.arg(experiments), '[]'::jsonb),
   coalesce(sqlc.arg(generated_at), now())
 );
 
@@ -30,7 +54,18 @@ select
   recommended_items,
   missing_video_ids,
   error_kind,
-  generated_at
+  generated_at,
+  idempotency_key,
+  replayed,
+  guest,
+  user_id_hash,
+  user_id_key_version,
+  served_items,
+  request_id,
+  trace_id,
+  scene,
+  page,
+  experiments
 from feed.recommendation_logs
 where log_id = sqlc.arg(log_id);
 
@@ -44,12 +79,88 @@ select
   recommended_items,
   missing_video_ids,
   error_kind,
-  generated_at
+  generated_at,
+  idempotency_key,
+  replayed,
+  guest,
+  user_id_hash,
+  user_id_key_version,
+  served_items,
+  request_id,
+  trace_id,
+  scene,
+  page,
+  experiments
 from feed.recommendation_logs
 where
   (sqlc.narg(user_id)::text is null or user_id = sqlc.narg(user_id)) and
+  (sqlc.narg(user_id_hashes)::text[] is null or user_id_hash = any(sqlc.narg(user_id_hashes)::text[])) and
   (sqlc.narg(source)::text is null or recommendation_source = sqlc.narg(source)) and
+  (sqlc.narg(error_kind)::text is null or error_kind = sqlc.narg(error_kind)) and
+  (not sqlc.arg(missing_only)::boolean or jsonb_array_length(missing_video_ids) > 0) and
+  (sqlc.narg(since)::timestamptz is null or generated_at >= sqlc.narg(since)) and
+  (sqlc.narg(until)::timestamptz is null or generated_at < sqlc.narg(until)) and
+  (
+    sqlc.narg(after_generated_at)::timestamptz is null or
+    (generated_at, log_id) < (sqlc.narg(after_generated_at)::timestamptz, sqlc.narg(after_log_id)::uuid)
+  )
+order by generated_at desc, log_id desc
+limit sqlc.arg(row_limit);
+
+-- name: ListRecommendationLogsForExport :many
+select
+  log_id,
+  user_id,
+  request_limit,
+  recommendation_source,
+  recommendation_latency_ms,
+  recommended_items,
+  missing_video_ids,
+  error_kind,
+  generated_at,
+  idempotency_key,
+  replayed,
+  guest,
+  user_id_hash,
+  user_id_key_version,
+  served_items,
+  request_id,
+  trace_id,
+  scene,
+  page,
+  experiments
+from feed.recommendation_logs
+where
+  generated_at < sqlc.arg(until)::timestamptz and
   (sqlc.narg(since)::timestamptz is null or generated_at >= sqlc.narg(since)) and
-  (sqlc.narg(until)::timestamptz is null or generated_at < sqlc.narg(until))
-order by generated_at desc
+  (
+    sqlc.narg(after_generated_at)::timestamptz is null or
+    (generated_at, log_id) > (sqlc.narg(after_generated_at)::timestamptz, sqlc.narg(after_log_id)::uuid)
+  )
+order by generated_at, log_id
 limit sqlc.arg(row_limit);
+
+-- name: ListRecommendationLogsByIDs :many
+select
+  log_id,
+  user_id,
+  request_limit,
+  recommendation_source,
+  recommendation_latency_ms,
+  recommended_items,
+  missing_video_ids,
+  error_kind,
+  generated_at,
+  idempotency_key,
+  replayed,
+  guest,
+  user_id_hash,
+  user_id_key_version,
+  served_items,
+  request_id,
+  trace_id,
+  scene,
+  page,
+  experiments
+from feed.recommendation_logs
+where log_id = any(sqlc.arg(log_ids)::uuid[]);
@@ -22,7 +22,18 @@ select
   recommended_items,
   missing_video_ids,
   error_kind,
-  generated_at
+  generated_at,
+  idempotency_key,
+  replayed,
+  guest,
+  user_id_hash,
+  user_id_key_version,
+  served_items,
+  request_id,
+  trace_id,
+  scene,
+  page,
+  experiments
 from feed.recommendation_logs
 where log_id = $1
 `
@@ -40,12 +51,24 @@ func (q *Queries) GetRecommendationLog(ctx context.Context, logID uuid.UUID) (Fe
 		&i.MissingVideoIds,
 		&i.ErrorKind,
 		&i.GeneratedAt,
+		&i.IdempotencyKey,
+		&i.Replayed,
+		&i.Guest,
+		&i.UserIDHash,
+		&i.UserIDKeyVersion,
+		&i.ServedItems,
+		&i.RequestID,
+		&i.TraceID,
+		&i.Scene,
+		&i.Page,
+		&i.Experiments,
 	)
 	return i, err
 }
 
 const insertRecommendationLog = `-- name: InsertRecommendationLog :exec
 insert into feed.recommendation_logs (
+  log_id,
   user_id,
   request_limit,
   recommendation_source,
@@ -53,21 +76,45 @@ insert into feed.recommendation_logs (
   recommended_items,
   missing_video_ids,
   error_kind,
+  idempotency_key,
+  replayed,
+  guest,
+  user_id_hash,
+  user_id_key_version,
+  served_items,
+  request_id,
+  trace_id,
+  scene,
+  page,
+  experiments,
   generated_at
 )
 values (
-  $1,
+  coalesce($1::uuid, gen_random_uuid()),
   $2,
   $3,
   $4,
-  coalesce($5, '[]'::jsonb),
+  $5,
   coalesce($6, '[]'::jsonb),
-  $7,
-  coalesce($8, now())
+  coalesce($7, '[]'::jsonb),
+  $8,
+  $9,
+  $10,
+  $11,
+  $12,
+  $13,
+  coalesce($14, '[]'::jsonb),
+  $15,
+  $16,
+  $17,
+  $18,
+  coalesce($19, '[]'::jsonb),
+  coalesce($20, now())
 )
 `
 
 type InsertRecommendationLogParams struct {
+	LogID                   pgtype.UUID `json:"log_id"`
 	UserID                  pgtype.Text `json:"user_id"`
 	RequestLimit            int32       `json:"request_limit"`
 	RecommendationSource    string      `json:"recommendation_source"`
@@ -75,11 +122,23 @@ type InsertRecommendationLogParams struct {
 	RecommendedItems        interface{} `json:"recommended_items"`
 	MissingVideoIds         interface{} `json:"missing_video_ids"`
 	ErrorKind               pgtype.Text `json:"error_kind"`
+	IdempotencyKey          pgtype.Text `json:"idempotency_key"`
+	Replayed                bool        `json:"replayed"`
+	Guest                   bool        `json:"guest"`
+	UserIDHash              pgtype.Text `json:"user_id_hash"`
+	UserIDKeyVersion        pgtype.Text `json:"user_id_key_version"`
+	ServedItems             interface{} `json:"served_items"`
+	RequestID               pgtype.Text `json:"request_id"`
+	TraceID                 pgtype.Text `json:"trace_id"`
+	Scene                   pgtype.Text `json:"scene"`
+	Page                    pgtype.Int4 `json:"page"`
+	Experiments             interface{} `json:"experiments"`
 	GeneratedAt             interface{} `json:"generated_at"`
 }
 
 func (q *Queries) InsertRecommendationLog(ctx context.Context, arg InsertRecommendationLogParams) error {
 	_, err := q.db.Exec(ctx, insertRecommendationLog,
+		arg.LogID,
 		arg.UserID,
 		arg.RequestLimit,
 		arg.RecommendationSource,
@@ -87,6 +146,17 @@ func (q *Queries) InsertRecommendationLog(ctx context.Context, arg InsertRecomme
 		arg.RecommendedItems,
 		arg.MissingVideoIds,
 		arg.ErrorKind,
+		arg.IdempotencyKey,
+		arg.Replayed,
+		arg.Guest,
+		arg.UserIDHash,
+		arg.UserIDKeyVersion,
+		arg.ServedItems,
+		arg.RequestID,
+		arg.TraceID,
+		arg.Scene,
+		arg.Page,
+		arg.Experiments,
 		arg.GeneratedAt,
 	)
 	return err
@@ -102,31 +172,215 @@ select
   recommended_items,
   missing_video_ids,
   error_kind,
-  generated_at
+  generated_at,
+  idempotency_key,
+  replayed,
+  guest,
+  user_id_hash,
+  user_id_key_version,
+  served_items,
+  request_id,
+  trace_id,
+  scene,
+  page,
+  experiments
 from feed.recommendation_logs
 where
   ($1::text is null or user_id = $1) and
-  ($2::text is null or recommendation_source = $2) and
-  ($3::timestamptz is null or generated_at >= $3) and
-  ($4::timestamptz is null or generated_at < $4)
-order by generated_at desc
-limit $5
+  ($2::text[] is null or user_id_hash = any($2::text[])) and
+  ($3::text is null or recommendation_source = $3) and
+  ($4::text is null or error_kind = $4) and
+  (not $5::boolean or jsonb_array_length(missing_video_ids) > 0) and
+  ($6::timestamptz is null or generated_at >= $6) and
+  ($7::timestamptz is null or generated_at < $7) and
+  (
+    $8::timestamptz is null or
+    (generated_at, log_id) < ($8::timestamptz, $9::uuid)
+  )
+order by generated_at desc, log_id desc
+limit $10
 `
 
 type ListRecommendationLogsParams struct {
-	UserID   pgtype.Text        `json:"user_id"`
-	Source   pgtype.Text        `json:"source"`
-	Since    pgtype.Timestamptz `json:"since"`
-	Until    pgtype.Timestamptz `json:"until"`
-	RowLimit int32              `json:"row_limit"`
+	UserID           pgtype.Text        `json:"user_id"`
+	UserIDHashes     []string           `json:"user_id_hashes"`
+	Source           pgtype.Text        `json:"source"`
+	ErrorKind        pgtype.Text        `json:"error_kind"`
+	MissingOnly      bool               `json:"missing_only"`
+	Since            pgtype.Timestamptz `json:"since"`
+	Until            pgtype.Timestamptz `json:"until"`
+	AfterGeneratedAt pgtype.Timestamptz `json:"after_generated_at"`
+	AfterLogID       pgtype.UUID        `json:"after_log_id"`
+	RowLimit         int32              `json:"row_limit"`
 }
 
 func (q *Queries) ListRecommendationLogs(ctx context.Context, arg ListRecommendationLogsParams) ([]FeedRecommendationLog, error) {
 	rows, err := q.db.Query(ctx, listRecommendationLogs,
 		arg.UserID,
+		arg.UserIDHashes,
 		arg.Source,
+		arg.ErrorKind,
+		arg.MissingOnly,
 		arg.Since,
 		arg.Until,
+		arg.AfterGeneratedAt,
+		arg.AfterLogID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FeedRecommendationLog{}
+	for rows.Next() {
+		var i FeedRecommendationLog
+		if err := rows.Scan(
+			&i.LogID,
+			&i.UserID,
+			&i.RequestLimit,
+			&i.RecommendationSource,
+			&i.RecommendationLatencyMs,
+			&i.RecommendedItems,
+			&i.MissingVideoIds,
+			&i.ErrorKind,
+			&i.GeneratedAt,
+			&i.IdempotencyKey,
+			&i.Replayed,
+			&i.Guest,
+			&i.UserIDHash,
+			&i.UserIDKeyVersion,
+			&i.ServedItems,
+			&i.RequestID,
+			&i.TraceID,
+			&i.Scene,
+			&i.Page,
+			&i.Experiments,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecommendationLogsByIDs = `-- name: ListRecommendationLogsByIDs :many
+select
+  log_id,
+  user_id,
+  request_limit,
+  recommendation_source,
+  recommendation_latency_ms,
+  recommended_items,
+  missing_video_ids,
+  error_kind,
+  generated_at,
+  idempotency_key,
+  replayed,
+  guest,
+  user_id_hash,
+  user_id_key_version,
+  served_items,
+  request_id,
+  trace_id,
+  scene,
+  page,
+  experiments
+from feed.recommendation_logs
+where log_id = any($1::uuid[])
+`
+
+func (q *Queries) ListRecommendationLogsByIDs(ctx context.Context, logIds []uuid.UUID) ([]FeedRecommendationLog, error) {
+	rows, err := q.db.Query(ctx, listRecommendationLogsByIDs, logIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FeedRecommendationLog{}
+	for rows.Next() {
+		var i FeedRecommendationLog
+		if err := rows.Scan(
+			&i.LogID,
+			&i.UserID,
+			&i.RequestLimit,
+			&i.RecommendationSource,
+			&i.RecommendationLatencyMs,
+			&i.RecommendedItems,
+			&i.MissingVideoIds,
+			&i.ErrorKind,
+			&i.GeneratedAt,
+			&i.IdempotencyKey,
+			&i.Replayed,
+			&i.Guest,
+			&i.UserIDHash,
+			&i.UserIDKeyVersion,
+			&i.ServedItems,
+			&i.RequestID,
+			&i.TraceID,
+			&i.Scene,
+			&i.Page,
+			&i.Experiments,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecommendationLogsForExport = `-- name: ListRecommendationLogsForExport :many
+select
+  log_id,
+  user_id,
+  request_limit,
+  recommendation_source,
+  recommendation_latency_ms,
+  recommended_items,
+  missing_video_ids,
+  error_kind,
+  generated_at,
+  idempotency_key,
+  replayed,
+  guest,
+  user_id_hash,
+  user_id_key_version,
+  served_items,
+  request_id,
+  trace_id,
+  scene,
+  page,
+  experiments
+from feed.recommendation_logs
+where
+  generated_at < $1::timestamptz and
+  ($2::timestamptz is null or generated_at >= $2) and
+  (
+    $3::timestamptz is null or
+    (generated_at, log_id) > ($3::timestamptz, $4::uuid)
+  )
+order by generated_at, log_id
+limit $5
+`
+
+type ListRecommendationLogsForExportParams struct {
+	Until            pgtype.Timestamptz `json:"until"`
+	Since            pgtype.Timestamptz `json:"since"`
+	AfterGeneratedAt pgtype.Timestamptz `json:"after_generated_at"`
+	AfterLogID       pgtype.UUID        `json:"after_log_id"`
+	RowLimit         int32              `json:"row_limit"`
+}
+
+func (q *Queries) ListRecommendationLogsForExport(ctx context.Context, arg ListRecommendationLogsForExportParams) ([]FeedRecommendationLog, error) {
+	rows, err := q.db.Query(ctx, listRecommendationLogsForExport,
+		arg.Until,
+		arg.Since,
+		arg.AfterGeneratedAt,
+		arg.AfterLogID,
 		arg.RowLimit,
 	)
 	if err != nil {
@@ -146,6 +400,17 @@ func (q *Queries) ListRecommendationLogs(ctx context.Context, arg ListRecommenda
 			&i.MissingVideoIds,
 			&i.ErrorKind,
 			&i.GeneratedAt,
+			&i.IdempotencyKey,
+			&i.Replayed,
+			&i.Guest,
+			&i.UserIDHash,
+			&i.UserIDKeyVersion,
+			&i.ServedItems,
+			&i.RequestID,
+			&i.TraceID,
+			&i.Scene,
+			&i.Page,
+			&i.Experiments,
 		); err != nil {
 			return nil, err
 		}
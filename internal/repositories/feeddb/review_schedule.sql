@@ -0,0 +1,80 @@
+-- name: UpsertReviewSchedule :execrows
+-- 仅当事件版本更新时覆盖复习计划并重新激活，返回 0 表示事件已过时。
+insert into feed.review_schedule as s (
+  user_id,
+  video_id,
+  due_at,
+  stability,
+  difficulty,
+  reps,
+  last_reviewed_at,
+  active,
+  version,
+  updated_at
+)
+values (
+  sqlc.arg(user_id),
+  sqlc.arg(video_id),
+  sqlc.arg(due_at),
+  sqlc.arg(stability),
+  sqlc.arg(difficulty),
+  sqlc.arg(reps),
+  sqlc.narg(last_reviewed_at),
+  true,
+  sqlc.arg(version),
+  sqlc.arg(updated_at)
+)
+on conflict (user_id, video_id) do update
+set due_at           = excluded.due_at,
+    stability        = excluded.stability,
+    difficulty       = excluded.difficulty,
+    reps             = excluded.reps,
+    last_reviewed_at = excluded.last_reviewed_at,
+    active           = true,
+    version          = excluded.version,
+    updated_at       = greatest(s.updated_at, excluded.updated_at)
+where s.version < excluded.version;
+
+-- name: DeactivateReviewSchedule :execrows
+-- 仅当事件版本更新时将视频移出复习队列；记录不存在时写入一条失活记录占住版本。
+insert into feed.review_schedule as s (
+  user_id,
+  video_id,
+  due_at,
+  active,
+  version,
+  updated_at
+)
+values (
+  sqlc.arg(user_id),
+  sqlc.arg(video_id),
+  sqlc.arg(updated_at),
+  false,
+  sqlc.arg(version),
+  sqlc.arg(updated_at)
+)
+on conflict (user_id, video_id) do update
+set active     = false,
+    version    = excluded.version,
+    updated_at = greatest(s.updated_at, excluded.updated_at)
+where s.version < excluded.version;
+
+-- name: ListDueReviews :many
+-- 按到期时间升序列出用户已到期的复习计划。
+select
+  user_id,
+  video_id,
+  due_at,
+  stability,
+  difficulty,
+  reps,
+  last_reviewed_at,
+  active,
+  version,
+  updated_at
+from feed.review_schedule
+where user_id = sqlc.arg(user_id)
+  and active
+  and due_at <= sqlc.arg(now)
+order by due_at asc, video_id asc
+limit sqlc.arg(row_limit);
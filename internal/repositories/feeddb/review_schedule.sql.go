@@ -0,0 +1,184 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: review_schedule.sql
+
+package feeddb
+
+import (
+	"context"
+
+	uuid "github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const deactivateReviewSchedule = `-- name: DeactivateReviewSchedule :execrows
+insert into feed.review_schedule as s (
+  user_id,
+  video_id,
+  due_at,
+  active,
+  version,
+  updated_at
+)
+values (
+  $1,
+  $2,
+  $3,
+  false,
+  $4,
+  $3
+)
+on conflict (user_id, video_id) do update
+set active     = false,
+    version    = excluded.version,
+    updated_at = greatest(s.updated_at, excluded.updated_at)
+where s.version < excluded.version
+`
+
+type DeactivateReviewScheduleParams struct {
+	UserID    string             `json:"user_id"`
+	VideoID   uuid.UUID          `json:"video_id"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	Version   int64              `json:"version"`
+}
+
+// 仅当事件版本更新时将视频移出复习队列；记录不存在时写入一条失活记录占住版本。
+func (q *Queries) DeactivateReviewSchedule(ctx context.Context, arg DeactivateReviewScheduleParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deactivateReviewSchedule,
+		arg.UserID,
+		arg.VideoID,
+		arg.UpdatedAt,
+		arg.Version,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listDueReviews = `-- name: ListDueReviews :many
+select
+  user_id,
+  video_id,
+  due_at,
+  stability,
+  difficulty,
+  reps,
+  last_reviewed_at,
+  active,
+  version,
+  updated_at
+from feed.review_schedule
+where user_id = $1
+  and active
+  and due_at <= $2
+order by due_at asc, video_id asc
+limit $3
+`
+
+type ListDueReviewsParams struct {
+	UserID   string             `json:"user_id"`
+	Now      pgtype.Timestamptz `json:"now"`
+	RowLimit int32              `json:"row_limit"`
+}
+
+// 按到期时间升序列出用户已到期的复习计划。
+func (q *Queries) ListDueReviews(ctx context.Context, arg ListDueReviewsParams) ([]FeedReviewSchedule, error) {
+	rows, err := q.db.Query(ctx, listDueReviews, arg.UserID, arg.Now, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FeedReviewSchedule{}
+	for rows.Next() {
+		var i FeedReviewSchedule
+		if err := rows.Scan(
+			&i.UserID,
+			&i.VideoID,
+			&i.DueAt,
+			&i.Stability,
+			&i.Difficulty,
+			&i.Reps,
+			&i.LastReviewedAt,
+			&i.Active,
+			&i.Version,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertReviewSchedule = `-- name: UpsertReviewSchedule :execrows
+insert into feed.review_schedule as s (
+  user_id,
+  video_id,
+  due_at,
+  stability,
+  difficulty,
+  reps,
+  last_reviewed_at,
+  active,
+  version,
+  updated_at
+)
+values (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  $7,
+  true,
+  $8,
+  $9
+)
+on conflict (user_id, video_id) do update
+set due_at           = excluded.due_at,
+    stability        = excluded.stability,
+    difficulty       = excluded.difficulty,
+    reps             = excluded.reps,
+    last_reviewed_at = excluded.last_reviewed_at,
+    active           = true,
+    version          = excluded.version,
+    updated_at       = greatest(s.updated_at, excluded.updated_at)
+where s.version < excluded.version
+`
+
+type UpsertReviewScheduleParams struct {
+	UserID         string             `json:"user_id"`
+	VideoID        uuid.UUID          `json:"video_id"`
+	DueAt          pgtype.Timestamptz `json:"due_at"`
+	Stability      float64            `json:"stability"`
+	Difficulty     float64            `json:"difficulty"`
+	Reps           int32              `json:"reps"`
+	LastReviewedAt pgtype.Timestamptz `json:"last_reviewed_at"`
+	Version        int64              `json:"version"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+// 仅当事件版本更新时覆盖复习计划并重新激活，返回 0 表示事件已过时。
+func (q *Queries) UpsertReviewSchedule(ctx context.Context, arg UpsertReviewScheduleParams) (int64, error) {
+	result, err := q.db.Exec(ctx, upsertReviewSchedule,
+		arg.UserID,
+		arg.VideoID,
+		arg.DueAt,
+		arg.Stability,
+		arg.Difficulty,
+		arg.Reps,
+		arg.LastReviewedAt,
+		arg.Version,
+		arg.UpdatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
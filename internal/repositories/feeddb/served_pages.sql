@@ -0,0 +1,38 @@
+-- name: InsertServedPage :exec
+insert into feed.served_pages (
+  log_id,
+  user_id_hash,
+  user_id_key_version,
+  guest,
+  scene,
+  recommendation_source,
+  served_items,
+  generated_at
+)
+values (
+  sqlc.arg(log_id),
+  sqlc.narg(user_id_hash),
+  sqlc.narg(user_id_key_version),
+  sqlc.arg(guest),
+  sqlc.narg(scene),
+  sqlc.arg(recommendation_source),
+  sqlc.arg(served_items),
+  sqlc.arg(generated_at)
+);
+
+-- name: ListServedPagesByIDs :many
+select
+  log_id,
+  user_id_hash,
+  user_id_key_version,
+  guest,
+  scene,
+  recommendation_source,
+  served_items,
+  generated_at
+from feed.served_pages
+where log_id = any(sqlc.arg(log_ids)::uuid[]);
+
+-- name: PurgeServedPagesBefore :execrows
+delete from feed.served_pages
+where generated_at < sqlc.arg(before);
@@ -0,0 +1,117 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: served_pages.sql
+
+package feeddb
+
+import (
+	"context"
+
+	uuid "github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const insertServedPage = `-- name: InsertServedPage :exec
+insert into feed.served_pages (
+  log_id,
+  user_id_hash,
+  user_id_key_version,
+  guest,
+  scene,
+  recommendation_source,
+  served_items,
+  generated_at
+)
+values (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  $7,
+  $8
+)
+`
+
+type InsertServedPageParams struct {
+	LogID                uuid.UUID          `json:"log_id"`
+	UserIDHash           pgtype.Text        `json:"user_id_hash"`
+	UserIDKeyVersion     pgtype.Text        `json:"user_id_key_version"`
+	Guest                bool               `json:"guest"`
+	Scene                pgtype.Text        `json:"scene"`
+	RecommendationSource string             `json:"recommendation_source"`
+	ServedItems          []byte             `json:"served_items"`
+	GeneratedAt          pgtype.Timestamptz `json:"generated_at"`
+}
+
+func (q *Queries) InsertServedPage(ctx context.Context, arg InsertServedPageParams) error {
+	_, err := q.db.Exec(ctx, insertServedPage,
+		arg.LogID,
+		arg.UserIDHash,
+		arg.UserIDKeyVersion,
+		arg.Guest,
+		arg.Scene,
+		arg.RecommendationSource,
+		arg.ServedItems,
+		arg.GeneratedAt,
+	)
+	return err
+}
+
+const listServedPagesByIDs = `-- name: ListServedPagesByIDs :many
+select
+  log_id,
+  user_id_hash,
+  user_id_key_version,
+  guest,
+  scene,
+  recommendation_source,
+  served_items,
+  generated_at
+from feed.served_pages
+where log_id = any($1::uuid[])
+`
+
+func (q *Queries) ListServedPagesByIDs(ctx context.Context, logIds []uuid.UUID) ([]FeedServedPage, error) {
+	rows, err := q.db.Query(ctx, listServedPagesByIDs, logIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FeedServedPage{}
+	for rows.Next() {
+		var i FeedServedPage
+		if err := rows.Scan(
+			&i.LogID,
+			&i.UserIDHash,
+			&i.UserIDKeyVersion,
+			&i.Guest,
+			&i.Scene,
+			&i.RecommendationSource,
+			&i.ServedItems,
+			&i.GeneratedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeServedPagesBefore = `-- name: PurgeServedPagesBefore :execrows
+delete from feed.served_pages
+where generated_at < $1
+`
+
+func (q *Queries) PurgeServedPagesBefore(ctx context.Context, before pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeServedPagesBefore, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
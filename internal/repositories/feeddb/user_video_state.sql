@@ -0,0 +1,114 @@
+-- name: UpsertUserVideoLiked :execrows
+-- 仅当事件版本更新时覆盖点赞状态，返回 0 表示事件已过时。
+insert into feed.user_video_state as s (
+  user_id,
+  video_id,
+  liked,
+  liked_version,
+  updated_at
+)
+values (
+  sqlc.arg(user_id),
+  sqlc.arg(video_id),
+  sqlc.arg(liked),
+  sqlc.arg(version),
+  sqlc.arg(updated_at)
+)
+on conflict (user_id, video_id) do update
+set liked         = excluded.liked,
+    liked_version = excluded.liked_version,
+    updated_at    = greatest(s.updated_at, excluded.updated_at)
+where s.liked_version < excluded.liked_version;
+
+-- name: UpsertUserVideoBookmarked :execrows
+-- 仅当事件版本更新时覆盖收藏状态，返回 0 表示事件已过时。
+insert into feed.user_video_state as s (
+  user_id,
+  video_id,
+  bookmarked,
+  bookmarked_version,
+  updated_at
+)
+values (
+  sqlc.arg(user_id),
+  sqlc.arg(video_id),
+  sqlc.arg(bookmarked),
+  sqlc.arg(version),
+  sqlc.arg(updated_at)
+)
+on conflict (user_id, video_id) do update
+set bookmarked         = excluded.bookmarked,
+    bookmarked_version = excluded.bookmarked_version,
+    updated_at         = greatest(s.updated_at, excluded.updated_at)
+where s.bookmarked_version < excluded.bookmarked_version;
+
+-- name: UpsertUserVideoProgress :execrows
+-- 仅当事件版本更新时覆盖观看进度，返回 0 表示事件已过时。
+insert into feed.user_video_state as s (
+  user_id,
+  video_id,
+  watched_ratio,
+  last_position_micros,
+  last_watched_at,
+  progress_version,
+  updated_at
+)
+values (
+  sqlc.arg(user_id),
+  sqlc.arg(video_id),
+  sqlc.arg(watched_ratio),
+  sqlc.narg(last_position_micros),
+  sqlc.arg(last_watched_at),
+  sqlc.arg(version),
+  sqlc.arg(updated_at)
+)
+on conflict (user_id, video_id) do update
+set watched_ratio        = excluded.watched_ratio,
+    last_position_micros = excluded.last_position_micros,
+    last_watched_at      = excluded.last_watched_at,
+    progress_version     = excluded.progress_version,
+    updated_at           = greatest(s.updated_at, excluded.updated_at)
+where s.progress_version < excluded.progress_version;
+
+-- name: ListUserVideoStates :many
+select
+  user_id,
+  video_id,
+  liked,
+  liked_version,
+  bookmarked,
+  bookmarked_version,
+  watched_ratio,
+  last_watched_at,
+  progress_version,
+  updated_at,
+  last_position_micros
+from feed.user_video_state
+where user_id = sqlc.arg(user_id)
+  and video_id = any(sqlc.arg(video_ids)::uuid[]);
+
+-- name: ListContinueWatching :many
+-- 按 (last_watched_at, video_id) 倒序列出观看进度位于 [min_ratio, max_ratio] 的视频，键集分页。
+select
+  user_id,
+  video_id,
+  liked,
+  liked_version,
+  bookmarked,
+  bookmarked_version,
+  watched_ratio,
+  last_watched_at,
+  progress_version,
+  updated_at,
+  last_position_micros
+from feed.user_video_state
+where user_id = sqlc.arg(user_id)
+  and last_watched_at is not null
+  and watched_ratio >= sqlc.arg(min_ratio)
+  and watched_ratio <= sqlc.arg(max_ratio)
+  and (
+    sqlc.narg(after_watched_at)::timestamptz is null or
+    (last_watched_at, video_id) < (sqlc.narg(after_watched_at)::timestamptz, sqlc.narg(after_video_id)::uuid)
+  )
+order by last_watched_at desc, video_id desc
+limit sqlc.arg(row_limit);
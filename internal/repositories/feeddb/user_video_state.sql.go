@@ -0,0 +1,288 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: user_video_state.sql
+
+package feeddb
+
+import (
+	"context"
+
+	uuid "github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const listContinueWatching = `-- name: ListContinueWatching :many
+select
+  user_id,
+  video_id,
+  liked,
+  liked_version,
+  bookmarked,
+  bookmarked_version,
+  watched_ratio,
+  last_watched_at,
+  progress_version,
+  updated_at,
+  last_position_micros
+from feed.user_video_state
+where user_id = $1
+  and last_watched_at is not null
+  and watched_ratio >= $2
+  and watched_ratio <= $3
+  and (
+    $4::timestamptz is null or
+    (last_watched_at, video_id) < ($4::timestamptz, $5::uuid)
+  )
+order by last_watched_at desc, video_id desc
+limit $6
+`
+
+type ListContinueWatchingParams struct {
+	UserID         string             `json:"user_id"`
+	MinRatio       float64            `json:"min_ratio"`
+	MaxRatio       float64            `json:"max_ratio"`
+	AfterWatchedAt pgtype.Timestamptz `json:"after_watched_at"`
+	AfterVideoID   pgtype.UUID        `json:"after_video_id"`
+	RowLimit       int32              `json:"row_limit"`
+}
+
+// 按 (last_watched_at, video_id) 倒序列出观看进度位于 [min_ratio, max_ratio] 的视频，键集分页。
+func (q *Queries) ListContinueWatching(ctx context.Context, arg ListContinueWatchingParams) ([]FeedUserVideoState, error) {
+	rows, err := q.db.Query(ctx, listContinueWatching,
+		arg.UserID,
+		arg.MinRatio,
+		arg.MaxRatio,
+		arg.AfterWatchedAt,
+		arg.AfterVideoID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FeedUserVideoState{}
+	for rows.Next() {
+		var i FeedUserVideoState
+		if err := rows.Scan(
+			&i.UserID,
+			&i.VideoID,
+			&i.Liked,
+			&i.LikedVersion,
+			&i.Bookmarked,
+			&i.BookmarkedVersion,
+			&i.WatchedRatio,
+			&i.LastWatchedAt,
+			&i.ProgressVersion,
+			&i.UpdatedAt,
+			&i.LastPositionMicros,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserVideoStates = `-- name: ListUserVideoStates :many
+select
+  user_id,
+  video_id,
+  liked,
+  liked_version,
+  bookmarked,
+  bookmarked_version,
+  watched_ratio,
+  last_watched_at,
+  progress_version,
+  updated_at,
+  last_position_micros
+from feed.user_video_state
+where user_id = $1
+  and video_id = any($2::uuid[])
+`
+
+type ListUserVideoStatesParams struct {
+	UserID   string      `json:"user_id"`
+	VideoIds []uuid.UUID `json:"video_ids"`
+}
+
+func (q *Queries) ListUserVideoStates(ctx context.Context, arg ListUserVideoStatesParams) ([]FeedUserVideoState, error) {
+	rows, err := q.db.Query(ctx, listUserVideoStates, arg.UserID, arg.VideoIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FeedUserVideoState{}
+	for rows.Next() {
+		var i FeedUserVideoState
+		if err := rows.Scan(
+			&i.UserID,
+			&i.VideoID,
+			&i.Liked,
+			&i.LikedVersion,
+			&i.Bookmarked,
+			&i.BookmarkedVersion,
+			&i.WatchedRatio,
+			&i.LastWatchedAt,
+			&i.ProgressVersion,
+			&i.UpdatedAt,
+			&i.LastPositionMicros,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertUserVideoBookmarked = `-- name: UpsertUserVideoBookmarked :execrows
+insert into feed.user_video_state as s (
+  user_id,
+  video_id,
+  bookmarked,
+  bookmarked_version,
+  updated_at
+)
+values (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5
+)
+on conflict (user_id, video_id) do update
+set bookmarked         = excluded.bookmarked,
+    bookmarked_version = excluded.bookmarked_version,
+    updated_at         = greatest(s.updated_at, excluded.updated_at)
+where s.bookmarked_version < excluded.bookmarked_version
+`
+
+type UpsertUserVideoBookmarkedParams struct {
+	UserID     string             `json:"user_id"`
+	VideoID    uuid.UUID          `json:"video_id"`
+	Bookmarked bool               `json:"bookmarked"`
+	Version    int64              `json:"version"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
+// 仅当事件版本更新时覆盖收藏状态，返回 0 表示事件已过时。
+func (q *Queries) UpsertUserVideoBookmarked(ctx context.Context, arg UpsertUserVideoBookmarkedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, upsertUserVideoBookmarked,
+		arg.UserID,
+		arg.VideoID,
+		arg.Bookmarked,
+		arg.Version,
+		arg.UpdatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const upsertUserVideoLiked = `-- name: UpsertUserVideoLiked :execrows
+insert into feed.user_video_state as s (
+  user_id,
+  video_id,
+  liked,
+  liked_version,
+  updated_at
+)
+values (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5
+)
+on conflict (user_id, video_id) do update
+set liked         = excluded.liked,
+    liked_version = excluded.liked_version,
+    updated_at    = greatest(s.updated_at, excluded.updated_at)
+where s.liked_version < excluded.liked_version
+`
+
+type UpsertUserVideoLikedParams struct {
+	UserID    string             `json:"user_id"`
+	VideoID   uuid.UUID          `json:"video_id"`
+	Liked     bool               `json:"liked"`
+	Version   int64              `json:"version"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+// 仅当事件版本更新时覆盖点赞状态，返回 0 表示事件已过时。
+func (q *Queries) UpsertUserVideoLiked(ctx context.Context, arg UpsertUserVideoLikedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, upsertUserVideoLiked,
+		arg.UserID,
+		arg.VideoID,
+		arg.Liked,
+		arg.Version,
+		arg.UpdatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const upsertUserVideoProgress = `-- name: UpsertUserVideoProgress :execrows
+insert into feed.user_video_state as s (
+  user_id,
+  video_id,
+  watched_ratio,
+  last_position_micros,
+  last_watched_at,
+  progress_version,
+  updated_at
+)
+values (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  $7
+)
+on conflict (user_id, video_id) do update
+set watched_ratio        = excluded.watched_ratio,
+    last_position_micros = excluded.last_position_micros,
+    last_watched_at      = excluded.last_watched_at,
+    progress_version     = excluded.progress_version,
+    updated_at           = greatest(s.updated_at, excluded.updated_at)
+where s.progress_version < excluded.progress_version
+`
+
+type UpsertUserVideoProgressParams struct {
+	UserID             string             `json:"user_id"`
+	VideoID            uuid.UUID          `json:"video_id"`
+	WatchedRatio       float64            `json:"watched_ratio"`
+	LastPositionMicros pgtype.Int8        `json:"last_position_micros"`
+	LastWatchedAt      pgtype.Timestamptz `json:"last_watched_at"`
+	Version            int64              `json:"version"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+}
+
+// 仅当事件版本更新时覆盖观看进度，返回 0 表示事件已过时。
+func (q *Queries) UpsertUserVideoProgress(ctx context.Context, arg UpsertUserVideoProgressParams) (int64, error) {
+	result, err := q.db.Exec(ctx, upsertUserVideoProgress,
+		arg.UserID,
+		arg.VideoID,
+		arg.WatchedRatio,
+		arg.LastPositionMicros,
+		arg.LastWatchedAt,
+		arg.Version,
+		arg.UpdatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,35 @@
+-- name: RecordUserWatchProgress :exec
+-- 合并一次观看进度：最高进度取较大值，首次/最近观看时间分别取较早/较晚值。
+insert into feed.user_watch_history as h (
+  user_id,
+  video_id,
+  max_watched_ratio,
+  first_watched_at,
+  last_watched_at,
+  updated_at
+)
+values (
+  sqlc.arg(user_id),
+  sqlc.arg(video_id),
+  sqlc.arg(watched_ratio),
+  sqlc.arg(watched_at),
+  sqlc.arg(watched_at),
+  now()
+)
+on conflict (user_id, video_id) do update
+set max_watched_ratio = greatest(h.max_watched_ratio, excluded.max_watched_ratio),
+    first_watched_at  = least(h.first_watched_at, excluded.first_watched_at),
+    last_watched_at   = greatest(h.last_watched_at, excluded.last_watched_at),
+    updated_at        = now();
+
+-- name: ListUserWatchHistory :many
+select
+  user_id,
+  video_id,
+  max_watched_ratio,
+  first_watched_at,
+  last_watched_at,
+  updated_at
+from feed.user_watch_history
+where user_id = sqlc.arg(user_id)
+  and video_id = any(sqlc.arg(video_ids)::uuid[]);
@@ -0,0 +1,100 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: user_watch_history.sql
+
+package feeddb
+
+import (
+	"context"
+
+	uuid "github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const listUserWatchHistory = `-- name: ListUserWatchHistory :many
+select
+  user_id,
+  video_id,
+  max_watched_ratio,
+  first_watched_at,
+  last_watched_at,
+  updated_at
+from feed.user_watch_history
+where user_id = $1
+  and video_id = any($2::uuid[])
+`
+
+type ListUserWatchHistoryParams struct {
+	UserID   string      `json:"user_id"`
+	VideoIds []uuid.UUID `json:"video_ids"`
+}
+
+func (q *Queries) ListUserWatchHistory(ctx context.Context, arg ListUserWatchHistoryParams) ([]FeedUserWatchHistory, error) {
+	rows, err := q.db.Query(ctx, listUserWatchHistory, arg.UserID, arg.VideoIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FeedUserWatchHistory{}
+	for rows.Next() {
+		var i FeedUserWatchHistory
+		if err := rows.Scan(
+			&i.UserID,
+			&i.VideoID,
+			&i.MaxWatchedRatio,
+			&i.FirstWatchedAt,
+			&i.LastWatchedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordUserWatchProgress = `-- name: RecordUserWatchProgress :exec
+insert into feed.user_watch_history as h (
+  user_id,
+  video_id,
+  max_watched_ratio,
+  first_watched_at,
+  last_watched_at,
+  updated_at
+)
+values (
+  $1,
+  $2,
+  $3,
+  $4,
+  $4,
+  now()
+)
+on conflict (user_id, video_id) do update
+set max_watched_ratio = greatest(h.max_watched_ratio, excluded.max_watched_ratio),
+    first_watched_at  = least(h.first_watched_at, excluded.first_watched_at),
+    last_watched_at   = greatest(h.last_watched_at, excluded.last_watched_at),
+    updated_at        = now()
+`
+
+type RecordUserWatchProgressParams struct {
+	UserID       string             `json:"user_id"`
+	VideoID      uuid.UUID          `json:"video_id"`
+	WatchedRatio float64            `json:"watched_ratio"`
+	WatchedAt    pgtype.Timestamptz `json:"watched_at"`
+}
+
+// 合并一次观看进度：最高进度取较大值，首次/最近观看时间分别取较早/较晚值。
+func (q *Queries) RecordUserWatchProgress(ctx context.Context, arg RecordUserWatchProgressParams) error {
+	_, err := q.db.Exec(ctx, recordUserWatchProgress,
+		arg.UserID,
+		arg.VideoID,
+		arg.WatchedRatio,
+		arg.WatchedAt,
+	)
+	return err
+}
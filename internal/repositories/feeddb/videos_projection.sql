@@ -10,7 +10,10 @@ insert into feed.videos_projection (
   visibility_status,
   published_at,
   version,
-  updated_at
+  updated_at,
+  creator_id,
+  primary_language,
+  tags
 )
 values (
   $1,
@@ -23,7 +26,10 @@ values (
   $8,
   $9,
   $10,
-  coalesce($11, now())
+  coalesce($11, now()),
+  $12,
+  $13,
+  $14
 )
 on conflict (video_id) do update
 set title               = excluded.title,
@@ -35,7 +41,10 @@ set title               = excluded.title,
     visibility_status   = excluded.visibility_status,
     published_at        = excluded.published_at,
     version             = excluded.version,
-    updated_at          = excluded.updated_at;
+    updated_at          = excluded.updated_at,
+    creator_id          = coalesce(excluded.creator_id, feed.videos_projection.creator_id),
+    primary_language    = coalesce(excluded.primary_language, feed.videos_projection.primary_language),
+    tags                = coalesce(excluded.tags, feed.videos_projection.tags);
 
 -- name: GetVideoProjection :one
 select
@@ -49,7 +58,10 @@ select
   visibility_status,
   published_at,
   version,
-  updated_at
+  updated_at,
+  creator_id,
+  primary_language,
+  tags
 from feed.videos_projection
 where video_id = $1;
 
@@ -65,7 +77,10 @@ select
   visibility_status,
   published_at,
   version,
-  updated_at
+  updated_at,
+  creator_id,
+  primary_language,
+  tags
 from feed.videos_projection
 where video_id = any($1::uuid[]);
 
@@ -75,3 +90,24 @@ from feed.videos_projection
 where status = 'ready'
 order by random()
 limit $1;
+
+-- name: ListSeededVideoIDs :many
+-- 按 sha256(seed || video_id) 的确定性伪随机顺序列出已发布视频：同一 seed 的顺序固定，以 (sort_key, video_id) 键集分页。
+select
+  video_id,
+  sha256(convert_to(sqlc.arg(seed)::text || video_id::text, 'UTF8'))::bytea as sort_key
+from feed.videos_projection
+where status = 'ready'
+  and (
+    sqlc.narg(after_sort_key)::bytea is null or
+    (sha256(convert_to(sqlc.arg(seed)::text || video_id::text, 'UTF8')), video_id) > (sqlc.narg(after_sort_key)::bytea, sqlc.narg(after_video_id)::uuid)
+  )
+order by sort_key, video_id
+limit sqlc.arg(row_limit);
+
+-- name: ListRecentVideoIDs :many
+select video_id
+from feed.videos_projection
+where status = 'ready'
+order by published_at desc nulls last, updated_at desc
+limit $1;
@@ -24,7 +24,10 @@ select
   visibility_status,
   published_at,
   version,
-  updated_at
+  updated_at,
+  creator_id,
+  primary_language,
+  tags
 from feed.videos_projection
 where video_id = $1
 `
@@ -44,6 +47,9 @@ func (q *Queries) GetVideoProjection(ctx context.Context, videoID uuid.UUID) (Fe
 		&i.PublishedAt,
 		&i.Version,
 		&i.UpdatedAt,
+		&i.CreatorID,
+		&i.PrimaryLanguage,
+		&i.Tags,
 	)
 	return i, err
 }
@@ -76,6 +82,86 @@ func (q *Queries) ListRandomVideoIDs(ctx context.Context, limit int32) ([]uuid.U
 	return items, nil
 }
 
+const listRecentVideoIDs = `-- name: ListRecentVideoIDs :many
+select video_id
+from feed.videos_projection
+where status = 'ready'
+order by published_at desc nulls last, updated_at desc
+limit $1
+`
+
+func (q *Queries) ListRecentVideoIDs(ctx context.Context, limit int32) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listRecentVideoIDs, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var video_id uuid.UUID
+		if err := rows.Scan(&video_id); err != nil {
+			return nil, err
+		}
+		items = append(items, video_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSeededVideoIDs = `-- name: ListSeededVideoIDs :many
+select
+  video_id,
+  sha256(convert_to($1::text || video_id::text, 'UTF8'))::bytea as sort_key
+from feed.videos_projection
+where status = 'ready'
+  and (
+    $2::bytea is null or
+    (sha256(convert_to($1::text || video_id::text, 'UTF8')), video_id) > ($2::bytea, $3::uuid)
+  )
+order by sort_key, video_id
+limit $4
+`
+
+type ListSeededVideoIDsParams struct {
+	Seed         string      `json:"seed"`
+	AfterSortKey []byte      `json:"after_sort_key"`
+	AfterVideoID pgtype.UUID `json:"after_video_id"`
+	RowLimit     int32       `json:"row_limit"`
+}
+
+type ListSeededVideoIDsRow struct {
+	VideoID uuid.UUID `json:"video_id"`
+	SortKey []byte    `json:"sort_key"`
+}
+
+// 按 sha256(seed || video_id) 的确定性伪随机顺序列出已发布视频：同一 seed 的顺序固定，以 (sort_key, video_id) 键集分页。
+func (q *Queries) ListSeededVideoIDs(ctx context.Context, arg ListSeededVideoIDsParams) ([]ListSeededVideoIDsRow, error) {
+	rows, err := q.db.Query(ctx, listSeededVideoIDs,
+		arg.Seed,
+		arg.AfterSortKey,
+		arg.AfterVideoID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSeededVideoIDsRow{}
+	for rows.Next() {
+		var i ListSeededVideoIDsRow
+		if err := rows.Scan(&i.VideoID, &i.SortKey); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listVideoProjections = `-- name: ListVideoProjections :many
 select
   video_id,
@@ -88,7 +174,10 @@ select
   visibility_status,
   published_at,
   version,
-  updated_at
+  updated_at,
+  creator_id,
+  primary_language,
+  tags
 from feed.videos_projection
 where video_id = any($1::uuid[])
 `
@@ -114,6 +203,9 @@ func (q *Queries) ListVideoProjections(ctx context.Context, dollar_1 []uuid.UUID
 			&i.PublishedAt,
 			&i.Version,
 			&i.UpdatedAt,
+			&i.CreatorID,
+			&i.PrimaryLanguage,
+			&i.Tags,
 		); err != nil {
 			return nil, err
 		}
@@ -137,7 +229,10 @@ insert into feed.videos_projection (
   visibility_status,
   published_at,
   version,
-  updated_at
+  updated_at,
+  creator_id,
+  primary_language,
+  tags
 )
 values (
   $1,
@@ -150,7 +245,10 @@ values (
   $8,
   $9,
   $10,
-  coalesce($11, now())
+  coalesce($11, now()),
+  $12,
+  $13,
+  $14
 )
 on conflict (video_id) do update
 set title               = excluded.title,
@@ -162,7 +260,10 @@ set title               = excluded.title,
     visibility_status   = excluded.visibility_status,
     published_at        = excluded.published_at,
     version             = excluded.version,
-    updated_at          = excluded.updated_at
+    updated_at          = excluded.updated_at,
+    creator_id          = coalesce(excluded.creator_id, feed.videos_projection.creator_id),
+    primary_language    = coalesce(excluded.primary_language, feed.videos_projection.primary_language),
+    tags                = coalesce(excluded.tags, feed.videos_projection.tags)
 `
 
 type UpsertVideoProjectionParams struct {
@@ -177,6 +278,9 @@ type UpsertVideoProjectionParams struct {
 	PublishedAt       pgtype.Timestamptz `json:"published_at"`
 	Version           int64              `json:"version"`
 	Column11          interface{}        `json:"column_11"`
+	CreatorID         pgtype.Text        `json:"creator_id"`
+	PrimaryLanguage   pgtype.Text        `json:"primary_language"`
+	Tags              []string           `json:"tags"`
 }
 
 func (q *Queries) UpsertVideoProjection(ctx context.Context, arg UpsertVideoProjectionParams) error {
@@ -192,6 +296,9 @@ func (q *Queries) UpsertVideoProjection(ctx context.Context, arg UpsertVideoProj
 		arg.PublishedAt,
 		arg.Version,
 		arg.Column11,
+		arg.CreatorID,
+		arg.PrimaryLanguage,
+		arg.Tags,
 	)
 	return err
 }
@@ -7,4 +7,12 @@ import "github.com/google/wire"
 var ProviderSet = wire.NewSet(
 	NewFeedVideoProjectionRepository,
 	NewFeedRecommendationLogRepository,
+	NewFeedIdempotencyRepository,
+	NewRateLimitRepository,
+	NewOutboxRepository,
+	NewUserVideoStateRepository,
+	NewUserWatchHistoryRepository,
+	NewReviewScheduleRepository,
+	NewCurationRuleRepository,
+	NewServedPageRepository,
 )
@@ -26,6 +26,73 @@ func FeedVideoProjectionFromRow(row feeddb.FeedVideosProjection) *po.FeedVideoPr
 		PublishedAt:       timestampPtr(row.PublishedAt),
 		Version:           row.Version,
 		UpdatedAt:         mustTimestamp(row.UpdatedAt),
+		CreatorID:         textPtr(row.CreatorID),
+		PrimaryLanguage:   textPtr(row.PrimaryLanguage),
+		Tags:              row.Tags,
+	}
+}
+
+// UserVideoStateFromRow 转换用户-视频状态投影。
+func UserVideoStateFromRow(row feeddb.FeedUserVideoState) *po.UserVideoState {
+	return &po.UserVideoState{
+		UserID:             row.UserID,
+		VideoID:            row.VideoID.String(),
+		Liked:              row.Liked,
+		LikedVersion:       row.LikedVersion,
+		Bookmarked:         row.Bookmarked,
+		BookmarkedVersion:  row.BookmarkedVersion,
+		WatchedRatio:       row.WatchedRatio,
+		LastPositionMicros: toInt64Ptr(row.LastPositionMicros),
+		LastWatchedAt:      timestampPtr(row.LastWatchedAt),
+		ProgressVersion:    row.ProgressVersion,
+		UpdatedAt:          mustTimestamp(row.UpdatedAt),
+	}
+}
+
+// UserWatchHistoryFromRow 转换用户观看历史投影。
+func UserWatchHistoryFromRow(row feeddb.FeedUserWatchHistory) *po.UserWatchHistory {
+	return &po.UserWatchHistory{
+		UserID:          row.UserID,
+		VideoID:         row.VideoID.String(),
+		MaxWatchedRatio: row.MaxWatchedRatio,
+		FirstWatchedAt:  mustTimestamp(row.FirstWatchedAt),
+		LastWatchedAt:   mustTimestamp(row.LastWatchedAt),
+		UpdatedAt:       mustTimestamp(row.UpdatedAt),
+	}
+}
+
+// ReviewScheduleFromRow 转换复习计划投影。
+func ReviewScheduleFromRow(row feeddb.FeedReviewSchedule) *po.ReviewSchedule {
+	return &po.ReviewSchedule{
+		UserID:         row.UserID,
+		VideoID:        row.VideoID.String(),
+		DueAt:          mustTimestamp(row.DueAt),
+		Stability:      row.Stability,
+		Difficulty:     row.Difficulty,
+		Reps:           row.Reps,
+		LastReviewedAt: timestampPtr(row.LastReviewedAt),
+		Active:         row.Active,
+		Version:        row.Version,
+		UpdatedAt:      mustTimestamp(row.UpdatedAt),
+	}
+}
+
+// CurationRuleFromRow 转换运营干预规则。
+func CurationRuleFromRow(row feeddb.FeedCurationRule) *po.CurationRule {
+	return &po.CurationRule{
+		RuleID:      row.RuleID.String(),
+		Action:      row.Action,
+		VideoID:     row.VideoID.String(),
+		Scene:       textPtr(row.Scene),
+		Locale:      textPtr(row.Locale),
+		UserSegment: textPtr(row.UserSegment),
+		Position:    int4Ptr(row.Position),
+		Priority:    row.Priority,
+		StartsAt:    timestampPtr(row.StartsAt),
+		EndsAt:      timestampPtr(row.EndsAt),
+		Note:        textPtr(row.Note),
+		CreatedAt:   mustTimestamp(row.CreatedAt),
+		UpdatedAt:   mustTimestamp(row.UpdatedAt),
 	}
 }
 
@@ -58,6 +125,18 @@ func FeedRecommendationLogFromRow(row feeddb.FeedRecommendationLog) (*po.FeedRec
 			return nil, fmt.Errorf("unmarshal missing_video_ids: %w", err)
 		}
 	}
+	served := []po.ServedItemLog{}
+	if len(row.ServedItems) > 0 {
+		if err := json.Unmarshal(row.ServedItems, &served); err != nil {
+			return nil, fmt.Errorf("unmarshal served_items: %w", err)
+		}
+	}
+	experiments := []po.ExperimentAssignmentLog{}
+	if len(row.Experiments) > 0 {
+		if err := json.Unmarshal(row.Experiments, &experiments); err != nil {
+			return nil, fmt.Errorf("unmarshal experiments: %w", err)
+		}
+	}
 	return &po.FeedRecommendationLog{
 		LogID:                   row.LogID.String(),
 		UserID:                  textPtr(row.UserID),
@@ -68,6 +147,59 @@ func FeedRecommendationLogFromRow(row feeddb.FeedRecommendationLog) (*po.FeedRec
 		MissingVideoIDs:         missing,
 		ErrorKind:               textPtr(row.ErrorKind),
 		GeneratedAt:             mustTimestamp(row.GeneratedAt),
+		IdempotencyKey:          textPtr(row.IdempotencyKey),
+		Replayed:                row.Replayed,
+		Guest:                   row.Guest,
+		UserIDHash:              textPtr(row.UserIDHash),
+		UserIDKeyVersion:        textPtr(row.UserIDKeyVersion),
+		ServedItems:             served,
+		RequestID:               textPtr(row.RequestID),
+		TraceID:                 textPtr(row.TraceID),
+		Scene:                   textPtr(row.Scene),
+		Page:                    int4Ptr(row.Page),
+		Experiments:             experiments,
+	}, nil
+}
+
+// ServedPageFromRow 转换下发页记录。
+func ServedPageFromRow(row feeddb.FeedServedPage) (*po.ServedPage, error) {
+	served := []po.ServedItemLog{}
+	if len(row.ServedItems) > 0 {
+		if err := json.Unmarshal(row.ServedItems, &served); err != nil {
+			return nil, fmt.Errorf("unmarshal served_items: %w", err)
+		}
+	}
+	return &po.ServedPage{
+		LogID:                row.LogID.String(),
+		UserIDHash:           textPtr(row.UserIDHash),
+		UserIDKeyVersion:     textPtr(row.UserIDKeyVersion),
+		Guest:                row.Guest,
+		Scene:                textPtr(row.Scene),
+		RecommendationSource: row.RecommendationSource,
+		ServedItems:          served,
+		GeneratedAt:          mustTimestamp(row.GeneratedAt),
+	}, nil
+}
+
+// FeedIdempotencySnapshotFromRow 转换幂等快照。
+func FeedIdempotencySnapshotFromRow(row feeddb.FeedIdempotencySnapshot) (*po.FeedIdempotencySnapshot, error) {
+	items := []po.RecommendedItemLog{}
+	if len(row.Items) > 0 {
+		if err := json.Unmarshal(row.Items, &items); err != nil {
+			return nil, fmt.Errorf("unmarshal snapshot items: %w", err)
+		}
+	}
+	return &po.FeedIdempotencySnapshot{
+		UserIDHash:           row.UserIDHash,
+		UserIDKeyVersion:     row.UserIDKeyVersion.String,
+		IdempotencyKey:       row.IdempotencyKey,
+		RequestLimit:         row.RequestLimit,
+		RecommendationSource: row.RecommendationSource,
+		Items:                items,
+		NextCursor:           textPtr(row.NextCursor),
+		RequestHash:          row.RequestHash.String,
+		CreatedAt:            mustTimestamp(row.CreatedAt),
+		ExpiresAt:            mustTimestamp(row.ExpiresAt),
 	}, nil
 }
 
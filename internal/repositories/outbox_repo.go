@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories/feeddb"
+	outboxpkg "github.com/bionicotaku/lingo-utils/outbox"
+	outboxcfg "github.com/bionicotaku/lingo-utils/outbox/config"
+	"github.com/bionicotaku/lingo-utils/outbox/store"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OutboxMessage 表示待发布的出站事件。
+type OutboxMessage = store.Message
+
+// OutboxRepository 封装共享 Outbox 仓储，并补充 Feed 侧的去重查询。
+type OutboxRepository struct {
+	delegate *store.Repository
+	queries  *feeddb.Queries
+	log      *log.Helper
+}
+
+// NewOutboxRepository 构建 Outbox 仓储，写入与发布复用 lingo-utils/outbox 仓储。
+func NewOutboxRepository(db *pgxpool.Pool, logger log.Logger, cfg outboxcfg.Config) *OutboxRepository {
+	helper := log.NewHelper(logger)
+	storeRepo, err := outboxpkg.NewRepository(db, logger, outboxpkg.RepositoryOptions{Schema: cfg.Schema})
+	if err != nil {
+		helper.Errorw("msg", "init outbox repository failed", "error", err)
+		storeRepo = store.NewRepository(db, logger)
+	}
+	return &OutboxRepository{
+		delegate: storeRepo,
+		queries:  feeddb.New(db),
+		log:      helper,
+	}
+}
+
+// Enqueue 在事务内写入待发布事件。
+func (r *OutboxRepository) Enqueue(ctx context.Context, sess txmanager.Session, msg OutboxMessage) error {
+	if err := r.delegate.Enqueue(ctx, sess, msg); err != nil {
+		return fmt.Errorf("enqueue outbox event %s: %w", msg.EventID, err)
+	}
+	return nil
+}
+
+// ExistingEventIDs 返回 ids 中已写入 Outbox 的事件 ID，用于客户端重试时去重。
+func (r *OutboxRepository) ExistingEventIDs(ctx context.Context, sess txmanager.Session, ids []uuid.UUID) (map[uuid.UUID]struct{}, error) {
+	existing := make(map[uuid.UUID]struct{})
+	if len(ids) == 0 {
+		return existing, nil
+	}
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	found, err := queries.ListExistingOutboxEventIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("list existing outbox events: %w", err)
+	}
+	for _, id := range found {
+		existing[id] = struct{}{}
+	}
+	return existing, nil
+}
+
+// Shared 暴露底层共享仓储，供 outbox 发布器使用。
+func (r *OutboxRepository) Shared() *store.Repository {
+	return r.delegate
+}
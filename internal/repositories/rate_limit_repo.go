@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories/feeddb"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RateLimitTake 表示一次令牌扣减的结果。
+type RateLimitTake struct {
+	Allowed bool
+	Tokens  float64
+}
+
+// RateLimitRepository 负责共享令牌桶的原子扣减与清理。
+type RateLimitRepository struct {
+	db      *pgxpool.Pool
+	queries *feeddb.Queries
+	log     *log.Helper
+}
+
+// NewRateLimitRepository 构造仓储实例。
+func NewRateLimitRepository(db *pgxpool.Pool, logger log.Logger) *RateLimitRepository {
+	return &RateLimitRepository{
+		db:      db,
+		queries: feeddb.New(db),
+		log:     log.NewHelper(logger),
+	}
+}
+
+// Take 按 rate/burst 惰性补充令牌桶并尝试扣减一个令牌。
+func (r *RateLimitRepository) Take(ctx context.Context, sess txmanager.Session, key string, rate float64, burst int) (RateLimitTake, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	row, err := queries.TakeRateLimitToken(ctx, feeddb.TakeRateLimitTokenParams{
+		BucketKey: key,
+		Burst:     float64(burst),
+		Rate:      rate,
+	})
+	if err != nil {
+		return RateLimitTake{}, fmt.Errorf("take rate limit token: %w", err)
+	}
+	return RateLimitTake{Allowed: row.LastAllowed, Tokens: row.Tokens}, nil
+}
+
+// PurgeIdle 删除 before 之后未再访问的令牌桶，返回删除行数。
+func (r *RateLimitRepository) PurgeIdle(ctx context.Context, sess txmanager.Session, before time.Time) (int64, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	rows, err := queries.DeleteIdleRateLimitBuckets(ctx, pgtype.Timestamptz{Time: before.UTC(), Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("delete idle rate limit buckets: %w", err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories/feeddb"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories/mappers"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ReviewScheduleRepository 维护 feed.review_schedule 投影。
+type ReviewScheduleRepository struct {
+	db      *pgxpool.Pool
+	queries *feeddb.Queries
+	log     *log.Helper
+}
+
+// NewReviewScheduleRepository 构造仓储实例。
+func NewReviewScheduleRepository(db *pgxpool.Pool, logger log.Logger) *ReviewScheduleRepository {
+	return &ReviewScheduleRepository{
+		db:      db,
+		queries: feeddb.New(db),
+		log:     log.NewHelper(logger),
+	}
+}
+
+// UpsertReviewInput 描述一次复习计划写入。
+type UpsertReviewInput struct {
+	UserID         string
+	VideoID        uuid.UUID
+	DueAt          time.Time
+	Stability      float64
+	Difficulty     float64
+	Reps           int32
+	LastReviewedAt *time.Time
+	Version        int64
+	UpdatedAt      time.Time
+}
+
+// Upsert 按版本写入复习计划并重新激活，返回是否生效；事件版本不高于当前版本时返回 false。
+func (r *ReviewScheduleRepository) Upsert(ctx context.Context, sess txmanager.Session, input UpsertReviewInput) (bool, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	affected, err := queries.UpsertReviewSchedule(ctx, feeddb.UpsertReviewScheduleParams{
+		UserID:         input.UserID,
+		VideoID:        input.VideoID,
+		DueAt:          mappers.ToPgTimestamptzPtr(&input.DueAt),
+		Stability:      input.Stability,
+		Difficulty:     input.Difficulty,
+		Reps:           input.Reps,
+		LastReviewedAt: mappers.ToPgTimestamptzPtr(input.LastReviewedAt),
+		Version:        input.Version,
+		UpdatedAt:      mappers.ToPgTimestamptzPtr(&input.UpdatedAt),
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorw("msg", "upsert review schedule failed", "video_id", input.VideoID, "error", err)
+		return false, fmt.Errorf("upsert review schedule: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// Deactivate 按版本将视频移出复习队列，返回是否生效；事件版本不高于当前版本时返回 false。
+func (r *ReviewScheduleRepository) Deactivate(ctx context.Context, sess txmanager.Session, userID string, videoID uuid.UUID, version int64, updatedAt time.Time) (bool, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	affected, err := queries.DeactivateReviewSchedule(ctx, feeddb.DeactivateReviewScheduleParams{
+		UserID:    userID,
+		VideoID:   videoID,
+		UpdatedAt: mappers.ToPgTimestamptzPtr(&updatedAt),
+		Version:   version,
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorw("msg", "deactivate review schedule failed", "video_id", videoID, "error", err)
+		return false, fmt.Errorf("deactivate review schedule: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// ListDue 按到期时间升序返回用户在 now 之前到期的复习计划，最多 limit 条。
+func (r *ReviewScheduleRepository) ListDue(ctx context.Context, sess txmanager.Session, userID string, now time.Time, limit int) ([]*po.ReviewSchedule, error) {
+	if userID == "" || limit <= 0 {
+		return nil, nil
+	}
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	rows, err := queries.ListDueReviews(ctx, feeddb.ListDueReviewsParams{
+		UserID:   userID,
+		Now:      mappers.ToPgTimestamptzPtr(&now),
+		RowLimit: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list due reviews: %w", err)
+	}
+	result := make([]*po.ReviewSchedule, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, mappers.ReviewScheduleFromRow(row))
+	}
+	return result, nil
+}
@@ -0,0 +1,168 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories/feeddb"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories/mappers"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ServedPageRepository 负责 feed.served_pages 的读写。
+type ServedPageRepository struct {
+	db      *pgxpool.Pool
+	queries *feeddb.Queries
+	log     *log.Helper
+}
+
+// NewServedPageRepository 构造仓储实例。
+func NewServedPageRepository(db *pgxpool.Pool, logger log.Logger) *ServedPageRepository {
+	return &ServedPageRepository{
+		db:      db,
+		queries: feeddb.New(db),
+		log:     log.NewHelper(logger),
+	}
+}
+
+// Insert 写入一条下发页记录，LogID 必须预先生成。
+func (r *ServedPageRepository) Insert(ctx context.Context, sess txmanager.Session, page po.ServedPage) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	logID, err := uuid.Parse(page.LogID)
+	if err != nil {
+		return fmt.Errorf("insert served page: invalid log id %q: %w", page.LogID, err)
+	}
+	items := page.ServedItems
+	if items == nil {
+		items = []po.ServedItemLog{}
+	}
+	served, err := json.Marshal(items)
+	if err != nil {
+		return fmt.Errorf("marshal served_items: %w", err)
+	}
+	generatedAt := page.GeneratedAt
+	if generatedAt.IsZero() {
+		generatedAt = time.Now()
+	}
+	if err := queries.InsertServedPage(ctx, feeddb.InsertServedPageParams{
+		LogID:                logID,
+		UserIDHash:           mappers.ToPgText(page.UserIDHash),
+		UserIDKeyVersion:     mappers.ToPgText(page.UserIDKeyVersion),
+		Guest:                page.Guest,
+		Scene:                mappers.ToPgText(page.Scene),
+		RecommendationSource: page.RecommendationSource,
+		ServedItems:          served,
+		GeneratedAt:          pgtype.Timestamptz{Time: generatedAt.UTC(), Valid: true},
+	}); err != nil {
+		return fmt.Errorf("insert served page: %w", err)
+	}
+	return nil
+}
+
+// servedPageCopyColumns 为 COPY feed.served_pages 时的列顺序，需与 InsertBatch 构造的行一致。
+var servedPageCopyColumns = []string{
+	"log_id",
+	"user_id_hash",
+	"user_id_key_version",
+	"guest",
+	"scene",
+	"recommendation_source",
+	"served_items",
+	"generated_at",
+}
+
+// InsertBatch 通过 COPY 协议批量写入下发页记录，返回写入行数；批内任一条编码失败时整批放弃。
+func (r *ServedPageRepository) InsertBatch(ctx context.Context, sess txmanager.Session, pages []po.ServedPage) (int64, error) {
+	if len(pages) == 0 {
+		return 0, nil
+	}
+	rows := make([][]any, 0, len(pages))
+	for _, page := range pages {
+		logID, err := uuid.Parse(page.LogID)
+		if err != nil {
+			return 0, fmt.Errorf("copy served pages: invalid log id %q: %w", page.LogID, err)
+		}
+		items := page.ServedItems
+		if items == nil {
+			items = []po.ServedItemLog{}
+		}
+		served, err := json.Marshal(items)
+		if err != nil {
+			return 0, fmt.Errorf("marshal served_items: %w", err)
+		}
+		generatedAt := page.GeneratedAt.UTC()
+		if page.GeneratedAt.IsZero() {
+			generatedAt = time.Now().UTC()
+		}
+		rows = append(rows, []any{
+			logID,
+			page.UserIDHash,
+			page.UserIDKeyVersion,
+			page.Guest,
+			page.Scene,
+			page.RecommendationSource,
+			served,
+			generatedAt,
+		})
+	}
+	var copier interface {
+		CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error)
+	} = r.db
+	if sess != nil {
+		copier = sess.Tx()
+	}
+	copied, err := copier.CopyFrom(ctx, pgx.Identifier{"feed", "served_pages"}, servedPageCopyColumns, pgx.CopyFromRows(rows))
+	if err != nil {
+		r.log.WithContext(ctx).Errorw("msg", "copy served pages failed", "count", len(pages), "error", err)
+		return 0, fmt.Errorf("copy served pages: %w", err)
+	}
+	return copied, nil
+}
+
+// ListByIDs 按 log_id 批量查询下发页记录，不存在的 ID 直接忽略。
+func (r *ServedPageRepository) ListByIDs(ctx context.Context, sess txmanager.Session, ids []uuid.UUID) ([]*po.ServedPage, error) {
+	if len(ids) == 0 {
+		return []*po.ServedPage{}, nil
+	}
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	rows, err := queries.ListServedPagesByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("list served pages by ids: %w", err)
+	}
+	result := make([]*po.ServedPage, 0, len(rows))
+	for _, row := range rows {
+		page, mapErr := mappers.ServedPageFromRow(row)
+		if mapErr != nil {
+			return nil, mapErr
+		}
+		result = append(result, page)
+	}
+	return result, nil
+}
+
+// PurgeBefore 删除 before 之前下发的记录，返回删除行数。
+func (r *ServedPageRepository) PurgeBefore(ctx context.Context, sess txmanager.Session, before time.Time) (int64, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	rows, err := queries.PurgeServedPagesBefore(ctx, pgtype.Timestamptz{Time: before.UTC(), Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("purge served pages: %w", err)
+	}
+	return rows, nil
+}
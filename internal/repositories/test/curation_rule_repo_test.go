@@ -0,0 +1,102 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurationRuleRepository_CRUD(t *testing.T) {
+	resetDatabase(t)
+
+	ctx := context.Background()
+	repo := newCurationRuleRepo()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	position := int32(1)
+
+	pin, err := repo.Create(ctx, nil, repositories.CurationRuleInput{
+		Action:   "pin",
+		VideoID:  uuid.New(),
+		Scene:    stringPtr("home"),
+		Locale:   stringPtr("ja"),
+		Position: &position,
+		Priority: 10,
+		EndsAt:   timePtr(now.Add(time.Hour)),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, pin.RuleID)
+	require.Equal(t, "ja", *pin.Locale)
+	require.Nil(t, pin.UserSegment)
+	require.Equal(t, int32(1), *pin.Position)
+
+	expired, err := repo.Create(ctx, nil, repositories.CurationRuleInput{
+		Action:   "block",
+		VideoID:  uuid.New(),
+		StartsAt: timePtr(now.Add(-2 * time.Hour)),
+		EndsAt:   timePtr(now.Add(-time.Hour)),
+	})
+	require.NoError(t, err)
+
+	active, err := repo.List(ctx, nil, now, false)
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	require.Equal(t, pin.RuleID, active[0].RuleID)
+
+	all, err := repo.List(ctx, nil, now, true)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+
+	ruleID := uuid.MustParse(pin.RuleID)
+	updated, err := repo.Update(ctx, nil, ruleID, repositories.CurationRuleInput{Action: "boost", VideoID: uuid.MustParse(pin.VideoID), Priority: 5})
+	require.NoError(t, err)
+	require.Equal(t, "boost", updated.Action)
+	require.Nil(t, updated.Scene)
+	require.Nil(t, updated.EndsAt)
+
+	require.NoError(t, repo.Delete(ctx, nil, uuid.MustParse(expired.RuleID)))
+	require.ErrorIs(t, repo.Delete(ctx, nil, uuid.MustParse(expired.RuleID)), repositories.ErrCurationRuleNotFound)
+	_, err = repo.Update(ctx, nil, uuid.New(), repositories.CurationRuleInput{Action: "block", VideoID: uuid.New()})
+	require.ErrorIs(t, err, repositories.ErrCurationRuleNotFound)
+	_, err = repo.Get(ctx, nil, uuid.New())
+	require.ErrorIs(t, err, repositories.ErrCurationRuleNotFound)
+
+	// 非法动作由表约束拒绝。
+	_, err = repo.Create(ctx, nil, repositories.CurationRuleInput{Action: "promote", VideoID: uuid.New()})
+	require.Error(t, err)
+}
+
+func TestCurationRuleRepository_ListenReceivesChanges(t *testing.T) {
+	resetDatabase(t)
+
+	repo := newCurationRuleRepo()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ready := make(chan struct{})
+	changes := make(chan struct{}, 4)
+	done := make(chan error, 1)
+	go func() {
+		done <- repo.Listen(ctx, func() { close(ready) }, func() { changes <- struct{}{} })
+	}()
+
+	select {
+	case <-ready:
+	case <-time.After(5 * time.Second):
+		t.Fatal("listener not ready")
+	}
+	_, err := repo.Create(context.Background(), nil, repositories.CurationRuleInput{Action: "block", VideoID: uuid.New()})
+	require.NoError(t, err)
+
+	select {
+	case <-changes:
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification not received")
+	}
+
+	cancel()
+	require.NoError(t, <-done)
+}
@@ -0,0 +1,91 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeedIdempotencyRepository_SaveAndGet(t *testing.T) {
+	resetDatabase(t)
+
+	ctx := context.Background()
+	repo := newIdempotencyRepo()
+
+	missing, err := repo.Get(ctx, nil, "hash-user-1", "key-1")
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	cursor := "cursor-1"
+	snapshot := po.FeedIdempotencySnapshot{
+		UserIDHash:           "hash-user-1",
+		UserIDKeyVersion:     "v1",
+		IdempotencyKey:       "key-1",
+		RequestLimit:         2,
+		RecommendationSource: "mock",
+		Items: []po.RecommendedItemLog{
+			{VideoID: "v1", Reason: "mock.random", Score: 0.9},
+			{VideoID: "v2", Reason: "mock.random", Score: 0.4},
+		},
+		NextCursor:  &cursor,
+		ExpiresAt:   time.Now().UTC().Add(time.Minute),
+		RequestHash: "hash-1",
+	}
+	inserted, err := repo.Save(ctx, nil, snapshot)
+	require.NoError(t, err)
+	require.True(t, inserted)
+
+	// 未过期快照不会被覆盖。
+	overwrite := snapshot
+	overwrite.Items = []po.RecommendedItemLog{{VideoID: "v3"}}
+	inserted, err = repo.Save(ctx, nil, overwrite)
+	require.NoError(t, err)
+	require.False(t, inserted)
+
+	stored, err := repo.Get(ctx, nil, "hash-user-1", "key-1")
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	require.Equal(t, int32(2), stored.RequestLimit)
+	require.Equal(t, snapshot.Items, stored.Items)
+	require.NotNil(t, stored.NextCursor)
+	require.Equal(t, cursor, *stored.NextCursor)
+	require.Equal(t, "hash-1", stored.RequestHash)
+	require.Equal(t, "v1", stored.UserIDKeyVersion)
+}
+
+func TestFeedIdempotencyRepository_ExpiredSnapshot(t *testing.T) {
+	resetDatabase(t)
+
+	ctx := context.Background()
+	repo := newIdempotencyRepo()
+
+	expired := po.FeedIdempotencySnapshot{
+		UserIDHash:           "hash-user-2",
+		IdempotencyKey:       "key-2",
+		RequestLimit:         1,
+		RecommendationSource: "mock",
+		ExpiresAt:            time.Now().UTC().Add(-time.Minute),
+	}
+	inserted, err := repo.Save(ctx, nil, expired)
+	require.NoError(t, err)
+	require.True(t, inserted)
+
+	stored, err := repo.Get(ctx, nil, "hash-user-2", "key-2")
+	require.NoError(t, err)
+	require.Nil(t, stored)
+
+	// 过期快照允许被同键的新请求覆盖。
+	fresh := expired
+	fresh.RequestLimit = 4
+	fresh.ExpiresAt = time.Now().UTC().Add(time.Minute)
+	inserted, err = repo.Save(ctx, nil, fresh)
+	require.NoError(t, err)
+	require.True(t, inserted)
+
+	purged, err := repo.PurgeExpired(ctx, nil, time.Now().UTC().Add(2*time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), purged)
+}
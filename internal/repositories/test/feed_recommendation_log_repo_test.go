@@ -87,6 +87,10 @@ func TestFeedRecommendationLogRepository_Insert(t *testing.T) {
 	listed := logs[0]
 	require.Equal(t, "mock", listed.RecommendationSource)
 	require.ElementsMatch(t, []string{"v2"}, listed.MissingVideoIDs)
+	require.NotNil(t, listed.ServedItems)
+	require.Empty(t, listed.ServedItems)
+	require.Nil(t, listed.RequestID)
+	require.Nil(t, listed.Page)
 
 	logID, err := uuid.Parse(listed.LogID)
 	require.NoError(t, err)
@@ -94,3 +98,285 @@ func TestFeedRecommendationLogRepository_Insert(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, listed.LogID, fetched.LogID)
 }
+
+func TestFeedRecommendationLogRepository_InsertReplay(t *testing.T) {
+	resetDatabase(t)
+
+	ctx := context.Background()
+	repo := newRecommendationLogRepo()
+
+	key := "retry-1"
+	requestID := "req-1"
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	scene := "home"
+	page := int32(2)
+	served := []po.ServedItemLog{{VideoID: "v1", Position: 1, ProjectionVersion: 3, VisibilityStatus: "public"}}
+	experiments := []po.ExperimentAssignmentLog{{Experiment: "ranker", Variant: "candidate", Forced: true}}
+	require.NoError(t, repo.Insert(ctx, nil, po.FeedRecommendationLog{
+		RequestLimit:         3,
+		RecommendationSource: "mock",
+		IdempotencyKey:       &key,
+		Replayed:             true,
+		ServedItems:          served,
+		RequestID:            &requestID,
+		TraceID:              &traceID,
+		Scene:                &scene,
+		Page:                 &page,
+		Experiments:          experiments,
+	}))
+
+	logs, err := repo.List(ctx, nil, repositories.ListRecommendationLogsParams{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	require.NotNil(t, logs[0].IdempotencyKey)
+	require.Equal(t, key, *logs[0].IdempotencyKey)
+	require.True(t, logs[0].Replayed)
+	require.Equal(t, served, logs[0].ServedItems)
+	require.Equal(t, requestID, *logs[0].RequestID)
+	require.Equal(t, traceID, *logs[0].TraceID)
+	require.Equal(t, scene, *logs[0].Scene)
+	require.Equal(t, page, *logs[0].Page)
+	require.Equal(t, experiments, logs[0].Experiments)
+}
+
+func TestFeedRecommendationLogRepository_InsertBatch(t *testing.T) {
+	resetDatabase(t)
+
+	ctx := context.Background()
+	repo := newRecommendationLogRepo()
+
+	base := time.Now().UTC().Truncate(time.Microsecond)
+	userHash := "hash-batch"
+	keyVersion := "v1"
+	errorKind := "recommendation_unavailable"
+	requestID := "req-batch"
+	page := int32(1)
+	served := []po.ServedItemLog{{VideoID: "v1", Position: 1, ProjectionVersion: 2, VisibilityStatus: "public"}}
+	entries := []po.FeedRecommendationLog{
+		{
+			UserIDHash:           &userHash,
+			UserIDKeyVersion:     &keyVersion,
+			RequestLimit:         3,
+			RecommendationSource: "mock",
+			RecommendedItems:     []po.RecommendedItemLog{{VideoID: "v1", Reason: "mock.random", Score: 0.5}},
+			ServedItems:          served,
+			RequestID:            &requestID,
+			Page:                 &page,
+			Experiments:          []po.ExperimentAssignmentLog{{Experiment: "ranker", Variant: "control"}},
+			GeneratedAt:          base,
+		},
+		{
+			RequestLimit:         4,
+			RecommendationSource: "guest",
+			ErrorKind:            &errorKind,
+			Guest:                true,
+			GeneratedAt:          base.Add(time.Second),
+		},
+	}
+
+	written, err := repo.InsertBatch(ctx, nil, entries)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), written)
+
+	logs, err := repo.List(ctx, nil, repositories.ListRecommendationLogsParams{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+
+	guestLog := logs[0]
+	require.Nil(t, guestLog.UserIDHash)
+	require.True(t, guestLog.Guest)
+	require.NotNil(t, guestLog.ErrorKind)
+	require.Equal(t, errorKind, *guestLog.ErrorKind)
+	require.Empty(t, guestLog.RecommendedItems)
+	require.Empty(t, guestLog.ServedItems)
+	require.Nil(t, guestLog.RequestID)
+	require.Empty(t, guestLog.Experiments)
+
+	userLog := logs[1]
+	require.Nil(t, userLog.UserID)
+	require.NotNil(t, userLog.UserIDHash)
+	require.Equal(t, userHash, *userLog.UserIDHash)
+	require.NotNil(t, userLog.UserIDKeyVersion)
+	require.Equal(t, keyVersion, *userLog.UserIDKeyVersion)
+	require.Equal(t, entries[0].RecommendedItems, userLog.RecommendedItems)
+	require.Equal(t, served, userLog.ServedItems)
+	require.Equal(t, requestID, *userLog.RequestID)
+	require.Equal(t, page, *userLog.Page)
+	require.Equal(t, entries[0].Experiments, userLog.Experiments)
+	require.Nil(t, userLog.TraceID)
+	require.True(t, userLog.GeneratedAt.Equal(base))
+	require.NotEmpty(t, userLog.LogID)
+
+	written, err = repo.InsertBatch(ctx, nil, nil)
+	require.NoError(t, err)
+	require.Zero(t, written)
+}
+
+func TestFeedRecommendationLogRepository_ListByUserHashes(t *testing.T) {
+	resetDatabase(t)
+
+	ctx := context.Background()
+	repo := newRecommendationLogRepo()
+
+	oldHash, oldVersion := "hash-old", "v1"
+	newHash, newVersion := "hash-new", "v2"
+	otherHash := "hash-other"
+	base := time.Now().UTC().Truncate(time.Microsecond)
+	_, err := repo.InsertBatch(ctx, nil, []po.FeedRecommendationLog{
+		{UserIDHash: &oldHash, UserIDKeyVersion: &oldVersion, RequestLimit: 1, RecommendationSource: "mock", GeneratedAt: base},
+		{UserIDHash: &newHash, UserIDKeyVersion: &newVersion, RequestLimit: 2, RecommendationSource: "mock", GeneratedAt: base.Add(time.Second)},
+		{UserIDHash: &otherHash, UserIDKeyVersion: &newVersion, RequestLimit: 3, RecommendationSource: "mock", GeneratedAt: base.Add(2 * time.Second)},
+	})
+	require.NoError(t, err)
+
+	logs, err := repo.List(ctx, nil, repositories.ListRecommendationLogsParams{
+		UserIDHashes: []string{oldHash, newHash},
+		Limit:        10,
+	})
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	require.Equal(t, newHash, *logs[0].UserIDHash)
+	require.Equal(t, oldHash, *logs[1].UserIDHash)
+}
+
+func TestFeedRecommendationLogRepository_ListByIDsWithPresetLogID(t *testing.T) {
+	resetDatabase(t)
+
+	ctx := context.Background()
+	repo := newRecommendationLogRepo()
+
+	base := time.Now().UTC().Truncate(time.Microsecond)
+	presetSingle, presetBatch := uuid.NewString(), uuid.NewString()
+	require.NoError(t, repo.Insert(ctx, nil, po.FeedRecommendationLog{
+		LogID:                presetSingle,
+		RequestLimit:         1,
+		RecommendationSource: "mock",
+		GeneratedAt:          base,
+	}))
+	_, err := repo.InsertBatch(ctx, nil, []po.FeedRecommendationLog{
+		{LogID: presetBatch, RequestLimit: 2, RecommendationSource: "mock", GeneratedAt: base.Add(time.Second)},
+		{RequestLimit: 3, RecommendationSource: "mock", GeneratedAt: base.Add(2 * time.Second)},
+	})
+	require.NoError(t, err)
+
+	logs, err := repo.ListByIDs(ctx, nil, []uuid.UUID{uuid.MustParse(presetSingle), uuid.MustParse(presetBatch), uuid.New()})
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	limits := map[string]int32{}
+	for _, entry := range logs {
+		limits[entry.LogID] = entry.RequestLimit
+	}
+	require.Equal(t, map[string]int32{presetSingle: 1, presetBatch: 2}, limits)
+
+	require.Error(t, repo.Insert(ctx, nil, po.FeedRecommendationLog{LogID: "not-a-uuid", RecommendationSource: "mock", GeneratedAt: base}))
+}
+
+func TestFeedRecommendationLogRepository_Partitions(t *testing.T) {
+	resetDatabase(t)
+
+	ctx := context.Background()
+	repo := newRecommendationLogRepo()
+
+	old := time.Date(2020, time.January, 10, 12, 0, 0, 0, time.UTC)
+	created, err := repo.EnsurePartitions(ctx, nil, old, 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, created)
+
+	// 已存在的分区不会重复创建。
+	created, err = repo.EnsurePartitions(ctx, nil, old, 2)
+	require.NoError(t, err)
+	require.Zero(t, created)
+
+	require.NoError(t, repo.Insert(ctx, nil, po.FeedRecommendationLog{
+		RequestLimit:         1,
+		RecommendationSource: "mock",
+		GeneratedAt:          old,
+	}))
+	var partition string
+	require.NoError(t, testPool.QueryRow(ctx, `select tableoid::regclass::text from feed.recommendation_logs where generated_at = $1`, old).Scan(&partition))
+	require.Equal(t, "feed.recommendation_logs_p20200110", partition)
+
+	dropped, err := repo.DropPartitionsBefore(ctx, nil, old.Add(24*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, []string{"recommendation_logs_p20200110"}, dropped)
+
+	var remaining int
+	require.NoError(t, testPool.QueryRow(ctx, `select count(*) from feed.recommendation_logs where generated_at = $1`, old).Scan(&remaining))
+	require.Zero(t, remaining)
+	var exists bool
+	require.NoError(t, testPool.QueryRow(ctx, `select to_regclass('feed.recommendation_logs_p20200111') is not null`).Scan(&exists))
+	require.True(t, exists)
+
+	// 未预建分区的数据落入 default，补建分区时随之搬迁。
+	orphan := time.Date(2020, time.February, 1, 6, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.Insert(ctx, nil, po.FeedRecommendationLog{
+		RequestLimit:         1,
+		RecommendationSource: "mock",
+		GeneratedAt:          orphan,
+	}))
+	require.NoError(t, testPool.QueryRow(ctx, `select tableoid::regclass::text from feed.recommendation_logs where generated_at = $1`, orphan).Scan(&partition))
+	require.Equal(t, "feed.recommendation_logs_default", partition)
+
+	created, err = repo.EnsurePartitions(ctx, nil, orphan, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, created)
+	require.NoError(t, testPool.QueryRow(ctx, `select tableoid::regclass::text from feed.recommendation_logs where generated_at = $1`, orphan).Scan(&partition))
+	require.Equal(t, "feed.recommendation_logs_p20200201", partition)
+}
+
+func TestFeedRecommendationLogRepository_ListKeysetAndFilters(t *testing.T) {
+	resetDatabase(t)
+
+	ctx := context.Background()
+	repo := newRecommendationLogRepo()
+
+	base := time.Now().UTC().Truncate(time.Microsecond)
+	errorKind := "recommendation_unavailable"
+	entries := []po.FeedRecommendationLog{
+		{RequestLimit: 1, RecommendationSource: "mock", GeneratedAt: base},
+		// 与上一条 generated_at 相同，验证按 log_id 打破平局。
+		{RequestLimit: 2, RecommendationSource: "mock", GeneratedAt: base},
+		{RequestLimit: 3, RecommendationSource: "mock", MissingVideoIDs: []string{"v9"}, GeneratedAt: base.Add(time.Second)},
+		{RequestLimit: 4, RecommendationSource: "guest", ErrorKind: &errorKind, GeneratedAt: base.Add(2 * time.Second)},
+	}
+	_, err := repo.InsertBatch(ctx, nil, entries)
+	require.NoError(t, err)
+
+	var seen []string
+	var after *repositories.RecommendationLogCursor
+	for {
+		page, err := repo.List(ctx, nil, repositories.ListRecommendationLogsParams{Limit: 1, After: after})
+		require.NoError(t, err)
+		if len(page) == 0 {
+			break
+		}
+		require.Len(t, page, 1)
+		seen = append(seen, page[0].LogID)
+		logID, err := uuid.Parse(page[0].LogID)
+		require.NoError(t, err)
+		after = &repositories.RecommendationLogCursor{GeneratedAt: page[0].GeneratedAt, LogID: logID}
+	}
+	require.Len(t, seen, 4)
+	require.Len(t, uniqueStrings(seen), 4)
+
+	missing, err := repo.List(ctx, nil, repositories.ListRecommendationLogsParams{MissingOnly: true, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, missing, 1)
+	require.Equal(t, int32(3), missing[0].RequestLimit)
+
+	failed, err := repo.List(ctx, nil, repositories.ListRecommendationLogsParams{ErrorKind: &errorKind, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+	require.Equal(t, "guest", failed[0].RecommendationSource)
+
+	_, err = repo.GetByID(ctx, nil, uuid.New())
+	require.ErrorIs(t, err, repositories.ErrRecommendationLogNotFound)
+}
+
+func uniqueStrings(values []string) map[string]struct{} {
+	out := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		out[v] = struct{}{}
+	}
+	return out
+}
@@ -49,6 +49,42 @@ func TestFeedVideoProjectionRepository_UpsertAndGet(t *testing.T) {
 	require.Equal(t, int64(1), record.Version)
 }
 
+func TestFeedVideoProjectionRepository_UpsertKeepsDiversityFields(t *testing.T) {
+	resetDatabase(t)
+
+	ctx := context.Background()
+	repo := newVideoProjectionRepo()
+
+	videoID := uuid.New()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	require.NoError(t, repo.Upsert(ctx, nil, repositories.UpsertFeedVideoProjectionInput{
+		VideoID:         videoID,
+		Title:           "v1",
+		CreatorID:       stringPtr("creator-1"),
+		PrimaryLanguage: stringPtr("en"),
+		Tags:            []string{"travel", "food"},
+		Version:         1,
+		UpdatedAt:       timePtr(now),
+	}))
+
+	// 未携带多样性字段的事件不覆盖已有值。
+	require.NoError(t, repo.Upsert(ctx, nil, repositories.UpsertFeedVideoProjectionInput{
+		VideoID:   videoID,
+		Title:     "v2",
+		Version:   2,
+		UpdatedAt: timePtr(now.Add(time.Second)),
+	}))
+
+	record, err := repo.Get(ctx, nil, videoID)
+	require.NoError(t, err)
+	require.Equal(t, "v2", record.Title)
+	require.NotNil(t, record.CreatorID)
+	require.Equal(t, "creator-1", *record.CreatorID)
+	require.NotNil(t, record.PrimaryLanguage)
+	require.Equal(t, "en", *record.PrimaryLanguage)
+	require.Equal(t, []string{"travel", "food"}, record.Tags)
+}
+
 func TestFeedVideoProjectionRepository_ListByIDs(t *testing.T) {
 	resetDatabase(t)
 
@@ -115,3 +151,62 @@ func TestFeedVideoProjectionRepository_ListRandomIDs(t *testing.T) {
 	require.NoError(t, err)
 	require.Nil(t, none)
 }
+
+func TestFeedVideoProjectionRepository_ListSeededIDs(t *testing.T) {
+	resetDatabase(t)
+
+	ctx := context.Background()
+	repo := newVideoProjectionRepo()
+
+	for idx := 0; idx < 7; idx++ {
+		status := "ready"
+		require.NoError(t, repo.Upsert(ctx, nil, repositories.UpsertFeedVideoProjectionInput{
+			VideoID: uuid.New(),
+			Title:   "Video",
+			Status:  &status,
+			Version: int64(idx + 1),
+		}))
+	}
+
+	all, err := repo.ListSeededIDs(ctx, nil, repositories.ListSeededVideoIDsParams{Seed: "seed-a", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, all, 7)
+
+	// 相同种子重复查询得到相同顺序。
+	again, err := repo.ListSeededIDs(ctx, nil, repositories.ListSeededVideoIDsParams{Seed: "seed-a", Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, all, again)
+
+	// 按键集翻页拼接后与一次性查询一致，无重复无遗漏。
+	var paged []repositories.SeededVideoID
+	var after *repositories.SeededVideoCursor
+	for {
+		page, err := repo.ListSeededIDs(ctx, nil, repositories.ListSeededVideoIDsParams{Seed: "seed-a", After: after, Limit: 3})
+		require.NoError(t, err)
+		paged = append(paged, page...)
+		if len(page) < 3 {
+			break
+		}
+		last := page[len(page)-1]
+		after = &repositories.SeededVideoCursor{SortKey: last.SortKey, VideoID: last.VideoID}
+	}
+	require.Equal(t, all, paged)
+
+	// 不同种子得到不同顺序。
+	other, err := repo.ListSeededIDs(ctx, nil, repositories.ListSeededVideoIDsParams{Seed: "seed-b", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, other, 7)
+	require.NotEqual(t, videoIDsOf(all), videoIDsOf(other))
+
+	none, err := repo.ListSeededIDs(ctx, nil, repositories.ListSeededVideoIDsParams{Seed: "seed-a"})
+	require.NoError(t, err)
+	require.Nil(t, none)
+}
+
+func videoIDsOf(rows []repositories.SeededVideoID) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.VideoID)
+	}
+	return ids
+}
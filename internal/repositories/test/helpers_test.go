@@ -115,7 +115,15 @@ func resetDatabase(t *testing.T) {
 	_, err := testPool.Exec(context.Background(), `
 		TRUNCATE TABLE
 			feed.inbox_events,
+			feed.outbox_events,
 			feed.recommendation_logs,
+			feed.served_pages,
+			feed.idempotency_snapshots,
+			feed.rate_limit_buckets,
+			feed.user_video_state,
+			feed.user_watch_history,
+			feed.review_schedule,
+			feed.curation_rules,
 			feed.videos_projection
 		RESTART IDENTITY
 	`)
@@ -126,15 +134,44 @@ func newVideoProjectionRepo() *repositories.FeedVideoProjectionRepository {
 	return repositories.NewFeedVideoProjectionRepository(testPool, stdLogger)
 }
 
+func newIdempotencyRepo() *repositories.FeedIdempotencyRepository {
+	return repositories.NewFeedIdempotencyRepository(testPool, stdLogger)
+}
+
+func newRateLimitRepo() *repositories.RateLimitRepository {
+	return repositories.NewRateLimitRepository(testPool, stdLogger)
+}
+
 func newRecommendationLogRepo() *repositories.FeedRecommendationLogRepository {
 	return repositories.NewFeedRecommendationLogRepository(testPool, stdLogger)
 }
 
+func newUserVideoStateRepo() *repositories.UserVideoStateRepository {
+	return repositories.NewUserVideoStateRepository(testPool, stdLogger)
+}
+
+func newUserWatchHistoryRepo() *repositories.UserWatchHistoryRepository {
+	return repositories.NewUserWatchHistoryRepository(testPool, stdLogger)
+}
+
+func newReviewScheduleRepo() *repositories.ReviewScheduleRepository {
+	return repositories.NewReviewScheduleRepository(testPool, stdLogger)
+}
+
+func newCurationRuleRepo() *repositories.CurationRuleRepository {
+	return repositories.NewCurationRuleRepository(testPool, stdLogger)
+}
+
 func newInboxRepo(t *testing.T) *repositories.InboxRepository {
 	t.Helper()
 	return repositories.NewInboxRepository(testPool, stdLogger, outboxcfg.Config{Schema: "feed"})
 }
 
+func newOutboxRepo(t *testing.T) *repositories.OutboxRepository {
+	t.Helper()
+	return repositories.NewOutboxRepository(testPool, stdLogger, outboxcfg.Config{Schema: "feed"})
+}
+
 func stringPtr(value string) *string {
 	return &value
 }
@@ -0,0 +1,49 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxRepository_EnqueueAndExistingEventIDs(t *testing.T) {
+	resetDatabase(t)
+
+	ctx := context.Background()
+	repo := newOutboxRepo(t)
+	eventID := uuid.New()
+	aggregateID := uuid.New()
+
+	require.NoError(t, repo.Enqueue(ctx, nil, repositories.OutboxMessage{
+		EventID:       eventID,
+		AggregateType: "recommendation_log",
+		AggregateID:   aggregateID,
+		EventType:     "feed.click",
+		Payload:       []byte("payload"),
+		Headers:       map[string]string{"event_type": "feed.click"},
+		AvailableAt:   time.Now().UTC(),
+	}))
+
+	existing, err := repo.ExistingEventIDs(ctx, nil, []uuid.UUID{eventID, uuid.New()})
+	require.NoError(t, err)
+	require.Len(t, existing, 1)
+	require.Contains(t, existing, eventID)
+
+	var (
+		storedType string
+		published  *time.Time
+	)
+	require.NoError(t, testPool.QueryRow(ctx, `
+		SELECT event_type, published_at FROM feed.outbox_events WHERE event_id = $1
+	`, eventID).Scan(&storedType, &published))
+	require.Equal(t, "feed.click", storedType)
+	require.Nil(t, published)
+
+	empty, err := repo.ExistingEventIDs(ctx, nil, nil)
+	require.NoError(t, err)
+	require.Empty(t, empty)
+}
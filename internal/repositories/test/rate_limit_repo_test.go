@@ -0,0 +1,50 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitRepository_Take(t *testing.T) {
+	resetDatabase(t)
+
+	ctx := context.Background()
+	repo := newRateLimitRepo()
+
+	// 极低速率保证测试期间几乎不补充令牌。
+	for i := 0; i < 2; i++ {
+		take, err := repo.Take(ctx, nil, "GetFeed||user:1", 0.001, 2)
+		require.NoError(t, err)
+		require.True(t, take.Allowed)
+	}
+
+	take, err := repo.Take(ctx, nil, "GetFeed||user:1", 0.001, 2)
+	require.NoError(t, err)
+	require.False(t, take.Allowed)
+	require.Less(t, take.Tokens, 1.0)
+
+	other, err := repo.Take(ctx, nil, "GetFeed||user:2", 0.001, 2)
+	require.NoError(t, err)
+	require.True(t, other.Allowed)
+}
+
+func TestRateLimitRepository_PurgeIdle(t *testing.T) {
+	resetDatabase(t)
+
+	ctx := context.Background()
+	repo := newRateLimitRepo()
+
+	_, err := repo.Take(ctx, nil, "GetFeed||user:1", 1, 1)
+	require.NoError(t, err)
+
+	deleted, err := repo.PurgeIdle(ctx, nil, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, deleted)
+
+	deleted, err = repo.PurgeIdle(ctx, nil, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+}
@@ -0,0 +1,62 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewScheduleRepository_ListDueHonorsVersions(t *testing.T) {
+	resetDatabase(t)
+
+	ctx := context.Background()
+	repo := newReviewScheduleRepo()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	overdue, due, future, removed := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	for _, input := range []repositories.UpsertReviewInput{
+		{UserID: "user-1", VideoID: due, DueAt: now.Add(-time.Minute), Reps: 1, Version: 1, UpdatedAt: now},
+		{UserID: "user-1", VideoID: overdue, DueAt: now.Add(-24 * time.Hour), Reps: 4, LastReviewedAt: timePtr(now.Add(-72 * time.Hour)), Version: 1, UpdatedAt: now},
+		{UserID: "user-1", VideoID: future, DueAt: now.Add(time.Hour), Version: 1, UpdatedAt: now},
+		{UserID: "user-1", VideoID: removed, DueAt: now.Add(-time.Hour), Version: 1, UpdatedAt: now},
+		{UserID: "user-2", VideoID: due, DueAt: now.Add(-time.Hour), Version: 1, UpdatedAt: now},
+	} {
+		applied, err := repo.Upsert(ctx, nil, input)
+		require.NoError(t, err)
+		require.True(t, applied)
+	}
+
+	applied, err := repo.Deactivate(ctx, nil, "user-1", removed, 2, now)
+	require.NoError(t, err)
+	require.True(t, applied)
+
+	// 旧版本事件既不能覆盖计划，也不能把已移除的视频重新激活。
+	applied, err = repo.Upsert(ctx, nil, repositories.UpsertReviewInput{UserID: "user-1", VideoID: removed, DueAt: now.Add(-time.Hour), Version: 1, UpdatedAt: now})
+	require.NoError(t, err)
+	require.False(t, applied)
+
+	schedules, err := repo.ListDue(ctx, nil, "user-1", now, 10)
+	require.NoError(t, err)
+	require.Len(t, schedules, 2)
+	require.Equal(t, overdue.String(), schedules[0].VideoID)
+	require.Equal(t, int32(4), schedules[0].Reps)
+	require.NotNil(t, schedules[0].LastReviewedAt)
+	require.Equal(t, due.String(), schedules[1].VideoID)
+
+	schedules, err = repo.ListDue(ctx, nil, "user-1", now, 1)
+	require.NoError(t, err)
+	require.Len(t, schedules, 1)
+
+	// 移除事件先于计划事件到达时占住版本，之后的同版本计划事件被忽略。
+	orphan := uuid.New()
+	applied, err = repo.Deactivate(ctx, nil, "user-1", orphan, 5, now)
+	require.NoError(t, err)
+	require.True(t, applied)
+	applied, err = repo.Upsert(ctx, nil, repositories.UpsertReviewInput{UserID: "user-1", VideoID: orphan, DueAt: now.Add(-time.Hour), Version: 5, UpdatedAt: now})
+	require.NoError(t, err)
+	require.False(t, applied)
+}
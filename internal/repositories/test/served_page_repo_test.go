@@ -0,0 +1,74 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServedPageRepository_InsertListPurge(t *testing.T) {
+	resetDatabase(t)
+
+	ctx := context.Background()
+	repo := repositories.NewServedPageRepository(testPool, stdLogger)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	hash, version, scene := "hash-1", "v1", "home"
+	fresh := po.ServedPage{
+		LogID:                uuid.NewString(),
+		UserIDHash:           &hash,
+		UserIDKeyVersion:     &version,
+		Scene:                &scene,
+		RecommendationSource: "mock",
+		ServedItems:          []po.ServedItemLog{{VideoID: uuid.NewString(), Position: 1, ProjectionVersion: 3}},
+		GeneratedAt:          now,
+	}
+	stale := po.ServedPage{LogID: uuid.NewString(), Guest: true, RecommendationSource: "guest", GeneratedAt: now.Add(-72 * time.Hour)}
+	require.NoError(t, repo.Insert(ctx, nil, fresh))
+	require.NoError(t, repo.Insert(ctx, nil, stale))
+
+	pages, err := repo.ListByIDs(ctx, nil, []uuid.UUID{uuid.MustParse(fresh.LogID), uuid.New()})
+	require.NoError(t, err)
+	require.Len(t, pages, 1)
+	require.Equal(t, fresh.LogID, pages[0].LogID)
+	require.Equal(t, "hash-1", *pages[0].UserIDHash)
+	require.Equal(t, "home", *pages[0].Scene)
+	require.Equal(t, fresh.ServedItems, pages[0].ServedItems)
+	require.True(t, fresh.GeneratedAt.Equal(pages[0].GeneratedAt))
+
+	deleted, err := repo.PurgeBefore(ctx, nil, now.Add(-48*time.Hour))
+	require.NoError(t, err)
+	require.EqualValues(t, 1, deleted)
+
+	pages, err = repo.ListByIDs(ctx, nil, []uuid.UUID{uuid.MustParse(stale.LogID)})
+	require.NoError(t, err)
+	require.Empty(t, pages)
+}
+
+func TestServedPageRepository_InsertBatch(t *testing.T) {
+	resetDatabase(t)
+
+	ctx := context.Background()
+	repo := repositories.NewServedPageRepository(testPool, stdLogger)
+
+	hash := "hash-batch"
+	pages := []po.ServedPage{
+		{LogID: uuid.NewString(), UserIDHash: &hash, RecommendationSource: "mock", ServedItems: []po.ServedItemLog{{VideoID: uuid.NewString(), Position: 1}}},
+		{LogID: uuid.NewString(), Guest: true, RecommendationSource: "guest"},
+	}
+	written, err := repo.InsertBatch(ctx, nil, pages)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, written)
+
+	stored, err := repo.ListByIDs(ctx, nil, []uuid.UUID{uuid.MustParse(pages[0].LogID), uuid.MustParse(pages[1].LogID)})
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+
+	_, err = repo.InsertBatch(ctx, nil, []po.ServedPage{{LogID: "not-a-uuid"}})
+	require.Error(t, err)
+}
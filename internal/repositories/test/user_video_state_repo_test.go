@@ -0,0 +1,110 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserVideoStateRepository_VersionedUpserts(t *testing.T) {
+	resetDatabase(t)
+
+	ctx := context.Background()
+	repo := newUserVideoStateRepo()
+	videoID, otherVideo := uuid.New(), uuid.New()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	applied, err := repo.UpsertEngagement(ctx, nil, repositories.UpsertEngagementInput{
+		UserID: "user-1", VideoID: videoID, Kind: repositories.EngagementLike, Active: true, Version: 5, UpdatedAt: now,
+	})
+	require.NoError(t, err)
+	require.True(t, applied)
+
+	// 同一维度的旧版本被忽略，其他维度各自按版本生效。
+	applied, err = repo.UpsertEngagement(ctx, nil, repositories.UpsertEngagementInput{
+		UserID: "user-1", VideoID: videoID, Kind: repositories.EngagementLike, Active: false, Version: 4, UpdatedAt: now,
+	})
+	require.NoError(t, err)
+	require.False(t, applied)
+
+	applied, err = repo.UpsertEngagement(ctx, nil, repositories.UpsertEngagementInput{
+		UserID: "user-1", VideoID: videoID, Kind: repositories.EngagementBookmark, Active: true, Version: 1, UpdatedAt: now,
+	})
+	require.NoError(t, err)
+	require.True(t, applied)
+
+	watchedAt := now.Add(-time.Hour)
+	applied, err = repo.UpsertProgress(ctx, nil, repositories.UpsertProgressInput{
+		UserID: "user-1", VideoID: videoID, WatchedRatio: 0.6, LastWatchedAt: &watchedAt, Version: 1, UpdatedAt: now,
+	})
+	require.NoError(t, err)
+	require.True(t, applied)
+
+	applied, err = repo.UpsertProgress(ctx, nil, repositories.UpsertProgressInput{
+		UserID: "user-1", VideoID: videoID, WatchedRatio: 0.1, Version: 1, UpdatedAt: now,
+	})
+	require.NoError(t, err)
+	require.False(t, applied)
+
+	_, err = repo.UpsertEngagement(ctx, nil, repositories.UpsertEngagementInput{
+		UserID: "user-2", VideoID: otherVideo, Kind: repositories.EngagementLike, Active: true, Version: 1, UpdatedAt: now,
+	})
+	require.NoError(t, err)
+
+	states, err := repo.ListByVideoIDs(ctx, nil, "user-1", []uuid.UUID{videoID, otherVideo})
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	state := states[0]
+	require.Equal(t, videoID.String(), state.VideoID)
+	require.True(t, state.Liked)
+	require.Equal(t, int64(5), state.LikedVersion)
+	require.True(t, state.Bookmarked)
+	require.Equal(t, 0.6, state.WatchedRatio)
+	require.NotNil(t, state.LastWatchedAt)
+	require.WithinDuration(t, watchedAt, *state.LastWatchedAt, time.Millisecond)
+
+	_, err = repo.UpsertEngagement(ctx, nil, repositories.UpsertEngagementInput{
+		UserID: "user-1", VideoID: videoID, Kind: "share", Version: 9, UpdatedAt: now,
+	})
+	require.Error(t, err)
+}
+
+func TestUserVideoStateRepository_ListContinueWatching(t *testing.T) {
+	resetDatabase(t)
+
+	ctx := context.Background()
+	repo := newUserVideoStateRepo()
+	base := time.Now().UTC().Truncate(time.Millisecond)
+
+	ratios := []float64{0.02, 0.3, 0.5, 0.7, 0.95}
+	ids := make([]uuid.UUID, len(ratios))
+	for i, ratio := range ratios {
+		ids[i] = uuid.New()
+		watchedAt := base.Add(time.Duration(i) * time.Minute)
+		position := int64(i+1) * 1_000_000
+		_, err := repo.UpsertProgress(ctx, nil, repositories.UpsertProgressInput{
+			UserID: "user-1", VideoID: ids[i], WatchedRatio: ratio, PositionMicros: &position, LastWatchedAt: &watchedAt, Version: 1, UpdatedAt: watchedAt,
+		})
+		require.NoError(t, err)
+	}
+
+	params := repositories.ListContinueWatchingParams{UserID: "user-1", MinRatio: 0.05, MaxRatio: 0.9, Limit: 2}
+	first, err := repo.ListContinueWatching(ctx, nil, params)
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+	require.Equal(t, ids[3].String(), first[0].VideoID)
+	require.Equal(t, ids[2].String(), first[1].VideoID)
+	require.NotNil(t, first[0].LastPositionMicros)
+	require.Equal(t, int64(4_000_000), *first[0].LastPositionMicros)
+
+	last := first[len(first)-1]
+	params.After = &repositories.ContinueWatchingCursor{LastWatchedAt: *last.LastWatchedAt, VideoID: uuid.MustParse(last.VideoID)}
+	second, err := repo.ListContinueWatching(ctx, nil, params)
+	require.NoError(t, err)
+	require.Len(t, second, 1)
+	require.Equal(t, ids[1].String(), second[0].VideoID)
+}
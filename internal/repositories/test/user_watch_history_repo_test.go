@@ -0,0 +1,44 @@
+package repositories_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserWatchHistoryRepository_KeepsMaxRatio(t *testing.T) {
+	resetDatabase(t)
+
+	ctx := context.Background()
+	repo := newUserWatchHistoryRepo()
+	videoID, unwatched := uuid.New(), uuid.New()
+	base := time.Now().UTC().Truncate(time.Millisecond)
+
+	inputs := []repositories.RecordProgressInput{
+		{UserID: "user-1", VideoID: videoID, WatchedRatio: 0.95, WatchedAt: base},
+		// 重看从头开始，最高进度不回退。
+		{UserID: "user-1", VideoID: videoID, WatchedRatio: 0.1, WatchedAt: base.Add(time.Hour)},
+		// 乱序到达的更早事件只前移首次观看时间。
+		{UserID: "user-1", VideoID: videoID, WatchedRatio: 0.5, WatchedAt: base.Add(-time.Hour)},
+		{UserID: "user-2", VideoID: unwatched, WatchedRatio: 1, WatchedAt: base},
+	}
+	for _, input := range inputs {
+		require.NoError(t, repo.RecordProgress(ctx, nil, input))
+	}
+
+	history, err := repo.ListByVideoIDs(ctx, nil, "user-1", []uuid.UUID{videoID, unwatched})
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, videoID.String(), history[0].VideoID)
+	require.Equal(t, 0.95, history[0].MaxWatchedRatio)
+	require.WithinDuration(t, base.Add(-time.Hour), history[0].FirstWatchedAt, time.Millisecond)
+	require.WithinDuration(t, base.Add(time.Hour), history[0].LastWatchedAt, time.Millisecond)
+
+	history, err = repo.ListByVideoIDs(ctx, nil, "user-1", nil)
+	require.NoError(t, err)
+	require.Empty(t, history)
+}
@@ -0,0 +1,198 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories/feeddb"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories/mappers"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// EngagementKind 为用户对视频的互动类型。
+type EngagementKind string
+
+const (
+	// EngagementLike 表示点赞。
+	EngagementLike EngagementKind = "like"
+	// EngagementBookmark 表示收藏。
+	EngagementBookmark EngagementKind = "bookmark"
+)
+
+// UserVideoStateRepository 维护 feed.user_video_state 投影。
+type UserVideoStateRepository struct {
+	db      *pgxpool.Pool
+	queries *feeddb.Queries
+	log     *log.Helper
+}
+
+// NewUserVideoStateRepository 构造仓储实例。
+func NewUserVideoStateRepository(db *pgxpool.Pool, logger log.Logger) *UserVideoStateRepository {
+	return &UserVideoStateRepository{
+		db:      db,
+		queries: feeddb.New(db),
+		log:     log.NewHelper(logger),
+	}
+}
+
+// UpsertEngagementInput 描述点赞/收藏状态写入参数。
+type UpsertEngagementInput struct {
+	UserID  string
+	VideoID uuid.UUID
+	Kind    EngagementKind
+	// Active 为 true 表示添加，false 表示取消。
+	Active    bool
+	Version   int64
+	UpdatedAt time.Time
+}
+
+// UpsertEngagement 按版本写入点赞或收藏状态，返回是否生效；事件版本不高于当前版本时返回 false。
+func (r *UserVideoStateRepository) UpsertEngagement(ctx context.Context, sess txmanager.Session, input UpsertEngagementInput) (bool, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	updatedAt := mappers.ToPgTimestamptzPtr(&input.UpdatedAt)
+	var (
+		affected int64
+		err      error
+	)
+	switch input.Kind {
+	case EngagementLike:
+		affected, err = queries.UpsertUserVideoLiked(ctx, feeddb.UpsertUserVideoLikedParams{
+			UserID:    input.UserID,
+			VideoID:   input.VideoID,
+			Liked:     input.Active,
+			Version:   input.Version,
+			UpdatedAt: updatedAt,
+		})
+	case EngagementBookmark:
+		affected, err = queries.UpsertUserVideoBookmarked(ctx, feeddb.UpsertUserVideoBookmarkedParams{
+			UserID:     input.UserID,
+			VideoID:    input.VideoID,
+			Bookmarked: input.Active,
+			Version:    input.Version,
+			UpdatedAt:  updatedAt,
+		})
+	default:
+		return false, fmt.Errorf("upsert user video engagement: unsupported kind %q", input.Kind)
+	}
+	if err != nil {
+		r.log.WithContext(ctx).Errorw("msg", "upsert user video engagement failed", "video_id", input.VideoID, "kind", input.Kind, "error", err)
+		return false, fmt.Errorf("upsert user video engagement: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// UpsertProgressInput 描述观看进度写入参数。
+type UpsertProgressInput struct {
+	UserID       string
+	VideoID      uuid.UUID
+	WatchedRatio float64
+	// PositionMicros 为播放位置，发布端未提供时为空。
+	PositionMicros *int64
+	LastWatchedAt  *time.Time
+	Version        int64
+	UpdatedAt      time.Time
+}
+
+// UpsertProgress 按版本写入观看进度，返回是否生效；事件版本不高于当前版本时返回 false。
+func (r *UserVideoStateRepository) UpsertProgress(ctx context.Context, sess txmanager.Session, input UpsertProgressInput) (bool, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	affected, err := queries.UpsertUserVideoProgress(ctx, feeddb.UpsertUserVideoProgressParams{
+		UserID:             input.UserID,
+		VideoID:            input.VideoID,
+		WatchedRatio:       input.WatchedRatio,
+		LastPositionMicros: mappers.ToPgInt8(input.PositionMicros),
+		LastWatchedAt:      mappers.ToPgTimestamptzPtr(input.LastWatchedAt),
+		Version:            input.Version,
+		UpdatedAt:          mappers.ToPgTimestamptzPtr(&input.UpdatedAt),
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorw("msg", "upsert user video progress failed", "video_id", input.VideoID, "error", err)
+		return false, fmt.Errorf("upsert user video progress: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// ListByVideoIDs 批量读取用户在指定视频上的状态，无记录的视频不出现在结果中。
+func (r *UserVideoStateRepository) ListByVideoIDs(ctx context.Context, sess txmanager.Session, userID string, videoIDs []uuid.UUID) ([]*po.UserVideoState, error) {
+	if userID == "" || len(videoIDs) == 0 {
+		return nil, nil
+	}
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	rows, err := queries.ListUserVideoStates(ctx, feeddb.ListUserVideoStatesParams{
+		UserID:   userID,
+		VideoIds: videoIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list user video states: %w", err)
+	}
+	result := make([]*po.UserVideoState, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, mappers.UserVideoStateFromRow(row))
+	}
+	return result, nil
+}
+
+// ContinueWatchingCursor 为 (last_watched_at, video_id) 键集分页位置，列表返回严格位于其后的记录。
+type ContinueWatchingCursor struct {
+	LastWatchedAt time.Time
+	VideoID       uuid.UUID
+}
+
+// ListContinueWatchingParams 描述继续学习列表的查询条件。
+type ListContinueWatchingParams struct {
+	UserID string
+	// MinRatio 与 MaxRatio 为观看进度区间（闭区间）。
+	MinRatio float64
+	MaxRatio float64
+	After    *ContinueWatchingCursor
+	Limit    int
+}
+
+// ListContinueWatching 按最近观看时间倒序返回观看进度位于区间内的视频。
+func (r *UserVideoStateRepository) ListContinueWatching(ctx context.Context, sess txmanager.Session, params ListContinueWatchingParams) ([]*po.UserVideoState, error) {
+	if params.UserID == "" {
+		return nil, nil
+	}
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	dbParams := feeddb.ListContinueWatchingParams{
+		UserID:   params.UserID,
+		MinRatio: params.MinRatio,
+		MaxRatio: params.MaxRatio,
+		RowLimit: int32(limit),
+	}
+	if params.After != nil {
+		dbParams.AfterWatchedAt = pgtype.Timestamptz{Time: params.After.LastWatchedAt.UTC(), Valid: true}
+		dbParams.AfterVideoID = pgtype.UUID{Bytes: params.After.VideoID, Valid: true}
+	}
+	rows, err := queries.ListContinueWatching(ctx, dbParams)
+	if err != nil {
+		return nil, fmt.Errorf("list continue watching: %w", err)
+	}
+	result := make([]*po.UserVideoState, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, mappers.UserVideoStateFromRow(row))
+	}
+	return result, nil
+}
@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories/feeddb"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories/mappers"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// UserWatchHistoryRepository 维护 feed.user_watch_history 投影。
+type UserWatchHistoryRepository struct {
+	db      *pgxpool.Pool
+	queries *feeddb.Queries
+	log     *log.Helper
+}
+
+// NewUserWatchHistoryRepository 构造仓储实例。
+func NewUserWatchHistoryRepository(db *pgxpool.Pool, logger log.Logger) *UserWatchHistoryRepository {
+	return &UserWatchHistoryRepository{
+		db:      db,
+		queries: feeddb.New(db),
+		log:     log.NewHelper(logger),
+	}
+}
+
+// RecordProgressInput 描述一次观看进度。
+type RecordProgressInput struct {
+	UserID       string
+	VideoID      uuid.UUID
+	WatchedRatio float64
+	WatchedAt    time.Time
+}
+
+// RecordProgress 合并观看进度：最高进度只增不减，重复或乱序的事件不会回退历史。
+func (r *UserWatchHistoryRepository) RecordProgress(ctx context.Context, sess txmanager.Session, input RecordProgressInput) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	err := queries.RecordUserWatchProgress(ctx, feeddb.RecordUserWatchProgressParams{
+		UserID:       input.UserID,
+		VideoID:      input.VideoID,
+		WatchedRatio: input.WatchedRatio,
+		WatchedAt:    mappers.ToPgTimestamptzPtr(&input.WatchedAt),
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorw("msg", "record user watch progress failed", "video_id", input.VideoID, "error", err)
+		return fmt.Errorf("record user watch progress: %w", err)
+	}
+	return nil
+}
+
+// ListByVideoIDs 批量读取用户在指定视频上的观看历史，未观看的视频不出现在结果中。
+func (r *UserWatchHistoryRepository) ListByVideoIDs(ctx context.Context, sess txmanager.Session, userID string, videoIDs []uuid.UUID) ([]*po.UserWatchHistory, error) {
+	if userID == "" || len(videoIDs) == 0 {
+		return nil, nil
+	}
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	rows, err := queries.ListUserWatchHistory(ctx, feeddb.ListUserWatchHistoryParams{
+		UserID:   userID,
+		VideoIds: videoIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list user watch history: %w", err)
+	}
+	result := make([]*po.UserWatchHistory, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, mappers.UserWatchHistoryFromRow(row))
+	}
+	return result, nil
+}
@@ -0,0 +1,433 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"golang.org/x/sync/errgroup"
+)
+
+// BlendStrategy 为多路推荐源的混排策略。
+type BlendStrategy string
+
+const (
+	// BlendStrategySlots 按权重把整页切分为各来源的固定槽位（最大余数法），来源条目不足时由其他来源补齐。
+	BlendStrategySlots BlendStrategy = "slots"
+	// BlendStrategyWeightedRoundRobin 按权重平滑轮询逐条选取，不设每页上限，耗尽的来源退出轮询。
+	BlendStrategyWeightedRoundRobin BlendStrategy = "weighted_round_robin"
+
+	blendingSource     = "blend"
+	defaultBlendBudget = 150 * time.Millisecond
+)
+
+// BlendingSources 按名称登记可参与混排的推荐源，未启用（nil）的来源不登记。
+type BlendingSources map[string]RecommendationProvider
+
+// NewBlendingSources 登记已有的推荐源：mock 为个性化推荐占位，fresh 为最新发布，review 为到期复习，remote 为外部推荐服务。
+func NewBlendingSources(mock *MockRecommendationProvider, fresh *FreshRecommendationProvider, review *ReviewDueProvider, remote RemoteRecommendationSource) BlendingSources {
+	sources := BlendingSources{}
+	if mock != nil {
+		sources[mockRecommendationSource] = mock
+	}
+	if fresh != nil {
+		sources[freshRecommendationSource] = fresh
+	}
+	if review != nil {
+		sources[reviewSource] = review
+	}
+	if remote != nil {
+		sources[remote.Source()] = remote
+	}
+	return sources
+}
+
+// BlendingConfig 控制首页多路推荐混排。
+type BlendingConfig struct {
+	Enabled  bool
+	Strategy BlendStrategy
+	// Sources 按优先级排列：权重相同时靠前的来源先出条目，非首页场景直接走第一个来源。
+	Sources []BlendingSourceConfig
+}
+
+// BlendingSourceConfig 为单个来源的权重与耗时预算。
+type BlendingSourceConfig struct {
+	Name   string
+	Weight float64
+	// Budget 为该来源的调用超时，超时视为该来源失败。
+	Budget time.Duration
+}
+
+type blendSource struct {
+	name     string
+	provider RecommendationProvider
+	weight   float64
+	budget   time.Duration
+}
+
+// BlendingRecommendationProvider 并发调用多个推荐源并按权重交织结果：
+// 每个来源独立超时，部分来源失败时以其余来源补齐整页，全部失败才返回错误。
+type BlendingRecommendationProvider struct {
+	sources  []blendSource
+	strategy BlendStrategy
+	log      *log.Helper
+}
+
+// NewBlendingRecommendationProvider 构造混排 Provider；未启用时返回 nil。
+// 配置引用的来源未登记（例如对应功能未启用）时跳过并告警，没有可用来源或权重非法时返回错误。
+func NewBlendingRecommendationProvider(registry BlendingSources, cfg BlendingConfig, logger log.Logger) (*BlendingRecommendationProvider, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	helper := log.NewHelper(logger)
+	strategy := cfg.Strategy
+	switch strategy {
+	case "":
+		strategy = BlendStrategySlots
+	case BlendStrategySlots, BlendStrategyWeightedRoundRobin:
+	default:
+		return nil, fmt.Errorf("blending: unsupported strategy %q", cfg.Strategy)
+	}
+	seen := make(map[string]struct{}, len(cfg.Sources))
+	sources := make([]blendSource, 0, len(cfg.Sources))
+	for _, sc := range cfg.Sources {
+		if _, dup := seen[sc.Name]; dup {
+			return nil, fmt.Errorf("blending: duplicate source %q", sc.Name)
+		}
+		seen[sc.Name] = struct{}{}
+		if sc.Weight <= 0 || math.IsNaN(sc.Weight) || math.IsInf(sc.Weight, 0) {
+			return nil, fmt.Errorf("blending: source %q weight must be positive", sc.Name)
+		}
+		provider, ok := registry[sc.Name]
+		if !ok || provider == nil {
+			helper.Warnw("msg", "blending: skip unavailable source", "source", sc.Name)
+			continue
+		}
+		budget := sc.Budget
+		if budget <= 0 {
+			budget = defaultBlendBudget
+		}
+		sources = append(sources, blendSource{name: sc.Name, provider: provider, weight: sc.Weight, budget: budget})
+	}
+	if len(sources) == 0 {
+		return nil, errors.New("blending: no available sources")
+	}
+	return &BlendingRecommendationProvider{
+		sources:  sources,
+		strategy: strategy,
+		log:      helper,
+	}, nil
+}
+
+// Source 返回推荐来源标识；单条目的原始来源见 Metadata["source"]。
+func (p *BlendingRecommendationProvider) Source() string {
+	return blendingSource
+}
+
+// GetFeed 仅对首页混排，其余场景直接调用第一个来源。
+// 混排结果的游标按来源记录续读位置（见 blendCursor），下一页从各来源实际取用到的位置继续，无法解析时返回 ErrInvalidPageToken。
+func (p *BlendingRecommendationProvider) GetFeed(ctx context.Context, input RecommendationInput) (*RecommendationResult, error) {
+	if input.Scene != "" && input.Scene != SceneHome {
+		return p.sources[0].provider.GetFeed(ctx, input)
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	state := blendCursor{Sources: make(map[string]blendSourceCursor, len(p.sources))}
+	if input.Cursor != "" {
+		decoded, err := decodeBlendCursor(input.Cursor)
+		if err != nil {
+			return nil, wrapFeedError(ErrInvalidPageToken, err)
+		}
+		state = decoded
+	}
+
+	// 每个来源都按整页取数，以便在其他来源失败或重复时补齐。
+	pages := make([]blendPage, len(p.sources))
+	errs := make([]error, len(p.sources))
+	var g errgroup.Group
+	for i, src := range p.sources {
+		pos := state.Sources[src.name]
+		if pos.Done {
+			continue
+		}
+		g.Go(func() error {
+			pages[i], errs[i] = p.fetch(ctx, src, RecommendationInput{
+				UserID:      input.UserID,
+				Limit:       limit + pos.Offset,
+				Scene:       input.Scene,
+				Cursor:      pos.Cursor,
+				Experiments: input.Experiments,
+			}, pos.Offset)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	var (
+		lists    = make([][]RecommendationItem, len(p.sources))
+		weights  = make([]float64, len(p.sources))
+		active   int
+		failures int
+		firstErr error
+	)
+	for i, src := range p.sources {
+		if state.Sources[src.name].Done {
+			continue
+		}
+		active++
+		if errs[i] != nil {
+			failures++
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+			continue
+		}
+		lists[i] = pages[i].items
+		weights[i] = src.weight
+	}
+	if active > 0 && failures == active {
+		p.log.WithContext(ctx).Errorw("msg", "blending: all sources failed", "error", firstErr)
+		var feedErr *FeedError
+		if errors.As(firstErr, &feedErr) {
+			return nil, firstErr
+		}
+		return nil, wrapFeedError(ErrRecommendationUnavailable, firstErr)
+	}
+
+	var caps []int
+	if p.strategy == BlendStrategySlots {
+		caps = slotQuotas(weights, limit)
+	}
+	items, consumed := interleaveSources(lists, weights, caps, limit)
+
+	// 失败的来源保持原位置，下一页重试；其余来源前进到本页实际取用的位置。
+	next := blendCursor{Sources: make(map[string]blendSourceCursor, len(p.sources))}
+	more := false
+	for i, src := range p.sources {
+		pos := state.Sources[src.name]
+		if errs[i] == nil && !pos.Done {
+			pos = advanceBlendSource(pos, pages[i], consumed[i])
+		}
+		next.Sources[src.name] = pos
+		if !pos.Done {
+			more = true
+		}
+	}
+	result := &RecommendationResult{Items: items, Source: blendingSource}
+	if more && len(items) > 0 {
+		cursor, err := encodeBlendCursor(next)
+		if err != nil {
+			return nil, wrapFeedError(ErrRecommendationUnavailable, err)
+		}
+		result.NextCursor = cursor
+	}
+	return result, nil
+}
+
+// blendPage 为单个来源本次返回的条目（已跳过续读偏移）及其原始页信息。
+type blendPage struct {
+	items []RecommendationItem
+	// fetched 为来源本次实际返回的条目数（含被跳过的偏移部分）。
+	fetched int
+	next    string
+}
+
+// fetch 在来源预算内调用单个推荐源，跳过前 offset 条已在上一页取用的条目，并把原始来源写入每个条目的 Metadata。
+func (p *BlendingRecommendationProvider) fetch(ctx context.Context, src blendSource, input RecommendationInput, offset int) (blendPage, error) {
+	callCtx, cancel := context.WithTimeout(ctx, src.budget)
+	defer cancel()
+	startedAt := time.Now()
+	result, err := src.provider.GetFeed(callCtx, input)
+	if err != nil {
+		p.log.WithContext(ctx).Warnw("msg", "blending: source failed", "source", src.name, "latency_ms", time.Since(startedAt).Milliseconds(), "error", err)
+		return blendPage{}, err
+	}
+	if result == nil {
+		return blendPage{}, nil
+	}
+	origin := result.Source
+	if origin == "" {
+		origin = src.name
+	}
+	page := blendPage{fetched: len(result.Items), next: result.NextCursor}
+	if offset < len(result.Items) {
+		page.items = make([]RecommendationItem, 0, len(result.Items)-offset)
+		for _, item := range result.Items[offset:] {
+			meta := make(map[string]string, len(item.Metadata)+1)
+			maps.Copy(meta, item.Metadata)
+			meta["source"] = origin
+			item.Metadata = meta
+			page.items = append(page.items, item)
+		}
+	}
+	return page, nil
+}
+
+// blendCursor 为混排翻页游标，按来源名记录续读位置；新增来源缺省从头读取。
+type blendCursor struct {
+	Sources map[string]blendSourceCursor `json:"s"`
+}
+
+// blendSourceCursor 为单个来源的续读位置：以 Cursor 调用来源并跳过前 Offset 条；Done 表示来源已读完。
+type blendSourceCursor struct {
+	Cursor string `json:"c,omitempty"`
+	Offset int    `json:"o,omitempty"`
+	Done   bool   `json:"d,omitempty"`
+}
+
+// advanceBlendSource 计算来源取用 consumed 条后的续读位置：
+// 末条带逐条游标时直接从其后续读；读完本页时换用来源的下一页游标，没有下一页则标记读完；否则累加偏移。
+func advanceBlendSource(pos blendSourceCursor, page blendPage, consumed int) blendSourceCursor {
+	if consumed > 0 {
+		if cursor := page.items[consumed-1].Cursor; cursor != "" {
+			return blendSourceCursor{Cursor: cursor}
+		}
+	}
+	offset := pos.Offset + consumed
+	if offset >= page.fetched {
+		if page.next == "" {
+			return blendSourceCursor{Done: true}
+		}
+		return blendSourceCursor{Cursor: page.next, Offset: offset - page.fetched}
+	}
+	pos.Offset = offset
+	return pos
+}
+
+// encodeBlendCursor 将各来源续读位置编码为不透明游标：base64url(JSON)。
+func encodeBlendCursor(cursor blendCursor) (string, error) {
+	raw, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func decodeBlendCursor(token string) (blendCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return blendCursor{}, err
+	}
+	var cursor blendCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return blendCursor{}, err
+	}
+	if cursor.Sources == nil {
+		return blendCursor{}, errors.New("malformed blending cursor")
+	}
+	for name, pos := range cursor.Sources {
+		if pos.Offset < 0 {
+			return blendCursor{}, fmt.Errorf("malformed blending cursor offset for source %q", name)
+		}
+	}
+	return cursor, nil
+}
+
+// slotQuotas 按最大余数法把 limit 个槽位分配给权重大于 0 的来源，余数相同时靠前的来源优先。
+func slotQuotas(weights []float64, limit int) []int {
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+	quotas := make([]int, len(weights))
+	if total <= 0 {
+		return quotas
+	}
+	type remainder struct {
+		index int
+		frac  float64
+	}
+	rems := make([]remainder, 0, len(weights))
+	assigned := 0
+	for i, w := range weights {
+		exact := float64(limit) * w / total
+		quotas[i] = int(math.Floor(exact))
+		assigned += quotas[i]
+		if w > 0 {
+			rems = append(rems, remainder{index: i, frac: exact - float64(quotas[i])})
+		}
+	}
+	sort.SliceStable(rems, func(a, b int) bool { return rems[a].frac > rems[b].frac })
+	for i := 0; assigned < limit && i < len(rems); i++ {
+		quotas[rems[i].index]++
+		assigned++
+	}
+	return quotas
+}
+
+// interleaveSources 以平滑加权轮询交织各来源的条目并按 video_id 去重。
+// caps 非空时先按各来源槽位上限选取，槽位用尽或来源耗尽后再不设上限地补齐到 limit。
+// 第二个返回值为各来源已扫描的条目数（含因重复跳过的条目），即下一页的续读位置。
+func interleaveSources(lists [][]RecommendationItem, weights []float64, caps []int, limit int) ([]RecommendationItem, []int) {
+	out := make([]RecommendationItem, 0, limit)
+	seen := make(map[string]struct{}, limit)
+	cursors := make([]int, len(lists))
+	taken := make([]int, len(lists))
+
+	// next 返回来源 i 中下一个未出现过的条目。
+	next := func(i int) (RecommendationItem, bool) {
+		for cursors[i] < len(lists[i]) {
+			item := lists[i][cursors[i]]
+			cursors[i]++
+			if _, dup := seen[item.VideoID]; dup {
+				continue
+			}
+			return item, true
+		}
+		return RecommendationItem{}, false
+	}
+
+	pass := func(capped bool) {
+		current := make([]float64, len(lists))
+		active := make([]bool, len(lists))
+		for i := range lists {
+			active[i] = weights[i] > 0 && (!capped || caps[i] > 0)
+		}
+		for len(out) < limit {
+			var total float64
+			chosen := -1
+			for i := range lists {
+				if !active[i] {
+					continue
+				}
+				current[i] += weights[i]
+				total += weights[i]
+				if chosen < 0 || current[i] > current[chosen] {
+					chosen = i
+				}
+			}
+			if chosen < 0 {
+				return
+			}
+			current[chosen] -= total
+			item, ok := next(chosen)
+			if !ok {
+				active[chosen] = false
+				continue
+			}
+			seen[item.VideoID] = struct{}{}
+			out = append(out, item)
+			taken[chosen]++
+			if capped && taken[chosen] >= caps[chosen] {
+				active[chosen] = false
+			}
+		}
+	}
+
+	if caps != nil {
+		pass(true)
+	}
+	pass(false)
+	return out, cursors
+}
+
+var _ RecommendationProvider = (*BlendingRecommendationProvider)(nil)
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+const (
+	// SceneContinueLearning 为继续学习场景：列出用户看到一半的视频。
+	SceneContinueLearning = "continue_learning"
+
+	continueLearningSource = "continue_learning"
+	continueLearningReason = "continue.watching"
+)
+
+// ContinueLearningConfig 控制继续学习场景的观看进度区间。
+type ContinueLearningConfig struct {
+	Enabled bool
+	// MinRatio 与 MaxRatio 为入选的观看进度区间（闭区间）。
+	MinRatio float64
+	MaxRatio float64
+}
+
+// ContinueLearningProvider 完全基于本地 feed.user_video_state 生成继续学习列表，
+// 按最近观看时间倒序返回进度位于区间内的视频，游标为 (last_watched_at, video_id) 键集。
+type ContinueLearningProvider struct {
+	states *repositories.UserVideoStateRepository
+	cfg    ContinueLearningConfig
+	log    *log.Helper
+}
+
+// NewContinueLearningProvider 构造继续学习 Provider；未启用时返回 nil。
+func NewContinueLearningProvider(states *repositories.UserVideoStateRepository, cfg ContinueLearningConfig, logger log.Logger) *ContinueLearningProvider {
+	if !cfg.Enabled || states == nil {
+		return nil
+	}
+	return &ContinueLearningProvider{
+		states: states,
+		cfg:    cfg,
+		log:    log.NewHelper(logger),
+	}
+}
+
+// Source 返回推荐来源标识。
+func (p *ContinueLearningProvider) Source() string {
+	return continueLearningSource
+}
+
+// GetFeed 返回一页继续学习条目，条目元数据携带续播位置与观看进度。
+func (p *ContinueLearningProvider) GetFeed(ctx context.Context, input RecommendationInput) (*RecommendationResult, error) {
+	result := &RecommendationResult{Source: continueLearningSource}
+	if input.UserID == "" {
+		return result, nil
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	params := repositories.ListContinueWatchingParams{
+		UserID:   input.UserID,
+		MinRatio: p.cfg.MinRatio,
+		MaxRatio: p.cfg.MaxRatio,
+		// 多取一条用于判断是否还有下一页。
+		Limit: limit + 1,
+	}
+	if input.Cursor != "" {
+		cursor, err := decodeContinueCursor(input.Cursor)
+		if err != nil {
+			return nil, wrapFeedError(ErrInvalidPageToken, err)
+		}
+		params.After = cursor
+	}
+	states, err := p.states.ListContinueWatching(ctx, nil, params)
+	if err != nil {
+		p.log.WithContext(ctx).Errorw("msg", "list continue watching failed", "error", err)
+		return nil, wrapFeedError(ErrRecommendationUnavailable, err)
+	}
+	if len(states) > limit {
+		states = states[:limit]
+		result.NextCursor = encodeContinueCursor(states[limit-1])
+	}
+	result.Items = make([]RecommendationItem, 0, len(states))
+	for _, state := range states {
+		meta := map[string]string{
+			"source":        continueLearningSource,
+			"watched_ratio": strconv.FormatFloat(state.WatchedRatio, 'f', 4, 64),
+		}
+		if state.LastPositionMicros != nil {
+			meta["resume_position_micros"] = strconv.FormatInt(*state.LastPositionMicros, 10)
+		}
+		result.Items = append(result.Items, RecommendationItem{
+			VideoID:  state.VideoID,
+			Reason:   continueLearningReason,
+			Score:    state.WatchedRatio,
+			Metadata: meta,
+		})
+	}
+	return result, nil
+}
+
+// encodeContinueCursor 将 (last_watched_at, video_id) 编码为不透明游标：base64url("<unix_micro>.<video_id>")。
+func encodeContinueCursor(state *po.UserVideoState) string {
+	var micros int64
+	if state.LastWatchedAt != nil {
+		micros = state.LastWatchedAt.UnixMicro()
+	}
+	raw := strconv.FormatInt(micros, 10) + "." + state.VideoID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeContinueCursor(token string) (*repositories.ContinueWatchingCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	micros, id, ok := strings.Cut(string(raw), ".")
+	if !ok {
+		return nil, errors.New("malformed continue learning cursor")
+	}
+	ts, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	videoID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return &repositories.ContinueWatchingCursor{LastWatchedAt: time.UnixMicro(ts).UTC(), VideoID: videoID}, nil
+}
@@ -0,0 +1,558 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/models/vo"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+// 运营干预动作。
+const (
+	CurationActionPin   = "pin"
+	CurationActionBoost = "boost"
+	CurationActionBlock = "block"
+
+	// 内置用户分群：访客与登录用户，网关透传的 x-md-user-segment 作为登录用户的附加分群。
+	CurationSegmentGuest = "guest"
+	CurationSegmentUser  = "user"
+
+	// CurationPinSlotKey 标记置顶条目在本页的位次（从 1 开始），写入推荐条目 metadata 并随补水进入卡片 attributes；
+	// 观看过滤与多样性重排据此把置顶条目留在原位。
+	CurationPinSlotKey = "curation_pin_slot"
+
+	curationPinReason   = "curation.pin"
+	curationRuleMetaKey = "curation_rule_id"
+
+	defaultCurationRefreshInterval  = 5 * time.Minute
+	defaultCurationReconnectBackoff = 5 * time.Second
+	curationLoadTimeout             = 5 * time.Second
+)
+
+// CurationConfig 控制运营干预规则。
+type CurationConfig struct {
+	Enabled bool
+	// RefreshInterval 为兜底全量刷新间隔，覆盖 LISTEN 连接中断期间错过的通知。
+	RefreshInterval time.Duration
+	// ReconnectBackoff 为 LISTEN 连接断开后的重连间隔。
+	ReconnectBackoff time.Duration
+}
+
+// CurationScope 描述一次请求中与规则匹配相关的维度。
+type CurationScope struct {
+	Scene    string
+	Locale   string
+	Segments []string
+	// Reorder 为 false 时只执行屏蔽，不执行置顶与提权（翻页请求、场景 Provider 的结果）。
+	Reorder bool
+}
+
+// CurationEngine 在内存中缓存未过期的运营规则，并在推荐结果返回后执行屏蔽、提权与置顶。
+// 规则写入后经 LISTEN/NOTIFY 通知各实例全量刷新，另按 RefreshInterval 兜底刷新。
+type CurationEngine struct {
+	repo  *repositories.CurationRuleRepository
+	cfg   CurationConfig
+	rules atomic.Pointer[[]*po.CurationRule]
+	now   func() time.Time
+	log   *log.Helper
+}
+
+// NewCurationEngine 构造规则引擎并启动后台监听；未启用时返回 nil。
+// 启动时同步加载一次规则，加载失败仅告警，由后台刷新补齐；返回的 cleanup 停止监听。
+func NewCurationEngine(repo *repositories.CurationRuleRepository, cfg CurationConfig, logger log.Logger) (*CurationEngine, func()) {
+	if !cfg.Enabled || repo == nil {
+		return nil, func() {}
+	}
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = defaultCurationRefreshInterval
+	}
+	if cfg.ReconnectBackoff <= 0 {
+		cfg.ReconnectBackoff = defaultCurationReconnectBackoff
+	}
+	e := &CurationEngine{
+		repo: repo,
+		cfg:  cfg,
+		now:  time.Now,
+		log:  log.NewHelper(logger),
+	}
+	e.rules.Store(&[]*po.CurationRule{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	e.reload(ctx)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		e.listen(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		e.refreshPeriodically(ctx)
+	}()
+	return e, func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+// NewStaticCurationEngine 以固定规则构造引擎，不访问数据库，供测试与离线评估使用。
+func NewStaticCurationEngine(rules []*po.CurationRule, now func() time.Time, logger log.Logger) *CurationEngine {
+	if now == nil {
+		now = time.Now
+	}
+	e := &CurationEngine{now: now, log: log.NewHelper(logger)}
+	e.rules.Store(&rules)
+	return e
+}
+
+// Refresh 立即全量重新加载规则，管理端写入后调用以便本实例无需等待通知。
+func (e *CurationEngine) Refresh(ctx context.Context) {
+	if e == nil || e.repo == nil {
+		return
+	}
+	e.reload(ctx)
+}
+
+func (e *CurationEngine) reload(ctx context.Context) {
+	loadCtx, cancel := context.WithTimeout(ctx, curationLoadTimeout)
+	defer cancel()
+	rules, err := e.repo.List(loadCtx, nil, e.now().UTC(), false)
+	if err != nil {
+		if ctx.Err() == nil {
+			e.log.WithContext(ctx).Warnw("msg", "load curation rules failed", "error", err)
+		}
+		return
+	}
+	e.rules.Store(&rules)
+	e.log.WithContext(ctx).Debugw("msg", "curation rules loaded", "count", len(rules))
+}
+
+// listen 维持 LISTEN 连接，断开后按 ReconnectBackoff 重连；监听建立时全量刷新一次。
+func (e *CurationEngine) listen(ctx context.Context) {
+	refresh := func() { e.reload(ctx) }
+	for {
+		err := e.repo.Listen(ctx, refresh, refresh)
+		if ctx.Err() != nil {
+			return
+		}
+		e.log.Warnw("msg", "curation rule listener disconnected", "error", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(e.cfg.ReconnectBackoff):
+		}
+	}
+}
+
+func (e *CurationEngine) refreshPeriodically(ctx context.Context) {
+	ticker := time.NewTicker(e.cfg.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.reload(ctx)
+		}
+	}
+}
+
+// Apply 对推荐条目执行运营规则，规则按优先级从高到低生效：
+//   - block：从结果中剔除，且优先于同一视频的置顶与提权；
+//   - boost：视频已在本页时最多提到 position（缺省 1）位，不会插入未被推荐的视频；
+//   - pin：把视频放在第 position（缺省 1）位，未被推荐时插入；多个置顶争用同一位次时，优先级低的顺延。
+//
+// 置顶插入后整页仍截断到 limit，置顶条目的最终位次写入 metadata[CurationPinSlotKey]。
+func (e *CurationEngine) Apply(ctx context.Context, scope CurationScope, items []RecommendationItem, limit int) []RecommendationItem {
+	if e == nil {
+		return items
+	}
+	rules := e.match(scope)
+	if len(rules) == 0 {
+		return items
+	}
+
+	blocked := make(map[string]struct{})
+	for _, rule := range rules {
+		if rule.Action == CurationActionBlock {
+			blocked[rule.VideoID] = struct{}{}
+		}
+	}
+	out := make([]RecommendationItem, 0, len(items)+1)
+	for _, item := range items {
+		if _, ok := blocked[normalizeVideoID(item.VideoID)]; ok {
+			continue
+		}
+		out = append(out, item)
+	}
+	if dropped := len(items) - len(out); dropped > 0 {
+		e.log.WithContext(ctx).Debugw("msg", "curation blocked videos", "count", dropped)
+	}
+	if !scope.Reorder {
+		return out
+	}
+
+	// 提权：按优先级从低到高执行，使高优先级规则最后落位、占据更靠前的位置。
+	for i := len(rules) - 1; i >= 0; i-- {
+		rule := rules[i]
+		if rule.Action != CurationActionBoost {
+			continue
+		}
+		if _, ok := blocked[rule.VideoID]; ok {
+			continue
+		}
+		idx := indexOfVideo(out, rule.VideoID)
+		target := rulePosition(rule)
+		if idx < 0 || idx <= target {
+			continue
+		}
+		item := withCurationMeta(out[idx], rule)
+		copy(out[target+1:idx+1], out[target:idx])
+		out[target] = item
+	}
+
+	// 置顶：高优先级先占位，冲突时顺延到下一个空位。
+	pinned := make(map[int]RecommendationItem)
+	pinnedIDs := make(map[string]struct{})
+	for _, rule := range rules {
+		if rule.Action != CurationActionPin {
+			continue
+		}
+		if _, ok := blocked[rule.VideoID]; ok {
+			continue
+		}
+		if _, ok := pinnedIDs[rule.VideoID]; ok {
+			continue
+		}
+		item := RecommendationItem{VideoID: rule.VideoID, Reason: curationPinReason}
+		if idx := indexOfVideo(out, rule.VideoID); idx >= 0 {
+			item = out[idx]
+			item.Reason = curationPinReason
+		}
+		slot := rulePosition(rule)
+		for {
+			if _, taken := pinned[slot]; !taken {
+				break
+			}
+			slot++
+		}
+		pinned[slot] = withCurationMeta(item, rule)
+		pinnedIDs[rule.VideoID] = struct{}{}
+	}
+	if len(pinned) == 0 {
+		return out
+	}
+	rest := make([]RecommendationItem, 0, len(out))
+	for _, item := range out {
+		if _, ok := pinnedIDs[normalizeVideoID(item.VideoID)]; !ok {
+			rest = append(rest, item)
+		}
+	}
+	total := len(rest) + len(pinned)
+	merged := make([]RecommendationItem, 0, total)
+	for slot := 0; slot < total; slot++ {
+		if item, ok := pinned[slot]; ok {
+			merged = append(merged, item)
+			delete(pinned, slot)
+			continue
+		}
+		if len(rest) == 0 {
+			break
+		}
+		merged = append(merged, rest[0])
+		rest = rest[1:]
+	}
+	// 置顶位次超出现有条目数时按位次顺序追加到末尾。
+	slots := slices.Sorted(maps.Keys(pinned))
+	for _, slot := range slots {
+		merged = append(merged, pinned[slot])
+	}
+	if limit > 0 && len(merged) > limit {
+		merged = merged[:limit]
+	}
+	for i, item := range merged {
+		if _, ok := pinnedIDs[normalizeVideoID(item.VideoID)]; ok {
+			merged[i].Metadata[CurationPinSlotKey] = strconv.Itoa(i + 1)
+		}
+	}
+	return merged
+}
+
+// holdPinned 取出带 CurationPinSlotKey 的置顶条目，对其余条目执行 reorder，再把置顶条目按位次放回；
+// 位次超出页长时追加到末尾。没有置顶条目时等价于直接执行 reorder。
+func holdPinned(items []vo.FeedItem, reorder func([]vo.FeedItem) []vo.FeedItem) []vo.FeedItem {
+	type pinnedItem struct {
+		slot int
+		item vo.FeedItem
+	}
+	var pinned []pinnedItem
+	rest := make([]vo.FeedItem, 0, len(items))
+	for _, item := range items {
+		if slot, err := strconv.Atoi(item.Attributes[CurationPinSlotKey]); err == nil && slot > 0 {
+			pinned = append(pinned, pinnedItem{slot: slot, item: item})
+			continue
+		}
+		rest = append(rest, item)
+	}
+	if len(pinned) == 0 {
+		return reorder(items)
+	}
+	out := reorder(rest)
+	slices.SortStableFunc(pinned, func(a, b pinnedItem) int { return a.slot - b.slot })
+	for _, p := range pinned {
+		out = slices.Insert(out, min(p.slot-1, len(out)), p.item)
+	}
+	return out
+}
+
+// match 返回当前生效且范围匹配的规则，保持加载时的优先级顺序。
+func (e *CurationEngine) match(scope CurationScope) []*po.CurationRule {
+	all := *e.rules.Load()
+	if len(all) == 0 {
+		return nil
+	}
+	now := e.now()
+	var matched []*po.CurationRule
+	for _, rule := range all {
+		if rule.StartsAt != nil && now.Before(*rule.StartsAt) {
+			continue
+		}
+		if rule.EndsAt != nil && !now.Before(*rule.EndsAt) {
+			continue
+		}
+		if !sceneMatches(rule.Scene, scope.Scene) || !localeMatches(rule.Locale, scope.Locale) || !segmentMatches(rule.UserSegment, scope.Segments) {
+			continue
+		}
+		matched = append(matched, rule)
+	}
+	return matched
+}
+
+// curationOtherScene 代表不被任何规则区分的场景，用作缓存键的占位值。
+const curationOtherScene = "*"
+
+// CacheScope 把请求的场景与语言区域映射到规则中出现过的取值，供按范围共享的缓存作为键：
+// 不被任何规则点名的场景归为同一占位值（首页与空场景视为同一场景），
+// 语言区域取能匹配的最长规则语言，无匹配时为空。映射结果相同的两个请求命中的规则集合相同，
+// 取值只来自已加载的规则，调用方传入任意字符串都不会扩大键空间。
+func (e *CurationEngine) CacheScope(scene, locale string) (string, string) {
+	if scene == "" {
+		scene = SceneHome
+	}
+	if e == nil {
+		return curationOtherScene, ""
+	}
+	canonicalScene, canonicalLocale := curationOtherScene, ""
+	for _, rule := range *e.rules.Load() {
+		if rule.Scene != nil && sceneMatches(rule.Scene, scene) {
+			canonicalScene = scene
+		}
+		if rule.Locale != nil && localeMatches(rule.Locale, locale) && len(*rule.Locale) > len(canonicalLocale) {
+			canonicalLocale = strings.ToLower(*rule.Locale)
+		}
+	}
+	return canonicalScene, canonicalLocale
+}
+
+func sceneMatches(ruleScene *string, scene string) bool {
+	if ruleScene == nil {
+		return true
+	}
+	if *ruleScene == scene {
+		return true
+	}
+	// 空场景即首页。
+	return (*ruleScene == SceneHome && scene == "") || (*ruleScene == "" && scene == SceneHome)
+}
+
+// localeMatches 按 BCP 47 前缀匹配：规则 ja 匹配请求 ja、ja-JP，不区分大小写。
+func localeMatches(ruleLocale *string, locale string) bool {
+	if ruleLocale == nil {
+		return true
+	}
+	want := strings.ToLower(*ruleLocale)
+	got := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	return got == want || strings.HasPrefix(got, want+"-")
+}
+
+func segmentMatches(ruleSegment *string, segments []string) bool {
+	if ruleSegment == nil {
+		return true
+	}
+	for _, segment := range segments {
+		if strings.EqualFold(segment, *ruleSegment) {
+			return true
+		}
+	}
+	return false
+}
+
+// rulePosition 返回规则目标位次的 0 基下标。
+func rulePosition(rule *po.CurationRule) int {
+	if rule.Position == nil || *rule.Position < 1 {
+		return 0
+	}
+	return int(*rule.Position) - 1
+}
+
+func indexOfVideo(items []RecommendationItem, videoID string) int {
+	for i, item := range items {
+		if normalizeVideoID(item.VideoID) == videoID {
+			return i
+		}
+	}
+	return -1
+}
+
+// normalizeVideoID 统一为小写 UUID 文本，与规则中的 video_id 比较；无法解析时原样返回。
+func normalizeVideoID(videoID string) string {
+	if id, err := uuid.Parse(videoID); err == nil {
+		return id.String()
+	}
+	return videoID
+}
+
+func withCurationMeta(item RecommendationItem, rule *po.CurationRule) RecommendationItem {
+	meta := make(map[string]string, len(item.Metadata)+1)
+	maps.Copy(meta, item.Metadata)
+	meta[curationRuleMetaKey] = rule.RuleID
+	item.Metadata = meta
+	return item
+}
+
+// CurationRuleInput 描述管理端提交的规则内容，范围字段为空表示不限。
+type CurationRuleInput struct {
+	Action      string
+	VideoID     string
+	Scene       string
+	Locale      string
+	UserSegment string
+	Position    int32
+	Priority    int32
+	StartsAt    *time.Time
+	EndsAt      *time.Time
+	Note        string
+}
+
+// CurationRuleAdmin 为管理端提供规则的增删改查，写入后立即刷新本实例缓存，其余实例经 NOTIFY 刷新。
+type CurationRuleAdmin struct {
+	repo   *repositories.CurationRuleRepository
+	engine *CurationEngine
+}
+
+// NewCurationRuleAdmin 构造 CurationRuleAdmin；engine 为空（规则未启用）时仍可维护规则。
+func NewCurationRuleAdmin(repo *repositories.CurationRuleRepository, engine *CurationEngine) *CurationRuleAdmin {
+	return &CurationRuleAdmin{repo: repo, engine: engine}
+}
+
+// Create 校验并写入一条规则。
+func (a *CurationRuleAdmin) Create(ctx context.Context, input CurationRuleInput) (*po.CurationRule, error) {
+	repoInput, err := toCurationRuleRepoInput(input)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := a.repo.Create(ctx, nil, repoInput)
+	if err != nil {
+		return nil, err
+	}
+	a.engine.Refresh(ctx)
+	return rule, nil
+}
+
+// Update 校验并整体覆盖一条规则。
+func (a *CurationRuleAdmin) Update(ctx context.Context, ruleID string, input CurationRuleInput) (*po.CurationRule, error) {
+	id, err := uuid.Parse(strings.TrimSpace(ruleID))
+	if err != nil {
+		return nil, wrapFeedError(ErrInvalidCurationRule, err)
+	}
+	repoInput, err := toCurationRuleRepoInput(input)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := a.repo.Update(ctx, nil, id, repoInput)
+	if err != nil {
+		return nil, curationStoreError(err)
+	}
+	a.engine.Refresh(ctx)
+	return rule, nil
+}
+
+// Delete 删除一条规则。
+func (a *CurationRuleAdmin) Delete(ctx context.Context, ruleID string) error {
+	id, err := uuid.Parse(strings.TrimSpace(ruleID))
+	if err != nil {
+		return wrapFeedError(ErrInvalidCurationRule, err)
+	}
+	if err := a.repo.Delete(ctx, nil, id); err != nil {
+		return curationStoreError(err)
+	}
+	a.engine.Refresh(ctx)
+	return nil
+}
+
+// List 按优先级降序返回规则，includeExpired 为 false 时不含已过期规则。
+func (a *CurationRuleAdmin) List(ctx context.Context, includeExpired bool) ([]*po.CurationRule, error) {
+	return a.repo.List(ctx, nil, time.Now().UTC(), includeExpired)
+}
+
+func curationStoreError(err error) error {
+	if errors.Is(err, repositories.ErrCurationRuleNotFound) {
+		return ErrCurationRuleNotFound
+	}
+	return err
+}
+
+func toCurationRuleRepoInput(input CurationRuleInput) (repositories.CurationRuleInput, error) {
+	action := strings.ToLower(strings.TrimSpace(input.Action))
+	switch action {
+	case CurationActionPin, CurationActionBoost, CurationActionBlock:
+	default:
+		return repositories.CurationRuleInput{}, wrapFeedError(ErrInvalidCurationRule, fmt.Errorf("unsupported action %q", input.Action))
+	}
+	videoID, err := uuid.Parse(strings.TrimSpace(input.VideoID))
+	if err != nil {
+		return repositories.CurationRuleInput{}, wrapFeedError(ErrInvalidCurationRule, err)
+	}
+	if input.Position < 0 || (action == CurationActionBlock && input.Position > 0) {
+		return repositories.CurationRuleInput{}, wrapFeedError(ErrInvalidCurationRule, errors.New("position must be positive and only applies to pin/boost"))
+	}
+	if input.StartsAt != nil && input.EndsAt != nil && !input.StartsAt.Before(*input.EndsAt) {
+		return repositories.CurationRuleInput{}, wrapFeedError(ErrInvalidCurationRule, errors.New("starts_at must be before ends_at"))
+	}
+	out := repositories.CurationRuleInput{
+		Action:      action,
+		VideoID:     videoID,
+		Scene:       optionalTrimmed(input.Scene),
+		Locale:      optionalTrimmed(input.Locale),
+		UserSegment: optionalTrimmed(input.UserSegment),
+		Priority:    input.Priority,
+		StartsAt:    input.StartsAt,
+		EndsAt:      input.EndsAt,
+		Note:        optionalTrimmed(input.Note),
+	}
+	if input.Position > 0 {
+		position := input.Position
+		out.Position = &position
+	}
+	return out, nil
+}
+
+func optionalTrimmed(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
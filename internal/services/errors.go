@@ -0,0 +1,160 @@
+package services
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrorKind 枚举 Feed 领域错误类别，取值即 Problem Details type 的后缀，需保持稳定。
+type ErrorKind string
+
+const (
+	// ErrorKindRecommendationUnavailable 推荐系统不可用或超时。
+	ErrorKindRecommendationUnavailable ErrorKind = "recommendation_unavailable"
+	// ErrorKindProjectionUnavailable 投影存储读取失败，无法补水。
+	ErrorKindProjectionUnavailable ErrorKind = "projection_unavailable"
+	// ErrorKindIdempotencyConflict 同一幂等键被用于参数不同的请求。
+	ErrorKindIdempotencyConflict ErrorKind = "idempotency_conflict"
+	// ErrorKindRateLimited 请求超出限流配额。
+	ErrorKindRateLimited ErrorKind = "rate_limited"
+	// ErrorKindInvalidArgument 请求参数不合法（如翻页游标损坏）。
+	ErrorKindInvalidArgument ErrorKind = "invalid_argument"
+	// ErrorKindNotFound 请求的资源不存在。
+	ErrorKindNotFound ErrorKind = "not_found"
+	// ErrorKindPseudonymizationDisabled 未配置用户标识假名化密钥，无法按 user_id 查询。
+	ErrorKindPseudonymizationDisabled ErrorKind = "pseudonymization_disabled"
+	// ErrorKindFeatureDisabled 请求的能力未在配置中启用。
+	ErrorKindFeatureDisabled ErrorKind = "feature_disabled"
+	// ErrorKindEventStoreUnavailable 事件校验或 Outbox 写入失败。
+	ErrorKindEventStoreUnavailable ErrorKind = "event_store_unavailable"
+)
+
+// problemTypePrefix 为对外 Problem type 的统一前缀。
+const problemTypePrefix = "feed.errors."
+
+// FeedError 是 Feed 用例层对外暴露的类型化错误。
+//
+// Message 为可直接返回给调用方的描述，不包含底层细节；Err 保存原始原因，仅用于日志。
+type FeedError struct {
+	Kind       ErrorKind
+	Message    string
+	RetryAfter time.Duration
+	Err        error
+}
+
+// Error 实现 error 接口。
+func (e *FeedError) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap 返回底层原因。
+func (e *FeedError) Unwrap() error {
+	return e.Err
+}
+
+// Is 按错误类别比较，使包装后的错误仍可用 errors.Is 匹配哨兵错误。
+func (e *FeedError) Is(target error) bool {
+	var other *FeedError
+	if !errors.As(target, &other) {
+		return false
+	}
+	return e.Kind == other.Kind
+}
+
+// Reason 返回稳定的 Problem type，例如 feed.errors.recommendation_unavailable。
+func (e *FeedError) Reason() string {
+	return problemTypePrefix + string(e.Kind)
+}
+
+// Retryable 表示调用方是否可以在 RetryAfter 后重试。
+func (e *FeedError) Retryable() bool {
+	return e.RetryAfter > 0
+}
+
+var (
+	// ErrRecommendationUnavailable 表示推荐不可用。
+	ErrRecommendationUnavailable = &FeedError{
+		Kind:       ErrorKindRecommendationUnavailable,
+		Message:    "recommendation unavailable",
+		RetryAfter: time.Second,
+	}
+	// ErrProjectionUnavailable 表示投影存储不可用。
+	ErrProjectionUnavailable = &FeedError{
+		Kind:       ErrorKindProjectionUnavailable,
+		Message:    "projection unavailable",
+		RetryAfter: time.Second,
+	}
+	// ErrIdempotencyKeyMismatch 表示同一幂等键被用于参数不同的请求。
+	ErrIdempotencyKeyMismatch = &FeedError{
+		Kind:    ErrorKindIdempotencyConflict,
+		Message: "idempotency key reused with different request",
+	}
+	// ErrGuestRateLimited 表示单个访客或访客总流量超出配额。
+	ErrGuestRateLimited = &FeedError{
+		Kind:       ErrorKindRateLimited,
+		Message:    "guest rate limit exceeded",
+		RetryAfter: time.Second,
+	}
+	// ErrUserRateLimited 表示单个用户超出按方法/场景配置的配额。
+	ErrUserRateLimited = &FeedError{
+		Kind:       ErrorKindRateLimited,
+		Message:    "rate limit exceeded",
+		RetryAfter: time.Second,
+	}
+	// ErrInvalidPageToken 表示翻页游标无法解析。
+	ErrInvalidPageToken = &FeedError{
+		Kind:    ErrorKindInvalidArgument,
+		Message: "invalid page token",
+	}
+	// ErrInvalidLogQuery 表示推荐日志查询条件不合法。
+	ErrInvalidLogQuery = &FeedError{
+		Kind:    ErrorKindInvalidArgument,
+		Message: "invalid recommendation log query",
+	}
+	// ErrRecommendationLogNotFound 表示推荐日志不存在或已过期。
+	ErrRecommendationLogNotFound = &FeedError{
+		Kind:    ErrorKindNotFound,
+		Message: "recommendation log not found",
+	}
+	// ErrUserHashingDisabled 表示未配置假名化密钥，无法按 user_id 反查日志。
+	ErrUserHashingDisabled = &FeedError{
+		Kind:    ErrorKindPseudonymizationDisabled,
+		Message: "user id hashing is not configured",
+	}
+	// ErrInteractionsDisabled 表示未启用交互事件上报。
+	ErrInteractionsDisabled = &FeedError{
+		Kind:    ErrorKindFeatureDisabled,
+		Message: "interaction reporting is disabled",
+	}
+	// ErrInvalidInteractionBatch 表示上报批次为空或超过上限。
+	ErrInvalidInteractionBatch = &FeedError{
+		Kind:    ErrorKindInvalidArgument,
+		Message: "invalid interaction batch",
+	}
+	// ErrInvalidCurationRule 表示运营规则内容不合法。
+	ErrInvalidCurationRule = &FeedError{
+		Kind:    ErrorKindInvalidArgument,
+		Message: "invalid curation rule",
+	}
+	// ErrCurationRuleNotFound 表示运营规则不存在。
+	ErrCurationRuleNotFound = &FeedError{
+		Kind:    ErrorKindNotFound,
+		Message: "curation rule not found",
+	}
+	// ErrInteractionStoreUnavailable 表示推荐日志读取或 Outbox 写入失败，整批可重试。
+	ErrInteractionStoreUnavailable = &FeedError{
+		Kind:       ErrorKindEventStoreUnavailable,
+		Message:    "interaction store unavailable",
+		RetryAfter: time.Second,
+	}
+)
+
+// wrapFeedError 以哨兵错误为模板附加底层原因。
+func wrapFeedError(sentinel *FeedError, cause error) *FeedError {
+	wrapped := *sentinel
+	wrapped.Err = cause
+	return &wrapped
+}
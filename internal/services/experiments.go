@@ -0,0 +1,268 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/go-kratos/kratos/v2/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// 实验分组写入卡片 attributes 与 Trace 属性时的键前缀。
+const (
+	experimentAttributePrefix = "exp."
+	experimentSpanPrefix      = "feed.experiment."
+)
+
+// ExperimentConfig 控制 A/B 实验分桶与分组覆盖。
+type ExperimentConfig struct {
+	Enabled bool
+	// AllowForcedVariants 为 true 时接受请求携带的强制分组（x-md-experiment-variants），仅供 QA 环境开启。
+	AllowForcedVariants bool
+	Experiments         []ExperimentDefinition
+}
+
+// ExperimentDefinition 描述一个实验：按 Salt 与 user_id 哈希，在各分组权重之和内确定性分桶。
+type ExperimentDefinition struct {
+	Key string
+	// Salt 为分桶哈希盐，空值取 Key；修改后用户重新分桶。
+	Salt     string
+	Variants []ExperimentVariantConfig
+}
+
+// ExperimentVariantConfig 为实验分组的流量权重与覆盖项，未设置的覆盖项沿用全局配置。
+type ExperimentVariantConfig struct {
+	Name string
+	// Weight 为流量权重，0 表示不参与分桶，只能被强制命中。
+	Weight uint32
+	// Provider 覆盖主推荐源，取值为 BlendingSources 中登记的来源名。
+	Provider string
+	// BlendSources 非空时以这组来源混排作为主推荐，优先于 Provider；策略沿用全局混排配置。
+	BlendSources []BlendingSourceConfig
+	// Rerank 覆盖多样性重排配置，所有阶段均关闭表示该分组不做重排。
+	Rerank *RerankConfig
+}
+
+// ExperimentAssignment 为一次请求在单个实验中命中的分组。
+type ExperimentAssignment struct {
+	Experiment string
+	Variant    string
+	// Forced 为 true 表示经强制头命中。
+	Forced bool
+}
+
+// ExperimentDecision 汇总一次请求的实验分组及其覆盖项，零值表示未参与任何实验。
+type ExperimentDecision struct {
+	Assignments []ExperimentAssignment
+	provider    RecommendationProvider
+	reranker    *RerankPipeline
+	rerankSet   bool
+}
+
+// Provider 返回分组覆盖的推荐链，nil 表示沿用默认推荐链。
+func (d ExperimentDecision) Provider() RecommendationProvider {
+	return d.provider
+}
+
+// Variants 返回 experiment → variant 映射，未参与实验时返回 nil。
+func (d ExperimentDecision) Variants() map[string]string {
+	if len(d.Assignments) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(d.Assignments))
+	for _, a := range d.Assignments {
+		out[a.Experiment] = a.Variant
+	}
+	return out
+}
+
+// Attributes 返回写入卡片 attributes 的实验标签，键为 exp.<experiment>。
+func (d ExperimentDecision) Attributes() map[string]string {
+	if len(d.Assignments) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(d.Assignments))
+	for _, a := range d.Assignments {
+		out[experimentAttributePrefix+a.Experiment] = a.Variant
+	}
+	return out
+}
+
+func (d ExperimentDecision) logEntries() []po.ExperimentAssignmentLog {
+	if len(d.Assignments) == 0 {
+		return nil
+	}
+	out := make([]po.ExperimentAssignmentLog, 0, len(d.Assignments))
+	for _, a := range d.Assignments {
+		out = append(out, po.ExperimentAssignmentLog{Experiment: a.Experiment, Variant: a.Variant, Forced: a.Forced})
+	}
+	return out
+}
+
+type experimentVariant struct {
+	name   string
+	weight uint64
+	// provider 与 reranker 为启动时按覆盖项预先组装的实例，nil 表示沿用默认。
+	provider  RecommendationProvider
+	reranker  *RerankPipeline
+	rerankSet bool
+}
+
+type experiment struct {
+	key      string
+	salt     string
+	variants []experimentVariant
+	total    uint64
+}
+
+// ExperimentAssigner 按 user_id 为请求分配实验分组，并给出分组对主推荐链与重排的覆盖。
+type ExperimentAssigner struct {
+	experiments []experiment
+	allowForced bool
+	log         *log.Helper
+}
+
+// NewExperimentAssigner 校验实验配置并预先组装各分组的推荐链与重排流水线；未启用或没有实验时返回 nil。
+// 分组的主推荐经 chain 与默认链路同样组装：失败时回退到本地链路，外包影子流量与首页复习混排。
+func NewExperimentAssigner(cfg ExperimentConfig, sources BlendingSources, blending BlendingConfig, chain *RecommendationChain, logger log.Logger) (*ExperimentAssigner, error) {
+	if !cfg.Enabled || len(cfg.Experiments) == 0 {
+		return nil, nil
+	}
+	a := &ExperimentAssigner{
+		allowForced: cfg.AllowForcedVariants,
+		log:         log.NewHelper(logger),
+	}
+	seen := make(map[string]struct{}, len(cfg.Experiments))
+	for _, def := range cfg.Experiments {
+		key := strings.TrimSpace(def.Key)
+		if key == "" {
+			return nil, fmt.Errorf("experiments: empty experiment key")
+		}
+		if _, dup := seen[key]; dup {
+			return nil, fmt.Errorf("experiments: duplicate experiment %q", key)
+		}
+		seen[key] = struct{}{}
+		exp := experiment{key: key, salt: firstNonEmpty(def.Salt, key)}
+		names := make(map[string]struct{}, len(def.Variants))
+		for _, vc := range def.Variants {
+			name := strings.TrimSpace(vc.Name)
+			if name == "" {
+				return nil, fmt.Errorf("experiments: %s: empty variant name", key)
+			}
+			if _, dup := names[name]; dup {
+				return nil, fmt.Errorf("experiments: %s: duplicate variant %q", key, name)
+			}
+			names[name] = struct{}{}
+			variant := experimentVariant{name: name, weight: uint64(vc.Weight)}
+			var primary RecommendationProvider
+			switch {
+			case len(vc.BlendSources) > 0:
+				blend, err := NewBlendingRecommendationProvider(sources, BlendingConfig{
+					Enabled:  true,
+					Strategy: blending.Strategy,
+					Sources:  vc.BlendSources,
+				}, logger)
+				if err != nil {
+					return nil, fmt.Errorf("experiments: %s/%s: %w", key, name, err)
+				}
+				primary = blend
+			case vc.Provider != "":
+				provider, ok := sources[vc.Provider]
+				if !ok || provider == nil {
+					return nil, fmt.Errorf("experiments: %s/%s: unavailable provider %q", key, name, vc.Provider)
+				}
+				primary = provider
+			}
+			if primary != nil {
+				variant.provider = chain.Build(primary)
+			}
+			if vc.Rerank != nil {
+				variant.reranker = NewRerankPipeline(*vc.Rerank, logger)
+				variant.rerankSet = true
+			}
+			exp.variants = append(exp.variants, variant)
+			exp.total += variant.weight
+		}
+		if exp.total == 0 {
+			return nil, fmt.Errorf("experiments: %s: total variant weight must be positive", key)
+		}
+		a.experiments = append(a.experiments, exp)
+	}
+	return a, nil
+}
+
+// Assign 为登录用户分配各实验的分组：forced 中指定且允许强制时直接命中，否则按哈希分桶。
+// 多个实验的覆盖项按配置顺序合并，后者覆盖前者。分组同时写入当前 span 的属性。
+func (a *ExperimentAssigner) Assign(ctx context.Context, userID string, forced map[string]string) ExperimentDecision {
+	var decision ExperimentDecision
+	if a == nil || userID == "" {
+		return decision
+	}
+	if len(forced) > 0 && !a.allowForced {
+		a.log.WithContext(ctx).Debugw("msg", "experiments: forced variants ignored", "count", len(forced))
+		forced = nil
+	}
+	span := trace.SpanFromContext(ctx)
+	for i := range a.experiments {
+		exp := &a.experiments[i]
+		variant, isForced := exp.forcedVariant(forced[exp.key])
+		if variant == nil {
+			if name := forced[exp.key]; name != "" {
+				a.log.WithContext(ctx).Warnw("msg", "experiments: unknown forced variant", "experiment", exp.key, "variant", name)
+			}
+			variant = exp.bucket(userID)
+		}
+		decision.Assignments = append(decision.Assignments, ExperimentAssignment{
+			Experiment: exp.key,
+			Variant:    variant.name,
+			Forced:     isForced,
+		})
+		if variant.provider != nil {
+			decision.provider = variant.provider
+		}
+		if variant.rerankSet {
+			decision.reranker = variant.reranker
+			decision.rerankSet = true
+		}
+		span.SetAttributes(attribute.String(experimentSpanPrefix+exp.key, variant.name))
+	}
+	return decision
+}
+
+func (e *experiment) forcedVariant(name string) (*experimentVariant, bool) {
+	if name == "" {
+		return nil, false
+	}
+	for i := range e.variants {
+		if e.variants[i].name == name {
+			return &e.variants[i], true
+		}
+	}
+	return nil, false
+}
+
+// bucket 以 sha256(salt:user_id) 的前 8 字节对总权重取模，落入累计权重区间对应的分组。
+func (e *experiment) bucket(userID string) *experimentVariant {
+	point := ExperimentBucket(e.salt, userID, e.total)
+	for i := range e.variants {
+		if point < e.variants[i].weight {
+			return &e.variants[i]
+		}
+		point -= e.variants[i].weight
+	}
+	return &e.variants[len(e.variants)-1]
+}
+
+// ExperimentBucket 返回 user_id 在 [0, total) 内的确定性桶号，同一 salt 与 user_id 总是得到相同结果。
+func ExperimentBucket(salt, userID string, total uint64) uint64 {
+	if total == 0 {
+		return 0
+	}
+	sum := sha256.Sum256([]byte(salt + ":" + userID))
+	return binary.BigEndian.Uint64(sum[:8]) % total
+}
@@ -0,0 +1,469 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	eventsv1 "github.com/bionicotaku/lingo-services-feed/api/feed/events/v1"
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/pseudonym"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// interactionAggregateType 为无稳定用户标识时 Feed 事件的聚合根类型，聚合根 ID 为推荐日志 ID，见 eventAggregate。
+const interactionAggregateType = "recommendation_log"
+
+// InteractionType 为客户端上报的交互类型。
+type InteractionType string
+
+const (
+	// InteractionImpression 表示卡片进入可视区域。
+	InteractionImpression InteractionType = "impression"
+	// InteractionClick 表示点击卡片。
+	InteractionClick InteractionType = "click"
+	// InteractionRefresh 表示用户主动刷新整页，不关联具体视频。
+	InteractionRefresh InteractionType = "refresh"
+)
+
+// InteractionStatus 为单条事件的处理结果。
+type InteractionStatus string
+
+const (
+	// InteractionAccepted 表示事件已写入 Outbox。
+	InteractionAccepted InteractionStatus = "accepted"
+	// InteractionDuplicate 表示 event_id 已写入过，客户端可视为成功。
+	InteractionDuplicate InteractionStatus = "duplicate"
+	// InteractionRejected 表示事件未通过校验，Reason 给出原因。
+	InteractionRejected InteractionStatus = "rejected"
+)
+
+// 事件被拒绝的原因，取值对外稳定。log_not_found 表示 log_id 不对应任何下发页（或已超出保留期）。
+const (
+	RejectReasonLogNotFound          = "log_not_found"
+	RejectReasonLogOwnerMismatch     = "log_owner_mismatch"
+	RejectReasonVideoIDRequired      = "video_id_required"
+	RejectReasonVideoNotServed       = "video_not_served"
+	RejectReasonPositionMismatch     = "position_mismatch"
+	RejectReasonOccurredAtOutOfRange = "occurred_at_out_of_range"
+	RejectReasonDuplicateInBatch     = "duplicate_in_batch"
+	RejectReasonUnsupportedType      = "unsupported_type"
+	RejectReasonInvalidEventID       = "invalid_event_id"
+	RejectReasonEventIDReserved      = "event_id_reserved"
+	RejectReasonInvalidLogID         = "invalid_log_id"
+	RejectReasonInvalidVideoID       = "invalid_video_id"
+	RejectReasonUnexpectedVideoID    = "video_id_unexpected"
+	RejectReasonOccurredAtMissing    = "occurred_at_required"
+	RejectReasonNegativePosition     = "invalid_position"
+)
+
+// InteractionInput 描述一条客户端上报的交互事件。
+type InteractionInput struct {
+	EventID string
+	Type    InteractionType
+	LogID   string
+	VideoID string
+	// Position 为客户端观察到的位次（从 1 开始），0 表示未提供。
+	Position   int
+	OccurredAt time.Time
+}
+
+// ReportInteractionsInput 为一次批量上报，身份信息由控制层从元数据解析。
+type ReportInteractionsInput struct {
+	UserID string
+	Guest  bool
+	Events []InteractionInput
+}
+
+// InteractionResult 为单条事件的处理结果，顺序与输入一致。
+type InteractionResult struct {
+	EventID string
+	Status  InteractionStatus
+	Reason  string
+}
+
+// ReportInteractionsResult 汇总批量上报的处理结果。
+type ReportInteractionsResult struct {
+	Results  []InteractionResult
+	Accepted int
+}
+
+// InteractionConfig 控制交互事件的校验窗口。
+type InteractionConfig struct {
+	Enabled      bool
+	MaxBatchSize int
+	// MaxEventAge 为 occurred_at 距接收时刻的最大时长。
+	MaxEventAge time.Duration
+	// ClockSkew 为允许的客户端时钟偏差，同时放宽 generated_at 下界与当前时刻上界。
+	ClockSkew time.Duration
+}
+
+// InteractionRecorder 依据下发页记录校验交互事件，并在同一事务内写入 Outbox 等待发布。
+//
+// 下发页记录（feed.served_pages）由 GetFeed 在请求路径内同步写入且不采样；推荐日志采样、异步且可能丢弃，
+// 仅在下发页记录缺失时（例如上线前签发的 log_id）作为兼容回查。
+type InteractionRecorder struct {
+	served *repositories.ServedPageRepository
+	logs   *repositories.FeedRecommendationLogRepository
+	outbox *repositories.OutboxRepository
+	tx     txmanager.Manager
+	hasher *pseudonym.Hasher
+	cfg    InteractionConfig
+	now    func() time.Time
+	log    *log.Helper
+}
+
+// NewInteractionRecorder 构造 InteractionRecorder。
+func NewInteractionRecorder(served *repositories.ServedPageRepository, logs *repositories.FeedRecommendationLogRepository, outbox *repositories.OutboxRepository, tx txmanager.Manager, hasher *pseudonym.Hasher, cfg InteractionConfig, logger log.Logger) *InteractionRecorder {
+	return &InteractionRecorder{
+		served: served,
+		logs:   logs,
+		outbox: outbox,
+		tx:     tx,
+		hasher: hasher,
+		cfg:    cfg,
+		now:    time.Now,
+		log:    log.NewHelper(logger),
+	}
+}
+
+// WithClock 替换时间源，供测试固定校验窗口。
+func (r *InteractionRecorder) WithClock(fn func() time.Time) {
+	if r != nil && fn != nil {
+		r.now = fn
+	}
+}
+
+// Enabled 表示交互上报可用，此时 GetFeed 需为每个下发页写入记录。
+func (r *InteractionRecorder) Enabled() bool {
+	return r != nil && r.cfg.Enabled && r.served != nil && r.outbox != nil && r.tx != nil
+}
+
+// pendingInteraction 为通过校验、等待写入 Outbox 的事件。
+type pendingInteraction struct {
+	index   int
+	eventID uuid.UUID
+	input   InteractionInput
+	page    *po.ServedPage
+	served  *po.ServedItemLog
+}
+
+// Record 校验并写入一批交互事件。单条事件的校验失败体现在结果中，不影响同批其他事件；
+// 仅当整批不合法或存储失败时返回错误。
+func (r *InteractionRecorder) Record(ctx context.Context, input ReportInteractionsInput) (*ReportInteractionsResult, error) {
+	if !r.Enabled() {
+		return nil, ErrInteractionsDisabled
+	}
+	if len(input.Events) == 0 {
+		return nil, ErrInvalidInteractionBatch
+	}
+	if r.cfg.MaxBatchSize > 0 && len(input.Events) > r.cfg.MaxBatchSize {
+		return nil, wrapFeedError(ErrInvalidInteractionBatch, fmt.Errorf("batch size %d exceeds %d", len(input.Events), r.cfg.MaxBatchSize))
+	}
+
+	receivedAt := r.now().UTC()
+	results := make([]InteractionResult, len(input.Events))
+	eventIDs := make([]uuid.UUID, len(input.Events))
+	logIDs := make([]uuid.UUID, 0, len(input.Events))
+	seenEvents := make(map[uuid.UUID]struct{}, len(input.Events))
+	seenLogs := make(map[uuid.UUID]struct{})
+	for i, evt := range input.Events {
+		results[i] = InteractionResult{EventID: evt.EventID, Status: InteractionRejected}
+		eventID, err := uuid.Parse(strings.TrimSpace(evt.EventID))
+		if err != nil {
+			results[i].Reason = RejectReasonInvalidEventID
+			continue
+		}
+		if _, dup := seenEvents[eventID]; dup {
+			results[i].Reason = RejectReasonDuplicateInBatch
+			continue
+		}
+		seenEvents[eventID] = struct{}{}
+		eventIDs[i] = eventID
+		logID, err := uuid.Parse(strings.TrimSpace(evt.LogID))
+		if err != nil {
+			results[i].Reason = RejectReasonInvalidLogID
+			continue
+		}
+		if _, ok := seenLogs[logID]; !ok {
+			seenLogs[logID] = struct{}{}
+			logIDs = append(logIDs, logID)
+		}
+	}
+	for i := range input.Events {
+		if results[i].Reason == "" && reservedEventID(eventIDs[i], seenLogs) {
+			results[i].Reason = RejectReasonEventIDReserved
+		}
+	}
+
+	byID, err := r.lookupServed(ctx, logIDs)
+	if err != nil {
+		return nil, wrapFeedError(ErrInteractionStoreUnavailable, err)
+	}
+
+	pending := make([]pendingInteraction, 0, len(input.Events))
+	for i, evt := range input.Events {
+		if results[i].Reason != "" {
+			continue
+		}
+		logID := uuid.MustParse(strings.TrimSpace(evt.LogID)).String()
+		page, ok := byID[logID]
+		if !ok {
+			results[i].Reason = RejectReasonLogNotFound
+			continue
+		}
+		served, reason := r.validate(input, evt, page, receivedAt)
+		if reason != "" {
+			results[i].Reason = reason
+			continue
+		}
+		pending = append(pending, pendingInteraction{index: i, eventID: eventIDs[i], input: evt, page: page, served: served})
+	}
+
+	accepted := 0
+	if len(pending) > 0 {
+		candidateIDs := make([]uuid.UUID, 0, len(pending))
+		for _, p := range pending {
+			candidateIDs = append(candidateIDs, p.eventID)
+		}
+		err = r.tx.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+			accepted = 0
+			existing, err := r.outbox.ExistingEventIDs(txCtx, sess, candidateIDs)
+			if err != nil {
+				return err
+			}
+			for _, p := range pending {
+				if _, dup := existing[p.eventID]; dup {
+					results[p.index].Status = InteractionDuplicate
+					continue
+				}
+				msg, err := buildInteractionMessage(p, receivedAt)
+				if err != nil {
+					return err
+				}
+				if err := r.outbox.Enqueue(txCtx, sess, msg); err != nil {
+					return err
+				}
+				results[p.index].Status = InteractionAccepted
+				accepted++
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, wrapFeedError(ErrInteractionStoreUnavailable, err)
+		}
+	}
+
+	rejected := len(input.Events) - accepted
+	if rejected > 0 {
+		r.log.WithContext(ctx).Debugw("msg", "interactions partially rejected", "accepted", accepted, "rejected", rejected)
+	}
+	return &ReportInteractionsResult{Results: results, Accepted: accepted}, nil
+}
+
+// lookupServed 按 log_id 查询下发页记录，缺失的 ID 回查推荐日志以兼容上线前签发的 log_id。
+func (r *InteractionRecorder) lookupServed(ctx context.Context, logIDs []uuid.UUID) (map[string]*po.ServedPage, error) {
+	pages, err := r.served.ListByIDs(ctx, nil, logIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*po.ServedPage, len(logIDs))
+	for _, page := range pages {
+		byID[page.LogID] = page
+	}
+	if r.logs == nil || len(byID) == len(logIDs) {
+		return byID, nil
+	}
+	missing := make([]uuid.UUID, 0, len(logIDs)-len(byID))
+	for _, id := range logIDs {
+		if _, ok := byID[id.String()]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	entries, err := r.logs.ListByIDs(ctx, nil, missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		byID[entry.LogID] = servedPageFromLog(entry)
+	}
+	return byID, nil
+}
+
+// servedPageFromLog 将推荐日志转换为下发页记录。
+func servedPageFromLog(entry *po.FeedRecommendationLog) *po.ServedPage {
+	return &po.ServedPage{
+		LogID:                entry.LogID,
+		UserID:               entry.UserID,
+		UserIDHash:           entry.UserIDHash,
+		UserIDKeyVersion:     entry.UserIDKeyVersion,
+		Guest:                entry.Guest,
+		Scene:                entry.Scene,
+		RecommendationSource: entry.RecommendationSource,
+		ServedItems:          entry.ServedItems,
+		GeneratedAt:          entry.GeneratedAt,
+	}
+}
+
+// validate 依据下发页记录校验事件归属、下发事实与时间窗口，返回命中的下发条目或拒绝原因。
+func (r *InteractionRecorder) validate(input ReportInteractionsInput, evt InteractionInput, page *po.ServedPage, receivedAt time.Time) (*po.ServedItemLog, string) {
+	if reason := r.checkOwner(input, page); reason != "" {
+		return nil, reason
+	}
+	if evt.OccurredAt.IsZero() {
+		return nil, RejectReasonOccurredAtMissing
+	}
+	occurredAt := evt.OccurredAt.UTC()
+	if occurredAt.Before(page.GeneratedAt.Add(-r.cfg.ClockSkew)) ||
+		occurredAt.After(receivedAt.Add(r.cfg.ClockSkew)) ||
+		(r.cfg.MaxEventAge > 0 && receivedAt.Sub(occurredAt) > r.cfg.MaxEventAge) {
+		return nil, RejectReasonOccurredAtOutOfRange
+	}
+	if evt.Position < 0 {
+		return nil, RejectReasonNegativePosition
+	}
+
+	switch evt.Type {
+	case InteractionRefresh:
+		if strings.TrimSpace(evt.VideoID) != "" {
+			return nil, RejectReasonUnexpectedVideoID
+		}
+		return nil, ""
+	case InteractionImpression, InteractionClick:
+	default:
+		return nil, RejectReasonUnsupportedType
+	}
+
+	videoID := strings.TrimSpace(evt.VideoID)
+	if videoID == "" {
+		return nil, RejectReasonVideoIDRequired
+	}
+	parsed, err := uuid.Parse(videoID)
+	if err != nil {
+		return nil, RejectReasonInvalidVideoID
+	}
+	videoID = parsed.String()
+	for i := range page.ServedItems {
+		served := &page.ServedItems[i]
+		if served.VideoID != videoID {
+			continue
+		}
+		if evt.Position > 0 && int32(evt.Position) != served.Position {
+			return nil, RejectReasonPositionMismatch
+		}
+		return served, ""
+	}
+	return nil, RejectReasonVideoNotServed
+}
+
+// checkOwner 校验上报者与推荐日志归属一致：访客仅能上报访客日志，登录用户需匹配日志中的用户哈希。
+func (r *InteractionRecorder) checkOwner(input ReportInteractionsInput, page *po.ServedPage) string {
+	if input.Guest || page.Guest {
+		if input.Guest && page.Guest {
+			return ""
+		}
+		return RejectReasonLogOwnerMismatch
+	}
+	if page.UserID != nil {
+		if *page.UserID == input.UserID {
+			return ""
+		}
+		return RejectReasonLogOwnerMismatch
+	}
+	if page.UserIDHash == nil || *page.UserIDHash == "" {
+		// 未启用假名化时日志不含用户标识，无法校验归属，仅依赖 log_id 的不可猜测性。
+		if r.hasher.Enabled() {
+			return RejectReasonLogOwnerMismatch
+		}
+		return ""
+	}
+	for _, digest := range r.hasher.Candidates(input.UserID) {
+		if digest.Hash == *page.UserIDHash {
+			return ""
+		}
+	}
+	return RejectReasonLogOwnerMismatch
+}
+
+// buildInteractionMessage 将通过校验的事件编码为 Outbox 消息，载荷中的用户标识沿用下发页记录中的哈希。
+func buildInteractionMessage(p pendingInteraction, receivedAt time.Time) (repositories.OutboxMessage, error) {
+	logID := uuid.MustParse(p.page.LogID)
+	eventType := interactionEventType(p.input.Type)
+	aggregateType, aggregateID := eventAggregate(p.page.Guest, p.page.UserIDHash, logID)
+	payload := &eventsv1.InteractionEvent{
+		EventId:              p.eventID.String(),
+		Type:                 interactionProtoType(p.input.Type),
+		LogId:                logID.String(),
+		UserIdHash:           derefString(p.page.UserIDHash),
+		UserIdKeyVersion:     derefString(p.page.UserIDKeyVersion),
+		Guest:                p.page.Guest,
+		Scene:                derefString(p.page.Scene),
+		RecommendationSource: p.page.RecommendationSource,
+		OccurredAt:           timestamppb.New(p.input.OccurredAt.UTC()),
+		ReceivedAt:           timestamppb.New(receivedAt),
+	}
+	if p.served != nil {
+		payload.VideoId = p.served.VideoID
+		payload.Position = p.served.Position
+		payload.ProjectionVersion = p.served.ProjectionVersion
+	}
+	data, err := proto.Marshal(payload)
+	if err != nil {
+		return repositories.OutboxMessage{}, fmt.Errorf("marshal interaction event: %w", err)
+	}
+	return repositories.OutboxMessage{
+		EventID:       p.eventID,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		EventType:     eventType,
+		Payload:       data,
+		Headers: map[string]string{
+			"event_type":     eventType,
+			"schema":         string(payload.ProtoReflect().Descriptor().FullName()),
+			"aggregate_type": aggregateType,
+			"aggregate_id":   aggregateID.String(),
+		},
+		AvailableAt: receivedAt,
+	}, nil
+}
+
+func interactionEventType(t InteractionType) string {
+	return "feed." + string(t)
+}
+
+func interactionProtoType(t InteractionType) eventsv1.InteractionType {
+	switch t {
+	case InteractionImpression:
+		return eventsv1.InteractionType_INTERACTION_TYPE_IMPRESSION
+	case InteractionClick:
+		return eventsv1.InteractionType_INTERACTION_TYPE_CLICK
+	case InteractionRefresh:
+		return eventsv1.InteractionType_INTERACTION_TYPE_REFRESH
+	default:
+		return eventsv1.InteractionType_INTERACTION_TYPE_UNSPECIFIED
+	}
+}
+
+func derefString(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+// reservedEventID 判断交互事件 ID 是否占用了服务端事件的取值：与任一 log_id 相同，
+// 或为名称派生的 UUIDv5（served 事件 ID 由 log_id 经 UUIDv5 派生，客户端应使用随机 UUID）。
+// 交互事件与 served 事件共用 Outbox 主键空间，放行会使随后的推荐日志批量写入因主键冲突回滚。
+func reservedEventID(eventID uuid.UUID, logIDs map[uuid.UUID]struct{}) bool {
+	if eventID.Version() == 5 {
+		return true
+	}
+	_, ok := logIDs[eventID]
+	return ok
+}
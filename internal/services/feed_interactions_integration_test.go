@@ -0,0 +1,205 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eventsv1 "github.com/bionicotaku/lingo-services-feed/api/feed/events/v1"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/bionicotaku/lingo-services-feed/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// servedFeed 为交互测试准备一页已下发的 Feed，返回服务实例、log_id 与按位次排列的视频 ID。
+func servedFeed(ctx context.Context, t *testing.T, userID string) (*services.FeedService, string, []string) {
+	t.Helper()
+	videoRepo := repositories.NewFeedVideoProjectionRepository(testPool, stdLogger)
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+	provider := &stubRecommendationProvider{source: "stub"}
+	for i, id := range ids {
+		require.NoError(t, videoRepo.Upsert(ctx, nil, repositories.UpsertFeedVideoProjectionInput{
+			VideoID: id,
+			Title:   "Video",
+			Version: int64(i + 3),
+		}))
+		provider.items = append(provider.items, services.RecommendationItem{VideoID: id.String(), Reason: "stub"})
+	}
+	service := newFeedService(provider)
+	resp, err := service.GetFeed(ctx, services.GetFeedInput{UserID: userID, Limit: 2, Scene: "home"})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.LogID)
+	return service, resp.LogID, []string{ids[0].String(), ids[1].String()}
+}
+
+func TestFeedService_ReportInteractions_ValidatesAgainstServedLog(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	service, logID, videos := servedFeed(ctx, t, "user-int")
+	now := time.Now().UTC()
+
+	impressionID, clickID, refreshID := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	res, err := service.ReportInteractions(ctx, services.ReportInteractionsInput{
+		UserID: "user-int",
+		Events: []services.InteractionInput{
+			{EventID: impressionID, Type: services.InteractionImpression, LogID: logID, VideoID: videos[0], Position: 1, OccurredAt: now},
+			{EventID: clickID, Type: services.InteractionClick, LogID: logID, VideoID: videos[1], OccurredAt: now},
+			{EventID: refreshID, Type: services.InteractionRefresh, LogID: logID, OccurredAt: now},
+			{EventID: uuid.NewString(), Type: services.InteractionClick, LogID: logID, VideoID: uuid.NewString(), OccurredAt: now},
+			{EventID: uuid.NewString(), Type: services.InteractionClick, LogID: logID, VideoID: videos[1], Position: 1, OccurredAt: now},
+			{EventID: uuid.NewString(), Type: services.InteractionImpression, LogID: logID, VideoID: videos[0], OccurredAt: now.Add(-2 * time.Hour)},
+			{EventID: uuid.NewString(), Type: services.InteractionImpression, LogID: uuid.NewString(), VideoID: videos[0], OccurredAt: now},
+			{EventID: impressionID, Type: services.InteractionImpression, LogID: logID, VideoID: videos[0], OccurredAt: now},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, res.Accepted)
+	statuses := make([]string, 0, len(res.Results))
+	for _, result := range res.Results {
+		statuses = append(statuses, string(result.Status)+":"+result.Reason)
+	}
+	require.Equal(t, []string{
+		"accepted:",
+		"accepted:",
+		"accepted:",
+		"rejected:" + services.RejectReasonVideoNotServed,
+		"rejected:" + services.RejectReasonPositionMismatch,
+		"rejected:" + services.RejectReasonOccurredAtOutOfRange,
+		"rejected:" + services.RejectReasonLogNotFound,
+		"rejected:" + services.RejectReasonDuplicateInBatch,
+	}, statuses)
+
+	rows, err := testPool.Query(ctx, `
+		SELECT event_id, aggregate_type, aggregate_id, event_type, payload
+		FROM feed.outbox_events
+		ORDER BY event_type`)
+	require.NoError(t, err)
+	defer rows.Close()
+	types := make([]string, 0, 3)
+	aggregates := make(map[uuid.UUID]struct{})
+	for rows.Next() {
+		var (
+			eventID, aggregateID   uuid.UUID
+			aggregateType, evtType string
+			payload                []byte
+		)
+		require.NoError(t, rows.Scan(&eventID, &aggregateType, &aggregateID, &evtType, &payload))
+		// 登录用户的事件按用户哈希派生的聚合根排序，同一用户共用一个 ordering key。
+		require.Equal(t, "feed_user", aggregateType)
+		aggregates[aggregateID] = struct{}{}
+		var event eventsv1.InteractionEvent
+		require.NoError(t, proto.Unmarshal(payload, &event))
+		require.Equal(t, eventID.String(), event.GetEventId())
+		require.Equal(t, testHasher.Hash("user-int").Hash, event.GetUserIdHash())
+		require.Equal(t, "home", event.GetScene())
+		if evtType == "feed.click" {
+			require.Equal(t, videos[1], event.GetVideoId())
+			require.Equal(t, int32(2), event.GetPosition())
+			require.Equal(t, int64(4), event.GetProjectionVersion())
+		}
+		types = append(types, evtType)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []string{"feed.click", "feed.impression", "feed.refresh"}, types)
+	require.Len(t, aggregates, 1)
+
+	// 客户端重试同一批时按 event_id 去重。
+	res, err = service.ReportInteractions(ctx, services.ReportInteractionsInput{
+		UserID: "user-int",
+		Events: []services.InteractionInput{
+			{EventID: clickID, Type: services.InteractionClick, LogID: logID, VideoID: videos[1], OccurredAt: now},
+		},
+	})
+	require.NoError(t, err)
+	require.Zero(t, res.Accepted)
+	require.Equal(t, services.InteractionDuplicate, res.Results[0].Status)
+}
+
+func TestFeedService_ReportInteractions_RejectsForeignLog(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	service, logID, videos := servedFeed(ctx, t, "user-owner")
+	event := services.InteractionInput{
+		EventID:    uuid.NewString(),
+		Type:       services.InteractionClick,
+		LogID:      logID,
+		VideoID:    videos[0],
+		OccurredAt: time.Now().UTC(),
+	}
+
+	res, err := service.ReportInteractions(ctx, services.ReportInteractionsInput{UserID: "user-other", Events: []services.InteractionInput{event}})
+	require.NoError(t, err)
+	require.Equal(t, services.RejectReasonLogOwnerMismatch, res.Results[0].Reason)
+
+	res, err = service.ReportInteractions(ctx, services.ReportInteractionsInput{Guest: true, Events: []services.InteractionInput{event}})
+	require.NoError(t, err)
+	require.Equal(t, services.RejectReasonLogOwnerMismatch, res.Results[0].Reason)
+
+	_, err = service.ReportInteractions(ctx, services.ReportInteractionsInput{UserID: "user-owner"})
+	require.ErrorIs(t, err, services.ErrInvalidInteractionBatch)
+}
+
+func TestFeedService_ReportInteractions_AcceptsSampledOutPage(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	videoRepo := repositories.NewFeedVideoProjectionRepository(testPool, stdLogger)
+	videoID := uuid.New()
+	require.NoError(t, videoRepo.Upsert(ctx, nil, repositories.UpsertFeedVideoProjectionInput{VideoID: videoID, Title: "Video", Version: 1}))
+	provider := &stubRecommendationProvider{source: "stub", items: []services.RecommendationItem{{VideoID: videoID.String(), Reason: "stub"}}}
+	logWriter, _ := services.NewRecommendationLogWriter(repositories.NewFeedRecommendationLogRepository(testPool, stdLogger), repositories.NewServedPageRepository(testPool, stdLogger), services.RecommendationLogWriterConfig{}, stdLogger)
+	// 采样率为 0：推荐日志不落库，交互事件仍依据下发页记录校验。
+	sampler := services.NewRecommendationLogSampler(services.RecommendationLogSamplingConfig{Enabled: true, DefaultRate: 0})
+	service := services.NewFeedService(provider, nil, videoRepo, logWriter,
+		repositories.NewFeedIdempotencyRepository(testPool, stdLogger), testHasher, sampler, newInteractionRecorder(), nil, nil, nil, nil, nil, nil,
+		nil, services.FeedServiceConfig{IdempotencyTTL: time.Minute}, stdLogger)
+
+	resp, err := service.GetFeed(ctx, services.GetFeedInput{UserID: "user-sampled", Limit: 1, Scene: "home"})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.LogID)
+
+	var logs int
+	require.NoError(t, testPool.QueryRow(ctx, `select count(*) from feed.recommendation_logs`).Scan(&logs))
+	require.Zero(t, logs)
+
+	res, err := service.ReportInteractions(ctx, services.ReportInteractionsInput{
+		UserID: "user-sampled",
+		Events: []services.InteractionInput{
+			{EventID: uuid.NewString(), Type: services.InteractionClick, LogID: resp.LogID, VideoID: videoID.String(), Position: 1, OccurredAt: time.Now().UTC()},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Accepted)
+}
+
+func TestFeedService_GetFeed_WritesServedPageAsync(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	videoRepo := repositories.NewFeedVideoProjectionRepository(testPool, stdLogger)
+	videoID := uuid.New()
+	require.NoError(t, videoRepo.Upsert(ctx, nil, repositories.UpsertFeedVideoProjectionInput{VideoID: videoID, Title: "Video", Version: 1}))
+	provider := &stubRecommendationProvider{source: "stub", items: []services.RecommendationItem{{VideoID: videoID.String(), Reason: "stub"}}}
+	logWriter, cleanup := services.NewRecommendationLogWriter(repositories.NewFeedRecommendationLogRepository(testPool, stdLogger), repositories.NewServedPageRepository(testPool, stdLogger),
+		services.RecommendationLogWriterConfig{Async: true, FlushInterval: time.Hour}, stdLogger)
+	service := services.NewFeedService(provider, nil, videoRepo, logWriter,
+		repositories.NewFeedIdempotencyRepository(testPool, stdLogger), testHasher, nil, newInteractionRecorder(), nil, nil, nil, nil, nil, nil,
+		nil, services.FeedServiceConfig{}, stdLogger)
+
+	resp, err := service.GetFeed(ctx, services.GetFeedInput{UserID: "user-async", Limit: 1})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.LogID)
+
+	// 下发页记录随推荐日志入队，请求返回时尚未落库。
+	var pages int
+	require.NoError(t, testPool.QueryRow(ctx, `select count(*) from feed.served_pages`).Scan(&pages))
+	require.Zero(t, pages)
+
+	cleanup()
+	require.NoError(t, testPool.QueryRow(ctx, `select count(*) from feed.served_pages where log_id = $1`, resp.LogID).Scan(&pages))
+	require.Equal(t, 1, pages)
+}
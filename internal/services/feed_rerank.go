@@ -0,0 +1,310 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/vo"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// RerankStage 为补水之后的单个重排阶段，输入输出均为整页条目。
+// 阶段只调整顺序，不增删条目；依赖的投影字段缺失（为空）时视为未知，不受该阶段约束。
+type RerankStage interface {
+	Name() string
+	Rerank(items []vo.FeedItem) []vo.FeedItem
+}
+
+// RerankConfig 控制补水后的多样性重排，各阶段独立开关，按 MMR → 创作者限频 → 语言连续 → 时长分散的顺序执行。
+type RerankConfig struct {
+	MMR            MMRConfig
+	CreatorCap     CreatorCapConfig
+	LanguageRun    LanguageRunConfig
+	DurationSpread DurationSpreadConfig
+}
+
+// RerankPipeline 依次执行已启用的重排阶段。
+type RerankPipeline struct {
+	stages []RerankStage
+	log    *log.Helper
+}
+
+// NewRerankPipeline 按配置组装重排阶段；没有启用任何阶段时返回 nil，FeedService 随之跳过重排。
+func NewRerankPipeline(cfg RerankConfig, logger log.Logger) *RerankPipeline {
+	var stages []RerankStage
+	if cfg.MMR.Enabled {
+		stages = append(stages, NewMMRStage(cfg.MMR))
+	}
+	if cfg.CreatorCap.Enabled {
+		stages = append(stages, NewCreatorCapStage(cfg.CreatorCap))
+	}
+	if cfg.LanguageRun.Enabled {
+		stages = append(stages, NewLanguageRunStage(cfg.LanguageRun))
+	}
+	if cfg.DurationSpread.Enabled {
+		stages = append(stages, NewDurationSpreadStage(cfg.DurationSpread))
+	}
+	return NewRerankPipelineFromStages(logger, stages...)
+}
+
+// NewRerankPipelineFromStages 以自定义阶段组装流水线；stages 为空时返回 nil。
+func NewRerankPipelineFromStages(logger log.Logger, stages ...RerankStage) *RerankPipeline {
+	if len(stages) == 0 {
+		return nil
+	}
+	return &RerankPipeline{
+		stages: stages,
+		log:    log.NewHelper(logger),
+	}
+}
+
+// Apply 依次执行各阶段并返回重排后的条目；单条目或空页原样返回。运营置顶条目不参与重排，保持在其位次。
+func (p *RerankPipeline) Apply(ctx context.Context, items []vo.FeedItem) []vo.FeedItem {
+	if p == nil || len(items) < 2 {
+		return items
+	}
+	return holdPinned(items, func(items []vo.FeedItem) []vo.FeedItem {
+		for _, stage := range p.stages {
+			startedAt := time.Now()
+			items = stage.Rerank(items)
+			p.log.WithContext(ctx).Debugw("msg", "rerank stage applied", "stage", stage.Name(), "count", len(items), "elapsed_us", time.Since(startedAt).Microseconds())
+		}
+		return items
+	})
+}
+
+// MMRConfig 控制按标签相似度的最大边际相关性重排。
+type MMRConfig struct {
+	Enabled bool
+	// Lambda 为相关性权重，取值 [0, 1]：1 保持原顺序，越小越强调与已选条目的差异。
+	Lambda float64
+}
+
+// MMRStage 以原排序位置作为相关性、以标签 Jaccard 相似度作为冗余度，贪心选出 λ·rel − (1−λ)·maxSim 最大的条目。
+type MMRStage struct {
+	lambda float64
+}
+
+// NewMMRStage 构造 MMR 阶段，Lambda 超出 [0, 1] 时截断。
+func NewMMRStage(cfg MMRConfig) *MMRStage {
+	return &MMRStage{lambda: min(max(cfg.Lambda, 0), 1)}
+}
+
+// Name 返回阶段名。
+func (s *MMRStage) Name() string {
+	return "mmr"
+}
+
+// Rerank 执行 MMR 重排。推荐分数在不同来源间量纲不一，相关性统一取原位置的线性衰减 1 − i/n。
+func (s *MMRStage) Rerank(items []vo.FeedItem) []vo.FeedItem {
+	n := len(items)
+	tagSets := make([]map[string]struct{}, n)
+	for i, item := range items {
+		if len(item.Tags) == 0 {
+			continue
+		}
+		set := make(map[string]struct{}, len(item.Tags))
+		for _, tag := range item.Tags {
+			set[tag] = struct{}{}
+		}
+		tagSets[i] = set
+	}
+	// maxSim[i] 为候选 i 与已选条目的最大相似度。
+	maxSim := make([]float64, n)
+	picked := make([]bool, n)
+	out := make([]vo.FeedItem, 0, n)
+	for len(out) < n {
+		best, bestScore := -1, 0.0
+		for i := range items {
+			if picked[i] {
+				continue
+			}
+			relevance := 1 - float64(i)/float64(n)
+			score := s.lambda*relevance - (1-s.lambda)*maxSim[i]
+			if best < 0 || score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		picked[best] = true
+		out = append(out, items[best])
+		for i := range items {
+			if !picked[i] {
+				maxSim[i] = max(maxSim[i], jaccard(tagSets[i], tagSets[best]))
+			}
+		}
+	}
+	return out
+}
+
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	inter := 0
+	for tag := range a {
+		if _, ok := b[tag]; ok {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(a)+len(b)-inter)
+}
+
+// CreatorCapConfig 控制同一创作者在一页中的出现次数。
+type CreatorCapConfig struct {
+	Enabled bool
+	// MaxPerCreator 为同一创作者在页首部分的最多条目数，默认 2。
+	MaxPerCreator int
+}
+
+// CreatorCapStage 保持相对顺序，把同一创作者超出上限的条目移到本页末尾。
+// 页内候选不足以满足上限时，末尾仍可能出现同一创作者的条目，但不会挤占其他创作者的位置。
+type CreatorCapStage struct {
+	maxPerCreator int
+}
+
+// NewCreatorCapStage 构造创作者限频阶段。
+func NewCreatorCapStage(cfg CreatorCapConfig) *CreatorCapStage {
+	limit := cfg.MaxPerCreator
+	if limit <= 0 {
+		limit = 2
+	}
+	return &CreatorCapStage{maxPerCreator: limit}
+}
+
+// Name 返回阶段名。
+func (s *CreatorCapStage) Name() string {
+	return "creator_cap"
+}
+
+// Rerank 执行创作者限频。
+func (s *CreatorCapStage) Rerank(items []vo.FeedItem) []vo.FeedItem {
+	counts := make(map[string]int, len(items))
+	kept := make([]vo.FeedItem, 0, len(items))
+	var overflow []vo.FeedItem
+	for _, item := range items {
+		if item.CreatorID == "" {
+			kept = append(kept, item)
+			continue
+		}
+		counts[item.CreatorID]++
+		if counts[item.CreatorID] > s.maxPerCreator {
+			overflow = append(overflow, item)
+			continue
+		}
+		kept = append(kept, item)
+	}
+	return append(kept, overflow...)
+}
+
+// LanguageRunConfig 控制同一语言的视频连续出现的条数。
+type LanguageRunConfig struct {
+	Enabled bool
+	// MaxConsecutive 为同一语言最多连续条数，默认 3。
+	MaxConsecutive int
+}
+
+// LanguageRunStage 限制同一语言的视频连续出现。
+type LanguageRunStage struct {
+	maxRun int
+}
+
+// NewLanguageRunStage 构造语言连续限制阶段。
+func NewLanguageRunStage(cfg LanguageRunConfig) *LanguageRunStage {
+	limit := cfg.MaxConsecutive
+	if limit <= 0 {
+		limit = 3
+	}
+	return &LanguageRunStage{maxRun: limit}
+}
+
+// Name 返回阶段名。
+func (s *LanguageRunStage) Name() string {
+	return "language_run"
+}
+
+// Rerank 执行语言连续限制。
+func (s *LanguageRunStage) Rerank(items []vo.FeedItem) []vo.FeedItem {
+	return limitRuns(items, s.maxRun, func(item vo.FeedItem) string { return item.Language })
+}
+
+// DurationSpreadConfig 控制时长相近的视频连续出现的条数。
+type DurationSpreadConfig struct {
+	Enabled bool
+	// Boundaries 为升序的时长分档边界，默认 1m、5m，即短/中/长三档。
+	Boundaries []time.Duration
+	// MaxConsecutive 为同一时长档最多连续条数，默认 2。
+	MaxConsecutive int
+}
+
+// DurationSpreadStage 按时长分档，限制同一档的视频连续出现，使长短视频交错。
+type DurationSpreadStage struct {
+	boundaries []int64
+	maxRun     int
+}
+
+// NewDurationSpreadStage 构造时长分散阶段。
+func NewDurationSpreadStage(cfg DurationSpreadConfig) *DurationSpreadStage {
+	bounds := cfg.Boundaries
+	if len(bounds) == 0 {
+		bounds = []time.Duration{time.Minute, 5 * time.Minute}
+	}
+	micros := make([]int64, 0, len(bounds))
+	for _, b := range bounds {
+		micros = append(micros, b.Microseconds())
+	}
+	limit := cfg.MaxConsecutive
+	if limit <= 0 {
+		limit = 2
+	}
+	return &DurationSpreadStage{boundaries: micros, maxRun: limit}
+}
+
+// Name 返回阶段名。
+func (s *DurationSpreadStage) Name() string {
+	return "duration_spread"
+}
+
+// Rerank 执行时长分散。
+func (s *DurationSpreadStage) Rerank(items []vo.FeedItem) []vo.FeedItem {
+	return limitRuns(items, s.maxRun, s.bucket)
+}
+
+// bucket 返回时长所在分档；时长未知时返回空串，不参与限制。
+func (s *DurationSpreadStage) bucket(item vo.FeedItem) string {
+	if item.DurationMicros <= 0 {
+		return ""
+	}
+	idx := 0
+	for idx < len(s.boundaries) && item.DurationMicros >= s.boundaries[idx] {
+		idx++
+	}
+	return string(rune('a' + idx))
+}
+
+// limitRuns 贪心地保持原顺序，当下一个条目会使相同 key 连续超过 maxRun 时，
+// 把其后第一个 key 不同的条目提前；找不到可提前的条目时按原顺序继续。空 key 不计入连续。
+func limitRuns(items []vo.FeedItem, maxRun int, key func(vo.FeedItem) string) []vo.FeedItem {
+	remaining := append([]vo.FeedItem(nil), items...)
+	out := make([]vo.FeedItem, 0, len(items))
+	runKey, runLen := "", 0
+	for len(remaining) > 0 {
+		pick := 0
+		if runKey != "" && runLen >= maxRun && key(remaining[0]) == runKey {
+			for i := 1; i < len(remaining); i++ {
+				if key(remaining[i]) != runKey {
+					pick = i
+					break
+				}
+			}
+		}
+		item := remaining[pick]
+		remaining = append(remaining[:pick], remaining[pick+1:]...)
+		out = append(out, item)
+		if k := key(item); k != "" && k == runKey {
+			runLen++
+		} else {
+			runKey, runLen = k, 1
+		}
+	}
+	return out
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	eventsv1 "github.com/bionicotaku/lingo-services-feed/api/feed/events/v1"
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// servedEventType 为 served 事件的消息属性 event_type。
+	servedEventType = "feed.served"
+	// userAggregateType 为按用户聚合的 Outbox 事件类型，聚合根 ID 由用户哈希派生并作为 ordering key。
+	userAggregateType = "feed_user"
+)
+
+// userAggregateNamespace 为用户哈希派生聚合根 ID 的 UUIDv5 命名空间，取值固定，修改会打乱在途事件的顺序。
+var userAggregateNamespace = uuid.MustParse("6f0c1c55-2d0e-4c1b-9a53-6a3f5b2f7e10")
+
+// servedEventNamespace 为 log_id 派生 served 事件 ID 的 UUIDv5 命名空间，取值固定，修改会使重复写入不再被 Outbox 主键拦截。
+var servedEventNamespace = uuid.MustParse("0b9d3a4e-5c71-4f2a-8e36-2d1f7c9a4b58")
+
+// ServedEventID 返回推荐日志对应的 served 事件 ID。
+//
+// 事件 ID 与 log_id 不同：客户端持有 log_id 且可自选交互事件 ID，二者共用 Outbox 主键空间，
+// 若直接复用 log_id，抢先以其为 ID 上报的交互事件会让整批日志写入因主键冲突回滚。
+func ServedEventID(logID uuid.UUID) uuid.UUID {
+	return uuid.NewSHA1(servedEventNamespace, logID[:])
+}
+
+// ServedEventConfig 控制推荐日志写入时是否同事务产出 FeedServedEvent。
+type ServedEventConfig struct {
+	Enabled bool
+}
+
+// ServedEventLogStore 在写推荐日志的同一事务内写入 feed.served Outbox 事件，
+// 保证发布出去的事件与日志一一对应：日志回滚则事件不存在，事件存在则日志必然可查。
+type ServedEventLogStore struct {
+	logs    *repositories.FeedRecommendationLogRepository
+	outbox  *repositories.OutboxRepository
+	tx      txmanager.Manager
+	metrics *logWriterMetrics
+	log     *log.Helper
+}
+
+// NewRecommendationLogStore 返回推荐日志写入实现：启用 served 事件时包装为事务写入，否则直接使用日志仓储。
+func NewRecommendationLogStore(logs *repositories.FeedRecommendationLogRepository, outbox *repositories.OutboxRepository, tx txmanager.Manager, cfg ServedEventConfig, logger log.Logger) RecommendationLogStore {
+	if !cfg.Enabled || outbox == nil || tx == nil {
+		return logs
+	}
+	return &ServedEventLogStore{
+		logs:    logs,
+		outbox:  outbox,
+		tx:      tx,
+		metrics: newLogWriterMetrics(),
+		log:     log.NewHelper(logger),
+	}
+}
+
+// Insert 写入单条推荐日志及其 served 事件；sess 非空时加入调用方事务。
+func (s *ServedEventLogStore) Insert(ctx context.Context, sess txmanager.Session, entry po.FeedRecommendationLog) error {
+	entry = prepareServedLog(entry)
+	return s.within(ctx, sess, func(txCtx context.Context, txSess txmanager.Session) error {
+		if err := s.logs.Insert(txCtx, txSess, entry); err != nil {
+			return err
+		}
+		return s.enqueue(txCtx, txSess, entry)
+	})
+}
+
+// InsertBatch 在同一事务内 COPY 一批推荐日志并逐条写入 served 事件，写库失败整批回滚。
+//
+// 事件在事务前编码：无法编码的条目跳过并计入丢弃指标（reason=malformed），不连累同批其他日志。
+func (s *ServedEventLogStore) InsertBatch(ctx context.Context, sess txmanager.Session, entries []po.FeedRecommendationLog) (int64, error) {
+	if len(entries) == 0 {
+		return 0, nil
+	}
+	batch := make([]po.FeedRecommendationLog, 0, len(entries))
+	messages := make([]servedOutboxMessage, 0, len(entries))
+	var malformed int64
+	for _, entry := range entries {
+		entry = prepareServedLog(entry)
+		if producesServedEvent(entry) {
+			msg, err := buildServedMessage(entry)
+			if err != nil {
+				malformed++
+				s.log.WithContext(ctx).Warnw("msg", "skip malformed recommendation log", "log_id", entry.LogID, "error", err)
+				continue
+			}
+			messages = append(messages, servedOutboxMessage{logID: entry.LogID, msg: msg})
+		}
+		batch = append(batch, entry)
+	}
+	s.metrics.recordDropped(ctx, dropReasonMalformed, malformed)
+	if len(batch) == 0 {
+		return 0, nil
+	}
+	var written int64
+	err := s.within(ctx, sess, func(txCtx context.Context, txSess txmanager.Session) error {
+		copied, err := s.logs.InsertBatch(txCtx, txSess, batch)
+		if err != nil {
+			return err
+		}
+		for _, m := range messages {
+			if err := s.publish(txCtx, txSess, m.logID, m.msg); err != nil {
+				return err
+			}
+		}
+		written = copied
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return written, nil
+}
+
+// prepareServedLog 预先补齐 log_id 与生成时间，使日志行与事件载荷引用同一取值。
+func prepareServedLog(entry po.FeedRecommendationLog) po.FeedRecommendationLog {
+	if entry.LogID == "" {
+		entry.LogID = uuid.NewString()
+	}
+	if entry.GeneratedAt.IsZero() {
+		entry.GeneratedAt = time.Now().UTC()
+	}
+	return entry
+}
+
+func (s *ServedEventLogStore) within(ctx context.Context, sess txmanager.Session, fn func(context.Context, txmanager.Session) error) error {
+	if sess != nil {
+		return fn(ctx, sess)
+	}
+	return s.tx.WithinTx(ctx, txmanager.TxOptions{}, fn)
+}
+
+// servedOutboxMessage 为预先编码的 served 事件及其日志 ID。
+type servedOutboxMessage struct {
+	logID string
+	msg   repositories.OutboxMessage
+}
+
+// producesServedEvent 判断日志是否产出 served 事件；失败请求的日志不代表任何下发，不产出事件。
+func producesServedEvent(entry po.FeedRecommendationLog) bool {
+	return entry.ErrorKind == nil || *entry.ErrorKind == ""
+}
+
+// enqueue 写入单条日志的 served 事件。
+func (s *ServedEventLogStore) enqueue(ctx context.Context, sess txmanager.Session, entry po.FeedRecommendationLog) error {
+	if !producesServedEvent(entry) {
+		return nil
+	}
+	msg, err := buildServedMessage(entry)
+	if err != nil {
+		return err
+	}
+	return s.publish(ctx, sess, entry.LogID, msg)
+}
+
+func (s *ServedEventLogStore) publish(ctx context.Context, sess txmanager.Session, logID string, msg repositories.OutboxMessage) error {
+	if err := s.outbox.Enqueue(ctx, sess, msg); err != nil {
+		s.log.WithContext(ctx).Errorw("msg", "enqueue served event failed", "log_id", logID, "error", err)
+		return err
+	}
+	return nil
+}
+
+// buildServedMessage 将推荐日志编码为 FeedServedEvent；event_id 由 log_id 派生，重复写入由 Outbox 主键拦截。
+func buildServedMessage(entry po.FeedRecommendationLog) (repositories.OutboxMessage, error) {
+	logID, err := uuid.Parse(entry.LogID)
+	if err != nil {
+		return repositories.OutboxMessage{}, fmt.Errorf("build served event: invalid log id %q: %w", entry.LogID, err)
+	}
+	recommended := make(map[string]po.RecommendedItemLog, len(entry.RecommendedItems))
+	for _, item := range entry.RecommendedItems {
+		recommended[item.VideoID] = item
+	}
+	items := make([]*eventsv1.ServedItem, 0, len(entry.ServedItems))
+	for _, served := range entry.ServedItems {
+		item := &eventsv1.ServedItem{
+			VideoId:           served.VideoID,
+			Position:          served.Position,
+			ProjectionVersion: served.ProjectionVersion,
+		}
+		if rec, ok := recommended[served.VideoID]; ok {
+			item.Reason = rec.Reason
+			item.Score = rec.Score
+		}
+		items = append(items, item)
+	}
+	eventID := ServedEventID(logID)
+	payload := &eventsv1.FeedServedEvent{
+		EventId:              eventID.String(),
+		LogId:                logID.String(),
+		UserIdHash:           derefString(entry.UserIDHash),
+		UserIdKeyVersion:     derefString(entry.UserIDKeyVersion),
+		Guest:                entry.Guest,
+		Scene:                derefString(entry.Scene),
+		RecommendationSource: entry.RecommendationSource,
+		Items:                items,
+		Replayed:             entry.Replayed,
+		RequestId:            derefString(entry.RequestID),
+		TraceId:              derefString(entry.TraceID),
+		GeneratedAt:          timestamppb.New(entry.GeneratedAt.UTC()),
+	}
+	if entry.Page != nil {
+		payload.Page = *entry.Page
+	}
+	data, err := proto.Marshal(payload)
+	if err != nil {
+		return repositories.OutboxMessage{}, fmt.Errorf("marshal served event: %w", err)
+	}
+	aggregateType, aggregateID := eventAggregate(entry.Guest, entry.UserIDHash, logID)
+	return repositories.OutboxMessage{
+		EventID:       eventID,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		EventType:     servedEventType,
+		Payload:       data,
+		Headers: map[string]string{
+			"event_type":     servedEventType,
+			"schema":         string(payload.ProtoReflect().Descriptor().FullName()),
+			"aggregate_type": aggregateType,
+			"aggregate_id":   aggregateID.String(),
+		},
+		AvailableAt: entry.GeneratedAt.UTC(),
+	}, nil
+}
+
+// eventAggregate 返回 Feed 事件的聚合根；发布器以聚合根 ID 作为 Pub/Sub ordering key。
+// 登录用户按用户哈希派生，使同一用户的 served 与交互事件按写入顺序投递；
+// 访客与未假名化的日志没有稳定的用户标识，退化为按推荐日志排序。
+// 密钥轮换后哈希改变，轮换窗口内新旧 ordering key 之间不保证顺序。
+func eventAggregate(guest bool, userIDHash *string, logID uuid.UUID) (string, uuid.UUID) {
+	hash := strings.TrimSpace(derefString(userIDHash))
+	if guest || hash == "" {
+		return interactionAggregateType, logID
+	}
+	return userAggregateType, uuid.NewSHA1(userAggregateNamespace, []byte(hash))
+}
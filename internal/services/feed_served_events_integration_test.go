@@ -0,0 +1,209 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eventsv1 "github.com/bionicotaku/lingo-services-feed/api/feed/events/v1"
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/bionicotaku/lingo-services-feed/internal/services"
+	outboxcfg "github.com/bionicotaku/lingo-utils/outbox/config"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func newServedEventStore(t *testing.T) services.RecommendationLogStore {
+	t.Helper()
+	manager, err := txmanager.NewManager(testPool, txmanager.Config{}, txmanager.Dependencies{Logger: stdLogger})
+	require.NoError(t, err)
+	return services.NewRecommendationLogStore(
+		repositories.NewFeedRecommendationLogRepository(testPool, stdLogger),
+		repositories.NewOutboxRepository(testPool, stdLogger, outboxcfg.Config{Schema: "feed"}),
+		manager,
+		services.ServedEventConfig{Enabled: true},
+		stdLogger,
+	)
+}
+
+type outboxRow struct {
+	EventID       uuid.UUID
+	AggregateType string
+	AggregateID   uuid.UUID
+	EventType     string
+	Payload       []byte
+}
+
+func listOutbox(ctx context.Context, t *testing.T) []outboxRow {
+	t.Helper()
+	rows, err := testPool.Query(ctx, `
+		SELECT event_id, aggregate_type, aggregate_id, event_type, payload
+		FROM feed.outbox_events
+		ORDER BY occurred_at, event_type`)
+	require.NoError(t, err)
+	defer rows.Close()
+	var out []outboxRow
+	for rows.Next() {
+		var row outboxRow
+		require.NoError(t, rows.Scan(&row.EventID, &row.AggregateType, &row.AggregateID, &row.EventType, &row.Payload))
+		out = append(out, row)
+	}
+	require.NoError(t, rows.Err())
+	return out
+}
+
+func TestFeedService_ServedEventSharesOrderingKeyWithInteractions(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	videoRepo := repositories.NewFeedVideoProjectionRepository(testPool, stdLogger)
+	provider := &stubRecommendationProvider{source: "stub"}
+	videos := []uuid.UUID{uuid.New(), uuid.New()}
+	for i, id := range videos {
+		require.NoError(t, videoRepo.Upsert(ctx, nil, repositories.UpsertFeedVideoProjectionInput{VideoID: id, Title: "Video", Version: int64(i + 1)}))
+		provider.items = append(provider.items, services.RecommendationItem{VideoID: id.String(), Reason: "stub", Score: float64(2 - i)})
+	}
+	writer, _ := services.NewRecommendationLogWriter(newServedEventStore(t), repositories.NewServedPageRepository(testPool, stdLogger), services.RecommendationLogWriterConfig{}, stdLogger)
+	service := services.NewFeedService(provider, services.NewGuestRecommendationProvider(services.NewFreshRecommendationProvider(videoRepo, stdLogger), videoRepo, stdLogger), videoRepo, writer,
+		repositories.NewFeedIdempotencyRepository(testPool, stdLogger), testHasher, nil, newInteractionRecorder(), nil, nil, nil, nil, nil, nil,
+		nil, services.FeedServiceConfig{IdempotencyTTL: time.Minute}, stdLogger)
+
+	resp, err := service.GetFeed(ctx, services.GetFeedInput{UserID: "user-served", Limit: 2, Scene: "home"})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.LogID)
+
+	events := listOutbox(ctx, t)
+	require.Len(t, events, 1)
+	served := events[0]
+	require.Equal(t, "feed.served", served.EventType)
+	require.Equal(t, services.ServedEventID(uuid.MustParse(resp.LogID)), served.EventID)
+	require.Equal(t, "feed_user", served.AggregateType)
+
+	var payload eventsv1.FeedServedEvent
+	require.NoError(t, proto.Unmarshal(served.Payload, &payload))
+	require.Equal(t, resp.LogID, payload.GetLogId())
+	require.Equal(t, testHasher.Hash("user-served").Hash, payload.GetUserIdHash())
+	require.Equal(t, "home", payload.GetScene())
+	require.Equal(t, int32(1), payload.GetPage())
+	require.Len(t, payload.GetItems(), 2)
+	require.Equal(t, videos[1].String(), payload.GetItems()[1].GetVideoId())
+	require.Equal(t, int32(2), payload.GetItems()[1].GetPosition())
+	require.Equal(t, int64(2), payload.GetItems()[1].GetProjectionVersion())
+	require.Equal(t, "stub", payload.GetItems()[1].GetReason())
+
+	res, err := service.ReportInteractions(ctx, services.ReportInteractionsInput{
+		UserID: "user-served",
+		Events: []services.InteractionInput{{
+			EventID: uuid.NewString(), Type: services.InteractionClick, LogID: resp.LogID, VideoID: videos[0].String(), OccurredAt: time.Now().UTC(),
+		}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Accepted)
+
+	events = listOutbox(ctx, t)
+	require.Len(t, events, 2)
+	for _, evt := range events {
+		require.Equal(t, served.AggregateID, evt.AggregateID)
+	}
+}
+
+func TestServedEventLogStore_BatchWritesLogsAndEventsTogether(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	store := newServedEventStore(t)
+
+	hash := "hash-batch"
+	failedKind := "recommendation_unavailable"
+	ok := po.NewFeedRecommendationLog(po.FeedRecommendationLogParams{UserIDHash: hash, RequestLimit: 1, RecommendationSource: "stub"})
+	ok.ServedItems = []po.ServedItemLog{{VideoID: uuid.NewString(), Position: 1, ProjectionVersion: 1}}
+	failed := po.NewFeedRecommendationLog(po.FeedRecommendationLogParams{UserIDHash: hash, RequestLimit: 1, RecommendationSource: "stub", ErrorKind: failedKind})
+	guest := po.NewFeedRecommendationLog(po.FeedRecommendationLogParams{Guest: true, RequestLimit: 1, RecommendationSource: "guest"})
+	malformed := po.NewFeedRecommendationLog(po.FeedRecommendationLogParams{LogID: "not-a-uuid", UserIDHash: hash, RequestLimit: 1, RecommendationSource: "stub"})
+
+	// 无法编码事件的条目被跳过，同批其余日志照常写入。
+	written, err := store.InsertBatch(ctx, nil, []po.FeedRecommendationLog{ok, malformed, failed, guest})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, written)
+
+	// 失败请求不产出 served 事件；访客按推荐日志聚合。
+	events := listOutbox(ctx, t)
+	require.Len(t, events, 2)
+	byType := map[string]outboxRow{}
+	for _, evt := range events {
+		byType[evt.AggregateType] = evt
+	}
+	require.Contains(t, byType, "feed_user")
+	require.Contains(t, byType, "recommendation_log")
+	require.Equal(t, services.ServedEventID(byType["recommendation_log"].AggregateID), byType["recommendation_log"].EventID)
+}
+
+func TestServedEventLogStore_RollsBackLogWhenEnqueueFails(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+	store := newServedEventStore(t)
+
+	logID := uuid.New()
+	_, err := testPool.Exec(ctx, `
+		INSERT INTO feed.outbox_events (event_id, aggregate_type, aggregate_id, event_type, payload, headers)
+		VALUES ($1, 'recommendation_log', $2, 'feed.served', '\x00', '{}')`, services.ServedEventID(logID), logID)
+	require.NoError(t, err)
+
+	entry := po.NewFeedRecommendationLog(po.FeedRecommendationLogParams{LogID: logID.String(), Guest: true, RequestLimit: 1, RecommendationSource: "guest"})
+	require.Error(t, store.Insert(ctx, nil, entry))
+
+	var count int
+	require.NoError(t, testPool.QueryRow(ctx, `SELECT count(*) FROM feed.recommendation_logs WHERE log_id = $1`, logID).Scan(&count))
+	require.Zero(t, count)
+}
+
+func TestServedEventLogStore_InteractionReusingLogIDDoesNotBlockBatch(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	videoRepo := repositories.NewFeedVideoProjectionRepository(testPool, stdLogger)
+	videoID := uuid.New()
+	require.NoError(t, videoRepo.Upsert(ctx, nil, repositories.UpsertFeedVideoProjectionInput{VideoID: videoID, Title: "Video", Version: 1}))
+	provider := &stubRecommendationProvider{source: "stub", items: []services.RecommendationItem{{VideoID: videoID.String(), Reason: "stub"}}}
+	// 异步写入且不定时刷盘：上报发生在日志落库之前。
+	writer, cleanup := services.NewRecommendationLogWriter(newServedEventStore(t), repositories.NewServedPageRepository(testPool, stdLogger),
+		services.RecommendationLogWriterConfig{Async: true, FlushInterval: time.Hour}, stdLogger)
+	service := services.NewFeedService(provider, nil, videoRepo, writer,
+		repositories.NewFeedIdempotencyRepository(testPool, stdLogger), testHasher, nil, newInteractionRecorder(), nil, nil, nil, nil, nil, nil,
+		nil, services.FeedServiceConfig{}, stdLogger)
+
+	resp, err := service.GetFeed(ctx, services.GetFeedInput{UserID: "user-race", Limit: 1})
+	require.NoError(t, err)
+	logID := uuid.MustParse(resp.LogID)
+
+	// 以 log_id 或派生的 served 事件 ID 作为交互事件 ID 均被拒绝，不写入 Outbox。
+	res, err := service.ReportInteractions(ctx, services.ReportInteractionsInput{
+		UserID: "user-race",
+		Events: []services.InteractionInput{
+			{EventID: resp.LogID, Type: services.InteractionImpression, LogID: resp.LogID, VideoID: videoID.String(), OccurredAt: time.Now().UTC()},
+			{EventID: services.ServedEventID(logID).String(), Type: services.InteractionImpression, LogID: resp.LogID, VideoID: videoID.String(), OccurredAt: time.Now().UTC()},
+		},
+	})
+	require.NoError(t, err)
+	require.Zero(t, res.Accepted)
+	for _, result := range res.Results {
+		require.Equal(t, services.RejectReasonEventIDReserved, result.Reason)
+	}
+	require.Empty(t, listOutbox(ctx, t))
+
+	// 即便 log_id 已被其他事件占用，served 事件也使用派生 ID，日志与事件照常落库。
+	_, err = testPool.Exec(ctx, `
+		INSERT INTO feed.outbox_events (event_id, aggregate_type, aggregate_id, event_type, payload, headers)
+		VALUES ($1, 'recommendation_log', $1, 'feed.click', '\x00', '{}')`, logID)
+	require.NoError(t, err)
+	cleanup()
+
+	var logs int
+	require.NoError(t, testPool.QueryRow(ctx, `SELECT count(*) FROM feed.recommendation_logs WHERE log_id = $1`, logID).Scan(&logs))
+	require.Equal(t, 1, logs)
+	var served int
+	require.NoError(t, testPool.QueryRow(ctx, `SELECT count(*) FROM feed.outbox_events WHERE event_id = $1`, services.ServedEventID(logID)).Scan(&served))
+	require.Equal(t, 1, served)
+}
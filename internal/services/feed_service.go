@@ -2,43 +2,130 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
-	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
 	"github.com/bionicotaku/lingo-services-feed/internal/models/vo"
+	"github.com/bionicotaku/lingo-services-feed/internal/pseudonym"
 	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
 )
 
 // GetFeedInput 描述获取 Feed 所需的参数。
 type GetFeedInput struct {
-	UserID string
-	Limit  int
+	UserID         string
+	Limit          int
+	IdempotencyKey string
+	// Guest 为 true 时表示匿名访客请求，UserID 为空，GuestID 为设备派生的伪 ID。
+	Guest   bool
+	GuestID string
+	// Scene 为推荐场景，空值表示默认场景；登记了场景 Provider 的场景改走对应 Provider。
+	Scene string
+	// Cursor 为上一页返回的 next_cursor，空值表示第一页。
+	Cursor string
+	// Page 为游标分页页码（从 1 开始），由 Controller 从游标解出，仅写入推荐日志与 feed.served 事件；
+	// 0 表示未知：不带游标时按首页记录，带游标时不记录页码。
+	Page int
+	// RequestID 为上游透传的请求 ID，仅写入推荐日志。
+	RequestID string
+	// Locale 与 Segment 为网关透传的语言区域与用户分群，用于匹配运营干预规则。
+	Locale  string
+	Segment string
+	// ForcedVariants 为 QA 经 x-md-experiment-variants 指定的实验分组，仅在配置允许时生效。
+	ForcedVariants map[string]string
+}
+
+// GuestRateLimiter 按访客 ID 判定访客 Feed 请求是否放行，拒绝时返回建议的重试间隔。
+type GuestRateLimiter interface {
+	AllowGuest(ctx context.Context, guestID string) (bool, time.Duration)
+}
+
+// FeedServiceConfig 控制 FeedService 的可选行为。
+type FeedServiceConfig struct {
+	// IdempotencyTTL 为幂等快照的保留时长，0 表示关闭幂等重放。
+	IdempotencyTTL time.Duration
+	// GuestCacheTTL 为访客 Feed 缓存时长，0 表示不缓存。
+	GuestCacheTTL time.Duration
+	// GuestRateLimit 为访客流量共享的每秒请求数，0 表示不限流；作为按访客 ID 限流之外的全局兜底。
+	GuestRateLimit float64
+	// GuestBurst 为访客令牌桶容量。
+	GuestBurst int
 }
 
 // FeedService 是 Feed MVP 的主用例，后续步骤会注入推荐 Provider 与投影仓储。
 type FeedService struct {
 	recommendations RecommendationProvider
+	guest           RecommendationProvider
 	projections     *repositories.FeedVideoProjectionRepository
-	logs            *repositories.FeedRecommendationLogRepository
+	logs            *RecommendationLogWriter
+	snapshots       *repositories.FeedIdempotencyRepository
+	cfg             FeedServiceConfig
+	guestCache      *guestFeedCache
+	guestLimiter    *rate.Limiter
+	guestBuckets    GuestRateLimiter
+	hasher          *pseudonym.Hasher
+	sampler         RecommendationLogSampler
+	interactions    *InteractionRecorder
+	userState       *UserStateHydrator
+	watched         *WatchedFilter
+	reranker        *RerankPipeline
+	curation        *CurationEngine
+	experiments     *ExperimentAssigner
+	scenes          SceneProviders
 	log             *log.Helper
 }
 
-// NewFeedService 构造 FeedService。
-func NewFeedService(recommendations RecommendationProvider, projections *repositories.FeedVideoProjectionRepository, logs *repositories.FeedRecommendationLogRepository, logger log.Logger) *FeedService {
-	return &FeedService{
+// NewFeedService 构造 FeedService。guest 为空时访客请求回退到主推荐 Provider；
+// hasher 为空时推荐日志与结构化日志均不记录用户标识，幂等快照也不启用（快照按用户哈希存储）；sampler 为空时推荐日志全量记录；
+// interactions 为空时 ReportInteractions 返回 ErrInteractionsDisabled；userState 为空时卡片不带用户状态；
+// watched 为空时不过滤已看过的视频；reranker 为空时不做多样性重排；curation 为空时不执行运营干预规则；
+// experiments 为空时不分配实验分组；scenes 中未登记的场景走主推荐 Provider；guestBuckets 为空时访客只受全局令牌桶限制。
+func NewFeedService(recommendations RecommendationProvider, guest *GuestRecommendationProvider, projections *repositories.FeedVideoProjectionRepository, logs *RecommendationLogWriter, snapshots *repositories.FeedIdempotencyRepository, hasher *pseudonym.Hasher, sampler RecommendationLogSampler, interactions *InteractionRecorder, userState *UserStateHydrator, watched *WatchedFilter, reranker *RerankPipeline, curation *CurationEngine, experiments *ExperimentAssigner, scenes SceneProviders, guestBuckets GuestRateLimiter, cfg FeedServiceConfig, logger log.Logger) *FeedService {
+	svc := &FeedService{
 		recommendations: recommendations,
+		guest:           recommendations,
 		projections:     projections,
 		logs:            logs,
+		snapshots:       snapshots,
+		cfg:             cfg,
+		guestCache:      newGuestFeedCache(cfg.GuestCacheTTL),
+		hasher:          hasher,
+		sampler:         sampler,
+		interactions:    interactions,
+		userState:       userState,
+		watched:         watched,
+		reranker:        reranker,
+		curation:        curation,
+		experiments:     experiments,
+		scenes:          scenes,
+		guestBuckets:    guestBuckets,
 		log:             log.NewHelper(logger),
 	}
+	if guest != nil {
+		svc.guest = guest
+	}
+	if sampler == nil {
+		svc.sampler = alwaysLogSampler{}
+	}
+	if cfg.GuestRateLimit > 0 {
+		burst := cfg.GuestBurst
+		if burst <= 0 {
+			burst = int(cfg.GuestRateLimit)
+		}
+		svc.guestLimiter = rate.NewLimiter(rate.Limit(cfg.GuestRateLimit), max(burst, 1))
+	}
+	return svc
 }
 
-// GetFeed 返回推荐结果。
+// GetFeed 返回推荐结果。携带幂等键的重试请求会重放首次返回的同一页。
 func (s *FeedService) GetFeed(ctx context.Context, input GetFeedInput) (*vo.FeedResponse, error) {
 	limit := input.Limit
 	if limit <= 0 {
@@ -47,49 +134,289 @@ func (s *FeedService) GetFeed(ctx context.Context, input GetFeedInput) (*vo.Feed
 	if limit > 100 {
 		limit = 100
 	}
+	reqCtx := requestContextFromInput(input)
+	if input.Guest {
+		return s.getGuestFeed(ctx, limit, input.GuestID, reqCtx)
+	}
+	// 分桶是确定性的，幂等重放时重新分配得到与首次请求相同的分组。
+	reqCtx.experiments = s.experiments.Assign(ctx, input.UserID, input.ForcedVariants)
+	idempotencyKey := ""
+	if s.idempotencyEnabled() && input.UserID != "" {
+		idempotencyKey = strings.TrimSpace(input.IdempotencyKey)
+	}
+	requestHash := ""
+	if idempotencyKey != "" {
+		requestHash = idempotencyRequestHash(reqCtx.Scene, strings.TrimSpace(input.Cursor), limit)
+		if snapshot := s.loadSnapshot(ctx, input.UserID, idempotencyKey); snapshot != nil {
+			return s.replaySnapshot(ctx, input.UserID, reqCtx, idempotencyKey, limit, requestHash, snapshot)
+		}
+	}
+
+	provider := s.providerForScene(reqCtx.Scene, reqCtx.experiments)
 	startedAt := time.Now()
-	recResult, err := s.recommendations.GetFeed(ctx, RecommendationInput{
-		UserID: input.UserID,
-		Limit:  limit,
+	recResult, err := provider.GetFeed(ctx, RecommendationInput{
+		UserID:      input.UserID,
+		Limit:       limit,
+		Scene:       reqCtx.Scene,
+		Cursor:      strings.TrimSpace(input.Cursor),
+		Experiments: reqCtx.experiments.Variants(),
 	})
 	latencyMs := millisOrZero(time.Since(startedAt))
-	source := s.resolveRecommendationSource(recResult)
+	source := resolveRecommendationSource(provider, recResult)
 	if err != nil {
 		s.logRecommendation(ctx, recommendationLogParams{
 			UserID:           input.UserID,
+			requestContext:   reqCtx,
 			Limit:            limit,
 			Source:           source,
 			LatencyMs:        latencyMs,
 			RecommendedItems: nil,
 			MissingVideoIDs:  nil,
 			ErrorKind:        errorKindFromError(err),
+			IdempotencyKey:   idempotencyKey,
 			GeneratedAt:      time.Now().UTC(),
 		})
 		return nil, err
 	}
-	resp := &vo.FeedResponse{
-		GeneratedAt: time.Now().UTC(),
+	var (
+		recItems   []RecommendationItem
+		nextCursor string
+	)
+	if recResult != nil {
+		recItems = recResult.Items
+		nextCursor = recResult.NextCursor
 	}
-	if recResult == nil || len(recResult.Items) == 0 {
+	recItems = s.curate(ctx, reqCtx, false, strings.TrimSpace(input.Cursor) == "", recItems, limit)
+	recommendedLogItems := toRecommendedLogItems(recItems)
+	resp, missingIDs, err := s.hydrate(ctx, recItems)
+	if err != nil {
 		s.logRecommendation(ctx, recommendationLogParams{
 			UserID:           input.UserID,
+			requestContext:   reqCtx,
 			Limit:            limit,
 			Source:           source,
 			LatencyMs:        latencyMs,
-			RecommendedItems: toRecommendedLogItems(nil),
-			MissingVideoIDs:  nil,
+			RecommendedItems: recommendedLogItems,
+			MissingVideoIDs:  missingIDs,
+			ErrorKind:        "projection_error",
+			IdempotencyKey:   idempotencyKey,
 			GeneratedAt:      resp.GeneratedAt,
 		})
-		return resp, nil
+		return nil, err
+	}
+	resp.NextCursor = nextCursor
+	var watchedIDs []string
+	resp.Items, watchedIDs = s.filterWatched(ctx, input.UserID, reqCtx.Scene, resp.Items)
+	resp.Items = s.rerank(ctx, reqCtx, resp.Items)
+	tagExperiments(resp.Items, reqCtx.experiments)
+	if idempotencyKey != "" {
+		// 并发的同键请求只有一个能写入快照，落败方改为重放胜出方的结果。
+		digest := s.hasher.Hash(input.UserID)
+		if !s.saveSnapshot(ctx, po.FeedIdempotencySnapshot{
+			UserIDHash:           digest.Hash,
+			UserIDKeyVersion:     digest.KeyVersion,
+			IdempotencyKey:       idempotencyKey,
+			RequestLimit:         int32(limit),
+			RecommendationSource: source,
+			Items:                recommendedLogItems,
+			NextCursor:           optionalCursor(resp.NextCursor),
+			ExpiresAt:            time.Now().UTC().Add(s.cfg.IdempotencyTTL),
+			RequestHash:          requestHash,
+		}) {
+			if snapshot := s.loadSnapshot(ctx, input.UserID, idempotencyKey); snapshot != nil {
+				return s.replaySnapshot(ctx, input.UserID, reqCtx, idempotencyKey, limit, requestHash, snapshot)
+			}
+		}
+	}
+	s.userState.Apply(ctx, input.UserID, resp.Items)
+	resp.LogID = s.recordServed(ctx, recommendationLogParams{
+		UserID:           input.UserID,
+		requestContext:   reqCtx,
+		Limit:            limit,
+		Source:           source,
+		LatencyMs:        latencyMs,
+		RecommendedItems: recommendedLogItems,
+		MissingVideoIDs:  missingIDs,
+		Missing:          resp.MissingProjections,
+		WatchedVideoIDs:  watchedIDs,
+		ServedItems:      toServedLogItems(resp.Items),
+		IdempotencyKey:   idempotencyKey,
+		GeneratedAt:      resp.GeneratedAt,
+	})
+	return resp, nil
+}
+
+// getGuestFeed 走非个性化推荐链，结果按 limit、场景与语言区域共享缓存。
+//
+// 访客先按访客 ID 计入独立令牌桶，单个设备无法耗尽全体访客的配额；通过后再计入全局令牌桶兜底总量。
+func (s *FeedService) getGuestFeed(ctx context.Context, limit int, guestID string, reqCtx requestContext) (*vo.FeedResponse, error) {
+	if s.guestBuckets != nil {
+		if allowed, retryAfter := s.guestBuckets.AllowGuest(ctx, guestID); !allowed {
+			limited := *ErrGuestRateLimited
+			if retryAfter > 0 {
+				limited.RetryAfter = retryAfter
+			}
+			return nil, &limited
+		}
+	}
+	if s.guestLimiter != nil && !s.guestLimiter.Allow() {
+		return nil, ErrGuestRateLimited
+	}
+	now := time.Now().UTC()
+	cacheKey := s.guestCacheKey(limit, reqCtx)
+	if entry, ok := s.guestCache.get(cacheKey, now); ok {
+		// 缓存的是值拷贝，各请求写入自己的 LogID 不会相互覆盖。
+		resp := entry.resp
+		resp.LogID = s.recordServed(ctx, recommendationLogParams{
+			requestContext:   reqCtx,
+			Limit:            limit,
+			Source:           entry.source,
+			RecommendedItems: entry.recommended,
+			MissingVideoIDs:  entry.missingIDs,
+			Missing:          resp.MissingProjections,
+			ServedItems:      toServedLogItems(resp.Items),
+			Guest:            true,
+			GeneratedAt:      now,
+		})
+		return &resp, nil
+	}
+
+	startedAt := time.Now()
+	recResult, err := s.guest.GetFeed(ctx, RecommendationInput{Limit: limit})
+	latencyMs := millisOrZero(time.Since(startedAt))
+	source := s.guest.Source()
+	if recResult != nil && recResult.Source != "" {
+		source = recResult.Source
+	}
+	if err != nil {
+		s.logRecommendation(ctx, recommendationLogParams{
+			requestContext: reqCtx,
+			Limit:          limit,
+			Source:         source,
+			LatencyMs:      latencyMs,
+			ErrorKind:      errorKindFromError(err),
+			Guest:          true,
+			GeneratedAt:    time.Now().UTC(),
+		})
+		return nil, err
+	}
+	var recItems []RecommendationItem
+	if recResult != nil {
+		recItems = recResult.Items
+	}
+	recItems = s.curate(ctx, reqCtx, true, true, recItems, limit)
+	recommendedLogItems := toRecommendedLogItems(recItems)
+	resp, missingIDs, err := s.hydrate(ctx, recItems)
+	params := recommendationLogParams{
+		requestContext:   reqCtx,
+		Limit:            limit,
+		Source:           source,
+		LatencyMs:        latencyMs,
+		RecommendedItems: recommendedLogItems,
+		MissingVideoIDs:  missingIDs,
+		Guest:            true,
+		GeneratedAt:      resp.GeneratedAt,
+	}
+	if err != nil {
+		params.ErrorKind = "projection_error"
+		s.logRecommendation(ctx, params)
+		return nil, err
+	}
+	resp.Items = s.rerank(ctx, reqCtx, resp.Items)
+	s.guestCache.put(cacheKey, guestCacheEntry{
+		resp:        *resp,
+		source:      source,
+		recommended: recommendedLogItems,
+		missingIDs:  missingIDs,
+	}, now)
+	params.Missing = resp.MissingProjections
+	params.ServedItems = toServedLogItems(resp.Items)
+	resp.LogID = s.recordServed(ctx, params)
+	return resp, nil
+}
+
+// guestCacheKey 以规范化后的场景与语言区域构造访客缓存键：登记了 Provider 的场景保留原值（不做重排），
+// 其余取值映射到运营规则中出现过的场景与语言，访客无法以任意请求头撑大缓存。
+func (s *FeedService) guestCacheKey(limit int, reqCtx requestContext) guestCacheKey {
+	scene, locale := s.curation.CacheScope(reqCtx.Scene, reqCtx.Locale)
+	if _, routed := s.scenes[reqCtx.Scene]; routed {
+		scene = reqCtx.Scene
+	}
+	return guestCacheKey{limit: limit, scene: scene, locale: locale}
+}
+
+// replaySnapshot 按快照中的视频顺序重新补水，返回与首次请求相同的一页。
+// 请求指纹与快照不一致时返回 ErrIdempotencyKeyMismatch；历史快照没有指纹，仅校验 limit。
+func (s *FeedService) replaySnapshot(ctx context.Context, userID string, reqCtx requestContext, key string, limit int, requestHash string, snapshot *po.FeedIdempotencySnapshot) (*vo.FeedResponse, error) {
+	if snapshot.RequestHash != "" && snapshot.RequestHash != requestHash {
+		return nil, ErrIdempotencyKeyMismatch
+	}
+	if int(snapshot.RequestLimit) != limit {
+		return nil, ErrIdempotencyKeyMismatch
+	}
+	recItems := make([]RecommendationItem, 0, len(snapshot.Items))
+	for _, item := range snapshot.Items {
+		recItems = append(recItems, RecommendationItem{
+			VideoID:  item.VideoID,
+			Reason:   item.Reason,
+			Score:    item.Score,
+			Metadata: cloneStringMap(item.Meta),
+		})
+	}
+	resp, missingIDs, err := s.hydrate(ctx, recItems)
+	params := recommendationLogParams{
+		UserID:           userID,
+		requestContext:   reqCtx,
+		Limit:            limit,
+		Source:           snapshot.RecommendationSource,
+		RecommendedItems: snapshot.Items,
+		MissingVideoIDs:  missingIDs,
+		IdempotencyKey:   key,
+		Replayed:         true,
+		GeneratedAt:      resp.GeneratedAt,
+	}
+	if err != nil {
+		params.ErrorKind = "projection_error"
+		s.logRecommendation(ctx, params)
+		return nil, err
+	}
+	if snapshot.NextCursor != nil {
+		resp.NextCursor = *snapshot.NextCursor
+	}
+	// 快照保存的是过滤前的推荐条目，重放时按同一规则重新过滤。
+	resp.Items, params.WatchedVideoIDs = s.filterWatched(ctx, userID, reqCtx.Scene, resp.Items)
+	resp.Items = s.rerank(ctx, reqCtx, resp.Items)
+	tagExperiments(resp.Items, reqCtx.experiments)
+	s.userState.Apply(ctx, userID, resp.Items)
+	params.Missing = resp.MissingProjections
+	params.ServedItems = toServedLogItems(resp.Items)
+	resp.LogID = s.recordServed(ctx, params)
+	return resp, nil
+}
+
+// ReportInteractions 校验客户端上报的曝光/点击/刷新事件，并在同一事务内写入 Outbox 等待发布。
+func (s *FeedService) ReportInteractions(ctx context.Context, input ReportInteractionsInput) (*ReportInteractionsResult, error) {
+	if s.interactions == nil {
+		return nil, ErrInteractionsDisabled
+	}
+	return s.interactions.Record(ctx, input)
+}
+
+// hydrate 根据推荐条目读取投影并组装响应，返回缺失的视频 ID 列表。
+func (s *FeedService) hydrate(ctx context.Context, recItems []RecommendationItem) (*vo.FeedResponse, []string, error) {
+	resp := &vo.FeedResponse{
+		GeneratedAt: time.Now().UTC(),
 	}
-	recommendedLogItems := toRecommendedLogItems(recResult.Items)
-	videoIDs := make([]uuid.UUID, 0, len(recResult.Items))
+	if len(recItems) == 0 {
+		return resp, nil, nil
+	}
+	videoIDs := make([]uuid.UUID, 0, len(recItems))
 	missing := make([]vo.MissingProjection, 0)
 	missingIDs := make([]string, 0)
-	for _, item := range recResult.Items {
+	for _, item := range recItems {
 		id, parseErr := uuid.Parse(item.VideoID)
 		if parseErr != nil {
-			missing = append(missing, vo.MissingProjection{VideoID: item.VideoID, Reason: "invalid video id"})
+			missing = append(missing, vo.MissingProjection{VideoID: item.VideoID, Reason: vo.MissingReasonInvalidVideoID})
 			missingIDs = append(missingIDs, item.VideoID)
 			continue
 		}
@@ -99,17 +426,7 @@ func (s *FeedService) GetFeed(ctx context.Context, input GetFeedInput) (*vo.Feed
 	if len(videoIDs) > 0 {
 		records, repoErr := s.projections.ListByIDs(ctx, nil, videoIDs)
 		if repoErr != nil {
-			s.logRecommendation(ctx, recommendationLogParams{
-				UserID:           input.UserID,
-				Limit:            limit,
-				Source:           source,
-				LatencyMs:        latencyMs,
-				RecommendedItems: recommendedLogItems,
-				MissingVideoIDs:  missingIDs,
-				ErrorKind:        "projection_error",
-				GeneratedAt:      resp.GeneratedAt,
-			})
-			return nil, fmt.Errorf("list projections: %w", repoErr)
+			return resp, missingIDs, wrapFeedError(ErrProjectionUnavailable, repoErr)
 		}
 		for _, record := range records {
 			if record == nil {
@@ -119,60 +436,252 @@ func (s *FeedService) GetFeed(ctx context.Context, input GetFeedInput) (*vo.Feed
 			projections[record.VideoID] = &item
 		}
 	}
-	items := make([]vo.FeedItem, 0, len(recResult.Items))
-	for _, rec := range recResult.Items {
+	items := make([]vo.FeedItem, 0, len(recItems))
+	for _, rec := range recItems {
 		if feedItem, ok := projections[rec.VideoID]; ok {
 			feedItem.ApplyRecommendation(rec.Reason, rec.Metadata, rec.Score)
 			items = append(items, *feedItem)
 			continue
 		}
-		missing = append(missing, vo.MissingProjection{VideoID: rec.VideoID, Reason: "projection missing"})
+		missing = append(missing, vo.MissingProjection{VideoID: rec.VideoID, Reason: vo.MissingReasonProjection})
 		missingIDs = append(missingIDs, rec.VideoID)
 	}
 	resp.Items = items
 	resp.MissingProjections = missing
 	resp.Partial = len(missing) > 0
-	s.logRecommendation(ctx, recommendationLogParams{
-		UserID:           input.UserID,
-		Limit:            limit,
-		Source:           source,
-		LatencyMs:        latencyMs,
-		RecommendedItems: recommendedLogItems,
-		MissingVideoIDs:  missingIDs,
-		GeneratedAt:      resp.GeneratedAt,
+	return resp, missingIDs, nil
+}
+
+// providerForScene 返回场景登记的 Provider，未登记时返回实验分组覆盖的推荐链或主推荐 Provider。
+func (s *FeedService) providerForScene(scene string, decision ExperimentDecision) RecommendationProvider {
+	if provider, ok := s.scenes[scene]; ok {
+		return provider
+	}
+	if provider := decision.Provider(); provider != nil {
+		return provider
+	}
+	return s.recommendations
+}
+
+// filterWatched 对主推荐链的结果执行观看过滤；场景 Provider 基于本地状态自行选材，不再过滤。
+func (s *FeedService) filterWatched(ctx context.Context, userID, scene string, items []vo.FeedItem) ([]vo.FeedItem, []string) {
+	if _, routed := s.scenes[scene]; routed {
+		return items, nil
+	}
+	// 运营置顶是显式决定，不受观看过滤影响，并保持在置顶位次。
+	var dropped []string
+	items = holdPinned(items, func(rest []vo.FeedItem) []vo.FeedItem {
+		rest, dropped = s.watched.Apply(ctx, userID, scene, rest)
+		return rest
 	})
-	return resp, nil
+	return items, dropped
+}
+
+// curate 执行运营干预规则：屏蔽对所有结果生效，置顶与提权仅作用于主推荐链的第一页。
+func (s *FeedService) curate(ctx context.Context, reqCtx requestContext, guest, firstPage bool, items []RecommendationItem, limit int) []RecommendationItem {
+	if s.curation == nil {
+		return items
+	}
+	_, routed := s.scenes[reqCtx.Scene]
+	scope := CurationScope{
+		Scene:   reqCtx.Scene,
+		Locale:  reqCtx.Locale,
+		Reorder: firstPage && !routed,
+	}
+	if guest {
+		scope.Segments = []string{CurationSegmentGuest}
+	} else {
+		scope.Segments = []string{CurationSegmentUser}
+		if reqCtx.Segment != "" {
+			scope.Segments = append(scope.Segments, reqCtx.Segment)
+		}
+	}
+	return s.curation.Apply(ctx, scope, items, limit)
+}
+
+// rerank 对主推荐链的结果执行多样性重排，实验分组覆盖了重排配置时改用分组的流水线；
+// 场景 Provider 的顺序（如复习到期时间）有业务含义，保持原样。
+func (s *FeedService) rerank(ctx context.Context, reqCtx requestContext, items []vo.FeedItem) []vo.FeedItem {
+	if _, routed := s.scenes[reqCtx.Scene]; routed {
+		return items
+	}
+	if reqCtx.experiments.rerankSet {
+		return reqCtx.experiments.reranker.Apply(ctx, items)
+	}
+	return s.reranker.Apply(ctx, items)
+}
+
+// tagExperiments 把命中的实验分组写入每张卡片的 attributes（exp.<experiment>=<variant>），便于客户端埋点回传。
+func tagExperiments(items []vo.FeedItem, decision ExperimentDecision) {
+	attrs := decision.Attributes()
+	if len(attrs) == 0 {
+		return
+	}
+	for i := range items {
+		if items[i].Attributes == nil {
+			items[i].Attributes = make(map[string]string, len(attrs))
+		}
+		for k, v := range attrs {
+			items[i].Attributes[k] = v
+		}
+	}
+}
+
+func (s *FeedService) idempotencyEnabled() bool {
+	return s.snapshots != nil && s.cfg.IdempotencyTTL > 0 && s.hasher.Enabled()
+}
+
+// idempotencyRequestHash 返回幂等请求参数的指纹：sha256("<scene>\x00<cursor>\x00<limit>") 的十六进制。
+func idempotencyRequestHash(scene, cursor string, limit int) string {
+	sum := sha256.Sum256([]byte(scene + "\x00" + cursor + "\x00" + strconv.Itoa(limit)))
+	return hex.EncodeToString(sum[:])
+}
+
+// loadSnapshot 按当前密钥版本的用户哈希读取幂等快照；读取失败时降级为正常请求。
+// 密钥轮换前写入的快照不再命中，轮换窗口内的重试退化为一次普通请求。
+func (s *FeedService) loadSnapshot(ctx context.Context, userID, key string) *po.FeedIdempotencySnapshot {
+	userIDHash := s.hasher.Hash(userID).Hash
+	snapshot, err := s.snapshots.Get(ctx, nil, userIDHash, key)
+	if err != nil {
+		s.log.WithContext(ctx).Warnw("msg", "load idempotency snapshot failed", "user_id_hash", userIDHash, "error", err)
+		return nil
+	}
+	return snapshot
+}
+
+// saveSnapshot 写入幂等快照，仅在已存在未过期快照时返回 false。
+func (s *FeedService) saveSnapshot(ctx context.Context, snapshot po.FeedIdempotencySnapshot) bool {
+	inserted, err := s.snapshots.Save(ctx, nil, snapshot)
+	if err != nil {
+		s.log.WithContext(ctx).Warnw("msg", "save idempotency snapshot failed", "user_id_hash", snapshot.UserIDHash, "error", err)
+		return true
+	}
+	return inserted
+}
+
+// requestContext 汇总请求级的观测字段，随推荐日志一并落库。
+type requestContext struct {
+	Scene     string
+	Page      int
+	RequestID string
+	Locale    string
+	Segment   string
+	// experiments 为登录用户命中的实验分组，访客为零值。
+	experiments ExperimentDecision
+}
+
+func requestContextFromInput(input GetFeedInput) requestContext {
+	page := input.Page
+	if page <= 0 && strings.TrimSpace(input.Cursor) == "" {
+		page = 1
+	}
+	return requestContext{
+		Scene:     strings.TrimSpace(input.Scene),
+		Page:      page,
+		RequestID: strings.TrimSpace(input.RequestID),
+		Locale:    strings.TrimSpace(input.Locale),
+		Segment:   strings.TrimSpace(input.Segment),
+	}
 }
 
 type recommendationLogParams struct {
+	requestContext
+	// LogID 为空时由 logRecommendation 生成。
+	LogID            string
 	UserID           string
 	Limit            int
 	Source           string
 	LatencyMs        int32
 	RecommendedItems []po.RecommendedItemLog
 	MissingVideoIDs  []string
-	ErrorKind        string
-	GeneratedAt      time.Time
+	// Missing 与 WatchedVideoIDs 用于在推荐条目上标注未下发原因。
+	Missing         []vo.MissingProjection
+	WatchedVideoIDs []string
+	ServedItems     []po.ServedItemLog
+	ErrorKind       string
+	IdempotencyKey  string
+	Replayed        bool
+	Guest           bool
+	GeneratedAt     time.Time
 }
 
-func (s *FeedService) logRecommendation(ctx context.Context, params recommendationLogParams) {
+// recordServed 为返回给客户端的一页分配 log_id：交互上报启用时写入不采样的下发页记录供交互事件校验，
+// 推荐日志按采样策略写入；两者经 RecommendationLogWriter 异步批量落库，请求路径不等待数据库。
+// 两者均未写入时返回空串。
+func (s *FeedService) recordServed(ctx context.Context, params recommendationLogParams) string {
+	if !s.interactions.Enabled() || !s.logs.RecordsServedPages() {
+		return s.logRecommendation(ctx, params)
+	}
+	params.LogID = uuid.NewString()
+	digest := s.hasher.Hash(params.UserID)
+	page := &po.ServedPage{
+		LogID:                params.LogID,
+		UserIDHash:           optionalString(digest.Hash),
+		UserIDKeyVersion:     optionalString(digest.KeyVersion),
+		Guest:                params.Guest,
+		Scene:                optionalString(params.Scene),
+		RecommendationSource: firstNonEmpty(params.Source, s.recommendations.Source()),
+		ServedItems:          params.ServedItems,
+		GeneratedAt:          params.GeneratedAt,
+	}
+	s.logs.WriteServed(ctx, page, s.recommendationLogEntry(ctx, params))
+	return params.LogID
+}
+
+// logRecommendation 按采样策略写入推荐日志，返回预先生成的 log_id；未写入时返回空串。
+func (s *FeedService) logRecommendation(ctx context.Context, params recommendationLogParams) string {
+	entry := s.recommendationLogEntry(ctx, params)
+	if entry == nil {
+		return ""
+	}
+	s.logs.Write(ctx, *entry)
+	return entry.LogID
+}
+
+// recommendationLogEntry 按采样策略构造推荐日志，未启用日志或未被采样时返回 nil。
+func (s *FeedService) recommendationLogEntry(ctx context.Context, params recommendationLogParams) *po.FeedRecommendationLog {
 	if s.logs == nil {
-		return
+		return nil
 	}
 	source := firstNonEmpty(params.Source, s.recommendations.Source())
+	if !s.sampler.ShouldLog(ctx, RecommendationLogSample{
+		UserID:    params.UserID,
+		Source:    source,
+		Scene:     params.Scene,
+		ErrorKind: params.ErrorKind,
+		Partial:   len(params.MissingVideoIDs) > 0,
+		Guest:     params.Guest,
+		Replayed:  params.Replayed,
+	}) {
+		return nil
+	}
+	digest := s.hasher.Hash(params.UserID)
+	logID := params.LogID
+	if logID == "" {
+		logID = uuid.NewString()
+	}
 	entry := po.NewFeedRecommendationLog(po.FeedRecommendationLogParams{
-		UserID:                  params.UserID,
+		LogID:                   logID,
+		UserIDHash:              digest.Hash,
+		UserIDKeyVersion:        digest.KeyVersion,
 		RequestLimit:            params.Limit,
 		RecommendationSource:    source,
 		RecommendationLatencyMS: params.LatencyMs,
-		RecommendedItems:        params.RecommendedItems,
+		RecommendedItems:        withMissingReasons(params.RecommendedItems, params.Missing, params.WatchedVideoIDs),
 		MissingVideoIDs:         params.MissingVideoIDs,
 		ErrorKind:               params.ErrorKind,
+		IdempotencyKey:          params.IdempotencyKey,
+		Replayed:                params.Replayed,
+		Guest:                   params.Guest,
+		ServedItems:             params.ServedItems,
+		RequestID:               params.RequestID,
+		TraceID:                 traceIDFromContext(ctx),
+		Scene:                   params.Scene,
+		Page:                    int32(params.Page),
+		Experiments:             params.experiments.logEntries(),
 		GeneratedAt:             params.GeneratedAt,
 	})
-	if err := s.logs.Insert(ctx, nil, entry); err != nil {
-		s.log.WithContext(ctx).Warnw("msg", "write recommendation log failed", "error", err)
-	}
+	return &entry
 }
 
 func toRecommendedLogItems(items []RecommendationItem) []po.RecommendedItemLog {
@@ -191,6 +700,51 @@ func toRecommendedLogItems(items []RecommendationItem) []po.RecommendedItemLog {
 	return logs
 }
 
+// withMissingReasons 返回标注了未下发原因的推荐条目副本，原切片可能被幂等快照或访客缓存复用，不做修改。
+func withMissingReasons(items []po.RecommendedItemLog, missing []vo.MissingProjection, watched []string) []po.RecommendedItemLog {
+	if len(missing) == 0 && len(watched) == 0 {
+		return items
+	}
+	reasons := make(map[string]string, len(missing)+len(watched))
+	for _, m := range missing {
+		reasons[m.VideoID] = po.MissingReasonProjection
+		if m.Reason == vo.MissingReasonInvalidVideoID {
+			reasons[m.VideoID] = po.MissingReasonInvalidVideoID
+		}
+	}
+	for _, id := range watched {
+		reasons[id] = po.MissingReasonWatched
+	}
+	annotated := make([]po.RecommendedItemLog, len(items))
+	copy(annotated, items)
+	for i := range annotated {
+		annotated[i].MissingReason = reasons[annotated[i].VideoID]
+	}
+	return annotated
+}
+
+// toServedLogItems 按响应中的最终顺序记录实际下发的条目，位次从 1 开始。
+func toServedLogItems(items []vo.FeedItem) []po.ServedItemLog {
+	served := make([]po.ServedItemLog, 0, len(items))
+	for i, item := range items {
+		served = append(served, po.ServedItemLog{
+			VideoID:           item.VideoID,
+			Position:          int32(i + 1),
+			ProjectionVersion: item.ProjectionVersion,
+			VisibilityStatus:  item.VisibilityStatus,
+		})
+	}
+	return served
+}
+
+// traceIDFromContext 返回当前 span 的 TraceID，无有效 span 时为空。
+func traceIDFromContext(ctx context.Context) string {
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		return sc.TraceID().String()
+	}
+	return ""
+}
+
 func cloneStringMap(src map[string]string) map[string]string {
 	if len(src) == 0 {
 		return nil
@@ -202,6 +756,13 @@ func cloneStringMap(src map[string]string) map[string]string {
 	return cloned
 }
 
+func optionalCursor(cursor string) *string {
+	if cursor == "" {
+		return nil
+	}
+	return &cursor
+}
+
 func firstNonEmpty(values ...string) string {
 	for _, v := range values {
 		if strings.TrimSpace(v) != "" {
@@ -223,12 +784,12 @@ func millisOrZero(d time.Duration) int32 {
 	return int32(ms)
 }
 
-func (s *FeedService) resolveRecommendationSource(result *RecommendationResult) string {
+func resolveRecommendationSource(provider RecommendationProvider, result *RecommendationResult) string {
 	if result != nil && result.Source != "" {
 		return result.Source
 	}
-	if s.recommendations != nil {
-		return s.recommendations.Source()
+	if provider != nil {
+		return provider.Source()
 	}
 	return "unknown"
 }
@@ -237,8 +798,9 @@ func errorKindFromError(err error) string {
 	if err == nil {
 		return ""
 	}
-	if errors.Is(err, ErrRecommendationUnavailable) {
-		return "recommendation_unavailable"
+	var feedErr *FeedError
+	if errors.As(err, &feedErr) {
+		return string(feedErr.Kind)
 	}
 	return "unknown_error"
 }
@@ -7,13 +7,18 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/models/vo"
+	"github.com/bionicotaku/lingo-services-feed/internal/pseudonym"
 	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
 	"github.com/bionicotaku/lingo-services-feed/internal/services"
+	outboxcfg "github.com/bionicotaku/lingo-utils/outbox/config"
+	"github.com/bionicotaku/lingo-utils/txmanager"
 	"github.com/docker/go-connections/nat"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/uuid"
@@ -28,8 +33,23 @@ var (
 	testPool      *pgxpool.Pool
 	testContainer testcontainers.Container
 	stdLogger     = log.NewStdLogger(io.Discard)
+	testHasher    = mustHasher()
 )
 
+func mustHasher() *pseudonym.Hasher {
+	hasher, err := pseudonym.New(pseudonym.Config{
+		ActiveVersion: "v2",
+		Keys: []pseudonym.Key{
+			{Version: "v1", Secret: []byte("test-key-v1")},
+			{Version: "v2", Secret: []byte("test-key-v2")},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return hasher
+}
+
 func TestMain(m *testing.M) {
 	ctx := context.Background()
 	code := 0
@@ -113,6 +133,13 @@ func resetDatabase(t *testing.T) {
 	_, err := testPool.Exec(context.Background(), `
 		TRUNCATE TABLE
 			feed.recommendation_logs,
+			feed.served_pages,
+			feed.idempotency_snapshots,
+			feed.outbox_events,
+			feed.user_video_state,
+			feed.user_watch_history,
+			feed.review_schedule,
+			feed.curation_rules,
 			feed.videos_projection
 		RESTART IDENTITY
 	`)
@@ -120,9 +147,32 @@ func resetDatabase(t *testing.T) {
 }
 
 func newFeedService(provider services.RecommendationProvider) *services.FeedService {
+	return newFeedServiceWithConfig(provider, services.FeedServiceConfig{IdempotencyTTL: time.Minute})
+}
+
+func newFeedServiceWithConfig(provider services.RecommendationProvider, cfg services.FeedServiceConfig) *services.FeedService {
 	videoRepo := repositories.NewFeedVideoProjectionRepository(testPool, stdLogger)
 	logRepo := repositories.NewFeedRecommendationLogRepository(testPool, stdLogger)
-	return services.NewFeedService(provider, videoRepo, logRepo, stdLogger)
+	snapshotRepo := repositories.NewFeedIdempotencyRepository(testPool, stdLogger)
+	guest := services.NewGuestRecommendationProvider(services.NewFreshRecommendationProvider(videoRepo, stdLogger), videoRepo, stdLogger)
+	logWriter, _ := services.NewRecommendationLogWriter(logRepo, repositories.NewServedPageRepository(testPool, stdLogger), services.RecommendationLogWriterConfig{}, stdLogger)
+	return services.NewFeedService(provider, guest, videoRepo, logWriter, snapshotRepo, testHasher, nil, newInteractionRecorder(), nil, nil, nil, nil, nil, nil, nil, cfg, stdLogger)
+}
+
+func newInteractionRecorder() *services.InteractionRecorder {
+	manager, err := txmanager.NewManager(testPool, txmanager.Config{}, txmanager.Dependencies{Logger: stdLogger})
+	if err != nil {
+		panic(err)
+	}
+	return services.NewInteractionRecorder(
+		repositories.NewServedPageRepository(testPool, stdLogger),
+		repositories.NewFeedRecommendationLogRepository(testPool, stdLogger),
+		repositories.NewOutboxRepository(testPool, stdLogger, outboxcfg.Config{Schema: "feed"}),
+		manager,
+		testHasher,
+		services.InteractionConfig{Enabled: true, MaxBatchSize: 10, MaxEventAge: time.Hour, ClockSkew: time.Minute},
+		stdLogger,
+	)
 }
 
 type stubRecommendationProvider struct {
@@ -130,10 +180,12 @@ type stubRecommendationProvider struct {
 	err       error
 	source    string
 	lastInput services.RecommendationInput
+	calls     int
 }
 
 func (s *stubRecommendationProvider) GetFeed(_ context.Context, input services.RecommendationInput) (*services.RecommendationResult, error) {
 	s.lastInput = input
+	s.calls++
 	if s.err != nil {
 		return nil, s.err
 	}
@@ -259,6 +311,56 @@ func TestFeedService_GetFeed_PartialLogsMissing(t *testing.T) {
 	require.False(t, logEntry.errorKind.Valid)
 }
 
+func TestFeedService_GetFeed_LogsServedItemsAndRequestContext(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	videoRepo := repositories.NewFeedVideoProjectionRepository(testPool, stdLogger)
+	visibility := "public"
+	served1, missingID, served2 := uuid.New(), uuid.New(), uuid.New()
+	for i, id := range []uuid.UUID{served1, served2} {
+		require.NoError(t, videoRepo.Upsert(ctx, nil, repositories.UpsertFeedVideoProjectionInput{
+			VideoID:          id,
+			Title:            "Video",
+			VisibilityStatus: &visibility,
+			Version:          int64(i + 5),
+		}))
+	}
+	provider := &stubRecommendationProvider{
+		source: "stub",
+		items: []services.RecommendationItem{
+			{VideoID: served1.String(), Reason: "reason.a", Score: 0.9},
+			{VideoID: missingID.String(), Reason: "reason.b", Score: 0.8},
+			{VideoID: served2.String(), Reason: "reason.c", Score: 0.7},
+		},
+	}
+	service := newFeedService(provider)
+
+	_, err := service.GetFeed(ctx, services.GetFeedInput{
+		UserID:    "user-served",
+		Limit:     3,
+		Scene:     "home",
+		Page:      2,
+		RequestID: "req-served",
+	})
+	require.NoError(t, err)
+
+	logRepo := repositories.NewFeedRecommendationLogRepository(testPool, stdLogger)
+	logs, err := logRepo.List(ctx, nil, repositories.ListRecommendationLogsParams{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	entry := logs[0]
+	require.Len(t, entry.RecommendedItems, 3)
+	require.Equal(t, []po.ServedItemLog{
+		{VideoID: served1.String(), Position: 1, ProjectionVersion: 5, VisibilityStatus: visibility},
+		{VideoID: served2.String(), Position: 2, ProjectionVersion: 6, VisibilityStatus: visibility},
+	}, entry.ServedItems)
+	require.Equal(t, "home", *entry.Scene)
+	require.Equal(t, int32(2), *entry.Page)
+	require.Equal(t, "req-served", *entry.RequestID)
+	require.Nil(t, entry.TraceID)
+}
+
 func TestFeedService_GetFeed_EmptyRecommendationLogged(t *testing.T) {
 	resetDatabase(t)
 	ctx := context.Background()
@@ -339,6 +441,198 @@ func TestFeedService_GetFeed_RecommendationErrorLogged(t *testing.T) {
 	require.Empty(t, logEntry.missingVideoIDs)
 }
 
+func TestFeedService_GetFeed_IdempotencyKeyReplaysPage(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	videoRepo := repositories.NewFeedVideoProjectionRepository(testPool, stdLogger)
+	now := time.Now().UTC()
+	video1 := uuid.New()
+	video2 := uuid.New()
+	for _, id := range []uuid.UUID{video1, video2} {
+		require.NoError(t, videoRepo.Upsert(ctx, nil, repositories.UpsertFeedVideoProjectionInput{
+			VideoID:   id,
+			Title:     "Video " + id.String()[:8],
+			Version:   1,
+			UpdatedAt: &now,
+		}))
+	}
+
+	provider := &stubRecommendationProvider{
+		source: "stub",
+		items: []services.RecommendationItem{
+			{VideoID: video2.String(), Reason: "reason.b", Score: 0.8},
+			{VideoID: video1.String(), Reason: "reason.a", Score: 0.4},
+		},
+	}
+	service := newFeedService(provider)
+
+	input := services.GetFeedInput{UserID: "user-7", Limit: 2, IdempotencyKey: "retry-1"}
+	first, err := service.GetFeed(ctx, input)
+	require.NoError(t, err)
+	require.Len(t, first.Items, 2)
+
+	// 推荐结果变化后，同键重试仍返回首次的页面，且不再调用 Provider。
+	provider.items = []services.RecommendationItem{{VideoID: video1.String(), Reason: "reason.c", Score: 0.1}}
+	second, err := service.GetFeed(ctx, input)
+	require.NoError(t, err)
+	require.Equal(t, 1, provider.calls)
+	require.Len(t, second.Items, 2)
+	require.Equal(t, video2.String(), second.Items[0].VideoID)
+	require.Equal(t, "reason.b", second.Items[0].ReasonCode)
+	require.Equal(t, video1.String(), second.Items[1].VideoID)
+
+	var replayed bool
+	var key sql.NullString
+	require.NoError(t, testPool.QueryRow(ctx, `
+		SELECT replayed, idempotency_key
+		FROM feed.recommendation_logs
+		ORDER BY generated_at DESC
+		LIMIT 1
+	`).Scan(&replayed, &key))
+	require.True(t, replayed)
+	require.Equal(t, "retry-1", key.String)
+
+	// 快照只保存用户哈希，不落明文 user_id。
+	var userIDHash, keyVersion string
+	require.NoError(t, testPool.QueryRow(ctx, `
+		SELECT user_id_hash, user_id_key_version FROM feed.idempotency_snapshots WHERE idempotency_key = 'retry-1'
+	`).Scan(&userIDHash, &keyVersion))
+	digest := testHasher.Hash("user-7")
+	require.Equal(t, digest.Hash, userIDHash)
+	require.Equal(t, digest.KeyVersion, keyVersion)
+
+	_, err = service.GetFeed(ctx, services.GetFeedInput{UserID: "user-7", Limit: 5, IdempotencyKey: "retry-1"})
+	require.ErrorIs(t, err, services.ErrIdempotencyKeyMismatch)
+
+	// 同键用于不同场景或翻页游标时同样视为冲突，不重放首页。
+	_, err = service.GetFeed(ctx, services.GetFeedInput{UserID: "user-7", Limit: 2, Scene: "review", IdempotencyKey: "retry-1"})
+	require.ErrorIs(t, err, services.ErrIdempotencyKeyMismatch)
+	_, err = service.GetFeed(ctx, services.GetFeedInput{UserID: "user-7", Limit: 2, Cursor: "page-2", IdempotencyKey: "retry-1"})
+	require.ErrorIs(t, err, services.ErrIdempotencyKeyMismatch)
+	require.Equal(t, 1, provider.calls)
+
+	_, err = service.GetFeed(ctx, services.GetFeedInput{UserID: "user-8", Limit: 2, IdempotencyKey: "retry-1"})
+	require.NoError(t, err)
+	require.Equal(t, 2, provider.calls)
+}
+
+func TestFeedService_GetFeed_GuestUsesRecentAndCaches(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	videoRepo := repositories.NewFeedVideoProjectionRepository(testPool, stdLogger)
+	now := time.Now().UTC()
+	ready := "ready"
+	older := uuid.New()
+	newer := uuid.New()
+	olderPublished := now.Add(-2 * time.Hour)
+	newerPublished := now.Add(-time.Hour)
+	for id, published := range map[uuid.UUID]*time.Time{older: &olderPublished, newer: &newerPublished} {
+		require.NoError(t, videoRepo.Upsert(ctx, nil, repositories.UpsertFeedVideoProjectionInput{
+			VideoID:     id,
+			Title:       "Video " + id.String()[:8],
+			Status:      &ready,
+			PublishedAt: published,
+			Version:     1,
+			UpdatedAt:   &now,
+		}))
+	}
+
+	provider := &stubRecommendationProvider{source: "stub"}
+	service := newFeedServiceWithConfig(provider, services.FeedServiceConfig{GuestCacheTTL: time.Minute})
+
+	input := services.GetFeedInput{Limit: 2, Guest: true, GuestID: "guest:abc"}
+	first, err := service.GetFeed(ctx, input)
+	require.NoError(t, err)
+	require.Equal(t, 0, provider.calls)
+	require.Len(t, first.Items, 2)
+	require.Equal(t, newer.String(), first.Items[0].VideoID)
+	require.Equal(t, "guest.recent", first.Items[0].ReasonCode)
+
+	// 新视频发布后缓存期内仍返回缓存结果。
+	latest := uuid.New()
+	require.NoError(t, videoRepo.Upsert(ctx, nil, repositories.UpsertFeedVideoProjectionInput{
+		VideoID:     latest,
+		Title:       "Latest",
+		Status:      &ready,
+		PublishedAt: &now,
+		Version:     1,
+		UpdatedAt:   &now,
+	}))
+	second, err := service.GetFeed(ctx, input)
+	require.NoError(t, err)
+	require.Equal(t, first.Items[0].VideoID, second.Items[0].VideoID)
+
+	var (
+		userID sql.NullString
+		guest  bool
+		source string
+	)
+	require.NoError(t, testPool.QueryRow(ctx, `
+		SELECT user_id, guest, recommendation_source
+		FROM feed.recommendation_logs
+		ORDER BY generated_at DESC
+		LIMIT 1
+	`).Scan(&userID, &guest, &source))
+	require.False(t, userID.Valid)
+	require.True(t, guest)
+	require.Equal(t, "guest", source)
+}
+
+func TestFeedService_GetFeed_GuestRateLimited(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	service := newFeedServiceWithConfig(&stubRecommendationProvider{}, services.FeedServiceConfig{
+		GuestRateLimit: 0.001,
+		GuestBurst:     1,
+	})
+
+	input := services.GetFeedInput{Limit: 1, Guest: true, GuestID: "guest:abc"}
+	_, err := service.GetFeed(ctx, input)
+	require.NoError(t, err)
+	_, err = service.GetFeed(ctx, input)
+	require.ErrorIs(t, err, services.ErrGuestRateLimited)
+}
+
+// guestBucketStub 模拟按访客 ID 的令牌桶：每个访客只放行一次。
+type guestBucketStub struct {
+	seen map[string]bool
+}
+
+func (s *guestBucketStub) AllowGuest(_ context.Context, guestID string) (bool, time.Duration) {
+	if s.seen[guestID] {
+		return false, 3 * time.Second
+	}
+	s.seen[guestID] = true
+	return true, 0
+}
+
+func TestFeedService_GetFeed_GuestRateLimitedPerDevice(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	videoRepo := repositories.NewFeedVideoProjectionRepository(testPool, stdLogger)
+	logRepo := repositories.NewFeedRecommendationLogRepository(testPool, stdLogger)
+	logWriter, _ := services.NewRecommendationLogWriter(logRepo, repositories.NewServedPageRepository(testPool, stdLogger), services.RecommendationLogWriterConfig{}, stdLogger)
+	service := services.NewFeedService(&stubRecommendationProvider{}, services.NewGuestRecommendationProvider(services.NewFreshRecommendationProvider(videoRepo, stdLogger), videoRepo, stdLogger), videoRepo, logWriter,
+		repositories.NewFeedIdempotencyRepository(testPool, stdLogger), testHasher, nil, newInteractionRecorder(), nil, nil, nil, nil, nil, nil,
+		&guestBucketStub{seen: map[string]bool{}}, services.FeedServiceConfig{GuestRateLimit: 100, GuestBurst: 100}, stdLogger)
+
+	_, err := service.GetFeed(ctx, services.GetFeedInput{Limit: 1, Guest: true, GuestID: "guest:a"})
+	require.NoError(t, err)
+	_, err = service.GetFeed(ctx, services.GetFeedInput{Limit: 1, Guest: true, GuestID: "guest:a"})
+	require.ErrorIs(t, err, services.ErrGuestRateLimited)
+	var feedErr *services.FeedError
+	require.ErrorAs(t, err, &feedErr)
+	require.Equal(t, 3*time.Second, feedErr.RetryAfter)
+
+	// 单个设备超限不影响其他访客。
+	_, err = service.GetFeed(ctx, services.GetFeedInput{Limit: 1, Guest: true, GuestID: "guest:b"})
+	require.NoError(t, err)
+}
+
 type recommendationLogRow struct {
 	requestLimit     int32
 	source           string
@@ -386,3 +680,525 @@ func fetchLatestRecommendationLog(ctx context.Context, t *testing.T) recommendat
 		errorKind:        errorKind,
 	}
 }
+
+func TestFeedService_GetFeed_LogsHashedUserID(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	service := newFeedService(&stubRecommendationProvider{source: "stub"})
+	_, err := service.GetFeed(ctx, services.GetFeedInput{UserID: "user-hash", Limit: 1})
+	require.NoError(t, err)
+
+	var (
+		rawUser    sql.NullString
+		userHash   sql.NullString
+		keyVersion sql.NullString
+	)
+	require.NoError(t, testPool.QueryRow(ctx, `
+		SELECT user_id, user_id_hash, user_id_key_version
+		FROM feed.recommendation_logs
+		ORDER BY generated_at DESC
+		LIMIT 1
+	`).Scan(&rawUser, &userHash, &keyVersion))
+	require.False(t, rawUser.Valid)
+	require.Equal(t, testHasher.Hash("user-hash").Hash, userHash.String)
+	require.Equal(t, "v2", keyVersion.String)
+
+	lookup := services.NewRecommendationLogLookup(repositories.NewFeedRecommendationLogRepository(testPool, stdLogger), testHasher)
+	logs, err := lookup.ListForUser(ctx, "user-hash", repositories.ListRecommendationLogsParams{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+
+	logs, err = lookup.ListForUser(ctx, "someone-else", repositories.ListRecommendationLogsParams{Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, logs)
+}
+
+func TestRecommendationLogLookup_ListPaginates(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	service := newFeedService(&stubRecommendationProvider{source: "stub"})
+	for range 3 {
+		_, err := service.GetFeed(ctx, services.GetFeedInput{UserID: "user-page", Limit: 1})
+		require.NoError(t, err)
+	}
+	_, err := service.GetFeed(ctx, services.GetFeedInput{UserID: "user-other", Limit: 1})
+	require.NoError(t, err)
+
+	lookup := services.NewRecommendationLogLookup(repositories.NewFeedRecommendationLogRepository(testPool, stdLogger), testHasher)
+	first, err := lookup.List(ctx, services.RecommendationLogQuery{UserID: "user-page", PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, first.Logs, 2)
+	require.NotEmpty(t, first.NextPageToken)
+
+	second, err := lookup.List(ctx, services.RecommendationLogQuery{UserID: "user-page", PageSize: 2, PageToken: first.NextPageToken})
+	require.NoError(t, err)
+	require.Len(t, second.Logs, 1)
+	require.Empty(t, second.NextPageToken)
+	require.NotEqual(t, first.Logs[1].LogID, second.Logs[0].LogID)
+
+	_, err = lookup.List(ctx, services.RecommendationLogQuery{PageToken: "not-a-token"})
+	require.ErrorIs(t, err, services.ErrInvalidPageToken)
+
+	_, err = lookup.Get(ctx, uuid.NewString())
+	require.ErrorIs(t, err, services.ErrRecommendationLogNotFound)
+}
+
+func TestFeedService_GetFeed_HydratesUserState(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	videoRepo := repositories.NewFeedVideoProjectionRepository(testPool, stdLogger)
+	stateRepo := repositories.NewUserVideoStateRepository(testPool, stdLogger)
+	liked, plain := uuid.New(), uuid.New()
+	provider := &stubRecommendationProvider{source: "stub"}
+	for i, id := range []uuid.UUID{liked, plain} {
+		require.NoError(t, videoRepo.Upsert(ctx, nil, repositories.UpsertFeedVideoProjectionInput{VideoID: id, Title: "Video", Version: int64(i + 1)}))
+		provider.items = append(provider.items, services.RecommendationItem{VideoID: id.String(), Reason: "stub"})
+	}
+	now := time.Now().UTC()
+	_, err := stateRepo.UpsertEngagement(ctx, nil, repositories.UpsertEngagementInput{
+		UserID: "user-state", VideoID: liked, Kind: repositories.EngagementLike, Active: true, Version: 1, UpdatedAt: now,
+	})
+	require.NoError(t, err)
+	_, err = stateRepo.UpsertProgress(ctx, nil, repositories.UpsertProgressInput{
+		UserID: "user-state", VideoID: liked, WatchedRatio: 0.4, LastWatchedAt: &now, Version: 1, UpdatedAt: now,
+	})
+	require.NoError(t, err)
+
+	hydrator := services.NewUserStateHydrator(stateRepo, services.UserStateConfig{Enabled: true}, stdLogger)
+	logWriter, _ := services.NewRecommendationLogWriter(repositories.NewFeedRecommendationLogRepository(testPool, stdLogger), repositories.NewServedPageRepository(testPool, stdLogger), services.RecommendationLogWriterConfig{}, stdLogger)
+	service := services.NewFeedService(provider, services.NewGuestRecommendationProvider(services.NewFreshRecommendationProvider(videoRepo, stdLogger), videoRepo, stdLogger), videoRepo, logWriter,
+		repositories.NewFeedIdempotencyRepository(testPool, stdLogger), testHasher, nil, newInteractionRecorder(), hydrator, nil, nil, nil, nil, nil,
+		nil, services.FeedServiceConfig{}, stdLogger)
+
+	resp, err := service.GetFeed(ctx, services.GetFeedInput{UserID: "user-state", Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 2)
+	require.NotNil(t, resp.Items[0].UserState)
+	require.True(t, resp.Items[0].UserState.Liked)
+	require.False(t, resp.Items[0].UserState.Bookmarked)
+	require.InDelta(t, 0.4, resp.Items[0].UserState.WatchedRatio, 1e-9)
+	require.Nil(t, resp.Items[1].UserState)
+
+	// 其他用户看不到该用户的状态。
+	resp, err = service.GetFeed(ctx, services.GetFeedInput{UserID: "user-other", Limit: 2})
+	require.NoError(t, err)
+	for _, item := range resp.Items {
+		require.Nil(t, item.UserState)
+	}
+}
+
+func TestFeedService_GetFeed_FiltersWatchedVideos(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	videoRepo := repositories.NewFeedVideoProjectionRepository(testPool, stdLogger)
+	historyRepo := repositories.NewUserWatchHistoryRepository(testPool, stdLogger)
+	finished, partial, fresh := uuid.New(), uuid.New(), uuid.New()
+	provider := &stubRecommendationProvider{source: "stub"}
+	for i, id := range []uuid.UUID{finished, partial, fresh} {
+		require.NoError(t, videoRepo.Upsert(ctx, nil, repositories.UpsertFeedVideoProjectionInput{VideoID: id, Title: "Video", Version: int64(i + 1)}))
+		provider.items = append(provider.items, services.RecommendationItem{VideoID: id.String(), Reason: "stub"})
+	}
+	now := time.Now().UTC()
+	require.NoError(t, historyRepo.RecordProgress(ctx, nil, repositories.RecordProgressInput{UserID: "user-watched", VideoID: finished, WatchedRatio: 0.95, WatchedAt: now}))
+	require.NoError(t, historyRepo.RecordProgress(ctx, nil, repositories.RecordProgressInput{UserID: "user-watched", VideoID: partial, WatchedRatio: 0.5, WatchedAt: now}))
+
+	filter := services.NewWatchedFilter(historyRepo, services.WatchedFilterConfig{
+		Enabled: true,
+		Default: services.WatchedThresholds{DropRatio: 0.9, DemoteRatio: 0.3},
+		Scenes:  map[string]services.WatchedThresholds{"review": {}},
+	}, stdLogger)
+	logWriter, _ := services.NewRecommendationLogWriter(repositories.NewFeedRecommendationLogRepository(testPool, stdLogger), repositories.NewServedPageRepository(testPool, stdLogger), services.RecommendationLogWriterConfig{}, stdLogger)
+	service := services.NewFeedService(provider, services.NewGuestRecommendationProvider(services.NewFreshRecommendationProvider(videoRepo, stdLogger), videoRepo, stdLogger), videoRepo, logWriter,
+		repositories.NewFeedIdempotencyRepository(testPool, stdLogger), testHasher, nil, newInteractionRecorder(), nil, filter, nil, nil, nil, nil,
+		nil, services.FeedServiceConfig{}, stdLogger)
+
+	resp, err := service.GetFeed(ctx, services.GetFeedInput{UserID: "user-watched", Limit: 3, Scene: "home"})
+	require.NoError(t, err)
+	require.False(t, resp.Partial)
+	require.Len(t, resp.Items, 2)
+	require.Equal(t, fresh.String(), resp.Items[0].VideoID)
+	require.Equal(t, partial.String(), resp.Items[1].VideoID)
+
+	// 看完的视频按 watched 原因记录在推荐条目上，不计入补水缺失。
+	logEntry := fetchLatestRecommendationLog(ctx, t)
+	require.Empty(t, logEntry.missingVideoIDs)
+	require.Len(t, logEntry.recommendedItems, 3)
+	require.Equal(t, po.MissingReasonWatched, logEntry.recommendedItems[0].MissingReason)
+	require.Empty(t, logEntry.recommendedItems[1].MissingReason)
+	require.Empty(t, logEntry.recommendedItems[2].MissingReason)
+
+	// review 场景关闭过滤，按推荐顺序原样下发。
+	resp, err = service.GetFeed(ctx, services.GetFeedInput{UserID: "user-watched", Limit: 3, Scene: "review"})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 3)
+	require.Equal(t, finished.String(), resp.Items[0].VideoID)
+
+	// 其他用户不受影响。
+	resp, err = service.GetFeed(ctx, services.GetFeedInput{UserID: "user-other", Limit: 3, Scene: "home"})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 3)
+}
+
+func TestFeedService_GetFeed_ContinueLearningScene(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	videoRepo := repositories.NewFeedVideoProjectionRepository(testPool, stdLogger)
+	stateRepo := repositories.NewUserVideoStateRepository(testPool, stdLogger)
+	base := time.Now().UTC().Truncate(time.Millisecond)
+	ratios := []float64{0.5, 0.2, 0.99, 0.6}
+	ids := make([]uuid.UUID, len(ratios))
+	for i, ratio := range ratios {
+		ids[i] = uuid.New()
+		require.NoError(t, videoRepo.Upsert(ctx, nil, repositories.UpsertFeedVideoProjectionInput{VideoID: ids[i], Title: "Video", Version: 1}))
+		watchedAt := base.Add(time.Duration(i) * time.Minute)
+		position := int64(i+1) * 10_000_000
+		_, err := stateRepo.UpsertProgress(ctx, nil, repositories.UpsertProgressInput{
+			UserID: "user-continue", VideoID: ids[i], WatchedRatio: ratio, PositionMicros: &position, LastWatchedAt: &watchedAt, Version: 1, UpdatedAt: watchedAt,
+		})
+		require.NoError(t, err)
+	}
+
+	continueProvider := services.NewContinueLearningProvider(stateRepo, services.ContinueLearningConfig{Enabled: true, MinRatio: 0.05, MaxRatio: 0.9}, stdLogger)
+	logWriter, _ := services.NewRecommendationLogWriter(repositories.NewFeedRecommendationLogRepository(testPool, stdLogger), repositories.NewServedPageRepository(testPool, stdLogger), services.RecommendationLogWriterConfig{}, stdLogger)
+	service := services.NewFeedService(&stubRecommendationProvider{source: "stub"}, services.NewGuestRecommendationProvider(services.NewFreshRecommendationProvider(videoRepo, stdLogger), videoRepo, stdLogger), videoRepo, logWriter,
+		repositories.NewFeedIdempotencyRepository(testPool, stdLogger), testHasher, nil, newInteractionRecorder(), nil, nil, nil, nil, nil,
+		services.NewSceneProviders(continueProvider, nil), nil, services.FeedServiceConfig{}, stdLogger)
+
+	resp, err := service.GetFeed(ctx, services.GetFeedInput{UserID: "user-continue", Limit: 2, Scene: services.SceneContinueLearning})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 2)
+	require.Equal(t, ids[3].String(), resp.Items[0].VideoID)
+	require.Equal(t, ids[1].String(), resp.Items[1].VideoID)
+	require.Equal(t, "continue.watching", resp.Items[0].ReasonCode)
+	require.Equal(t, "40000000", resp.Items[0].Attributes["resume_position_micros"])
+	require.NotEmpty(t, resp.NextCursor)
+
+	logEntry := fetchLatestRecommendationLog(ctx, t)
+	require.Equal(t, "continue_learning", logEntry.source)
+
+	resp, err = service.GetFeed(ctx, services.GetFeedInput{UserID: "user-continue", Limit: 2, Scene: services.SceneContinueLearning, Cursor: resp.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 1)
+	require.Equal(t, ids[0].String(), resp.Items[0].VideoID)
+	require.Empty(t, resp.NextCursor)
+
+	_, err = service.GetFeed(ctx, services.GetFeedInput{UserID: "user-continue", Limit: 2, Scene: services.SceneContinueLearning, Cursor: "not-a-cursor"})
+	require.ErrorIs(t, err, services.ErrInvalidPageToken)
+}
+
+func TestFeedService_GetFeed_ReviewSceneAndHomeBlending(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	videoRepo := repositories.NewFeedVideoProjectionRepository(testPool, stdLogger)
+	reviewRepo := repositories.NewReviewScheduleRepository(testPool, stdLogger)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	reviewIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	for i, id := range reviewIDs {
+		require.NoError(t, videoRepo.Upsert(ctx, nil, repositories.UpsertFeedVideoProjectionInput{VideoID: id, Title: "Review", Version: 1}))
+		_, err := reviewRepo.Upsert(ctx, nil, repositories.UpsertReviewInput{
+			UserID: "user-review", VideoID: id, DueAt: now.Add(-time.Duration(2-i) * time.Hour), Reps: 2, Version: 1, UpdatedAt: now,
+		})
+		require.NoError(t, err)
+	}
+	primaryItems := make([]services.RecommendationItem, 0, 10)
+	for i := 0; i < 10; i++ {
+		id := uuid.New()
+		require.NoError(t, videoRepo.Upsert(ctx, nil, repositories.UpsertFeedVideoProjectionInput{VideoID: id, Title: "Video", Version: 1}))
+		primaryItems = append(primaryItems, services.RecommendationItem{VideoID: id.String(), Reason: "stub"})
+	}
+	// 主推荐中已包含的复习视频不重复出现。
+	primaryItems[0] = services.RecommendationItem{VideoID: reviewIDs[1].String(), Reason: "stub"}
+
+	reviewCfg := services.ReviewQueueConfig{Enabled: true, BlendFraction: 0.2}
+	reviewProvider := services.NewReviewDueProvider(reviewRepo, reviewCfg, stdLogger)
+	primary := &stubRecommendationProvider{source: "stub", items: primaryItems}
+	logWriter, _ := services.NewRecommendationLogWriter(repositories.NewFeedRecommendationLogRepository(testPool, stdLogger), repositories.NewServedPageRepository(testPool, stdLogger), services.RecommendationLogWriterConfig{}, stdLogger)
+	service := services.NewFeedService(services.NewReviewBlendingProvider(primary, reviewProvider, reviewCfg, stdLogger), services.NewGuestRecommendationProvider(services.NewFreshRecommendationProvider(videoRepo, stdLogger), videoRepo, stdLogger), videoRepo, logWriter,
+		repositories.NewFeedIdempotencyRepository(testPool, stdLogger), testHasher, nil, newInteractionRecorder(), nil, nil, nil, nil, nil,
+		services.NewSceneProviders(nil, reviewProvider), nil, services.FeedServiceConfig{}, stdLogger)
+
+	resp, err := service.GetFeed(ctx, services.GetFeedInput{UserID: "user-review", Limit: 5, Scene: services.SceneReview})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 2)
+	require.Equal(t, reviewIDs[0].String(), resp.Items[0].VideoID)
+	require.Equal(t, "review.due", resp.Items[0].ReasonCode)
+	require.Equal(t, "review", fetchLatestRecommendationLog(ctx, t).source)
+	require.Zero(t, primary.calls)
+
+	resp, err = service.GetFeed(ctx, services.GetFeedInput{UserID: "user-review", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 10)
+	reviewCount := 0
+	seen := make(map[string]struct{}, len(resp.Items))
+	for _, item := range resp.Items {
+		require.NotContains(t, seen, item.VideoID)
+		seen[item.VideoID] = struct{}{}
+		if item.ReasonCode == "review.due" {
+			reviewCount++
+		}
+	}
+	require.Equal(t, 2, reviewCount)
+	require.Equal(t, "review.due", resp.Items[2].ReasonCode)
+	require.Equal(t, "review.due", resp.Items[7].ReasonCode)
+	require.Equal(t, "stub", fetchLatestRecommendationLog(ctx, t).source)
+
+	// 其他场景不混排。
+	resp, err = service.GetFeed(ctx, services.GetFeedInput{UserID: "user-review", Limit: 10, Scene: "explore"})
+	require.NoError(t, err)
+	for _, item := range resp.Items {
+		require.NotEqual(t, "review.due", item.ReasonCode)
+	}
+
+	// 主推荐失败时以到期复习兜底。
+	primary.err = services.ErrRecommendationUnavailable
+	resp, err = service.GetFeed(ctx, services.GetFeedInput{UserID: "user-review", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 2)
+}
+
+// offsetPagedProvider 按 limit 与十进制偏移游标分页返回固定条目。
+type offsetPagedProvider struct {
+	items  []services.RecommendationItem
+	limits []int
+}
+
+func (p *offsetPagedProvider) GetFeed(_ context.Context, input services.RecommendationInput) (*services.RecommendationResult, error) {
+	p.limits = append(p.limits, input.Limit)
+	offset := 0
+	if input.Cursor != "" {
+		parsed, err := strconv.Atoi(input.Cursor)
+		if err != nil {
+			return nil, services.ErrInvalidPageToken
+		}
+		offset = parsed
+	}
+	end := min(offset+input.Limit, len(p.items))
+	result := &services.RecommendationResult{Items: append([]services.RecommendationItem(nil), p.items[offset:end]...), Source: "paged"}
+	if end < len(p.items) {
+		result.NextCursor = strconv.Itoa(end)
+	}
+	return result, nil
+}
+
+func (p *offsetPagedProvider) Source() string { return "paged" }
+
+func TestFeedService_GetFeed_ReviewBlendingKeepsPrimaryPaging(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	videoRepo := repositories.NewFeedVideoProjectionRepository(testPool, stdLogger)
+	reviewRepo := repositories.NewReviewScheduleRepository(testPool, stdLogger)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	reviewIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	for i, id := range reviewIDs {
+		require.NoError(t, videoRepo.Upsert(ctx, nil, repositories.UpsertFeedVideoProjectionInput{VideoID: id, Title: "Review", Version: 1}))
+		_, err := reviewRepo.Upsert(ctx, nil, repositories.UpsertReviewInput{
+			UserID: "user-paging", VideoID: id, DueAt: now.Add(-time.Duration(2-i) * time.Hour), Reps: 1, Version: 1, UpdatedAt: now,
+		})
+		require.NoError(t, err)
+	}
+	primary := &offsetPagedProvider{}
+	for i := 0; i < 12; i++ {
+		id := uuid.New()
+		require.NoError(t, videoRepo.Upsert(ctx, nil, repositories.UpsertFeedVideoProjectionInput{VideoID: id, Title: "Video", Version: 1}))
+		primary.items = append(primary.items, services.RecommendationItem{VideoID: id.String(), Reason: "stub"})
+	}
+
+	reviewCfg := services.ReviewQueueConfig{Enabled: true, BlendFraction: 0.4}
+	provider := services.NewReviewBlendingProvider(primary, services.NewReviewDueProvider(reviewRepo, reviewCfg, stdLogger), reviewCfg, stdLogger)
+	logWriter, _ := services.NewRecommendationLogWriter(repositories.NewFeedRecommendationLogRepository(testPool, stdLogger), repositories.NewServedPageRepository(testPool, stdLogger), services.RecommendationLogWriterConfig{}, stdLogger)
+	service := services.NewFeedService(provider, nil, videoRepo, logWriter,
+		repositories.NewFeedIdempotencyRepository(testPool, stdLogger), testHasher, nil, newInteractionRecorder(), nil, nil, nil, nil, nil,
+		nil, nil, services.FeedServiceConfig{}, stdLogger)
+
+	// 第一页：2 条复习占配额，主推荐只取 3 条。
+	page1, err := service.GetFeed(ctx, services.GetFeedInput{UserID: "user-paging", Limit: 5})
+	require.NoError(t, err)
+	require.Len(t, page1.Items, 5)
+	require.NotEmpty(t, page1.NextCursor)
+	page2, err := service.GetFeed(ctx, services.GetFeedInput{UserID: "user-paging", Limit: 5, Cursor: page1.NextCursor})
+	require.NoError(t, err)
+	require.Equal(t, []int{3, 5}, primary.limits)
+
+	// 两页的主推荐条目首尾相接，没有被混排挤掉的视频。
+	var served []string
+	reviewCount := 0
+	for _, item := range append(page1.Items, page2.Items...) {
+		if item.ReasonCode == "review.due" {
+			reviewCount++
+			continue
+		}
+		served = append(served, item.VideoID)
+	}
+	require.Equal(t, 2, reviewCount)
+	want := make([]string, 0, 8)
+	for _, item := range primary.items[:8] {
+		want = append(want, item.VideoID)
+	}
+	require.Equal(t, want, served)
+}
+
+func TestFeedService_GetFeed_PagesThroughBlendedHome(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	videoRepo := repositories.NewFeedVideoProjectionRepository(testPool, stdLogger)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	ready := "ready"
+	for i := 0; i < 12; i++ {
+		publishedAt := now.Add(-time.Duration(i) * time.Minute)
+		require.NoError(t, videoRepo.Upsert(ctx, nil, repositories.UpsertFeedVideoProjectionInput{
+			VideoID: uuid.New(), Title: "Video", Status: &ready, PublishedAt: &publishedAt, Version: 1,
+		}))
+	}
+	mock := services.NewMockRecommendationProvider(videoRepo, services.MockRecommendationConfig{Seed: 7, Fixed: true}, stdLogger)
+	fresh := services.NewFreshRecommendationProvider(videoRepo, stdLogger)
+	blender, err := services.NewBlendingRecommendationProvider(services.NewBlendingSources(mock, fresh, nil, nil), services.BlendingConfig{
+		Enabled: true,
+		Sources: []services.BlendingSourceConfig{
+			{Name: "mock", Weight: 3, Budget: time.Second},
+			{Name: "fresh", Weight: 1, Budget: time.Second},
+		},
+	}, stdLogger)
+	require.NoError(t, err)
+	service := newFeedService(blender)
+
+	first, err := service.GetFeed(ctx, services.GetFeedInput{UserID: "user-blend", Limit: 4})
+	require.NoError(t, err)
+	require.Len(t, first.Items, 4)
+	require.NotEmpty(t, first.NextCursor)
+
+	second, err := service.GetFeed(ctx, services.GetFeedInput{UserID: "user-blend", Limit: 4, Cursor: first.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, second.Items, 4)
+	require.NotEmpty(t, second.NextCursor)
+	require.NotEqual(t, feedVideoIDs(first.Items), feedVideoIDs(second.Items))
+
+	// 同一游标重放得到同一页。
+	again, err := service.GetFeed(ctx, services.GetFeedInput{UserID: "user-blend", Limit: 4, Cursor: first.NextCursor})
+	require.NoError(t, err)
+	require.Equal(t, feedVideoIDs(second.Items), feedVideoIDs(again.Items))
+}
+
+func TestFeedService_GetFeed_AppliesCurationRules(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	videoRepo := repositories.NewFeedVideoProjectionRepository(testPool, stdLogger)
+	ids := make([]uuid.UUID, 0, 5)
+	items := make([]services.RecommendationItem, 0, 4)
+	for i := 0; i < 5; i++ {
+		id := uuid.New()
+		ids = append(ids, id)
+		require.NoError(t, videoRepo.Upsert(ctx, nil, repositories.UpsertFeedVideoProjectionInput{VideoID: id, Title: "Video", Version: 1}))
+		if i < 4 {
+			items = append(items, services.RecommendationItem{VideoID: id.String(), Reason: "stub"})
+		}
+	}
+	announcement := ids[4]
+
+	ruleRepo := repositories.NewCurationRuleRepository(testPool, stdLogger)
+	engine, stop := services.NewCurationEngine(ruleRepo, services.CurationConfig{Enabled: true}, stdLogger)
+	defer stop()
+	admin := services.NewCurationRuleAdmin(ruleRepo, engine)
+	_, err := admin.Create(ctx, services.CurationRuleInput{Action: services.CurationActionPin, VideoID: announcement.String(), Scene: services.SceneHome, Position: 1})
+	require.NoError(t, err)
+	_, err = admin.Create(ctx, services.CurationRuleInput{Action: services.CurationActionBlock, VideoID: ids[1].String()})
+	require.NoError(t, err)
+	_, err = admin.Create(ctx, services.CurationRuleInput{Action: services.CurationActionBlock, VideoID: "not-a-uuid"})
+	require.ErrorIs(t, err, services.ErrInvalidCurationRule)
+
+	logWriter, _ := services.NewRecommendationLogWriter(repositories.NewFeedRecommendationLogRepository(testPool, stdLogger), repositories.NewServedPageRepository(testPool, stdLogger), services.RecommendationLogWriterConfig{}, stdLogger)
+	service := services.NewFeedService(&stubRecommendationProvider{source: "stub", items: items}, services.NewGuestRecommendationProvider(services.NewFreshRecommendationProvider(videoRepo, stdLogger), videoRepo, stdLogger), videoRepo, logWriter,
+		repositories.NewFeedIdempotencyRepository(testPool, stdLogger), testHasher, nil, newInteractionRecorder(), nil, nil, nil, engine, nil,
+		nil, nil, services.FeedServiceConfig{}, stdLogger)
+
+	resp, err := service.GetFeed(ctx, services.GetFeedInput{UserID: "user-curation", Limit: 4})
+	require.NoError(t, err)
+	require.Equal(t, []string{announcement.String(), ids[0].String(), ids[2].String(), ids[3].String()}, feedVideoIDs(resp.Items))
+	require.Equal(t, "curation.pin", resp.Items[0].ReasonCode)
+
+	// 翻页只执行屏蔽，置顶仅出现在第一页。
+	resp, err = service.GetFeed(ctx, services.GetFeedInput{UserID: "user-curation", Limit: 4, Cursor: "page-2"})
+	require.NoError(t, err)
+	require.Equal(t, []string{ids[0].String(), ids[2].String(), ids[3].String()}, feedVideoIDs(resp.Items))
+}
+
+func feedVideoIDs(items []vo.FeedItem) []string {
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		out = append(out, item.VideoID)
+	}
+	return out
+}
+
+func TestFeedService_GetFeed_AssignsExperimentVariants(t *testing.T) {
+	resetDatabase(t)
+	ctx := context.Background()
+
+	videoRepo := repositories.NewFeedVideoProjectionRepository(testPool, stdLogger)
+	items := make([]services.RecommendationItem, 0, 2)
+	for i := 0; i < 2; i++ {
+		id := uuid.New()
+		require.NoError(t, videoRepo.Upsert(ctx, nil, repositories.UpsertFeedVideoProjectionInput{VideoID: id, Title: "Video", Version: 1}))
+		items = append(items, services.RecommendationItem{VideoID: id.String(), Reason: "stub"})
+	}
+	primary := &stubRecommendationProvider{source: "stub", items: items}
+	candidate := &stubRecommendationProvider{source: "candidate", items: items[:1]}
+	// candidate 权重为 0，只能经强制头命中。
+	assigner, err := services.NewExperimentAssigner(services.ExperimentConfig{
+		Enabled:             true,
+		AllowForcedVariants: true,
+		Experiments: []services.ExperimentDefinition{{
+			Key: "ranker",
+			Variants: []services.ExperimentVariantConfig{
+				{Name: "control", Weight: 1},
+				{Name: "candidate", Provider: "candidate"},
+			},
+		}},
+	}, services.BlendingSources{"candidate": candidate}, services.BlendingConfig{}, nil, stdLogger)
+	require.NoError(t, err)
+
+	logWriter, _ := services.NewRecommendationLogWriter(repositories.NewFeedRecommendationLogRepository(testPool, stdLogger), repositories.NewServedPageRepository(testPool, stdLogger), services.RecommendationLogWriterConfig{}, stdLogger)
+	service := services.NewFeedService(primary, services.NewGuestRecommendationProvider(services.NewFreshRecommendationProvider(videoRepo, stdLogger), videoRepo, stdLogger), videoRepo, logWriter,
+		repositories.NewFeedIdempotencyRepository(testPool, stdLogger), testHasher, nil, newInteractionRecorder(), nil, nil, nil, nil, assigner,
+		nil, nil, services.FeedServiceConfig{}, stdLogger)
+
+	resp, err := service.GetFeed(ctx, services.GetFeedInput{UserID: "user-exp", Limit: 5})
+	require.NoError(t, err)
+	require.Equal(t, 1, primary.calls)
+	require.Equal(t, map[string]string{"ranker": "control"}, primary.lastInput.Experiments)
+	require.Len(t, resp.Items, 2)
+	require.Equal(t, "control", resp.Items[0].Attributes["exp.ranker"])
+	require.Equal(t, []po.ExperimentAssignmentLog{{Experiment: "ranker", Variant: "control"}}, fetchLatestExperiments(ctx, t))
+
+	resp, err = service.GetFeed(ctx, services.GetFeedInput{UserID: "user-exp", Limit: 5, ForcedVariants: map[string]string{"ranker": "candidate"}})
+	require.NoError(t, err)
+	require.Equal(t, 1, primary.calls)
+	require.Equal(t, 1, candidate.calls)
+	require.Len(t, resp.Items, 1)
+	require.Equal(t, "candidate", resp.Items[0].Attributes["exp.ranker"])
+	require.Equal(t, []po.ExperimentAssignmentLog{{Experiment: "ranker", Variant: "candidate", Forced: true}}, fetchLatestExperiments(ctx, t))
+
+	// 访客不参与实验。
+	resp, err = service.GetFeed(ctx, services.GetFeedInput{Guest: true, GuestID: "guest:exp", Limit: 5})
+	require.NoError(t, err)
+	for _, item := range resp.Items {
+		require.NotContains(t, item.Attributes, "exp.ranker")
+	}
+}
+
+func fetchLatestExperiments(ctx context.Context, t *testing.T) []po.ExperimentAssignmentLog {
+	t.Helper()
+	var raw []byte
+	require.NoError(t, testPool.QueryRow(ctx, `SELECT experiments FROM feed.recommendation_logs ORDER BY generated_at DESC LIMIT 1`).Scan(&raw))
+	var out []po.ExperimentAssignmentLog
+	require.NoError(t, json.Unmarshal(raw, &out))
+	return out
+}
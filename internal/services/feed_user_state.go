@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/models/vo"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+// UserStateConfig 控制是否为登录用户的卡片补充点赞/收藏/观看进度。
+type UserStateConfig struct {
+	Enabled bool
+}
+
+// UserStateHydrator 从 feed.user_video_state 读取用户-视频状态并写入 FeedItem。
+//
+// 状态仅用于展示，读取失败时降级为不带状态的卡片，不影响 Feed 返回。
+type UserStateHydrator struct {
+	states *repositories.UserVideoStateRepository
+	log    *log.Helper
+}
+
+// NewUserStateHydrator 构造 UserStateHydrator；未启用时返回 nil，FeedService 随之跳过状态补水。
+func NewUserStateHydrator(states *repositories.UserVideoStateRepository, cfg UserStateConfig, logger log.Logger) *UserStateHydrator {
+	if !cfg.Enabled || states == nil {
+		return nil
+	}
+	return &UserStateHydrator{
+		states: states,
+		log:    log.NewHelper(logger),
+	}
+}
+
+// Apply 为 items 填充 userID 的状态；无记录的视频保持 UserState 为空。
+func (h *UserStateHydrator) Apply(ctx context.Context, userID string, items []vo.FeedItem) {
+	if h == nil || userID == "" || len(items) == 0 {
+		return
+	}
+	ids := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		if id, err := uuid.Parse(item.VideoID); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	states, err := h.states.ListByVideoIDs(ctx, nil, userID, ids)
+	if err != nil {
+		h.log.WithContext(ctx).Warnw("msg", "load user video states failed", "count", len(ids), "error", err)
+		return
+	}
+	byVideo := make(map[string]*po.UserVideoState, len(states))
+	for _, state := range states {
+		byVideo[state.VideoID] = state
+	}
+	for i := range items {
+		items[i].ApplyUserState(byVideo[items[i].VideoID])
+	}
+}
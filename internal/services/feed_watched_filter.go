@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/vo"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+// WatchedThresholds 为按历史最高观看进度判定的阈值，取值 (0, 1]，0 表示关闭对应动作。
+type WatchedThresholds struct {
+	// DropRatio 为剔除阈值：进度达到该值视为看完，不再下发。
+	DropRatio float64
+	// DemoteRatio 为降权阈值：进度达到该值但未看完时移到本页末尾。
+	DemoteRatio float64
+}
+
+// WatchedFilterConfig 控制观看过滤阶段。
+type WatchedFilterConfig struct {
+	Enabled bool
+	Default WatchedThresholds
+	// Scenes 按推荐场景覆盖默认阈值。
+	Scenes map[string]WatchedThresholds
+}
+
+// WatchedFilter 依据 feed.user_watch_history 剔除登录用户已看完的视频，并可将看过一部分的视频降到本页末尾。
+//
+// 过滤只作用于补水后的单页结果，不向推荐模块回补条目；读取历史失败时原样返回，不影响 Feed 下发。
+type WatchedFilter struct {
+	history *repositories.UserWatchHistoryRepository
+	cfg     WatchedFilterConfig
+	log     *log.Helper
+}
+
+// NewWatchedFilter 构造 WatchedFilter；未启用时返回 nil，FeedService 随之跳过过滤。
+func NewWatchedFilter(history *repositories.UserWatchHistoryRepository, cfg WatchedFilterConfig, logger log.Logger) *WatchedFilter {
+	if !cfg.Enabled || history == nil {
+		return nil
+	}
+	return &WatchedFilter{
+		history: history,
+		cfg:     cfg,
+		log:     log.NewHelper(logger),
+	}
+}
+
+// Thresholds 返回 scene 生效的阈值。
+func (f *WatchedFilter) Thresholds(scene string) WatchedThresholds {
+	if thresholds, ok := f.cfg.Scenes[strings.TrimSpace(scene)]; ok {
+		return thresholds
+	}
+	return f.cfg.Default
+}
+
+// Apply 按 scene 的阈值过滤 items，返回保留的条目（降权条目保持相对顺序排在末尾）与被剔除的视频 ID。
+func (f *WatchedFilter) Apply(ctx context.Context, userID, scene string, items []vo.FeedItem) ([]vo.FeedItem, []string) {
+	if f == nil || userID == "" || len(items) == 0 {
+		return items, nil
+	}
+	thresholds := f.Thresholds(scene)
+	if thresholds.DropRatio <= 0 && thresholds.DemoteRatio <= 0 {
+		return items, nil
+	}
+	ids := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		if id, err := uuid.Parse(item.VideoID); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	history, err := f.history.ListByVideoIDs(ctx, nil, userID, ids)
+	if err != nil {
+		f.log.WithContext(ctx).Warnw("msg", "load user watch history failed", "count", len(ids), "error", err)
+		return items, nil
+	}
+	if len(history) == 0 {
+		return items, nil
+	}
+	ratios := make(map[string]float64, len(history))
+	for _, record := range history {
+		ratios[record.VideoID] = record.MaxWatchedRatio
+	}
+
+	kept := make([]vo.FeedItem, 0, len(items))
+	var (
+		demoted []vo.FeedItem
+		dropped []string
+	)
+	for _, item := range items {
+		ratio, ok := ratios[item.VideoID]
+		switch {
+		case !ok:
+			kept = append(kept, item)
+		case thresholds.DropRatio > 0 && ratio >= thresholds.DropRatio:
+			dropped = append(dropped, item.VideoID)
+		case thresholds.DemoteRatio > 0 && ratio >= thresholds.DemoteRatio:
+			demoted = append(demoted, item)
+		default:
+			kept = append(kept, item)
+		}
+	}
+	return append(kept, demoted...), dropped
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+const (
+	freshRecommendationSource = "fresh"
+	freshReason               = "fresh.recent"
+)
+
+// FreshRecommendationProvider 按发布时间倒序返回最新视频，作为混排中的新鲜度来源。
+type FreshRecommendationProvider struct {
+	repo *repositories.FeedVideoProjectionRepository
+	log  *log.Helper
+}
+
+// NewFreshRecommendationProvider 构造最新发布推荐源。
+func NewFreshRecommendationProvider(repo *repositories.FeedVideoProjectionRepository, logger log.Logger) *FreshRecommendationProvider {
+	return &FreshRecommendationProvider{
+		repo: repo,
+		log:  log.NewHelper(logger),
+	}
+}
+
+// Source 返回推荐来源标识。
+func (p *FreshRecommendationProvider) Source() string {
+	return freshRecommendationSource
+}
+
+// GetFeed 返回最新发布的视频，忽略 input.UserID 与游标。
+func (p *FreshRecommendationProvider) GetFeed(ctx context.Context, input RecommendationInput) (*RecommendationResult, error) {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	ids, err := p.repo.ListRecentIDs(ctx, nil, limit)
+	if err != nil {
+		p.log.WithContext(ctx).Errorw("msg", "fresh recommendation list recent ids failed", "error", err)
+		return nil, wrapFeedError(ErrRecommendationUnavailable, err)
+	}
+	items := make([]RecommendationItem, 0, len(ids))
+	for i, id := range ids {
+		items = append(items, RecommendationItem{
+			VideoID:  id.String(),
+			Reason:   freshReason,
+			Score:    1 - float64(i)/float64(limit),
+			Metadata: map[string]string{"source": freshRecommendationSource},
+		})
+	}
+	return &RecommendationResult{Items: items, Source: freshRecommendationSource}, nil
+}
+
+var _ RecommendationProvider = (*FreshRecommendationProvider)(nil)
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/models/vo"
+)
+
+// guestFeedCacheMaxEntries 为访客缓存的条目上限，超出时先清理过期条目，仍满则不再写入。
+const guestFeedCacheMaxEntries = 1024
+
+// guestFeedCache 缓存访客 Feed 结果。访客推荐与用户无关，因此按 limit 全局共享；
+// 运营规则可按场景与语言区域生效，两者经 CurationEngine.CacheScope 规范化后也计入缓存键。
+type guestFeedCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[guestCacheKey]guestCacheEntry
+}
+
+type guestCacheKey struct {
+	limit  int
+	scene  string
+	locale string
+}
+
+type guestCacheEntry struct {
+	resp        vo.FeedResponse
+	source      string
+	recommended []po.RecommendedItemLog
+	missingIDs  []string
+	expiresAt   time.Time
+}
+
+func newGuestFeedCache(ttl time.Duration) *guestFeedCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &guestFeedCache{
+		ttl:     ttl,
+		entries: make(map[guestCacheKey]guestCacheEntry),
+	}
+}
+
+// get 返回未过期的缓存条目，响应为浅拷贝，调用方不得修改其中的切片。
+func (c *guestFeedCache) get(key guestCacheKey, now time.Time) (guestCacheEntry, bool) {
+	if c == nil {
+		return guestCacheEntry{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || !now.Before(entry.expiresAt) {
+		return guestCacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (c *guestFeedCache) put(key guestCacheKey, entry guestCacheEntry, now time.Time) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= guestFeedCacheMaxEntries {
+		for k, existing := range c.entries {
+			if !now.Before(existing.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= guestFeedCacheMaxEntries {
+			return
+		}
+	}
+	entry.expiresAt = now.Add(c.ttl)
+	c.entries[key] = entry
+}
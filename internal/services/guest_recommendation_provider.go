@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"maps"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+// GuestRecommendationProvider 为访客提供非个性化推荐：以最新发布推荐源（FreshRecommendationProvider）为主，不足时随机补齐。
+type GuestRecommendationProvider struct {
+	fresh *FreshRecommendationProvider
+	repo  *repositories.FeedVideoProjectionRepository
+	log   *log.Helper
+}
+
+const (
+	guestRecommendationSource = "guest"
+	guestReasonRecent         = "guest.recent"
+	guestReasonRandom         = "guest.random"
+)
+
+// NewGuestRecommendationProvider 构造访客推荐链，最新视频的选取与打分沿用 fresh。
+func NewGuestRecommendationProvider(fresh *FreshRecommendationProvider, repo *repositories.FeedVideoProjectionRepository, logger log.Logger) *GuestRecommendationProvider {
+	return &GuestRecommendationProvider{
+		fresh: fresh,
+		repo:  repo,
+		log:   log.NewHelper(logger),
+	}
+}
+
+// Source 返回推荐来源标识。
+func (p *GuestRecommendationProvider) Source() string {
+	return guestRecommendationSource
+}
+
+// GetFeed 返回最新发布的视频，数量不足 limit 时以随机视频补齐，忽略 input.UserID。
+// 条目的 reason_code 与 metadata.source 改记为访客推荐，便于与登录用户的 fresh 来源区分。
+func (p *GuestRecommendationProvider) GetFeed(ctx context.Context, input RecommendationInput) (*RecommendationResult, error) {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	recent, err := p.fresh.GetFeed(ctx, RecommendationInput{Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]RecommendationItem, 0, limit)
+	seen := make(map[string]struct{}, limit)
+	for _, item := range recent.Items {
+		seen[item.VideoID] = struct{}{}
+		meta := maps.Clone(item.Metadata)
+		if meta == nil {
+			meta = map[string]string{}
+		}
+		meta["source"] = guestRecommendationSource
+		item.Reason = guestReasonRecent
+		item.Metadata = meta
+		items = append(items, item)
+	}
+	if len(items) < limit {
+		random, randErr := p.repo.ListRandomIDs(ctx, nil, limit)
+		if randErr != nil {
+			// 已有最新视频时降级返回，不因补齐失败整体报错。
+			p.log.WithContext(ctx).Warnw("msg", "guest recommendation fill random ids failed", "error", randErr)
+		}
+		items = appendRandomFill(items, seen, random, limit)
+	}
+	return &RecommendationResult{Items: items, Source: guestRecommendationSource}, nil
+}
+
+// appendRandomFill 以随机视频补齐到 limit 条，跳过已在结果中的视频。
+func appendRandomFill(items []RecommendationItem, seen map[string]struct{}, random []uuid.UUID, limit int) []RecommendationItem {
+	for _, id := range random {
+		if len(items) >= limit {
+			break
+		}
+		videoID := id.String()
+		if _, dup := seen[videoID]; dup {
+			continue
+		}
+		seen[videoID] = struct{}{}
+		items = append(items, RecommendationItem{
+			VideoID:  videoID,
+			Reason:   guestReasonRandom,
+			Metadata: map[string]string{"source": guestRecommendationSource},
+		})
+	}
+	return items
+}
+
+var _ RecommendationProvider = (*GuestRecommendationProvider)(nil)
@@ -8,5 +8,23 @@ import "github.com/google/wire"
 // 包含所有 Usecase 的构造器。
 var ProviderSet = wire.NewSet(
 	NewMockRecommendationProvider,
+	NewFreshRecommendationProvider,
+	NewBlendingSources,
+	NewBlendingRecommendationProvider,
+	NewRecommendationLogWriter,
+	NewRecommendationLogSampler,
 	NewFeedService,
+	NewRecommendationLogLookup,
+	NewInteractionRecorder,
+	NewRecommendationLogStore,
+	NewUserStateHydrator,
+	NewWatchedFilter,
+	NewRerankPipeline,
+	NewCurationEngine,
+	NewCurationRuleAdmin,
+	NewExperimentAssigner,
+	NewShadowTraffic,
+	NewContinueLearningProvider,
+	NewReviewDueProvider,
+	NewSceneProviders,
 )
@@ -2,17 +2,34 @@ package services
 
 import (
 	"context"
-	"math/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
 )
 
-// MockRecommendationProvider 根据本地投影随机返回视频。
+// MockRecommendationConfig 控制 Mock 推荐的确定性排列。
+type MockRecommendationConfig struct {
+	// Seed 为全局种子，修改后所有用户的顺序随之改变。
+	Seed int64
+	// Fixed 为 true 时请求种子不含日期，同一用户的顺序跨天不变。
+	Fixed bool
+}
+
+// MockRecommendationProvider 根据本地投影返回伪随机顺序的视频。
+// 顺序由请求种子（全局种子 + user_id + UTC 日期）决定，相同种子在投影不变时总是得到相同且可翻页的结果。
 type MockRecommendationProvider struct {
 	repo *repositories.FeedVideoProjectionRepository
-	rng  *rand.Rand
+	cfg  MockRecommendationConfig
+	now  func() time.Time
 	log  *log.Helper
 }
 
@@ -24,38 +41,106 @@ func (p *MockRecommendationProvider) Source() string {
 }
 
 // NewMockRecommendationProvider 构造基于投影表的 Mock 推荐实现。
-func NewMockRecommendationProvider(repo *repositories.FeedVideoProjectionRepository, logger log.Logger) *MockRecommendationProvider {
-	seed := time.Now().UnixNano()
+func NewMockRecommendationProvider(repo *repositories.FeedVideoProjectionRepository, cfg MockRecommendationConfig, logger log.Logger) *MockRecommendationProvider {
 	return &MockRecommendationProvider{
 		repo: repo,
-		rng:  rand.New(rand.NewSource(seed)),
+		cfg:  cfg,
+		now:  time.Now,
 		log:  log.NewHelper(logger),
 	}
 }
 
-// GetFeed 返回随机挑选的视频 ID。
+// MockRequestSeed 返回一次请求的排列种子：sha256("<seed>:<user_id>:<YYYY-MM-DD>") 前 8 字节的十六进制，
+// 日期按 UTC 计算；fixed 为 true 时省略日期。
+func MockRequestSeed(seed int64, userID string, day time.Time, fixed bool) string {
+	raw := strconv.FormatInt(seed, 10) + ":" + userID
+	if !fixed {
+		raw += ":" + day.UTC().Format(time.DateOnly)
+	}
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:8])
+}
+
+// GetFeed 按请求种子的伪随机顺序返回视频 ID。翻页游标携带种子，跨越 UTC 零点时后续页仍沿用首页的顺序。
 func (p *MockRecommendationProvider) GetFeed(ctx context.Context, input RecommendationInput) (*RecommendationResult, error) {
 	limit := input.Limit
 	if limit <= 0 {
 		limit = 20
 	}
-	ids, err := p.repo.ListRandomIDs(ctx, nil, limit)
+	params := repositories.ListSeededVideoIDsParams{
+		// 多取一条用于判断是否还有下一页。
+		Limit: limit + 1,
+	}
+	if input.Cursor != "" {
+		seed, after, err := decodeMockCursor(input.Cursor)
+		if err != nil {
+			return nil, wrapFeedError(ErrInvalidPageToken, err)
+		}
+		params.Seed = seed
+		params.After = after
+	} else {
+		params.Seed = MockRequestSeed(p.cfg.Seed, input.UserID, p.now(), p.cfg.Fixed)
+	}
+	rows, err := p.repo.ListSeededIDs(ctx, nil, params)
 	if err != nil {
 		p.log.WithContext(ctx).Errorw("msg", "mock recommendation list ids failed", "error", err)
-		return nil, ErrRecommendationUnavailable
+		return nil, wrapFeedError(ErrRecommendationUnavailable, err)
+	}
+	result := &RecommendationResult{Source: mockRecommendationSource}
+	if len(rows) > limit {
+		rows = rows[:limit]
+		result.NextCursor = encodeMockCursor(params.Seed, rows[limit-1])
 	}
-	items := make([]RecommendationItem, 0, len(ids))
-	for _, id := range ids {
-		items = append(items, RecommendationItem{
-			VideoID: id.String(),
+	result.Items = make([]RecommendationItem, 0, len(rows))
+	for _, row := range rows {
+		result.Items = append(result.Items, RecommendationItem{
+			VideoID: row.VideoID.String(),
 			Reason:  "mock.random",
-			Score:   p.rng.Float64(),
+			Score:   mockScore(row.SortKey),
 			Metadata: map[string]string{
 				"source": mockRecommendationSource,
 			},
+			Cursor: encodeMockCursor(params.Seed, row),
 		})
 	}
-	return &RecommendationResult{Items: items, Source: mockRecommendationSource}, nil
+	return result, nil
+}
+
+// mockScore 由排序键前 8 字节映射到 (0, 1]，随列表顺序单调递减，同一种子下可复现。
+func mockScore(sortKey []byte) float64 {
+	if len(sortKey) < 8 {
+		return 0
+	}
+	return 1 - float64(binary.BigEndian.Uint64(sortKey[:8]))/(1<<64)
+}
+
+// encodeMockCursor 将 (seed, sort_key, video_id) 编码为不透明游标：base64url("<seed>.<sort_key_hex>.<video_id>")。
+func encodeMockCursor(seed string, row repositories.SeededVideoID) string {
+	raw := seed + "." + hex.EncodeToString(row.SortKey) + "." + row.VideoID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeMockCursor(token string) (string, *repositories.SeededVideoCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", nil, err
+	}
+	parts := strings.Split(string(raw), ".")
+	if len(parts) != 3 || parts[0] == "" {
+		return "", nil, errors.New("malformed mock recommendation cursor")
+	}
+	sortKey, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return "", nil, err
+	}
+	if len(sortKey) != sha256.Size {
+		return "", nil, errors.New("malformed mock recommendation cursor sort key")
+	}
+	videoID, err := uuid.Parse(parts[2])
+	if err != nil {
+		return "", nil, err
+	}
+	return parts[0], &repositories.SeededVideoCursor{SortKey: sortKey, VideoID: videoID}, nil
 }
 
 var _ RecommendationProvider = (*MockRecommendationProvider)(nil)
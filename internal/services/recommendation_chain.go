@@ -0,0 +1,42 @@
+package services
+
+import "github.com/go-kratos/kratos/v2/log"
+
+// RecommendationChain 按统一顺序组装推荐链，默认链路与实验分组覆盖的链路共用：
+// 主推荐失败或熔断时回退到本地链路（多路混排或 Mock 推荐），外层依次挂影子流量与首页复习混排。
+type RecommendationChain struct {
+	local     RecommendationProvider
+	shadow    *ShadowTraffic
+	review    *ReviewDueProvider
+	reviewCfg ReviewQueueConfig
+	logger    log.Logger
+}
+
+// NewRecommendationChain 以 local 为本地兜底链路构造组装器；shadow 与 review 为 nil 时对应环节原样透传。
+func NewRecommendationChain(local RecommendationProvider, shadow *ShadowTraffic, review *ReviewDueProvider, reviewCfg ReviewQueueConfig, logger log.Logger) *RecommendationChain {
+	return &RecommendationChain{
+		local:     local,
+		shadow:    shadow,
+		review:    review,
+		reviewCfg: reviewCfg,
+		logger:    logger,
+	}
+}
+
+// Build 以 primary 为主推荐组装完整推荐链；primary 为 nil 或即本地链路时直接以本地链路为主推荐。
+// 组装器为 nil 时原样返回 primary。
+func (c *RecommendationChain) Build(primary RecommendationProvider) RecommendationProvider {
+	if c == nil {
+		return primary
+	}
+	chain := c.local
+	switch {
+	case primary == nil:
+	case c.local == nil:
+		chain = primary
+	case primary != c.local:
+		chain = NewFallbackRecommendationProvider(primary, c.local, c.logger)
+	}
+	chain = NewShadowedRecommendationProvider(chain, c.shadow)
+	return NewReviewBlendingProvider(chain, c.review, c.reviewCfg, c.logger)
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/pseudonym"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultLogPageSize = 50
+	maxLogPageSize     = 500
+)
+
+// RecommendationLogLookup 供运维/客服工具按已知 user_id 查询推荐日志。
+// 日志只保存 HMAC 哈希，因此查询时对所有密钥版本计算哈希，跨密钥轮换周期命中历史数据。
+type RecommendationLogLookup struct {
+	repo   *repositories.FeedRecommendationLogRepository
+	hasher *pseudonym.Hasher
+}
+
+// NewRecommendationLogLookup 构造 RecommendationLogLookup。
+func NewRecommendationLogLookup(repo *repositories.FeedRecommendationLogRepository, hasher *pseudonym.Hasher) *RecommendationLogLookup {
+	return &RecommendationLogLookup{repo: repo, hasher: hasher}
+}
+
+// HashUserID 返回 userID 在各密钥版本下的哈希，便于人工比对日志或外部系统中的 user_id_hash。
+func (l *RecommendationLogLookup) HashUserID(userID string) ([]pseudonym.Digest, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, ErrInvalidLogQuery
+	}
+	if !l.hasher.Enabled() {
+		return nil, ErrUserHashingDisabled
+	}
+	return l.hasher.Candidates(userID), nil
+}
+
+// ListForUser 按已知 userID 查询推荐日志；params 中的 UserID/UserIDHashes 会被覆盖。
+func (l *RecommendationLogLookup) ListForUser(ctx context.Context, userID string, params repositories.ListRecommendationLogsParams) ([]*po.FeedRecommendationLog, error) {
+	hashes, err := l.userHashes(userID)
+	if err != nil {
+		return nil, err
+	}
+	params.UserID = nil
+	params.UserIDHashes = hashes
+	return l.repo.List(ctx, nil, params)
+}
+
+// RecommendationLogQuery 描述管理端的推荐日志分页查询，所有条件为 AND 关系。
+type RecommendationLogQuery struct {
+	// UserID 为明文用户 ID，与 UserIDHash 互斥。
+	UserID      string
+	UserIDHash  string
+	Source      string
+	ErrorKind   string
+	MissingOnly bool
+	Since       *time.Time
+	Until       *time.Time
+	PageSize    int
+	PageToken   string
+}
+
+// RecommendationLogPage 为一页推荐日志，NextPageToken 为空表示没有更多数据。
+type RecommendationLogPage struct {
+	Logs          []*po.FeedRecommendationLog
+	NextPageToken string
+}
+
+// List 按 (generated_at, log_id) 倒序键集分页查询推荐日志。
+func (l *RecommendationLogLookup) List(ctx context.Context, query RecommendationLogQuery) (*RecommendationLogPage, error) {
+	userID := strings.TrimSpace(query.UserID)
+	userHash := strings.TrimSpace(query.UserIDHash)
+	if userID != "" && userHash != "" {
+		return nil, wrapFeedError(ErrInvalidLogQuery, errors.New("user_id and user_id_hash are mutually exclusive"))
+	}
+	pageSize := query.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultLogPageSize
+	}
+	pageSize = min(pageSize, maxLogPageSize)
+
+	params := repositories.ListRecommendationLogsParams{
+		Source:      optionalString(query.Source),
+		ErrorKind:   optionalString(query.ErrorKind),
+		MissingOnly: query.MissingOnly,
+		Since:       query.Since,
+		Until:       query.Until,
+		// 多取一条用于判断是否存在下一页。
+		Limit: pageSize + 1,
+	}
+	switch {
+	case userID != "":
+		hashes, err := l.userHashes(userID)
+		if err != nil {
+			return nil, err
+		}
+		params.UserIDHashes = hashes
+	case userHash != "":
+		params.UserIDHashes = []string{userHash}
+	}
+	if query.PageToken != "" {
+		cursor, err := decodeLogPageToken(query.PageToken)
+		if err != nil {
+			return nil, wrapFeedError(ErrInvalidPageToken, err)
+		}
+		params.After = cursor
+	}
+
+	logs, err := l.repo.List(ctx, nil, params)
+	if err != nil {
+		return nil, err
+	}
+	page := &RecommendationLogPage{Logs: logs}
+	if len(logs) > pageSize {
+		page.Logs = logs[:pageSize]
+		last := page.Logs[pageSize-1]
+		token, err := encodeLogPageToken(last)
+		if err != nil {
+			return nil, err
+		}
+		page.NextPageToken = token
+	}
+	return page, nil
+}
+
+// Get 按 log_id 返回单条推荐日志。
+func (l *RecommendationLogLookup) Get(ctx context.Context, logID string) (*po.FeedRecommendationLog, error) {
+	id, err := uuid.Parse(strings.TrimSpace(logID))
+	if err != nil {
+		return nil, wrapFeedError(ErrInvalidLogQuery, err)
+	}
+	entry, err := l.repo.GetByID(ctx, nil, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRecommendationLogNotFound) {
+			return nil, ErrRecommendationLogNotFound
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (l *RecommendationLogLookup) userHashes(userID string) ([]string, error) {
+	digests, err := l.HashUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]string, 0, len(digests))
+	for _, digest := range digests {
+		hashes = append(hashes, digest.Hash)
+	}
+	return hashes, nil
+}
+
+// encodeLogPageToken 将 (generated_at, log_id) 编码为不透明游标：base64url("<unix_micro>.<log_id>")。
+func encodeLogPageToken(entry *po.FeedRecommendationLog) (string, error) {
+	id, err := uuid.Parse(entry.LogID)
+	if err != nil {
+		return "", err
+	}
+	raw := strconv.FormatInt(entry.GeneratedAt.UnixMicro(), 10) + "." + id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw)), nil
+}
+
+func decodeLogPageToken(token string) (*repositories.RecommendationLogCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	micros, id, ok := strings.Cut(string(raw), ".")
+	if !ok {
+		return nil, errors.New("malformed page token")
+	}
+	ts, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	logID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return &repositories.RecommendationLogCursor{GeneratedAt: time.UnixMicro(ts).UTC(), LogID: logID}, nil
+}
+
+func optionalString(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
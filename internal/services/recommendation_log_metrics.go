@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	noopmetric "go.opentelemetry.io/otel/metric/noop"
+)
+
+const (
+	dropReasonQueueFull   = "queue_full"
+	dropReasonCanceled    = "canceled"
+	dropReasonClosed      = "closed"
+	dropReasonFlushFailed = "flush_failed"
+	// dropReasonMalformed 为无法编码 served 事件、在批量写入前跳过的日志。
+	dropReasonMalformed = "malformed"
+)
+
+type logWriterMetrics struct {
+	written       metric.Int64Counter
+	dropped       metric.Int64Counter
+	servedDropped metric.Int64Counter
+	enabled       bool
+}
+
+func newLogWriterMetrics() *logWriterMetrics {
+	meterProvider := otel.GetMeterProvider()
+	if meterProvider == nil {
+		meterProvider = noopmetric.NewMeterProvider()
+	}
+	meter := meterProvider.Meter("lingo-services-feed.recommendation_log_writer")
+
+	written, err := meter.Int64Counter("feed_recommendation_log_written_total", metric.WithDescription("Number of recommendation logs persisted"))
+	if err != nil {
+		return &logWriterMetrics{}
+	}
+	dropped, err := meter.Int64Counter("feed_recommendation_log_dropped_total", metric.WithDescription("Number of recommendation logs dropped before persistence"))
+	if err != nil {
+		return &logWriterMetrics{}
+	}
+	servedDropped, err := meter.Int64Counter("feed_served_page_dropped_total", metric.WithDescription("Number of served page records dropped before persistence"))
+	if err != nil {
+		return &logWriterMetrics{}
+	}
+	return &logWriterMetrics{written: written, dropped: dropped, servedDropped: servedDropped, enabled: true}
+}
+
+func (m *logWriterMetrics) recordWritten(ctx context.Context, count int64) {
+	if m == nil || !m.enabled || count <= 0 {
+		return
+	}
+	m.written.Add(ctx, count)
+}
+
+func (m *logWriterMetrics) recordDropped(ctx context.Context, reason string, count int64) {
+	if m == nil || !m.enabled || count <= 0 {
+		return
+	}
+	m.dropped.Add(ctx, count, metric.WithAttributes(attribute.String("reason", reason)))
+}
+
+func (m *logWriterMetrics) recordServedDropped(ctx context.Context, reason string, count int64) {
+	if m == nil || !m.enabled || count <= 0 {
+		return
+	}
+	m.servedDropped.Add(ctx, count, metric.WithAttributes(attribute.String("reason", reason)))
+}
+
+type logSamplerMetrics struct {
+	decisions metric.Int64Counter
+	enabled   bool
+}
+
+func newLogSamplerMetrics() *logSamplerMetrics {
+	meterProvider := otel.GetMeterProvider()
+	if meterProvider == nil {
+		meterProvider = noopmetric.NewMeterProvider()
+	}
+	meter := meterProvider.Meter("lingo-services-feed.recommendation_log_sampler")
+
+	decisions, err := meter.Int64Counter("feed_recommendation_log_sampling_total", metric.WithDescription("Number of recommendation log sampling decisions"))
+	if err != nil {
+		return &logSamplerMetrics{}
+	}
+	return &logSamplerMetrics{decisions: decisions, enabled: true}
+}
+
+func (m *logSamplerMetrics) record(ctx context.Context, source string, kept bool, reason string) {
+	if m == nil || !m.enabled {
+		return
+	}
+	decision := "skipped"
+	if kept {
+		decision = "kept"
+	}
+	m.decisions.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("source", source),
+		attribute.String("decision", decision),
+		attribute.String("reason", reason),
+	))
+}
+
+type shadowMetrics struct {
+	requests        metric.Int64Counter
+	jaccard         metric.Float64Histogram
+	rankCorrelation metric.Float64Histogram
+	hydrationMiss   metric.Float64Histogram
+	latency         metric.Float64Histogram
+	enabled         bool
+}
+
+func newShadowMetrics() *shadowMetrics {
+	meterProvider := otel.GetMeterProvider()
+	if meterProvider == nil {
+		meterProvider = noopmetric.NewMeterProvider()
+	}
+	meter := meterProvider.Meter("lingo-services-feed.shadow_traffic")
+
+	requests, err := meter.Int64Counter("feed_shadow_requests_total", metric.WithDescription("Number of shadow candidate calls by outcome"))
+	if err != nil {
+		return &shadowMetrics{}
+	}
+	jaccard, err := meter.Float64Histogram("feed_shadow_jaccard", metric.WithDescription("Jaccard overlap between primary and candidate recommendations"))
+	if err != nil {
+		return &shadowMetrics{}
+	}
+	rankCorrelation, err := meter.Float64Histogram("feed_shadow_rank_correlation", metric.WithDescription("Spearman rank correlation of videos recommended by both primary and candidate"))
+	if err != nil {
+		return &shadowMetrics{}
+	}
+	hydrationMiss, err := meter.Float64Histogram("feed_shadow_hydration_miss_rate", metric.WithDescription("Share of candidate videos without a feed projection"))
+	if err != nil {
+		return &shadowMetrics{}
+	}
+	latency, err := meter.Float64Histogram("feed_shadow_candidate_latency_ms", metric.WithDescription("Latency of shadow candidate calls"), metric.WithUnit("ms"))
+	if err != nil {
+		return &shadowMetrics{}
+	}
+	return &shadowMetrics{
+		requests:        requests,
+		jaccard:         jaccard,
+		rankCorrelation: rankCorrelation,
+		hydrationMiss:   hydrationMiss,
+		latency:         latency,
+		enabled:         true,
+	}
+}
+
+func (m *shadowMetrics) recordOutcome(ctx context.Context, candidate, scene, outcome string) {
+	if m == nil || !m.enabled {
+		return
+	}
+	m.requests.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("candidate", candidate),
+		attribute.String("scene", scene),
+		attribute.String("outcome", outcome),
+	))
+}
+
+func (m *shadowMetrics) recordComparison(ctx context.Context, candidate, scene string, cmp ShadowComparison, missRate float64, missValid bool, latency time.Duration) {
+	if m == nil || !m.enabled {
+		return
+	}
+	m.recordOutcome(ctx, candidate, scene, shadowOutcomeCompared)
+	attrs := metric.WithAttributes(attribute.String("candidate", candidate), attribute.String("scene", scene))
+	m.jaccard.Record(ctx, cmp.Jaccard, attrs)
+	if cmp.RankCorrelationValid {
+		m.rankCorrelation.Record(ctx, cmp.RankCorrelation, attrs)
+	}
+	if missValid {
+		m.hydrationMiss.Record(ctx, missRate, attrs)
+	}
+	m.latency.Record(ctx, float64(latency.Milliseconds()), attrs)
+}
+
+type fallbackMetrics struct {
+	fallbacks metric.Int64Counter
+	enabled   bool
+}
+
+func newFallbackMetrics() *fallbackMetrics {
+	meterProvider := otel.GetMeterProvider()
+	if meterProvider == nil {
+		meterProvider = noopmetric.NewMeterProvider()
+	}
+	meter := meterProvider.Meter("lingo-services-feed.recommendation_fallback")
+
+	fallbacks, err := meter.Int64Counter("feed_recommendation_fallback_total", metric.WithDescription("Number of requests served by the fallback chain after the primary provider failed"))
+	if err != nil {
+		return &fallbackMetrics{}
+	}
+	return &fallbackMetrics{fallbacks: fallbacks, enabled: true}
+}
+
+func (m *fallbackMetrics) record(ctx context.Context, source, reason string) {
+	if m == nil || !m.enabled {
+		return
+	}
+	m.fallbacks.Add(ctx, 1, metric.WithAttributes(attribute.String("source", source), attribute.String("reason", reason)))
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"encoding/binary"
+	"math/rand/v2"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RecommendationLogSample 描述一次待记录的推荐请求，供采样策略判定。
+type RecommendationLogSample struct {
+	UserID    string
+	Source    string
+	Scene     string
+	ErrorKind string
+	Partial   bool
+	Guest     bool
+	Replayed  bool
+}
+
+// RecommendationLogSampler 决定推荐日志是否落库，FeedService.logRecommendation 在写入前调用。
+type RecommendationLogSampler interface {
+	ShouldLog(ctx context.Context, sample RecommendationLogSample) bool
+}
+
+// RecommendationLogSamplingRule 为指定 source/scene 设置采样率；空字段表示匹配任意值。
+type RecommendationLogSamplingRule struct {
+	Source string
+	Scene  string
+	Rate   float64
+}
+
+// RecommendationLogSamplingConfig 控制推荐日志采样。
+type RecommendationLogSamplingConfig struct {
+	// Enabled 为 false 时全量记录，保持旧行为。
+	Enabled bool
+	// DefaultRate 为未命中任何规则时的采样率，取值 [0,1]。
+	DefaultRate float64
+	// Rules 按 source+scene > source > scene 的优先级匹配。
+	Rules []RecommendationLogSamplingRule
+	// DebugUserIDs 中的用户始终全量记录，便于排查个案。
+	DebugUserIDs []string
+}
+
+const (
+	samplingReasonError     = "error"
+	samplingReasonPartial   = "partial"
+	samplingReasonDebugUser = "debug_user"
+	samplingReasonTrace     = "trace_sampled"
+	samplingReasonRate      = "rate"
+)
+
+// RateRecommendationLogSampler 是默认采样实现：错误、partial 与调试用户强制记录，
+// 已被采样的 Trace 强制记录，其余按规则采样率基于 TraceID 做确定性判定，
+// 使同一请求的日志与 Trace 采样结果一致（与 OTel TraceIDRatioBased 取相同的 TraceID 位段）。
+type RateRecommendationLogSampler struct {
+	defaultRate float64
+	exact       map[string]float64
+	bySource    map[string]float64
+	byScene     map[string]float64
+	debugUsers  map[string]struct{}
+	metrics     *logSamplerMetrics
+}
+
+// NewRecommendationLogSampler 根据配置构造采样器；未启用时返回全量记录的实现。
+func NewRecommendationLogSampler(cfg RecommendationLogSamplingConfig) RecommendationLogSampler {
+	if !cfg.Enabled {
+		return alwaysLogSampler{}
+	}
+	return NewRateRecommendationLogSampler(cfg)
+}
+
+// NewRateRecommendationLogSampler 构造按规则采样的实现。
+func NewRateRecommendationLogSampler(cfg RecommendationLogSamplingConfig) *RateRecommendationLogSampler {
+	s := &RateRecommendationLogSampler{
+		defaultRate: clampRate(cfg.DefaultRate),
+		exact:       make(map[string]float64),
+		bySource:    make(map[string]float64),
+		byScene:     make(map[string]float64),
+		debugUsers:  make(map[string]struct{}, len(cfg.DebugUserIDs)),
+		metrics:     newLogSamplerMetrics(),
+	}
+	for _, rule := range cfg.Rules {
+		source := strings.TrimSpace(rule.Source)
+		scene := strings.TrimSpace(rule.Scene)
+		rate := clampRate(rule.Rate)
+		switch {
+		case source != "" && scene != "":
+			s.exact[source+"|"+scene] = rate
+		case source != "":
+			s.bySource[source] = rate
+		case scene != "":
+			s.byScene[scene] = rate
+		default:
+			s.defaultRate = rate
+		}
+	}
+	for _, id := range cfg.DebugUserIDs {
+		if id = strings.TrimSpace(id); id != "" {
+			s.debugUsers[id] = struct{}{}
+		}
+	}
+	return s
+}
+
+// ShouldLog 实现 RecommendationLogSampler。
+func (s *RateRecommendationLogSampler) ShouldLog(ctx context.Context, sample RecommendationLogSample) bool {
+	if reason, forced := s.forced(ctx, sample); forced {
+		s.metrics.record(ctx, sample.Source, true, reason)
+		return true
+	}
+	keep := sampleByTraceID(ctx, s.Rate(sample.Source, sample.Scene))
+	s.metrics.record(ctx, sample.Source, keep, samplingReasonRate)
+	return keep
+}
+
+// Rate 返回 source/scene 命中的采样率。
+func (s *RateRecommendationLogSampler) Rate(source, scene string) float64 {
+	if rate, ok := s.exact[source+"|"+scene]; ok {
+		return rate
+	}
+	if rate, ok := s.bySource[source]; ok {
+		return rate
+	}
+	if rate, ok := s.byScene[scene]; ok {
+		return rate
+	}
+	return s.defaultRate
+}
+
+func (s *RateRecommendationLogSampler) forced(ctx context.Context, sample RecommendationLogSample) (string, bool) {
+	switch {
+	case sample.ErrorKind != "":
+		return samplingReasonError, true
+	case sample.Partial:
+		return samplingReasonPartial, true
+	}
+	if _, ok := s.debugUsers[sample.UserID]; ok && sample.UserID != "" {
+		return samplingReasonDebugUser, true
+	}
+	if trace.SpanContextFromContext(ctx).IsSampled() {
+		return samplingReasonTrace, true
+	}
+	return "", false
+}
+
+// sampleByTraceID 取 TraceID 低 8 字节做阈值比较，与 OTel TraceIDRatioBased 算法一致；无 Trace 时随机采样。
+func sampleByTraceID(ctx context.Context, rate float64) bool {
+	if rate >= 1 {
+		return true
+	}
+	if rate <= 0 {
+		return false
+	}
+	threshold := uint64(rate * (1 << 63))
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		tid := sc.TraceID()
+		return binary.BigEndian.Uint64(tid[8:16])>>1 < threshold
+	}
+	return rand.Uint64()>>1 < threshold
+}
+
+func clampRate(rate float64) float64 {
+	switch {
+	case rate < 0:
+		return 0
+	case rate > 1:
+		return 1
+	default:
+		return rate
+	}
+}
+
+// alwaysLogSampler 为未启用采样时的全量实现。
+type alwaysLogSampler struct{}
+
+func (alwaysLogSampler) ShouldLog(context.Context, RecommendationLogSample) bool { return true }
+
+var _ RecommendationLogSampler = (*RateRecommendationLogSampler)(nil)
@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// LogOverflowPolicy 描述异步队列写满时的处理方式。
+type LogOverflowPolicy string
+
+const (
+	// LogOverflowDrop 队列满时直接丢弃并计数，不阻塞请求（默认）。
+	LogOverflowDrop LogOverflowPolicy = "drop"
+	// LogOverflowBlock 队列满时阻塞等待，直到入队或请求 Context 结束。
+	LogOverflowBlock LogOverflowPolicy = "block"
+)
+
+const (
+	defaultLogQueueSize     = 1024
+	defaultLogBatchSize     = 100
+	defaultLogFlushInterval = time.Second
+	defaultLogFlushTimeout  = 5 * time.Second
+)
+
+// RecommendationLogWriterConfig 控制推荐日志的写入方式。
+type RecommendationLogWriterConfig struct {
+	// Async 为 false 时在请求链路内同步 INSERT，保持旧行为。
+	Async bool
+	// QueueSize 为异步队列容量。
+	QueueSize int
+	// BatchSize 为单次 COPY 的最大条数，达到后立即刷盘。
+	BatchSize int
+	// FlushInterval 为定时刷盘周期。
+	FlushInterval time.Duration
+	// FlushTimeout 为单批写入及关闭时整体排空的超时。
+	FlushTimeout time.Duration
+	// Overflow 为队列写满时的策略。
+	Overflow LogOverflowPolicy
+}
+
+// RecommendationLogStore 抽象推荐日志的单条与批量写入。
+type RecommendationLogStore interface {
+	Insert(ctx context.Context, sess txmanager.Session, entry po.FeedRecommendationLog) error
+	InsertBatch(ctx context.Context, sess txmanager.Session, entries []po.FeedRecommendationLog) (int64, error)
+}
+
+// logRecord 为写入队列中的一条记录：下发页记录与按采样保留的推荐日志，至少其一非空。
+type logRecord struct {
+	page  *po.ServedPage
+	entry *po.FeedRecommendationLog
+}
+
+// RecommendationLogWriter 将推荐日志与下发页记录移出请求关键路径：入队后由后台协程按批 COPY 写入。
+//
+// 关闭时（Wire cleanup）停止接收新日志，并在 FlushTimeout 内排空队列。
+type RecommendationLogWriter struct {
+	store   RecommendationLogStore
+	pages   *repositories.ServedPageRepository
+	cfg     RecommendationLogWriterConfig
+	queue   chan logRecord
+	done    chan struct{}
+	mu      sync.RWMutex
+	closed  bool
+	once    sync.Once
+	metrics *logWriterMetrics
+	log     *log.Helper
+}
+
+// NewRecommendationLogWriter 构造日志写入器并在异步模式下启动后台协程，返回的 cleanup 负责排空队列。
+// pages 为空时不写入下发页记录。
+func NewRecommendationLogWriter(store RecommendationLogStore, pages *repositories.ServedPageRepository, cfg RecommendationLogWriterConfig, logger log.Logger) (*RecommendationLogWriter, func()) {
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = defaultLogQueueSize
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultLogBatchSize
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = defaultLogFlushInterval
+	}
+	if cfg.FlushTimeout <= 0 {
+		cfg.FlushTimeout = defaultLogFlushTimeout
+	}
+	if cfg.Overflow != LogOverflowBlock {
+		cfg.Overflow = LogOverflowDrop
+	}
+	w := &RecommendationLogWriter{
+		store:   store,
+		pages:   pages,
+		cfg:     cfg,
+		metrics: newLogWriterMetrics(),
+		log:     log.NewHelper(logger),
+	}
+	if !cfg.Async {
+		return w, func() {}
+	}
+	w.queue = make(chan logRecord, cfg.QueueSize)
+	w.done = make(chan struct{})
+	go w.run()
+
+	cleanup := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.FlushTimeout)
+		defer cancel()
+		if err := w.Close(ctx); err != nil {
+			w.log.Warnw("msg", "recommendation log writer close timed out", "pending", len(w.queue), "error", err)
+		}
+	}
+	return w, cleanup
+}
+
+// RecordsServedPages 表示写入器会持久化下发页记录。
+func (w *RecommendationLogWriter) RecordsServedPages() bool {
+	return w != nil && w.pages != nil
+}
+
+// Write 记录一条推荐日志。异步模式下仅入队，写入失败只记录日志与指标，不影响请求结果。
+func (w *RecommendationLogWriter) Write(ctx context.Context, entry po.FeedRecommendationLog) {
+	w.enqueue(ctx, logRecord{entry: &entry})
+}
+
+// WriteServed 记录一页下发：page 为不采样的下发页记录，entry 为按采样保留的推荐日志，均可为空。
+// 与推荐日志共用异步队列与批量 COPY，请求路径不等待数据库。
+func (w *RecommendationLogWriter) WriteServed(ctx context.Context, page *po.ServedPage, entry *po.FeedRecommendationLog) {
+	if !w.RecordsServedPages() {
+		page = nil
+	}
+	if page == nil && entry == nil {
+		return
+	}
+	w.enqueue(ctx, logRecord{page: page, entry: entry})
+}
+
+func (w *RecommendationLogWriter) enqueue(ctx context.Context, record logRecord) {
+	if w == nil || w.store == nil {
+		return
+	}
+	if !w.cfg.Async {
+		w.insert(ctx, record)
+		return
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		w.recordDropped(ctx, dropReasonClosed, record)
+		return
+	}
+	if w.cfg.Overflow == LogOverflowBlock {
+		select {
+		case w.queue <- record:
+		case <-ctx.Done():
+			w.recordDropped(ctx, dropReasonCanceled, record)
+		}
+		return
+	}
+	select {
+	case w.queue <- record:
+	default:
+		w.recordDropped(ctx, dropReasonQueueFull, record)
+	}
+}
+
+// insert 为同步模式下的逐条写入。
+func (w *RecommendationLogWriter) insert(ctx context.Context, record logRecord) {
+	if record.page != nil {
+		if err := w.pages.Insert(ctx, nil, *record.page); err != nil {
+			w.log.WithContext(ctx).Warnw("msg", "write served page failed", "log_id", record.page.LogID, "error", err)
+			w.metrics.recordServedDropped(ctx, dropReasonFlushFailed, 1)
+		}
+	}
+	if record.entry != nil {
+		if err := w.store.Insert(ctx, nil, *record.entry); err != nil {
+			w.log.WithContext(ctx).Warnw("msg", "write recommendation log failed", "error", err)
+			return
+		}
+		w.metrics.recordWritten(ctx, 1)
+	}
+}
+
+func (w *RecommendationLogWriter) recordDropped(ctx context.Context, reason string, record logRecord) {
+	if record.entry != nil {
+		w.metrics.recordDropped(ctx, reason, 1)
+	}
+	if record.page != nil {
+		w.metrics.recordServedDropped(ctx, reason, 1)
+	}
+}
+
+// Close 停止接收新日志并等待队列排空，ctx 结束时返回其错误。可重复调用。
+func (w *RecommendationLogWriter) Close(ctx context.Context) error {
+	if w == nil || w.queue == nil {
+		return nil
+	}
+	w.once.Do(func() {
+		// 写锁等待阻塞中的 Write 完成入队，之后再关闭通道以免向已关闭通道发送。
+		w.mu.Lock()
+		w.closed = true
+		close(w.queue)
+		w.mu.Unlock()
+	})
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *RecommendationLogWriter) run() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]logRecord, 0, w.cfg.BatchSize)
+	for {
+		select {
+		case record, ok := <-w.queue:
+			if !ok {
+				w.flush(batch)
+				return
+			}
+			batch = append(batch, record)
+			if len(batch) >= w.cfg.BatchSize {
+				w.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				w.flush(batch)
+				batch = batch[:0]
+			}
+		}
+	}
+}
+
+// flush 先写下发页记录再写推荐日志，两者相互独立，一方失败不影响另一方。
+func (w *RecommendationLogWriter) flush(batch []logRecord) {
+	if len(batch) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), w.cfg.FlushTimeout)
+	defer cancel()
+	pages := make([]po.ServedPage, 0, len(batch))
+	entries := make([]po.FeedRecommendationLog, 0, len(batch))
+	for _, record := range batch {
+		if record.page != nil {
+			pages = append(pages, *record.page)
+		}
+		if record.entry != nil {
+			entries = append(entries, *record.entry)
+		}
+	}
+	if len(pages) > 0 {
+		if _, err := w.pages.InsertBatch(ctx, nil, pages); err != nil {
+			w.log.Warnw("msg", "flush served pages failed", "count", len(pages), "error", err)
+			w.metrics.recordServedDropped(ctx, dropReasonFlushFailed, int64(len(pages)))
+		}
+	}
+	if len(entries) == 0 {
+		return
+	}
+	written, err := w.store.InsertBatch(ctx, nil, entries)
+	if err != nil {
+		w.log.Warnw("msg", "flush recommendation logs failed", "count", len(entries), "error", err)
+		w.metrics.recordDropped(ctx, dropReasonFlushFailed, int64(len(entries)))
+		return
+	}
+	w.metrics.recordWritten(ctx, written)
+}
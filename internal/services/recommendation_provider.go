@@ -1,9 +1,6 @@
 package services
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 // RecommendationProvider 抽象推荐系统的调用能力。
 type RecommendationProvider interface {
@@ -15,12 +12,20 @@ type RecommendationProvider interface {
 type RecommendationInput struct {
 	UserID string
 	Limit  int
+	// Scene 为推荐场景，空值表示首页；Provider 可据此决定是否混排。
+	Scene string
+	// Cursor 为上一页返回的游标，空值表示第一页；不支持翻页的 Provider 忽略该字段。
+	Cursor string
+	// Experiments 为请求命中的实验分组（experiment → variant），供外部推荐系统按分组选择模型。
+	Experiments map[string]string
 }
 
 // RecommendationResult 包含推荐条目与下一游标。
 type RecommendationResult struct {
 	Items  []RecommendationItem
 	Source string
+	// NextCursor 为下一页游标，空值表示没有更多数据。
+	NextCursor string
 }
 
 // RecommendationItem 表示推荐返回的单条数据。
@@ -29,7 +34,21 @@ type RecommendationItem struct {
 	Reason   string
 	Score    float64
 	Metadata map[string]string
+	// Cursor 为从该条目之后继续读取的游标，仅支持逐条续读的 Provider 设置；混排据此在来源页中途续读。
+	Cursor string
 }
 
-// ErrRecommendationUnavailable 表示推荐不可用。
-var ErrRecommendationUnavailable = errors.New("recommendation unavailable")
+// SceneProviders 按推荐场景路由到专用 Provider，未登记的场景走主推荐链。
+type SceneProviders map[string]RecommendationProvider
+
+// NewSceneProviders 登记已启用的场景 Provider，未启用（nil）的场景不登记。
+func NewSceneProviders(continueLearning *ContinueLearningProvider, review *ReviewDueProvider) SceneProviders {
+	scenes := SceneProviders{}
+	if continueLearning != nil {
+		scenes[SceneContinueLearning] = continueLearning
+	}
+	if review != nil {
+		scenes[SceneReview] = review
+	}
+	return scenes
+}
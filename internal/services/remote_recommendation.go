@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// ErrRecommendationCircuitOpen 表示外部推荐服务的熔断器处于打开状态，请求未发出即被拒绝。
+var ErrRecommendationCircuitOpen = errors.New("recommendation: circuit open")
+
+const (
+	fallbackReasonError       = "error"
+	fallbackReasonCircuitOpen = "circuit_open"
+
+	// 翻页游标前缀，标记签发游标的链路：主推荐与兜底链路的游标格式互不兼容。
+	fallbackCursorPrimary  = "p."
+	fallbackCursorFallback = "f."
+)
+
+// RemoteRecommendationConfig 控制外部推荐服务客户端（internal/clients/recommendation）。
+type RemoteRecommendationConfig struct {
+	Enabled bool
+	// Primary 为 true 时以外部推荐作为主推荐链，失败或熔断时回退到原有链路；
+	// 为 false 时只登记为推荐源 remote，供影子流量、混排与实验引用。
+	Primary bool
+	// Targets 为推荐服务后端地址，对冲请求发往与首个请求不同的后端。
+	Targets []string
+	// Timeout 为单次调用（含对冲）的总超时。
+	Timeout time.Duration
+	Hedge   RemoteHedgeConfig
+	Breaker RemoteBreakerConfig
+}
+
+// RemoteHedgeConfig 控制对冲请求：首个请求在延迟分位数内未返回（或快速失败）时向另一后端补发一次，先成功者胜出。
+type RemoteHedgeConfig struct {
+	Enabled bool
+	// Percentile 为触发对冲的延迟分位数，基于最近 Window 次调用尝试（含对冲落败方）统计。
+	Percentile float64
+	// InitialDelay 为样本不足时使用的对冲延迟。
+	InitialDelay time.Duration
+	// MinDelay 为对冲延迟下限，防止延迟极低时几乎每次都补发。
+	MinDelay time.Duration
+	Window   int
+}
+
+// RemoteBreakerConfig 控制熔断器：连续失败达到阈值后打开，OpenDuration 后放行少量探测请求（半开），探测成功即关闭。
+type RemoteBreakerConfig struct {
+	Enabled          bool
+	FailureThreshold int
+	OpenDuration     time.Duration
+	HalfOpenProbes   int
+}
+
+// RemoteRecommendationSource 为外部推荐服务客户端，未启用时为 nil。
+type RemoteRecommendationSource RecommendationProvider
+
+// fallbackProvider 调用主推荐失败时改走兜底链路；熔断打开时主推荐立即返回，不等待超时。
+type fallbackProvider struct {
+	primary  RecommendationProvider
+	fallback RecommendationProvider
+	metrics  *fallbackMetrics
+	log      *log.Helper
+}
+
+// NewFallbackRecommendationProvider 以 fallback 兜底 primary；primary 为 nil 时原样返回 fallback。
+func NewFallbackRecommendationProvider(primary, fallback RecommendationProvider, logger log.Logger) RecommendationProvider {
+	if primary == nil {
+		return fallback
+	}
+	return &fallbackProvider{
+		primary:  primary,
+		fallback: fallback,
+		metrics:  newFallbackMetrics(),
+		log:      log.NewHelper(logger),
+	}
+}
+
+// Source 返回主推荐来源标识；实际由兜底链路返回时结果中的 Source 为兜底来源。
+func (p *fallbackProvider) Source() string {
+	return p.primary.Source()
+}
+
+// GetFeed 调用主推荐，失败时改走兜底链路；请求本身已取消或超时时不再兜底。
+// 返回的游标带签发链路前缀：兜底链路签发的游标继续由兜底链路翻页；主推荐签发的游标在回退时丢弃，
+// 兜底链路从第一页开始，避免把对方无法解析的游标传给它。
+func (p *fallbackProvider) GetFeed(ctx context.Context, input RecommendationInput) (*RecommendationResult, error) {
+	tag, cursor := splitFallbackCursor(input.Cursor)
+	if tag == fallbackCursorFallback {
+		input.Cursor = cursor
+		return p.serveFallback(ctx, input)
+	}
+	primaryInput := input
+	primaryInput.Cursor = cursor
+	result, err := p.primary.GetFeed(ctx, primaryInput)
+	if err == nil || ctx.Err() != nil {
+		return tagFallbackCursors(result, fallbackCursorPrimary), err
+	}
+	logger := p.log.WithContext(ctx)
+	reason := fallbackReasonError
+	if errors.Is(err, ErrRecommendationCircuitOpen) {
+		reason = fallbackReasonCircuitOpen
+		logger.Debugw("msg", "recommendation: circuit open, serve fallback", "primary", p.primary.Source(), "fallback", p.fallback.Source())
+	} else {
+		logger.Warnw("msg", "recommendation: primary failed, serve fallback", "primary", p.primary.Source(), "fallback", p.fallback.Source(), "error", err)
+	}
+	p.metrics.record(ctx, p.primary.Source(), reason)
+	input.Cursor = ""
+	return p.serveFallback(ctx, input)
+}
+
+func (p *fallbackProvider) serveFallback(ctx context.Context, input RecommendationInput) (*RecommendationResult, error) {
+	result, err := p.fallback.GetFeed(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return tagFallbackCursors(result, fallbackCursorFallback), nil
+}
+
+// splitFallbackCursor 拆出游标的签发链路前缀；未带前缀的游标（上线前签发）视为主推荐签发。
+func splitFallbackCursor(cursor string) (string, string) {
+	for _, tag := range []string{fallbackCursorPrimary, fallbackCursorFallback} {
+		if rest, ok := strings.CutPrefix(cursor, tag); ok {
+			return tag, rest
+		}
+	}
+	return fallbackCursorPrimary, cursor
+}
+
+// tagFallbackCursors 为结果的下一页游标与逐条续翻游标加上签发链路前缀，返回副本。
+func tagFallbackCursors(result *RecommendationResult, tag string) *RecommendationResult {
+	if result == nil {
+		return nil
+	}
+	tagged := *result
+	if tagged.NextCursor != "" {
+		tagged.NextCursor = tag + tagged.NextCursor
+	}
+	tagged.Items = make([]RecommendationItem, len(result.Items))
+	for i, item := range result.Items {
+		if item.Cursor != "" {
+			item.Cursor = tag + item.Cursor
+		}
+		tagged.Items[i] = item
+	}
+	return &tagged
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/go-kratos/kratos/v2/log"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// SceneReview 为复习场景：只返回到期的复习视频。
+	SceneReview = "review"
+	// SceneHome 为首页场景，空场景等同首页；复习混排只作用于首页。
+	SceneHome = "home"
+
+	reviewSource = "review"
+	reviewReason = "review.due"
+)
+
+// ReviewQueueConfig 控制复习队列 Provider 及其在首页的混排比例。
+type ReviewQueueConfig struct {
+	Enabled bool
+	// BlendFraction 为首页第一页中复习条目的占比，取值 [0, 1]，0 表示不混排。
+	BlendFraction float64
+}
+
+// ReviewDueProvider 基于本地 feed.review_schedule 投影返回已到期的复习视频，
+// 按到期时间升序排列，最早到期的优先复习。到期列表每次请求重新计算，不支持翻页。
+type ReviewDueProvider struct {
+	reviews *repositories.ReviewScheduleRepository
+	log     *log.Helper
+	clock   func() time.Time
+}
+
+// NewReviewDueProvider 构造复习队列 Provider；未启用时返回 nil。
+func NewReviewDueProvider(reviews *repositories.ReviewScheduleRepository, cfg ReviewQueueConfig, logger log.Logger) *ReviewDueProvider {
+	if !cfg.Enabled || reviews == nil {
+		return nil
+	}
+	return &ReviewDueProvider{
+		reviews: reviews,
+		log:     log.NewHelper(logger),
+		clock:   time.Now,
+	}
+}
+
+// Source 返回推荐来源标识。
+func (p *ReviewDueProvider) Source() string {
+	return reviewSource
+}
+
+// GetFeed 返回已到期的复习条目，条目元数据携带到期时间与复习次数。
+func (p *ReviewDueProvider) GetFeed(ctx context.Context, input RecommendationInput) (*RecommendationResult, error) {
+	result := &RecommendationResult{Source: reviewSource}
+	if input.UserID == "" {
+		return result, nil
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	now := p.clock().UTC()
+	schedules, err := p.reviews.ListDue(ctx, nil, input.UserID, now, limit)
+	if err != nil {
+		p.log.WithContext(ctx).Errorw("msg", "list due reviews failed", "error", err)
+		return nil, wrapFeedError(ErrRecommendationUnavailable, err)
+	}
+	result.Items = make([]RecommendationItem, 0, len(schedules))
+	for _, schedule := range schedules {
+		result.Items = append(result.Items, RecommendationItem{
+			VideoID: schedule.VideoID,
+			Reason:  reviewReason,
+			// 逾期越久分数越高，单位为小时。
+			Score: now.Sub(schedule.DueAt).Hours(),
+			Metadata: map[string]string{
+				"source": reviewSource,
+				"due_at": schedule.DueAt.Format(time.RFC3339),
+				"reps":   strconv.FormatInt(int64(schedule.Reps), 10),
+			},
+		})
+	}
+	return result, nil
+}
+
+// reviewBlendingProvider 在首页第一页按比例混入到期复习，复习条目均匀分布在整页中。
+type reviewBlendingProvider struct {
+	primary  RecommendationProvider
+	review   *ReviewDueProvider
+	fraction float64
+	log      *log.Helper
+}
+
+// NewReviewBlendingProvider 用复习队列包装主推荐 Provider；复习队列未启用或比例为 0 时原样返回主 Provider。
+func NewReviewBlendingProvider(primary RecommendationProvider, review *ReviewDueProvider, cfg ReviewQueueConfig, logger log.Logger) RecommendationProvider {
+	if review == nil || cfg.BlendFraction <= 0 {
+		return primary
+	}
+	return &reviewBlendingProvider{
+		primary:  primary,
+		review:   review,
+		fraction: math.Min(cfg.BlendFraction, 1),
+		log:      log.NewHelper(logger),
+	}
+}
+
+// Source 返回主推荐来源标识。
+func (p *reviewBlendingProvider) Source() string {
+	return p.primary.Source()
+}
+
+// GetFeed 并发读取到期复习与主推荐并混排。主推荐只取 limit 减去复习配额的条数，混排不会截掉主推荐条目，
+// 其 NextCursor 原样下发即可从本页之后续读；到期复习不足配额时本页相应变短。
+// 复习读取失败时降级为纯主推荐；主推荐失败但有到期复习时以复习兜底。
+func (p *reviewBlendingProvider) GetFeed(ctx context.Context, input RecommendationInput) (*RecommendationResult, error) {
+	if input.UserID == "" || input.Cursor != "" || (input.Scene != "" && input.Scene != SceneHome) {
+		return p.primary.GetFeed(ctx, input)
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	// 至少给主推荐留一个位置，否则无从得到续读游标。
+	quota := min(int(math.Ceil(float64(limit)*p.fraction)), limit-1)
+	if quota <= 0 {
+		return p.primary.GetFeed(ctx, input)
+	}
+
+	var (
+		reviews    []RecommendationItem
+		primary    *RecommendationResult
+		primaryErr error
+		g          errgroup.Group
+	)
+	g.Go(func() error {
+		due, err := p.review.GetFeed(ctx, RecommendationInput{UserID: input.UserID, Limit: quota})
+		if err != nil {
+			p.log.WithContext(ctx).Warnw("msg", "review blending: skip due reviews", "error", err)
+			return nil
+		}
+		reviews = due.Items
+		return nil
+	})
+	g.Go(func() error {
+		primaryInput := input
+		primaryInput.Limit = limit - quota
+		primary, primaryErr = p.primary.GetFeed(ctx, primaryInput)
+		return nil
+	})
+	_ = g.Wait()
+
+	if primaryErr != nil {
+		if len(reviews) == 0 {
+			return nil, primaryErr
+		}
+		p.log.WithContext(ctx).Warnw("msg", "review blending: primary failed, serve due reviews only", "error", primaryErr)
+		return &RecommendationResult{Items: reviews, Source: reviewSource}, nil
+	}
+	if len(reviews) == 0 {
+		return primary, nil
+	}
+	blended := *primary
+	blended.Items = InterleaveReviewItems(primary.Items, reviews, limit)
+	return &blended, nil
+}
+
+// InterleaveReviewItems 将复习条目均匀插入主推荐条目中：第 i 条复习位于 (2i+1)·n/(2k) 处（n 为页大小、k 为复习条数），
+// 主推荐中与复习重复的视频被剔除，结果截断到 limit 条。
+func InterleaveReviewItems(primary, reviews []RecommendationItem, limit int) []RecommendationItem {
+	reviewIDs := make(map[string]struct{}, len(reviews))
+	for _, item := range reviews {
+		reviewIDs[item.VideoID] = struct{}{}
+	}
+	rest := make([]RecommendationItem, 0, len(primary))
+	for _, item := range primary {
+		if _, dup := reviewIDs[item.VideoID]; !dup {
+			rest = append(rest, item)
+		}
+	}
+	if len(reviews) > limit {
+		reviews = reviews[:limit]
+	}
+	total := min(limit, len(rest)+len(reviews))
+	out := make([]RecommendationItem, 0, total)
+	next := 0
+	for _, item := range rest {
+		for next < len(reviews) && len(out) < total && len(out) >= (2*next+1)*total/(2*len(reviews)) {
+			out = append(out, reviews[next])
+			next++
+		}
+		if len(out) >= total {
+			break
+		}
+		out = append(out, item)
+	}
+	for ; next < len(reviews) && len(out) < total; next++ {
+		out = append(out, reviews[next])
+	}
+	return out
+}
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultShadowBudget         = 300 * time.Millisecond
+	defaultShadowMaxConcurrency = 8
+
+	shadowOutcomeCompared      = "compared"
+	shadowOutcomeDropped       = "dropped"
+	shadowOutcomeTimeout       = "timeout"
+	shadowOutcomeError         = "error"
+	shadowOutcomePrimaryFailed = "primary_failed"
+)
+
+// ShadowConfig 控制候选推荐源的影子流量：主推荐照常返回，同一请求异步调用候选源并比对结果。
+type ShadowConfig struct {
+	Enabled bool
+	// Candidate 为候选推荐源，取值为 BlendingSources 中登记的来源名。
+	Candidate string
+	// Budget 为候选调用与投影命中检查的总超时，与用户请求的截止时间无关。
+	Budget time.Duration
+	// MaxConcurrency 为同时进行的影子调用上限，超出时直接丢弃，不排队。
+	MaxConcurrency int
+	// SampleRate 为参与比对的请求比例，按 TraceID 采样。
+	SampleRate float64
+}
+
+// ShadowComparison 为主推荐与候选推荐同一页结果的比对，同一视频重复出现时只取首次位置。
+type ShadowComparison struct {
+	PrimaryCount   int
+	CandidateCount int
+	Overlap        int
+	// Jaccard 为两侧视频集合的交并比，两侧均为空时为 1。
+	Jaccard float64
+	// RankCorrelation 为共同视频在两侧相对排名上的 Spearman 相关系数，取值 [-1, 1]；
+	// 共同视频少于 2 条时无法计算，RankCorrelationValid 为 false。
+	RankCorrelation      float64
+	RankCorrelationValid bool
+}
+
+// CompareRecommendations 计算两页推荐结果的重合度与排序一致性。
+func CompareRecommendations(primary, candidate []RecommendationItem) ShadowComparison {
+	primaryIDs := uniqueVideoIDs(primary)
+	candidateIDs := uniqueVideoIDs(candidate)
+	cmp := ShadowComparison{PrimaryCount: len(primaryIDs), CandidateCount: len(candidateIDs)}
+
+	candidateRank := make(map[string]int, len(candidateIDs))
+	for i, id := range candidateIDs {
+		candidateRank[id] = i
+	}
+	// 共同视频按主推荐顺序排列，并记下其在候选侧的原始位置。
+	var common []int
+	for _, id := range primaryIDs {
+		if pos, ok := candidateRank[id]; ok {
+			common = append(common, pos)
+		}
+	}
+	cmp.Overlap = len(common)
+	union := cmp.PrimaryCount + cmp.CandidateCount - cmp.Overlap
+	if union == 0 {
+		cmp.Jaccard = 1
+	} else {
+		cmp.Jaccard = float64(cmp.Overlap) / float64(union)
+	}
+
+	k := len(common)
+	if k < 2 {
+		return cmp
+	}
+	// 候选侧位置压缩为共同视频内部的名次，再按 ρ = 1 - 6Σd² / (k(k²-1)) 计算。
+	rankInCandidate := make(map[int]int, k)
+	positions := append([]int(nil), common...)
+	slices.Sort(positions)
+	for rank, pos := range positions {
+		rankInCandidate[pos] = rank
+	}
+	var sumSquares float64
+	for rank, pos := range common {
+		d := float64(rank - rankInCandidate[pos])
+		sumSquares += d * d
+	}
+	n := float64(k)
+	cmp.RankCorrelation = 1 - 6*sumSquares/(n*(n*n-1))
+	cmp.RankCorrelationValid = true
+	return cmp
+}
+
+// ShadowTraffic 异步调用候选推荐源并与主推荐比对，结果只写日志与指标，不影响返回给用户的内容。
+type ShadowTraffic struct {
+	candidate   RecommendationProvider
+	name        string
+	budget      time.Duration
+	sampleRate  float64
+	slots       chan struct{}
+	projections *repositories.FeedVideoProjectionRepository
+	metrics     *shadowMetrics
+	wg          sync.WaitGroup
+	log         *log.Helper
+}
+
+// NewShadowTraffic 构造影子流量比对器；未启用时返回 nil。候选源未登记时返回错误。
+// projections 为 nil 时不统计候选结果的投影缺失率。返回的 cleanup 等待进行中的影子调用结束。
+func NewShadowTraffic(cfg ShadowConfig, sources BlendingSources, projections *repositories.FeedVideoProjectionRepository, logger log.Logger) (*ShadowTraffic, func(), error) {
+	if !cfg.Enabled {
+		return nil, func() {}, nil
+	}
+	candidate, ok := sources[cfg.Candidate]
+	if !ok || candidate == nil {
+		return nil, nil, fmt.Errorf("shadow: unavailable candidate provider %q", cfg.Candidate)
+	}
+	if cfg.Budget <= 0 {
+		cfg.Budget = defaultShadowBudget
+	}
+	if cfg.MaxConcurrency <= 0 {
+		cfg.MaxConcurrency = defaultShadowMaxConcurrency
+	}
+	if cfg.SampleRate <= 0 || cfg.SampleRate > 1 {
+		cfg.SampleRate = 1
+	}
+	s := &ShadowTraffic{
+		candidate:   candidate,
+		name:        cfg.Candidate,
+		budget:      cfg.Budget,
+		sampleRate:  cfg.SampleRate,
+		slots:       make(chan struct{}, cfg.MaxConcurrency),
+		projections: projections,
+		metrics:     newShadowMetrics(),
+		log:         log.NewHelper(logger),
+	}
+	return s, s.Wait, nil
+}
+
+// Wait 阻塞直到所有进行中的影子调用结束。
+func (s *ShadowTraffic) Wait() {
+	if s == nil {
+		return
+	}
+	s.wg.Wait()
+}
+
+type shadowPrimary struct {
+	result *RecommendationResult
+	err    error
+}
+
+// start 在有空闲并发槽位时发起候选调用，返回用于交付主推荐结果的回调；翻页请求、未采样或槽位已满时返回 nil。
+// 游标由主推荐源签发，候选源无法解读，因此只比对第一页。
+// 候选调用脱离请求的取消信号，只受 budget 约束，因此用户请求结束不会中断比对。
+func (s *ShadowTraffic) start(ctx context.Context, input RecommendationInput) func(*RecommendationResult, error) {
+	if input.Cursor != "" || !sampleByTraceID(ctx, s.sampleRate) {
+		return nil
+	}
+	select {
+	case s.slots <- struct{}{}:
+	default:
+		s.metrics.recordOutcome(ctx, s.name, input.Scene, shadowOutcomeDropped)
+		return nil
+	}
+	primaryCh := make(chan shadowPrimary, 1)
+	s.wg.Add(1)
+	go s.run(context.WithoutCancel(ctx), input, primaryCh)
+	return func(result *RecommendationResult, err error) {
+		primaryCh <- shadowPrimary{result: result, err: err}
+	}
+}
+
+func (s *ShadowTraffic) run(ctx context.Context, input RecommendationInput, primaryCh <-chan shadowPrimary) {
+	defer func() {
+		<-s.slots
+		s.wg.Done()
+	}()
+	callCtx, cancel := context.WithTimeout(ctx, s.budget)
+	defer cancel()
+
+	started := time.Now()
+	candidate, err := s.candidate.GetFeed(callCtx, input)
+	latency := time.Since(started)
+	primary := <-primaryCh
+
+	logger := s.log.WithContext(ctx)
+	if err != nil {
+		outcome := shadowOutcomeError
+		if errors.Is(err, context.DeadlineExceeded) {
+			outcome = shadowOutcomeTimeout
+		}
+		s.metrics.recordOutcome(ctx, s.name, input.Scene, outcome)
+		logger.Warnw("msg", "shadow: candidate failed", "candidate", s.name, "scene", input.Scene, "outcome", outcome, "latency_ms", latency.Milliseconds(), "error", err)
+		return
+	}
+	if primary.err != nil || primary.result == nil {
+		s.metrics.recordOutcome(ctx, s.name, input.Scene, shadowOutcomePrimaryFailed)
+		return
+	}
+
+	cmp := CompareRecommendations(primary.result.Items, candidate.Items)
+	missRate, missErr := s.hydrationMissRate(callCtx, candidate.Items)
+	missValid := missErr == nil && s.projections != nil && cmp.CandidateCount > 0
+	s.metrics.recordComparison(ctx, s.name, input.Scene, cmp, missRate, missValid, latency)
+
+	keyvals := []any{
+		"msg", "shadow: comparison",
+		"candidate", s.name,
+		"primary_source", primary.result.Source,
+		"scene", input.Scene,
+		"primary_count", cmp.PrimaryCount,
+		"candidate_count", cmp.CandidateCount,
+		"overlap", cmp.Overlap,
+		"jaccard", cmp.Jaccard,
+		"latency_ms", latency.Milliseconds(),
+	}
+	if cmp.RankCorrelationValid {
+		keyvals = append(keyvals, "rank_correlation", cmp.RankCorrelation)
+	}
+	if missErr != nil {
+		keyvals = append(keyvals, "hydration_error", missErr)
+	} else if missValid {
+		keyvals = append(keyvals, "hydration_miss_rate", missRate)
+	}
+	logger.Infow(keyvals...)
+}
+
+// hydrationMissRate 返回候选结果中在 feed.videos_projection 找不到投影的比例；非法 video_id 视为缺失。
+func (s *ShadowTraffic) hydrationMissRate(ctx context.Context, items []RecommendationItem) (float64, error) {
+	if s.projections == nil || len(items) == 0 {
+		return 0, nil
+	}
+	ids := uniqueVideoIDs(items)
+	parsed := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if vid, err := uuid.Parse(id); err == nil {
+			parsed = append(parsed, vid)
+		}
+	}
+	records, err := s.projections.ListByIDs(ctx, nil, parsed)
+	if err != nil {
+		return 0, err
+	}
+	return float64(len(ids)-len(records)) / float64(len(ids)), nil
+}
+
+// shadowedProvider 在主推荐之外旁路调用候选源，返回值始终来自主推荐。
+type shadowedProvider struct {
+	primary RecommendationProvider
+	shadow  *ShadowTraffic
+}
+
+// NewShadowedRecommendationProvider 为主推荐挂上影子流量；shadow 为 nil 时原样返回主 Provider。
+func NewShadowedRecommendationProvider(primary RecommendationProvider, shadow *ShadowTraffic) RecommendationProvider {
+	if shadow == nil {
+		return primary
+	}
+	return &shadowedProvider{primary: primary, shadow: shadow}
+}
+
+// Source 返回主推荐来源标识。
+func (p *shadowedProvider) Source() string {
+	return p.primary.Source()
+}
+
+// GetFeed 先发起候选调用再同步调用主推荐，两者并行；主推荐结果交给影子协程比对后原样返回。
+func (p *shadowedProvider) GetFeed(ctx context.Context, input RecommendationInput) (*RecommendationResult, error) {
+	deliver := p.shadow.start(ctx, input)
+	result, err := p.primary.GetFeed(ctx, input)
+	if deliver != nil {
+		deliver(result, err)
+	}
+	return result, err
+}
+
+func uniqueVideoIDs(items []RecommendationItem) []string {
+	seen := make(map[string]struct{}, len(items))
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		if _, dup := seen[item.VideoID]; dup {
+			continue
+		}
+		seen[item.VideoID] = struct{}{}
+		ids = append(ids, item.VideoID)
+	}
+	return ids
+}
@@ -0,0 +1,287 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/services"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSource 返回固定条目，可模拟失败与超时。
+type fakeSource struct {
+	name  string
+	ids   []string
+	err   error
+	delay time.Duration
+	calls int
+}
+
+func (f *fakeSource) GetFeed(ctx context.Context, input services.RecommendationInput) (*services.RecommendationResult, error) {
+	f.calls++
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	items := make([]services.RecommendationItem, 0, len(f.ids))
+	for _, id := range f.ids {
+		if len(items) >= input.Limit {
+			break
+		}
+		items = append(items, services.RecommendationItem{VideoID: id, Reason: f.name + ".reason", Metadata: map[string]string{"rank": id}})
+	}
+	return &services.RecommendationResult{Items: items, Source: f.name}, nil
+}
+
+func (f *fakeSource) Source() string { return f.name }
+
+func sourceIDs(prefix string, n int) []string {
+	ids := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		ids = append(ids, fmt.Sprintf("%s-%d", prefix, i))
+	}
+	return ids
+}
+
+func newBlender(t *testing.T, strategy services.BlendStrategy, sources []services.BlendingSourceConfig, providers ...*fakeSource) *services.BlendingRecommendationProvider {
+	t.Helper()
+	registry := services.BlendingSources{}
+	for _, p := range providers {
+		registry[p.name] = p
+	}
+	blender, err := services.NewBlendingRecommendationProvider(registry, services.BlendingConfig{
+		Enabled:  true,
+		Strategy: strategy,
+		Sources:  sources,
+	}, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+	require.NotNil(t, blender)
+	return blender
+}
+
+func sourcesOf(items []services.RecommendationItem) []string {
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		out = append(out, item.Metadata["source"])
+	}
+	return out
+}
+
+func TestBlendingProvider_SlotsInterleaveByWeight(t *testing.T) {
+	main := &fakeSource{name: "main", ids: sourceIDs("m", 10)}
+	fresh := &fakeSource{name: "fresh", ids: sourceIDs("f", 10)}
+	blender := newBlender(t, services.BlendStrategySlots, []services.BlendingSourceConfig{
+		{Name: "main", Weight: 3},
+		{Name: "fresh", Weight: 1},
+	}, main, fresh)
+
+	result, err := blender.GetFeed(context.Background(), services.RecommendationInput{UserID: "u", Limit: 8})
+	require.NoError(t, err)
+	require.Equal(t, "blend", result.Source)
+	require.Equal(t, []string{"m-0", "m-1", "f-0", "m-2", "m-3", "m-4", "f-1", "m-5"}, videoIDs(result.Items))
+	require.Equal(t, []string{"main", "main", "fresh", "main", "main", "main", "fresh", "main"}, sourcesOf(result.Items))
+	// 原有元数据保留。
+	require.Equal(t, "f-0", result.Items[2].Metadata["rank"])
+}
+
+func TestBlendingProvider_DedupesAndBackfills(t *testing.T) {
+	main := &fakeSource{name: "main", ids: []string{"a", "b", "c", "d", "e", "g"}}
+	fresh := &fakeSource{name: "fresh", ids: []string{"a", "x"}}
+	blender := newBlender(t, services.BlendStrategySlots, []services.BlendingSourceConfig{
+		{Name: "main", Weight: 1},
+		{Name: "fresh", Weight: 1},
+	}, main, fresh)
+
+	result, err := blender.GetFeed(context.Background(), services.RecommendationInput{UserID: "u", Limit: 6})
+	require.NoError(t, err)
+	// fresh 的 "a" 与 main 重复被跳过，fresh 耗尽后由 main 补齐剩余槽位。
+	require.Equal(t, []string{"a", "x", "b", "c", "d", "e"}, videoIDs(result.Items))
+	require.Equal(t, "main", result.Items[0].Metadata["source"])
+	require.Equal(t, "fresh", result.Items[1].Metadata["source"])
+}
+
+func TestBlendingProvider_WeightedRoundRobin(t *testing.T) {
+	main := &fakeSource{name: "main", ids: sourceIDs("m", 10)}
+	fresh := &fakeSource{name: "fresh", ids: sourceIDs("f", 1)}
+	blender := newBlender(t, services.BlendStrategyWeightedRoundRobin, []services.BlendingSourceConfig{
+		{Name: "main", Weight: 1},
+		{Name: "fresh", Weight: 1},
+	}, main, fresh)
+
+	result, err := blender.GetFeed(context.Background(), services.RecommendationInput{UserID: "u", Limit: 5})
+	require.NoError(t, err)
+	require.Equal(t, []string{"m-0", "f-0", "m-1", "m-2", "m-3"}, videoIDs(result.Items))
+}
+
+func TestBlendingProvider_PartialFailureDegrades(t *testing.T) {
+	main := &fakeSource{name: "main", ids: sourceIDs("m", 10)}
+	broken := &fakeSource{name: "broken", err: errors.New("boom")}
+	slow := &fakeSource{name: "slow", ids: sourceIDs("s", 10), delay: time.Second}
+	blender := newBlender(t, services.BlendStrategySlots, []services.BlendingSourceConfig{
+		{Name: "main", Weight: 1},
+		{Name: "broken", Weight: 1},
+		{Name: "slow", Weight: 1, Budget: 10 * time.Millisecond},
+	}, main, broken, slow)
+
+	startedAt := time.Now()
+	result, err := blender.GetFeed(context.Background(), services.RecommendationInput{UserID: "u", Limit: 4})
+	require.NoError(t, err)
+	require.Less(t, time.Since(startedAt), 500*time.Millisecond)
+	require.Equal(t, []string{"m-0", "m-1", "m-2", "m-3"}, videoIDs(result.Items))
+}
+
+func TestBlendingProvider_AllSourcesFail(t *testing.T) {
+	blender := newBlender(t, services.BlendStrategySlots, []services.BlendingSourceConfig{
+		{Name: "a", Weight: 1},
+		{Name: "b", Weight: 1},
+	}, &fakeSource{name: "a", err: errors.New("a down")}, &fakeSource{name: "b", err: errors.New("b down")})
+
+	_, err := blender.GetFeed(context.Background(), services.RecommendationInput{UserID: "u", Limit: 4})
+	require.ErrorIs(t, err, services.ErrRecommendationUnavailable)
+}
+
+func TestBlendingProvider_NonHomeSceneUsesFirstSource(t *testing.T) {
+	main := &fakeSource{name: "main", ids: sourceIDs("m", 3)}
+	fresh := &fakeSource{name: "fresh", ids: sourceIDs("f", 3)}
+	blender := newBlender(t, services.BlendStrategySlots, []services.BlendingSourceConfig{
+		{Name: "main", Weight: 1},
+		{Name: "fresh", Weight: 1},
+	}, main, fresh)
+
+	result, err := blender.GetFeed(context.Background(), services.RecommendationInput{UserID: "u", Limit: 3, Scene: "explore"})
+	require.NoError(t, err)
+	require.Equal(t, "main", result.Source)
+	require.Zero(t, fresh.calls)
+}
+
+// pagedSource 以下标为游标翻页：itemCursors 时为每个条目设置逐条游标，ignoreCursor 模拟不支持翻页、总是从头返回的来源。
+type pagedSource struct {
+	name         string
+	ids          []string
+	itemCursors  bool
+	ignoreCursor bool
+}
+
+func (p *pagedSource) GetFeed(_ context.Context, input services.RecommendationInput) (*services.RecommendationResult, error) {
+	start := 0
+	if input.Cursor != "" && !p.ignoreCursor {
+		n, err := strconv.Atoi(input.Cursor)
+		if err != nil {
+			return nil, services.ErrInvalidPageToken
+		}
+		start = n
+	}
+	end := min(start+input.Limit, len(p.ids))
+	result := &services.RecommendationResult{Source: p.name}
+	for i := start; i < end; i++ {
+		item := services.RecommendationItem{VideoID: p.ids[i]}
+		if p.itemCursors {
+			item.Cursor = strconv.Itoa(i + 1)
+		}
+		result.Items = append(result.Items, item)
+	}
+	if end < len(p.ids) && !p.ignoreCursor {
+		result.NextCursor = strconv.Itoa(end)
+	}
+	return result, nil
+}
+
+func (p *pagedSource) Source() string { return p.name }
+
+func TestBlendingProvider_PagesThroughSources(t *testing.T) {
+	main := &pagedSource{name: "main", ids: sourceIDs("m", 7), itemCursors: true}
+	remote := &pagedSource{name: "remote", ids: sourceIDs("r", 5)}
+	fresh := &pagedSource{name: "fresh", ids: sourceIDs("f", 3), ignoreCursor: true}
+	registry := services.BlendingSources{"main": main, "remote": remote, "fresh": fresh}
+	blender, err := services.NewBlendingRecommendationProvider(registry, services.BlendingConfig{
+		Enabled: true,
+		Sources: []services.BlendingSourceConfig{
+			{Name: "main", Weight: 2},
+			{Name: "remote", Weight: 1},
+			{Name: "fresh", Weight: 1},
+		},
+	}, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	first, err := blender.GetFeed(ctx, services.RecommendationInput{UserID: "u", Limit: 4})
+	require.NoError(t, err)
+	require.Equal(t, []string{"m-0", "r-0", "f-0", "m-1"}, videoIDs(first.Items))
+	require.NotEmpty(t, first.NextCursor)
+
+	second, err := blender.GetFeed(ctx, services.RecommendationInput{UserID: "u", Limit: 4, Cursor: first.NextCursor})
+	require.NoError(t, err)
+	require.Equal(t, []string{"m-2", "r-1", "f-1", "m-3"}, videoIDs(second.Items))
+	require.Equal(t, "blend", second.Source)
+	require.NotEmpty(t, second.NextCursor)
+
+	// 继续翻页直至读完：各来源按原顺序恰好出现一次。
+	bySource := map[string][]string{}
+	for _, page := range []*services.RecommendationResult{first, second} {
+		for _, item := range page.Items {
+			bySource[item.Metadata["source"]] = append(bySource[item.Metadata["source"]], item.VideoID)
+		}
+	}
+	cursor := second.NextCursor
+	for i := 0; cursor != "" && i < 10; i++ {
+		page, err := blender.GetFeed(ctx, services.RecommendationInput{UserID: "u", Limit: 4, Cursor: cursor})
+		require.NoError(t, err)
+		for _, item := range page.Items {
+			bySource[item.Metadata["source"]] = append(bySource[item.Metadata["source"]], item.VideoID)
+		}
+		cursor = page.NextCursor
+	}
+	require.Empty(t, cursor)
+	require.Equal(t, map[string][]string{"main": main.ids, "remote": remote.ids, "fresh": fresh.ids}, bySource)
+}
+
+func TestBlendingProvider_InvalidCursor(t *testing.T) {
+	blender := newBlender(t, services.BlendStrategySlots, []services.BlendingSourceConfig{
+		{Name: "main", Weight: 1},
+	}, &fakeSource{name: "main", ids: sourceIDs("m", 3)})
+
+	for _, cursor := range []string{"%%%", "bm90LWpzb24"} {
+		_, err := blender.GetFeed(context.Background(), services.RecommendationInput{UserID: "u", Limit: 3, Cursor: cursor})
+		require.ErrorIs(t, err, services.ErrInvalidPageToken, cursor)
+	}
+}
+
+func TestNewBlendingRecommendationProvider_Config(t *testing.T) {
+	logger := log.NewStdLogger(io.Discard)
+	registry := services.BlendingSources{"main": &fakeSource{name: "main"}}
+
+	disabled, err := services.NewBlendingRecommendationProvider(registry, services.BlendingConfig{}, logger)
+	require.NoError(t, err)
+	require.Nil(t, disabled)
+
+	// 未登记的来源被跳过。
+	blender, err := services.NewBlendingRecommendationProvider(registry, services.BlendingConfig{Enabled: true, Sources: []services.BlendingSourceConfig{
+		{Name: "main", Weight: 1},
+		{Name: "review", Weight: 1},
+	}}, logger)
+	require.NoError(t, err)
+	require.NotNil(t, blender)
+
+	for name, cfg := range map[string]services.BlendingConfig{
+		"no sources":     {Enabled: true, Sources: []services.BlendingSourceConfig{{Name: "review", Weight: 1}}},
+		"zero weight":    {Enabled: true, Sources: []services.BlendingSourceConfig{{Name: "main"}}},
+		"duplicate name": {Enabled: true, Sources: []services.BlendingSourceConfig{{Name: "main", Weight: 1}, {Name: "main", Weight: 2}}},
+		"bad strategy":   {Enabled: true, Strategy: "random", Sources: []services.BlendingSourceConfig{{Name: "main", Weight: 1}}},
+	} {
+		_, err := services.NewBlendingRecommendationProvider(registry, cfg, logger)
+		require.Error(t, err, name)
+	}
+}
@@ -0,0 +1,147 @@
+package services_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/services"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+)
+
+// 规则中的 video_id 为小写 UUID，便于与推荐条目直接比较。
+const (
+	vidA = "00000000-0000-0000-0000-00000000000a"
+	vidB = "00000000-0000-0000-0000-00000000000b"
+	vidC = "00000000-0000-0000-0000-00000000000c"
+	vidD = "00000000-0000-0000-0000-00000000000d"
+	vidE = "00000000-0000-0000-0000-00000000000e"
+	vidX = "00000000-0000-0000-0000-0000000000ff"
+)
+
+func recItems(ids ...string) []services.RecommendationItem {
+	items := make([]services.RecommendationItem, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, services.RecommendationItem{VideoID: id, Reason: "mock.random"})
+	}
+	return items
+}
+
+func curationRule(id, action, videoID string, position int32, mutate ...func(*po.CurationRule)) *po.CurationRule {
+	rule := &po.CurationRule{RuleID: id, Action: action, VideoID: videoID}
+	if position > 0 {
+		rule.Position = &position
+	}
+	for _, fn := range mutate {
+		fn(rule)
+	}
+	return rule
+}
+
+func strPtr(value string) *string { return &value }
+
+func TestCurationEngine_BlockBoostPin(t *testing.T) {
+	// 规则按优先级降序排列，与从数据库加载时一致。
+	engine := services.NewStaticCurationEngine([]*po.CurationRule{
+		curationRule("pin-x", services.CurationActionPin, vidX, 1),
+		curationRule("pin-a", services.CurationActionPin, vidA, 1),
+		curationRule("boost-e", services.CurationActionBoost, vidE, 2),
+		curationRule("block-c", services.CurationActionBlock, vidC, 0),
+	}, nil, log.NewStdLogger(io.Discard))
+
+	scope := services.CurationScope{Segments: []string{services.CurationSegmentUser}, Reorder: true}
+	out := engine.Apply(context.Background(), scope, recItems(vidA, vidB, vidC, vidD, vidE), 5)
+	// c 被屏蔽；e 提到第 2 位；x 插入第 1 位，a 的第 1 位被占用后顺延到第 2 位。
+	require.Equal(t, []string{vidX, vidA, vidE, vidB, vidD}, videoIDs(out))
+	require.Equal(t, "curation.pin", out[0].Reason)
+	require.Equal(t, "pin-x", out[0].Metadata["curation_rule_id"])
+	require.Equal(t, "boost-e", out[2].Metadata["curation_rule_id"])
+	require.Equal(t, "mock.random", out[2].Reason)
+
+	// 置顶插入后截断到 limit。
+	out = engine.Apply(context.Background(), scope, recItems(vidA, vidB, vidC, vidD, vidE), 3)
+	require.Equal(t, []string{vidX, vidA, vidE}, videoIDs(out))
+
+	// 翻页请求只执行屏蔽。
+	scope.Reorder = false
+	out = engine.Apply(context.Background(), scope, recItems(vidA, vidB, vidC, vidD, vidE), 5)
+	require.Equal(t, []string{vidA, vidB, vidD, vidE}, videoIDs(out))
+}
+
+func TestCurationEngine_BlockWinsAndPinBeyondPage(t *testing.T) {
+	engine := services.NewStaticCurationEngine([]*po.CurationRule{
+		curationRule("pin-b", services.CurationActionPin, vidB, 1),
+		curationRule("pin-x", services.CurationActionPin, vidX, 10),
+		curationRule("block-b", services.CurationActionBlock, vidB, 0),
+	}, nil, log.NewStdLogger(io.Discard))
+
+	out := engine.Apply(context.Background(), services.CurationScope{Reorder: true}, recItems(vidA, vidB, vidC), 10)
+	require.Equal(t, []string{vidA, vidC, vidX}, videoIDs(out))
+}
+
+func TestCurationEngine_ScopeAndWindow(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	engine := services.NewStaticCurationEngine([]*po.CurationRule{
+		curationRule("ja", services.CurationActionBlock, vidA, 0, func(r *po.CurationRule) { r.Locale = strPtr("ja") }),
+		curationRule("premium", services.CurationActionBlock, vidB, 0, func(r *po.CurationRule) { r.UserSegment = strPtr("premium") }),
+		curationRule("home", services.CurationActionBlock, vidC, 0, func(r *po.CurationRule) { r.Scene = strPtr(services.SceneHome) }),
+		curationRule("future", services.CurationActionBlock, vidD, 0, func(r *po.CurationRule) { r.StartsAt = timeRef(now.Add(time.Hour)) }),
+		curationRule("ended", services.CurationActionBlock, vidE, 0, func(r *po.CurationRule) { r.EndsAt = timeRef(now) }),
+	}, func() time.Time { return now }, log.NewStdLogger(io.Discard))
+
+	all := recItems(vidA, vidB, vidC, vidD, vidE)
+	cases := []struct {
+		name  string
+		scope services.CurationScope
+		want  []string
+	}{
+		{"home ja-JP premium", services.CurationScope{Locale: "ja-JP", Segments: []string{"user", "premium"}}, []string{vidD, vidE}},
+		{"explore en user", services.CurationScope{Scene: "explore", Locale: "en-US", Segments: []string{"user"}}, []string{vidA, vidB, vidC, vidD, vidE}},
+		{"explicit home scene", services.CurationScope{Scene: services.SceneHome, Locale: "JA", Segments: []string{"guest"}}, []string{vidB, vidD, vidE}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, videoIDs(engine.Apply(context.Background(), tc.scope, all, 10)))
+		})
+	}
+}
+
+func timeRef(t time.Time) *time.Time { return &t }
+
+func TestCurationEngine_CacheScope(t *testing.T) {
+	engine := services.NewStaticCurationEngine([]*po.CurationRule{
+		curationRule("r1", services.CurationActionBlock, vidA, 0, func(r *po.CurationRule) {
+			r.Scene = strPtr("explore")
+			r.Locale = strPtr("ja")
+		}),
+		curationRule("r2", services.CurationActionBlock, vidB, 0, func(r *po.CurationRule) { r.Locale = strPtr("ja-JP") }),
+		curationRule("r3", services.CurationActionBlock, vidC, 0, func(r *po.CurationRule) { r.Scene = strPtr(services.SceneHome) }),
+	}, nil, log.NewStdLogger(io.Discard))
+
+	cases := []struct {
+		scene, locale string
+		wantScene     string
+		wantLocale    string
+	}{
+		{"explore", "ja-JP", "explore", "ja-jp"},
+		{"explore", "ja-KS", "explore", "ja"},
+		{"", "en-US", services.SceneHome, ""},
+		{services.SceneHome, "", services.SceneHome, ""},
+		{"random-1", "xx-garbage-1", "*", ""},
+		{"random-2", "xx-garbage-2", "*", ""},
+	}
+	for _, tc := range cases {
+		scene, locale := engine.CacheScope(tc.scene, tc.locale)
+		require.Equal(t, tc.wantScene, scene, tc)
+		require.Equal(t, tc.wantLocale, locale, tc)
+	}
+
+	var nilEngine *services.CurationEngine
+	scene, locale := nilEngine.CacheScope("anything", "fr")
+	require.Equal(t, "*", scene)
+	require.Empty(t, locale)
+}
@@ -0,0 +1,149 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/services"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+)
+
+func newAssigner(t *testing.T, allowForced bool, defs ...services.ExperimentDefinition) *services.ExperimentAssigner {
+	t.Helper()
+	assigner, err := services.NewExperimentAssigner(services.ExperimentConfig{
+		Enabled:             true,
+		AllowForcedVariants: allowForced,
+		Experiments:         defs,
+	}, services.BlendingSources{}, services.BlendingConfig{}, nil, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+	require.NotNil(t, assigner)
+	return assigner
+}
+
+func TestExperimentBucket_DeterministicAndBalanced(t *testing.T) {
+	require.Equal(t, services.ExperimentBucket("exp", "user-1", 100), services.ExperimentBucket("exp", "user-1", 100))
+
+	assigner := newAssigner(t, false, services.ExperimentDefinition{
+		Key: "ranker",
+		Variants: []services.ExperimentVariantConfig{
+			{Name: "control", Weight: 3},
+			{Name: "treatment", Weight: 1},
+		},
+	})
+	counts := map[string]int{}
+	for i := 0; i < 10000; i++ {
+		decision := assigner.Assign(context.Background(), fmt.Sprintf("user-%d", i), nil)
+		require.Len(t, decision.Assignments, 1)
+		counts[decision.Assignments[0].Variant]++
+	}
+	// 3:1 的权重，允许 ±2% 的偏差。
+	require.InDelta(t, 7500, counts["control"], 200)
+	require.InDelta(t, 2500, counts["treatment"], 200)
+
+	// 修改盐后部分用户换组。
+	moved := 0
+	for i := 0; i < 1000; i++ {
+		userID := fmt.Sprintf("user-%d", i)
+		if services.ExperimentBucket("ranker", userID, 4) != services.ExperimentBucket("ranker-v2", userID, 4) {
+			moved++
+		}
+	}
+	require.Positive(t, moved)
+}
+
+func TestExperimentAssigner_ForcedVariants(t *testing.T) {
+	def := services.ExperimentDefinition{
+		Key: "ranker",
+		Variants: []services.ExperimentVariantConfig{
+			{Name: "control", Weight: 1},
+			{Name: "qa_only"},
+		},
+	}
+	ctx := context.Background()
+
+	assigner := newAssigner(t, true, def)
+	decision := assigner.Assign(ctx, "user-1", map[string]string{"ranker": "qa_only"})
+	require.Equal(t, []services.ExperimentAssignment{{Experiment: "ranker", Variant: "qa_only", Forced: true}}, decision.Assignments)
+	require.Equal(t, map[string]string{"ranker": "qa_only"}, decision.Variants())
+	require.Equal(t, map[string]string{"exp.ranker": "qa_only"}, decision.Attributes())
+
+	// 未知分组回退到哈希分桶。
+	decision = assigner.Assign(ctx, "user-1", map[string]string{"ranker": "missing"})
+	require.Equal(t, []services.ExperimentAssignment{{Experiment: "ranker", Variant: "control"}}, decision.Assignments)
+
+	// 未允许强制时忽略请求头。
+	decision = newAssigner(t, false, def).Assign(ctx, "user-1", map[string]string{"ranker": "qa_only"})
+	require.Equal(t, "control", decision.Assignments[0].Variant)
+	require.False(t, decision.Assignments[0].Forced)
+
+	// 访客与未启用实验均不分组。
+	require.Empty(t, assigner.Assign(ctx, "", nil).Assignments)
+	var disabled *services.ExperimentAssigner
+	require.Empty(t, disabled.Assign(ctx, "user-1", nil).Assignments)
+}
+
+func TestNewExperimentAssigner_Validation(t *testing.T) {
+	logger := log.NewStdLogger(io.Discard)
+	build := func(defs ...services.ExperimentDefinition) error {
+		_, err := services.NewExperimentAssigner(services.ExperimentConfig{Enabled: true, Experiments: defs},
+			services.BlendingSources{"mock": &fakeSource{name: "mock"}}, services.BlendingConfig{}, nil, logger)
+		return err
+	}
+	variant := func(name string, weight uint32) services.ExperimentVariantConfig {
+		return services.ExperimentVariantConfig{Name: name, Weight: weight}
+	}
+
+	assigner, err := services.NewExperimentAssigner(services.ExperimentConfig{}, nil, services.BlendingConfig{}, nil, logger)
+	require.NoError(t, err)
+	require.Nil(t, assigner)
+
+	require.NoError(t, build(services.ExperimentDefinition{Key: "a", Variants: []services.ExperimentVariantConfig{
+		variant("control", 1),
+		{Name: "mock_only", Weight: 1, Provider: "mock"},
+		{Name: "blend", Weight: 1, BlendSources: []services.BlendingSourceConfig{{Name: "mock", Weight: 1}}},
+		{Name: "no_rerank", Weight: 1, Rerank: &services.RerankConfig{}},
+	}}))
+	require.Error(t, build(services.ExperimentDefinition{Key: "a", Variants: []services.ExperimentVariantConfig{variant("x", 0)}}))
+	require.Error(t, build(services.ExperimentDefinition{Key: "a", Variants: []services.ExperimentVariantConfig{variant("x", 1), variant("x", 1)}}))
+	require.Error(t, build(
+		services.ExperimentDefinition{Key: "a", Variants: []services.ExperimentVariantConfig{variant("x", 1)}},
+		services.ExperimentDefinition{Key: "a", Variants: []services.ExperimentVariantConfig{variant("y", 1)}},
+	))
+	require.Error(t, build(services.ExperimentDefinition{Key: "a", Variants: []services.ExperimentVariantConfig{{Name: "x", Weight: 1, Provider: "unknown"}}}))
+}
+
+func TestExperimentAssigner_VariantChainFallsBack(t *testing.T) {
+	logger := log.NewStdLogger(io.Discard)
+	local := &fakeSource{name: "mock", ids: []string{"m1", "m2"}}
+	remote := &fakeSource{name: "remote", err: services.ErrRecommendationUnavailable}
+	assigner, err := services.NewExperimentAssigner(services.ExperimentConfig{
+		Enabled:             true,
+		AllowForcedVariants: true,
+		Experiments: []services.ExperimentDefinition{{
+			Key: "ranker",
+			Variants: []services.ExperimentVariantConfig{
+				{Name: "control", Weight: 1},
+				{Name: "remote", Provider: "remote"},
+			},
+		}},
+	}, services.BlendingSources{"mock": local, "remote": remote}, services.BlendingConfig{},
+		services.NewRecommendationChain(local, nil, nil, services.ReviewQueueConfig{}, logger), logger)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.Nil(t, assigner.Assign(ctx, "user-1", nil).Provider())
+
+	// 分组的外部推荐失败时与默认链路一样回退到本地链路。
+	provider := assigner.Assign(ctx, "user-1", map[string]string{"ranker": "remote"}).Provider()
+	require.NotNil(t, provider)
+	result, err := provider.GetFeed(ctx, services.RecommendationInput{UserID: "user-1", Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, 1, remote.calls)
+	require.Equal(t, 1, local.calls)
+	require.Len(t, result.Items, 2)
+	require.Equal(t, "m1", result.Items[0].VideoID)
+}
@@ -0,0 +1,143 @@
+package services_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/models/vo"
+	"github.com/bionicotaku/lingo-services-feed/internal/services"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+)
+
+func feedItemIDs(items []vo.FeedItem) []string {
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		out = append(out, item.VideoID)
+	}
+	return out
+}
+
+func TestMMRStage_SpreadsSimilarTags(t *testing.T) {
+	items := []vo.FeedItem{
+		{VideoID: "a", Tags: []string{"travel", "food"}},
+		{VideoID: "b", Tags: []string{"travel", "food"}},
+		{VideoID: "c", Tags: []string{"music"}},
+		{VideoID: "d"},
+	}
+
+	out := services.NewMMRStage(services.MMRConfig{Lambda: 0.5}).Rerank(items)
+	// b 与 a 标签完全相同，被无标签的 d 与不相似的 c 越过。
+	require.Equal(t, []string{"a", "c", "d", "b"}, feedItemIDs(out))
+
+	// lambda=1 只看相关性，保持原顺序。
+	out = services.NewMMRStage(services.MMRConfig{Lambda: 1}).Rerank(items)
+	require.Equal(t, []string{"a", "b", "c", "d"}, feedItemIDs(out))
+}
+
+func TestCreatorCapStage_DemotesOverflow(t *testing.T) {
+	items := []vo.FeedItem{
+		{VideoID: "a1", CreatorID: "a"},
+		{VideoID: "a2", CreatorID: "a"},
+		{VideoID: "a3", CreatorID: "a"},
+		{VideoID: "x"},
+		{VideoID: "b1", CreatorID: "b"},
+		{VideoID: "a4", CreatorID: "a"},
+	}
+
+	out := services.NewCreatorCapStage(services.CreatorCapConfig{MaxPerCreator: 2}).Rerank(items)
+	require.Equal(t, []string{"a1", "a2", "x", "b1", "a3", "a4"}, feedItemIDs(out))
+}
+
+func TestLanguageRunStage_BreaksLongRuns(t *testing.T) {
+	items := []vo.FeedItem{
+		{VideoID: "e1", Language: "en"},
+		{VideoID: "e2", Language: "en"},
+		{VideoID: "e3", Language: "en"},
+		{VideoID: "u"},
+		{VideoID: "j1", Language: "ja"},
+		{VideoID: "e4", Language: "en"},
+	}
+
+	out := services.NewLanguageRunStage(services.LanguageRunConfig{MaxConsecutive: 2}).Rerank(items)
+	// 未知语言的 u 打断连续并被提前。
+	require.Equal(t, []string{"e1", "e2", "u", "e3", "j1", "e4"}, feedItemIDs(out))
+
+	// 找不到其他语言时按原顺序输出。
+	sameLang := []vo.FeedItem{{VideoID: "1", Language: "en"}, {VideoID: "2", Language: "en"}, {VideoID: "3", Language: "en"}}
+	out = services.NewLanguageRunStage(services.LanguageRunConfig{MaxConsecutive: 1}).Rerank(sameLang)
+	require.Equal(t, []string{"1", "2", "3"}, feedItemIDs(out))
+}
+
+func TestDurationSpreadStage_AlternatesBuckets(t *testing.T) {
+	short := (30 * time.Second).Microseconds()
+	long := (10 * time.Minute).Microseconds()
+	items := []vo.FeedItem{
+		{VideoID: "s1", DurationMicros: short},
+		{VideoID: "s2", DurationMicros: short},
+		{VideoID: "s3", DurationMicros: short},
+		{VideoID: "l1", DurationMicros: long},
+		{VideoID: "s4", DurationMicros: short},
+	}
+
+	out := services.NewDurationSpreadStage(services.DurationSpreadConfig{
+		Boundaries:     []time.Duration{time.Minute, 5 * time.Minute},
+		MaxConsecutive: 1,
+	}).Rerank(items)
+	require.Equal(t, []string{"s1", "l1", "s2", "s3", "s4"}, feedItemIDs(out))
+}
+
+func TestRerankPipeline_Config(t *testing.T) {
+	logger := log.NewStdLogger(io.Discard)
+	require.Nil(t, services.NewRerankPipeline(services.RerankConfig{}, logger))
+
+	pipeline := services.NewRerankPipeline(services.RerankConfig{
+		CreatorCap:  services.CreatorCapConfig{Enabled: true, MaxPerCreator: 1},
+		LanguageRun: services.LanguageRunConfig{Enabled: true, MaxConsecutive: 1},
+	}, logger)
+	require.NotNil(t, pipeline)
+
+	items := []vo.FeedItem{
+		{VideoID: "a1", CreatorID: "a", Language: "en"},
+		{VideoID: "a2", CreatorID: "a", Language: "ja"},
+		{VideoID: "b1", CreatorID: "b", Language: "en"},
+		{VideoID: "c1", CreatorID: "c", Language: "ja"},
+	}
+	out := pipeline.Apply(context.Background(), items)
+	// creator_cap：a1 b1 c1 a2；language_run：b1 与 a1 同为 en，c1 提前。
+	require.Equal(t, []string{"a1", "c1", "b1", "a2"}, feedItemIDs(out))
+
+	// nil 流水线原样返回。
+	var disabled *services.RerankPipeline
+	require.Equal(t, items, disabled.Apply(context.Background(), items))
+}
+
+func TestRerankPipeline_KeepsCurationPinInSlot(t *testing.T) {
+	logger := log.NewStdLogger(io.Discard)
+	engine := services.NewStaticCurationEngine([]*po.CurationRule{
+		curationRule("pin-x", services.CurationActionPin, vidX, 3),
+	}, nil, logger)
+	scope := services.CurationScope{Segments: []string{services.CurationSegmentUser}, Reorder: true}
+	recs := engine.Apply(context.Background(), scope, recItems(vidA, vidB, vidC, vidD), 5)
+	require.Equal(t, []string{vidA, vidB, vidX, vidC, vidD}, videoIDs(recs))
+	require.Equal(t, "3", recs[2].Metadata[services.CurationPinSlotKey])
+
+	creators := map[string]string{vidA: "a", vidB: "a", vidX: "x", vidC: "c", vidD: "d"}
+	items := make([]vo.FeedItem, 0, len(recs))
+	for _, rec := range recs {
+		item := vo.FeedItem{VideoID: rec.VideoID, CreatorID: creators[rec.VideoID]}
+		item.ApplyRecommendation(rec.Reason, rec.Metadata, rec.Score)
+		items = append(items, item)
+	}
+
+	pipeline := services.NewRerankPipeline(services.RerankConfig{
+		CreatorCap: services.CreatorCapConfig{Enabled: true, MaxPerCreator: 1},
+	}, logger)
+	out := pipeline.Apply(context.Background(), items)
+	// creator_cap 把 b 移到末尾，其余条目前移，置顶的 x 仍在第 3 位。
+	require.Equal(t, []string{vidA, vidC, vidX, vidD, vidB}, feedItemIDs(out))
+}
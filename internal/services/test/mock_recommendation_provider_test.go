@@ -0,0 +1,31 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/services"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockRequestSeed(t *testing.T) {
+	morning := time.Date(2025, 3, 1, 1, 0, 0, 0, time.UTC)
+	evening := time.Date(2025, 3, 1, 23, 0, 0, 0, time.UTC)
+	nextDay := time.Date(2025, 3, 2, 1, 0, 0, 0, time.UTC)
+
+	seed := services.MockRequestSeed(42, "user-1", morning, false)
+	require.Len(t, seed, 16)
+
+	// 同一用户同一 UTC 日内种子不变，跨天、换用户或改全局种子后变化。
+	require.Equal(t, seed, services.MockRequestSeed(42, "user-1", evening, false))
+	require.Equal(t, seed, services.MockRequestSeed(42, "user-1", evening.In(time.FixedZone("UTC+8", 8*3600)), false))
+	require.NotEqual(t, seed, services.MockRequestSeed(42, "user-1", nextDay, false))
+	require.NotEqual(t, seed, services.MockRequestSeed(42, "user-2", morning, false))
+	require.NotEqual(t, seed, services.MockRequestSeed(43, "user-1", morning, false))
+
+	// fixed 时忽略日期。
+	fixed := services.MockRequestSeed(42, "user-1", morning, true)
+	require.Equal(t, fixed, services.MockRequestSeed(42, "user-1", nextDay, true))
+	require.NotEqual(t, seed, fixed)
+}
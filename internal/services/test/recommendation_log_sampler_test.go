@@ -0,0 +1,79 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/services"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func traceContext(traceID trace.TraceID, sampled bool) context.Context {
+	flags := trace.TraceFlags(0)
+	if sampled {
+		flags = trace.FlagsSampled
+	}
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     trace.SpanID{1},
+		TraceFlags: flags,
+	})
+	return trace.ContextWithSpanContext(context.Background(), sc)
+}
+
+func TestRecommendationLogSampler_DisabledLogsEverything(t *testing.T) {
+	sampler := services.NewRecommendationLogSampler(services.RecommendationLogSamplingConfig{DefaultRate: 0})
+	require.True(t, sampler.ShouldLog(context.Background(), services.RecommendationLogSample{Source: "mock"}))
+}
+
+func TestRecommendationLogSampler_ForcedCases(t *testing.T) {
+	sampler := services.NewRateRecommendationLogSampler(services.RecommendationLogSamplingConfig{
+		Enabled:      true,
+		DefaultRate:  0,
+		DebugUserIDs: []string{"debug-user"},
+	})
+	ctx := context.Background()
+
+	require.False(t, sampler.ShouldLog(ctx, services.RecommendationLogSample{Source: "mock", UserID: "user-1"}))
+	require.True(t, sampler.ShouldLog(ctx, services.RecommendationLogSample{Source: "mock", ErrorKind: "recommendation_unavailable"}))
+	require.True(t, sampler.ShouldLog(ctx, services.RecommendationLogSample{Source: "mock", Partial: true}))
+	require.True(t, sampler.ShouldLog(ctx, services.RecommendationLogSample{Source: "mock", UserID: "debug-user"}))
+	require.True(t, sampler.ShouldLog(traceContext(trace.TraceID{0xff}, true), services.RecommendationLogSample{Source: "mock"}))
+}
+
+func TestRecommendationLogSampler_RulePrecedence(t *testing.T) {
+	sampler := services.NewRateRecommendationLogSampler(services.RecommendationLogSamplingConfig{
+		Enabled:     true,
+		DefaultRate: 0.5,
+		Rules: []services.RecommendationLogSamplingRule{
+			{Scene: "home", Rate: 0.2},
+			{Source: "guest", Rate: 0.01},
+			{Source: "guest", Scene: "home", Rate: 0.3},
+			{Source: "mock", Rate: 7},
+		},
+	})
+
+	require.InDelta(t, 0.3, sampler.Rate("guest", "home"), 1e-9)
+	require.InDelta(t, 0.01, sampler.Rate("guest", "other"), 1e-9)
+	require.InDelta(t, 0.2, sampler.Rate("real", "home"), 1e-9)
+	require.InDelta(t, 0.5, sampler.Rate("real", "other"), 1e-9)
+	require.InDelta(t, 1.0, sampler.Rate("mock", ""), 1e-9)
+}
+
+func TestRecommendationLogSampler_TraceIDIsDeterministic(t *testing.T) {
+	sampler := services.NewRateRecommendationLogSampler(services.RecommendationLogSamplingConfig{
+		Enabled:     true,
+		DefaultRate: 0.5,
+	})
+	sample := services.RecommendationLogSample{Source: "mock"}
+
+	// 低 8 字节较小的 TraceID 落在阈值内，较大的落在阈值外，与 TraceIDRatioBased 判定一致。
+	low := trace.TraceID{0: 0xff, 8: 0x10}
+	high := trace.TraceID{0: 0x01, 8: 0xf0}
+	for range 10 {
+		require.True(t, sampler.ShouldLog(traceContext(low, false), sample))
+		require.False(t, sampler.ShouldLog(traceContext(high, false), sample))
+	}
+}
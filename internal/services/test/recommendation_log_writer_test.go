@@ -0,0 +1,165 @@
+package services_test
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/services"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLogStore 记录写入的日志；release 非空时 InsertBatch 先通知 started 再阻塞，以模拟慢库。
+type fakeLogStore struct {
+	mu      sync.Mutex
+	single  []po.FeedRecommendationLog
+	batches [][]po.FeedRecommendationLog
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingLogStore() *fakeLogStore {
+	return &fakeLogStore{started: make(chan struct{}, 16), release: make(chan struct{})}
+}
+
+func (s *fakeLogStore) Insert(_ context.Context, _ txmanager.Session, entry po.FeedRecommendationLog) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.single = append(s.single, entry)
+	return nil
+}
+
+func (s *fakeLogStore) InsertBatch(_ context.Context, _ txmanager.Session, entries []po.FeedRecommendationLog) (int64, error) {
+	if s.release != nil {
+		s.started <- struct{}{}
+		<-s.release
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches, append([]po.FeedRecommendationLog(nil), entries...))
+	return int64(len(entries)), nil
+}
+
+func (s *fakeLogStore) written() []po.FeedRecommendationLog {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []po.FeedRecommendationLog
+	for _, batch := range s.batches {
+		out = append(out, batch...)
+	}
+	return out
+}
+
+func logEntry(limit int) po.FeedRecommendationLog {
+	return po.NewFeedRecommendationLog(po.FeedRecommendationLogParams{
+		UserIDHash:           "hash-1",
+		RequestLimit:         limit,
+		RecommendationSource: "mock",
+	})
+}
+
+func TestRecommendationLogWriter_FlushesQueueOnShutdown(t *testing.T) {
+	store := &fakeLogStore{}
+	writer, cleanup := services.NewRecommendationLogWriter(store, nil, services.RecommendationLogWriterConfig{
+		Async:         true,
+		QueueSize:     64,
+		BatchSize:     8,
+		FlushInterval: time.Hour,
+	}, log.NewStdLogger(io.Discard))
+
+	for i := 1; i <= 20; i++ {
+		writer.Write(context.Background(), logEntry(i))
+	}
+	cleanup()
+
+	written := store.written()
+	require.Len(t, written, 20)
+	for i, entry := range written {
+		require.Equal(t, int32(i+1), entry.RequestLimit)
+	}
+	for _, batch := range store.batches {
+		require.LessOrEqual(t, len(batch), 8)
+	}
+
+	// 关闭后的写入直接丢弃，不会 panic。
+	writer.Write(context.Background(), logEntry(99))
+	require.Len(t, store.written(), 20)
+}
+
+func TestRecommendationLogWriter_FlushesOnInterval(t *testing.T) {
+	store := &fakeLogStore{}
+	writer, cleanup := services.NewRecommendationLogWriter(store, nil, services.RecommendationLogWriterConfig{
+		Async:         true,
+		BatchSize:     100,
+		FlushInterval: 10 * time.Millisecond,
+	}, log.NewStdLogger(io.Discard))
+	defer cleanup()
+
+	writer.Write(context.Background(), logEntry(1))
+	require.Eventually(t, func() bool { return len(store.written()) == 1 }, time.Second, 5*time.Millisecond)
+}
+
+func TestRecommendationLogWriter_DropWhenQueueFull(t *testing.T) {
+	store := newBlockingLogStore()
+	writer, cleanup := services.NewRecommendationLogWriter(store, nil, services.RecommendationLogWriterConfig{
+		Async:         true,
+		QueueSize:     2,
+		BatchSize:     1,
+		FlushInterval: time.Hour,
+		Overflow:      services.LogOverflowDrop,
+	}, log.NewStdLogger(io.Discard))
+
+	// 第一条被后台协程取走并阻塞在 InsertBatch，队列再容纳两条，其余丢弃。
+	writer.Write(context.Background(), logEntry(1))
+	<-store.started
+	for i := 2; i <= 10; i++ {
+		writer.Write(context.Background(), logEntry(i))
+	}
+	close(store.release)
+	cleanup()
+
+	written := store.written()
+	require.Len(t, written, 3)
+	require.Equal(t, int32(3), written[2].RequestLimit)
+}
+
+func TestRecommendationLogWriter_BlockHonorsContext(t *testing.T) {
+	store := newBlockingLogStore()
+	writer, cleanup := services.NewRecommendationLogWriter(store, nil, services.RecommendationLogWriterConfig{
+		Async:         true,
+		QueueSize:     1,
+		BatchSize:     1,
+		FlushInterval: time.Hour,
+		Overflow:      services.LogOverflowBlock,
+	}, log.NewStdLogger(io.Discard))
+
+	writer.Write(context.Background(), logEntry(1))
+	<-store.started
+	writer.Write(context.Background(), logEntry(2))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	writer.Write(ctx, logEntry(3))
+	require.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond)
+
+	close(store.release)
+	cleanup()
+	require.Len(t, store.written(), 2)
+}
+
+func TestRecommendationLogWriter_SyncMode(t *testing.T) {
+	store := &fakeLogStore{}
+	writer, cleanup := services.NewRecommendationLogWriter(store, nil, services.RecommendationLogWriterConfig{}, log.NewStdLogger(io.Discard))
+	defer cleanup()
+
+	writer.Write(context.Background(), logEntry(1))
+	require.Len(t, store.single, 1)
+	require.Empty(t, store.batches)
+}
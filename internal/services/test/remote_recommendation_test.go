@@ -0,0 +1,65 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/services"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+)
+
+// switchableSource 在 err 非空时失败，否则按 pagedSource 分页，并记录收到的游标。
+type switchableSource struct {
+	*pagedSource
+	err     error
+	cursors []string
+}
+
+func (s *switchableSource) GetFeed(ctx context.Context, input services.RecommendationInput) (*services.RecommendationResult, error) {
+	s.cursors = append(s.cursors, input.Cursor)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.pagedSource.GetFeed(ctx, input)
+}
+
+func TestFallbackProvider_TagsCursorsBySource(t *testing.T) {
+	primary := &switchableSource{pagedSource: &pagedSource{name: "remote", ids: sourceIDs("r", 6), itemCursors: true}}
+	fallback := &switchableSource{pagedSource: &pagedSource{name: "mock", ids: sourceIDs("m", 6)}}
+	provider := services.NewFallbackRecommendationProvider(primary, fallback, log.NewStdLogger(io.Discard))
+	ctx := context.Background()
+
+	page, err := provider.GetFeed(ctx, services.RecommendationInput{Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, "remote", page.Source)
+	require.Equal(t, "p.2", page.NextCursor)
+	require.Equal(t, "p.1", page.Items[0].Cursor)
+
+	// 主推荐故障：其签发的游标不传给兜底链路，兜底从第一页开始。
+	primary.err = errors.New("remote down")
+	page, err = provider.GetFeed(ctx, services.RecommendationInput{Limit: 2, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	require.Equal(t, "mock", page.Source)
+	require.Equal(t, []string{"m-0", "m-1"}, videoIDs(page.Items))
+	require.Equal(t, "f.2", page.NextCursor)
+	require.Equal(t, []string{"2"}, primary.cursors[1:])
+	require.Equal(t, []string{""}, fallback.cursors)
+
+	// 兜底签发的游标继续由兜底链路翻页，即使主推荐已恢复。
+	primary.err = nil
+	page, err = provider.GetFeed(ctx, services.RecommendationInput{Limit: 2, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	require.Equal(t, []string{"m-2", "m-3"}, videoIDs(page.Items))
+	require.Len(t, primary.cursors, 2)
+	require.Equal(t, []string{"", "2"}, fallback.cursors)
+
+	// 未带前缀的游标（上线前签发）视为主推荐签发。
+	page, err = provider.GetFeed(ctx, services.RecommendationInput{Limit: 2, Cursor: "4"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"r-4", "r-5"}, videoIDs(page.Items))
+	require.Empty(t, page.NextCursor)
+}
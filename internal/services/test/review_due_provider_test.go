@@ -0,0 +1,71 @@
+package services_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/services"
+
+	"github.com/stretchr/testify/require"
+)
+
+func recommendationItems(prefix string, n int) []services.RecommendationItem {
+	items := make([]services.RecommendationItem, 0, n)
+	for i := 0; i < n; i++ {
+		items = append(items, services.RecommendationItem{VideoID: fmt.Sprintf("%s-%d", prefix, i)})
+	}
+	return items
+}
+
+func videoIDs(items []services.RecommendationItem) []string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.VideoID)
+	}
+	return ids
+}
+
+func TestInterleaveReviewItems(t *testing.T) {
+	cases := []struct {
+		name    string
+		primary []services.RecommendationItem
+		reviews []services.RecommendationItem
+		limit   int
+		want    []string
+	}{
+		{
+			name:    "spreads reviews evenly",
+			primary: recommendationItems("p", 10),
+			reviews: recommendationItems("r", 2),
+			limit:   10,
+			want:    []string{"p-0", "p-1", "r-0", "p-2", "p-3", "p-4", "p-5", "r-1", "p-6", "p-7"},
+		},
+		{
+			name:    "drops primary duplicates",
+			primary: append([]services.RecommendationItem{{VideoID: "r-0"}}, recommendationItems("p", 4)...),
+			reviews: recommendationItems("r", 1),
+			limit:   4,
+			want:    []string{"p-0", "p-1", "r-0", "p-2"},
+		},
+		{
+			name:    "appends reviews when primary runs short",
+			primary: recommendationItems("p", 1),
+			reviews: recommendationItems("r", 2),
+			limit:   10,
+			want:    []string{"r-0", "p-0", "r-1"},
+		},
+		{
+			name:    "reviews only",
+			primary: nil,
+			reviews: recommendationItems("r", 3),
+			limit:   2,
+			want:    []string{"r-0", "r-1"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := services.InterleaveReviewItems(tc.primary, tc.reviews, tc.limit)
+			require.Equal(t, tc.want, videoIDs(got))
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/services"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingSource 记录收到的请求，release 关闭前阻塞，用于观察影子调用的并发与入参。
+type blockingSource struct {
+	name    string
+	ids     []string
+	err     error
+	release chan struct{}
+
+	mu     sync.Mutex
+	inputs []services.RecommendationInput
+}
+
+func (b *blockingSource) GetFeed(ctx context.Context, input services.RecommendationInput) (*services.RecommendationResult, error) {
+	b.mu.Lock()
+	b.inputs = append(b.inputs, input)
+	b.mu.Unlock()
+	if b.release != nil {
+		select {
+		case <-b.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	items := make([]services.RecommendationItem, 0, len(b.ids))
+	for _, id := range b.ids {
+		items = append(items, services.RecommendationItem{VideoID: id})
+	}
+	return &services.RecommendationResult{Items: items, Source: b.name}, nil
+}
+
+func (b *blockingSource) Source() string { return b.name }
+
+func (b *blockingSource) calls() []services.RecommendationInput {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]services.RecommendationInput(nil), b.inputs...)
+}
+
+func itemsOf(ids ...string) []services.RecommendationItem {
+	items := make([]services.RecommendationItem, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, services.RecommendationItem{VideoID: id})
+	}
+	return items
+}
+
+func TestCompareRecommendations(t *testing.T) {
+	cmp := services.CompareRecommendations(itemsOf("a", "b", "c"), itemsOf("a", "b", "c"))
+	require.Equal(t, 3, cmp.Overlap)
+	require.InDelta(t, 1, cmp.Jaccard, 1e-9)
+	require.True(t, cmp.RankCorrelationValid)
+	require.InDelta(t, 1, cmp.RankCorrelation, 1e-9)
+
+	cmp = services.CompareRecommendations(itemsOf("a", "b", "c"), itemsOf("c", "b", "a"))
+	require.InDelta(t, -1, cmp.RankCorrelation, 1e-9)
+
+	// 共同视频 a、b、c 在候选侧名次为 b、a、c：Σd² = 2，ρ = 1 - 12/24 = 0.5。
+	cmp = services.CompareRecommendations(itemsOf("a", "b", "x", "c"), itemsOf("y", "b", "a", "c", "z", "a"))
+	require.Equal(t, 4, cmp.PrimaryCount)
+	require.Equal(t, 5, cmp.CandidateCount)
+	require.Equal(t, 3, cmp.Overlap)
+	require.InDelta(t, 3.0/6.0, cmp.Jaccard, 1e-9)
+	require.InDelta(t, 0.5, cmp.RankCorrelation, 1e-9)
+
+	cmp = services.CompareRecommendations(itemsOf("a", "b"), itemsOf("c"))
+	require.Zero(t, cmp.Jaccard)
+	require.False(t, cmp.RankCorrelationValid)
+
+	cmp = services.CompareRecommendations(nil, nil)
+	require.InDelta(t, 1, cmp.Jaccard, 1e-9)
+	require.False(t, cmp.RankCorrelationValid)
+}
+
+func TestShadowedProvider_ServesPrimaryAndCallsCandidate(t *testing.T) {
+	logger := log.NewStdLogger(io.Discard)
+	primary := &blockingSource{name: "mock", ids: []string{"a", "b"}}
+	candidate := &blockingSource{name: "fresh", ids: []string{"b", "c"}, release: make(chan struct{})}
+
+	shadow, cleanup, err := services.NewShadowTraffic(services.ShadowConfig{
+		Enabled:   true,
+		Candidate: "fresh",
+		Budget:    time.Second,
+	}, services.BlendingSources{"fresh": candidate}, nil, logger)
+	require.NoError(t, err)
+	provider := services.NewShadowedRecommendationProvider(primary, shadow)
+	require.Equal(t, "mock", provider.Source())
+
+	// 候选源阻塞时主推荐照常返回，且请求上下文取消不影响影子调用。
+	ctx, cancel := context.WithCancel(context.Background())
+	input := services.RecommendationInput{UserID: "user-1", Limit: 2, Scene: services.SceneHome, Experiments: map[string]string{"exp": "a"}}
+	result, err := provider.GetFeed(ctx, input)
+	require.NoError(t, err)
+	require.Equal(t, "mock", result.Source)
+	require.Len(t, result.Items, 2)
+	cancel()
+
+	// 翻页请求携带主推荐源签发的游标，不发起影子调用。
+	_, err = provider.GetFeed(context.Background(), services.RecommendationInput{UserID: "user-1", Limit: 2, Scene: services.SceneHome, Cursor: "p.next"})
+	require.NoError(t, err)
+
+	close(candidate.release)
+	cleanup()
+	require.Equal(t, []services.RecommendationInput{input}, candidate.calls())
+}
+
+func TestShadowedProvider_ConcurrencyCapAndFailures(t *testing.T) {
+	logger := log.NewStdLogger(io.Discard)
+	primary := &blockingSource{name: "mock", ids: []string{"a"}}
+	candidate := &blockingSource{name: "fresh", ids: []string{"a"}, release: make(chan struct{})}
+
+	shadow, cleanup, err := services.NewShadowTraffic(services.ShadowConfig{
+		Enabled:        true,
+		Candidate:      "fresh",
+		Budget:         time.Second,
+		MaxConcurrency: 1,
+	}, services.BlendingSources{"fresh": candidate}, nil, logger)
+	require.NoError(t, err)
+	provider := services.NewShadowedRecommendationProvider(primary, shadow)
+
+	// 槽位被第一次调用占满，第二次直接丢弃。
+	for i := 0; i < 2; i++ {
+		_, err := provider.GetFeed(context.Background(), services.RecommendationInput{UserID: "user-1", Limit: 1})
+		require.NoError(t, err)
+	}
+	close(candidate.release)
+	shadow.Wait()
+	require.Len(t, candidate.calls(), 1)
+
+	// 槽位释放后可再次发起；候选失败或主推荐失败都不改变返回给用户的结果。
+	candidate.err = errors.New("boom")
+	result, err := provider.GetFeed(context.Background(), services.RecommendationInput{UserID: "user-1", Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	shadow.Wait()
+	primary.err = errors.New("primary down")
+	_, err = provider.GetFeed(context.Background(), services.RecommendationInput{UserID: "user-1", Limit: 1})
+	require.EqualError(t, err, "primary down")
+	cleanup()
+	require.Len(t, candidate.calls(), 3)
+
+	// 候选超出预算按超时处理，不阻塞主推荐。
+	slow := &blockingSource{name: "fresh", release: make(chan struct{})}
+	shadow, cleanup, err = services.NewShadowTraffic(services.ShadowConfig{
+		Enabled:   true,
+		Candidate: "fresh",
+		Budget:    10 * time.Millisecond,
+	}, services.BlendingSources{"fresh": slow}, nil, logger)
+	require.NoError(t, err)
+	primary.err = nil
+	_, err = services.NewShadowedRecommendationProvider(primary, shadow).GetFeed(context.Background(), services.RecommendationInput{UserID: "user-1", Limit: 1})
+	require.NoError(t, err)
+	cleanup()
+	require.Len(t, slow.calls(), 1)
+}
+
+func TestNewShadowTraffic_Config(t *testing.T) {
+	logger := log.NewStdLogger(io.Discard)
+	shadow, cleanup, err := services.NewShadowTraffic(services.ShadowConfig{}, nil, nil, logger)
+	require.NoError(t, err)
+	require.Nil(t, shadow)
+	cleanup()
+
+	primary := &blockingSource{name: "mock"}
+	require.Same(t, primary, services.NewShadowedRecommendationProvider(primary, nil))
+
+	_, _, err = services.NewShadowTraffic(services.ShadowConfig{Enabled: true, Candidate: "unknown"},
+		services.BlendingSources{"fresh": &blockingSource{name: "fresh"}}, nil, logger)
+	require.Error(t, err)
+}
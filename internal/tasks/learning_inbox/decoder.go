@@ -0,0 +1,29 @@
+// Package learninginbox 提供维护复习计划投影的 Learning Inbox Runner。
+package learninginbox
+
+import (
+	"fmt"
+
+	inboxv1 "github.com/bionicotaku/lingo-services-feed/api/feed/inbox/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// decoder 实现 inbox.Decoder 接口，将 Pub/Sub payload 解析为 Learning 事件。
+type decoder struct{}
+
+// newDecoder 构造事件解码器。
+func newDecoder() *decoder {
+	return &decoder{}
+}
+
+// Decode 解析事件载荷。
+func (d *decoder) Decode(data []byte) (*inboxv1.LearningEvent, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("learning inbox: empty payload")
+	}
+	evt := &inboxv1.LearningEvent{}
+	if err := proto.Unmarshal(data, evt); err != nil {
+		return nil, fmt.Errorf("learning inbox: unmarshal event: %w", err)
+	}
+	return evt, nil
+}
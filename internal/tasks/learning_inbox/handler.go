@@ -0,0 +1,175 @@
+package learninginbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	inboxv1 "github.com/bionicotaku/lingo-services-feed/api/feed/inbox/v1"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/bionicotaku/lingo-utils/outbox/store"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+type eventHandler struct {
+	reviews *repositories.ReviewScheduleRepository
+	log     *log.Helper
+	metrics *inboxMetrics
+	clock   func() time.Time
+}
+
+func newEventHandler(reviews *repositories.ReviewScheduleRepository, logger log.Logger, metrics *inboxMetrics) *eventHandler {
+	return &eventHandler{
+		reviews: reviews,
+		log:     log.NewHelper(logger),
+		metrics: metrics,
+		clock:   time.Now,
+	}
+}
+
+func (h *eventHandler) Handle(ctx context.Context, sess txmanager.Session, evt *inboxv1.LearningEvent, inboxEvt *store.InboxEvent) error {
+	if evt == nil {
+		return fmt.Errorf("learning inbox: nil event")
+	}
+	eventType := evt.GetEventType().String()
+
+	occurredAt, err := parseRFC3339(evt.GetOccurredAt())
+	if err != nil {
+		h.metrics.recordFailure(ctx, eventType, err)
+		return fmt.Errorf("learning inbox: parse occurred_at: %w", err)
+	}
+	if occurredAt.IsZero() {
+		occurredAt = h.clock().UTC()
+	}
+
+	var (
+		applied   bool
+		handleErr error
+	)
+	switch evt.GetEventType() {
+	case inboxv1.LearningEventType_LEARNING_EVENT_TYPE_REVIEW_SCHEDULED:
+		applied, handleErr = h.handleReviewScheduled(ctx, sess, evt, inboxEvt, occurredAt)
+	case inboxv1.LearningEventType_LEARNING_EVENT_TYPE_REVIEW_REMOVED:
+		applied, handleErr = h.handleReviewRemoved(ctx, sess, evt, inboxEvt, occurredAt)
+	default:
+		h.log.WithContext(ctx).Debugw("msg", "learning inbox: skip unsupported event", "event_type", eventType, "event_id", evt.GetEventId())
+		return nil
+	}
+
+	if handleErr != nil {
+		h.metrics.recordFailure(ctx, eventType, handleErr)
+		return handleErr
+	}
+	if !applied {
+		h.log.WithContext(ctx).Debugw("msg", "learning inbox: skip stale event", "event_type", eventType, "event_id", evt.GetEventId(), "event_version", evt.GetVersion())
+	}
+	h.metrics.recordSuccess(ctx, eventType, applied, occurredAt, h.clock())
+	return nil
+}
+
+func (h *eventHandler) handleReviewScheduled(ctx context.Context, sess txmanager.Session, evt *inboxv1.LearningEvent, inboxEvt *store.InboxEvent, occurredAt time.Time) (bool, error) {
+	payload := evt.GetReviewScheduled()
+	if payload == nil {
+		return false, errors.New("learning inbox: review_scheduled payload missing")
+	}
+	userID, videoID, err := resolveAggregate(payload.GetUserId(), payload.GetVideoId(), evt, inboxEvt)
+	if err != nil {
+		return false, err
+	}
+	dueAt, err := parseRFC3339(payload.GetDueAt())
+	if err != nil {
+		return false, fmt.Errorf("learning inbox: parse due_at: %w", err)
+	}
+	if dueAt.IsZero() {
+		return false, errors.New("learning inbox: due_at missing")
+	}
+	lastReviewedAt, err := parseRFC3339(payload.GetLastReviewedAt())
+	if err != nil {
+		return false, fmt.Errorf("learning inbox: parse last_reviewed_at: %w", err)
+	}
+	var lastReviewed *time.Time
+	if !lastReviewedAt.IsZero() {
+		lastReviewed = &lastReviewedAt
+	}
+
+	applied, err := h.reviews.Upsert(ctx, sess, repositories.UpsertReviewInput{
+		UserID:         userID,
+		VideoID:        videoID,
+		DueAt:          dueAt,
+		Stability:      payload.GetStability(),
+		Difficulty:     payload.GetDifficulty(),
+		Reps:           payload.GetReps(),
+		LastReviewedAt: lastReviewed,
+		Version:        eventVersion(evt.GetVersion(), occurredAt),
+		UpdatedAt:      occurredAt,
+	})
+	if err != nil {
+		return false, fmt.Errorf("learning inbox: upsert review schedule: %w", err)
+	}
+	return applied, nil
+}
+
+func (h *eventHandler) handleReviewRemoved(ctx context.Context, sess txmanager.Session, evt *inboxv1.LearningEvent, inboxEvt *store.InboxEvent, occurredAt time.Time) (bool, error) {
+	payload := evt.GetReviewRemoved()
+	if payload == nil {
+		return false, errors.New("learning inbox: review_removed payload missing")
+	}
+	userID, videoID, err := resolveAggregate(payload.GetUserId(), payload.GetVideoId(), evt, inboxEvt)
+	if err != nil {
+		return false, err
+	}
+	applied, err := h.reviews.Deactivate(ctx, sess, userID, videoID, eventVersion(evt.GetVersion(), occurredAt), occurredAt)
+	if err != nil {
+		return false, fmt.Errorf("learning inbox: deactivate review schedule: %w", err)
+	}
+	return applied, nil
+}
+
+// resolveAggregate 优先使用载荷中的 user_id/video_id，缺失时回退到 "<user_id>:<video_id>" 形式的聚合根 ID。
+func resolveAggregate(userID, videoID string, evt *inboxv1.LearningEvent, inboxEvt *store.InboxEvent) (string, uuid.UUID, error) {
+	if userID == "" || videoID == "" {
+		aggregateID := evt.GetAggregateId()
+		if aggregateID == "" && inboxEvt != nil && inboxEvt.AggregateID != nil {
+			aggregateID = *inboxEvt.AggregateID
+		}
+		if u, v, ok := strings.Cut(aggregateID, ":"); ok {
+			if userID == "" {
+				userID = u
+			}
+			if videoID == "" {
+				videoID = v
+			}
+		}
+	}
+	if strings.TrimSpace(userID) == "" {
+		return "", uuid.Nil, errors.New("learning inbox: user_id missing")
+	}
+	parsed, err := uuid.Parse(videoID)
+	if err != nil {
+		return "", uuid.Nil, fmt.Errorf("learning inbox: parse video_id: %w", err)
+	}
+	return strings.TrimSpace(userID), parsed, nil
+}
+
+// eventVersion 返回事件版本；发布端未携带版本时以 occurred_at 的微秒时间戳代替，保证较新的事件覆盖较旧的事件。
+func eventVersion(version int64, occurredAt time.Time) int64 {
+	if version > 0 {
+		return version
+	}
+	return occurredAt.UnixMicro()
+}
+
+func parseRFC3339(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	ts, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ts.UTC(), nil
+}
@@ -0,0 +1,79 @@
+package learninginbox
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	noopmetric "go.opentelemetry.io/otel/metric/noop"
+)
+
+type inboxMetrics struct {
+	success metric.Int64Counter
+	failure metric.Int64Counter
+	stale   metric.Int64Counter
+	lag     metric.Float64Histogram
+	enabled bool
+}
+
+func newInboxMetrics() *inboxMetrics {
+	meterProvider := otel.GetMeterProvider()
+	if meterProvider == nil {
+		meterProvider = noopmetric.NewMeterProvider()
+	}
+	meter := meterProvider.Meter("lingo-services-feed.learning_inbox")
+
+	success, err := meter.Int64Counter("learning_inbox_success_total", metric.WithDescription("Number of learning events applied to review schedule"))
+	if err != nil {
+		return &inboxMetrics{}
+	}
+	failure, err := meter.Int64Counter("learning_inbox_failure_total", metric.WithDescription("Number of learning events failed"))
+	if err != nil {
+		return &inboxMetrics{}
+	}
+	stale, err := meter.Int64Counter("learning_inbox_stale_total", metric.WithDescription("Number of learning events skipped because a newer version was already applied"))
+	if err != nil {
+		return &inboxMetrics{}
+	}
+	lag, err := meter.Float64Histogram("learning_inbox_event_lag_ms", metric.WithDescription("Lag between event occurred_at and processing time"), metric.WithUnit("ms"))
+	if err != nil {
+		return &inboxMetrics{}
+	}
+
+	return &inboxMetrics{
+		success: success,
+		failure: failure,
+		stale:   stale,
+		lag:     lag,
+		enabled: true,
+	}
+}
+
+func (m *inboxMetrics) recordSuccess(ctx context.Context, eventType string, applied bool, occurredAt time.Time, now time.Time) {
+	if m == nil || !m.enabled {
+		return
+	}
+	attrs := metric.WithAttributes(attribute.String("event_type", eventType))
+	if applied {
+		m.success.Add(ctx, 1, attrs)
+	} else {
+		m.stale.Add(ctx, 1, attrs)
+	}
+	if !occurredAt.IsZero() && !now.IsZero() {
+		lag := now.Sub(occurredAt).Milliseconds()
+		if lag < 0 {
+			lag = 0
+		}
+		m.lag.Record(ctx, float64(lag), attrs)
+	}
+}
+
+func (m *inboxMetrics) recordFailure(ctx context.Context, eventType string, _ error) {
+	if m == nil || !m.enabled {
+		return
+	}
+	attrs := metric.WithAttributes(attribute.String("event_type", eventType))
+	m.failure.Add(ctx, 1, attrs)
+}
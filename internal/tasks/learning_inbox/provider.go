@@ -0,0 +1,51 @@
+package learninginbox
+
+import (
+	"context"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/bionicotaku/lingo-utils/gcpubsub"
+	outboxcfg "github.com/bionicotaku/lingo-utils/outbox/config"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// SubscriptionConfig 为 Learning 事件订阅的连接与 Inbox 配置，Name 为其在 messaging.topics 中的键。
+type SubscriptionConfig struct {
+	Name   string
+	PubSub gcpubsub.Config
+	Inbox  outboxcfg.InboxConfig
+}
+
+// ProvideSubscriber 为 Learning 事件订阅构造 Pub/Sub Subscriber；未配置订阅时返回 nil，任务随之禁用。
+func ProvideSubscriber(ctx context.Context, sub SubscriptionConfig, deps gcpubsub.Dependencies) (gcpubsub.Subscriber, func(), error) {
+	if sub.PubSub.ProjectID == "" || sub.PubSub.SubscriptionID == "" {
+		return nil, func() {}, nil
+	}
+	component, cleanup, err := gcpubsub.NewComponent(ctx, sub.PubSub, deps)
+	if err != nil {
+		return nil, nil, err
+	}
+	return gcpubsub.ProvideSubscriber(component), cleanup, nil
+}
+
+// ProvideTask 根据配置和依赖构造 Learning Inbox 任务。
+func ProvideTask(
+	subscriber gcpubsub.Subscriber,
+	inboxRepo *repositories.InboxRepository,
+	reviews *repositories.ReviewScheduleRepository,
+	tx txmanager.Manager,
+	sub SubscriptionConfig,
+	logger log.Logger,
+) *Task {
+	if subscriber == nil {
+		log.NewHelper(logger).Warnw("msg", "learning inbox: skip initialization, subscription not configured", "topic", sub.Name)
+		return nil
+	}
+	normalized := sub.Inbox.Normalize()
+	if normalized.SourceService == "" {
+		log.NewHelper(logger).Warn("learning inbox: skip initialization, source_service not configured")
+		return nil
+	}
+	return NewTask(subscriber, inboxRepo, reviews, tx, logger, normalized)
+}
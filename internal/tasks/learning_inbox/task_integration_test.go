@@ -0,0 +1,211 @@
+package learninginbox_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	inboxv1 "github.com/bionicotaku/lingo-services-feed/api/feed/inbox/v1"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	learninginbox "github.com/bionicotaku/lingo-services-feed/internal/tasks/learning_inbox"
+	"github.com/bionicotaku/lingo-utils/gcpubsub"
+	outboxcfg "github.com/bionicotaku/lingo-utils/outbox/config"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/docker/go-connections/nat"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestLearningInboxTask_MaintainsReviewSchedule(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dsn, terminate := startPostgres(ctx, t)
+	defer terminate()
+
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { pool.Close() })
+
+	applyMigrations(ctx, t, pool)
+
+	logger := log.NewStdLogger(io.Discard)
+	inboxRepo := repositories.NewInboxRepository(pool, logger, outboxcfg.Config{Schema: "feed"})
+	reviews := repositories.NewReviewScheduleRepository(pool, logger)
+	manager, err := txmanager.NewManager(pool, txmanager.Config{}, txmanager.Dependencies{Logger: logger})
+	require.NoError(t, err)
+
+	userID := "user-1"
+	first, second, removed := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	stub := &stubSubscriber{messages: []*gcpubsub.Message{
+		buildMessage(t, scheduledEvent(userID, second, 1, now.Add(-time.Hour), now.Add(-10*time.Minute))),
+		buildMessage(t, scheduledEvent(userID, first, 1, now.Add(-time.Hour), now.Add(-30*time.Minute))),
+		buildMessage(t, scheduledEvent(userID, removed, 1, now.Add(-time.Hour), now.Add(-20*time.Minute))),
+		buildMessage(t, removedEvent(userID, removed, 2, now.Add(-time.Minute))),
+	}}
+
+	cfg := outboxcfg.InboxConfig{SourceService: "learning", MaxConcurrency: 1}
+	task := learninginbox.NewTask(stub, inboxRepo, reviews, manager, logger, cfg)
+	require.NotNil(t, task)
+	require.NoError(t, task.Run(ctx))
+
+	due, err := reviews.ListDue(ctx, nil, userID, now, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	require.Equal(t, first.String(), due[0].VideoID)
+	require.Equal(t, second.String(), due[1].VideoID)
+	require.Equal(t, int32(3), due[0].Reps)
+
+	// 乱序到达的旧版本 scheduled 事件不会把已移除的视频重新加入队列；新版本把复习推迟到未来。
+	stub.messages = []*gcpubsub.Message{
+		buildMessage(t, scheduledEvent(userID, removed, 1, now.Add(-time.Hour), now.Add(-20*time.Minute))),
+		buildMessage(t, scheduledEvent(userID, first, 2, now, now.Add(24*time.Hour))),
+	}
+	require.NoError(t, task.Run(ctx))
+
+	due, err = reviews.ListDue(ctx, nil, userID, now, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	require.Equal(t, second.String(), due[0].VideoID)
+}
+
+func TestLearningInboxTask_DisabledWithoutSubscription(t *testing.T) {
+	sub, cleanup, err := learninginbox.ProvideSubscriber(context.Background(), learninginbox.SubscriptionConfig{Name: "learning_events"}, gcpubsub.Dependencies{})
+	require.NoError(t, err)
+	defer cleanup()
+	require.Nil(t, sub)
+	require.Nil(t, learninginbox.ProvideTask(sub, nil, nil, nil, learninginbox.SubscriptionConfig{}, log.NewStdLogger(io.Discard)))
+}
+
+func scheduledEvent(userID string, videoID uuid.UUID, version int64, occurredAt, dueAt time.Time) *inboxv1.LearningEvent {
+	return &inboxv1.LearningEvent{
+		EventId:       uuid.NewString(),
+		EventType:     inboxv1.LearningEventType_LEARNING_EVENT_TYPE_REVIEW_SCHEDULED,
+		AggregateId:   userID + ":" + videoID.String(),
+		AggregateType: "review_card",
+		Version:       version,
+		OccurredAt:    occurredAt.Format(time.RFC3339Nano),
+		Payload: &inboxv1.LearningEvent_ReviewScheduled_{ReviewScheduled: &inboxv1.LearningEvent_ReviewScheduled{
+			UserId:         userID,
+			VideoId:        videoID.String(),
+			DueAt:          dueAt.Format(time.RFC3339Nano),
+			Stability:      2.5,
+			Difficulty:     5,
+			Reps:           3,
+			LastReviewedAt: occurredAt.Format(time.RFC3339Nano),
+		}},
+	}
+}
+
+func removedEvent(userID string, videoID uuid.UUID, version int64, occurredAt time.Time) *inboxv1.LearningEvent {
+	return &inboxv1.LearningEvent{
+		EventId:       uuid.NewString(),
+		EventType:     inboxv1.LearningEventType_LEARNING_EVENT_TYPE_REVIEW_REMOVED,
+		AggregateId:   userID + ":" + videoID.String(),
+		AggregateType: "review_card",
+		Version:       version,
+		OccurredAt:    occurredAt.Format(time.RFC3339Nano),
+		Payload: &inboxv1.LearningEvent_ReviewRemoved_{ReviewRemoved: &inboxv1.LearningEvent_ReviewRemoved{
+			UserId:  userID,
+			VideoId: videoID.String(),
+		}},
+	}
+}
+
+// stubSubscriber delivers queued messages synchronously.
+type stubSubscriber struct {
+	messages []*gcpubsub.Message
+}
+
+func (s *stubSubscriber) Receive(ctx context.Context, handler func(context.Context, *gcpubsub.Message) error) error {
+	for _, msg := range s.messages {
+		if err := handler(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *stubSubscriber) Stop() {}
+
+func buildMessage(t *testing.T, evt *inboxv1.LearningEvent) *gcpubsub.Message {
+	data, err := proto.Marshal(evt)
+	require.NoError(t, err)
+	return &gcpubsub.Message{
+		ID:   uuid.NewString(),
+		Data: data,
+		Attributes: map[string]string{
+			"event_id":       evt.GetEventId(),
+			"event_type":     evt.GetEventType().String(),
+			"aggregate_id":   evt.GetAggregateId(),
+			"aggregate_type": evt.GetAggregateType(),
+		},
+	}
+}
+
+func startPostgres(ctx context.Context, t *testing.T) (string, func()) {
+	t.Helper()
+
+	req := testcontainers.ContainerRequest{
+		Image:        "postgres:16-alpine",
+		ExposedPorts: []string{"5432/tcp"},
+		Env: map[string]string{
+			"POSTGRES_PASSWORD": "postgres",
+			"POSTGRES_USER":     "postgres",
+			"POSTGRES_DB":       "feed",
+		},
+		WaitingFor: wait.ForSQL("5432/tcp", "postgres", func(host string, port nat.Port) string {
+			return fmt.Sprintf("postgres://postgres:postgres@%s:%s/feed?sslmode=disable", host, port.Port())
+		}).WithStartupTimeout(60 * time.Second),
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		t.Skipf("skip learning inbox tests: cannot start postgres container: %v", err)
+	}
+
+	host, err := container.Host(ctx)
+	require.NoError(t, err)
+
+	port, err := container.MappedPort(ctx, "5432")
+	require.NoError(t, err)
+
+	dsn := fmt.Sprintf("postgres://postgres:postgres@%s:%s/feed?sslmode=disable", host, port.Port())
+	cleanup := func() {
+		termCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = container.Terminate(termCtx)
+	}
+	return dsn, cleanup
+}
+
+func applyMigrations(ctx context.Context, t *testing.T, pool *pgxpool.Pool) {
+	t.Helper()
+
+	migrationsDir := filepath.Join("..", "..", "..", "migrations")
+	entries, err := filepath.Glob(filepath.Join(migrationsDir, "*.sql"))
+	require.NoError(t, err)
+	sort.Strings(entries)
+
+	for _, path := range entries {
+		content, readErr := os.ReadFile(path)
+		require.NoError(t, readErr)
+		_, execErr := pool.Exec(ctx, string(content))
+		require.NoErrorf(t, execErr, "apply migration %s", filepath.Base(path))
+	}
+}
@@ -0,0 +1,229 @@
+// Package logexport 将 feed.recommendation_logs 按时间窗口导出为 NDJSON/Parquet 文件，供离线评估推荐效果。
+//
+// 导出按 (generated_at, log_id) 正序扫描，成功后把最后一条日志写入水位文件；
+// 下次导出从水位之后继续，夜间增量导出不会产生重复行。
+package logexport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultBatchSize = 1000
+	// defaultSettleLag 为 until 相对当前时间的回退量：异步日志写入存在延迟，
+	// 过于接近当前时间的窗口可能在水位推进后才落库，导致漏导。
+	defaultSettleLag = 5 * time.Minute
+	filePrefix       = "recommendation_logs_"
+)
+
+// LogSource 抽象导出所需的日志扫描能力。
+type LogSource interface {
+	ListForExport(ctx context.Context, sess txmanager.Session, params repositories.ExportRecommendationLogsParams) ([]*po.FeedRecommendationLog, error)
+}
+
+// Options 描述一次导出。
+type Options struct {
+	Format Format
+	// OutputDir 为导出文件目录，不存在时自动创建。
+	OutputDir string
+	// WatermarkPath 为水位文件路径，空值表示不续传（每次按 Since/Until 全量导出）。
+	WatermarkPath string
+	// Since 为窗口下界（含），与水位同时存在时取二者中较晚者。
+	Since *time.Time
+	// Until 为窗口上界（不含），空值表示 now - SettleLag。
+	Until *time.Time
+	// SettleLag 为未指定 Until 时相对当前时间的回退量。
+	SettleLag time.Duration
+	// BatchSize 为单次扫描条数。
+	BatchSize int
+}
+
+// Result 汇总一次导出。
+type Result struct {
+	// File 为本次产出的文件，无新数据时为空。
+	File      string
+	Logs      int
+	Rows      int
+	Watermark *Watermark
+}
+
+// Exporter 执行推荐日志导出。
+type Exporter struct {
+	source LogSource
+	log    *log.Helper
+	now    func() time.Time
+}
+
+// NewExporter 构造 Exporter。
+func NewExporter(source LogSource, logger log.Logger) *Exporter {
+	return &Exporter{
+		source: source,
+		log:    log.NewHelper(logger),
+		now:    time.Now,
+	}
+}
+
+// WithClock 替换时间来源，便于测试。
+func (e *Exporter) WithClock(now func() time.Time) *Exporter {
+	if now != nil {
+		e.now = now
+	}
+	return e
+}
+
+// Export 流式扫描窗口内的日志并写入单个文件，成功后推进水位。
+//
+// 文件先写入临时文件再 rename；文件名由起始水位决定，若在写水位前中断，
+// 重跑会从同一水位开始并覆盖同名文件，不会留下重复数据。
+func (e *Exporter) Export(ctx context.Context, opts Options) (*Result, error) {
+	if e == nil || e.source == nil {
+		return nil, errors.New("log export: source not configured")
+	}
+	if opts.Format == "" {
+		opts.Format = FormatNDJSON
+	}
+	if opts.OutputDir == "" {
+		return nil, errors.New("log export: output dir is required")
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	if opts.SettleLag <= 0 {
+		opts.SettleLag = defaultSettleLag
+	}
+	until := e.now().UTC().Add(-opts.SettleLag)
+	if opts.Until != nil {
+		until = opts.Until.UTC()
+	}
+
+	var start *Watermark
+	if opts.WatermarkPath != "" {
+		wm, err := LoadWatermark(opts.WatermarkPath)
+		if err != nil {
+			return nil, err
+		}
+		start = wm
+	}
+	var after *repositories.RecommendationLogCursor
+	if start != nil {
+		after = &repositories.RecommendationLogCursor{GeneratedAt: start.GeneratedAt, LogID: start.LogID}
+	}
+	if opts.Since != nil && !opts.Since.Before(until) {
+		return nil, fmt.Errorf("log export: since %s is not before until %s", opts.Since.UTC().Format(time.RFC3339), until.Format(time.RFC3339))
+	}
+
+	if err := os.MkdirAll(opts.OutputDir, 0o755); err != nil {
+		return nil, fmt.Errorf("log export: create output dir: %w", err)
+	}
+	finalPath := filepath.Join(opts.OutputDir, fileName(start, opts.Since, opts.Format))
+	tmp, err := os.CreateTemp(opts.OutputDir, "."+filepath.Base(finalPath)+".tmp-*")
+	if err != nil {
+		return nil, fmt.Errorf("log export: create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	writer := newRowWriter(opts.Format, tmp)
+	result := &Result{}
+	var last *po.FeedRecommendationLog
+	for {
+		batch, err := e.source.ListForExport(ctx, nil, repositories.ExportRecommendationLogsParams{
+			After: after,
+			Since: opts.Since,
+			Until: until,
+			Limit: opts.BatchSize,
+		})
+		if err != nil {
+			tmp.Close()
+			return nil, err
+		}
+		for _, entry := range batch {
+			rows := ExpandRows(entry)
+			if err := writer.Write(rows); err != nil {
+				tmp.Close()
+				return nil, fmt.Errorf("log export: write rows: %w", err)
+			}
+			result.Logs++
+			result.Rows += len(rows)
+			last = entry
+		}
+		if len(batch) < opts.BatchSize {
+			break
+		}
+		cursor, err := cursorOf(last)
+		if err != nil {
+			tmp.Close()
+			return nil, err
+		}
+		after = cursor
+	}
+	if err := writer.Close(); err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("log export: finalize file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("log export: close file: %w", err)
+	}
+	if last == nil {
+		e.log.WithContext(ctx).Infow("msg", "log export: no new recommendation logs", "until", until)
+		result.Watermark = start
+		return result, nil
+	}
+	if err := os.Rename(tmp.Name(), finalPath); err != nil {
+		return nil, fmt.Errorf("log export: rename file: %w", err)
+	}
+	result.File = finalPath
+
+	cursor, err := cursorOf(last)
+	if err != nil {
+		return nil, err
+	}
+	wm := Watermark{
+		GeneratedAt: cursor.GeneratedAt,
+		LogID:       cursor.LogID,
+		File:        filepath.Base(finalPath),
+		ExportedAt:  e.now().UTC(),
+	}
+	if opts.WatermarkPath != "" {
+		if err := SaveWatermark(opts.WatermarkPath, wm); err != nil {
+			return nil, err
+		}
+	}
+	result.Watermark = &wm
+	e.log.WithContext(ctx).Infow("msg", "log export: completed", "file", finalPath, "logs", result.Logs, "rows", result.Rows)
+	return result, nil
+}
+
+func cursorOf(entry *po.FeedRecommendationLog) (*repositories.RecommendationLogCursor, error) {
+	id, err := uuid.Parse(entry.LogID)
+	if err != nil {
+		return nil, fmt.Errorf("log export: invalid log id %q: %w", entry.LogID, err)
+	}
+	return &repositories.RecommendationLogCursor{GeneratedAt: entry.GeneratedAt.UTC(), LogID: id}, nil
+}
+
+// fileName 由起始位置决定：同一水位重跑得到同名文件，覆盖上次中断的产物。
+func fileName(start *Watermark, since *time.Time, format Format) string {
+	const layout = "20060102T150405.000000Z"
+	var tag string
+	switch {
+	case start != nil:
+		tag = start.GeneratedAt.UTC().Format(layout) + "_" + strings.ReplaceAll(start.LogID.String(), "-", "")[:12]
+	case since != nil:
+		tag = since.UTC().Format(layout)
+	default:
+		tag = "initial"
+	}
+	return filePrefix + tag + format.extension()
+}
@@ -0,0 +1,115 @@
+package logexport
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+)
+
+// Row 为导出文件中的一行：一条推荐日志中的一个推荐位。
+// 没有推荐条目的日志（如推荐失败）输出一行 Rank=0、VideoID 为空的记录，保证请求本身不丢失。
+type Row struct {
+	LogID                   string    `json:"log_id" parquet:"log_id"`
+	GeneratedAt             time.Time `json:"generated_at" parquet:"generated_at,timestamp(microsecond)"`
+	UserIDHash              string    `json:"user_id_hash,omitempty" parquet:"user_id_hash,optional"`
+	UserIDKeyVersion        string    `json:"user_id_key_version,omitempty" parquet:"user_id_key_version,optional"`
+	Guest                   bool      `json:"guest" parquet:"guest"`
+	RecommendationSource    string    `json:"recommendation_source" parquet:"recommendation_source,dict"`
+	RequestLimit            int32     `json:"request_limit" parquet:"request_limit"`
+	RecommendationLatencyMS int32     `json:"recommendation_latency_ms,omitempty" parquet:"recommendation_latency_ms,optional"`
+	ErrorKind               string    `json:"error_kind,omitempty" parquet:"error_kind,optional,dict"`
+	Replayed                bool      `json:"replayed" parquet:"replayed"`
+	Scene                   string    `json:"scene,omitempty" parquet:"scene,optional,dict"`
+	Page                    int32     `json:"page,omitempty" parquet:"page,optional"`
+	RequestID               string    `json:"request_id,omitempty" parquet:"request_id,optional"`
+	TraceID                 string    `json:"trace_id,omitempty" parquet:"trace_id,optional"`
+	// Experiments 为命中的实验分组（JSON 数组），按 experiment/variant 拆分对比时解析。
+	Experiments string `json:"experiments,omitempty" parquet:"experiments,optional"`
+	// Rank 为推荐位次，从 1 开始。
+	Rank    int32   `json:"rank" parquet:"rank"`
+	VideoID string  `json:"video_id,omitempty" parquet:"video_id,optional"`
+	Reason  string  `json:"reason,omitempty" parquet:"reason,optional,dict"`
+	Score   float64 `json:"score" parquet:"score"`
+	// Missing 表示该视频在补水时缺失投影，未实际返回给用户。
+	Missing bool `json:"missing" parquet:"missing"`
+	// MissingReason 为该视频未下发的原因（projection_missing / invalid_video_id / watched），已下发时为空。
+	MissingReason string `json:"missing_reason,omitempty" parquet:"missing_reason,optional,dict"`
+	Meta          string `json:"meta,omitempty" parquet:"meta,optional"`
+	// ServedPosition 为该视频在实际响应中的位次（从 1 开始），0 表示未下发。
+	ServedPosition    int32 `json:"served_position" parquet:"served_position"`
+	ProjectionVersion int64 `json:"projection_version,omitempty" parquet:"projection_version,optional"`
+}
+
+// ExpandRows 将一条推荐日志按推荐位展开为多行。
+func ExpandRows(entry *po.FeedRecommendationLog) []Row {
+	if entry == nil {
+		return nil
+	}
+	base := Row{
+		LogID:                entry.LogID,
+		GeneratedAt:          entry.GeneratedAt.UTC(),
+		UserIDHash:           derefString(entry.UserIDHash),
+		UserIDKeyVersion:     derefString(entry.UserIDKeyVersion),
+		Guest:                entry.Guest,
+		RecommendationSource: entry.RecommendationSource,
+		RequestLimit:         entry.RequestLimit,
+		ErrorKind:            derefString(entry.ErrorKind),
+		Replayed:             entry.Replayed,
+		Scene:                derefString(entry.Scene),
+		RequestID:            derefString(entry.RequestID),
+		TraceID:              derefString(entry.TraceID),
+	}
+	if entry.RecommendationLatencyMS != nil {
+		base.RecommendationLatencyMS = *entry.RecommendationLatencyMS
+	}
+	if entry.Page != nil {
+		base.Page = *entry.Page
+	}
+	if len(entry.Experiments) > 0 {
+		if raw, err := json.Marshal(entry.Experiments); err == nil {
+			base.Experiments = string(raw)
+		}
+	}
+	if len(entry.RecommendedItems) == 0 {
+		return []Row{base}
+	}
+	missing := make(map[string]struct{}, len(entry.MissingVideoIDs))
+	for _, id := range entry.MissingVideoIDs {
+		missing[id] = struct{}{}
+	}
+	served := make(map[string]po.ServedItemLog, len(entry.ServedItems))
+	for _, item := range entry.ServedItems {
+		if _, ok := served[item.VideoID]; !ok {
+			served[item.VideoID] = item
+		}
+	}
+	rows := make([]Row, 0, len(entry.RecommendedItems))
+	for i, item := range entry.RecommendedItems {
+		row := base
+		row.Rank = int32(i + 1)
+		row.VideoID = item.VideoID
+		row.Reason = item.Reason
+		row.Score = item.Score
+		_, row.Missing = missing[item.VideoID]
+		row.MissingReason = item.MissingReason
+		if s, ok := served[item.VideoID]; ok {
+			row.ServedPosition = s.Position
+			row.ProjectionVersion = s.ProjectionVersion
+		}
+		if len(item.Meta) > 0 {
+			if raw, err := json.Marshal(item.Meta); err == nil {
+				row.Meta = string(raw)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func derefString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,228 @@
+package logexport_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	logexport "github.com/bionicotaku/lingo-services-feed/internal/tasks/log_export"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySource 以内存切片模拟 ListForExport 的键集扫描语义。
+type memorySource struct {
+	logs []*po.FeedRecommendationLog
+}
+
+func (s *memorySource) add(entry *po.FeedRecommendationLog) {
+	s.logs = append(s.logs, entry)
+	sort.Slice(s.logs, func(i, j int) bool {
+		if !s.logs[i].GeneratedAt.Equal(s.logs[j].GeneratedAt) {
+			return s.logs[i].GeneratedAt.Before(s.logs[j].GeneratedAt)
+		}
+		return s.logs[i].LogID < s.logs[j].LogID
+	})
+}
+
+func (s *memorySource) ListForExport(_ context.Context, _ txmanager.Session, params repositories.ExportRecommendationLogsParams) ([]*po.FeedRecommendationLog, error) {
+	var out []*po.FeedRecommendationLog
+	for _, entry := range s.logs {
+		if !entry.GeneratedAt.Before(params.Until) {
+			continue
+		}
+		if params.Since != nil && entry.GeneratedAt.Before(*params.Since) {
+			continue
+		}
+		if params.After != nil {
+			after := params.After
+			if entry.GeneratedAt.Before(after.GeneratedAt) ||
+				(entry.GeneratedAt.Equal(after.GeneratedAt) && entry.LogID <= after.LogID.String()) {
+				continue
+			}
+		}
+		out = append(out, entry)
+		if len(out) == params.Limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+func newLog(at time.Time, videoIDs ...string) *po.FeedRecommendationLog {
+	hash := "hash-1"
+	items := make([]po.RecommendedItemLog, 0, len(videoIDs))
+	for i, id := range videoIDs {
+		items = append(items, po.RecommendedItemLog{VideoID: id, Reason: "mock.random", Score: float64(len(videoIDs) - i)})
+	}
+	return &po.FeedRecommendationLog{
+		LogID:                uuid.NewString(),
+		UserIDHash:           &hash,
+		RequestLimit:         int32(len(videoIDs)),
+		RecommendationSource: "mock",
+		RecommendedItems:     items,
+		GeneratedAt:          at,
+	}
+}
+
+func readNDJSON(t *testing.T, path string) []logexport.Row {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var rows []logexport.Row
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var row logexport.Row
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &row))
+		rows = append(rows, row)
+	}
+	require.NoError(t, scanner.Err())
+	return rows
+}
+
+func TestExpandRows(t *testing.T) {
+	entry := newLog(time.Now().UTC(), "v1", "v2", "v3")
+	entry.MissingVideoIDs = []string{"v2"}
+	entry.RecommendedItems[1].MissingReason = po.MissingReasonProjection
+	entry.RecommendedItems[0].Meta = map[string]string{"k": "v"}
+	entry.ServedItems = []po.ServedItemLog{
+		{VideoID: "v3", Position: 1, ProjectionVersion: 4},
+		{VideoID: "v1", Position: 2, ProjectionVersion: 9},
+	}
+	scene := "home"
+	entry.Scene = &scene
+	entry.Experiments = []po.ExperimentAssignmentLog{{Experiment: "ranker", Variant: "control"}}
+
+	rows := logexport.ExpandRows(entry)
+	require.Len(t, rows, 3)
+	for i, row := range rows {
+		require.Equal(t, int32(i+1), row.Rank)
+		require.Equal(t, entry.LogID, row.LogID)
+		require.Equal(t, "hash-1", row.UserIDHash)
+	}
+	require.Equal(t, "v2", rows[1].VideoID)
+	require.True(t, rows[1].Missing)
+	require.Equal(t, po.MissingReasonProjection, rows[1].MissingReason)
+	require.False(t, rows[0].Missing)
+	require.Empty(t, rows[0].MissingReason)
+	require.Zero(t, rows[1].ServedPosition)
+	require.Equal(t, int32(2), rows[0].ServedPosition)
+	require.Equal(t, int64(9), rows[0].ProjectionVersion)
+	require.Equal(t, int32(1), rows[2].ServedPosition)
+	require.Equal(t, "home", rows[2].Scene)
+	require.JSONEq(t, `{"k":"v"}`, rows[0].Meta)
+	require.JSONEq(t, `[{"experiment":"ranker","variant":"control"}]`, rows[2].Experiments)
+
+	failed := newLog(time.Now().UTC())
+	kind := "recommendation_unavailable"
+	failed.ErrorKind = &kind
+	rows = logexport.ExpandRows(failed)
+	require.Len(t, rows, 1)
+	require.Zero(t, rows[0].Rank)
+	require.Equal(t, kind, rows[0].ErrorKind)
+}
+
+func TestExporter_ResumesFromWatermark(t *testing.T) {
+	dir := t.TempDir()
+	watermark := filepath.Join(dir, "watermark.json")
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	now := base.Add(time.Hour)
+
+	source := &memorySource{}
+	// 同一时刻的两条日志按 log_id 排序，固定 ID 使顺序确定。
+	tied := []*po.FeedRecommendationLog{newLog(base, "v1", "v2"), newLog(base, "v3")}
+	tied[0].LogID = "00000000-0000-0000-0000-000000000001"
+	tied[1].LogID = "00000000-0000-0000-0000-000000000002"
+	source.add(tied[0])
+	source.add(tied[1])
+	source.add(newLog(base.Add(time.Minute), "v4"))
+	// 位于 settle lag 之内，首次导出不应包含。
+	source.add(newLog(now.Add(-time.Minute), "v5"))
+
+	exporter := logexport.NewExporter(source, log.NewStdLogger(io.Discard)).WithClock(func() time.Time { return now })
+	opts := logexport.Options{
+		Format:        logexport.FormatNDJSON,
+		OutputDir:     dir,
+		WatermarkPath: watermark,
+		BatchSize:     2,
+	}
+
+	first, err := exporter.Export(context.Background(), opts)
+	require.NoError(t, err)
+	require.Equal(t, 3, first.Logs)
+	require.Equal(t, 4, first.Rows)
+	rows := readNDJSON(t, first.File)
+	require.Len(t, rows, 4)
+	require.Equal(t, []int32{1, 2}, []int32{rows[0].Rank, rows[1].Rank})
+
+	// 水位之后无新数据时不产出文件，水位保持不变。
+	again, err := exporter.Export(context.Background(), opts)
+	require.NoError(t, err)
+	require.Empty(t, again.File)
+	require.Equal(t, first.Watermark.LogID, again.Watermark.LogID)
+
+	// 时间推进后只导出新增部分。
+	later := now.Add(time.Hour)
+	exporter.WithClock(func() time.Time { return later })
+	source.add(newLog(later.Add(-30*time.Minute), "v6", "v7"))
+	second, err := exporter.Export(context.Background(), opts)
+	require.NoError(t, err)
+	require.Equal(t, 2, second.Logs)
+	require.NotEqual(t, first.File, second.File)
+	videos := make([]string, 0)
+	for _, row := range readNDJSON(t, second.File) {
+		videos = append(videos, row.VideoID)
+	}
+	require.Equal(t, []string{"v5", "v6", "v7"}, videos)
+
+	saved, err := logexport.LoadWatermark(watermark)
+	require.NoError(t, err)
+	require.Equal(t, second.Watermark.LogID, saved.LogID)
+	require.Equal(t, filepath.Base(second.File), saved.File)
+}
+
+func TestExporter_Parquet(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	source := &memorySource{}
+	source.add(newLog(base, "v1", "v2"))
+	until := base.Add(time.Hour)
+
+	exporter := logexport.NewExporter(source, log.NewStdLogger(io.Discard))
+	result, err := exporter.Export(context.Background(), logexport.Options{
+		Format:    logexport.FormatParquet,
+		OutputDir: dir,
+		Until:     &until,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ".parquet", filepath.Ext(result.File))
+
+	rows, err := parquet.ReadFile[logexport.Row](result.File)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, "v2", rows[1].VideoID)
+	require.Equal(t, int32(2), rows[1].Rank)
+	require.True(t, rows[0].GeneratedAt.Equal(base))
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := logexport.ParseFormat("PARQUET")
+	require.NoError(t, err)
+	require.Equal(t, logexport.FormatParquet, format)
+
+	_, err = logexport.ParseFormat("csv")
+	require.Error(t, err)
+}
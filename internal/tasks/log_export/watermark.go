@@ -0,0 +1,69 @@
+package logexport
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Watermark 记录最近一次成功导出的最后一条日志位置，下次导出从其后继续。
+type Watermark struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	LogID       uuid.UUID `json:"log_id"`
+	// File 为产出该水位的导出文件，便于人工核对。
+	File       string    `json:"file,omitempty"`
+	ExportedAt time.Time `json:"exported_at"`
+}
+
+// LoadWatermark 读取水位文件，文件不存在时返回 nil。
+func LoadWatermark(path string) (*Watermark, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read watermark: %w", err)
+	}
+	var wm Watermark
+	if err := json.Unmarshal(raw, &wm); err != nil {
+		return nil, fmt.Errorf("decode watermark %s: %w", path, err)
+	}
+	return &wm, nil
+}
+
+// SaveWatermark 以临时文件 + rename 的方式原子写入水位。
+func SaveWatermark(path string, wm Watermark) error {
+	raw, err := json.MarshalIndent(wm, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode watermark: %w", err)
+	}
+	return writeFileAtomic(path, append(raw, '\n'))
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write temp file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("rename %s: %w", path, err)
+	}
+	return nil
+}
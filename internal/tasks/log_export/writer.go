@@ -0,0 +1,86 @@
+package logexport
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// Format 为导出文件格式。
+type Format string
+
+const (
+	// FormatNDJSON 每行一个 JSON 对象。
+	FormatNDJSON Format = "ndjson"
+	// FormatParquet 为 zstd 压缩的 Parquet 文件。
+	FormatParquet Format = "parquet"
+)
+
+// ParseFormat 解析命令行传入的格式名。
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(value))) {
+	case FormatNDJSON, "":
+		return FormatNDJSON, nil
+	case FormatParquet:
+		return FormatParquet, nil
+	default:
+		return "", fmt.Errorf("unsupported export format %q (want ndjson or parquet)", value)
+	}
+}
+
+// extension 返回文件扩展名。
+func (f Format) extension() string {
+	if f == FormatParquet {
+		return ".parquet"
+	}
+	return ".ndjson"
+}
+
+// rowWriter 抽象按批写入导出行。
+type rowWriter interface {
+	Write(rows []Row) error
+	Close() error
+}
+
+func newRowWriter(format Format, out io.Writer) rowWriter {
+	if format == FormatParquet {
+		return &parquetRowWriter{w: parquet.NewGenericWriter[Row](out, parquet.Compression(&parquet.Zstd))}
+	}
+	buf := bufio.NewWriter(out)
+	return &ndjsonRowWriter{buf: buf, enc: json.NewEncoder(buf)}
+}
+
+type ndjsonRowWriter struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+func (w *ndjsonRowWriter) Write(rows []Row) error {
+	for i := range rows {
+		if err := w.enc.Encode(&rows[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *ndjsonRowWriter) Close() error {
+	return w.buf.Flush()
+}
+
+type parquetRowWriter struct {
+	w *parquet.GenericWriter[Row]
+}
+
+func (w *parquetRowWriter) Write(rows []Row) error {
+	_, err := w.w.Write(rows)
+	return err
+}
+
+func (w *parquetRowWriter) Close() error {
+	return w.w.Close()
+}
@@ -0,0 +1,85 @@
+package logretention
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	noopmetric "go.opentelemetry.io/otel/metric/noop"
+)
+
+type retentionMetrics struct {
+	created metric.Int64Counter
+	dropped metric.Int64Counter
+	failure metric.Int64Counter
+	swept   metric.Int64Counter
+	// defaultRows 为 default 兜底分区的剩余行数，非零即告警。
+	defaultRows metric.Int64Gauge
+	enabled     bool
+}
+
+func newRetentionMetrics() *retentionMetrics {
+	meterProvider := otel.GetMeterProvider()
+	if meterProvider == nil {
+		meterProvider = noopmetric.NewMeterProvider()
+	}
+	meter := meterProvider.Meter("lingo-services-feed.log_retention")
+
+	created, err := meter.Int64Counter("log_retention_partitions_created_total", metric.WithDescription("Number of recommendation log partitions created ahead"))
+	if err != nil {
+		return &retentionMetrics{}
+	}
+	dropped, err := meter.Int64Counter("log_retention_partitions_dropped_total", metric.WithDescription("Number of expired recommendation log partitions dropped"))
+	if err != nil {
+		return &retentionMetrics{}
+	}
+	failure, err := meter.Int64Counter("log_retention_failure_total", metric.WithDescription("Number of failed partition maintenance steps"))
+	if err != nil {
+		return &retentionMetrics{}
+	}
+	swept, err := meter.Int64Counter("log_retention_rows_swept_total", metric.WithDescription("Number of expired rows deleted by retention sweepers"))
+	if err != nil {
+		return &retentionMetrics{}
+	}
+	defaultRows, err := meter.Int64Gauge("log_retention_default_partition_rows", metric.WithDescription("Rows left in the recommendation log default partition after maintenance"))
+	if err != nil {
+		return &retentionMetrics{}
+	}
+	return &retentionMetrics{created: created, dropped: dropped, failure: failure, swept: swept, defaultRows: defaultRows, enabled: true}
+}
+
+func (m *retentionMetrics) recordCreated(ctx context.Context, count int) {
+	if m == nil || !m.enabled || count <= 0 {
+		return
+	}
+	m.created.Add(ctx, int64(count))
+}
+
+func (m *retentionMetrics) recordDropped(ctx context.Context, count int) {
+	if m == nil || !m.enabled || count <= 0 {
+		return
+	}
+	m.dropped.Add(ctx, int64(count))
+}
+
+func (m *retentionMetrics) recordFailure(ctx context.Context, step string) {
+	if m == nil || !m.enabled {
+		return
+	}
+	m.failure.Add(ctx, 1, metric.WithAttributes(attribute.String("step", step)))
+}
+
+func (m *retentionMetrics) recordSwept(ctx context.Context, step string, count int64) {
+	if m == nil || !m.enabled || count <= 0 {
+		return
+	}
+	m.swept.Add(ctx, count, metric.WithAttributes(attribute.String("step", step)))
+}
+
+func (m *retentionMetrics) recordDefaultRows(ctx context.Context, count int64) {
+	if m == nil || !m.enabled {
+		return
+	}
+	m.defaultRows.Record(ctx, count)
+}
@@ -0,0 +1,55 @@
+package logretention
+
+import (
+	"context"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/infrastructure/ratelimiter"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// ProvideTask 根据配置构造分区维护任务，未启用时返回 nil。
+func ProvideTask(logs *repositories.FeedRecommendationLogRepository, cfg Config, sweepers Sweepers, logger log.Logger) *Task {
+	if !cfg.Enabled {
+		log.NewHelper(logger).Warn("log retention: skip initialization, feed.log_retention.enabled=false")
+		return nil
+	}
+	return NewTask(logs, cfg, sweepers, logger)
+}
+
+// minRateLimitIdle 为令牌桶的最短空闲保留时长，吸收任务与数据库之间的时钟偏差并避免频繁重建热点桶。
+const minRateLimitIdle = time.Hour
+
+// ProvideSweepers 登记随分区维护执行的过期行清理步骤。
+func ProvideSweepers(served *repositories.ServedPageRepository, snapshots *repositories.FeedIdempotencyRepository, buckets *repositories.RateLimitRepository, limits ratelimiter.Config, cfg Config) Sweepers {
+	var sweepers Sweepers
+	if buckets != nil && limits.Enabled && limits.Backend == ratelimiter.BackendPostgres {
+		// 空闲超过补满时长的桶与不存在的桶等价，删除不影响限流结果。
+		idle := max(ratelimiter.NewPolicy(limits).RefillWindow(), minRateLimitIdle)
+		sweepers = append(sweepers, Sweeper{
+			Name: "rate_limit_buckets",
+			Sweep: func(ctx context.Context, now time.Time) (int64, error) {
+				return buckets.PurgeIdle(ctx, nil, now.Add(-idle))
+			},
+		})
+	}
+	if snapshots != nil {
+		// 过期快照已不会被读取，仅占用空间。
+		sweepers = append(sweepers, Sweeper{
+			Name: "idempotency_snapshots",
+			Sweep: func(ctx context.Context, now time.Time) (int64, error) {
+				return snapshots.PurgeExpired(ctx, nil, now)
+			},
+		})
+	}
+	if served != nil && cfg.ServedPageRetention > 0 {
+		sweepers = append(sweepers, Sweeper{
+			Name: "served_pages",
+			Sweep: func(ctx context.Context, now time.Time) (int64, error) {
+				return served.PurgeBefore(ctx, nil, now.Add(-cfg.ServedPageRetention))
+			},
+		})
+	}
+	return sweepers
+}
@@ -0,0 +1,188 @@
+// Package logretention 维护 feed.recommendation_logs 的日分区：预建未来分区、删除超出保留期的分区，
+// 并清理 default 兜底分区中的过期行；
+// 同一周期内执行登记的清理器（Sweeper），删除其他表中的过期行。
+package logretention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+const (
+	defaultPremakeDays = 7
+	defaultInterval    = time.Hour
+)
+
+// Config 控制分区维护行为。
+type Config struct {
+	// Enabled 为 false 时不构造任务。
+	Enabled bool
+	// Retention 为日志保留时长，0 表示只预建分区、不删除。
+	Retention time.Duration
+	// PremakeDays 为从当天起预建的分区天数。
+	PremakeDays int
+	// Interval 为维护周期。
+	Interval time.Duration
+	// ServedPageRetention 为下发页记录（feed.served_pages）的保留时长，0 表示不清理。
+	ServedPageRetention time.Duration
+}
+
+// Sweeper 为随分区维护周期执行的清理步骤，Sweep 返回删除的行数。
+type Sweeper struct {
+	// Name 用作日志与指标中的 step 标签。
+	Name  string
+	Sweep func(ctx context.Context, now time.Time) (int64, error)
+}
+
+// Sweepers 为按顺序执行的清理步骤集合。
+type Sweepers []Sweeper
+
+// Result 汇总单次维护的结果。
+type Result struct {
+	Created int
+	Dropped []string
+	// DefaultPurged 为从 default 兜底分区删除的过期行数。
+	DefaultPurged int64
+	// DefaultRows 为维护后 default 兜底分区的剩余行数，非零说明预建分区未覆盖写入日期。
+	DefaultRows int64
+	// Swept 为各清理步骤删除的行数，键为 Sweeper.Name。
+	Swept map[string]int64
+}
+
+// Task 周期性执行推荐日志分区维护与过期行清理。
+type Task struct {
+	logs     *repositories.FeedRecommendationLogRepository
+	sweepers Sweepers
+	cfg      Config
+	metrics  *retentionMetrics
+	log      *log.Helper
+	now      func() time.Time
+}
+
+// NewTask 构造分区维护任务，缺失仓储时返回 nil。
+func NewTask(logs *repositories.FeedRecommendationLogRepository, cfg Config, sweepers Sweepers, logger log.Logger) *Task {
+	if logs == nil {
+		return nil
+	}
+	if cfg.PremakeDays <= 0 {
+		cfg.PremakeDays = defaultPremakeDays
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultInterval
+	}
+	return &Task{
+		logs:     logs,
+		sweepers: sweepers,
+		cfg:      cfg,
+		metrics:  newRetentionMetrics(),
+		log:      log.NewHelper(logger),
+		now:      time.Now,
+	}
+}
+
+// Run 启动时立即维护一次，之后按 Interval 周期执行，直到 ctx 结束。
+// 单次失败只记录日志与指标，下个周期重试。
+func (t *Task) Run(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	ticker := time.NewTicker(t.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := t.RunOnce(ctx); err != nil {
+			t.log.WithContext(ctx).Errorw("msg", "log retention: maintenance failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce 预建 [今天, 今天+PremakeDays) 的分区，删除早于 now-Retention 的整日分区，再依次执行清理器。
+// 分区维护失败不阻塞清理器，各步骤的错误合并返回。
+func (t *Task) RunOnce(ctx context.Context) (Result, error) {
+	if t == nil {
+		return Result{}, nil
+	}
+	now := t.now().UTC()
+	result, err := t.maintainPartitions(ctx, now)
+	errs := []error{err}
+
+	for _, sweeper := range t.sweepers {
+		deleted, sweepErr := sweeper.Sweep(ctx, now)
+		if sweepErr != nil {
+			t.metrics.recordFailure(ctx, sweeper.Name)
+			errs = append(errs, fmt.Errorf("%s: %w", sweeper.Name, sweepErr))
+			continue
+		}
+		t.metrics.recordSwept(ctx, sweeper.Name, deleted)
+		if deleted > 0 {
+			if result.Swept == nil {
+				result.Swept = make(map[string]int64, len(t.sweepers))
+			}
+			result.Swept[sweeper.Name] = deleted
+			t.log.WithContext(ctx).Infow("msg", "log retention: expired rows swept", "step", sweeper.Name, "deleted", deleted)
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+func (t *Task) maintainPartitions(ctx context.Context, now time.Time) (Result, error) {
+	created, err := t.logs.EnsurePartitions(ctx, nil, now, t.cfg.PremakeDays)
+	if err != nil {
+		t.metrics.recordFailure(ctx, "ensure")
+		return Result{}, err
+	}
+	t.metrics.recordCreated(ctx, created)
+	result := Result{Created: created}
+
+	if t.cfg.Retention > 0 {
+		dropped, dropErr := t.logs.DropPartitionsBefore(ctx, nil, now.Add(-t.cfg.Retention))
+		if dropErr != nil {
+			t.metrics.recordFailure(ctx, "drop")
+			return result, dropErr
+		}
+		t.metrics.recordDropped(ctx, len(dropped))
+		result.Dropped = dropped
+
+		// 落入 default 分区的过期行不会随日分区删除，按同一保留期单独清理。
+		purged, purgeErr := t.logs.PurgeDefaultPartitionBefore(ctx, nil, now.Add(-t.cfg.Retention))
+		if purgeErr != nil {
+			t.metrics.recordFailure(ctx, "default_purge")
+			return result, purgeErr
+		}
+		t.metrics.recordSwept(ctx, "recommendation_logs_default", purged)
+		result.DefaultPurged = purged
+	}
+
+	if result.Created > 0 || len(result.Dropped) > 0 || result.DefaultPurged > 0 {
+		t.log.WithContext(ctx).Infow("msg", "log retention: partitions maintained", "created", result.Created, "dropped", result.Dropped, "default_purged", result.DefaultPurged)
+	}
+
+	remaining, err := t.logs.CountDefaultPartition(ctx, nil)
+	if err != nil {
+		t.metrics.recordFailure(ctx, "default_count")
+		return result, err
+	}
+	t.metrics.recordDefaultRows(ctx, remaining)
+	result.DefaultRows = remaining
+	if remaining > 0 {
+		t.log.WithContext(ctx).Warnw("msg", "log retention: default partition not empty, partitions are not premade ahead of writes", "rows", remaining)
+	}
+	return result, nil
+}
+
+// WithClock 提供测试替换时间。
+func (t *Task) WithClock(fn func() time.Time) {
+	if t == nil || fn == nil {
+		return
+	}
+	t.now = fn
+}
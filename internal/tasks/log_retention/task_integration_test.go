@@ -0,0 +1,176 @@
+package logretention_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/infrastructure/ratelimiter"
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	logretention "github.com/bionicotaku/lingo-services-feed/internal/tasks/log_retention"
+	"github.com/docker/go-connections/nat"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestLogRetentionTask_CreatesAndDropsPartitions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dsn, terminate := startPostgres(ctx, t)
+	defer terminate()
+
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { pool.Close() })
+
+	applyMigrations(ctx, t, pool)
+
+	logger := log.NewStdLogger(io.Discard)
+	logs := repositories.NewFeedRecommendationLogRepository(pool, logger)
+
+	now := time.Date(2030, time.March, 15, 8, 0, 0, 0, time.UTC)
+	expired := now.Add(-40 * 24 * time.Hour)
+	kept := now.Add(-10 * 24 * time.Hour)
+	for _, ts := range []time.Time{expired, kept} {
+		_, err = logs.EnsurePartitions(ctx, nil, ts, 1)
+		require.NoError(t, err)
+		require.NoError(t, logs.Insert(ctx, nil, po.FeedRecommendationLog{
+			RequestLimit:         1,
+			RecommendationSource: "mock",
+			GeneratedAt:          ts,
+		}))
+	}
+
+	// 未预建分区的日子落入 default 分区：过期行随维护删除，未过期行保留并计入剩余行数。
+	for _, ts := range []time.Time{now.Add(-50 * 24 * time.Hour), now.Add(-5 * 24 * time.Hour)} {
+		require.NoError(t, logs.Insert(ctx, nil, po.FeedRecommendationLog{
+			RequestLimit:         1,
+			RecommendationSource: "mock",
+			GeneratedAt:          ts,
+		}))
+	}
+
+	served := repositories.NewServedPageRepository(pool, logger)
+	for _, ts := range []time.Time{now.Add(-72 * time.Hour), now.Add(-time.Hour)} {
+		require.NoError(t, served.Insert(ctx, nil, po.ServedPage{
+			LogID:                uuid.NewString(),
+			RecommendationSource: "mock",
+			GeneratedAt:          ts,
+		}))
+	}
+
+	snapshots := repositories.NewFeedIdempotencyRepository(pool, logger)
+	for i, expiresAt := range []time.Time{now.Add(-time.Minute), now.Add(time.Hour)} {
+		_, err = snapshots.Save(ctx, nil, po.FeedIdempotencySnapshot{
+			UserIDHash:           "hash-user-1",
+			IdempotencyKey:       fmt.Sprintf("key-%d", i),
+			RequestLimit:         1,
+			RecommendationSource: "mock",
+			ExpiresAt:            expiresAt,
+		})
+		require.NoError(t, err)
+	}
+
+	cfg := logretention.Config{
+		Enabled:             true,
+		Retention:           30 * 24 * time.Hour,
+		PremakeDays:         3,
+		ServedPageRetention: 48 * time.Hour,
+	}
+	task := logretention.NewTask(logs, cfg, logretention.ProvideSweepers(served, snapshots, nil, ratelimiter.Config{}, cfg), logger)
+	require.NotNil(t, task)
+	task.WithClock(func() time.Time { return now })
+
+	result, err := task.RunOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, result.Created)
+	require.Equal(t, []string{"recommendation_logs_p20300203"}, result.Dropped)
+	require.Equal(t, map[string]int64{"idempotency_snapshots": 1, "served_pages": 1}, result.Swept)
+	require.Equal(t, int64(1), result.DefaultPurged)
+	require.Equal(t, int64(1), result.DefaultRows)
+
+	for _, name := range []string{"recommendation_logs_p20300315", "recommendation_logs_p20300317", "recommendation_logs_p20300305"} {
+		var exists bool
+		require.NoError(t, pool.QueryRow(ctx, `select to_regclass('feed.' || $1) is not null`, name).Scan(&exists))
+		require.Truef(t, exists, "partition %s should exist", name)
+	}
+
+	var count int
+	require.NoError(t, pool.QueryRow(ctx, `select count(*) from feed.recommendation_logs`).Scan(&count))
+	require.Equal(t, 2, count)
+
+	// 再次执行无新增也无删除。
+	result, err = task.RunOnce(ctx)
+	require.NoError(t, err)
+	require.Zero(t, result.Created)
+	require.Empty(t, result.Dropped)
+	require.Empty(t, result.Swept)
+	require.Zero(t, result.DefaultPurged)
+	require.Equal(t, int64(1), result.DefaultRows)
+}
+
+func startPostgres(ctx context.Context, t *testing.T) (string, func()) {
+	t.Helper()
+
+	req := testcontainers.ContainerRequest{
+		Image:        "postgres:16-alpine",
+		ExposedPorts: []string{"5432/tcp"},
+		Env: map[string]string{
+			"POSTGRES_PASSWORD": "postgres",
+			"POSTGRES_USER":     "postgres",
+			"POSTGRES_DB":       "feed",
+		},
+		WaitingFor: wait.ForSQL("5432/tcp", "postgres", func(host string, port nat.Port) string {
+			return fmt.Sprintf("postgres://postgres:postgres@%s:%s/feed?sslmode=disable", host, port.Port())
+		}).WithStartupTimeout(60 * time.Second),
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		t.Skipf("skip log retention tests: cannot start postgres container: %v", err)
+	}
+
+	host, err := container.Host(ctx)
+	require.NoError(t, err)
+
+	port, err := container.MappedPort(ctx, "5432")
+	require.NoError(t, err)
+
+	dsn := fmt.Sprintf("postgres://postgres:postgres@%s:%s/feed?sslmode=disable", host, port.Port())
+	cleanup := func() {
+		termCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = container.Terminate(termCtx)
+	}
+	return dsn, cleanup
+}
+
+func applyMigrations(ctx context.Context, t *testing.T, pool *pgxpool.Pool) {
+	t.Helper()
+
+	migrationsDir := filepath.Join("..", "..", "..", "migrations")
+	entries, err := filepath.Glob(filepath.Join(migrationsDir, "*.sql"))
+	require.NoError(t, err)
+	sort.Strings(entries)
+
+	for _, path := range entries {
+		content, readErr := os.ReadFile(path)
+		require.NoError(t, readErr)
+		_, execErr := pool.Exec(ctx, string(content))
+		require.NoErrorf(t, execErr, "apply migration %s", filepath.Base(path))
+	}
+}
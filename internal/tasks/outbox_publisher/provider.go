@@ -0,0 +1,43 @@
+package outboxpublisher
+
+import (
+	"context"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/bionicotaku/lingo-utils/gcpubsub"
+	outboxcfg "github.com/bionicotaku/lingo-utils/outbox/config"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// TopicConfig 为发布目标 Topic 的连接配置，Name 为其在 messaging.topics 中的键。
+type TopicConfig struct {
+	Name   string
+	PubSub gcpubsub.Config
+}
+
+// ProvidePublisher 为发布目标 Topic 构造 Pub/Sub Publisher；未配置 Topic 时返回 nil，任务随之禁用。
+func ProvidePublisher(ctx context.Context, topic TopicConfig, deps gcpubsub.Dependencies) (gcpubsub.Publisher, func(), error) {
+	if topic.PubSub.ProjectID == "" || topic.PubSub.TopicID == "" {
+		return nil, func() {}, nil
+	}
+	component, cleanup, err := gcpubsub.NewComponent(ctx, topic.PubSub, deps)
+	if err != nil {
+		return nil, nil, err
+	}
+	return gcpubsub.ProvidePublisher(component), cleanup, nil
+}
+
+// ProvideTask 根据配置和依赖构造 Outbox 发布任务。
+func ProvideTask(
+	pub gcpubsub.Publisher,
+	outboxRepo *repositories.OutboxRepository,
+	topic TopicConfig,
+	cfg outboxcfg.Config,
+	logger log.Logger,
+) *Task {
+	if pub == nil {
+		log.NewHelper(logger).Warnw("msg", "outbox publisher: skip initialization, topic not configured", "topic", topic.Name)
+		return nil
+	}
+	return NewTask(pub, outboxRepo, logger, cfg.Normalize().Publisher)
+}
@@ -0,0 +1,61 @@
+// Package outboxpublisher 运行 lingo-utils/outbox 发布器，将 feed.outbox_events 中的待发布事件投递到 Pub/Sub。
+package outboxpublisher
+
+import (
+	"context"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/bionicotaku/lingo-utils/gcpubsub"
+	outboxcfg "github.com/bionicotaku/lingo-utils/outbox/config"
+	"github.com/bionicotaku/lingo-utils/outbox/publisher"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// Task 封装 Outbox 发布循环：认领未发布事件、发布、回写 published_at，失败按退避重试。
+type Task struct {
+	runner *publisher.Runner
+}
+
+// NewTask 构造 Outbox 发布任务。
+func NewTask(
+	pub gcpubsub.Publisher,
+	outboxRepo *repositories.OutboxRepository,
+	logger log.Logger,
+	cfg outboxcfg.PublisherConfig,
+) *Task {
+	if pub == nil || outboxRepo == nil {
+		return nil
+	}
+
+	runner, err := publisher.NewRunner(publisher.RunnerParams{
+		Store:     outboxRepo.Shared(),
+		Publisher: pub,
+		Config:    cfg.Normalize(),
+		Logger:    logger,
+	})
+	if err != nil {
+		log.NewHelper(logger).Errorw("msg", "outbox publisher: init runner failed", "error", err)
+		return nil
+	}
+
+	task := &Task{runner: runner}
+	task.runner.WithClock(time.Now)
+	return task
+}
+
+// Run 启动发布循环，直到 ctx 取消。
+func (t *Task) Run(ctx context.Context) error {
+	if t == nil || t.runner == nil {
+		return nil
+	}
+	return t.runner.Run(ctx)
+}
+
+// WithClock 提供测试替换时间。
+func (t *Task) WithClock(fn func() time.Time) {
+	if t == nil || t.runner == nil || fn == nil {
+		return
+	}
+	t.runner.WithClock(fn)
+}
@@ -0,0 +1,267 @@
+package outboxpublisher_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	eventsv1 "github.com/bionicotaku/lingo-services-feed/api/feed/events/v1"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	outboxpublisher "github.com/bionicotaku/lingo-services-feed/internal/tasks/outbox_publisher"
+	"github.com/bionicotaku/lingo-utils/gcpubsub"
+	outboxcfg "github.com/bionicotaku/lingo-utils/outbox/config"
+	"github.com/docker/go-connections/nat"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	emulatorProject      = "feed-test"
+	emulatorTopic        = "feed.events"
+	emulatorSubscription = "feed.events.test"
+)
+
+func TestOutboxPublisherTask_PublishesToEmulator(t *testing.T) {
+	ctx := context.Background()
+	dsn, terminatePG := startPostgres(ctx, t)
+	defer terminatePG()
+	emulator, terminatePubSub := startPubSubEmulator(ctx, t)
+	defer terminatePubSub()
+
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { pool.Close() })
+	applyMigrations(ctx, t, pool)
+
+	createTopicAndSubscription(t, emulator)
+
+	logger := log.NewStdLogger(io.Discard)
+	cfg := outboxcfg.Config{Schema: "feed", Publisher: outboxcfg.PublisherConfig{
+		BatchSize:      10,
+		TickInterval:   100 * time.Millisecond,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		MaxAttempts:    3,
+		PublishTimeout: 5 * time.Second,
+		Workers:        1,
+		LockTTL:        10 * time.Second,
+	}}
+	outboxRepo := repositories.NewOutboxRepository(pool, logger, cfg)
+
+	eventID, logID, videoID := uuid.New(), uuid.New(), uuid.New()
+	payload, err := proto.Marshal(&eventsv1.InteractionEvent{
+		EventId:    eventID.String(),
+		Type:       eventsv1.InteractionType_INTERACTION_TYPE_CLICK,
+		LogId:      logID.String(),
+		VideoId:    videoID.String(),
+		Position:   1,
+		OccurredAt: timestamppb.Now(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, outboxRepo.Enqueue(ctx, nil, repositories.OutboxMessage{
+		EventID:       eventID,
+		AggregateType: "recommendation_log",
+		AggregateID:   logID,
+		EventType:     "feed.click",
+		Payload:       payload,
+		Headers:       map[string]string{"event_type": "feed.click"},
+		AvailableAt:   time.Now().UTC(),
+	}))
+
+	topic := outboxpublisher.TopicConfig{Name: "feed_events", PubSub: gcpubsub.Config{
+		ProjectID:        emulatorProject,
+		TopicID:          emulatorTopic,
+		EmulatorEndpoint: emulator,
+		PublishTimeout:   5 * time.Second,
+	}}
+	pub, cleanup, err := outboxpublisher.ProvidePublisher(ctx, topic, gcpubsub.Dependencies{Logger: logger})
+	require.NoError(t, err)
+	defer cleanup()
+	task := outboxpublisher.ProvideTask(pub, outboxRepo, topic, cfg, logger)
+	require.NotNil(t, task)
+
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() { done <- task.Run(runCtx) }()
+
+	require.Eventually(t, func() bool {
+		var published *time.Time
+		if err := pool.QueryRow(ctx, `SELECT published_at FROM feed.outbox_events WHERE event_id = $1`, eventID).Scan(&published); err != nil {
+			return false
+		}
+		return published != nil
+	}, 30*time.Second, 200*time.Millisecond)
+	cancel()
+	<-done
+
+	messages := pullMessages(t, emulator)
+	require.Len(t, messages, 1)
+	require.Equal(t, "feed.click", messages[0].Attributes["event_type"])
+	data, err := base64.StdEncoding.DecodeString(messages[0].Data)
+	require.NoError(t, err)
+	var event eventsv1.InteractionEvent
+	require.NoError(t, proto.Unmarshal(data, &event))
+	require.Equal(t, eventID.String(), event.GetEventId())
+	require.Equal(t, videoID.String(), event.GetVideoId())
+}
+
+func TestOutboxPublisherTask_DisabledWithoutTopic(t *testing.T) {
+	pub, cleanup, err := outboxpublisher.ProvidePublisher(context.Background(), outboxpublisher.TopicConfig{Name: "feed_events"}, gcpubsub.Dependencies{})
+	require.NoError(t, err)
+	defer cleanup()
+	require.Nil(t, pub)
+	require.Nil(t, outboxpublisher.ProvideTask(pub, nil, outboxpublisher.TopicConfig{}, outboxcfg.Config{}, log.NewStdLogger(io.Discard)))
+}
+
+type pulledMessage struct {
+	Data       string            `json:"data"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+// createTopicAndSubscription 通过 emulator 的 REST 接口建立测试 Topic 与订阅。
+func createTopicAndSubscription(t *testing.T, endpoint string) {
+	t.Helper()
+	topicPath := fmt.Sprintf("projects/%s/topics/%s", emulatorProject, emulatorTopic)
+	emulatorRequest(t, http.MethodPut, endpoint, topicPath, nil, nil)
+	emulatorRequest(t, http.MethodPut, endpoint, fmt.Sprintf("projects/%s/subscriptions/%s", emulatorProject, emulatorSubscription), map[string]any{
+		"topic": topicPath,
+	}, nil)
+}
+
+func pullMessages(t *testing.T, endpoint string) []pulledMessage {
+	t.Helper()
+	var resp struct {
+		ReceivedMessages []struct {
+			Message pulledMessage `json:"message"`
+		} `json:"receivedMessages"`
+	}
+	emulatorRequest(t, http.MethodPost, endpoint, fmt.Sprintf("projects/%s/subscriptions/%s:pull", emulatorProject, emulatorSubscription), map[string]any{
+		"maxMessages": 10,
+	}, &resp)
+	out := make([]pulledMessage, 0, len(resp.ReceivedMessages))
+	for _, received := range resp.ReceivedMessages {
+		out = append(out, received.Message)
+	}
+	return out
+}
+
+func emulatorRequest(t *testing.T, method, endpoint, path string, body any, out any) {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequest(method, fmt.Sprintf("http://%s/v1/%s", endpoint, path), reader)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equalf(t, http.StatusOK, resp.StatusCode, "emulator %s %s: %s", method, path, raw)
+	if out != nil {
+		require.NoError(t, json.Unmarshal(raw, out))
+	}
+}
+
+func startPubSubEmulator(ctx context.Context, t *testing.T) (string, func()) {
+	t.Helper()
+
+	req := testcontainers.ContainerRequest{
+		Image:        "gcr.io/google.com/cloudsdktool/google-cloud-cli:emulators",
+		ExposedPorts: []string{"8085/tcp"},
+		Cmd:          []string{"gcloud", "beta", "emulators", "pubsub", "start", "--host-port=0.0.0.0:8085", "--project=" + emulatorProject},
+		WaitingFor:   wait.ForLog("Server started").WithStartupTimeout(90 * time.Second),
+	}
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		t.Skipf("skip outbox publisher tests: cannot start pubsub emulator: %v", err)
+	}
+
+	host, err := container.Host(ctx)
+	require.NoError(t, err)
+	port, err := container.MappedPort(ctx, "8085")
+	require.NoError(t, err)
+
+	cleanup := func() {
+		termCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = container.Terminate(termCtx)
+	}
+	return fmt.Sprintf("%s:%s", host, port.Port()), cleanup
+}
+
+func startPostgres(ctx context.Context, t *testing.T) (string, func()) {
+	t.Helper()
+
+	req := testcontainers.ContainerRequest{
+		Image:        "postgres:16-alpine",
+		ExposedPorts: []string{"5432/tcp"},
+		Env: map[string]string{
+			"POSTGRES_PASSWORD": "postgres",
+			"POSTGRES_USER":     "postgres",
+			"POSTGRES_DB":       "feed",
+		},
+		WaitingFor: wait.ForSQL("5432/tcp", "postgres", func(host string, port nat.Port) string {
+			return fmt.Sprintf("postgres://postgres:postgres@%s:%s/feed?sslmode=disable", host, port.Port())
+		}).WithStartupTimeout(60 * time.Second),
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		t.Skipf("skip outbox publisher tests: cannot start postgres container: %v", err)
+	}
+
+	host, err := container.Host(ctx)
+	require.NoError(t, err)
+	port, err := container.MappedPort(ctx, "5432")
+	require.NoError(t, err)
+
+	dsn := fmt.Sprintf("postgres://postgres:postgres@%s:%s/feed?sslmode=disable", host, port.Port())
+	cleanup := func() {
+		termCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = container.Terminate(termCtx)
+	}
+	return dsn, cleanup
+}
+
+func applyMigrations(ctx context.Context, t *testing.T, pool *pgxpool.Pool) {
+	t.Helper()
+
+	migrationsDir := filepath.Join("..", "..", "..", "migrations")
+	entries, err := filepath.Glob(filepath.Join(migrationsDir, "*.sql"))
+	require.NoError(t, err)
+	sort.Strings(entries)
+
+	for _, path := range entries {
+		content, readErr := os.ReadFile(path)
+		require.NoError(t, readErr)
+		_, execErr := pool.Exec(ctx, string(content))
+		require.NoErrorf(t, execErr, "apply migration %s", filepath.Base(path))
+	}
+}
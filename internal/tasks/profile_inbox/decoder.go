@@ -0,0 +1,29 @@
+// Package profileinbox 提供维护用户-视频状态投影的 Profile Inbox Runner。
+package profileinbox
+
+import (
+	"fmt"
+
+	inboxv1 "github.com/bionicotaku/lingo-services-feed/api/feed/inbox/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// decoder 实现 inbox.Decoder 接口，将 Pub/Sub payload 解析为 Profile 事件。
+type decoder struct{}
+
+// newDecoder 构造事件解码器。
+func newDecoder() *decoder {
+	return &decoder{}
+}
+
+// Decode 解析事件载荷。
+func (d *decoder) Decode(data []byte) (*inboxv1.ProfileEvent, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("profile inbox: empty payload")
+	}
+	evt := &inboxv1.ProfileEvent{}
+	if err := proto.Unmarshal(data, evt); err != nil {
+		return nil, fmt.Errorf("profile inbox: unmarshal event: %w", err)
+	}
+	return evt, nil
+}
@@ -0,0 +1,222 @@
+package profileinbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	inboxv1 "github.com/bionicotaku/lingo-services-feed/api/feed/inbox/v1"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	"github.com/bionicotaku/lingo-utils/outbox/store"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+type eventHandler struct {
+	states  *repositories.UserVideoStateRepository
+	history *repositories.UserWatchHistoryRepository
+	log     *log.Helper
+	metrics *inboxMetrics
+	clock   func() time.Time
+}
+
+func newEventHandler(repo *repositories.UserVideoStateRepository, history *repositories.UserWatchHistoryRepository, logger log.Logger, metrics *inboxMetrics) *eventHandler {
+	return &eventHandler{
+		states:  repo,
+		history: history,
+		log:     log.NewHelper(logger),
+		metrics: metrics,
+		clock:   time.Now,
+	}
+}
+
+func (h *eventHandler) Handle(ctx context.Context, sess txmanager.Session, evt *inboxv1.ProfileEvent, inboxEvt *store.InboxEvent) error {
+	if evt == nil {
+		return fmt.Errorf("profile inbox: nil event")
+	}
+	eventType := evt.GetEventType().String()
+
+	occurredAt, err := parseRFC3339(evt.GetOccurredAt())
+	if err != nil {
+		h.metrics.recordFailure(ctx, eventType, err)
+		return fmt.Errorf("profile inbox: parse occurred_at: %w", err)
+	}
+	if occurredAt.IsZero() {
+		occurredAt = h.clock().UTC()
+	}
+
+	var (
+		applied   bool
+		handleErr error
+	)
+	switch evt.GetEventType() {
+	case inboxv1.ProfileEventType_PROFILE_EVENT_TYPE_ENGAGEMENT_ADDED:
+		applied, handleErr = h.handleEngagement(ctx, sess, evt, inboxEvt, true, occurredAt)
+	case inboxv1.ProfileEventType_PROFILE_EVENT_TYPE_ENGAGEMENT_REMOVED:
+		applied, handleErr = h.handleEngagement(ctx, sess, evt, inboxEvt, false, occurredAt)
+	case inboxv1.ProfileEventType_PROFILE_EVENT_TYPE_WATCH_PROGRESSED:
+		applied, handleErr = h.handleWatchProgressed(ctx, sess, evt, inboxEvt, occurredAt)
+	default:
+		h.log.WithContext(ctx).Debugw("msg", "profile inbox: skip unsupported event", "event_type", eventType, "event_id", evt.GetEventId())
+		return nil
+	}
+
+	if handleErr != nil {
+		h.metrics.recordFailure(ctx, eventType, handleErr)
+		return handleErr
+	}
+	if !applied {
+		h.log.WithContext(ctx).Debugw("msg", "profile inbox: skip stale event", "event_type", eventType, "event_id", evt.GetEventId(), "event_version", evt.GetVersion())
+	}
+	h.metrics.recordSuccess(ctx, eventType, applied, occurredAt, h.clock())
+	return nil
+}
+
+func (h *eventHandler) handleEngagement(ctx context.Context, sess txmanager.Session, evt *inboxv1.ProfileEvent, inboxEvt *store.InboxEvent, active bool, occurredAt time.Time) (bool, error) {
+	payload := evt.GetEngagement()
+	if payload == nil {
+		return false, errors.New("profile inbox: engagement payload missing")
+	}
+	var kind repositories.EngagementKind
+	switch payload.GetEngagementType() {
+	case inboxv1.EngagementType_ENGAGEMENT_TYPE_LIKE:
+		kind = repositories.EngagementLike
+	case inboxv1.EngagementType_ENGAGEMENT_TYPE_BOOKMARK:
+		kind = repositories.EngagementBookmark
+	default:
+		h.log.WithContext(ctx).Debugw("msg", "profile inbox: skip unsupported engagement", "engagement_type", payload.GetEngagementType().String(), "event_id", evt.GetEventId())
+		return true, nil
+	}
+
+	userID, videoID, err := resolveAggregate(payload.GetUserId(), payload.GetVideoId(), evt, inboxEvt)
+	if err != nil {
+		return false, err
+	}
+	applied, err := h.states.UpsertEngagement(ctx, sess, repositories.UpsertEngagementInput{
+		UserID:    userID,
+		VideoID:   videoID,
+		Kind:      kind,
+		Active:    active,
+		Version:   eventVersion(evt.GetVersion(), occurredAt),
+		UpdatedAt: occurredAt,
+	})
+	if err != nil {
+		return false, fmt.Errorf("profile inbox: upsert engagement: %w", err)
+	}
+	return applied, nil
+}
+
+func (h *eventHandler) handleWatchProgressed(ctx context.Context, sess txmanager.Session, evt *inboxv1.ProfileEvent, inboxEvt *store.InboxEvent, occurredAt time.Time) (bool, error) {
+	payload := evt.GetWatchProgressed()
+	if payload == nil {
+		return false, errors.New("profile inbox: watch_progressed payload missing")
+	}
+	userID, videoID, err := resolveAggregate(payload.GetUserId(), payload.GetVideoId(), evt, inboxEvt)
+	if err != nil {
+		return false, err
+	}
+	lastWatchedAt, err := parseRFC3339(payload.GetLastWatchedAt())
+	if err != nil {
+		return false, fmt.Errorf("profile inbox: parse last_watched_at: %w", err)
+	}
+	if lastWatchedAt.IsZero() {
+		lastWatchedAt = occurredAt
+	}
+
+	ratio := watchedRatio(payload)
+	applied, err := h.states.UpsertProgress(ctx, sess, repositories.UpsertProgressInput{
+		UserID:         userID,
+		VideoID:        videoID,
+		WatchedRatio:   ratio,
+		PositionMicros: positionMicros(payload),
+		LastWatchedAt:  &lastWatchedAt,
+		Version:        eventVersion(evt.GetVersion(), occurredAt),
+		UpdatedAt:      occurredAt,
+	})
+	if err != nil {
+		return false, fmt.Errorf("profile inbox: upsert watch progress: %w", err)
+	}
+	// 观看历史只保留最高进度，过时的进度事件同样参与合并。
+	if err := h.history.RecordProgress(ctx, sess, repositories.RecordProgressInput{
+		UserID:       userID,
+		VideoID:      videoID,
+		WatchedRatio: ratio,
+		WatchedAt:    lastWatchedAt,
+	}); err != nil {
+		return false, fmt.Errorf("profile inbox: record watch history: %w", err)
+	}
+	return applied, nil
+}
+
+// resolveAggregate 优先使用载荷中的 user_id/video_id，缺失时回退到 "<user_id>:<video_id>" 形式的聚合根 ID。
+func resolveAggregate(userID, videoID string, evt *inboxv1.ProfileEvent, inboxEvt *store.InboxEvent) (string, uuid.UUID, error) {
+	if userID == "" || videoID == "" {
+		aggregateID := evt.GetAggregateId()
+		if aggregateID == "" && inboxEvt != nil && inboxEvt.AggregateID != nil {
+			aggregateID = *inboxEvt.AggregateID
+		}
+		if u, v, ok := strings.Cut(aggregateID, ":"); ok {
+			if userID == "" {
+				userID = u
+			}
+			if videoID == "" {
+				videoID = v
+			}
+		}
+	}
+	if strings.TrimSpace(userID) == "" {
+		return "", uuid.Nil, errors.New("profile inbox: user_id missing")
+	}
+	parsed, err := uuid.Parse(videoID)
+	if err != nil {
+		return "", uuid.Nil, fmt.Errorf("profile inbox: parse video_id: %w", err)
+	}
+	return strings.TrimSpace(userID), parsed, nil
+}
+
+// watchedRatio 返回 [0, 1] 内的观看进度；发布端未给出比例时按播放位置与总时长推算。
+func watchedRatio(payload *inboxv1.ProfileEvent_WatchProgressed) float64 {
+	ratio := payload.GetProgressRatio()
+	if ratio <= 0 && payload.GetDurationMicros() > 0 {
+		ratio = float64(payload.GetPositionMicros()) / float64(payload.GetDurationMicros())
+	}
+	if math.IsNaN(ratio) || ratio < 0 {
+		return 0
+	}
+	return math.Min(ratio, 1)
+}
+
+// positionMicros 返回续播位置；发布端只给出进度比例时按时长推算，二者都缺失时为空。
+func positionMicros(payload *inboxv1.ProfileEvent_WatchProgressed) *int64 {
+	position := payload.GetPositionMicros()
+	if position <= 0 && payload.GetProgressRatio() > 0 && payload.GetDurationMicros() > 0 {
+		position = int64(payload.GetProgressRatio() * float64(payload.GetDurationMicros()))
+	}
+	if position <= 0 {
+		return nil
+	}
+	return &position
+}
+
+// eventVersion 返回事件版本；发布端未携带版本时以 occurred_at 的微秒时间戳代替，保证较新的事件覆盖较旧的事件。
+func eventVersion(version int64, occurredAt time.Time) int64 {
+	if version > 0 {
+		return version
+	}
+	return occurredAt.UnixMicro()
+}
+
+func parseRFC3339(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	ts, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ts.UTC(), nil
+}
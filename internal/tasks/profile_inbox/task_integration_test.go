@@ -0,0 +1,237 @@
+package profileinbox_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	inboxv1 "github.com/bionicotaku/lingo-services-feed/api/feed/inbox/v1"
+	"github.com/bionicotaku/lingo-services-feed/internal/models/po"
+	"github.com/bionicotaku/lingo-services-feed/internal/repositories"
+	profileinbox "github.com/bionicotaku/lingo-services-feed/internal/tasks/profile_inbox"
+	"github.com/bionicotaku/lingo-utils/gcpubsub"
+	outboxcfg "github.com/bionicotaku/lingo-utils/outbox/config"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/docker/go-connections/nat"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestProfileInboxTask_MaintainsUserVideoState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dsn, terminate := startPostgres(ctx, t)
+	defer terminate()
+
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { pool.Close() })
+
+	applyMigrations(ctx, t, pool)
+
+	logger := log.NewStdLogger(io.Discard)
+	inboxRepo := repositories.NewInboxRepository(pool, logger, outboxcfg.Config{Schema: "feed"})
+	states := repositories.NewUserVideoStateRepository(pool, logger)
+	history := repositories.NewUserWatchHistoryRepository(pool, logger)
+	manager, err := txmanager.NewManager(pool, txmanager.Config{}, txmanager.Dependencies{Logger: logger})
+	require.NoError(t, err)
+
+	userID, videoID := "user-1", uuid.New()
+	occurredAt := time.Now().UTC().Truncate(time.Millisecond)
+
+	stub := &stubSubscriber{messages: []*gcpubsub.Message{
+		buildMessage(t, engagementEvent(inboxv1.ProfileEventType_PROFILE_EVENT_TYPE_ENGAGEMENT_ADDED, inboxv1.EngagementType_ENGAGEMENT_TYPE_LIKE, userID, videoID, 1, occurredAt)),
+		buildMessage(t, engagementEvent(inboxv1.ProfileEventType_PROFILE_EVENT_TYPE_ENGAGEMENT_ADDED, inboxv1.EngagementType_ENGAGEMENT_TYPE_BOOKMARK, userID, videoID, 1, occurredAt)),
+		buildMessage(t, progressEvent(userID, videoID, 2, occurredAt, &inboxv1.ProfileEvent_WatchProgressed{
+			PositionMicros: 30_000_000,
+			DurationMicros: 120_000_000,
+		})),
+	}}
+
+	cfg := outboxcfg.InboxConfig{SourceService: "profile", MaxConcurrency: 1}
+	task := profileinbox.NewTask(stub, inboxRepo, states, history, manager, logger, cfg)
+	require.NotNil(t, task)
+	require.NoError(t, task.Run(ctx))
+
+	state := loadState(ctx, t, states, userID, videoID)
+	require.True(t, state.Liked)
+	require.True(t, state.Bookmarked)
+	require.InDelta(t, 0.25, state.WatchedRatio, 1e-9)
+	require.NotNil(t, state.LastPositionMicros)
+	require.Equal(t, int64(30_000_000), *state.LastPositionMicros)
+	require.NotNil(t, state.LastWatchedAt)
+	require.WithinDuration(t, occurredAt, *state.LastWatchedAt, time.Millisecond)
+
+	// 乱序到达的旧版本事件被忽略；取消点赞不影响收藏与进度。
+	stub.messages = []*gcpubsub.Message{
+		buildMessage(t, progressEvent(userID, videoID, 1, occurredAt.Add(-time.Minute), &inboxv1.ProfileEvent_WatchProgressed{ProgressRatio: 0.9})),
+		buildMessage(t, engagementEvent(inboxv1.ProfileEventType_PROFILE_EVENT_TYPE_ENGAGEMENT_REMOVED, inboxv1.EngagementType_ENGAGEMENT_TYPE_LIKE, userID, videoID, 2, occurredAt.Add(time.Minute))),
+		buildMessage(t, progressEvent(userID, videoID, 3, occurredAt.Add(time.Minute), &inboxv1.ProfileEvent_WatchProgressed{ProgressRatio: 1.4})),
+	}
+	require.NoError(t, task.Run(ctx))
+
+	state = loadState(ctx, t, states, userID, videoID)
+	require.False(t, state.Liked)
+	require.Equal(t, int64(2), state.LikedVersion)
+	require.True(t, state.Bookmarked)
+	require.Equal(t, 1.0, state.WatchedRatio)
+	require.Equal(t, int64(3), state.ProgressVersion)
+
+	// 重看后最近进度回到开头，观看历史仍保留最高进度。
+	stub.messages = []*gcpubsub.Message{
+		buildMessage(t, progressEvent(userID, videoID, 4, occurredAt.Add(time.Hour), &inboxv1.ProfileEvent_WatchProgressed{ProgressRatio: 0.1})),
+	}
+	require.NoError(t, task.Run(ctx))
+
+	state = loadState(ctx, t, states, userID, videoID)
+	require.InDelta(t, 0.1, state.WatchedRatio, 1e-9)
+	watched, err := history.ListByVideoIDs(ctx, nil, userID, []uuid.UUID{videoID})
+	require.NoError(t, err)
+	require.Len(t, watched, 1)
+	require.Equal(t, 1.0, watched[0].MaxWatchedRatio)
+	require.WithinDuration(t, occurredAt.Add(-time.Minute), watched[0].FirstWatchedAt, time.Millisecond)
+	require.WithinDuration(t, occurredAt.Add(time.Hour), watched[0].LastWatchedAt, time.Millisecond)
+}
+
+func TestProfileInboxTask_DisabledWithoutSubscription(t *testing.T) {
+	sub, cleanup, err := profileinbox.ProvideSubscriber(context.Background(), profileinbox.SubscriptionConfig{Name: "profile_events"}, gcpubsub.Dependencies{})
+	require.NoError(t, err)
+	defer cleanup()
+	require.Nil(t, sub)
+	require.Nil(t, profileinbox.ProvideTask(sub, nil, nil, nil, nil, profileinbox.SubscriptionConfig{}, log.NewStdLogger(io.Discard)))
+}
+
+func loadState(ctx context.Context, t *testing.T, states *repositories.UserVideoStateRepository, userID string, videoID uuid.UUID) *po.UserVideoState {
+	t.Helper()
+	records, err := states.ListByVideoIDs(ctx, nil, userID, []uuid.UUID{videoID})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	return records[0]
+}
+
+func engagementEvent(eventType inboxv1.ProfileEventType, kind inboxv1.EngagementType, userID string, videoID uuid.UUID, version int64, occurredAt time.Time) *inboxv1.ProfileEvent {
+	return &inboxv1.ProfileEvent{
+		EventId:       uuid.NewString(),
+		EventType:     eventType,
+		AggregateId:   userID + ":" + videoID.String(),
+		AggregateType: "user_video_engagement",
+		Version:       version,
+		OccurredAt:    occurredAt.Format(time.RFC3339Nano),
+		Payload: &inboxv1.ProfileEvent_Engagement{Engagement: &inboxv1.ProfileEvent_EngagementChanged{
+			UserId:         userID,
+			VideoId:        videoID.String(),
+			EngagementType: kind,
+		}},
+	}
+}
+
+func progressEvent(userID string, videoID uuid.UUID, version int64, occurredAt time.Time, payload *inboxv1.ProfileEvent_WatchProgressed) *inboxv1.ProfileEvent {
+	payload.UserId = userID
+	payload.VideoId = videoID.String()
+	return &inboxv1.ProfileEvent{
+		EventId:       uuid.NewString(),
+		EventType:     inboxv1.ProfileEventType_PROFILE_EVENT_TYPE_WATCH_PROGRESSED,
+		AggregateId:   userID + ":" + videoID.String(),
+		AggregateType: "user_video_progress",
+		Version:       version,
+		OccurredAt:    occurredAt.Format(time.RFC3339Nano),
+		Payload:       &inboxv1.ProfileEvent_WatchProgressed_{WatchProgressed: payload},
+	}
+}
+
+// stubSubscriber delivers queued messages synchronously.
+type stubSubscriber struct {
+	messages []*gcpubsub.Message
+}
+
+func (s *stubSubscriber) Receive(ctx context.Context, handler func(context.Context, *gcpubsub.Message) error) error {
+	for _, msg := range s.messages {
+		if err := handler(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *stubSubscriber) Stop() {}
+
+func buildMessage(t *testing.T, evt *inboxv1.ProfileEvent) *gcpubsub.Message {
+	data, err := proto.Marshal(evt)
+	require.NoError(t, err)
+	return &gcpubsub.Message{
+		ID:   uuid.NewString(),
+		Data: data,
+		Attributes: map[string]string{
+			"event_id":       evt.GetEventId(),
+			"event_type":     evt.GetEventType().String(),
+			"aggregate_id":   evt.GetAggregateId(),
+			"aggregate_type": evt.GetAggregateType(),
+		},
+	}
+}
+
+func startPostgres(ctx context.Context, t *testing.T) (string, func()) {
+	t.Helper()
+
+	req := testcontainers.ContainerRequest{
+		Image:        "postgres:16-alpine",
+		ExposedPorts: []string{"5432/tcp"},
+		Env: map[string]string{
+			"POSTGRES_PASSWORD": "postgres",
+			"POSTGRES_USER":     "postgres",
+			"POSTGRES_DB":       "feed",
+		},
+		WaitingFor: wait.ForSQL("5432/tcp", "postgres", func(host string, port nat.Port) string {
+			return fmt.Sprintf("postgres://postgres:postgres@%s:%s/feed?sslmode=disable", host, port.Port())
+		}).WithStartupTimeout(60 * time.Second),
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		t.Skipf("skip profile inbox tests: cannot start postgres container: %v", err)
+	}
+
+	host, err := container.Host(ctx)
+	require.NoError(t, err)
+
+	port, err := container.MappedPort(ctx, "5432")
+	require.NoError(t, err)
+
+	dsn := fmt.Sprintf("postgres://postgres:postgres@%s:%s/feed?sslmode=disable", host, port.Port())
+	cleanup := func() {
+		termCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = container.Terminate(termCtx)
+	}
+	return dsn, cleanup
+}
+
+func applyMigrations(ctx context.Context, t *testing.T, pool *pgxpool.Pool) {
+	t.Helper()
+
+	migrationsDir := filepath.Join("..", "..", "..", "migrations")
+	entries, err := filepath.Glob(filepath.Join(migrationsDir, "*.sql"))
+	require.NoError(t, err)
+	sort.Strings(entries)
+
+	for _, path := range entries {
+		content, readErr := os.ReadFile(path)
+		require.NoError(t, readErr)
+		_, execErr := pool.Exec(ctx, string(content))
+		require.NoErrorf(t, execErr, "apply migration %s", filepath.Base(path))
+	}
+}
@@ -0,0 +1,34 @@
+-- ============================================
+-- Feed 幂等快照（x-md-idempotency-key）
+-- ============================================
+
+-- ============================================
+-- 1) 幂等快照表：feed.idempotency_snapshots
+-- ============================================
+create table if not exists feed.idempotency_snapshots (
+  user_id               text not null,                          -- 请求用户
+  idempotency_key       text not null,                          -- 客户端提供的幂等键
+  request_limit         integer not null,                       -- 首次请求的 limit，用于校验重放参数
+  recommendation_source text not null,                          -- 首次请求的推荐来源
+  items                 jsonb not null default '[]'::jsonb,     -- 有序推荐条目（video_id/reason/score/meta）
+  next_cursor           text,                                   -- 首次响应的下一页游标
+  created_at            timestamptz not null default now(),     -- 快照写入时间
+  expires_at            timestamptz not null,                   -- 快照过期时间（TTL）
+  primary key (user_id, idempotency_key)
+);
+
+comment on table feed.idempotency_snapshots is 'GetFeed 幂等快照：相同 (user_id, idempotency_key) 的重试返回同一页结果';
+comment on column feed.idempotency_snapshots.items is '首次推荐返回的有序条目（JSON 数组），重放时基于最新投影重新补水';
+
+create index if not exists feed_idempotency_snapshots_expires_idx
+  on feed.idempotency_snapshots (expires_at);
+comment on index feed.feed_idempotency_snapshots_expires_idx is '按过期时间清理快照';
+
+-- ============================================
+-- 2) 推荐日志标记重放
+-- ============================================
+alter table feed.recommendation_logs
+  add column if not exists idempotency_key text,                 -- 请求携带的幂等键
+  add column if not exists replayed boolean not null default false; -- 是否由幂等快照重放
+
+comment on column feed.recommendation_logs.replayed is '是否为幂等重放请求（true 表示未重新调用推荐）';
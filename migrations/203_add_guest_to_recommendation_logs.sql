@@ -0,0 +1,13 @@
+-- ============================================
+-- 推荐日志访客标记（匿名/访客 Feed）
+-- ============================================
+
+alter table feed.recommendation_logs
+  add column if not exists guest boolean not null default false; -- 是否为访客请求（user_id 保持为空）
+
+comment on column feed.recommendation_logs.guest is '访客请求标记：true 时 user_id 为空，推荐来自非个性化链路';
+
+create index if not exists feed_videos_projection_recent_idx
+  on feed.videos_projection (published_at desc nulls last)
+  where status = 'ready';
+comment on index feed.feed_videos_projection_recent_idx is '访客 Feed 按发布时间倒序选取最新视频';
@@ -0,0 +1,17 @@
+-- ============================================
+-- 用户级令牌桶（共享限流模式）
+-- ============================================
+
+create table if not exists feed.rate_limit_buckets (
+  bucket_key   text primary key,                          -- 方法/场景/用户组合键
+  tokens       double precision not null,                 -- 当前剩余令牌（扣减后）
+  last_allowed boolean not null default true,             -- 最近一次请求是否放行
+  updated_at   timestamptz not null default now()         -- 最近一次补充/扣减时间
+);
+
+comment on table feed.rate_limit_buckets is 'GetFeed 用户级令牌桶，多实例共享限流时使用（backend=postgres）';
+comment on column feed.rate_limit_buckets.tokens is '按 updated_at 之后的耗时惰性补充，上限为 burst';
+
+create index if not exists feed_rate_limit_buckets_updated_idx
+  on feed.rate_limit_buckets (updated_at);
+comment on index feed.feed_rate_limit_buckets_updated_idx is '清理长期空闲的令牌桶';
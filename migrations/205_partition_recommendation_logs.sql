@@ -0,0 +1,159 @@
+-- ============================================
+-- 推荐日志按天分区（generated_at，UTC 自然日）
+-- ============================================
+-- 1) 旧的非分区表改名为 recommendation_logs_legacy；
+-- 2) 新建 range 分区父表与 default 分区，列顺序与旧表保持一致；
+-- 3) 按旧数据的时间跨度补齐日分区并迁移数据，随后删除旧表。
+-- 重复执行时父表已是分区表，第 1/3 步自动跳过。
+
+do $$
+begin
+  if exists (
+    select 1
+    from pg_class c
+    join pg_namespace n on n.oid = c.relnamespace
+    where n.nspname = 'feed' and c.relname = 'recommendation_logs' and c.relkind = 'r'
+  ) then
+    alter table feed.recommendation_logs rename to recommendation_logs_legacy;
+    alter table feed.recommendation_logs_legacy rename constraint recommendation_logs_pkey to recommendation_logs_legacy_pkey;
+  end if;
+end $$;
+
+create table if not exists feed.recommendation_logs (
+  log_id        uuid not null default gen_random_uuid(),     -- 日志主键（分区内唯一，与 generated_at 组成主键）
+  user_id       text,                                        -- 用户标识（脱敏/匿名）
+  request_limit integer not null,                            -- 请求的 limit
+  recommendation_source text not null,                       -- 推荐来源（mock/random/real）
+  recommendation_latency_ms integer,                         -- 推荐调用耗时
+  recommended_items jsonb not null default '[]'::jsonb,      -- 推荐模块原始返回（含 reason/score）
+  missing_video_ids jsonb not null default '[]'::jsonb,      -- 未补水的 video_id 列表
+  error_kind    text,                                        -- 异常类型（recommendation_unavailable 等）
+  generated_at  timestamptz not null default now(),          -- 生成时间（分区键）
+  idempotency_key text,                                      -- 请求携带的幂等键
+  replayed      boolean not null default false,              -- 是否由幂等快照重放
+  guest         boolean not null default false,              -- 是否为访客请求
+  primary key (log_id, generated_at)
+) partition by range (generated_at);
+
+comment on table feed.recommendation_logs is 'Feed 推荐调用日志，按 generated_at 日分区，由 log_retention 任务预建与清理分区';
+comment on column feed.recommendation_logs.recommended_items is '推荐模块原始返回的有序列表（JSON 数组）';
+comment on column feed.recommendation_logs.missing_video_ids is '未补水的视频 ID 列表（JSON 数组）';
+comment on column feed.recommendation_logs.replayed is '是否为幂等重放请求（true 表示未重新调用推荐）';
+comment on column feed.recommendation_logs.guest is '访客请求标记：true 时 user_id 为空，推荐来自非个性化链路';
+
+create table if not exists feed.recommendation_logs_default
+  partition of feed.recommendation_logs default;
+comment on table feed.recommendation_logs_default is '兜底分区：仅在预建分区缺失时接收数据，正常情况下应为空';
+
+create index if not exists feed_recommendation_logs_generated_idx
+  on feed.recommendation_logs (generated_at desc);
+comment on index feed.feed_recommendation_logs_generated_idx is '按时间倒序分页查询推荐日志';
+
+create index if not exists feed_recommendation_logs_user_generated_idx
+  on feed.recommendation_logs (user_id, generated_at desc);
+comment on index feed.feed_recommendation_logs_user_generated_idx is '按用户查询推荐日志';
+
+-- 预建 [p_from, p_from + p_days) 的日分区，返回新建数量；已存在的分区跳过。
+-- 若 default 分区中已有落入该日的数据（预建曾中断），先搬入新分区再挂载，避免挂载失败。
+create or replace function feed.ensure_recommendation_log_partitions(p_from date, p_days integer)
+returns integer
+language plpgsql
+as $$
+declare
+  part_day date;
+  part_name text;
+  lower_bound timestamptz;
+  upper_bound timestamptz;
+  created integer := 0;
+begin
+  if p_days <= 0 then
+    return 0;
+  end if;
+  for i in 0..p_days - 1 loop
+    part_day := p_from + i;
+    part_name := 'recommendation_logs_p' || to_char(part_day, 'YYYYMMDD');
+    if to_regclass(format('feed.%I', part_name)) is not null then
+      continue;
+    end if;
+    lower_bound := part_day::timestamp at time zone 'UTC';
+    upper_bound := (part_day + 1)::timestamp at time zone 'UTC';
+    execute format('create table feed.%I (like feed.recommendation_logs including defaults)', part_name);
+    execute format(
+      'with moved as (delete from feed.recommendation_logs_default where generated_at >= %L and generated_at < %L returning *) insert into feed.%I select * from moved',
+      lower_bound, upper_bound, part_name
+    );
+    execute format(
+      'alter table feed.recommendation_logs attach partition feed.%I for values from (%L) to (%L)',
+      part_name, lower_bound, upper_bound
+    );
+    created := created + 1;
+  end loop;
+  return created;
+end;
+$$;
+
+comment on function feed.ensure_recommendation_log_partitions(date, integer) is '预建推荐日志日分区（UTC），返回新建数量';
+
+-- 删除结束时间不晚于 p_before 的日分区，返回被删除的分区名。
+create or replace function feed.drop_recommendation_log_partitions(p_before date)
+returns setof text
+language plpgsql
+as $$
+declare
+  part record;
+begin
+  for part in
+    select child.relname
+    from pg_inherits inh
+    join pg_class child on child.oid = inh.inhrelid
+    join pg_class parent on parent.oid = inh.inhparent
+    join pg_namespace ns on ns.oid = parent.relnamespace
+    where ns.nspname = 'feed'
+      and parent.relname = 'recommendation_logs'
+      and case
+        when child.relname ~ '^recommendation_logs_p[0-9]{8}$'
+          then to_date(right(child.relname::text, 8), 'YYYYMMDD') < p_before
+        else false
+      end
+    order by child.relname
+  loop
+    execute format('drop table feed.%I', part.relname);
+    return next part.relname::text;
+  end loop;
+end;
+$$;
+
+comment on function feed.drop_recommendation_log_partitions(date) is '按保留期删除过期的推荐日志日分区';
+
+-- 迁移旧数据：按旧表时间跨度补齐分区（并预建未来 7 天），再整体搬迁。
+do $$
+declare
+  first_day date;
+begin
+  if to_regclass('feed.recommendation_logs_legacy') is null then
+    perform feed.ensure_recommendation_log_partitions((now() at time zone 'UTC')::date, 7);
+    return;
+  end if;
+
+  select coalesce(min((generated_at at time zone 'UTC')::date), (now() at time zone 'UTC')::date)
+    into first_day
+  from feed.recommendation_logs_legacy;
+
+  perform feed.ensure_recommendation_log_partitions(
+    first_day,
+    ((now() at time zone 'UTC')::date - first_day) + 7
+  );
+
+  insert into feed.recommendation_logs (
+    log_id, user_id, request_limit, recommendation_source, recommendation_latency_ms,
+    recommended_items, missing_video_ids, error_kind, generated_at,
+    idempotency_key, replayed, guest
+  )
+  select
+    log_id, user_id, request_limit, recommendation_source, recommendation_latency_ms,
+    recommended_items, missing_video_ids, error_kind, generated_at,
+    idempotency_key, replayed, guest
+  from feed.recommendation_logs_legacy;
+
+  drop table feed.recommendation_logs_legacy;
+end $$;
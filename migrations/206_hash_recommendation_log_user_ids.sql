@@ -0,0 +1,19 @@
+-- ============================================
+-- 推荐日志用户标识脱敏（HMAC 哈希 + 密钥版本）
+-- ============================================
+-- 新写入的日志不再保存明文 user_id，仅保存 user_id_hash 与 user_id_key_version；
+-- 历史明文数据无法在库内重算哈希（密钥不入库），随分区保留期自然过期。
+
+alter table feed.recommendation_logs
+  add column if not exists user_id_hash text,         -- HMAC-SHA256(user_id)，十六进制
+  add column if not exists user_id_key_version text;  -- 计算哈希所用的密钥版本
+
+comment on column feed.recommendation_logs.user_id is '历史明文用户标识，仅存在于脱敏上线前的数据';
+comment on column feed.recommendation_logs.user_id_hash is '用户标识的 HMAC-SHA256 哈希，按已知 user_id 反查时需对所有密钥版本计算';
+comment on column feed.recommendation_logs.user_id_key_version is 'user_id_hash 所用密钥版本，密钥轮换后用于定位旧数据';
+
+drop index if exists feed.feed_recommendation_logs_user_generated_idx;
+
+create index if not exists feed_recommendation_logs_user_hash_generated_idx
+  on feed.recommendation_logs (user_id_hash, generated_at desc);
+comment on index feed.feed_recommendation_logs_user_hash_generated_idx is '按用户哈希查询推荐日志';
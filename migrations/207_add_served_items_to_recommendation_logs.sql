@@ -0,0 +1,23 @@
+-- ============================================
+-- 推荐日志记录实际下发结果与请求上下文
+-- ============================================
+-- recommended_items 仅记录推荐模块原始返回；served_items 记录补水、过滤、重排之后
+-- 真正返回给用户的条目及其最终位次，作为曝光（impression）的事实依据。
+
+alter table feed.recommendation_logs
+  add column if not exists served_items jsonb not null default '[]'::jsonb,  -- 实际下发条目（position/projection_version/visibility_status）
+  add column if not exists request_id text,                                  -- 请求 ID（x-md-request-id）
+  add column if not exists trace_id text,                                    -- OpenTelemetry trace ID
+  add column if not exists scene text,                                       -- 推荐场景，空值表示默认场景
+  add column if not exists page integer;                                     -- 游标分页页码，从 1 开始
+
+comment on column feed.recommendation_logs.served_items is '补水/过滤/重排后实际返回的有序列表（JSON 数组），position 从 1 开始';
+comment on column feed.recommendation_logs.request_id is '请求 ID，用于关联网关与客户端日志';
+comment on column feed.recommendation_logs.trace_id is 'OpenTelemetry trace ID（十六进制），无有效 span 时为空';
+comment on column feed.recommendation_logs.scene is '推荐场景';
+comment on column feed.recommendation_logs.page is '游标分页页码，从 1 开始';
+
+create index if not exists feed_recommendation_logs_request_idx
+  on feed.recommendation_logs (request_id)
+  where request_id is not null;
+comment on index feed.feed_recommendation_logs_request_idx is '按请求 ID 定位推荐日志';
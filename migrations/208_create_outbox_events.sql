@@ -0,0 +1,30 @@
+-- ============================================
+-- Outbox 事件表：feed.outbox_events
+-- ============================================
+-- 结构与 lingo-utils/outbox 共享仓储一致：业务事务内写入，
+-- 由 cmd/tasks/outbox_publisher 认领（FOR UPDATE SKIP LOCKED）后发布到 Pub/Sub 并回写 published_at。
+
+create table if not exists feed.outbox_events (
+  event_id          uuid primary key,                       -- 事件 ID（发布幂等键）
+  aggregate_type    text not null,                          -- 聚合根类型，例如 recommendation_log
+  aggregate_id      uuid not null,                          -- 聚合根主键，同时作为 ordering key
+  event_type        text not null,                          -- 事件名称，例如 feed.impression
+  payload           bytea not null,                         -- protobuf 编码的事件载荷
+  headers           jsonb not null default '{}'::jsonb,     -- 发布时附带的消息属性
+  occurred_at       timestamptz not null default now(),     -- 写入时间
+  available_at      timestamptz not null default now(),     -- 最早可发布时间（失败退避后推迟）
+  published_at      timestamptz,                            -- 成功发布时间
+  delivery_attempts integer not null default 0,             -- 已尝试发布次数
+  last_error        text,                                   -- 最近一次发布错误
+  lock_token        text,                                   -- 发布租约标记
+  locked_at         timestamptz                             -- 租约获取时间
+);
+
+comment on table feed.outbox_events is 'Feed Outbox 表：与业务写入同事务记录待发布事件';
+comment on column feed.outbox_events.aggregate_id is '聚合根主键，发布时作为 Pub/Sub ordering key';
+comment on column feed.outbox_events.headers is '发布时附带的消息属性（JSON 对象）';
+
+create index if not exists feed_outbox_events_pending_idx
+  on feed.outbox_events (available_at)
+  where published_at is null;
+comment on index feed.feed_outbox_events_pending_idx is '发布器按 available_at 认领未发布事件';
@@ -0,0 +1,27 @@
+-- ============================================
+-- 用户-视频状态投影：feed.user_video_state
+-- ============================================
+-- 由 cmd/tasks/profile_inbox 消费 profile.engagement.* 与 profile.watch.progressed 维护，
+-- 供 Feed 卡片展示点赞/收藏状态与继续观看进度。点赞、收藏、观看进度来自不同事件流，
+-- 各自携带版本号，仅当事件版本大于当前版本时覆盖对应字段，乱序与重放不会回退状态。
+
+create table if not exists feed.user_video_state (
+  user_id            text not null,                          -- 用户 ID（与 userinfo 一致）
+  video_id           uuid not null,                          -- 视频 ID
+  liked              boolean not null default false,         -- 是否点赞
+  liked_version      bigint not null default 0,              -- 点赞状态对应的事件版本
+  bookmarked         boolean not null default false,         -- 是否收藏
+  bookmarked_version bigint not null default 0,              -- 收藏状态对应的事件版本
+  watched_ratio      double precision not null default 0,    -- 观看进度 [0, 1]
+  last_watched_at    timestamptz,                            -- 最近观看时间
+  progress_version   bigint not null default 0,              -- 观看进度对应的事件版本
+  updated_at         timestamptz not null default now(),     -- 最近一次写入时间
+  primary key (user_id, video_id),
+  constraint user_video_state_watched_ratio_range check (watched_ratio >= 0 and watched_ratio <= 1)
+);
+
+comment on table feed.user_video_state is '用户-视频状态投影：点赞/收藏/观看进度，来源 Profile 事件';
+comment on column feed.user_video_state.liked_version is '点赞状态的事件版本，单调递增，旧版本事件被忽略';
+comment on column feed.user_video_state.bookmarked_version is '收藏状态的事件版本，单调递增，旧版本事件被忽略';
+comment on column feed.user_video_state.progress_version is '观看进度的事件版本，单调递增，旧版本事件被忽略';
+comment on column feed.user_video_state.watched_ratio is '观看进度，0 表示未观看，1 表示看完';
@@ -0,0 +1,20 @@
+-- ============================================
+-- 用户观看历史投影：feed.user_watch_history
+-- ============================================
+-- 由 cmd/tasks/profile_inbox 消费 profile.watch.progressed 维护，供 GetFeed 过滤已看完的视频。
+-- 与 feed.user_video_state 的“最近进度”不同，这里保留历史最高进度：用户重看时进度回到开头，
+-- 但该视频仍应视为已看过。最高进度只增不减，乱序与重放天然幂等，无需版本号。
+
+create table if not exists feed.user_watch_history (
+  user_id           text not null,                          -- 用户 ID（与 userinfo 一致）
+  video_id          uuid not null,                          -- 视频 ID
+  max_watched_ratio double precision not null default 0,    -- 历史最高观看进度 [0, 1]
+  first_watched_at  timestamptz not null,                   -- 首次观看时间
+  last_watched_at   timestamptz not null,                   -- 最近观看时间
+  updated_at        timestamptz not null default now(),     -- 最近一次写入时间
+  primary key (user_id, video_id),
+  constraint user_watch_history_ratio_range check (max_watched_ratio >= 0 and max_watched_ratio <= 1)
+);
+
+comment on table feed.user_watch_history is '用户观看历史投影：每个视频的历史最高进度，来源 Profile 观看事件';
+comment on column feed.user_watch_history.max_watched_ratio is '历史最高观看进度，只增不减，按场景阈值判定看完/看过';
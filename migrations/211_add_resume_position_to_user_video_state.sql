@@ -0,0 +1,15 @@
+-- ============================================
+-- 继续学习：记录续播位置并支持按最近观看时间分页
+-- ============================================
+-- continue_learning 场景按 last_watched_at 倒序列出观看进度在区间内的视频，
+-- 卡片携带 last_position_micros 作为续播位置。
+
+alter table feed.user_video_state
+  add column if not exists last_position_micros bigint;          -- 最近一次上报的播放位置（微秒）
+
+comment on column feed.user_video_state.last_position_micros is '最近一次上报的播放位置（微秒），用于续播；未上报时为空';
+
+create index if not exists user_video_state_continue_idx
+  on feed.user_video_state (user_id, last_watched_at desc, video_id desc)
+  where last_watched_at is not null;
+comment on index feed.user_video_state_continue_idx is '继续学习列表：按用户的最近观看时间键集分页';
@@ -0,0 +1,29 @@
+-- ============================================
+-- 复习计划投影：feed.review_schedule
+-- ============================================
+-- 由 cmd/tasks/learning_inbox 消费 learning.review.scheduled / learning.review.removed 维护，
+-- 供 ReviewDueProvider 按到期时间取出待复习视频。移出复习队列时保留行并置 active=false，
+-- 使版本号持续生效，旧版本的 scheduled 事件不会把已移除的视频重新加入队列。
+
+create table if not exists feed.review_schedule (
+  user_id          text not null,                          -- 用户 ID（与 userinfo 一致）
+  video_id         uuid not null,                          -- 视频 ID
+  due_at           timestamptz not null,                   -- 下一次复习到期时间
+  stability        double precision not null default 0,    -- FSRS 记忆稳定度（天）
+  difficulty       double precision not null default 0,    -- FSRS 难度
+  reps             integer not null default 0,             -- 已完成的复习次数
+  last_reviewed_at timestamptz,                            -- 最近一次复习时间
+  active           boolean not null default true,          -- 是否仍在复习队列中
+  version          bigint not null default 0,              -- 对应的事件版本
+  updated_at       timestamptz not null default now(),     -- 最近一次写入时间
+  primary key (user_id, video_id)
+);
+
+comment on table feed.review_schedule is '复习计划投影：每个视频的下一次复习时间，来源 Learning 事件';
+comment on column feed.review_schedule.active is 'false 表示已移出复习队列，行保留用于版本比较';
+comment on column feed.review_schedule.version is '事件版本，单调递增，旧版本事件被忽略';
+
+create index if not exists review_schedule_due_idx
+  on feed.review_schedule (user_id, due_at, video_id)
+  where active;
+comment on index feed.review_schedule_due_idx is '待复习列表：按用户的到期时间升序读取';
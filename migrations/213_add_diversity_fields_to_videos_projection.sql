@@ -0,0 +1,15 @@
+-- ============================================
+-- 多样性重排：视频投影补充创作者、语言与标签
+-- ============================================
+-- 补水后的重排阶段按创作者限频、按语言限制连续出现、按标签做 MMR 去相似。
+-- 三个字段均可为空，表示上游尚未提供；写入时为空的字段保留原值，
+-- 只认识旧字段的事件不会把已有值清空。
+
+alter table feed.videos_projection
+  add column if not exists creator_id text,                    -- 创作者（上传者）ID
+  add column if not exists primary_language text,              -- 视频主语言（BCP 47）
+  add column if not exists tags text[];                        -- 主题标签
+
+comment on column feed.videos_projection.creator_id is '创作者 ID，供重排阶段按创作者限频；为空表示未知';
+comment on column feed.videos_projection.primary_language is '视频主语言（BCP 47），供重排阶段限制同语言连续出现；为空表示未知';
+comment on column feed.videos_projection.tags is '主题标签，供重排阶段按标签相似度做 MMR；为空表示未知';
@@ -0,0 +1,45 @@
+-- ============================================
+-- 运营干预规则：feed.curation_rules
+-- ============================================
+-- 运营通过 FeedAdminService 维护置顶（pin）、提权（boost）、屏蔽（block）规则，
+-- FeedService 在推荐结果返回后按请求的场景 / 语言区域 / 用户分群匹配规则并执行。
+-- 规则在进程内缓存，写入后经触发器 pg_notify('feed_curation_rules') 通知各实例刷新。
+
+create table if not exists feed.curation_rules (
+  rule_id      uuid primary key default gen_random_uuid(), -- 规则 ID
+  action       text not null,                              -- pin / boost / block
+  video_id     uuid not null,                              -- 目标视频
+  scene        text,                                       -- 适用场景，null 表示全部场景
+  locale       text,                                       -- 适用语言区域（BCP 47 前缀匹配），null 表示全部
+  user_segment text,                                       -- 适用用户分群，null 表示全部
+  position     integer,                                    -- pin：目标位次；boost：最多提到的位次（从 1 开始）
+  priority     integer not null default 0,                 -- 冲突时优先级高的规则先执行
+  starts_at    timestamptz,                                -- 生效时间，null 表示立即生效
+  ends_at      timestamptz,                                -- 失效时间，null 表示长期有效
+  note         text,                                       -- 运营备注
+  created_at   timestamptz not null default now(),
+  updated_at   timestamptz not null default now(),
+  constraint curation_rules_action_check check (action in ('pin', 'boost', 'block')),
+  constraint curation_rules_position_check check (position is null or position >= 1),
+  constraint curation_rules_window_check check (starts_at is null or ends_at is null or starts_at < ends_at)
+);
+
+comment on table feed.curation_rules is '运营干预规则：按场景/语言区域/用户分群置顶、提权或屏蔽视频';
+comment on column feed.curation_rules.user_segment is '用户分群：guest / user，或网关透传的 x-md-user-segment';
+
+create index if not exists curation_rules_ends_at_idx
+  on feed.curation_rules (ends_at);
+comment on index feed.curation_rules_ends_at_idx is '加载未过期规则';
+
+create or replace function feed.notify_curation_rules_changed() returns trigger
+language plpgsql as $$
+begin
+  perform pg_notify('feed_curation_rules', coalesce(new.rule_id, old.rule_id)::text);
+  return null;
+end;
+$$;
+
+drop trigger if exists curation_rules_notify on feed.curation_rules;
+create trigger curation_rules_notify
+  after insert or update or delete on feed.curation_rules
+  for each row execute function feed.notify_curation_rules_changed();
@@ -0,0 +1,10 @@
+-- ============================================
+-- 推荐日志记录 A/B 实验分组
+-- ============================================
+-- 每条日志记录请求命中的实验分组（JSON 数组），离线按 experiment/variant 对比各分组的曝光与点击；
+-- forced 为 true 的条目来自 QA 强制头，分析时应剔除。
+
+alter table feed.recommendation_logs
+  add column if not exists experiments jsonb not null default '[]'::jsonb;  -- 命中的实验分组（experiment/variant/forced）
+
+comment on column feed.recommendation_logs.experiments is '命中的 A/B 实验分组（JSON 数组），forced=true 表示经 x-md-experiment-variants 强制命中';
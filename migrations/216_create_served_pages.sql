@@ -0,0 +1,24 @@
+-- ============================================
+-- 下发页记录：feed.served_pages
+-- ============================================
+-- 推荐日志按采样策略异步写入、队列溢出时丢弃，不能作为交互事件的校验依据。
+-- GetFeed 在请求路径内同步写入每个返回页的最小下发事实（不采样），ReportInteractions 据此校验
+-- 事件归属与下发条目；行在 feed.log_retention.served_page_retention 之后由 log_retention 任务清理。
+
+create table if not exists feed.served_pages (
+  log_id                uuid primary key,                   -- 与响应 log_id、推荐日志 log_id 一致
+  user_id_hash          text,                               -- HMAC(user_id)，访客为空
+  user_id_key_version   text,                               -- HMAC 密钥版本
+  guest                 boolean not null default false,     -- 是否访客请求
+  scene                 text,                               -- 推荐场景
+  recommendation_source text not null,                      -- 推荐来源
+  served_items          jsonb not null default '[]'::jsonb, -- 实际下发条目（video_id/position/projection_version）
+  generated_at          timestamptz not null default now()  -- 下发时间
+);
+
+comment on table feed.served_pages is '下发页记录：不采样、同步写入，作为曝光/点击等交互事件的校验依据';
+comment on column feed.served_pages.served_items is '实际返回的有序列表（JSON 数组），position 从 1 开始';
+
+create index if not exists served_pages_generated_at_idx
+  on feed.served_pages (generated_at);
+comment on index feed.served_pages_generated_at_idx is 'log_retention 按下发时间清理过期记录';
@@ -0,0 +1,11 @@
+-- ============================================
+-- 幂等快照记录请求指纹
+-- ============================================
+-- 快照按 (user_id, idempotency_key) 存储；同一键被用于 scene/cursor/limit 不同的请求时，
+-- 重放会返回与请求不符的页面。request_hash 保存首次请求参数的指纹，重放前比对，不一致时返回冲突。
+-- 历史快照该列为空，仍按 request_limit 校验，随 TTL 自然过期。
+
+alter table feed.idempotency_snapshots
+  add column if not exists request_hash text;  -- sha256(scene, cursor, limit) 十六进制
+
+comment on column feed.idempotency_snapshots.request_hash is '首次请求参数 (scene, cursor, limit) 的 SHA-256 指纹，重放时校验';
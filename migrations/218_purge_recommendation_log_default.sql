@@ -0,0 +1,33 @@
+-- ============================================
+-- 推荐日志 default 分区清理
+-- ============================================
+-- 预建分区缺失期间写入的日志落入 feed.recommendation_logs_default，只有在后续预建覆盖该日时才会被搬出；
+-- 已过保留期的日子不会再预建，这些行会永久留在 default 分区。log_retention 按保留期删除其中的过期行，
+-- 并上报剩余行数：default 分区非空说明预建未跟上写入，需要告警。
+
+-- 删除 default 分区中 generated_at 早于 p_before 的行，返回删除行数。
+create or replace function feed.purge_recommendation_log_default(p_before timestamptz)
+returns bigint
+language plpgsql
+as $$
+declare
+  deleted bigint;
+begin
+  delete from feed.recommendation_logs_default where generated_at < p_before;
+  get diagnostics deleted = row_count;
+  return deleted;
+end;
+$$;
+
+comment on function feed.purge_recommendation_log_default(timestamptz) is '按保留期删除 default 兜底分区中的过期推荐日志';
+
+-- 返回 default 分区当前行数。
+create or replace function feed.count_recommendation_log_default()
+returns bigint
+language sql
+stable
+as $$
+  select count(*) from feed.recommendation_logs_default;
+$$;
+
+comment on function feed.count_recommendation_log_default() is 'default 兜底分区行数，非空表示预建分区未覆盖写入日期';
@@ -0,0 +1,24 @@
+-- ============================================
+-- 幂等快照用户标识脱敏（HMAC 哈希 + 密钥版本）
+-- ============================================
+-- 快照与推荐日志一样不再保存明文 user_id，改以当前密钥版本的 user_id_hash 与幂等键为主键。
+-- 历史明文快照无法在库内重算哈希（密钥不入库）；快照只在幂等 TTL 内有效，迁移时直接清空，
+-- 迁移窗口内的重试退化为一次普通请求。
+
+do $$
+begin
+  if exists (
+    select 1 from information_schema.columns
+    where table_schema = 'feed' and table_name = 'idempotency_snapshots' and column_name = 'user_id'
+  ) then
+    delete from feed.idempotency_snapshots;
+    alter table feed.idempotency_snapshots rename column user_id to user_id_hash;
+  end if;
+end $$;
+
+alter table feed.idempotency_snapshots
+  add column if not exists user_id_key_version text;  -- 计算哈希所用的密钥版本
+
+comment on table feed.idempotency_snapshots is 'GetFeed 幂等快照：相同 (user_id_hash, idempotency_key) 的重试返回同一页结果';
+comment on column feed.idempotency_snapshots.user_id_hash is '用户标识的 HMAC-SHA256 哈希（写入时的当前密钥版本），密钥轮换后旧快照不再命中';
+comment on column feed.idempotency_snapshots.user_id_key_version is 'user_id_hash 所用密钥版本';
@@ -0,0 +1,15 @@
+create table if not exists feed.idempotency_snapshots (
+  user_id text not null,
+  idempotency_key text not null,
+  request_limit integer not null,
+  recommendation_source text not null,
+  items jsonb not null default '[]'::jsonb,
+  next_cursor text,
+  created_at timestamptz not null default now(),
+  expires_at timestamptz not null,
+  primary key (user_id, idempotency_key)
+);
+
+alter table feed.recommendation_logs
+  add column idempotency_key text,
+  add column replayed boolean not null default false;
@@ -0,0 +1,2 @@
+alter table feed.recommendation_logs
+  add column guest boolean not null default false;
@@ -0,0 +1,6 @@
+create table if not exists feed.rate_limit_buckets (
+  bucket_key text primary key,
+  tokens double precision not null,
+  last_allowed boolean not null default true,
+  updated_at timestamptz not null default now()
+);
@@ -0,0 +1,66 @@
+-- 推荐日志分区维护函数；分区化不改变列定义，sqlc 沿用 201–203 中的表结构。
+
+create or replace function feed.ensure_recommendation_log_partitions(p_from date, p_days integer)
+returns integer
+language plpgsql
+as $$
+declare
+  part_day date;
+  part_name text;
+  lower_bound timestamptz;
+  upper_bound timestamptz;
+  created integer := 0;
+begin
+  if p_days <= 0 then
+    return 0;
+  end if;
+  for i in 0..p_days - 1 loop
+    part_day := p_from + i;
+    part_name := 'recommendation_logs_p' || to_char(part_day, 'YYYYMMDD');
+    if to_regclass(format('feed.%I', part_name)) is not null then
+      continue;
+    end if;
+    lower_bound := part_day::timestamp at time zone 'UTC';
+    upper_bound := (part_day + 1)::timestamp at time zone 'UTC';
+    execute format('create table feed.%I (like feed.recommendation_logs including defaults)', part_name);
+    execute format(
+      'with moved as (delete from feed.recommendation_logs_default where generated_at >= %L and generated_at < %L returning *) insert into feed.%I select * from moved',
+      lower_bound, upper_bound, part_name
+    );
+    execute format(
+      'alter table feed.recommendation_logs attach partition feed.%I for values from (%L) to (%L)',
+      part_name, lower_bound, upper_bound
+    );
+    created := created + 1;
+  end loop;
+  return created;
+end;
+$$;
+
+create or replace function feed.drop_recommendation_log_partitions(p_before date)
+returns setof text
+language plpgsql
+as $$
+declare
+  part record;
+begin
+  for part in
+    select child.relname
+    from pg_inherits inh
+    join pg_class child on child.oid = inh.inhrelid
+    join pg_class parent on parent.oid = inh.inhparent
+    join pg_namespace ns on ns.oid = parent.relnamespace
+    where ns.nspname = 'feed'
+      and parent.relname = 'recommendation_logs'
+      and case
+        when child.relname ~ '^recommendation_logs_p[0-9]{8}$'
+          then to_date(right(child.relname::text, 8), 'YYYYMMDD') < p_before
+        else false
+      end
+    order by child.relname
+  loop
+    execute format('drop table feed.%I', part.relname);
+    return next part.relname::text;
+  end loop;
+end;
+$$;
@@ -0,0 +1,3 @@
+alter table feed.recommendation_logs
+  add column user_id_hash text,
+  add column user_id_key_version text;
@@ -0,0 +1,6 @@
+alter table feed.recommendation_logs
+  add column served_items jsonb not null default '[]'::jsonb,
+  add column request_id text,
+  add column trace_id text,
+  add column scene text,
+  add column page integer;
@@ -0,0 +1,15 @@
+create table feed.outbox_events (
+  event_id          uuid primary key,
+  aggregate_type    text not null,
+  aggregate_id      uuid not null,
+  event_type        text not null,
+  payload           bytea not null,
+  headers           jsonb not null default '{}'::jsonb,
+  occurred_at       timestamptz not null default now(),
+  available_at      timestamptz not null default now(),
+  published_at      timestamptz,
+  delivery_attempts integer not null default 0,
+  last_error        text,
+  lock_token        text,
+  locked_at         timestamptz
+);
@@ -0,0 +1,13 @@
+create table feed.user_video_state (
+  user_id            text not null,
+  video_id           uuid not null,
+  liked              boolean not null default false,
+  liked_version      bigint not null default 0,
+  bookmarked         boolean not null default false,
+  bookmarked_version bigint not null default 0,
+  watched_ratio      double precision not null default 0,
+  last_watched_at    timestamptz,
+  progress_version   bigint not null default 0,
+  updated_at         timestamptz not null default now(),
+  primary key (user_id, video_id)
+);
@@ -0,0 +1,9 @@
+create table feed.user_watch_history (
+  user_id           text not null,
+  video_id          uuid not null,
+  max_watched_ratio double precision not null default 0,
+  first_watched_at  timestamptz not null,
+  last_watched_at   timestamptz not null,
+  updated_at        timestamptz not null default now(),
+  primary key (user_id, video_id)
+);
@@ -0,0 +1,2 @@
+alter table feed.user_video_state
+  add column last_position_micros bigint;
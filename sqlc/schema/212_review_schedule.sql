@@ -0,0 +1,13 @@
+create table feed.review_schedule (
+  user_id          text not null,
+  video_id         uuid not null,
+  due_at           timestamptz not null,
+  stability        double precision not null default 0,
+  difficulty       double precision not null default 0,
+  reps             integer not null default 0,
+  last_reviewed_at timestamptz,
+  active           boolean not null default true,
+  version          bigint not null default 0,
+  updated_at       timestamptz not null default now(),
+  primary key (user_id, video_id)
+);
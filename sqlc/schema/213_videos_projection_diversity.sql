@@ -0,0 +1,4 @@
+alter table feed.videos_projection
+  add column creator_id text,
+  add column primary_language text,
+  add column tags text[];
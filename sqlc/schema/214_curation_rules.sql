@@ -0,0 +1,15 @@
+create table feed.curation_rules (
+  rule_id      uuid primary key default gen_random_uuid(),
+  action       text not null,
+  video_id     uuid not null,
+  scene        text,
+  locale       text,
+  user_segment text,
+  position     integer,
+  priority     integer not null default 0,
+  starts_at    timestamptz,
+  ends_at      timestamptz,
+  note         text,
+  created_at   timestamptz not null default now(),
+  updated_at   timestamptz not null default now()
+);
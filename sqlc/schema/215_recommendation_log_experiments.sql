@@ -0,0 +1,2 @@
+alter table feed.recommendation_logs
+  add column experiments jsonb not null default '[]'::jsonb;
@@ -0,0 +1,10 @@
+create table feed.served_pages (
+  log_id                uuid primary key,
+  user_id_hash          text,
+  user_id_key_version   text,
+  guest                 boolean not null default false,
+  scene                 text,
+  recommendation_source text not null,
+  served_items          jsonb not null default '[]'::jsonb,
+  generated_at          timestamptz not null default now()
+);
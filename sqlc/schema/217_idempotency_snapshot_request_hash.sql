@@ -0,0 +1,2 @@
+alter table feed.idempotency_snapshots
+  add column request_hash text;
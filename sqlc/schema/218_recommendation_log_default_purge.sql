@@ -0,0 +1,24 @@
+-- 推荐日志 default 分区清理函数；default 分区不在 sqlc 表结构中，经函数访问。
+
+create or replace function feed.purge_recommendation_log_default(p_before timestamptz)
+returns bigint
+language plpgsql
+as $$
+declare
+  deleted bigint;
+begin
+  delete from feed.recommendation_logs_default where generated_at < p_before;
+  get diagnostics deleted = row_count;
+  return deleted;
+end;
+$$;
+
+
+create or replace function feed.count_recommendation_log_default()
+returns bigint
+language sql
+stable
+as $$
+  select count(*) from feed.recommendation_logs_default;
+$$;
+
@@ -0,0 +1,5 @@
+alter table feed.idempotency_snapshots
+  rename column user_id to user_id_hash;
+
+alter table feed.idempotency_snapshots
+  add column user_id_key_version text;